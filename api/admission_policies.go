@@ -0,0 +1,77 @@
+package api
+
+import "fmt"
+
+// AdmissionPolicies is used to query the admission policy endpoints.
+type AdmissionPolicies struct {
+	client *Client
+}
+
+// AdmissionPolicies returns a new handle on the admission policies.
+func (c *Client) AdmissionPolicies() *AdmissionPolicies {
+	return &AdmissionPolicies{client: c}
+}
+
+// List is used to dump all of the policies.
+func (a *AdmissionPolicies) List(q *QueryOptions) ([]*AdmissionPolicyListStub, *QueryMeta, error) {
+	var resp []*AdmissionPolicyListStub
+	qm, err := a.client.query("/v1/admission/policies", &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return resp, qm, nil
+}
+
+// Upsert is used to create or update a policy
+func (a *AdmissionPolicies) Upsert(policy *AdmissionPolicy, q *WriteOptions) (*WriteMeta, error) {
+	if policy == nil || policy.Name == "" {
+		return nil, fmt.Errorf("missing policy name")
+	}
+	wm, err := a.client.write("/v1/admission/policy/"+policy.Name, policy, nil, q)
+	if err != nil {
+		return nil, err
+	}
+	return wm, nil
+}
+
+// Delete is used to delete a policy
+func (a *AdmissionPolicies) Delete(policyName string, q *WriteOptions) (*WriteMeta, error) {
+	if policyName == "" {
+		return nil, fmt.Errorf("missing policy name")
+	}
+	wm, err := a.client.delete("/v1/admission/policy/"+policyName, nil, q)
+	if err != nil {
+		return nil, err
+	}
+	return wm, nil
+}
+
+// Info is used to query a specific policy
+func (a *AdmissionPolicies) Info(policyName string, q *QueryOptions) (*AdmissionPolicy, *QueryMeta, error) {
+	if policyName == "" {
+		return nil, nil, fmt.Errorf("missing policy name")
+	}
+	var resp AdmissionPolicy
+	wm, err := a.client.query("/v1/admission/policy/"+policyName, &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &resp, wm, nil
+}
+
+type AdmissionPolicy struct {
+	Name             string
+	Description      string
+	EnforcementLevel string
+	Policy           string
+	CreateIndex      uint64
+	ModifyIndex      uint64
+}
+
+type AdmissionPolicyListStub struct {
+	Name             string
+	Description      string
+	EnforcementLevel string
+	CreateIndex      uint64
+	ModifyIndex      uint64
+}
@@ -0,0 +1,53 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdmissionPolicies_ListUpsertDelete(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	c, s := makeClient(t, nil, nil)
+	defer s.Stop()
+	ap := c.AdmissionPolicies()
+
+	// Listing when nothing exists returns empty
+	result, _, err := ap.List(nil)
+	require.NoError(err)
+	require.Empty(result)
+
+	// Register a policy
+	policy := &AdmissionPolicy{
+		Name:             "test",
+		Description:      "test",
+		EnforcementLevel: "advisory",
+		Policy:           `rule "count" { condition = length(job.TaskGroups) > 0 }`,
+	}
+	wm, err := ap.Upsert(policy, nil)
+	require.NoError(err)
+	assertWriteMeta(t, wm)
+
+	// Check the list again
+	result, qm, err := ap.List(nil)
+	require.NoError(err)
+	assertQueryMeta(t, qm)
+	require.Len(result, 1)
+	require.Equal("test", result[0].Name)
+
+	// Query the policy
+	out, qm, err := ap.Info("test", nil)
+	require.NoError(err)
+	assertQueryMeta(t, qm)
+	require.Equal(policy.Policy, out.Policy)
+
+	// Delete the policy
+	wm, err = ap.Delete("test", nil)
+	require.NoError(err)
+	assertWriteMeta(t, wm)
+
+	result, _, err = ap.List(nil)
+	require.NoError(err)
+	require.Empty(result)
+}
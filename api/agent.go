@@ -3,7 +3,9 @@ package api
 import (
 	"encoding/json"
 	"fmt"
+	"io/ioutil"
 	"net/url"
+	"strconv"
 )
 
 // Agent encapsulates an API client which talks to Nomad's
@@ -237,6 +239,133 @@ func (a *Agent) Health() (*AgentHealthResponse, error) {
 	return nil, fmt.Errorf("unable to unmarshal response with status %d: %v", resp.StatusCode, err)
 }
 
+// Monitor returns a channel which will receive streaming logs from the agent.
+// Providing a non-nil stopCh can be used to close the connection and stop log
+// streaming. The log_level, log_json, node_id and server_id query parameters
+// select the logs to stream.
+func (a *Agent) Monitor(stopCh <-chan struct{}, q *QueryOptions) (<-chan *StreamFrame, <-chan error) {
+	errCh := make(chan error, 1)
+	r, err := a.client.newRequest("GET", "/v1/agent/monitor")
+	if err != nil {
+		errCh <- err
+		return nil, errCh
+	}
+
+	r.setQueryOptions(q)
+	_, resp, err := requireOK(a.client.doRequest(r))
+	if err != nil {
+		errCh <- err
+		return nil, errCh
+	}
+
+	frames := make(chan *StreamFrame, 10)
+	go func() {
+		defer resp.Body.Close()
+
+		dec := json.NewDecoder(resp.Body)
+
+		for {
+			select {
+			case <-stopCh:
+				close(frames)
+				return
+			default:
+			}
+
+			// Decode the next frame
+			var frame StreamFrame
+			if err := dec.Decode(&frame); err != nil {
+				close(frames)
+				errCh <- err
+				return
+			}
+
+			// Discard heartbeat frames
+			if frame.IsHeartbeat() {
+				continue
+			}
+
+			select {
+			case frames <- &frame:
+			case <-stopCh:
+				close(frames)
+				return
+			}
+		}
+	}()
+
+	return frames, errCh
+}
+
+// PprofOptions are the options of a profile request.
+type PprofOptions struct {
+	// ServerID is the name or ID of the server to profile, or "leader"
+	ServerID string
+
+	// NodeID is the ID of the client to profile
+	NodeID string
+
+	// Seconds is the duration of CPU profiles and traces
+	Seconds int
+
+	// Debug is the format of looked up profiles, 0 being the binary format
+	Debug int
+
+	// GC runs a garbage collection before taking a heap profile
+	GC bool
+}
+
+// CPUProfile returns a CPU profile of the agent, or of the server or client
+// given by the options.
+func (a *Agent) CPUProfile(opts PprofOptions, q *QueryOptions) ([]byte, error) {
+	return a.pprofRequest("profile", opts, q)
+}
+
+// Trace returns an execution trace of the agent, or of the server or client
+// given by the options.
+func (a *Agent) Trace(opts PprofOptions, q *QueryOptions) ([]byte, error) {
+	return a.pprofRequest("trace", opts, q)
+}
+
+// Lookup returns the named profile, such as "goroutine" or "heap", of the
+// agent, or of the server or client given by the options.
+func (a *Agent) Lookup(profile string, opts PprofOptions, q *QueryOptions) ([]byte, error) {
+	return a.pprofRequest(profile, opts, q)
+}
+
+func (a *Agent) pprofRequest(req string, opts PprofOptions, q *QueryOptions) ([]byte, error) {
+	if q == nil {
+		q = &QueryOptions{}
+	}
+	if q.Params == nil {
+		q.Params = make(map[string]string)
+	}
+
+	if opts.Seconds > 0 {
+		q.Params["seconds"] = strconv.Itoa(opts.Seconds)
+	}
+	if opts.Debug != 0 {
+		q.Params["debug"] = strconv.Itoa(opts.Debug)
+	}
+	if opts.GC {
+		q.Params["gc"] = "true"
+	}
+	if opts.NodeID != "" {
+		q.Params["node_id"] = opts.NodeID
+	}
+	if opts.ServerID != "" {
+		q.Params["server_id"] = opts.ServerID
+	}
+
+	body, err := a.client.rawQuery(fmt.Sprintf("/v1/agent/pprof/%s", req), q)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	return ioutil.ReadAll(body)
+}
+
 // joinResponse is used to decode the response we get while
 // sending a member join request.
 type joinResponse struct {
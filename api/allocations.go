@@ -33,6 +33,7 @@ const (
 	AllocClientStatusComplete = "complete"
 	AllocClientStatusFailed   = "failed"
 	AllocClientStatusLost     = "lost"
+	AllocClientStatusUnknown  = "unknown"
 )
 
 // Allocations is used to query the alloc-related endpoints.
@@ -286,6 +287,108 @@ func (a *Allocations) execFrames(ctx context.Context, alloc *Allocation, task st
 
 }
 
+// PortForward forwards a connection to a TCP port of an allocation, given as
+// a port label or number. Data read from conn is written to the port and data
+// read from the port is written to conn.
+//
+// The call blocks until the port closes the connection, conn is closed or ctx
+// is done.
+func (a *Allocations) PortForward(ctx context.Context, alloc *Allocation, port string,
+	conn io.ReadWriter, q *QueryOptions) error {
+
+	nodeClient, err := a.client.GetNodeClientWithTimeout(alloc.NodeID, ClientConnTimeout, q)
+	if err != nil {
+		return err
+	}
+
+	if q == nil {
+		q = &QueryOptions{}
+	}
+	if q.Params == nil {
+		q.Params = make(map[string]string)
+	}
+	q.Params["port"] = port
+
+	reqPath := fmt.Sprintf("/v1/client/allocation/%s/port-forward", alloc.ID)
+
+	ws, _, err := nodeClient.websocket(reqPath, q)
+	if err != nil {
+		// There was an error talking directly to the client. Non-network
+		// errors are fatal, but network errors can attempt to route via RPC.
+		if _, ok := err.(net.Error); !ok {
+			return err
+		}
+
+		ws, _, err = a.client.websocket(reqPath, q)
+		if err != nil {
+			return err
+		}
+	}
+	defer ws.Close()
+
+	var sendLock sync.Mutex
+	send := func(messageType int, data []byte) error {
+		sendLock.Lock()
+		defer sendLock.Unlock()
+		return ws.WriteMessage(messageType, data)
+	}
+
+	streamCtx, cancelFn := context.WithCancel(ctx)
+	defer cancelFn()
+
+	// Close the websocket connection if the stream is done
+	go func() {
+		<-streamCtx.Done()
+		send(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+		ws.Close()
+	}()
+
+	// Forward the data read from conn. An empty message signals that conn
+	// will not be written to anymore.
+	go func() {
+		buf := make([]byte, 32*1024)
+		for {
+			n, err := conn.Read(buf)
+			if n != 0 {
+				if err := send(websocket.BinaryMessage, buf[:n]); err != nil {
+					cancelFn()
+					return
+				}
+			}
+
+			if err == io.EOF {
+				send(websocket.BinaryMessage, []byte{})
+				return
+			} else if err != nil {
+				cancelFn()
+				return
+			}
+		}
+	}()
+
+	for {
+		_, data, err := ws.ReadMessage()
+		if websocket.IsCloseError(err, websocket.CloseNormalClosure) {
+			return nil
+		} else if wsErr, ok := err.(*websocket.CloseError); ok && wsErr.Text != "" {
+			// drop websocket code, not relevant to user
+			return errors.New(wsErr.Text)
+		} else if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			} else if streamCtx.Err() != nil {
+				// conn was closed
+				return nil
+			}
+			return err
+		}
+
+		if _, err := conn.Write(data); err != nil {
+			return err
+		}
+	}
+}
+
 func (a *Allocations) Stats(alloc *Allocation, q *QueryOptions) (*AllocResourceUsage, error) {
 	var resp AllocResourceUsage
 	path := fmt.Sprintf("/v1/client/allocation/%s/stats", alloc.ID)
@@ -7,6 +7,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"net"
 	"net/http"
 	"net/url"
@@ -51,6 +52,18 @@ type QueryOptions struct {
 	// If set, used as prefix for resource list searches
 	Prefix string
 
+	// Filter is an expression the entries returned by list requests must
+	// match, such as `Status == "running"`.
+	Filter string
+
+	// PerPage is the maximum number of entries returned by list requests.
+	// If zero, all entries are returned.
+	PerPage int32
+
+	// NextToken is the token of the first entry returned by a list request,
+	// as returned in the QueryMeta of the previous page.
+	NextToken string
+
 	// Set HTTP parameters on the query.
 	Params map[string]string
 
@@ -86,6 +99,10 @@ type QueryMeta struct {
 
 	// How long did the request take
 	RequestTime time.Duration
+
+	// NextToken is the token of the first entry of the next page of a list
+	// request. It is empty if there are no more entries.
+	NextToken string
 }
 
 // WriteMeta is used to return meta data about a write
@@ -514,6 +531,15 @@ func (r *request) setQueryOptions(q *QueryOptions) {
 	if q.Prefix != "" {
 		r.params.Set("prefix", q.Prefix)
 	}
+	if q.Filter != "" {
+		r.params.Set("filter", q.Filter)
+	}
+	if q.PerPage != 0 {
+		r.params.Set("per_page", strconv.FormatInt(int64(q.PerPage), 10))
+	}
+	if q.NextToken != "" {
+		r.params.Set("next_token", q.NextToken)
+	}
 	for k, v := range q.Params {
 		r.params.Set(k, v)
 	}
@@ -693,6 +719,25 @@ func (c *Client) rawQuery(endpoint string, q *QueryOptions) (io.ReadCloser, erro
 	return resp.Body, nil
 }
 
+// rawPut makes a PUT request to the specified endpoint with the body and
+// discards the response body.
+func (c *Client) rawPut(endpoint string, body io.Reader, q *QueryOptions) error {
+	r, err := c.newRequest("PUT", endpoint)
+	if err != nil {
+		return err
+	}
+	r.setQueryOptions(q)
+	r.body = body
+	_, resp, err := requireOK(c.doRequest(r))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	_, err = io.Copy(ioutil.Discard, resp.Body)
+	return err
+}
+
 // websocket makes a websocket request to the specific endpoint
 func (c *Client) websocket(endpoint string, q *QueryOptions) (*websocket.Conn, *http.Response, error) {
 
@@ -877,6 +922,9 @@ func parseQueryMeta(resp *http.Response, q *QueryMeta) error {
 	default:
 		q.KnownLeader = false
 	}
+
+	// Parse the X-Nomad-NextToken
+	q.NextToken = header.Get("X-Nomad-NextToken")
 	return nil
 }
 
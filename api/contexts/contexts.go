@@ -12,4 +12,11 @@ const (
 	Namespaces  Context = "namespaces"
 	Quotas      Context = "quotas"
 	All         Context = "all"
+
+	// Contexts only used by fuzzy search
+	Groups   Context = "groups"
+	Tasks    Context = "tasks"
+	Services Context = "services"
+	Images   Context = "images"
+	NodeMeta Context = "node_meta"
 )
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"net/url"
 	"sort"
 	"time"
 )
@@ -30,6 +31,43 @@ func (e *Evaluations) PrefixList(prefix string) ([]*Evaluation, *QueryMeta, erro
 	return e.List(&QueryOptions{Prefix: prefix})
 }
 
+// EvalDeleteOptions are the filters used to select the evaluations to delete.
+// At least one of JobID, Status or OlderThan must be set.
+type EvalDeleteOptions struct {
+	JobID     string
+	Status    string
+	OlderThan time.Duration
+}
+
+// EvalDeleteResponse is the response to a batch evaluation deletion.
+type EvalDeleteResponse struct {
+	Deleted int
+	Skipped int
+	WriteMeta
+}
+
+// Delete is used to delete the evaluations matching the given filters.
+// Evaluations that are being processed by a scheduler are skipped.
+func (e *Evaluations) Delete(opts *EvalDeleteOptions, q *WriteOptions) (*EvalDeleteResponse, *WriteMeta, error) {
+	v := url.Values{}
+	if opts.JobID != "" {
+		v.Set("job", opts.JobID)
+	}
+	if opts.Status != "" {
+		v.Set("status", opts.Status)
+	}
+	if opts.OlderThan != 0 {
+		v.Set("older_than", opts.OlderThan.String())
+	}
+
+	var resp EvalDeleteResponse
+	wm, err := e.client.delete("/v1/evaluations?"+v.Encode(), &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &resp, wm, nil
+}
+
 // Info is used to query a single evaluation by its ID.
 func (e *Evaluations) Info(evalID string, q *QueryOptions) (*Evaluation, *QueryMeta, error) {
 	var resp Evaluation
@@ -160,6 +160,86 @@ func (a *AllocFS) Cat(alloc *Allocation, path string, q *QueryOptions) (io.ReadC
 	return r, nil
 }
 
+// Download returns a tar archive of the file or directory at the path of the
+// allocation. The entries of the archive are named relative to the parent of
+// the path. The caller must close the returned reader.
+func (a *AllocFS) Download(alloc *Allocation, path string, q *QueryOptions) (io.ReadCloser, error) {
+	nodeClient, err := a.client.GetNodeClientWithTimeout(alloc.NodeID, ClientConnTimeout, q)
+	if err != nil {
+		return nil, err
+	}
+
+	if q == nil {
+		q = &QueryOptions{}
+	}
+	if q.Params == nil {
+		q.Params = make(map[string]string)
+	}
+
+	q.Params["path"] = path
+	reqPath := fmt.Sprintf("/v1/client/fs/download/%s", alloc.ID)
+	r, err := nodeClient.rawQuery(reqPath, q)
+	if err != nil {
+		// There was a networking error when talking directly to the client.
+		if _, ok := err.(net.Error); !ok {
+			return nil, err
+		}
+
+		// Try via the server
+		r, err = a.client.rawQuery(reqPath, q)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return r, nil
+}
+
+// Upload extracts the tar archive read from r into the directory at the path
+// of the allocation.
+func (a *AllocFS) Upload(alloc *Allocation, path string, r io.Reader, q *QueryOptions) error {
+	nodeClient, err := a.client.GetNodeClientWithTimeout(alloc.NodeID, ClientConnTimeout, q)
+	if err != nil {
+		return err
+	}
+
+	if q == nil {
+		q = &QueryOptions{}
+	}
+	if q.Params == nil {
+		q.Params = make(map[string]string)
+	}
+
+	q.Params["path"] = path
+	reqPath := fmt.Sprintf("/v1/client/fs/upload/%s", alloc.ID)
+	body := &countingReader{r: r}
+	err = nodeClient.rawPut(reqPath, body, q)
+	if err != nil {
+		// There was a networking error when talking directly to the client
+		// before any of the archive was sent.
+		if _, ok := err.(net.Error); !ok || body.n > 0 {
+			return err
+		}
+
+		// Try via the server
+		return a.client.rawPut(reqPath, r, q)
+	}
+
+	return nil
+}
+
+// countingReader counts the bytes read from the underlying reader.
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
+
 // Stream streams the content of a file blocking on EOF.
 // The parameters are:
 // * path: path to file to stream.
@@ -23,6 +23,13 @@ const (
 	// PeriodicSpecCron is used for a cron spec.
 	PeriodicSpecCron = "cron"
 
+	// PeriodicCatchupNone, PeriodicCatchupLatest and PeriodicCatchupAll are
+	// the policies for running launches of a periodic job that were missed
+	// while no leader was running the periodic dispatcher.
+	PeriodicCatchupNone   = "none"
+	PeriodicCatchupLatest = "latest"
+	PeriodicCatchupAll    = "all"
+
 	// DefaultNamespace is the default namespace.
 	DefaultNamespace = "default"
 
@@ -87,6 +94,10 @@ type RegisterOptions struct {
 	EnforceIndex   bool
 	ModifyIndex    uint64
 	PolicyOverride bool
+
+	// Submission is the source of the job, stored alongside the registered
+	// job version.
+	Submission *JobSubmission
 }
 
 // Register is used to register a new job. It returns the ID
@@ -116,6 +127,7 @@ func (j *Jobs) RegisterOpts(job *Job, opts *RegisterOptions, q *WriteOptions) (*
 		if opts.PolicyOverride {
 			req.PolicyOverride = true
 		}
+		req.Submission = opts.Submission
 	}
 
 	var resp JobRegisterResponse
@@ -164,6 +176,61 @@ func (j *Jobs) Versions(jobID string, diffs bool, q *QueryOptions) ([]*Job, []*J
 	return resp.Versions, resp.Diffs, qm, nil
 }
 
+// Diff is used to diff two versions of a job. If from or to is nil, the
+// current version of the job is used in its place. If contextual is set, the
+// diff includes the unchanged fields.
+func (j *Jobs) Diff(jobID string, from, to *uint64, contextual bool, q *QueryOptions) (*JobDiff, *QueryMeta, error) {
+	v := url.Values{}
+	if from != nil {
+		v.Set("from", strconv.FormatUint(*from, 10))
+	}
+	if to != nil {
+		v.Set("to", strconv.FormatUint(*to, 10))
+	}
+	if contextual {
+		v.Set("contextual", "true")
+	}
+
+	var resp JobDiff
+	qm, err := j.client.query("/v1/job/"+jobID+"/diff?"+v.Encode(), &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &resp, qm, nil
+}
+
+// DiffJob is used to diff a version of a job against a job that isn't
+// registered, such as one parsed from a local job file, without planning it.
+// If from is nil, the current version of the job is used.
+func (j *Jobs) DiffJob(job *Job, from *uint64, contextual bool, q *QueryOptions) (*JobDiff, *QueryMeta, error) {
+	if job == nil || job.ID == nil {
+		return nil, nil, fmt.Errorf("must pass non-nil job with an ID")
+	}
+
+	req := &JobDiffRequest{
+		Job:         job,
+		FromVersion: from,
+		Contextual:  contextual,
+	}
+
+	var resp JobDiff
+	qm, err := j.client.putQuery("/v1/job/"+*job.ID+"/diff", req, &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &resp, qm, nil
+}
+
+// Submission is used to retrieve the source of a job version.
+func (j *Jobs) Submission(jobID string, version int, q *QueryOptions) (*JobSubmission, *QueryMeta, error) {
+	var resp JobSubmission
+	qm, err := j.client.query(fmt.Sprintf("/v1/job/%s/submission?version=%d", jobID, version), &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &resp, qm, nil
+}
+
 // Allocations is used to return the allocs for a given job ID.
 func (j *Jobs) Allocations(jobID string, allAllocs bool, q *QueryOptions) ([]*AllocationListStub, *QueryMeta, error) {
 	var resp []*AllocationListStub
@@ -275,6 +342,17 @@ func (j *Jobs) PeriodicForce(jobID string, q *WriteOptions) (string, *WriteMeta,
 	return resp.EvalID, wm, nil
 }
 
+// PeriodicStatus returns the upcoming launch times of a periodic job, limited
+// to next, along with its launch history.
+func (j *Jobs) PeriodicStatus(jobID string, next int, q *QueryOptions) (*PeriodicStatus, *QueryMeta, error) {
+	var resp PeriodicStatus
+	qm, err := j.client.query(fmt.Sprintf("/v1/job/%s/periodic/status?next=%d", jobID, next), &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &resp, qm, nil
+}
+
 // PlanOptions is used to pass through job planning parameters
 type PlanOptions struct {
 	Diff           bool
@@ -317,13 +395,23 @@ func (j *Jobs) Summary(jobID string, q *QueryOptions) (*JobSummary, *QueryMeta,
 	return &resp, qm, nil
 }
 
+// Dispatch is used to dispatch the parameterized job.
 func (j *Jobs) Dispatch(jobID string, meta map[string]string,
 	payload []byte, q *WriteOptions) (*JobDispatchResponse, *WriteMeta, error) {
+	return j.DispatchWithIdempotencyToken(jobID, meta, payload, "", q)
+}
+
+// DispatchWithIdempotencyToken is used to dispatch the parameterized job. If
+// idempotencyToken is set and was already used to dispatch the job, the
+// previously dispatched job is returned.
+func (j *Jobs) DispatchWithIdempotencyToken(jobID string, meta map[string]string,
+	payload []byte, idempotencyToken string, q *WriteOptions) (*JobDispatchResponse, *WriteMeta, error) {
 	var resp JobDispatchResponse
 	req := &JobDispatchRequest{
-		JobID:   jobID,
-		Meta:    meta,
-		Payload: payload,
+		JobID:            jobID,
+		Meta:             meta,
+		Payload:          payload,
+		IdempotencyToken: idempotencyToken,
 	}
 	wm, err := j.client.write("/v1/job/"+jobID+"/dispatch", req, &resp, q)
 	if err != nil {
@@ -352,6 +440,120 @@ func (j *Jobs) Revert(jobID string, version uint64, enforcePriorVersion *uint64,
 	return &resp, wm, nil
 }
 
+// RevertToTag is used to revert the given job to the version tagged with the
+// passed tag name. If enforceVersion is set, the job is only reverted if the
+// current version is at the passed version.
+func (j *Jobs) RevertToTag(jobID, tagName string, enforcePriorVersion *uint64,
+	q *WriteOptions, vaultToken string) (*JobRegisterResponse, *WriteMeta, error) {
+
+	var resp JobRegisterResponse
+	req := &JobRevertRequest{
+		JobID:               jobID,
+		TagName:             tagName,
+		EnforcePriorVersion: enforcePriorVersion,
+		VaultToken:          vaultToken,
+	}
+	wm, err := j.client.write("/v1/job/"+jobID+"/revert", req, &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &resp, wm, nil
+}
+
+// TagVersion is used to tag a job version with a name and description. If
+// version is nil, the current version of the job is tagged.
+func (j *Jobs) TagVersion(jobID, name, description string, version *uint64,
+	q *WriteOptions) (*JobTagResponse, *WriteMeta, error) {
+
+	var resp JobTagResponse
+	req := &JobTagRequest{
+		JobID:       jobID,
+		Name:        name,
+		Description: description,
+		Version:     version,
+	}
+	wm, err := j.client.write("/v1/job/"+jobID+"/tag", req, &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &resp, wm, nil
+}
+
+// UntagVersion is used to remove the tag with the given name from the job
+// version it is attached to.
+func (j *Jobs) UntagVersion(jobID, name string, q *WriteOptions) (*WriteMeta, error) {
+	wm, err := j.client.delete("/v1/job/"+jobID+"/tag?name="+url.QueryEscape(name), nil, q)
+	if err != nil {
+		return nil, err
+	}
+	return wm, nil
+}
+
+// ScaleOptions is used to pass through the parameters of a scaling request
+type ScaleOptions struct {
+	// Message, Error and Meta describe the scaling event recorded for the
+	// task group
+	Message string
+	Error   bool
+	Meta    map[string]interface{}
+
+	EnforceIndex   bool
+	ModifyIndex    uint64
+	PolicyOverride bool
+}
+
+// Scale is used to set the count of a task group of a job, recording a
+// scaling event with the given message. If count is nil, only the scaling
+// event is recorded.
+func (j *Jobs) Scale(jobID, group string, count *int, message string,
+	q *WriteOptions) (*JobRegisterResponse, *WriteMeta, error) {
+	return j.ScaleOpts(jobID, group, count, &ScaleOptions{Message: message}, q)
+}
+
+// ScaleOpts is used to set the count of a task group of a job with the given
+// options. If count is nil, only the scaling event is recorded.
+func (j *Jobs) ScaleOpts(jobID, group string, count *int, opts *ScaleOptions,
+	q *WriteOptions) (*JobRegisterResponse, *WriteMeta, error) {
+
+	req := &ScalingRequest{
+		JobID: jobID,
+		Target: map[string]string{
+			"Group": group,
+		},
+	}
+	if count != nil {
+		req.Count = int64ToPtr(int64(*count))
+	}
+	if opts != nil {
+		req.Message = opts.Message
+		req.Error = opts.Error
+		req.Meta = opts.Meta
+		if opts.EnforceIndex {
+			req.EnforceIndex = true
+			req.JobModifyIndex = opts.ModifyIndex
+		}
+		req.PolicyOverride = opts.PolicyOverride
+	}
+
+	var resp JobRegisterResponse
+	wm, err := j.client.write("/v1/job/"+jobID+"/scale", req, &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &resp, wm, nil
+}
+
+// ScaleStatus is used to retrieve the counts of the task groups of a job
+// along with their recent scaling events.
+func (j *Jobs) ScaleStatus(jobID string, q *QueryOptions) (*JobScaleStatus, *QueryMeta, error) {
+	var resp JobScaleStatus
+	qm, err := j.client.query("/v1/job/"+jobID+"/scale", &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &resp, qm, nil
+}
+
 // Stable is used to mark a job version's stability.
 func (j *Jobs) Stable(jobID string, version uint64, stable bool,
 	q *WriteOptions) (*JobStabilityResponse, *WriteMeta, error) {
@@ -374,6 +576,38 @@ type periodicForceResponse struct {
 	EvalID string
 }
 
+// PeriodicStatus is the upcoming launches and launch history of a periodic
+// job.
+type PeriodicStatus struct {
+	// Next is the set of upcoming launch times of the job.
+	Next []time.Time
+
+	// LastLaunch is the last recorded launch of the job. If the job has never
+	// launched, it holds the time the job was registered.
+	LastLaunch *PeriodicLaunch
+
+	// Launches is the set of child jobs launched by the periodic job, newest
+	// first.
+	Launches []*PeriodicChildLaunch
+}
+
+// PeriodicLaunch is the last launch of a periodic job.
+type PeriodicLaunch struct {
+	ID          string
+	Namespace   string
+	Launch      time.Time
+	CreateIndex uint64
+	ModifyIndex uint64
+}
+
+// PeriodicChildLaunch describes a single child job launched by a periodic job.
+type PeriodicChildLaunch struct {
+	JobID      string
+	Launch     time.Time
+	Status     string
+	SubmitTime int64
+}
+
 // UpdateStrategy defines a task groups update strategy.
 type UpdateStrategy struct {
 	Stagger          *time.Duration `mapstructure:"stagger"`
@@ -580,9 +814,12 @@ func (u *UpdateStrategy) Empty() bool {
 type PeriodicConfig struct {
 	Enabled         *bool
 	Spec            *string
+	Specs           []string
 	SpecType        *string
-	ProhibitOverlap *bool   `mapstructure:"prohibit_overlap"`
-	TimeZone        *string `mapstructure:"time_zone"`
+	ProhibitOverlap *bool          `mapstructure:"prohibit_overlap"`
+	TimeZone        *string        `mapstructure:"time_zone"`
+	Catchup         *string        `mapstructure:"catchup"`
+	CatchupWindow   *time.Duration `mapstructure:"catchup_window"`
 }
 
 func (p *PeriodicConfig) Canonicalize() {
@@ -601,6 +838,12 @@ func (p *PeriodicConfig) Canonicalize() {
 	if p.TimeZone == nil || *p.TimeZone == "" {
 		p.TimeZone = stringToPtr("UTC")
 	}
+	if p.Catchup == nil || *p.Catchup == "" {
+		p.Catchup = stringToPtr(PeriodicCatchupLatest)
+	}
+	if p.CatchupWindow == nil {
+		p.CatchupWindow = timeToPtr(0)
+	}
 }
 
 // Next returns the closest time instant matching the spec that is after the
@@ -608,13 +851,33 @@ func (p *PeriodicConfig) Canonicalize() {
 // returned. The `time.Location` of the returned value matches that of the
 // passed time.
 func (p *PeriodicConfig) Next(fromTime time.Time) (time.Time, error) {
-	if *p.SpecType == PeriodicSpecCron {
-		if e, err := cronexpr.Parse(*p.Spec); err == nil {
-			return cronParseNext(e, fromTime, *p.Spec)
+	if *p.SpecType != PeriodicSpecCron {
+		return time.Time{}, nil
+	}
+
+	specs := p.Specs
+	if p.Spec != nil && *p.Spec != "" {
+		specs = append([]string{*p.Spec}, specs...)
+	}
+
+	// Launch at the earliest time matched by any of the specs
+	var next time.Time
+	for _, spec := range specs {
+		e, err := cronexpr.Parse(spec)
+		if err != nil {
+			continue
+		}
+
+		t, err := cronParseNext(e, fromTime, spec)
+		if err != nil {
+			return time.Time{}, err
+		}
+		if !t.IsZero() && (next.IsZero() || t.Before(next)) {
+			next = t
 		}
 	}
 
-	return time.Time{}, nil
+	return next, nil
 }
 
 // cronParseNext is a helper that parses the next time for the given expression
@@ -641,9 +904,45 @@ func (p *PeriodicConfig) GetLocation() (*time.Location, error) {
 
 // ParameterizedJobConfig is used to configure the parameterized job.
 type ParameterizedJobConfig struct {
-	Payload      string
-	MetaRequired []string `mapstructure:"meta_required"`
-	MetaOptional []string `mapstructure:"meta_optional"`
+	Payload       string
+	MetaRequired  []string `mapstructure:"meta_required"`
+	MetaOptional  []string `mapstructure:"meta_optional"`
+	MaxConcurrent int      `mapstructure:"max_concurrent"`
+}
+
+// Multiregion is used to deploy a job to a set of regions.
+type Multiregion struct {
+	Strategy *MultiregionStrategy
+	Regions  []*MultiregionRegion `mapstructure:"region"`
+}
+
+func (m *Multiregion) Canonicalize() {
+	if m.Strategy == nil {
+		m.Strategy = &MultiregionStrategy{}
+	}
+	if m.Strategy.MaxParallel == nil {
+		m.Strategy.MaxParallel = intToPtr(1)
+	}
+	for _, region := range m.Regions {
+		if region.Count == nil {
+			region.Count = intToPtr(0)
+		}
+	}
+}
+
+// MultiregionStrategy controls how a multiregion job is rolled out across
+// its regions.
+type MultiregionStrategy struct {
+	MaxParallel *int `mapstructure:"max_parallel"`
+}
+
+// MultiregionRegion specifies the count and datacenters of a multiregion job
+// within one region.
+type MultiregionRegion struct {
+	Name        string
+	Count       *int
+	Datacenters []string
+	Meta        map[string]string
 }
 
 // Job is used to serialize a job.
@@ -666,6 +965,7 @@ type Job struct {
 	Periodic          *PeriodicConfig
 	ParameterizedJob  *ParameterizedJobConfig
 	Dispatched        bool
+	Multiregion       *Multiregion
 	Payload           []byte
 	Reschedule        *ReschedulePolicy
 	Migrate           *MigrateStrategy
@@ -675,12 +975,20 @@ type Job struct {
 	StatusDescription *string
 	Stable            *bool
 	Version           *uint64
+	VersionTag        *JobVersionTag
 	SubmitTime        *int64
 	CreateIndex       *uint64
 	ModifyIndex       *uint64
 	JobModifyIndex    *uint64
 }
 
+// JobVersionTag is a named tag attached to a job version.
+type JobVersionTag struct {
+	Name        string
+	Description string
+	TaggedTime  int64
+}
+
 // IsPeriodic returns whether a job is periodic.
 func (j *Job) IsPeriodic() bool {
 	return j.Periodic != nil
@@ -749,6 +1057,9 @@ func (j *Job) Canonicalize() {
 	if j.Periodic != nil {
 		j.Periodic.Canonicalize()
 	}
+	if j.Multiregion != nil {
+		j.Multiregion.Canonicalize()
+	}
 	if j.Update != nil {
 		j.Update.Canonicalize()
 	} else if *j.Type == JobTypeService {
@@ -794,6 +1105,7 @@ type JobChildrenSummary struct {
 	Pending int64
 	Running int64
 	Dead    int64
+	Queued  int64
 }
 
 func (jc *JobChildrenSummary) Sum() int {
@@ -801,7 +1113,7 @@ func (jc *JobChildrenSummary) Sum() int {
 		return 0
 	}
 
-	return int(jc.Pending + jc.Running + jc.Dead)
+	return int(jc.Pending + jc.Running + jc.Dead + jc.Queued)
 }
 
 // TaskGroup summarizes the state of all the allocations of a particular
@@ -813,6 +1125,7 @@ type TaskGroupSummary struct {
 	Running  int
 	Starting int
 	Lost     int
+	Unknown  int
 }
 
 // JobListStub is used to return a subset of information about
@@ -830,6 +1143,7 @@ type JobListStub struct {
 	Status            string
 	StatusDescription string
 	JobSummary        *JobSummary
+	Meta              map[string]string
 	CreateIndex       uint64
 	ModifyIndex       uint64
 	JobModifyIndex    uint64
@@ -966,6 +1280,10 @@ type JobRevertRequest struct {
 	// version before reverting.
 	EnforcePriorVersion *uint64
 
+	// TagName is the name of the tagged version to revert to. If set, it is
+	// used instead of JobVersion.
+	TagName string `json:",omitempty"`
+
 	// VaultToken is the Vault token that proves the submitter of the job revert
 	// has access to any Vault policies specified in the targeted job version. This
 	// field is only used to authorize the revert and is not stored after the Job
@@ -985,15 +1303,39 @@ type JobRegisterRequest struct {
 	JobModifyIndex uint64
 	PolicyOverride bool
 
+	// Submission is the source of the job as it was submitted.
+	Submission *JobSubmission
+
 	WriteRequest
 }
 
+// JobSubmission is the source of a job version as it was submitted.
+type JobSubmission struct {
+	// Source is the text of the submitted job file.
+	Source string
+
+	// Format is the format of the source, one of hcl1, hcl2 or json.
+	Format string
+
+	// VariableFlags are the values of the variables set with -var flags.
+	VariableFlags map[string]string
+
+	// Variables is the content of the variable files.
+	Variables string
+
+	Namespace      string
+	JobID          string
+	Version        uint64
+	JobModifyIndex uint64
+}
+
 // RegisterJobRequest is used to serialize a job registration
 type RegisterJobRequest struct {
 	Job            *Job
-	EnforceIndex   bool   `json:",omitempty"`
-	JobModifyIndex uint64 `json:",omitempty"`
-	PolicyOverride bool   `json:",omitempty"`
+	EnforceIndex   bool           `json:",omitempty"`
+	JobModifyIndex uint64         `json:",omitempty"`
+	PolicyOverride bool           `json:",omitempty"`
+	Submission     *JobSubmission `json:",omitempty"`
 }
 
 // JobRegisterResponse is used to respond to a job registration
@@ -1017,6 +1359,19 @@ type JobDeregisterResponse struct {
 	QueryMeta
 }
 
+// JobDiffRequest is used to diff a version of a job against a job that isn't
+// registered.
+type JobDiffRequest struct {
+	Job *Job
+
+	// FromVersion is the version to diff from. If unset, the current version
+	// is used.
+	FromVersion *uint64
+
+	// Contextual toggles including unchanged fields in the diff
+	Contextual bool
+}
+
 type JobPlanRequest struct {
 	Job            *Job
 	Diff           bool
@@ -1093,9 +1448,10 @@ type DesiredUpdates struct {
 }
 
 type JobDispatchRequest struct {
-	JobID   string
-	Payload []byte
-	Meta    map[string]string
+	JobID            string
+	Payload          []byte
+	Meta             map[string]string
+	IdempotencyToken string
 }
 
 type JobDispatchResponse struct {
@@ -1103,6 +1459,8 @@ type JobDispatchResponse struct {
 	EvalID          string
 	EvalCreateIndex uint64
 	JobCreateIndex  uint64
+	Queued          bool
+	Existing        bool
 	WriteMeta
 }
 
@@ -1130,6 +1488,23 @@ type JobStabilityResponse struct {
 	WriteMeta
 }
 
+// JobTagRequest is used to tag a job version.
+type JobTagRequest struct {
+	JobID       string
+	Name        string
+	Description string
+
+	// Version is the version to tag. If unset, the current version is tagged.
+	Version *uint64
+
+	WriteRequest
+}
+
+// JobTagResponse is the response when tagging a job version.
+type JobTagResponse struct {
+	WriteMeta
+}
+
 // JobEvaluateRequest is used when we just need to re-evaluate a target job
 type JobEvaluateRequest struct {
 	JobID       string
@@ -592,6 +592,8 @@ func TestJobs_Canonicalize(t *testing.T) {
 					SpecType:        stringToPtr(PeriodicSpecCron),
 					ProhibitOverlap: boolToPtr(false),
 					TimeZone:        stringToPtr("UTC"),
+					Catchup:         stringToPtr(PeriodicCatchupLatest),
+					CatchupWindow:   timeToPtr(0),
 				},
 			},
 		},
@@ -935,6 +937,179 @@ func TestJobs_Versions(t *testing.T) {
 	}
 }
 
+func TestJobs_Submission(t *testing.T) {
+	t.Parallel()
+	c, s := makeClient(t, nil, nil)
+	defer s.Stop()
+	jobs := c.Jobs()
+
+	// Register the job with its source
+	job := testJob()
+	opts := &RegisterOptions{
+		Submission: &JobSubmission{
+			Source: `job "job1" {}`,
+			Format: "hcl1",
+		},
+	}
+	_, wm, err := jobs.RegisterOpts(job, opts, nil)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	assertWriteMeta(t, wm)
+
+	// Query the source of the job version
+	sub, qm, err := jobs.Submission(*job.ID, 0, nil)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	assertQueryMeta(t, qm)
+	if sub.Source != `job "job1" {}` || sub.Format != "hcl1" || sub.JobID != *job.ID {
+		t.Fatalf("bad: %#v", sub)
+	}
+
+	// Unknown versions are not found
+	_, _, err = jobs.Submission(*job.ID, 1, nil)
+	if err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Fatalf("expected not found error, got: %#v", err)
+	}
+}
+
+func TestJobs_Diff(t *testing.T) {
+	t.Parallel()
+	c, s := makeClient(t, nil, nil)
+	defer s.Stop()
+	jobs := c.Jobs()
+
+	// Register two versions of the job
+	job := testJob()
+	for _, priority := range []int{50, 60} {
+		job.Priority = intToPtr(priority)
+		if _, _, err := jobs.Register(job, nil); err != nil {
+			t.Fatalf("err: %s", err)
+		}
+	}
+
+	// Diff the first version against the current one
+	diff, qm, err := jobs.Diff(*job.ID, uint64ToPtr(0), nil, false, nil)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	assertQueryMeta(t, qm)
+	if diff.Type != "Edited" || len(diff.Fields) != 1 || diff.Fields[0].Name != "Priority" {
+		t.Fatalf("bad: %#v", diff)
+	}
+
+	// Diff the current version against a local job
+	job.Priority = intToPtr(70)
+	diff, _, err = jobs.DiffJob(job, nil, false, nil)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if len(diff.Fields) != 1 || diff.Fields[0].Old != "60" || diff.Fields[0].New != "70" {
+		t.Fatalf("bad: %#v", diff)
+	}
+}
+
+func TestJobs_TagVersion(t *testing.T) {
+	t.Parallel()
+	c, s := makeClient(t, nil, nil)
+	defer s.Stop()
+	jobs := c.Jobs()
+
+	// Register the job
+	job := testJob()
+	_, wm, err := jobs.Register(job, nil)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	assertWriteMeta(t, wm)
+
+	// Tag the current version
+	_, wm, err = jobs.TagVersion(*job.ID, "release", "first release", nil, nil)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	assertWriteMeta(t, wm)
+
+	out, _, err := jobs.Info(*job.ID, nil)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if out.VersionTag == nil || out.VersionTag.Name != "release" || out.VersionTag.Description != "first release" {
+		t.Fatalf("bad: %#v", out.VersionTag)
+	}
+
+	// Remove the tag
+	wm, err = jobs.UntagVersion(*job.ID, "release", nil)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	assertWriteMeta(t, wm)
+
+	out, _, err = jobs.Info(*job.ID, nil)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if out.VersionTag != nil {
+		t.Fatalf("bad: %#v", out.VersionTag)
+	}
+}
+
+func TestJobs_Scale(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	c, s := makeClient(t, nil, nil)
+	defer s.Stop()
+	jobs := c.Jobs()
+
+	// Register the job
+	job := testJob()
+	regResp, wm, err := jobs.Register(job, nil)
+	require.NoError(err)
+	assertWriteMeta(t, wm)
+	group := *job.TaskGroups[0].Name
+
+	// Scaling with a stale modify index fails
+	_, _, err = jobs.ScaleOpts(*job.ID, group, intToPtr(2), &ScaleOptions{
+		EnforceIndex: true,
+		ModifyIndex:  regResp.JobModifyIndex + 1,
+	}, nil)
+	require.Error(err)
+	require.Contains(err.Error(), RegisterEnforceIndexErrPrefix)
+
+	// Scale the task group
+	resp, wm, err := jobs.ScaleOpts(*job.ID, group, intToPtr(2), &ScaleOptions{
+		Message:      "scale out",
+		Meta:         map[string]interface{}{"source": "test"},
+		EnforceIndex: true,
+		ModifyIndex:  regResp.JobModifyIndex,
+	}, nil)
+	require.NoError(err)
+	assertWriteMeta(t, wm)
+	require.NotEmpty(resp.EvalID)
+
+	out, _, err := jobs.Info(*job.ID, nil)
+	require.NoError(err)
+	require.Equal(2, *out.TaskGroups[0].Count)
+
+	// Record an event without changing the count
+	_, _, err = jobs.Scale(*job.ID, group, nil, "no-op", nil)
+	require.NoError(err)
+
+	status, qm, err := jobs.ScaleStatus(*job.ID, nil)
+	require.NoError(err)
+	assertQueryMeta(t, qm)
+	tgStatus := status.TaskGroups[group]
+	require.Equal(2, tgStatus.Desired)
+	require.Len(tgStatus.Events, 2)
+	require.Equal("no-op", tgStatus.Events[0].Message)
+	require.Nil(tgStatus.Events[0].Count)
+	require.Equal("scale out", tgStatus.Events[1].Message)
+	require.EqualValues(2, *tgStatus.Events[1].Count)
+	require.EqualValues(1, tgStatus.Events[1].PreviousCount)
+	require.Equal("test", tgStatus.Events[1].Meta["source"])
+}
+
 func TestJobs_PrefixList(t *testing.T) {
 	t.Parallel()
 	c, s := makeClient(t, nil, nil)
@@ -1224,6 +1399,37 @@ func TestJobs_PeriodicForce(t *testing.T) {
 	t.Fatalf("evaluation %q missing", evalID)
 }
 
+func TestJobs_Dispatch(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	c, s := makeClient(t, nil, nil)
+	defer s.Stop()
+	jobs := c.Jobs()
+
+	// Register a parameterized job
+	job := testJob()
+	job.ParameterizedJob = &ParameterizedJobConfig{}
+	_, _, err := jobs.Register(job, nil)
+	require.NoError(err)
+
+	// Dispatch the job without an idempotency token
+	resp, wm, err := jobs.Dispatch(*job.ID, nil, nil, nil)
+	require.NoError(err)
+	assertWriteMeta(t, wm)
+	require.NotEmpty(resp.DispatchedJobID)
+	require.False(resp.Existing)
+
+	// Dispatching twice with the same token returns the same job
+	resp1, _, err := jobs.DispatchWithIdempotencyToken(*job.ID, nil, nil, "foo", nil)
+	require.NoError(err)
+	require.NotEqual(resp.DispatchedJobID, resp1.DispatchedJobID)
+
+	resp2, _, err := jobs.DispatchWithIdempotencyToken(*job.ID, nil, nil, "foo", nil)
+	require.NoError(err)
+	require.Equal(resp1.DispatchedJobID, resp2.DispatchedJobID)
+	require.True(resp2.Existing)
+}
+
 func TestJobs_Plan(t *testing.T) {
 	t.Parallel()
 	c, s := makeClient(t, nil, nil)
@@ -1,6 +1,9 @@
 package api
 
-import "strconv"
+import (
+	"strconv"
+	"time"
+)
 
 // Operator can be used to perform low-level operator tasks for Nomad.
 type Operator struct {
@@ -31,10 +34,13 @@ type RaftServer struct {
 
 	// Voter is true if this server has a vote in the cluster. This might
 	// be false if the server is staging and still coming online, or if
-	// it's a non-voting server, which will be added in a future release of
-	// Nomad.
+	// it's a non-voting read replica.
 	Voter bool
 
+	// ReadReplica is true if this server is configured as a non-voting
+	// server that is never promoted to a voter.
+	ReadReplica bool
+
 	// RaftProtocol is the version of the Raft protocol spoken by this server.
 	RaftProtocol string
 }
@@ -176,3 +182,85 @@ func (op *Operator) SchedulerCASConfiguration(conf *SchedulerConfiguration, q *W
 
 	return &out, wm, nil
 }
+
+const (
+	// EvalBrokerActionDrop removes evaluations from the eval broker and marks
+	// them as cancelled.
+	EvalBrokerActionDrop = "drop"
+
+	// EvalBrokerActionReenqueue moves waiting, failed or blocked evaluations
+	// back into the ready queue of their scheduler.
+	EvalBrokerActionReenqueue = "reenqueue"
+)
+
+// BrokerEval summarizes an evaluation held by the eval broker.
+type BrokerEval struct {
+	ID          string
+	Namespace   string
+	JobID       string
+	Type        string
+	TriggeredBy string
+	Priority    int
+	Queue       string
+	Dequeues    int
+	WaitUntil   time.Time
+	CreateTime  int64
+}
+
+// EvalBrokerInspection lists the evaluations held by the eval broker and the
+// blocked evals tracker on the leader.
+type EvalBrokerInspection struct {
+	Ready    []*BrokerEval
+	Unacked  []*BrokerEval
+	Waiting  []*BrokerEval
+	Pending  []*BrokerEval
+	Captured []*BrokerEval
+	Escaped  []*BrokerEval
+}
+
+// EvalBrokerUpdateRequest is used to drop or re-enqueue evaluations.
+type EvalBrokerUpdateRequest struct {
+	Action  string
+	EvalIDs []string
+}
+
+// EvalBrokerUpdateResponse lists the evaluations that were updated.
+type EvalBrokerUpdateResponse struct {
+	Updated []string
+	WriteMeta
+}
+
+// EvalBrokerInspect is used to list the evaluations held by the eval broker.
+func (op *Operator) EvalBrokerInspect(q *QueryOptions) (*EvalBrokerInspection, *QueryMeta, error) {
+	var resp EvalBrokerInspection
+	qm, err := op.c.query("/v1/operator/evals/broker", &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &resp, qm, nil
+}
+
+// EvalBrokerDrop is used to remove evaluations from the eval broker and mark
+// them as cancelled.
+func (op *Operator) EvalBrokerDrop(evalIDs []string, q *WriteOptions) (*EvalBrokerUpdateResponse, *WriteMeta, error) {
+	return op.evalBrokerUpdate(EvalBrokerActionDrop, evalIDs, q)
+}
+
+// EvalBrokerReenqueue is used to move waiting, failed or blocked evaluations
+// back into the ready queue of their scheduler.
+func (op *Operator) EvalBrokerReenqueue(evalIDs []string, q *WriteOptions) (*EvalBrokerUpdateResponse, *WriteMeta, error) {
+	return op.evalBrokerUpdate(EvalBrokerActionReenqueue, evalIDs, q)
+}
+
+func (op *Operator) evalBrokerUpdate(action string, evalIDs []string, q *WriteOptions) (*EvalBrokerUpdateResponse, *WriteMeta, error) {
+	req := &EvalBrokerUpdateRequest{
+		Action:  action,
+		EvalIDs: evalIDs,
+	}
+	var out EvalBrokerUpdateResponse
+	wm, err := op.c.write("/v1/operator/evals/broker", req, &out, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &out, wm, nil
+}
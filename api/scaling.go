@@ -0,0 +1,126 @@
+package api
+
+import "fmt"
+
+// Scaling is used to query the scaling policies of the task groups.
+type Scaling struct {
+	client *Client
+}
+
+// Scaling returns a handle on the scaling endpoints.
+func (c *Client) Scaling() *Scaling {
+	return &Scaling{client: c}
+}
+
+// ListPolicies is used to list the scaling policies of the namespace.
+func (s *Scaling) ListPolicies(q *QueryOptions) ([]*ScalingPolicyListStub, *QueryMeta, error) {
+	var resp []*ScalingPolicyListStub
+	qm, err := s.client.query("/v1/scaling/policies", &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return resp, qm, nil
+}
+
+// GetPolicy is used to query a specific scaling policy.
+func (s *Scaling) GetPolicy(id string, q *QueryOptions) (*ScalingPolicy, *QueryMeta, error) {
+	if id == "" {
+		return nil, nil, fmt.Errorf("missing policy ID")
+	}
+	var resp ScalingPolicy
+	qm, err := s.client.query("/v1/scaling/policy/"+id, &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &resp, qm, nil
+}
+
+// ScalingRequest is the payload of a request to scale a task group of a job
+type ScalingRequest struct {
+	JobID string
+
+	// Target identifies the scaled task group by its "Group" key
+	Target map[string]string
+
+	// Count is the new count of the task group. If unset, only the scaling
+	// event is recorded.
+	Count *int64
+
+	Message string
+	Error   bool
+	Meta    map[string]interface{}
+
+	EnforceIndex   bool
+	JobModifyIndex uint64
+	PolicyOverride bool
+
+	WriteRequest
+}
+
+// ScalingEvent describes a scaling action or a failed attempt to scale a task
+// group
+type ScalingEvent struct {
+	Time          int64
+	Count         *int64
+	PreviousCount int64
+	Message       string
+	Error         bool
+	Meta          map[string]interface{}
+	EvalID        *string
+	CreateIndex   uint64
+}
+
+// JobScaleStatus is the scaling status of the task groups of a job
+type JobScaleStatus struct {
+	JobID          string
+	Namespace      string
+	JobCreateIndex uint64
+	JobModifyIndex uint64
+	JobStopped     bool
+	TaskGroups     map[string]TaskGroupScaleStatus
+}
+
+// TaskGroupScaleStatus is the scaling status of a task group, along with its
+// most recent scaling events
+type TaskGroupScaleStatus struct {
+	Desired   int
+	Placed    int
+	Running   int
+	Healthy   int
+	Unhealthy int
+	Events    []ScalingEvent
+}
+
+// ScalingPolicy is the scaling policy of a task group. Nomad keeps the count
+// of the task group within Min and Max; Policy is passed through to external
+// autoscalers.
+type ScalingPolicy struct {
+	ID          string
+	Namespace   string
+	Target      map[string]string
+	Min         *int64
+	Max         *int64
+	Policy      map[string]interface{}
+	Enabled     *bool
+	CreateIndex uint64
+	ModifyIndex uint64
+}
+
+// Canonicalize sets the defaults of the scaling policy of the task group.
+func (p *ScalingPolicy) Canonicalize(tg *TaskGroup) {
+	if p.Enabled == nil {
+		p.Enabled = boolToPtr(true)
+	}
+	if p.Min == nil {
+		p.Min = int64ToPtr(int64(*tg.Count))
+	}
+}
+
+// ScalingPolicyListStub is a summary of a scaling policy.
+type ScalingPolicyListStub struct {
+	ID          string
+	Enabled     bool
+	Target      map[string]string
+	CreateIndex uint64
+	ModifyIndex uint64
+}
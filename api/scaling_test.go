@@ -0,0 +1,55 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestScaling_ListPolicies(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	c, s := makeClient(t, nil, nil)
+	defer s.Stop()
+	scaling := c.Scaling()
+
+	// No policies before a job is registered
+	policies, qm, err := scaling.ListPolicies(nil)
+	require.NoError(err)
+	assertQueryMeta(t, qm)
+	require.Empty(policies)
+
+	// Register a job with a scaling policy
+	job := testJob()
+	job.TaskGroups[0].Scaling = &ScalingPolicy{
+		Max: int64ToPtr(10),
+		Policy: map[string]interface{}{
+			"target": "cpu",
+		},
+	}
+	_, wm, err := c.Jobs().Register(job, nil)
+	require.NoError(err)
+	assertWriteMeta(t, wm)
+
+	policies, qm, err = scaling.ListPolicies(nil)
+	require.NoError(err)
+	assertQueryMeta(t, qm)
+	require.Len(policies, 1)
+	require.True(policies[0].Enabled)
+	require.Equal(*job.ID, policies[0].Target["Job"])
+	require.Equal(*job.TaskGroups[0].Name, policies[0].Target["Group"])
+
+	// Query the policy
+	policy, qm, err := scaling.GetPolicy(policies[0].ID, nil)
+	require.NoError(err)
+	assertQueryMeta(t, qm)
+	require.Equal(policies[0].ID, policy.ID)
+	require.EqualValues(1, *policy.Min)
+	require.EqualValues(10, *policy.Max)
+	require.Equal("cpu", policy.Policy["target"])
+
+	// Query a missing policy
+	_, _, err = scaling.GetPolicy("missing", nil)
+	require.Error(err)
+	require.Contains(err.Error(), "not found")
+}
@@ -26,6 +26,21 @@ func (s *Search) PrefixSearch(prefix string, context contexts.Context, q *QueryO
 	return &resp, qm, nil
 }
 
+// FuzzySearch returns the fields of objects in the given context that contain
+// the text, such as job, task or node names. The all context searches every
+// context the token may read.
+func (s *Search) FuzzySearch(text string, context contexts.Context, q *QueryOptions) (*FuzzySearchResponse, *QueryMeta, error) {
+	var resp FuzzySearchResponse
+	req := &FuzzySearchRequest{Text: text, Context: context}
+
+	qm, err := s.client.putQuery("/v1/search/fuzzy", req, &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &resp, qm, nil
+}
+
 type SearchRequest struct {
 	Prefix  string
 	Context contexts.Context
@@ -37,3 +52,23 @@ type SearchResponse struct {
 	Truncations map[contexts.Context]bool
 	QueryMeta
 }
+
+type FuzzySearchRequest struct {
+	Text    string
+	Context contexts.Context
+	QueryOptions
+}
+
+// FuzzyMatch is a field that matched a fuzzy search. ID is the value of the
+// field and Scope identifies the object it belongs to, such as the namespace,
+// job ID and group name of a task.
+type FuzzyMatch struct {
+	ID    string
+	Scope []string
+}
+
+type FuzzySearchResponse struct {
+	Matches     map[contexts.Context][]FuzzyMatch
+	Truncations map[contexts.Context]bool
+	QueryMeta
+}
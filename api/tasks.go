@@ -380,21 +380,23 @@ type VolumeMount struct {
 
 // TaskGroup is the unit of scheduling.
 type TaskGroup struct {
-	Name             *string
-	Count            *int
-	Constraints      []*Constraint
-	Affinities       []*Affinity
-	Tasks            []*Task
-	Spreads          []*Spread
-	Volumes          map[string]*VolumeRequest
-	RestartPolicy    *RestartPolicy
-	ReschedulePolicy *ReschedulePolicy
-	EphemeralDisk    *EphemeralDisk
-	Update           *UpdateStrategy
-	Migrate          *MigrateStrategy
-	Networks         []*NetworkResource
-	Meta             map[string]string
-	Services         []*Service
+	Name                *string
+	Count               *int
+	Constraints         []*Constraint
+	Affinities          []*Affinity
+	Tasks               []*Task
+	Spreads             []*Spread
+	Volumes             map[string]*VolumeRequest
+	RestartPolicy       *RestartPolicy
+	ReschedulePolicy    *ReschedulePolicy
+	EphemeralDisk       *EphemeralDisk
+	Update              *UpdateStrategy
+	Migrate             *MigrateStrategy
+	Networks            []*NetworkResource
+	Meta                map[string]string
+	Services            []*Service
+	Scaling             *ScalingPolicy
+	MaxClientDisconnect *time.Duration `mapstructure:"max_client_disconnect"`
 }
 
 // NewTaskGroup creates a new TaskGroup.
@@ -411,7 +413,14 @@ func (g *TaskGroup) Canonicalize(job *Job) {
 		g.Name = stringToPtr("")
 	}
 	if g.Count == nil {
-		g.Count = intToPtr(1)
+		if g.Scaling != nil && g.Scaling.Min != nil {
+			g.Count = intToPtr(int(*g.Scaling.Min))
+		} else {
+			g.Count = intToPtr(1)
+		}
+	}
+	if g.Scaling != nil {
+		g.Scaling.Canonicalize(g)
 	}
 	for _, t := range g.Tasks {
 		t.Canonicalize(g, job)
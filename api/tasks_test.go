@@ -394,6 +394,40 @@ func TestTaskGroup_Canonicalize_Update(t *testing.T) {
 	assert.Nil(t, tg.Update)
 }
 
+func TestTaskGroup_Canonicalize_Scaling(t *testing.T) {
+	require := require.New(t)
+
+	job := &Job{
+		ID: stringToPtr("test"),
+	}
+	job.Canonicalize()
+
+	// The count defaults to the minimum of the policy
+	tg := &TaskGroup{
+		Name: stringToPtr("foo"),
+		Scaling: &ScalingPolicy{
+			Min: int64ToPtr(3),
+			Max: int64ToPtr(10),
+		},
+	}
+	tg.Canonicalize(job)
+	require.Equal(3, *tg.Count)
+	require.True(*tg.Scaling.Enabled)
+
+	// The minimum defaults to the count
+	tg = &TaskGroup{
+		Name:  stringToPtr("foo"),
+		Count: intToPtr(5),
+		Scaling: &ScalingPolicy{
+			Max:     int64ToPtr(10),
+			Enabled: boolToPtr(false),
+		},
+	}
+	tg.Canonicalize(job)
+	require.EqualValues(5, *tg.Scaling.Min)
+	require.False(*tg.Scaling.Enabled)
+}
+
 func TestTaskGroup_Merge_Update(t *testing.T) {
 	job := &Job{
 		ID:     stringToPtr("test"),
@@ -74,6 +74,28 @@ func (c *Client) ResolveToken(secretID string) (*acl.ACL, error) {
 	return a, err
 }
 
+// checkAllocNsOp returns a permission denied error unless the ACL object allows
+// one of the operations in the namespace of the allocation. Operations on an
+// allocation are authorized against its namespace rather than the namespace of
+// the request, so a token can't reach the allocations of other namespaces by
+// ID. Unknown allocations are authorized against the namespace of the request.
+func (c *Client) checkAllocNsOp(aclObj *acl.ACL, allocID, namespace string, ops ...string) error {
+	if aclObj == nil {
+		return nil
+	}
+
+	if ar, err := c.getAllocRunner(allocID); err == nil {
+		namespace = ar.Alloc().Namespace
+	}
+
+	for _, op := range ops {
+		if aclObj.AllowNsOp(namespace, op) {
+			return nil
+		}
+	}
+	return structs.ErrPermissionDenied
+}
+
 func (c *Client) resolveTokenAndACL(secretID string) (*acl.ACL, *structs.ACLToken, error) {
 	// Fast-path if ACLs are disabled
 	if !c.config.ACLEnabled {
@@ -0,0 +1,125 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	metrics "github.com/armon/go-metrics"
+	log "github.com/hashicorp/go-hclog"
+	cstructs "github.com/hashicorp/nomad/client/structs"
+	"github.com/hashicorp/nomad/command/agent/monitor"
+	"github.com/hashicorp/nomad/command/agent/pprof"
+	"github.com/hashicorp/nomad/helper"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/ugorji/go/codec"
+)
+
+// Agent endpoint is used for accessing the agent running the client.
+type Agent struct {
+	c *Client
+}
+
+func NewAgentEndpoint(c *Client) *Agent {
+	a := &Agent{c: c}
+	a.c.streamingRpcs.Register("Agent.Monitor", a.monitor)
+	return a
+}
+
+// monitor is used to stream the logs of the agent
+func (a *Agent) monitor(conn io.ReadWriteCloser) {
+	defer metrics.MeasureSince([]string{"client", "agent", "monitor"}, time.Now())
+	defer conn.Close()
+
+	// Decode the arguments
+	var args cstructs.MonitorRequest
+	decoder := codec.NewDecoder(conn, structs.MsgpackHandle)
+	encoder := codec.NewEncoder(conn, structs.MsgpackHandle)
+
+	if err := decoder.Decode(&args); err != nil {
+		handleStreamResultError(err, helper.Int64ToPtr(500), encoder)
+		return
+	}
+
+	// Check agent read permissions
+	if aclObj, err := a.c.ResolveToken(args.AuthToken); err != nil {
+		handleStreamResultError(err, nil, encoder)
+		return
+	} else if aclObj != nil && !aclObj.AllowAgentRead() {
+		handleStreamResultError(structs.ErrPermissionDenied, helper.Int64ToPtr(403), encoder)
+		return
+	}
+
+	logLevel := log.LevelFromString(args.LogLevel)
+	if args.LogLevel == "" {
+		logLevel = log.LevelFromString("INFO")
+	}
+
+	if logLevel == log.NoLevel {
+		handleStreamResultError(errors.New("Unknown log level"), helper.Int64ToPtr(400), encoder)
+		return
+	}
+
+	m := monitor.New(512, a.c.config.Logger, &log.LoggerOptions{
+		JSONFormat: args.LogJSON,
+		Level:      logLevel,
+	})
+
+	if err := monitor.Stream(conn, encoder, m, args.PlainText); err != nil {
+		handleStreamResultError(err, helper.Int64ToPtr(500), encoder)
+		return
+	}
+}
+
+// Profile is used to retrieve a runtime profile of the agent
+func (a *Agent) Profile(args *cstructs.AgentPprofRequest, reply *cstructs.AgentPprofResponse) error {
+	defer metrics.MeasureSince([]string{"client", "agent", "profile"}, time.Now())
+
+	// Check agent write permissions
+	aclObj, err := a.c.ResolveToken(args.AuthToken)
+	if err != nil {
+		return err
+	} else if aclObj != nil && !aclObj.AllowAgentWrite() {
+		return structs.ErrPermissionDenied
+	}
+
+	// Without ACLs, profiling requires enable_debug
+	if aclObj == nil && !a.c.config.EnableDebug {
+		return structs.ErrPermissionDenied
+	}
+
+	// Stop profiling if the client shuts down
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		select {
+		case <-a.c.shutdownCh:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	var payload []byte
+	var headers map[string]string
+
+	switch args.ReqType {
+	case pprof.CPUReq:
+		payload, headers, err = pprof.CPUProfile(ctx, args.Seconds)
+	case pprof.TraceReq:
+		payload, headers, err = pprof.Trace(ctx, args.Seconds)
+	case pprof.LookupReq:
+		payload, headers, err = pprof.Profile(args.Profile, args.Debug, args.GC)
+	default:
+		err = fmt.Errorf("unknown profile type %q", args.ReqType)
+	}
+	if err != nil {
+		return err
+	}
+
+	reply.AgentID = a.c.NodeID()
+	reply.Payload = payload
+	reply.HTTPHeaders = headers
+	return nil
+}
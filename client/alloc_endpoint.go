@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"net"
 	"time"
 
 	metrics "github.com/armon/go-metrics"
@@ -27,6 +28,7 @@ type Allocations struct {
 func NewAllocationsEndpoint(c *Client) *Allocations {
 	a := &Allocations{c: c}
 	a.c.streamingRpcs.Register("Allocations.Exec", a.exec)
+	a.c.streamingRpcs.Register("Allocations.PortForward", a.portForward)
 	return a
 }
 
@@ -52,8 +54,8 @@ func (a *Allocations) GarbageCollect(args *nstructs.AllocSpecificRequest, reply
 	// Check submit job permissions
 	if aclObj, err := a.c.ResolveToken(args.AuthToken); err != nil {
 		return err
-	} else if aclObj != nil && !aclObj.AllowNsOp(args.Namespace, acl.NamespaceCapabilitySubmitJob) {
-		return nstructs.ErrPermissionDenied
+	} else if err := a.c.checkAllocNsOp(aclObj, args.AllocID, args.Namespace, acl.NamespaceCapabilitySubmitJob); err != nil {
+		return err
 	}
 
 	if !a.c.CollectAllocation(args.AllocID) {
@@ -71,8 +73,8 @@ func (a *Allocations) Signal(args *nstructs.AllocSignalRequest, reply *nstructs.
 	// Check alloc-lifecycle permissions
 	if aclObj, err := a.c.ResolveToken(args.AuthToken); err != nil {
 		return err
-	} else if aclObj != nil && !aclObj.AllowNsOp(args.Namespace, acl.NamespaceCapabilityAllocLifecycle) {
-		return nstructs.ErrPermissionDenied
+	} else if err := a.c.checkAllocNsOp(aclObj, args.AllocID, args.Namespace, acl.NamespaceCapabilityAllocLifecycle); err != nil {
+		return err
 	}
 
 	return a.c.SignalAllocation(args.AllocID, args.Task, args.Signal)
@@ -84,8 +86,8 @@ func (a *Allocations) Restart(args *nstructs.AllocRestartRequest, reply *nstruct
 
 	if aclObj, err := a.c.ResolveToken(args.AuthToken); err != nil {
 		return err
-	} else if aclObj != nil && !aclObj.AllowNsOp(args.Namespace, acl.NamespaceCapabilityAllocLifecycle) {
-		return nstructs.ErrPermissionDenied
+	} else if err := a.c.checkAllocNsOp(aclObj, args.AllocID, args.Namespace, acl.NamespaceCapabilityAllocLifecycle); err != nil {
+		return err
 	}
 
 	return a.c.RestartAllocation(args.AllocID, args.TaskName)
@@ -98,8 +100,8 @@ func (a *Allocations) Stats(args *cstructs.AllocStatsRequest, reply *cstructs.Al
 	// Check read job permissions
 	if aclObj, err := a.c.ResolveToken(args.AuthToken); err != nil {
 		return err
-	} else if aclObj != nil && !aclObj.AllowNsOp(args.Namespace, acl.NamespaceCapabilityReadJob) {
-		return nstructs.ErrPermissionDenied
+	} else if err := a.c.checkAllocNsOp(aclObj, args.AllocID, args.Namespace, acl.NamespaceCapabilityReadJob); err != nil {
+		return err
 	}
 
 	clientStats := a.c.StatsReporter()
@@ -170,11 +172,8 @@ func (a *Allocations) execImpl(encoder *codec.Encoder, decoder *codec.Decoder, e
 	// Check read permissions
 	if err != nil {
 		return nil, err
-	} else if aclObj != nil {
-		exec := aclObj.AllowNsOp(req.QueryOptions.Namespace, acl.NamespaceCapabilityAllocExec)
-		if !exec {
-			return nil, structs.ErrPermissionDenied
-		}
+	} else if err := a.c.checkAllocNsOp(aclObj, req.AllocID, req.QueryOptions.Namespace, acl.NamespaceCapabilityAllocExec); err != nil {
+		return nil, err
 	}
 
 	// Validate the arguments
@@ -209,10 +208,9 @@ func (a *Allocations) execImpl(encoder *codec.Encoder, decoder *codec.Decoder, e
 	}
 
 	// check node access
-	if aclObj != nil && capabilities.FSIsolation == drivers.FSIsolationNone {
-		exec := aclObj.AllowNsOp(req.QueryOptions.Namespace, acl.NamespaceCapabilityAllocNodeExec)
-		if !exec {
-			return nil, structs.ErrPermissionDenied
+	if capabilities.FSIsolation == drivers.FSIsolationNone {
+		if err := a.c.checkAllocNsOp(aclObj, req.AllocID, req.QueryOptions.Namespace, acl.NamespaceCapabilityAllocNodeExec); err != nil {
+			return nil, err
 		}
 	}
 
@@ -253,6 +251,142 @@ func (a *Allocations) execImpl(encoder *codec.Encoder, decoder *codec.Decoder, e
 	return nil, nil
 }
 
+// portForward is used to forward a connection to a port of an allocation
+func (a *Allocations) portForward(conn io.ReadWriteCloser) {
+	defer metrics.MeasureSince([]string{"client", "allocations", "port_forward"}, time.Now())
+	defer conn.Close()
+
+	sessionID := uuid.Generate()
+	decoder := codec.NewDecoder(conn, structs.MsgpackHandle)
+	encoder := codec.NewEncoder(conn, structs.MsgpackHandle)
+
+	code, err := a.portForwardImpl(encoder, decoder, sessionID)
+	if err != nil {
+		a.c.logger.Info("port forward session ended with an error", "error", err, "code", code)
+		handleStreamResultError(err, code, encoder)
+		return
+	}
+
+	a.c.logger.Info("port forward session ended", "session_id", sessionID)
+}
+
+func (a *Allocations) portForwardImpl(encoder *codec.Encoder, decoder *codec.Decoder, sessionID string) (code *int64, err error) {
+
+	// Decode the arguments
+	var req cstructs.AllocPortForwardRequest
+	if err := decoder.Decode(&req); err != nil {
+		return helper.Int64ToPtr(500), err
+	}
+
+	aclObj, token, err := a.c.resolveTokenAndACL(req.QueryOptions.AuthToken)
+	{
+		// log access
+		tokenName, tokenID := "", ""
+		if token != nil {
+			tokenName, tokenID = token.Name, token.AccessorID
+		}
+
+		a.c.logger.Info("port forward session starting",
+			"session_id", sessionID,
+			"alloc_id", req.AllocID,
+			"port", req.Port,
+			"access_token_name", tokenName,
+			"access_token_id", tokenID,
+		)
+	}
+
+	// Check exec permissions
+	if err != nil {
+		return nil, err
+	} else if err := a.c.checkAllocNsOp(aclObj, req.AllocID, req.QueryOptions.Namespace, acl.NamespaceCapabilityAllocExec); err != nil {
+		return nil, err
+	}
+
+	// Validate the arguments
+	if req.AllocID == "" {
+		return helper.Int64ToPtr(400), allocIDNotPresentErr
+	}
+	if req.Port == "" {
+		return helper.Int64ToPtr(400), portNotPresentErr
+	}
+
+	ar, err := a.c.getAllocRunner(req.AllocID)
+	if err != nil {
+		code := helper.Int64ToPtr(500)
+		if structs.IsErrUnknownAllocation(err) {
+			code = helper.Int64ToPtr(404)
+		}
+
+		return code, err
+	}
+
+	target, err := ar.DialPort(req.Port)
+	if err != nil {
+		return helper.Int64ToPtr(400), fmt.Errorf("failed to connect to port %q: %v", req.Port, err)
+	}
+	defer target.Close()
+
+	if err := forwardPortStream(target, encoder, decoder); err != nil {
+		return helper.Int64ToPtr(500), err
+	}
+	return nil, nil
+}
+
+// forwardPortStream copies the frames of the stream to the target connection
+// and the data read from the target connection to the stream until the target
+// connection is closed.
+func forwardPortStream(target net.Conn, encoder *codec.Encoder, decoder *codec.Decoder) error {
+	inputDoneCh := make(chan struct{})
+	go func() {
+		defer close(inputDoneCh)
+		for {
+			var frame cstructs.AllocPortForwardFrame
+			if err := decoder.Decode(&frame); err != nil {
+				// The stream was closed, so stop reading from the target
+				target.Close()
+				return
+			}
+
+			if len(frame.Data) != 0 {
+				if _, err := target.Write(frame.Data); err != nil {
+					target.Close()
+					return
+				}
+			}
+
+			// Keep decoding after closing the writing side, so that the
+			// target is closed once the stream ends
+			if frame.Close {
+				if cw, ok := target.(interface{ CloseWrite() error }); ok {
+					cw.CloseWrite()
+				}
+			}
+		}
+	}()
+
+	buf := make([]byte, 32*1024)
+	for {
+		n, err := target.Read(buf)
+		if n != 0 {
+			if err := encoder.Encode(cstructs.StreamErrWrapper{Payload: buf[:n]}); err != nil {
+				return err
+			}
+		}
+
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			// Reading fails once the input side closed the target
+			select {
+			case <-inputDoneCh:
+				return nil
+			default:
+			}
+			return err
+		}
+	}
+}
+
 // newExecStream returns a new exec stream as expected by drivers that interpolate with RPC streaming format
 func newExecStream(decoder *codec.Decoder, encoder *codec.Encoder) drivers.ExecTaskStream {
 	buf := new(bytes.Buffer)
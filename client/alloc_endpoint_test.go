@@ -402,6 +402,220 @@ func TestAllocations_Stats(t *testing.T) {
 	})
 }
 
+// testRunAllocInNamespace registers a batch job in a new namespace and waits
+// for its allocation to run on the client.
+func testRunAllocInNamespace(t *testing.T, s *nomad.Server, token string) *nstructs.AllocListStub {
+	ns := mock.Namespace()
+	nsReq := &nstructs.NamespaceUpsertRequest{
+		Namespaces:   []*nstructs.Namespace{ns},
+		WriteRequest: nstructs.WriteRequest{Region: "global", AuthToken: token},
+	}
+	var nsResp nstructs.GenericResponse
+	require.NoError(t, s.RPC("Namespace.UpsertNamespaces", nsReq, &nsResp))
+
+	job := mock.BatchJob()
+	job.Namespace = ns.Name
+	job.TaskGroups[0].Count = 1
+	job.TaskGroups[0].Tasks[0].Config = map[string]interface{}{
+		"run_for": "20s",
+	}
+	regReq := &nstructs.JobRegisterRequest{
+		Job: job,
+		WriteRequest: nstructs.WriteRequest{
+			Region:    "global",
+			Namespace: ns.Name,
+			AuthToken: token,
+		},
+	}
+	var regResp nstructs.JobRegisterResponse
+	require.NoError(t, s.RPC("Job.Register", regReq, &regResp))
+
+	var alloc *nstructs.AllocListStub
+	testutil.WaitForResult(func() (bool, error) {
+		req := &nstructs.JobSpecificRequest{
+			JobID: job.ID,
+			QueryOptions: nstructs.QueryOptions{
+				Region:    "global",
+				Namespace: ns.Name,
+				AuthToken: token,
+			},
+		}
+		var resp nstructs.JobAllocationsResponse
+		if err := s.RPC("Job.Allocations", req, &resp); err != nil {
+			return false, err
+		}
+		if len(resp.Allocations) == 0 {
+			return false, fmt.Errorf("no allocations")
+		}
+		alloc = resp.Allocations[0]
+		if alloc.ClientStatus != nstructs.AllocClientStatusRunning {
+			return false, fmt.Errorf("alloc not running: %s", alloc.ClientStatus)
+		}
+		return true, nil
+	}, func(err error) {
+		require.NoError(t, err)
+	})
+	return alloc
+}
+
+// TestAllocations_CrossNamespace_ACL asserts that the operations on an
+// allocation are authorized against the namespace of the allocation.
+func TestAllocations_CrossNamespace_ACL(t *testing.T) {
+	t.Parallel()
+	server, addr, root := testACLServer(t, nil)
+	defer server.Shutdown()
+
+	client, cleanup := TestClient(t, func(c *config.Config) {
+		c.Servers = []string{addr}
+		c.ACLEnabled = true
+	})
+	defer cleanup()
+
+	alloc := testRunAllocInNamespace(t, server, root.SecretID)
+
+	caps := []string{acl.NamespaceCapabilityReadJob, acl.NamespaceCapabilitySubmitJob,
+		acl.NamespaceCapabilityAllocLifecycle}
+	defaultToken := mock.CreatePolicyAndToken(t, server.State(), 1005, "default",
+		mock.NamespacePolicy(nstructs.DefaultNamespace, "", caps))
+	allocToken := mock.CreatePolicyAndToken(t, server.State(), 1007, "alloc",
+		mock.NamespacePolicy(alloc.Namespace, "", caps))
+
+	qo := nstructs.QueryOptions{
+		Region:    "global",
+		Namespace: nstructs.DefaultNamespace,
+	}
+	cases := []struct {
+		Method string
+		Req    func(token string) interface{}
+		Resp   interface{}
+
+		// Allowed is whether the request is also made with the token of
+		// the namespace of the allocation, which is skipped for requests
+		// that would remove the allocation
+		Allowed bool
+	}{
+		{
+			Method: "Allocations.Stats",
+			Req: func(token string) interface{} {
+				req := &cstructs.AllocStatsRequest{AllocID: alloc.ID, QueryOptions: qo}
+				req.AuthToken = token
+				return req
+			},
+			Resp:    &cstructs.AllocStatsResponse{},
+			Allowed: true,
+		},
+		{
+			Method: "Allocations.Signal",
+			Req: func(token string) interface{} {
+				req := &nstructs.AllocSignalRequest{AllocID: alloc.ID, QueryOptions: qo}
+				req.AuthToken = token
+				return req
+			},
+			Resp:    &nstructs.GenericResponse{},
+			Allowed: true,
+		},
+		{
+			Method: "Allocations.Restart",
+			Req: func(token string) interface{} {
+				req := &nstructs.AllocRestartRequest{AllocID: alloc.ID, QueryOptions: qo}
+				req.AuthToken = token
+				return req
+			},
+			Resp:    &nstructs.GenericResponse{},
+			Allowed: true,
+		},
+		{
+			Method: "Allocations.GarbageCollect",
+			Req: func(token string) interface{} {
+				req := &nstructs.AllocSpecificRequest{AllocID: alloc.ID, QueryOptions: qo}
+				req.AuthToken = token
+				return req
+			},
+			Resp: &nstructs.GenericResponse{},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.Method, func(t *testing.T) {
+			// A token of the namespace of the request is denied
+			err := client.ClientRPC(c.Method, c.Req(defaultToken.SecretID), c.Resp)
+			require.EqualError(t, err, nstructs.ErrPermissionDenied.Error())
+
+			if c.Allowed {
+				require.NoError(t, client.ClientRPC(c.Method, c.Req(allocToken.SecretID), c.Resp))
+			}
+		})
+	}
+}
+
+func TestAlloc_CrossNamespace_Exec_ACL(t *testing.T) {
+	t.Parallel()
+	server, addr, root := testACLServer(t, nil)
+	defer server.Shutdown()
+
+	client, cleanup := TestClient(t, func(c *config.Config) {
+		c.Servers = []string{addr}
+		c.ACLEnabled = true
+	})
+	defer cleanup()
+
+	alloc := testRunAllocInNamespace(t, server, root.SecretID)
+
+	caps := []string{acl.NamespaceCapabilityAllocExec, acl.NamespaceCapabilityAllocNodeExec}
+	defaultToken := mock.CreatePolicyAndToken(t, server.State(), 1005, "default",
+		mock.NamespacePolicy(nstructs.DefaultNamespace, "", caps))
+	allocToken := mock.CreatePolicyAndToken(t, server.State(), 1007, "alloc",
+		mock.NamespacePolicy(alloc.Namespace, "", caps))
+
+	qo := func(token string) nstructs.QueryOptions {
+		return nstructs.QueryOptions{
+			Region:    "global",
+			Namespace: nstructs.DefaultNamespace,
+			AuthToken: token,
+		}
+	}
+	cases := []struct {
+		Method string
+		Req    func(token string) interface{}
+
+		// Error is the error of the request made with the token of the
+		// namespace of the allocation
+		Error string
+	}{
+		{
+			Method: "Allocations.Exec",
+			Req: func(token string) interface{} {
+				return &cstructs.AllocExecRequest{AllocID: alloc.ID, Task: "missing", Cmd: []string{"ls"}, QueryOptions: qo(token)}
+			},
+			Error: "task not found",
+		},
+		{
+			Method: "Allocations.PortForward",
+			Req: func(token string) interface{} {
+				return &cstructs.AllocPortForwardRequest{AllocID: alloc.ID, Port: "missing", QueryOptions: qo(token)}
+			},
+			Error: `failed to connect to port "missing"`,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.Method, func(t *testing.T) {
+			// A token of the namespace of the request is denied
+			msgs := fsStreamingRpc(t, client, c.Method, c.Req(defaultToken.SecretID))
+			require.NotEmpty(t, msgs)
+			require.NotNil(t, msgs[0].Error)
+			require.Contains(t, msgs[0].Error.Error(), nstructs.ErrPermissionDenied.Error())
+
+			// A token of the namespace of the allocation is allowed, and the
+			// request fails on its arguments
+			msgs = fsStreamingRpc(t, client, c.Method, c.Req(allocToken.SecretID))
+			require.NotEmpty(t, msgs)
+			require.NotNil(t, msgs[0].Error)
+			require.Contains(t, msgs[0].Error.Error(), c.Error)
+		})
+	}
+}
+
 func TestAllocations_Stats_ACL(t *testing.T) {
 	t.Parallel()
 	require := require.New(t)
@@ -1235,3 +1449,243 @@ func decodeFrames(t *testing.T, p1 net.Conn, frames chan<- *drivers.ExecTaskStre
 		frames <- &frame
 	}
 }
+
+func TestAlloc_PortForward(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	// Start a server and client
+	s := nomad.TestServer(t, nil)
+	defer s.Shutdown()
+	testutil.WaitForLeader(t, s.RPC)
+
+	c, cleanup := TestClient(t, func(c *config.Config) {
+		c.Servers = []string{s.GetConfig().RPCAddr.String()}
+	})
+	defer cleanup()
+
+	job := mock.BatchJob()
+	job.TaskGroups[0].Count = 1
+	job.TaskGroups[0].Tasks[0].Config = map[string]interface{}{
+		"run_for": "20s",
+	}
+	job.TaskGroups[0].Tasks[0].Resources.Networks[0].DynamicPorts = []structs.Port{{Label: "http"}}
+
+	// Wait for client to be running job
+	testutil.WaitForRunning(t, s.RPC, job)
+
+	// Get the allocation
+	args := nstructs.AllocListRequest{}
+	args.Region = "global"
+	resp := nstructs.AllocListResponse{}
+	require.NoError(s.RPC("Alloc.List", &args, &resp))
+	require.Len(resp.Allocations, 1)
+	alloc, err := s.State().AllocByID(nil, resp.Allocations[0].ID)
+	require.NoError(err)
+
+	// Listen on the allocated port in place of the task
+	network := alloc.AllocatedResources.Tasks[job.TaskGroups[0].Tasks[0].Name].Networks[0]
+	port := network.DynamicPorts[0].Value
+	l, err := net.Listen("tcp", net.JoinHostPort(network.IP, fmt.Sprint(port)))
+	require.NoError(err)
+	defer l.Close()
+
+	// Echo the data read from the connection
+	go func() {
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		io.Copy(conn, conn)
+	}()
+
+	// Get the handler
+	handler, err := c.StreamingRpcHandler("Allocations.PortForward")
+	require.Nil(err)
+
+	for _, label := range []string{"http", fmt.Sprint(port)} {
+		t.Run(label, func(t *testing.T) {
+			if label != "http" {
+				// Accept a second connection
+				go func() {
+					conn, err := l.Accept()
+					if err != nil {
+						return
+					}
+					defer conn.Close()
+					io.Copy(conn, conn)
+				}()
+			}
+
+			// Create a pipe
+			p1, p2 := net.Pipe()
+			defer p1.Close()
+			defer p2.Close()
+
+			// Start the handler
+			go handler(p2)
+
+			// Send the request and data
+			encoder := codec.NewEncoder(p1, nstructs.MsgpackHandle)
+			require.NoError(encoder.Encode(&cstructs.AllocPortForwardRequest{
+				AllocID:      alloc.ID,
+				Port:         label,
+				QueryOptions: nstructs.QueryOptions{Region: "global"},
+			}))
+			require.NoError(encoder.Encode(&cstructs.AllocPortForwardFrame{Data: []byte("hello")}))
+			require.NoError(encoder.Encode(&cstructs.AllocPortForwardFrame{Close: true}))
+
+			// Read the echoed data until the connection is closed
+			received := ""
+			decoder := codec.NewDecoder(p1, nstructs.MsgpackHandle)
+			for {
+				var msg cstructs.StreamErrWrapper
+				if err := decoder.Decode(&msg); err != nil {
+					require.True(err == io.EOF || strings.Contains(err.Error(), "closed"), "unexpected error: %v", err)
+					break
+				}
+				require.Nil(msg.Error)
+				received += string(msg.Payload)
+			}
+			require.Equal("hello", received)
+		})
+	}
+}
+
+func TestAlloc_PortForward_UnknownPort(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	// Start a server and client
+	s := nomad.TestServer(t, nil)
+	defer s.Shutdown()
+	testutil.WaitForLeader(t, s.RPC)
+
+	c, cleanup := TestClient(t, func(c *config.Config) {
+		c.Servers = []string{s.GetConfig().RPCAddr.String()}
+	})
+	defer cleanup()
+
+	job := mock.BatchJob()
+	job.TaskGroups[0].Count = 1
+	job.TaskGroups[0].Tasks[0].Config = map[string]interface{}{
+		"run_for": "20s",
+	}
+	testutil.WaitForRunning(t, s.RPC, job)
+
+	args := nstructs.AllocListRequest{}
+	args.Region = "global"
+	resp := nstructs.AllocListResponse{}
+	require.NoError(s.RPC("Alloc.List", &args, &resp))
+	require.Len(resp.Allocations, 1)
+
+	cases := []struct {
+		Port          string
+		ExpectedError string
+	}{
+		{Port: "admin", ExpectedError: `unknown port label "admin"`},
+		{Port: "2", ExpectedError: "port 2 is not allocated to the allocation"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Port, func(t *testing.T) {
+			handler, err := c.StreamingRpcHandler("Allocations.PortForward")
+			require.Nil(err)
+
+			p1, p2 := net.Pipe()
+			defer p1.Close()
+			defer p2.Close()
+
+			go handler(p2)
+
+			encoder := codec.NewEncoder(p1, nstructs.MsgpackHandle)
+			require.NoError(encoder.Encode(&cstructs.AllocPortForwardRequest{
+				AllocID:      resp.Allocations[0].ID,
+				Port:         tc.Port,
+				QueryOptions: nstructs.QueryOptions{Region: "global"},
+			}))
+
+			var msg cstructs.StreamErrWrapper
+			decoder := codec.NewDecoder(p1, nstructs.MsgpackHandle)
+			require.NoError(decoder.Decode(&msg))
+			require.NotNil(msg.Error)
+			require.Contains(msg.Error.Error(), tc.ExpectedError)
+			require.EqualValues(400, *msg.Error.Code)
+		})
+	}
+}
+
+func TestAlloc_PortForward_ACL(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	// Start a server and client
+	s, root := nomad.TestACLServer(t, nil)
+	defer s.Shutdown()
+	testutil.WaitForLeader(t, s.RPC)
+
+	client, cleanup := TestClient(t, func(c *config.Config) {
+		c.ACLEnabled = true
+		c.Servers = []string{s.GetConfig().RPCAddr.String()}
+	})
+	defer cleanup()
+
+	policyBad := mock.NamespacePolicy(structs.DefaultNamespace, "", []string{acl.NamespaceCapabilityReadJob})
+	tokenBad := mock.CreatePolicyAndToken(t, s.State(), 1005, "invalid", policyBad)
+
+	policyGood := mock.NamespacePolicy(structs.DefaultNamespace, "", []string{acl.NamespaceCapabilityAllocExec})
+	tokenGood := mock.CreatePolicyAndToken(t, s.State(), 1009, "valid", policyGood)
+
+	cases := []struct {
+		Name          string
+		Token         string
+		ExpectedError string
+	}{
+		{
+			Name:          "bad token",
+			Token:         tokenBad.SecretID,
+			ExpectedError: structs.ErrPermissionDenied.Error(),
+		},
+		{
+			Name:          "good token",
+			Token:         tokenGood.SecretID,
+			ExpectedError: structs.ErrUnknownAllocationPrefix,
+		},
+		{
+			Name:          "root token",
+			Token:         root.SecretID,
+			ExpectedError: structs.ErrUnknownAllocationPrefix,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			handler, err := client.StreamingRpcHandler("Allocations.PortForward")
+			require.Nil(err)
+
+			p1, p2 := net.Pipe()
+			defer p1.Close()
+			defer p2.Close()
+
+			go handler(p2)
+
+			encoder := codec.NewEncoder(p1, nstructs.MsgpackHandle)
+			require.NoError(encoder.Encode(&cstructs.AllocPortForwardRequest{
+				AllocID: uuid.Generate(),
+				Port:    "http",
+				QueryOptions: nstructs.QueryOptions{
+					Region:    "global",
+					AuthToken: c.Token,
+					Namespace: nstructs.DefaultNamespace,
+				},
+			}))
+
+			var msg cstructs.StreamErrWrapper
+			decoder := codec.NewDecoder(p1, nstructs.MsgpackHandle)
+			require.NoError(decoder.Decode(&msg))
+			require.NotNil(msg.Error)
+			require.Contains(msg.Error.Error(), c.ExpectedError)
+		})
+	}
+}
@@ -93,6 +93,8 @@ type AllocDirFS interface {
 	List(path string) ([]*cstructs.AllocFileInfo, error)
 	Stat(path string) (*cstructs.AllocFileInfo, error)
 	ReadAt(path string, offset int64) (io.ReadCloser, error)
+	ReadTar(path string, w io.Writer) error
+	WriteTar(path string, r io.Reader) error
 	Snapshot(w io.Writer) error
 	BlockUntilExists(ctx context.Context, path string) (chan error, error)
 	ChangeEvents(ctx context.Context, path string, curOffset int64) (*watch.FileChanges, error)
@@ -464,6 +466,247 @@ func (d *AllocDir) ReadAt(path string, offset int64) (io.ReadCloser, error) {
 	return f, nil
 }
 
+// ReadTar writes an archive of the file or directory at the path relative to
+// the alloc dir to w. The entries of the archive are named relative to the
+// parent of the path. Symlinks are archived as symlinks and the secret
+// directories of tasks are skipped.
+func (d *AllocDir) ReadTar(path string, w io.Writer) error {
+	if escapes, err := structs.PathEscapesAllocDir("", path); err != nil {
+		return fmt.Errorf("Failed to check if path escapes alloc directory: %v", err)
+	} else if escapes {
+		return fmt.Errorf("Path escapes the alloc directory")
+	}
+
+	p := filepath.Join(d.AllocDir, path)
+	root, err := d.resolvePath(p)
+	if err != nil {
+		return err
+	}
+
+	secretDirs, err := d.secretDirs()
+	if err != nil {
+		return err
+	}
+	if inSecretDir(secretDirs, root) {
+		return fmt.Errorf("Reading secret file prohibited: %s", path)
+	}
+
+	// Name the entries relative to the parent of the requested path rather
+	// than the resolved one
+	base := filepath.Base(p)
+
+	tw := tar.NewWriter(w)
+	walkFn := func(path string, fileInfo os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if inSecretDir(secretDirs, path) {
+			if fileInfo.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		link := ""
+		switch mode := fileInfo.Mode(); {
+		case mode.IsDir(), mode.IsRegular():
+		case mode&os.ModeSymlink != 0:
+			target, err := os.Readlink(path)
+			if err != nil {
+				return fmt.Errorf("error reading symlink: %v", err)
+			}
+			link = target
+		default:
+			// Skip sockets, devices and named pipes
+			return nil
+		}
+
+		relPath, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		hdr, err := tar.FileInfoHeader(fileInfo, link)
+		if err != nil {
+			return fmt.Errorf("error creating file header: %v", err)
+		}
+		hdr.Name = filepath.ToSlash(filepath.Join(base, relPath))
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+
+		// If it's a directory or symlink we just write the header into the tar
+		if !fileInfo.Mode().IsRegular() {
+			return nil
+		}
+
+		file, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+
+		_, err = io.Copy(tw, file)
+		return err
+	}
+
+	if err := filepath.Walk(root, walkFn); err != nil {
+		return err
+	}
+	return tw.Close()
+}
+
+// WriteTar extracts the archive read from r into the directory at the path
+// relative to the alloc dir. Entries that would be written outside of the
+// alloc dir, either by their name or by following a symlink, or into the
+// secret directory of a task are rejected. Regular files, directories and
+// symlinks are supported.
+func (d *AllocDir) WriteTar(path string, r io.Reader) error {
+	if escapes, err := structs.PathEscapesAllocDir("", path); err != nil {
+		return fmt.Errorf("Failed to check if path escapes alloc directory: %v", err)
+	} else if escapes {
+		return fmt.Errorf("Path escapes the alloc directory")
+	}
+
+	allocDir, err := filepath.EvalSymlinks(d.AllocDir)
+	if err != nil {
+		return err
+	}
+	root, err := d.resolvePath(filepath.Join(d.AllocDir, path))
+	if err != nil {
+		return err
+	}
+	if fi, err := os.Stat(root); err != nil {
+		return err
+	} else if !fi.IsDir() {
+		return fmt.Errorf("Path is not a directory: %s", path)
+	}
+
+	secretDirs, err := d.secretDirs()
+	if err != nil {
+		return err
+	}
+	if inSecretDir(secretDirs, root) {
+		return fmt.Errorf("Writing secret file prohibited: %s", path)
+	}
+
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		name := filepath.Clean(filepath.FromSlash(hdr.Name))
+		if filepath.IsAbs(name) {
+			return fmt.Errorf("Archive entry %q has an absolute path", hdr.Name)
+		}
+		if escapes, err := structs.PathEscapesAllocDir("", name); err != nil {
+			return fmt.Errorf("Failed to check if archive entry escapes directory: %v", err)
+		} else if escapes {
+			return fmt.Errorf("Archive entry %q escapes the directory", hdr.Name)
+		}
+		if name == "." {
+			continue
+		}
+
+		// Resolve the parent of the entry to ensure following a symlink
+		// does not leave the alloc dir
+		parent, err := d.resolvePath(filepath.Join(root, filepath.Dir(name)))
+		if err != nil {
+			return err
+		}
+		if inSecretDir(secretDirs, filepath.Join(parent, filepath.Base(name))) {
+			return fmt.Errorf("Writing secret file prohibited: %s", hdr.Name)
+		}
+
+		if err := writeTarEntry(tr, hdr, allocDir, parent, filepath.Base(name)); err != nil {
+			return fmt.Errorf("failed to write %q: %v", hdr.Name, err)
+		}
+	}
+}
+
+// resolvePath resolves the symlinks of the absolute path within the alloc
+// dir. Trailing elements of the path that do not exist are appended to the
+// resolved path as is. An error is returned if the resolved path is outside
+// of the alloc dir.
+func (d *AllocDir) resolvePath(p string) (string, error) {
+	allocDir, err := filepath.EvalSymlinks(d.AllocDir)
+	if err != nil {
+		return "", err
+	}
+
+	// Resolve the longest existing prefix of the path
+	existing, missing := p, ""
+	var resolved string
+	for {
+		resolved, err = filepath.EvalSymlinks(existing)
+		if err == nil {
+			break
+		}
+		if !os.IsNotExist(err) {
+			return "", err
+		}
+
+		parent := filepath.Dir(existing)
+		if parent == existing {
+			return "", err
+		}
+		missing = filepath.Join(filepath.Base(existing), missing)
+		existing = parent
+	}
+	resolved = filepath.Join(resolved, missing)
+
+	if !pathWithin(allocDir, resolved) {
+		return "", fmt.Errorf("Path escapes the alloc directory")
+	}
+	return resolved, nil
+}
+
+// secretDirs returns the resolved secret directories of the tasks.
+func (d *AllocDir) secretDirs() ([]string, error) {
+	allocDir, err := filepath.EvalSymlinks(d.AllocDir)
+	if err != nil {
+		return nil, err
+	}
+
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	dirs := make([]string, 0, len(d.TaskDirs))
+	for _, dir := range d.TaskDirs {
+		rel, err := filepath.Rel(d.AllocDir, dir.SecretsDir)
+		if err != nil {
+			return nil, err
+		}
+		dirs = append(dirs, filepath.Join(allocDir, rel))
+	}
+	return dirs, nil
+}
+
+// inSecretDir returns whether the path is within one of the secret dirs.
+func inSecretDir(secretDirs []string, path string) bool {
+	for _, dir := range secretDirs {
+		if pathWithin(dir, path) {
+			return true
+		}
+	}
+	return false
+}
+
+// pathWithin returns whether the path is the base path or is within it. Both
+// paths must be absolute and clean.
+func pathWithin(base, path string) bool {
+	rel, err := filepath.Rel(base, path)
+	if err != nil {
+		return false
+	}
+	return rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
 // BlockUntilExists blocks until the passed file relative the allocation
 // directory exists. The block can be cancelled with the passed context.
 func (d *AllocDir) BlockUntilExists(ctx context.Context, path string) (chan error, error) {
@@ -327,6 +327,16 @@ func TestAllocDir_EscapeChecking(t *testing.T) {
 	if _, err := d.ChangeEvents(context.Background(), "../foo", 0); err == nil || !strings.Contains(err.Error(), "escapes") {
 		t.Fatalf("ChangeEvents of escaping path didn't error: %v", err)
 	}
+
+	// ReadTar
+	if err := d.ReadTar("../foo", ioutil.Discard); err == nil || !strings.Contains(err.Error(), "escapes") {
+		t.Fatalf("ReadTar of escaping path didn't error: %v", err)
+	}
+
+	// WriteTar
+	if err := d.WriteTar("../foo", &bytes.Buffer{}); err == nil || !strings.Contains(err.Error(), "escapes") {
+		t.Fatalf("WriteTar of escaping path didn't error: %v", err)
+	}
 }
 
 // Test that `nomad fs` can't read secrets
@@ -355,6 +365,180 @@ func TestAllocDir_ReadAt_SecretDir(t *testing.T) {
 	}
 }
 
+func TestAllocDir_ReadWriteTar(t *testing.T) {
+	require := require.New(t)
+	tmp, err := ioutil.TempDir("", "AllocDir")
+	require.NoError(err)
+	defer os.RemoveAll(tmp)
+
+	d := NewAllocDir(testlog.HCLogger(t), tmp)
+	require.NoError(d.Build())
+	defer d.Destroy()
+
+	td := d.NewTaskDir(t1.Name)
+	require.NoError(td.Build(false, nil))
+
+	// Create a directory tree in the task's local dir
+	src := filepath.Join(td.LocalDir, "src")
+	require.NoError(os.MkdirAll(filepath.Join(src, "sub"), 0755))
+	require.NoError(ioutil.WriteFile(filepath.Join(src, "a.txt"), []byte("foo"), 0600))
+	require.NoError(ioutil.WriteFile(filepath.Join(src, "sub", "b.txt"), []byte("bar"), 0644))
+	require.NoError(os.Symlink("a.txt", filepath.Join(src, "link")))
+
+	var buf bytes.Buffer
+	require.NoError(d.ReadTar(filepath.Join(t1.Name, TaskLocal, "src"), &buf))
+
+	// Entries are named relative to the parent of the path
+	var names []string
+	tr := tar.NewReader(bytes.NewReader(buf.Bytes()))
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(err)
+		names = append(names, hdr.Name)
+	}
+	require.ElementsMatch([]string{"src", "src/a.txt", "src/link", "src/sub", "src/sub/b.txt"}, names)
+
+	// Extract the archive into the shared data dir
+	require.NoError(d.WriteTar(filepath.Join(SharedAllocName, SharedDataDir), &buf))
+
+	dst := filepath.Join(d.SharedDir, SharedDataDir, "src")
+	out, err := ioutil.ReadFile(filepath.Join(dst, "a.txt"))
+	require.NoError(err)
+	require.Equal("foo", string(out))
+
+	out, err = ioutil.ReadFile(filepath.Join(dst, "sub", "b.txt"))
+	require.NoError(err)
+	require.Equal("bar", string(out))
+
+	fi, err := os.Stat(filepath.Join(dst, "a.txt"))
+	require.NoError(err)
+	require.Equal(os.FileMode(0600), fi.Mode().Perm())
+
+	target, err := os.Readlink(filepath.Join(dst, "link"))
+	require.NoError(err)
+	require.Equal("a.txt", target)
+
+	// Writing into a file fails
+	err = d.WriteTar(filepath.Join(SharedAllocName, SharedDataDir, "src", "a.txt"), &bytes.Buffer{})
+	require.Error(err)
+	require.Contains(err.Error(), "not a directory")
+}
+
+// testTar returns an archive with a file for each of the given names.
+func testTar(t *testing.T, names ...string) *bytes.Buffer {
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	for _, name := range names {
+		hdr := &tar.Header{
+			Name:     name,
+			Mode:     0644,
+			Size:     3,
+			Typeflag: tar.TypeReg,
+		}
+		require.NoError(t, tw.WriteHeader(hdr))
+		_, err := tw.Write([]byte("foo"))
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	return &buf
+}
+
+func TestAllocDir_WriteTar_Escape(t *testing.T) {
+	require := require.New(t)
+	tmp, err := ioutil.TempDir("", "AllocDir")
+	require.NoError(err)
+	defer os.RemoveAll(tmp)
+
+	outside, err := ioutil.TempDir("", "Outside")
+	require.NoError(err)
+	defer os.RemoveAll(outside)
+
+	d := NewAllocDir(testlog.HCLogger(t), tmp)
+	require.NoError(d.Build())
+	defer d.Destroy()
+
+	data := filepath.Join(SharedAllocName, SharedDataDir)
+
+	// Entries escaping the directory are rejected
+	err = d.WriteTar(data, testTar(t, "../../../foo"))
+	require.Error(err)
+	require.Contains(err.Error(), "escapes")
+
+	// Entries are not written through symlinks leaving the alloc dir
+	require.NoError(os.Symlink(outside, filepath.Join(d.SharedDir, SharedDataDir, "out")))
+	err = d.WriteTar(data, testTar(t, "out/foo"))
+	require.Error(err)
+	require.Contains(err.Error(), "escapes")
+
+	// Nor is the target resolved outside of the alloc dir
+	err = d.WriteTar(filepath.Join(data, "out"), testTar(t, "foo"))
+	require.Error(err)
+	require.Contains(err.Error(), "escapes")
+
+	// Existing symlinks are replaced rather than followed
+	require.NoError(os.Symlink(filepath.Join(outside, "bar"), filepath.Join(d.SharedDir, SharedDataDir, "bar")))
+	require.NoError(d.WriteTar(data, testTar(t, "bar")))
+
+	fi, err := os.Lstat(filepath.Join(d.SharedDir, SharedDataDir, "bar"))
+	require.NoError(err)
+	require.True(fi.Mode().IsRegular())
+
+	files, err := ioutil.ReadDir(outside)
+	require.NoError(err)
+	require.Empty(files)
+
+	// Reading through a symlink leaving the alloc dir fails
+	err = d.ReadTar(filepath.Join(data, "out"), ioutil.Discard)
+	require.Error(err)
+	require.Contains(err.Error(), "escapes")
+}
+
+func TestAllocDir_Tar_SecretDir(t *testing.T) {
+	require := require.New(t)
+	tmp, err := ioutil.TempDir("", "AllocDir")
+	require.NoError(err)
+	defer os.RemoveAll(tmp)
+
+	d := NewAllocDir(testlog.HCLogger(t), tmp)
+	require.NoError(d.Build())
+	defer d.Destroy()
+
+	td := d.NewTaskDir(t1.Name)
+	require.NoError(td.Build(false, nil))
+	require.NoError(ioutil.WriteFile(filepath.Join(td.SecretsDir, "token"), []byte("secret"), 0600))
+
+	// Reading or writing the secret dir fails
+	secrets := filepath.Join(t1.Name, TaskSecrets)
+	err = d.ReadTar(secrets, ioutil.Discard)
+	require.Error(err)
+	require.Contains(err.Error(), "secret file prohibited")
+
+	err = d.WriteTar(secrets, testTar(t, "foo"))
+	require.Error(err)
+	require.Contains(err.Error(), "secret file prohibited")
+
+	err = d.WriteTar(t1.Name, testTar(t, TaskSecrets+"/foo"))
+	require.Error(err)
+	require.Contains(err.Error(), "secret file prohibited")
+
+	// Archiving the task dir skips the secret dir
+	var buf bytes.Buffer
+	require.NoError(d.ReadTar(t1.Name, &buf))
+
+	tr := tar.NewReader(&buf)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(err)
+		require.NotContains(hdr.Name, TaskSecrets)
+	}
+}
+
 func TestAllocDir_SplitPath(t *testing.T) {
 	dir, err := ioutil.TempDir("", "tmpdirtest")
 	if err != nil {
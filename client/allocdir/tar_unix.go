@@ -0,0 +1,136 @@
+// +build darwin dragonfly freebsd linux netbsd openbsd solaris
+
+package allocdir
+
+import (
+	"archive/tar"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/sys/unix"
+)
+
+// writeTarEntry writes the archive entry named name into the parent directory,
+// replacing any existing file or symlink. The parent must be a resolved path
+// within the resolved alloc dir. The parent is opened from the alloc dir
+// without following symlinks, and the entry is written relative to it, so a
+// directory replaced by a symlink after the path was resolved can't redirect
+// the write outside of the alloc dir.
+func writeTarEntry(tr *tar.Reader, hdr *tar.Header, allocDir, parent, name string) error {
+	dirfd, err := openDirNoFollow(allocDir, parent)
+	if err != nil {
+		return err
+	}
+	defer unix.Close(dirfd)
+
+	perm := uint32(os.FileMode(hdr.Mode).Perm())
+
+	var st unix.Stat_t
+	exists := true
+	if err := unix.Fstatat(dirfd, name, &st, unix.AT_SYMLINK_NOFOLLOW); err == unix.ENOENT {
+		exists = false
+	} else if err != nil {
+		return err
+	}
+	isDir := exists && st.Mode&unix.S_IFMT == unix.S_IFDIR
+
+	switch hdr.Typeflag {
+	case tar.TypeDir:
+		if isDir {
+			fd, err := unix.Openat(dirfd, name, unix.O_RDONLY|unix.O_DIRECTORY|unix.O_NOFOLLOW|unix.O_CLOEXEC, 0)
+			if err != nil {
+				return err
+			}
+			defer unix.Close(fd)
+			return unix.Fchmod(fd, perm)
+		}
+		if exists {
+			if err := unix.Unlinkat(dirfd, name, 0); err != nil {
+				return err
+			}
+		}
+		return unix.Mkdirat(dirfd, name, perm)
+
+	case tar.TypeReg, tar.TypeRegA:
+		if isDir {
+			return fmt.Errorf("a directory exists at the path")
+		}
+
+		// Remove the existing file so a symlink is never followed
+		if exists {
+			if err := unix.Unlinkat(dirfd, name, 0); err != nil {
+				return err
+			}
+		}
+
+		fd, err := unix.Openat(dirfd, name, unix.O_CREAT|unix.O_EXCL|unix.O_WRONLY|unix.O_NOFOLLOW|unix.O_CLOEXEC, perm)
+		if err != nil {
+			return err
+		}
+		f := os.NewFile(uintptr(fd), filepath.Join(parent, name))
+		if _, err := io.Copy(f, tr); err != nil {
+			f.Close()
+			return err
+		}
+		return f.Close()
+
+	case tar.TypeSymlink:
+		if isDir {
+			return fmt.Errorf("a directory exists at the path")
+		}
+		if exists {
+			if err := unix.Unlinkat(dirfd, name, 0); err != nil {
+				return err
+			}
+		}
+		return unix.Symlinkat(hdr.Linkname, dirfd, name)
+
+	default:
+		return fmt.Errorf("unsupported file type %q", hdr.Typeflag)
+	}
+}
+
+// openDirNoFollow opens the directory at the path within the base directory,
+// creating the missing directories. Each element of the path is opened
+// relative to its parent without following symlinks.
+func openDirNoFollow(base, path string) (int, error) {
+	rel, err := filepath.Rel(base, path)
+	if err != nil {
+		return -1, err
+	}
+	if rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return -1, fmt.Errorf("Path escapes the alloc directory")
+	}
+
+	fd, err := unix.Open(base, unix.O_RDONLY|unix.O_DIRECTORY|unix.O_CLOEXEC, 0)
+	if err != nil {
+		return -1, err
+	}
+	if rel == "." {
+		return fd, nil
+	}
+
+	const flags = unix.O_RDONLY | unix.O_DIRECTORY | unix.O_NOFOLLOW | unix.O_CLOEXEC
+	cur := base
+	for _, elem := range strings.Split(rel, string(filepath.Separator)) {
+		cur = filepath.Join(cur, elem)
+		next, err := unix.Openat(fd, elem, flags, 0)
+		if err == unix.ENOENT {
+			if err = unix.Mkdirat(fd, elem, 0777); err == nil || err == unix.EEXIST {
+				next, err = unix.Openat(fd, elem, flags, 0)
+			}
+		}
+		unix.Close(fd)
+		if err == unix.ELOOP || err == unix.ENOTDIR {
+			return -1, fmt.Errorf("%s is not a directory", cur)
+		}
+		if err != nil {
+			return -1, err
+		}
+		fd = next
+	}
+	return fd, nil
+}
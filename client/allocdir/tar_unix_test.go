@@ -0,0 +1,59 @@
+// +build darwin dragonfly freebsd linux netbsd openbsd solaris
+
+package allocdir
+
+import (
+	"archive/tar"
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/stretchr/testify/require"
+)
+
+// TestAllocDir_WriteTar_SymlinkSwap asserts entries aren't written through a
+// directory replaced by a symlink after its path was resolved.
+func TestAllocDir_WriteTar_SymlinkSwap(t *testing.T) {
+	require := require.New(t)
+	tmp, err := ioutil.TempDir("", "AllocDir")
+	require.NoError(err)
+	defer os.RemoveAll(tmp)
+
+	outside, err := ioutil.TempDir("", "Outside")
+	require.NoError(err)
+	defer os.RemoveAll(outside)
+
+	d := NewAllocDir(testlog.HCLogger(t), tmp)
+	require.NoError(d.Build())
+	defer d.Destroy()
+
+	allocDir, err := filepath.EvalSymlinks(d.AllocDir)
+	require.NoError(err)
+	data := filepath.Join(allocDir, SharedAllocName, SharedDataDir)
+
+	// The resolved parent of the entry is swapped for a symlink
+	parent := filepath.Join(data, "swapped")
+	require.NoError(os.Symlink(outside, parent))
+
+	for _, typ := range []byte{tar.TypeReg, tar.TypeDir, tar.TypeSymlink} {
+		hdr := &tar.Header{Name: "swapped/foo", Typeflag: typ, Mode: 0644, Linkname: "bar"}
+		err = writeTarEntry(tar.NewReader(bytes.NewReader(nil)), hdr, allocDir, parent, "foo")
+		require.Error(err)
+		require.Contains(err.Error(), "is not a directory")
+	}
+
+	files, err := ioutil.ReadDir(outside)
+	require.NoError(err)
+	require.Empty(files)
+
+	// Missing parents are created
+	hdr := &tar.Header{Name: "a/b/foo", Typeflag: tar.TypeDir, Mode: 0755}
+	err = writeTarEntry(tar.NewReader(bytes.NewReader(nil)), hdr, allocDir, filepath.Join(data, "a", "b"), "foo")
+	require.NoError(err)
+	fi, err := os.Stat(filepath.Join(data, "a", "b", "foo"))
+	require.NoError(err)
+	require.True(fi.IsDir())
+}
@@ -0,0 +1,75 @@
+package allocdir
+
+import (
+	"archive/tar"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// writeTarEntry writes the archive entry named name into the parent directory,
+// replacing any existing file or symlink. The parent must be a resolved path
+// within the resolved alloc dir.
+func writeTarEntry(tr *tar.Reader, hdr *tar.Header, allocDir, parent, name string) error {
+	if err := os.MkdirAll(parent, 0777); err != nil {
+		return err
+	}
+	dest := filepath.Join(parent, name)
+
+	perm := os.FileMode(hdr.Mode).Perm()
+
+	existing, err := os.Lstat(dest)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	switch hdr.Typeflag {
+	case tar.TypeDir:
+		if existing != nil {
+			if existing.IsDir() {
+				return os.Chmod(dest, perm)
+			}
+			if err := os.Remove(dest); err != nil {
+				return err
+			}
+		}
+		return os.Mkdir(dest, perm)
+
+	case tar.TypeReg, tar.TypeRegA:
+		if existing != nil {
+			if existing.IsDir() {
+				return fmt.Errorf("a directory exists at the path")
+			}
+
+			// Remove the existing file so a symlink is never followed
+			if err := os.Remove(dest); err != nil {
+				return err
+			}
+		}
+
+		f, err := os.OpenFile(dest, os.O_CREATE|os.O_EXCL|os.O_WRONLY, perm)
+		if err != nil {
+			return err
+		}
+		if _, err := io.Copy(f, tr); err != nil {
+			f.Close()
+			return err
+		}
+		return f.Close()
+
+	case tar.TypeSymlink:
+		if existing != nil {
+			if existing.IsDir() {
+				return fmt.Errorf("a directory exists at the path")
+			}
+			if err := os.Remove(dest); err != nil {
+				return err
+			}
+		}
+		return os.Symlink(hdr.Linkname, dest)
+
+	default:
+		return fmt.Errorf("unsupported file type %q", hdr.Typeflag)
+	}
+}
@@ -104,6 +104,11 @@ type allocRunner struct {
 	state     *state.State
 	stateLock sync.RWMutex
 
+	// networkIsolationSpec describes the network namespace of the alloc, if
+	// any. It is set by the network hook.
+	networkIsolationSpec     *drivers.NetworkIsolationSpec
+	networkIsolationSpecLock sync.RWMutex
+
 	stateDB cstate.StateDB
 
 	// allocDir is used to build the allocations directory structure.
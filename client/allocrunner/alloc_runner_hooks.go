@@ -23,6 +23,10 @@ type allocNetworkIsolationSetter struct {
 }
 
 func (a *allocNetworkIsolationSetter) SetNetworkIsolation(n *drivers.NetworkIsolationSpec) {
+	a.ar.networkIsolationSpecLock.Lock()
+	a.ar.networkIsolationSpec = n
+	a.ar.networkIsolationSpecLock.Unlock()
+
 	for _, tr := range a.ar.tasks {
 		tr.SetNetworkIsolation(n)
 	}
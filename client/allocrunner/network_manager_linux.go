@@ -2,8 +2,10 @@ package allocrunner
 
 import (
 	"fmt"
+	"net"
 	"strings"
 
+	"github.com/containernetworking/plugins/pkg/ns"
 	hclog "github.com/hashicorp/go-hclog"
 	clientconfig "github.com/hashicorp/nomad/client/config"
 	"github.com/hashicorp/nomad/client/lib/nsutil"
@@ -136,3 +138,15 @@ func newNetworkConfigurator(log hclog.Logger, alloc *structs.Allocation, config
 		return &hostNetworkConfigurator{}, nil
 	}
 }
+
+// dialNetNS connects to the TCP address from within the network namespace at
+// the given path.
+func dialNetNS(nsPath, address string) (net.Conn, error) {
+	var conn net.Conn
+	err := ns.WithNetNSPath(nsPath, func(ns.NetNS) error {
+		var err error
+		conn, err = net.DialTimeout("tcp", address, portDialTimeout)
+		return err
+	})
+	return conn, err
+}
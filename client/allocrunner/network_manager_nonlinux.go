@@ -3,6 +3,9 @@
 package allocrunner
 
 import (
+	"fmt"
+	"net"
+
 	hclog "github.com/hashicorp/go-hclog"
 	clientconfig "github.com/hashicorp/nomad/client/config"
 	"github.com/hashicorp/nomad/client/pluginmanager/drivermanager"
@@ -18,3 +21,7 @@ func newNetworkManager(alloc *structs.Allocation, driverManager drivermanager.Ma
 func newNetworkConfigurator(log hclog.Logger, alloc *structs.Allocation, config *clientconfig.Config) (NetworkConfigurator, error) {
 	return &hostNetworkConfigurator{}, nil
 }
+
+func dialNetNS(nsPath, address string) (net.Conn, error) {
+	return nil, fmt.Errorf("network namespaces are not supported on this platform")
+}
@@ -0,0 +1,112 @@
+package allocrunner
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// portDialTimeout is the timeout for connecting to a port of an allocation
+const portDialTimeout = 10 * time.Second
+
+// DialPort connects to a TCP port of the allocation. The port is either the
+// label or the number of a port. If the allocation has a network namespace
+// the connection is made to the loopback address inside the namespace,
+// otherwise the port must be allocated to the allocation and is dialed on the
+// host.
+func (ar *allocRunner) DialPort(port string) (net.Conn, error) {
+	ar.networkIsolationSpecLock.RLock()
+	spec := ar.networkIsolationSpec
+	ar.networkIsolationSpecLock.RUnlock()
+
+	isolated := spec != nil && spec.Path != ""
+	ip, portNum, err := lookupAllocPort(ar.Alloc(), port, isolated)
+	if err != nil {
+		return nil, err
+	}
+
+	if isolated {
+		return dialNetNS(spec.Path, net.JoinHostPort("127.0.0.1", strconv.Itoa(portNum)))
+	}
+	return net.DialTimeout("tcp", net.JoinHostPort(ip, strconv.Itoa(portNum)), portDialTimeout)
+}
+
+// lookupAllocPort returns the address to dial for the port label or number
+// of the allocation. Ports of isolated allocations are returned without an IP
+// as they are dialed inside the network namespace of the allocation.
+func lookupAllocPort(alloc *structs.Allocation, port string, isolated bool) (string, int, error) {
+	networks := allocNetworks(alloc)
+
+	// Look for a port with the label
+	for _, n := range networks {
+		for _, p := range append(n.ReservedPorts, n.DynamicPorts...) {
+			if p.Label != port {
+				continue
+			}
+			if !isolated {
+				return n.IP, p.Value, nil
+			}
+			if p.To > 0 {
+				return "", p.To, nil
+			}
+			return "", p.Value, nil
+		}
+	}
+
+	portNum, err := strconv.Atoi(port)
+	if err != nil {
+		return "", 0, fmt.Errorf("unknown port label %q", port)
+	}
+	if portNum < 1 || portNum > 65535 {
+		return "", 0, fmt.Errorf("invalid port %d", portNum)
+	}
+
+	// Any port of the network namespace can be dialed
+	if isolated {
+		return "", portNum, nil
+	}
+
+	// Host ports must be allocated to the allocation
+	for _, n := range networks {
+		for _, p := range append(n.ReservedPorts, n.DynamicPorts...) {
+			if p.Value == portNum {
+				return n.IP, p.Value, nil
+			}
+		}
+	}
+	return "", 0, fmt.Errorf("port %d is not allocated to the allocation", portNum)
+}
+
+// allocNetworks returns the shared networks of the allocation followed by
+// the networks of its tasks, ordered by task name.
+func allocNetworks(alloc *structs.Allocation) []*structs.NetworkResource {
+	var networks []*structs.NetworkResource
+	if ar := alloc.AllocatedResources; ar != nil {
+		networks = append(networks, ar.Shared.Networks...)
+
+		tasks := make([]string, 0, len(ar.Tasks))
+		for name := range ar.Tasks {
+			tasks = append(tasks, name)
+		}
+		sort.Strings(tasks)
+		for _, name := range tasks {
+			networks = append(networks, ar.Tasks[name].Networks...)
+		}
+		return networks
+	}
+
+	// COMPAT(0.11): Remove in 0.11
+	tasks := make([]string, 0, len(alloc.TaskResources))
+	for name := range alloc.TaskResources {
+		tasks = append(tasks, name)
+	}
+	sort.Strings(tasks)
+	for _, name := range tasks {
+		networks = append(networks, alloc.TaskResources[name].Networks...)
+	}
+	return networks
+}
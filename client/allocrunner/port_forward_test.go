@@ -0,0 +1,55 @@
+package allocrunner
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLookupAllocPort(t *testing.T) {
+	t.Parallel()
+
+	alloc := mock.Alloc()
+	alloc.AllocatedResources.Shared.Networks = []*structs.NetworkResource{
+		{
+			IP:            "10.0.0.1",
+			ReservedPorts: []structs.Port{{Label: "admin", Value: 8000}},
+			DynamicPorts:  []structs.Port{{Label: "db", Value: 23456, To: 5432}},
+		},
+	}
+
+	cases := []struct {
+		name     string
+		port     string
+		isolated bool
+		ip       string
+		portNum  int
+		err      string
+	}{
+		{name: "host label", port: "db", ip: "10.0.0.1", portNum: 23456},
+		{name: "host task label", port: "http", ip: "192.168.0.100", portNum: 9876},
+		{name: "host number", port: "8000", ip: "10.0.0.1", portNum: 8000},
+		{name: "host unallocated number", port: "5432", err: "port 5432 is not allocated"},
+		{name: "isolated mapped label", port: "db", isolated: true, portNum: 5432},
+		{name: "isolated unmapped label", port: "admin", isolated: true, portNum: 8000},
+		{name: "isolated number", port: "5432", isolated: true, portNum: 5432},
+		{name: "unknown label", port: "web", err: `unknown port label "web"`},
+		{name: "invalid number", port: "70000", isolated: true, err: "invalid port 70000"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			ip, portNum, err := lookupAllocPort(alloc, c.port, c.isolated)
+			if c.err != "" {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), c.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, c.ip, ip)
+			require.Equal(t, c.portNum, portNum)
+		})
+	}
+}
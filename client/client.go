@@ -146,6 +146,8 @@ type AllocRunner interface {
 
 	GetTaskExecHandler(taskName string) drivermanager.TaskExecHandler
 	GetTaskDriverCapabilities(taskName string) (*drivers.Capabilities, error)
+
+	DialPort(port string) (net.Conn, error)
 }
 
 // Client is used to implement the client interaction with Nomad. Clients
@@ -1721,6 +1723,10 @@ func (c *Client) updateNodeStatus() error {
 		if haveHeartbeated {
 			c.logger.Warn("missed heartbeat",
 				"req_latency", end.Sub(start), "heartbeat_ttl", oldTTL, "since_last_heartbeat", time.Since(last))
+
+			// The servers may have marked our allocations as unknown while
+			// the node was disconnected so resend their current state.
+			go c.resendAllocStates()
 		}
 	}
 
@@ -1790,6 +1796,33 @@ func (c *Client) AllocStateUpdated(alloc *structs.Allocation) {
 	}
 }
 
+// resendAllocStates sends the current state of every allocation to the
+// servers. It is used when the node reconnects after missing its heartbeats.
+func (c *Client) resendAllocStates() {
+	c.allocLock.RLock()
+	runners := make([]AllocRunner, 0, len(c.allocs))
+	for _, ar := range c.allocs {
+		runners = append(runners, ar)
+	}
+	c.allocLock.RUnlock()
+
+	for _, ar := range runners {
+		if ar.IsDestroyed() {
+			continue
+		}
+
+		state := ar.AllocState()
+		alloc := &structs.Allocation{
+			ID:                ar.Alloc().ID,
+			TaskStates:        state.TaskStates,
+			ClientStatus:      state.ClientStatus,
+			ClientDescription: state.ClientDescription,
+			DeploymentStatus:  state.DeploymentStatus,
+		}
+		c.AllocStateUpdated(alloc)
+	}
+}
+
 // allocSync is a long lived function that batches allocation updates to the
 // server.
 func (c *Client) allocSync() {
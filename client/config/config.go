@@ -8,9 +8,9 @@ import (
 	"strings"
 	"time"
 
-	log "github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/nomad/client/state"
 	"github.com/hashicorp/nomad/helper"
+	"github.com/hashicorp/nomad/helper/logging"
 	"github.com/hashicorp/nomad/helper/pluginutils/loader"
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/hashicorp/nomad/nomad/structs/config"
@@ -67,6 +67,10 @@ type RPCHandler interface {
 
 // Config is used to parameterize and configure the behavior of the client
 type Config struct {
+	// EnableDebug is used to enable debugging RPC endpoints
+	// in the absence of ACLs
+	EnableDebug bool
+
 	// DevMode controls if we are in a development mode which
 	// avoids persistent storage.
 	DevMode bool
@@ -81,7 +85,7 @@ type Config struct {
 	LogOutput io.Writer
 
 	// Logger provides a logger to thhe client
-	Logger log.Logger
+	Logger logging.InterceptLogger
 
 	// Region is the clients region
 	Region string
@@ -1,6 +1,7 @@
 package client
 
 import (
+	"bufio"
 	"bytes"
 	"context"
 	"fmt"
@@ -30,6 +31,7 @@ var (
 	allocIDNotPresentErr = fmt.Errorf("must provide a valid alloc id")
 	pathNotPresentErr    = fmt.Errorf("must provide a file path")
 	taskNotPresentErr    = fmt.Errorf("must provide task name")
+	portNotPresentErr    = fmt.Errorf("must provide a port")
 	logTypeNotPresentErr = fmt.Errorf("must provide log type (stdout/stderr)")
 	invalidOrigin        = fmt.Errorf("origin must be start or end")
 )
@@ -78,6 +80,8 @@ func NewFileSystemEndpoint(c *Client) *FileSystem {
 	f := &FileSystem{c}
 	f.c.streamingRpcs.Register("FileSystem.Logs", f.logs)
 	f.c.streamingRpcs.Register("FileSystem.Stream", f.stream)
+	f.c.streamingRpcs.Register("FileSystem.Download", f.download)
+	f.c.streamingRpcs.Register("FileSystem.Upload", f.upload)
 	return f
 }
 
@@ -102,8 +106,8 @@ func (f *FileSystem) List(args *cstructs.FsListRequest, reply *cstructs.FsListRe
 	// Check read permissions
 	if aclObj, err := f.c.ResolveToken(args.QueryOptions.AuthToken); err != nil {
 		return err
-	} else if aclObj != nil && !aclObj.AllowNsOp(args.Namespace, acl.NamespaceCapabilityReadFS) {
-		return structs.ErrPermissionDenied
+	} else if err := f.c.checkAllocNsOp(aclObj, args.AllocID, args.Namespace, acl.NamespaceCapabilityReadFS); err != nil {
+		return err
 	}
 
 	fs, err := f.c.GetAllocFS(args.AllocID)
@@ -126,8 +130,8 @@ func (f *FileSystem) Stat(args *cstructs.FsStatRequest, reply *cstructs.FsStatRe
 	// Check read permissions
 	if aclObj, err := f.c.ResolveToken(args.QueryOptions.AuthToken); err != nil {
 		return err
-	} else if aclObj != nil && !aclObj.AllowNsOp(args.Namespace, acl.NamespaceCapabilityReadFS) {
-		return structs.ErrPermissionDenied
+	} else if err := f.c.checkAllocNsOp(aclObj, args.AllocID, args.Namespace, acl.NamespaceCapabilityReadFS); err != nil {
+		return err
 	}
 
 	fs, err := f.c.GetAllocFS(args.AllocID)
@@ -163,8 +167,8 @@ func (f *FileSystem) stream(conn io.ReadWriteCloser) {
 	if aclObj, err := f.c.ResolveToken(req.QueryOptions.AuthToken); err != nil {
 		handleStreamResultError(err, helper.Int64ToPtr(403), encoder)
 		return
-	} else if aclObj != nil && !aclObj.AllowNsOp(req.Namespace, acl.NamespaceCapabilityReadFS) {
-		handleStreamResultError(structs.ErrPermissionDenied, helper.Int64ToPtr(403), encoder)
+	} else if err := f.c.checkAllocNsOp(aclObj, req.AllocID, req.Namespace, acl.NamespaceCapabilityReadFS); err != nil {
+		handleStreamResultError(err, helper.Int64ToPtr(403), encoder)
 		return
 	}
 
@@ -317,6 +321,167 @@ OUTER:
 	}
 }
 
+// download is used to stream a tar archive of a file or directory in an
+// allocation's directory.
+func (f *FileSystem) download(conn io.ReadWriteCloser) {
+	defer metrics.MeasureSince([]string{"client", "file_system", "download"}, time.Now())
+	defer conn.Close()
+
+	// Decode the arguments
+	var req cstructs.FsDownloadRequest
+	decoder := codec.NewDecoder(conn, structs.MsgpackHandle)
+	encoder := codec.NewEncoder(conn, structs.MsgpackHandle)
+
+	if err := decoder.Decode(&req); err != nil {
+		handleStreamResultError(err, helper.Int64ToPtr(500), encoder)
+		return
+	}
+
+	// Check read permissions
+	if aclObj, err := f.c.ResolveToken(req.QueryOptions.AuthToken); err != nil {
+		handleStreamResultError(err, helper.Int64ToPtr(403), encoder)
+		return
+	} else if err := f.c.checkAllocNsOp(aclObj, req.AllocID, req.Namespace, acl.NamespaceCapabilityReadFS); err != nil {
+		handleStreamResultError(err, helper.Int64ToPtr(403), encoder)
+		return
+	}
+
+	// Validate the arguments
+	if req.AllocID == "" {
+		handleStreamResultError(allocIDNotPresentErr, helper.Int64ToPtr(400), encoder)
+		return
+	}
+	if req.Path == "" {
+		handleStreamResultError(pathNotPresentErr, helper.Int64ToPtr(400), encoder)
+		return
+	}
+
+	fs, err := f.c.GetAllocFS(req.AllocID)
+	if err != nil {
+		code := helper.Int64ToPtr(500)
+		if structs.IsErrUnknownAllocation(err) {
+			code = helper.Int64ToPtr(404)
+		}
+
+		handleStreamResultError(err, code, encoder)
+		return
+	}
+
+	// Batch the writes of the archive into frames
+	w := bufio.NewWriterSize(&streamPayloadWriter{encoder}, streamFrameSize)
+	if err := fs.ReadTar(req.Path, w); err != nil {
+		handleStreamResultError(err, fsErrorCode(err), encoder)
+		return
+	}
+	if err := w.Flush(); err != nil {
+		handleStreamResultError(err, helper.Int64ToPtr(500), encoder)
+	}
+}
+
+// upload is used to extract a tar archive streamed by the caller into a
+// directory of an allocation. The result is sent once the archive has been
+// extracted.
+func (f *FileSystem) upload(conn io.ReadWriteCloser) {
+	defer metrics.MeasureSince([]string{"client", "file_system", "upload"}, time.Now())
+	defer conn.Close()
+
+	// Decode the arguments
+	var req cstructs.FsUploadRequest
+	decoder := codec.NewDecoder(conn, structs.MsgpackHandle)
+	encoder := codec.NewEncoder(conn, structs.MsgpackHandle)
+
+	if err := decoder.Decode(&req); err != nil {
+		handleStreamResultError(err, helper.Int64ToPtr(500), encoder)
+		return
+	}
+
+	// Check write permissions
+	if aclObj, err := f.c.ResolveToken(req.QueryOptions.AuthToken); err != nil {
+		handleStreamResultError(err, helper.Int64ToPtr(403), encoder)
+		return
+	} else if err := f.c.checkAllocNsOp(aclObj, req.AllocID, req.Namespace, acl.NamespaceCapabilityAllocWriteFS); err != nil {
+		handleStreamResultError(err, helper.Int64ToPtr(403), encoder)
+		return
+	}
+
+	// Validate the arguments
+	if req.AllocID == "" {
+		handleStreamResultError(allocIDNotPresentErr, helper.Int64ToPtr(400), encoder)
+		return
+	}
+	if req.Path == "" {
+		handleStreamResultError(pathNotPresentErr, helper.Int64ToPtr(400), encoder)
+		return
+	}
+
+	fs, err := f.c.GetAllocFS(req.AllocID)
+	if err != nil {
+		code := helper.Int64ToPtr(500)
+		if structs.IsErrUnknownAllocation(err) {
+			code = helper.Int64ToPtr(404)
+		}
+
+		handleStreamResultError(err, code, encoder)
+		return
+	}
+
+	// Feed the archive carried by the frames to the extraction
+	pr, pw := io.Pipe()
+	go func() {
+		for {
+			var frame cstructs.FsUploadFrame
+			if err := decoder.Decode(&frame); err != nil {
+				// The archive must be terminated by an EOF frame
+				if err == io.EOF {
+					err = io.ErrUnexpectedEOF
+				}
+				pw.CloseWithError(err)
+				return
+			}
+
+			if len(frame.Data) > 0 {
+				if _, err := pw.Write(frame.Data); err != nil {
+					return
+				}
+			}
+			if frame.EOF {
+				pw.Close()
+				return
+			}
+		}
+	}()
+
+	err = fs.WriteTar(req.Path, pr)
+	pr.Close()
+	if err != nil {
+		handleStreamResultError(err, fsErrorCode(err), encoder)
+		return
+	}
+
+	encoder.Encode(&cstructs.StreamErrWrapper{})
+}
+
+// streamPayloadWriter writes the data it is given as the payload of
+// StreamErrWrappers.
+type streamPayloadWriter struct {
+	encoder *codec.Encoder
+}
+
+func (w *streamPayloadWriter) Write(p []byte) (int, error) {
+	if err := w.encoder.Encode(&cstructs.StreamErrWrapper{Payload: p}); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// fsErrorCode returns the code for an error accessing the alloc dir.
+func fsErrorCode(err error) *int64 {
+	if os.IsNotExist(err) {
+		return helper.Int64ToPtr(404)
+	}
+	return helper.Int64ToPtr(400)
+}
+
 // logs is is used to stream a task's logs.
 func (f *FileSystem) logs(conn io.ReadWriteCloser) {
 	defer metrics.MeasureSince([]string{"client", "file_system", "logs"}, time.Now())
@@ -336,13 +501,10 @@ func (f *FileSystem) logs(conn io.ReadWriteCloser) {
 	if aclObj, err := f.c.ResolveToken(req.QueryOptions.AuthToken); err != nil {
 		handleStreamResultError(err, nil, encoder)
 		return
-	} else if aclObj != nil {
-		readfs := aclObj.AllowNsOp(req.QueryOptions.Namespace, acl.NamespaceCapabilityReadFS)
-		logs := aclObj.AllowNsOp(req.QueryOptions.Namespace, acl.NamespaceCapabilityReadLogs)
-		if !readfs && !logs {
-			handleStreamResultError(structs.ErrPermissionDenied, nil, encoder)
-			return
-		}
+	} else if err := f.c.checkAllocNsOp(aclObj, req.AllocID, req.QueryOptions.Namespace,
+		acl.NamespaceCapabilityReadFS, acl.NamespaceCapabilityReadLogs); err != nil {
+		handleStreamResultError(err, nil, encoder)
+		return
 	}
 
 	// Validate the arguments
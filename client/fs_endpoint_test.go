@@ -1,6 +1,8 @@
 package client
 
 import (
+	"archive/tar"
+	"bytes"
 	"context"
 	"fmt"
 	"io"
@@ -178,6 +180,98 @@ func TestFS_Stat_ACL(t *testing.T) {
 	}
 }
 
+// TestFS_CrossNamespace_ACL asserts that the file system operations on an
+// allocation are authorized against the namespace of the allocation.
+func TestFS_CrossNamespace_ACL(t *testing.T) {
+	t.Parallel()
+	s, root := nomad.TestACLServer(t, nil)
+	defer s.Shutdown()
+	testutil.WaitForLeader(t, s.RPC)
+
+	client, cleanup := TestClient(t, func(c *config.Config) {
+		c.ACLEnabled = true
+		c.Servers = []string{s.GetConfig().RPCAddr.String()}
+	})
+	defer cleanup()
+
+	alloc := testRunAllocInNamespace(t, s, root.SecretID)
+
+	caps := []string{acl.NamespaceCapabilityReadFS, acl.NamespaceCapabilityReadLogs,
+		acl.NamespaceCapabilityAllocWriteFS}
+	defaultToken := mock.CreatePolicyAndToken(t, s.State(), 1005, "default",
+		mock.NamespacePolicy(structs.DefaultNamespace, "", caps))
+	allocToken := mock.CreatePolicyAndToken(t, s.State(), 1007, "alloc",
+		mock.NamespacePolicy(alloc.Namespace, "", caps))
+
+	qo := func(token string) structs.QueryOptions {
+		return structs.QueryOptions{
+			Region:    "global",
+			Namespace: structs.DefaultNamespace,
+			AuthToken: token,
+		}
+	}
+
+	// A token of the namespace of the request is denied, while a token of
+	// the namespace of the allocation is allowed
+	var listResp cstructs.FsListResponse
+	listReq := &cstructs.FsListRequest{AllocID: alloc.ID, Path: "/", QueryOptions: qo(defaultToken.SecretID)}
+	require.EqualError(t, client.ClientRPC("FileSystem.List", listReq, &listResp), structs.ErrPermissionDenied.Error())
+	listReq.QueryOptions = qo(allocToken.SecretID)
+	require.NoError(t, client.ClientRPC("FileSystem.List", listReq, &listResp))
+
+	var statResp cstructs.FsStatResponse
+	statReq := &cstructs.FsStatRequest{AllocID: alloc.ID, Path: "/", QueryOptions: qo(defaultToken.SecretID)}
+	require.EqualError(t, client.ClientRPC("FileSystem.Stat", statReq, &statResp), structs.ErrPermissionDenied.Error())
+	statReq.QueryOptions = qo(allocToken.SecretID)
+	require.NoError(t, client.ClientRPC("FileSystem.Stat", statReq, &statResp))
+
+	streams := []struct {
+		Method string
+		Req    func(token string) interface{}
+		Input  []interface{}
+	}{
+		{
+			Method: "FileSystem.Stream",
+			Req: func(token string) interface{} {
+				return &cstructs.FsStreamRequest{AllocID: alloc.ID, Path: "alloc/logs", QueryOptions: qo(token)}
+			},
+		},
+		{
+			Method: "FileSystem.Logs",
+			Req: func(token string) interface{} {
+				return &cstructs.FsLogsRequest{AllocID: alloc.ID, Task: "web", LogType: "stdout", QueryOptions: qo(token)}
+			},
+		},
+		{
+			Method: "FileSystem.Download",
+			Req: func(token string) interface{} {
+				return &cstructs.FsDownloadRequest{AllocID: alloc.ID, Path: "alloc/logs", QueryOptions: qo(token)}
+			},
+		},
+		{
+			Method: "FileSystem.Upload",
+			Req: func(token string) interface{} {
+				return &cstructs.FsUploadRequest{AllocID: alloc.ID, Path: "alloc/data", QueryOptions: qo(token)}
+			},
+			Input: []interface{}{&cstructs.FsUploadFrame{EOF: true}},
+		},
+	}
+	for _, c := range streams {
+		t.Run(c.Method, func(t *testing.T) {
+			msgs := fsStreamingRpc(t, client, c.Method, c.Req(defaultToken.SecretID), c.Input...)
+			require.Len(t, msgs, 1)
+			require.NotNil(t, msgs[0].Error)
+			require.Contains(t, msgs[0].Error.Error(), structs.ErrPermissionDenied.Error())
+
+			for _, msg := range fsStreamingRpc(t, client, c.Method, c.Req(allocToken.SecretID), c.Input...) {
+				if msg.Error != nil {
+					require.NotContains(t, msg.Error.Error(), structs.ErrPermissionDenied.Error())
+				}
+			}
+		})
+	}
+}
+
 func TestFS_List_NoAlloc(t *testing.T) {
 	t.Parallel()
 	require := require.New(t)
@@ -803,6 +897,247 @@ OUTER:
 	}
 }
 
+// fsStreamingRpc makes the streaming RPC by sending the request followed by
+// the input messages and returns the messages received until the handler
+// closes the connection.
+func fsStreamingRpc(t *testing.T, c *Client, method string, req interface{}, input ...interface{}) []*cstructs.StreamErrWrapper {
+	handler, err := c.StreamingRpcHandler(method)
+	require.NoError(t, err)
+
+	// Create a pipe
+	p1, p2 := net.Pipe()
+	defer p1.Close()
+
+	// Start the handler
+	go handler(p2)
+
+	// Send the request and input
+	go func() {
+		encoder := codec.NewEncoder(p1, structs.MsgpackHandle)
+		if err := encoder.Encode(req); err != nil {
+			return
+		}
+		for _, in := range input {
+			if err := encoder.Encode(in); err != nil {
+				return
+			}
+		}
+	}()
+
+	var msgs []*cstructs.StreamErrWrapper
+	doneCh := make(chan struct{})
+	go func() {
+		defer close(doneCh)
+		decoder := codec.NewDecoder(p1, structs.MsgpackHandle)
+		for {
+			var msg cstructs.StreamErrWrapper
+			if err := decoder.Decode(&msg); err != nil {
+				return
+			}
+			msgs = append(msgs, &msg)
+		}
+	}()
+
+	select {
+	case <-doneCh:
+	case <-time.After(10 * time.Second):
+		t.Fatal("timeout")
+	}
+	return msgs
+}
+
+func TestFS_UploadDownload(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	// Start a server and client
+	s := nomad.TestServer(t, nil)
+	defer s.Shutdown()
+	testutil.WaitForLeader(t, s.RPC)
+
+	c, cleanup := TestClient(t, func(c *config.Config) {
+		c.Servers = []string{s.GetConfig().RPCAddr.String()}
+	})
+	defer cleanup()
+
+	job := mock.BatchJob()
+	job.TaskGroups[0].Count = 1
+	job.TaskGroups[0].Tasks[0].Config = map[string]interface{}{
+		"run_for": "10s",
+	}
+
+	// Wait for alloc to be running
+	alloc := testutil.WaitForRunning(t, s.RPC, job)[0]
+	qo := structs.QueryOptions{Region: "global"}
+
+	// Create an archive with a directory and a file
+	expected := "Hello from the other side"
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	require.NoError(tw.WriteHeader(&tar.Header{Name: "dump", Mode: 0755, Typeflag: tar.TypeDir}))
+	require.NoError(tw.WriteHeader(&tar.Header{Name: "dump/heap", Mode: 0644, Size: int64(len(expected)), Typeflag: tar.TypeReg}))
+	_, err := tw.Write([]byte(expected))
+	require.NoError(err)
+	require.NoError(tw.Close())
+
+	// Upload the archive in two frames
+	archive := buf.Bytes()
+	msgs := fsStreamingRpc(t, c, "FileSystem.Upload", &cstructs.FsUploadRequest{
+		AllocID:      alloc.ID,
+		Path:         "alloc/data",
+		QueryOptions: qo,
+	},
+		&cstructs.FsUploadFrame{Data: archive[:100]},
+		&cstructs.FsUploadFrame{Data: archive[100:]},
+		&cstructs.FsUploadFrame{EOF: true})
+	require.Len(msgs, 1)
+	require.Nil(msgs[0].Error)
+
+	fs, err := c.GetAllocFS(alloc.ID)
+	require.NoError(err)
+	info, err := fs.Stat("alloc/data/dump/heap")
+	require.NoError(err)
+	require.EqualValues(len(expected), info.Size)
+
+	// Download the directory
+	msgs = fsStreamingRpc(t, c, "FileSystem.Download", &cstructs.FsDownloadRequest{
+		AllocID:      alloc.ID,
+		Path:         "alloc/data/dump",
+		QueryOptions: qo,
+	})
+
+	var out bytes.Buffer
+	for _, msg := range msgs {
+		require.Nil(msg.Error)
+		out.Write(msg.Payload)
+	}
+
+	files := make(map[string]string)
+	tr := tar.NewReader(&out)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(err)
+
+		data, err := ioutil.ReadAll(tr)
+		require.NoError(err)
+		files[hdr.Name] = string(data)
+	}
+	require.Equal(map[string]string{"dump": "", "dump/heap": expected}, files)
+
+	// Downloading secrets fails
+	msgs = fsStreamingRpc(t, c, "FileSystem.Download", &cstructs.FsDownloadRequest{
+		AllocID:      alloc.ID,
+		Path:         "web/secrets",
+		QueryOptions: qo,
+	})
+	require.Len(msgs, 1)
+	require.NotNil(msgs[0].Error)
+	require.Contains(msgs[0].Error.Error(), "secret file prohibited")
+	require.EqualValues(400, *msgs[0].Error.Code)
+
+	// Uploading outside of the alloc dir fails
+	msgs = fsStreamingRpc(t, c, "FileSystem.Upload", &cstructs.FsUploadRequest{
+		AllocID:      alloc.ID,
+		Path:         "../",
+		QueryOptions: qo,
+	}, &cstructs.FsUploadFrame{EOF: true})
+	require.Len(msgs, 1)
+	require.NotNil(msgs[0].Error)
+	require.Contains(msgs[0].Error.Error(), "escapes")
+
+	// Uploading a truncated archive fails
+	msgs = fsStreamingRpc(t, c, "FileSystem.Upload", &cstructs.FsUploadRequest{
+		AllocID:      alloc.ID,
+		Path:         "alloc/data",
+		QueryOptions: qo,
+	}, &cstructs.FsUploadFrame{Data: archive[:600]}, &cstructs.FsUploadFrame{EOF: true})
+	require.Len(msgs, 1)
+	require.NotNil(msgs[0].Error)
+	require.Contains(msgs[0].Error.Error(), "unexpected EOF")
+}
+
+func TestFS_UploadDownload_ACL(t *testing.T) {
+	t.Parallel()
+
+	// Start a server
+	s, root := nomad.TestACLServer(t, nil)
+	defer s.Shutdown()
+	testutil.WaitForLeader(t, s.RPC)
+
+	client, cleanup := TestClient(t, func(c *config.Config) {
+		c.ACLEnabled = true
+		c.Servers = []string{s.GetConfig().RPCAddr.String()}
+	})
+	defer cleanup()
+
+	policyRead := mock.NamespacePolicy(structs.DefaultNamespace, "", []string{acl.NamespaceCapabilityReadFS})
+	tokenRead := mock.CreatePolicyAndToken(t, s.State(), 1005, "read", policyRead)
+
+	policyWrite := mock.NamespacePolicy(structs.DefaultNamespace, "", []string{acl.NamespaceCapabilityAllocWriteFS})
+	tokenWrite := mock.CreatePolicyAndToken(t, s.State(), 1009, "write", policyWrite)
+
+	cases := []struct {
+		Name          string
+		Method        string
+		Token         string
+		ExpectedError string
+	}{
+		{
+			Name:          "download without read-fs",
+			Method:        "FileSystem.Download",
+			Token:         tokenWrite.SecretID,
+			ExpectedError: structs.ErrPermissionDenied.Error(),
+		},
+		{
+			Name:          "download with read-fs",
+			Method:        "FileSystem.Download",
+			Token:         tokenRead.SecretID,
+			ExpectedError: structs.ErrUnknownAllocationPrefix,
+		},
+		{
+			Name:          "upload without alloc-write-fs",
+			Method:        "FileSystem.Upload",
+			Token:         tokenRead.SecretID,
+			ExpectedError: structs.ErrPermissionDenied.Error(),
+		},
+		{
+			Name:          "upload with alloc-write-fs",
+			Method:        "FileSystem.Upload",
+			Token:         tokenWrite.SecretID,
+			ExpectedError: structs.ErrUnknownAllocationPrefix,
+		},
+		{
+			Name:          "upload with root token",
+			Method:        "FileSystem.Upload",
+			Token:         root.SecretID,
+			ExpectedError: structs.ErrUnknownAllocationPrefix,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			// Make the request with bad allocation id
+			qo := structs.QueryOptions{
+				Namespace: structs.DefaultNamespace,
+				Region:    "global",
+				AuthToken: c.Token,
+			}
+			var req interface{} = &cstructs.FsDownloadRequest{AllocID: uuid.Generate(), Path: "foo", QueryOptions: qo}
+			if c.Method == "FileSystem.Upload" {
+				req = &cstructs.FsUploadRequest{AllocID: uuid.Generate(), Path: "foo", QueryOptions: qo}
+			}
+
+			msgs := fsStreamingRpc(t, client, c.Method, req)
+			require.Len(t, msgs, 1)
+			require.NotNil(t, msgs[0].Error)
+			require.Contains(t, msgs[0].Error.Error(), c.ExpectedError)
+		})
+	}
+}
+
 func TestFS_Logs_NoAlloc(t *testing.T) {
 	t.Parallel()
 	require := require.New(t)
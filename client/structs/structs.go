@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/hashicorp/nomad/client/stats"
+	"github.com/hashicorp/nomad/command/agent/pprof"
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/hashicorp/nomad/plugins/device"
 )
@@ -109,6 +110,38 @@ type FsStreamRequest struct {
 	structs.QueryOptions
 }
 
+// FsDownloadRequest is the initial request for downloading a file or
+// directory of an allocation as a tar archive.
+type FsDownloadRequest struct {
+	// AllocID is the allocation to download from
+	AllocID string
+
+	// Path is the path to the file or directory to download
+	Path string
+
+	structs.QueryOptions
+}
+
+// FsUploadRequest is the initial request for uploading a tar archive into a
+// directory of an allocation. It is followed by FsUploadFrames carrying the
+// archive.
+type FsUploadRequest struct {
+	// AllocID is the allocation to upload to
+	AllocID string
+
+	// Path is the path to the directory to extract the archive into
+	Path string
+
+	structs.QueryOptions
+}
+
+// FsUploadFrame carries a chunk of the archive being uploaded. A frame with
+// EOF set marks the end of the archive.
+type FsUploadFrame struct {
+	Data []byte
+	EOF  bool
+}
+
 // FsLogsRequest is the initial request for accessing allocation logs.
 type FsLogsRequest struct {
 	// AllocID is the allocation to stream logs from
@@ -136,6 +169,64 @@ type FsLogsRequest struct {
 	structs.QueryOptions
 }
 
+// MonitorRequest is the initial request for streaming the logs of an agent.
+type MonitorRequest struct {
+	// LogLevel is the log level filter we want to stream logs on
+	LogLevel string
+
+	// LogJSON specifies if log format should be unstructured or json
+	LogJSON bool
+
+	// NodeID is the node we want to track the logs of
+	NodeID string
+
+	// ServerID is the server we want to track the logs of
+	ServerID string
+
+	// PlainText disables base64 encoding.
+	PlainText bool
+
+	structs.QueryOptions
+}
+
+// AgentPprofRequest is used to request a runtime profile of an agent.
+type AgentPprofRequest struct {
+	// ReqType is the type of the profile
+	ReqType pprof.ReqType
+
+	// Profile is the name of the profile to look up
+	Profile string
+
+	// Seconds is the duration of CPU profiles and traces
+	Seconds int
+
+	// Debug is the format of looked up profiles, 0 being the binary format
+	Debug int
+
+	// GC runs a garbage collection before taking a heap profile
+	GC bool
+
+	// NodeID is the node we want to profile
+	NodeID string
+
+	// ServerID is the server we want to profile
+	ServerID string
+
+	structs.QueryOptions
+}
+
+// AgentPprofResponse is used to return a runtime profile of an agent.
+type AgentPprofResponse struct {
+	// AgentID is the ID of the profiled agent
+	AgentID string
+
+	// Payload is the profile
+	Payload []byte
+
+	// HTTPHeaders are the headers of the profile when served over HTTP
+	HTTPHeaders map[string]string
+}
+
 // StreamErrWrapper is used to serialize output of a stream of a file or logs.
 type StreamErrWrapper struct {
 	// Error stores any error that may have occurred.
@@ -162,6 +253,26 @@ type AllocExecRequest struct {
 	structs.QueryOptions
 }
 
+// AllocPortForwardRequest is the initial request for forwarding a connection
+// to a port of an Alloc
+type AllocPortForwardRequest struct {
+	// AllocID is the allocation to forward the connection to
+	AllocID string
+
+	// Port is the label or number of the port to connect to
+	Port string
+
+	structs.QueryOptions
+}
+
+// AllocPortForwardFrame carries the data written to a forwarded connection.
+// A frame with Close set signals that the writer closed its side of the
+// connection.
+type AllocPortForwardFrame struct {
+	Data  []byte
+	Close bool
+}
+
 // AllocStatsRequest is used to request the resource usage of a given
 // allocation, potentially filtering by task
 type AllocStatsRequest struct {
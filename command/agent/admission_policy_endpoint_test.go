@@ -0,0 +1,137 @@
+package agent
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTP_AdmissionPolicyList(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	httpTest(t, nil, func(s *TestAgent) {
+		p1 := mock.AdmissionPolicy()
+		p2 := mock.AdmissionPolicy()
+		args := structs.AdmissionPolicyUpsertRequest{
+			Policies:     []*structs.AdmissionPolicy{p1, p2},
+			WriteRequest: structs.WriteRequest{Region: "global"},
+		}
+		var resp structs.GenericResponse
+		require.NoError(s.Agent.RPC("AdmissionPolicy.UpsertPolicies", &args, &resp))
+
+		// Make the HTTP request
+		req, err := http.NewRequest("GET", "/v1/admission/policies", nil)
+		require.NoError(err)
+		respW := httptest.NewRecorder()
+
+		// Make the request
+		obj, err := s.Server.AdmissionPoliciesRequest(respW, req)
+		require.NoError(err)
+
+		// Check for the index
+		require.NotZero(respW.HeaderMap.Get("X-Nomad-Index"))
+		require.Equal("true", respW.HeaderMap.Get("X-Nomad-KnownLeader"))
+
+		// Check the output
+		require.Len(obj.([]*structs.AdmissionPolicyListStub), 2)
+	})
+}
+
+func TestHTTP_AdmissionPolicyQuery(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	httpTest(t, nil, func(s *TestAgent) {
+		p1 := mock.AdmissionPolicy()
+		args := structs.AdmissionPolicyUpsertRequest{
+			Policies:     []*structs.AdmissionPolicy{p1},
+			WriteRequest: structs.WriteRequest{Region: "global"},
+		}
+		var resp structs.GenericResponse
+		require.NoError(s.Agent.RPC("AdmissionPolicy.UpsertPolicies", &args, &resp))
+
+		// Make the HTTP request
+		req, err := http.NewRequest("GET", "/v1/admission/policy/"+p1.Name, nil)
+		require.NoError(err)
+		respW := httptest.NewRecorder()
+
+		// Make the request
+		obj, err := s.Server.AdmissionPolicySpecificRequest(respW, req)
+		require.NoError(err)
+		require.NotZero(respW.HeaderMap.Get("X-Nomad-Index"))
+
+		// Check the output
+		out := obj.(*structs.AdmissionPolicy)
+		require.Equal(p1.Name, out.Name)
+		require.Equal(p1.Policy, out.Policy)
+
+		// Query a missing policy
+		req, err = http.NewRequest("GET", "/v1/admission/policy/missing", nil)
+		require.NoError(err)
+		respW = httptest.NewRecorder()
+		_, err = s.Server.AdmissionPolicySpecificRequest(respW, req)
+		require.Error(err)
+		require.Equal(404, err.(HTTPCodedError).Code())
+	})
+}
+
+func TestHTTP_AdmissionPolicyUpdate(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	httpTest(t, nil, func(s *TestAgent) {
+		p1 := mock.AdmissionPolicy()
+
+		// A name mismatch is rejected
+		req, err := http.NewRequest("PUT", "/v1/admission/policy/other", encodeReq(p1))
+		require.NoError(err)
+		respW := httptest.NewRecorder()
+		_, err = s.Server.AdmissionPolicySpecificRequest(respW, req)
+		require.Error(err)
+		require.Equal(400, err.(HTTPCodedError).Code())
+
+		// Make the HTTP request
+		req, err = http.NewRequest("PUT", "/v1/admission/policy/"+p1.Name, encodeReq(p1))
+		require.NoError(err)
+		respW = httptest.NewRecorder()
+		obj, err := s.Server.AdmissionPolicySpecificRequest(respW, req)
+		require.NoError(err)
+		require.Nil(obj)
+		require.NotZero(respW.HeaderMap.Get("X-Nomad-Index"))
+
+		// Check the policy was created
+		out, err := s.Agent.server.State().AdmissionPolicyByName(nil, p1.Name)
+		require.NoError(err)
+		require.NotNil(out)
+	})
+}
+
+func TestHTTP_AdmissionPolicyDelete(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	httpTest(t, nil, func(s *TestAgent) {
+		p1 := mock.AdmissionPolicy()
+		args := structs.AdmissionPolicyUpsertRequest{
+			Policies:     []*structs.AdmissionPolicy{p1},
+			WriteRequest: structs.WriteRequest{Region: "global"},
+		}
+		var resp structs.GenericResponse
+		require.NoError(s.Agent.RPC("AdmissionPolicy.UpsertPolicies", &args, &resp))
+
+		// Make the HTTP request
+		req, err := http.NewRequest("DELETE", "/v1/admission/policy/"+p1.Name, nil)
+		require.NoError(err)
+		respW := httptest.NewRecorder()
+		obj, err := s.Server.AdmissionPolicySpecificRequest(respW, req)
+		require.NoError(err)
+		require.Nil(obj)
+		require.NotZero(respW.HeaderMap.Get("X-Nomad-Index"))
+
+		// Check the policy was deleted
+		out, err := s.Agent.server.State().AdmissionPolicyByName(nil, p1.Name)
+		require.NoError(err)
+		require.Nil(out)
+	})
+}
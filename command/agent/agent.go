@@ -23,6 +23,7 @@ import (
 	clientconfig "github.com/hashicorp/nomad/client/config"
 	"github.com/hashicorp/nomad/client/state"
 	"github.com/hashicorp/nomad/command/agent/consul"
+	"github.com/hashicorp/nomad/helper/logging"
 	"github.com/hashicorp/nomad/helper/pluginutils/loader"
 	"github.com/hashicorp/nomad/helper/uuid"
 	"github.com/hashicorp/nomad/nomad"
@@ -53,7 +54,7 @@ type Agent struct {
 	config     *Config
 	configLock sync.Mutex
 
-	logger     log.Logger
+	logger     logging.InterceptLogger
 	httpLogger log.Logger
 	logOutput  io.Writer
 
@@ -87,7 +88,7 @@ type Agent struct {
 }
 
 // NewAgent is used to create a new agent with the given configuration
-func NewAgent(config *Config, logger log.Logger, logOutput io.Writer, inmem *metrics.InmemSink) (*Agent, error) {
+func NewAgent(config *Config, logger logging.InterceptLogger, logOutput io.Writer, inmem *metrics.InmemSink) (*Agent, error) {
 	a := &Agent{
 		config:     config,
 		logOutput:  logOutput,
@@ -132,6 +133,7 @@ func convertServerConfig(agentConfig *Config) (*nomad.Config, error) {
 		conf = nomad.DefaultConfig()
 	}
 	conf.DevMode = agentConfig.DevMode
+	conf.EnableDebug = agentConfig.EnableDebug
 	conf.Build = agentConfig.Version.VersionNumber()
 	if agentConfig.Region != "" {
 		conf.Region = agentConfig.Region
@@ -199,6 +201,11 @@ func convertServerConfig(agentConfig *Config) (*nomad.Config, error) {
 		conf.SentinelConfig = agentConfig.Sentinel
 	}
 	if agentConfig.Server.NonVotingServer {
+		// Non-voters can only be added to the cluster by servers speaking
+		// Raft protocol version 3
+		if agentConfig.Server.RaftProtocol != 0 && agentConfig.Server.RaftProtocol < 3 {
+			return nil, fmt.Errorf("non_voting_server requires raft_protocol 3 or later")
+		}
 		conf.NonVoter = true
 	}
 	if agentConfig.Server.RedundancyZone != "" {
@@ -291,6 +298,21 @@ func convertServerConfig(agentConfig *Config) (*nomad.Config, error) {
 		}
 		conf.JobGCThreshold = dur
 	}
+	if retention := agentConfig.Server.DispatchTokenRetention; retention != "" {
+		dur, err := time.ParseDuration(retention)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse dispatch_token_retention: %v", err)
+		} else if dur <= time.Duration(0) {
+			return nil, fmt.Errorf("dispatch_token_retention should be greater than 0s")
+		}
+		conf.DispatchTokenRetention = dur
+	}
+	for _, w := range agentConfig.Server.AdmissionWebhooks {
+		if err := w.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid admission_webhook %q: %v", w.Name, err)
+		}
+		conf.AdmissionWebhooks = append(conf.AdmissionWebhooks, w.Copy())
+	}
 	if gcThreshold := agentConfig.Server.EvalGCThreshold; gcThreshold != "" {
 		dur, err := time.ParseDuration(gcThreshold)
 		if err != nil {
@@ -433,6 +455,7 @@ func convertClientConfig(agentConfig *Config) (*clientconfig.Config, error) {
 	conf.Servers = agentConfig.Client.Servers
 	conf.LogLevel = agentConfig.LogLevel
 	conf.DevMode = agentConfig.DevMode
+	conf.EnableDebug = agentConfig.EnableDebug
 	if agentConfig.Region != "" {
 		conf.Region = agentConfig.Region
 	}
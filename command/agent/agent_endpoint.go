@@ -2,17 +2,28 @@ package agent
 
 import (
 	"encoding/json"
+	"fmt"
 	"net"
 	"net/http"
 	"sort"
+	"strconv"
 	"strings"
 
+	log "github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/nomad/acl"
+	cstructs "github.com/hashicorp/nomad/client/structs"
+	"github.com/hashicorp/nomad/command/agent/pprof"
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/hashicorp/serf/serf"
 	"github.com/mitchellh/copystructure"
 )
 
+const (
+	// agentPprofMaxSeconds is the maximum duration of CPU profiles and
+	// traces
+	agentPprofMaxSeconds = 30
+)
+
 type Member struct {
 	Name        string
 	Addr        net.IP
@@ -90,6 +101,210 @@ func (s *HTTPServer) AgentSelfRequest(resp http.ResponseWriter, req *http.Reques
 	return self, nil
 }
 
+// AgentMonitor streams the logs of an agent. The parameters are:
+// * log_level: level of the logs to stream, defaults to info.
+// * log_json: A boolean of whether to format the logs as JSON.
+// * node_id: ID of the client to stream the logs of.
+// * server_id: name or ID of the server to stream the logs of, or "leader".
+// * plain: A boolean of whether to stream the logs without framing them.
+func (s *HTTPServer) AgentMonitor(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	if req.Method != "GET" {
+		return nil, CodedError(405, ErrInvalidMethod)
+	}
+
+	q := req.URL.Query()
+
+	// Get the provided loglevel
+	logLevel := q.Get("log_level")
+	if logLevel == "" {
+		logLevel = "INFO"
+	}
+
+	if log.LevelFromString(logLevel) == log.NoLevel {
+		return nil, CodedError(400, fmt.Sprintf("Unknown log level: %s", logLevel))
+	}
+
+	logJSON := false
+	if logJSONStr := q.Get("log_json"); logJSONStr != "" {
+		parsed, err := strconv.ParseBool(logJSONStr)
+		if err != nil {
+			return nil, CodedError(400, fmt.Sprintf("Unknown option for log json: %v", err))
+		}
+		logJSON = parsed
+	}
+
+	plain := false
+	if plainStr := q.Get("plain"); plainStr != "" {
+		parsed, err := strconv.ParseBool(plainStr)
+		if err != nil {
+			return nil, CodedError(400, fmt.Sprintf("Unknown option for plain: %v", err))
+		}
+		plain = parsed
+	}
+
+	nodeID := q.Get("node_id")
+	serverID := q.Get("server_id")
+	if nodeID != "" && serverID != "" {
+		return nil, CodedError(400, "Cannot target node and server simultaneously")
+	}
+
+	// Create the request arguments
+	args := &cstructs.MonitorRequest{
+		NodeID:    nodeID,
+		ServerID:  serverID,
+		LogLevel:  logLevel,
+		LogJSON:   logJSON,
+		PlainText: plain,
+	}
+	if s.parse(resp, req, &args.QueryOptions.Region, &args.QueryOptions) {
+		return nil, nil
+	}
+
+	// Get the correct handler
+	var handler structs.StreamingRpcHandler
+	var handlerErr error
+	if nodeID != "" {
+		localClient, remoteClient, localServer := s.rpcHandlerForNode(nodeID)
+		if localClient {
+			handler, handlerErr = s.agent.Client().StreamingRpcHandler("Agent.Monitor")
+		} else if remoteClient {
+			handler, handlerErr = s.agent.Client().RemoteStreamingRpcHandler("Agent.Monitor")
+		} else if localServer {
+			handler, handlerErr = s.agent.Server().StreamingRpcHandler("Agent.Monitor")
+		}
+	} else if srv := s.agent.Server(); srv != nil {
+		handler, handlerErr = srv.StreamingRpcHandler("Agent.Monitor")
+	} else if serverID != "" {
+		handler, handlerErr = s.agent.Client().RemoteStreamingRpcHandler("Agent.Monitor")
+	} else {
+		handler, handlerErr = s.agent.Client().StreamingRpcHandler("Agent.Monitor")
+	}
+
+	if handlerErr != nil {
+		return nil, CodedError(500, handlerErr.Error())
+	}
+	if handler == nil {
+		return nil, CodedError(400, "No local Node and node_id not provided")
+	}
+
+	return s.streamingRpcImpl(resp, req, handler, args)
+}
+
+// AgentPprofRequest routes the profile requests of the agent or of the agent
+// given by the node_id or server_id parameter.
+func (s *HTTPServer) AgentPprofRequest(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	if req.Method != "GET" {
+		return nil, CodedError(405, ErrInvalidMethod)
+	}
+
+	path := strings.TrimPrefix(req.URL.Path, "/v1/agent/pprof/")
+	switch path {
+	case "":
+		return nil, CodedError(404, "Pprof index is not supported")
+	case "profile":
+		return s.agentPprof(pprof.CPUReq, path, resp, req)
+	case "trace":
+		return s.agentPprof(pprof.TraceReq, path, resp, req)
+	default:
+		return s.agentPprof(pprof.LookupReq, path, resp, req)
+	}
+}
+
+// agentPprof writes a profile of an agent. The parameters are:
+// * node_id: ID of the client to profile.
+// * server_id: name or ID of the server to profile, or "leader".
+// * seconds: duration of CPU profiles and traces, defaults to 1 and capped at
+//   agentPprofMaxSeconds.
+// * debug: format of looked up profiles, defaults to 0, the binary format.
+// * gc: A boolean of whether to run a garbage collection before a heap
+//   profile.
+func (s *HTTPServer) agentPprof(reqType pprof.ReqType, profile string, resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	q := req.URL.Query()
+
+	nodeID := q.Get("node_id")
+	serverID := q.Get("server_id")
+	if nodeID != "" && serverID != "" {
+		return nil, CodedError(400, "Cannot target node and server simultaneously")
+	}
+
+	var err error
+	secs := 1
+	if secsStr := q.Get("seconds"); secsStr != "" {
+		secs, err = strconv.Atoi(secsStr)
+		if err != nil || secs <= 0 {
+			return nil, CodedError(400, fmt.Sprintf("Invalid seconds: %q", secsStr))
+		}
+		if secs > agentPprofMaxSeconds {
+			secs = agentPprofMaxSeconds
+		}
+	}
+
+	debug := 0
+	if debugStr := q.Get("debug"); debugStr != "" {
+		debug, err = strconv.Atoi(debugStr)
+		if err != nil {
+			return nil, CodedError(400, fmt.Sprintf("Invalid debug: %q", debugStr))
+		}
+	}
+
+	gc := false
+	if gcStr := q.Get("gc"); gcStr != "" {
+		gc, err = strconv.ParseBool(gcStr)
+		if err != nil {
+			return nil, CodedError(400, fmt.Sprintf("Invalid gc: %q", gcStr))
+		}
+	}
+
+	args := &cstructs.AgentPprofRequest{
+		ReqType:  reqType,
+		Profile:  profile,
+		Seconds:  secs,
+		Debug:    debug,
+		GC:       gc,
+		NodeID:   nodeID,
+		ServerID: serverID,
+	}
+	if s.parse(resp, req, &args.QueryOptions.Region, &args.QueryOptions) {
+		return nil, nil
+	}
+
+	// Make the RPC
+	var reply cstructs.AgentPprofResponse
+	var rpcErr error
+	if nodeID != "" {
+		localClient, remoteClient, localServer := s.rpcHandlerForNode(nodeID)
+		if localClient {
+			rpcErr = s.agent.Client().ClientRPC("Agent.Profile", args, &reply)
+		} else if remoteClient {
+			rpcErr = s.agent.Client().RPC("Agent.Profile", args, &reply)
+		} else if localServer {
+			rpcErr = s.agent.Server().RPC("Agent.Profile", args, &reply)
+		}
+	} else if srv := s.agent.Server(); srv != nil {
+		rpcErr = srv.RPC("Agent.Profile", args, &reply)
+	} else if serverID != "" {
+		rpcErr = s.agent.Client().RPC("Agent.Profile", args, &reply)
+	} else {
+		rpcErr = s.agent.Client().ClientRPC("Agent.Profile", args, &reply)
+	}
+
+	if rpcErr != nil {
+		if structs.IsErrNoNodeConn(rpcErr) || structs.IsErrUnknownNode(rpcErr) ||
+			strings.Contains(rpcErr.Error(), "Profile not found") {
+			rpcErr = CodedError(404, rpcErr.Error())
+		} else if strings.Contains(rpcErr.Error(), "unknown nomad server") {
+			rpcErr = CodedError(400, rpcErr.Error())
+		}
+		return nil, rpcErr
+	}
+
+	for k, v := range reply.HTTPHeaders {
+		resp.Header().Set(k, v)
+	}
+	resp.Write(reply.Payload)
+	return nil, nil
+}
+
 func (s *HTTPServer) AgentJoinRequest(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
 	if req.Method != "PUT" && req.Method != "POST" {
 		return nil, CodedError(405, ErrInvalidMethod)
@@ -2,12 +2,15 @@ package agent
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
+	"io/ioutil"
 	"net"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"strings"
 	"testing"
 	"time"
 
@@ -120,6 +123,277 @@ func TestHTTP_AgentSelf_ACL(t *testing.T) {
 	})
 }
 
+func TestHTTP_AgentMonitor(t *testing.T) {
+	t.Parallel()
+
+	httpTest(t, nil, func(s *TestAgent) {
+		// invalid log_json
+		{
+			req, err := http.NewRequest("GET", "/v1/agent/monitor?log_json=no", nil)
+			require.Nil(t, err)
+			resp := httptest.NewRecorder()
+
+			// Make the request
+			_, err = s.Server.AgentMonitor(resp, req)
+			if err.(HTTPCodedError).Code() != 400 {
+				t.Fatalf("expected 400 response, got: %v", resp.Code)
+			}
+		}
+
+		// unknown log_level
+		{
+			req, err := http.NewRequest("GET", "/v1/agent/monitor?log_level=unknown", nil)
+			require.Nil(t, err)
+			resp := httptest.NewRecorder()
+
+			// Make the request
+			_, err = s.Server.AgentMonitor(resp, req)
+			if err.(HTTPCodedError).Code() != 400 {
+				t.Fatalf("expected 400 response, got: %v", resp.Code)
+			}
+		}
+
+		// check for a specific log
+		{
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			req, err := http.NewRequest("GET", "/v1/agent/monitor?log_level=warn&plain=true", nil)
+			require.Nil(t, err)
+			req = req.WithContext(ctx)
+			resp := testutil.NewResponseRecorder()
+
+			go func() {
+				s.Server.AgentMonitor(resp, req)
+			}()
+
+			// send the same log until monitor sink is set up
+			out := ""
+			testutil.WaitForResult(func() (bool, error) {
+				s.Server.logger.Warn("log that should be sent")
+
+				output, err := ioutil.ReadAll(resp)
+				if err != nil {
+					return false, err
+				}
+
+				out += string(output)
+				want := "[WARN ] http: log that should be sent"
+				if strings.Contains(out, want) {
+					return true, nil
+				}
+
+				return false, fmt.Errorf("missing expected log, got: %v, want: %v", out, want)
+			}, func(err error) {
+				require.Fail(t, err.Error())
+			})
+		}
+
+		// stream logs for a given node
+		{
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			req, err := http.NewRequest("GET", "/v1/agent/monitor?log_level=debug&plain=true&node_id="+s.client.NodeID(), nil)
+			require.Nil(t, err)
+			req = req.WithContext(ctx)
+			resp := testutil.NewResponseRecorder()
+
+			go func() {
+				s.Server.AgentMonitor(resp, req)
+			}()
+
+			// send the same log until monitor sink is set up
+			out := ""
+			testutil.WaitForResult(func() (bool, error) {
+				s.Agent.logger.Debug("log that should be sent")
+
+				output, err := ioutil.ReadAll(resp)
+				if err != nil {
+					return false, err
+				}
+
+				out += string(output)
+				want := "[DEBUG] agent: log that should be sent"
+				if strings.Contains(out, want) {
+					return true, nil
+				}
+
+				return false, fmt.Errorf("missing expected log, got: %v, want: %v", out, want)
+			}, func(err error) {
+				require.Fail(t, err.Error())
+			})
+		}
+	})
+}
+
+func TestHTTP_AgentPprofRequest(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name         string
+		url          string
+		expectedCode int
+		contentType  string
+	}{
+		{
+			name:         "goroutine",
+			url:          "/v1/agent/pprof/goroutine",
+			expectedCode: 200,
+			contentType:  "application/octet-stream",
+		},
+		{
+			name:         "heap text",
+			url:          "/v1/agent/pprof/heap?debug=1&gc=true",
+			expectedCode: 200,
+			contentType:  "text/plain; charset=utf-8",
+		},
+		{
+			name:         "cpu profile",
+			url:          "/v1/agent/pprof/profile?seconds=1",
+			expectedCode: 200,
+			contentType:  "application/octet-stream",
+		},
+		{
+			name:         "trace",
+			url:          "/v1/agent/pprof/trace?seconds=1",
+			expectedCode: 200,
+			contentType:  "application/octet-stream",
+		},
+		{
+			name:         "unknown profile",
+			url:          "/v1/agent/pprof/unknown",
+			expectedCode: 404,
+		},
+		{
+			name:         "index",
+			url:          "/v1/agent/pprof/",
+			expectedCode: 404,
+		},
+		{
+			name:         "invalid seconds",
+			url:          "/v1/agent/pprof/profile?seconds=foo",
+			expectedCode: 400,
+		},
+		{
+			name:         "node and server",
+			url:          "/v1/agent/pprof/heap?node_id=foo&server_id=bar",
+			expectedCode: 400,
+		},
+		{
+			name:         "unknown server",
+			url:          "/v1/agent/pprof/heap?server_id=unknown",
+			expectedCode: 400,
+		},
+	}
+
+	httpTest(t, nil, func(s *TestAgent) {
+		// Profile the local client through the server too
+		cases = append(cases, struct {
+			name         string
+			url          string
+			expectedCode int
+			contentType  string
+		}{
+			name:         "local client",
+			url:          "/v1/agent/pprof/goroutine?node_id=" + s.client.NodeID(),
+			expectedCode: 200,
+			contentType:  "application/octet-stream",
+		})
+
+		for _, tc := range cases {
+			t.Run(tc.name, func(t *testing.T) {
+				req, err := http.NewRequest("GET", tc.url, nil)
+				require.NoError(t, err)
+				resp := httptest.NewRecorder()
+
+				_, err = s.Server.AgentPprofRequest(resp, req)
+				if tc.expectedCode != 200 {
+					require.Error(t, err)
+					codedErr, ok := err.(HTTPCodedError)
+					require.True(t, ok, "expected coded error, got: %v", err)
+					require.Equal(t, tc.expectedCode, codedErr.Code())
+					return
+				}
+
+				require.NoError(t, err)
+				require.Equal(t, tc.contentType, resp.Header().Get("Content-Type"))
+				require.NotEmpty(t, resp.Body.Bytes())
+			})
+		}
+	})
+}
+
+func TestHTTP_AgentPprofRequest_InvalidWait(t *testing.T) {
+	t.Parallel()
+	httpTest(t, nil, func(s *TestAgent) {
+		req, err := http.NewRequest("GET", "/v1/agent/pprof/heap?wait=foo", nil)
+		require.NoError(t, err)
+		resp := httptest.NewRecorder()
+
+		// The parse error is written and no profile is taken
+		out, err := s.Server.AgentPprofRequest(resp, req)
+		require.NoError(t, err)
+		require.Nil(t, out)
+		require.Equal(t, 400, resp.Code)
+		require.Equal(t, "Invalid wait time", resp.Body.String())
+	})
+}
+
+func TestHTTP_AgentMonitor_InvalidWait(t *testing.T) {
+	t.Parallel()
+	httpTest(t, nil, func(s *TestAgent) {
+		req, err := http.NewRequest("GET", "/v1/agent/monitor?wait=foo", nil)
+		require.NoError(t, err)
+		resp := httptest.NewRecorder()
+
+		// The parse error is written and no logs are streamed
+		out, err := s.Server.AgentMonitor(resp, req)
+		require.NoError(t, err)
+		require.Nil(t, out)
+		require.Equal(t, 400, resp.Code)
+		require.Equal(t, "Invalid wait time", resp.Body.String())
+	})
+}
+
+func TestHTTP_AgentPprofRequest_ACL(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	httpACLTest(t, nil, func(s *TestAgent) {
+		state := s.Agent.server.State()
+
+		req, err := http.NewRequest("GET", "/v1/agent/pprof/goroutine", nil)
+		require.Nil(err)
+
+		// Try request without a token and expect failure
+		{
+			respW := httptest.NewRecorder()
+			_, err := s.Server.AgentPprofRequest(respW, req)
+			require.NotNil(err)
+			require.Equal(err.Error(), structs.ErrPermissionDenied.Error())
+		}
+
+		// Try request with a read token and expect failure
+		{
+			respW := httptest.NewRecorder()
+			token := mock.CreatePolicyAndToken(t, state, 1005, "invalid", mock.AgentPolicy(acl.PolicyRead))
+			setToken(req, token)
+			_, err := s.Server.AgentPprofRequest(respW, req)
+			require.NotNil(err)
+			require.Equal(err.Error(), structs.ErrPermissionDenied.Error())
+		}
+
+		// Try request with a write token
+		{
+			respW := httptest.NewRecorder()
+			token := mock.CreatePolicyAndToken(t, state, 1007, "valid", mock.AgentPolicy(acl.PolicyWrite))
+			setToken(req, token)
+			_, err := s.Server.AgentPprofRequest(respW, req)
+			require.Nil(err)
+			require.NotEmpty(respW.Body.Bytes())
+		}
+	})
+}
+
 func TestHTTP_AgentJoin(t *testing.T) {
 	t.Parallel()
 	httpTest(t, nil, func(s *TestAgent) {
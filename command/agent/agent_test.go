@@ -260,6 +260,22 @@ func TestAgent_ServerConfig(t *testing.T) {
 	if out.BootstrapExpect != 3 {
 		t.Fatalf("should have bootstrap-expect = 3")
 	}
+
+	// Read replicas require Raft protocol version 3
+	conf.Server.NonVotingServer = true
+	conf.Server.RaftProtocol = 2
+	if _, err := a.serverConfig(); err == nil || !strings.Contains(err.Error(), "raft_protocol") {
+		t.Fatalf("expected raft protocol error, got: %v", err)
+	}
+
+	conf.Server.RaftProtocol = 3
+	out, err = a.serverConfig()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if !out.NonVoter {
+		t.Fatalf("should have set non-voter")
+	}
 }
 
 func TestAgent_ClientConfig(t *testing.T) {
@@ -147,6 +147,8 @@ func (s *HTTPServer) ClientAllocRequest(resp http.ResponseWriter, req *http.Requ
 		return s.allocStats(allocID, resp, req)
 	case "exec":
 		return s.allocExec(allocID, resp, req)
+	case "port-forward":
+		return s.allocPortForward(allocID, resp, req)
 	case "snapshot":
 		if s.agent.client == nil {
 			return nil, clientNotRunning
@@ -402,8 +404,35 @@ func (s *HTTPServer) allocExec(allocID string, resp http.ResponseWriter, req *ht
 }
 
 func (s *HTTPServer) execStreamImpl(ws *websocket.Conn, args *cstructs.AllocExecRequest) (interface{}, error) {
-	allocID := args.AllocID
-	method := "Allocations.Exec"
+	forwardInput := func(encoder *codec.Encoder, ws *websocket.Conn, errCh chan<- HTTPCodedError, _ context.CancelFunc) {
+		forwardExecInput(encoder, ws, errCh)
+	}
+	return s.allocStreamImpl(ws, args.AllocID, "Allocations.Exec", args, forwardInput, websocket.TextMessage)
+}
+
+func (s *HTTPServer) allocPortForward(allocID string, resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	// Build the request and parse the ACL token
+	args := cstructs.AllocPortForwardRequest{
+		AllocID: allocID,
+		Port:    req.URL.Query().Get("port"),
+	}
+	s.parse(resp, req, &args.QueryOptions.Region, &args.QueryOptions)
+
+	conn, err := s.wsUpgrader.Upgrade(resp, req, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to upgrade connection: %v", err)
+	}
+
+	return s.allocStreamImpl(conn, allocID, "Allocations.PortForward", &args, forwardPortInput, websocket.BinaryMessage)
+}
+
+// allocStreamImpl bridges a websocket connection with a streaming RPC of the
+// allocation. The input of the websocket is forwarded by forwardInput, which
+// may cancel the stream, and the payloads of the streaming RPC are written as
+// messages of messageType.
+func (s *HTTPServer) allocStreamImpl(ws *websocket.Conn, allocID, method string, args interface{},
+	forwardInput func(*codec.Encoder, *websocket.Conn, chan<- HTTPCodedError, context.CancelFunc),
+	messageType int) (interface{}, error) {
 
 	// Get the correct handler
 	localClient, remoteClient, localServer := s.rpcHandlerForAlloc(allocID)
@@ -448,7 +477,7 @@ func (s *HTTPServer) execStreamImpl(ws *websocket.Conn, args *cstructs.AllocExec
 			return
 		}
 
-		go forwardExecInput(encoder, ws, errCh)
+		go forwardInput(encoder, ws, errCh, cancel)
 
 		for {
 			select {
@@ -481,7 +510,7 @@ func (s *HTTPServer) execStreamImpl(ws *websocket.Conn, args *cstructs.AllocExec
 				return
 			}
 
-			if err := ws.WriteMessage(websocket.TextMessage, res.Payload); err != nil {
+			if err := ws.WriteMessage(messageType, res.Payload); err != nil {
 				errCh <- CodedError(500, err.Error())
 				return
 			}
@@ -549,3 +578,27 @@ func forwardExecInput(encoder *codec.Encoder, ws *websocket.Conn, errCh chan<- H
 		}
 	}
 }
+
+// forwardPortInput forwards the data of a port forwarding websocket
+// connection to the streaming RPC connection to client. An empty message
+// closes the writing side of the forwarded connection, while closing the
+// websocket connection ends the stream.
+func forwardPortInput(encoder *codec.Encoder, ws *websocket.Conn, errCh chan<- HTTPCodedError, cancel context.CancelFunc) {
+	defer cancel()
+	for {
+		_, data, err := ws.ReadMessage()
+		if err == io.EOF || websocket.IsCloseError(err, websocket.CloseNormalClosure) {
+			return
+		}
+
+		if err != nil {
+			errCh <- CodedError(500, err.Error())
+			return
+		}
+
+		if err := encoder.Encode(cstructs.AllocPortForwardFrame{Data: data, Close: len(data) == 0}); err != nil {
+			errCh <- CodedError(500, err.Error())
+			return
+		}
+	}
+}
@@ -361,6 +361,10 @@ type ServerConfig struct {
 	// can be used to filter by age.
 	JobGCThreshold string `hcl:"job_gc_threshold"`
 
+	// DispatchTokenRetention controls how long an idempotency
+	// token used to dispatch a parameterized job is honored.
+	DispatchTokenRetention string `hcl:"dispatch_token_retention"`
+
 	// EvalGCThreshold controls how "old" an eval must be to be collected by GC.
 	// Age is not the only requirement for a eval to be GCed but the threshold
 	// can be used to filter by age.
@@ -415,8 +419,8 @@ type ServerConfig struct {
 	// true, we ignore the leave, and rejoin the cluster on start.
 	RejoinAfterLeave bool `hcl:"rejoin_after_leave"`
 
-	// (Enterprise-only) NonVotingServer is whether this server will act as a
-	// non-voting member of the cluster to help provide read scalability.
+	// NonVotingServer is whether this server will act as a non-voting member
+	// of the cluster to help provide read scalability.
 	NonVotingServer bool `hcl:"non_voting_server"`
 
 	// (Enterprise-only) RedundancyZone is the redundancy zone to use for this server.
@@ -432,6 +436,10 @@ type ServerConfig struct {
 	// ServerJoin contains information that is used to attempt to join servers
 	ServerJoin *ServerJoin `hcl:"server_join"`
 
+	// AdmissionWebhooks are the external webhooks called to mutate or
+	// validate jobs when they are registered or planned.
+	AdmissionWebhooks []*config.AdmissionWebhookConfig `hcl:"admission_webhook"`
+
 	// ExtraKeysHCL is used by hcl to surface unexpected keys
 	ExtraKeysHCL []string `hcl:",unusedKeys" json:"-"`
 }
@@ -1264,6 +1272,9 @@ func (a *ServerConfig) Merge(b *ServerConfig) *ServerConfig {
 	if b.JobGCThreshold != "" {
 		result.JobGCThreshold = b.JobGCThreshold
 	}
+	if b.DispatchTokenRetention != "" {
+		result.DispatchTokenRetention = b.DispatchTokenRetention
+	}
 	if b.EvalGCThreshold != "" {
 		result.EvalGCThreshold = b.EvalGCThreshold
 	}
@@ -1312,6 +1323,9 @@ func (a *ServerConfig) Merge(b *ServerConfig) *ServerConfig {
 	if b.ServerJoin != nil {
 		result.ServerJoin = result.ServerJoin.Merge(b.ServerJoin)
 	}
+	if len(b.AdmissionWebhooks) != 0 {
+		result.AdmissionWebhooks = config.AdmissionWebhookSetMerge(a.AdmissionWebhooks, b.AdmissionWebhooks)
+	}
 
 	// Add the schedulers
 	result.EnabledSchedulers = append(result.EnabledSchedulers, b.EnabledSchedulers...)
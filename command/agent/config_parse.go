@@ -48,7 +48,7 @@ func ParseConfigFile(path string) (*Config, error) {
 	}
 
 	// convert strings to time.Durations
-	err = durations([]td{
+	tds := []td{
 		{"gc_interval", &c.Client.GCInterval, &c.Client.GCIntervalHCL},
 		{"acl.token_ttl", &c.ACL.TokenTTL, &c.ACL.TokenTTLHCL},
 		{"acl.policy_ttl", &c.ACL.PolicyTTL, &c.ACL.PolicyTTLHCL},
@@ -61,7 +61,12 @@ func ParseConfigFile(path string) (*Config, error) {
 		{"autopilot.server_stabilization_time", &c.Autopilot.ServerStabilizationTime, &c.Autopilot.ServerStabilizationTimeHCL},
 		{"autopilot.last_contact_threshold", &c.Autopilot.LastContactThreshold, &c.Autopilot.LastContactThresholdHCL},
 		{"telemetry.collection_interval", &c.Telemetry.collectionInterval, &c.Telemetry.CollectionInterval},
-	})
+	}
+	for _, w := range c.Server.AdmissionWebhooks {
+		path := fmt.Sprintf("server.admission_webhook.%s.timeout", w.Name)
+		tds = append(tds, td{path, &w.Timeout, &w.TimeoutHCL})
+	}
+	err = durations(tds)
 	if err != nil {
 		return nil, err
 	}
@@ -149,6 +154,16 @@ func extraKeys(c *Config) error {
 		removeEqualFold(&c.ExtraKeysHCL, "server")
 	}
 
+	// Remove AdmissionWebhook extra keys
+	for _, w := range c.Server.AdmissionWebhooks {
+		removeEqualFold(&c.Server.ExtraKeysHCL, w.Name)
+		removeEqualFold(&c.Server.ExtraKeysHCL, "admission_webhook")
+		if len(w.ExtraKeysHCL) != 0 {
+			return fmt.Errorf("server.admission_webhook.%s unexpected keys %s",
+				w.Name, strings.Join(w.ExtraKeysHCL, ", "))
+		}
+	}
+
 	for _, k := range []string{"datadog_tags"} {
 		removeEqualFold(&c.ExtraKeysHCL, k)
 		removeEqualFold(&c.ExtraKeysHCL, "telemetry")
@@ -100,6 +100,7 @@ var basicConfig = &Config{
 		JobGCInterval:          "3m",
 		JobGCThreshold:         "12h",
 		DeploymentGCThreshold:  "12h",
+		DispatchTokenRetention: "48h",
 		HeartbeatGrace:         30 * time.Second,
 		HeartbeatGraceHCL:      "30s",
 		MinHeartbeatTTL:        33 * time.Second,
@@ -121,6 +122,18 @@ var basicConfig = &Config{
 			RetryIntervalHCL: "15s",
 			RetryMaxAttempts: 3,
 		},
+		AdmissionWebhooks: []*config.AdmissionWebhookConfig{
+			{
+				Name:          "labels",
+				URL:           "https://admission.example.com/labels",
+				Operations:    []string{"register"},
+				Timeout:       2 * time.Second,
+				TimeoutHCL:    "2s",
+				FailurePolicy: "fail_open",
+				CAFile:        "/path/to/ca",
+				TLSSkipVerify: true,
+			},
+		},
 	},
 	ACL: &ACLConfig{
 		Enabled:          true,
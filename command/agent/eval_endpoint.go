@@ -1,17 +1,26 @@
 package agent
 
 import (
+	"fmt"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/nomad/nomad/structs"
 )
 
 func (s *HTTPServer) EvalsRequest(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
-	if req.Method != "GET" {
+	switch req.Method {
+	case "GET":
+		return s.evalsList(resp, req)
+	case "DELETE":
+		return s.evalsDelete(resp, req)
+	default:
 		return nil, CodedError(405, ErrInvalidMethod)
 	}
+}
 
+func (s *HTTPServer) evalsList(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
 	args := structs.EvalListRequest{}
 	if s.parse(resp, req, &args.Region, &args.QueryOptions) {
 		return nil, nil
@@ -29,6 +38,33 @@ func (s *HTTPServer) EvalsRequest(resp http.ResponseWriter, req *http.Request) (
 	return out.Evaluations, nil
 }
 
+func (s *HTTPServer) evalsDelete(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	query := req.URL.Query()
+	args := structs.EvalBatchDeleteRequest{
+		JobID:  query.Get("job"),
+		Status: query.Get("status"),
+	}
+	if olderThan := query.Get("older_than"); olderThan != "" {
+		d, err := time.ParseDuration(olderThan)
+		if err != nil {
+			return nil, CodedError(400, fmt.Sprintf("Failed to parse older_than: %v", err))
+		}
+		args.OlderThan = d
+	}
+	if args.JobID == "" && args.Status == "" && args.OlderThan == 0 {
+		return nil, CodedError(400, "At least one of the job, status or older_than filters must be set")
+	}
+	s.parseWriteRequest(req, &args.WriteRequest)
+
+	var out structs.EvalBatchDeleteResponse
+	if err := s.agent.RPC("Eval.Delete", &args, &out); err != nil {
+		return nil, err
+	}
+
+	setIndex(resp, out.Index)
+	return out, nil
+}
+
 func (s *HTTPServer) EvalSpecificRequest(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
 	path := strings.TrimPrefix(req.URL.Path, "/v1/evaluation/")
 	switch {
@@ -7,6 +7,7 @@ import (
 
 	"github.com/hashicorp/nomad/nomad/mock"
 	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/stretchr/testify/require"
 )
 
 func TestHTTP_EvalList(t *testing.T) {
@@ -54,6 +55,53 @@ func TestHTTP_EvalList(t *testing.T) {
 	})
 }
 
+func TestHTTP_EvalsDelete(t *testing.T) {
+	t.Parallel()
+	httpTest(t, nil, func(s *TestAgent) {
+		require := require.New(t)
+
+		// Directly manipulate the state
+		state := s.Agent.server.State()
+		eval1 := mock.Eval()
+		eval2 := mock.Eval()
+		require.NoError(state.UpsertEvals(1000, []*structs.Evaluation{eval1, eval2}))
+
+		// Filters are required
+		req, err := http.NewRequest("DELETE", "/v1/evaluations", nil)
+		require.NoError(err)
+		respW := httptest.NewRecorder()
+		_, err = s.Server.EvalsRequest(respW, req)
+		require.Error(err)
+		require.Contains(err.Error(), "At least one")
+
+		// Invalid durations are rejected
+		req, err = http.NewRequest("DELETE", "/v1/evaluations?older_than=foo", nil)
+		require.NoError(err)
+		respW = httptest.NewRecorder()
+		_, err = s.Server.EvalsRequest(respW, req)
+		require.Error(err)
+		require.Contains(err.Error(), "older_than")
+
+		// Delete the evals of the first job
+		req, err = http.NewRequest("DELETE", "/v1/evaluations?job="+eval1.JobID, nil)
+		require.NoError(err)
+		respW = httptest.NewRecorder()
+		obj, err := s.Server.EvalsRequest(respW, req)
+		require.NoError(err)
+		require.NotEmpty(respW.HeaderMap.Get("X-Nomad-Index"))
+
+		out := obj.(structs.EvalBatchDeleteResponse)
+		require.Equal(1, out.Deleted)
+
+		e, err := state.EvalByID(nil, eval1.ID)
+		require.NoError(err)
+		require.Nil(e)
+		e, err = state.EvalByID(nil, eval2.ID)
+		require.NoError(err)
+		require.NotNil(e)
+	})
+}
+
 func TestHTTP_EvalPrefixList(t *testing.T) {
 	t.Parallel()
 	httpTest(t, nil, func(s *TestAgent) {
@@ -25,6 +25,12 @@ var (
 	invalidOrigin         = fmt.Errorf("origin must be start or end")
 )
 
+const (
+	// uploadFrameSize is the maximum number of bytes of an uploaded archive
+	// to send in a single frame
+	uploadFrameSize = 64 * 1024
+)
+
 func (s *HTTPServer) FsRequest(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
 	path := strings.TrimPrefix(req.URL.Path, "/v1/client/fs/")
 	switch {
@@ -40,6 +46,10 @@ func (s *HTTPServer) FsRequest(resp http.ResponseWriter, req *http.Request) (int
 		return s.Stream(resp, req)
 	case strings.HasPrefix(path, "logs/"):
 		return s.Logs(resp, req)
+	case strings.HasPrefix(path, "download/"):
+		return s.Download(resp, req)
+	case strings.HasPrefix(path, "upload/"):
+		return s.Upload(resp, req)
 	default:
 		return nil, CodedError(404, ErrInvalidMethod)
 	}
@@ -324,12 +334,127 @@ func (s *HTTPServer) Logs(resp http.ResponseWriter, req *http.Request) (interfac
 	return s.fsStreamImpl(resp, req, "FileSystem.Logs", fsReq, fsReq.AllocID)
 }
 
+// Download streams a tar archive of a file or directory of an allocation.
+func (s *HTTPServer) Download(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	var allocID, path string
+
+	if allocID = strings.TrimPrefix(req.URL.Path, "/v1/client/fs/download/"); allocID == "" {
+		return nil, allocIDNotPresentErr
+	}
+	if path = req.URL.Query().Get("path"); path == "" {
+		return nil, fileNameNotPresentErr
+	}
+
+	// Create the request arguments
+	fsReq := &cstructs.FsDownloadRequest{
+		AllocID: allocID,
+		Path:    path,
+	}
+	s.parse(resp, req, &fsReq.QueryOptions.Region, &fsReq.QueryOptions)
+
+	// Make the request
+	return s.fsStreamImpl(resp, req, "FileSystem.Download", fsReq, fsReq.AllocID)
+}
+
+// Upload extracts the tar archive in the request body into a directory of an
+// allocation.
+func (s *HTTPServer) Upload(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	if req.Method != "PUT" && req.Method != "POST" {
+		return nil, CodedError(405, ErrInvalidMethod)
+	}
+
+	var allocID, path string
+	if allocID = strings.TrimPrefix(req.URL.Path, "/v1/client/fs/upload/"); allocID == "" {
+		return nil, allocIDNotPresentErr
+	}
+	if path = req.URL.Query().Get("path"); path == "" {
+		return nil, fileNameNotPresentErr
+	}
+
+	// Create the request arguments
+	fsReq := &cstructs.FsUploadRequest{
+		AllocID: allocID,
+		Path:    path,
+	}
+	s.parse(resp, req, &fsReq.QueryOptions.Region, &fsReq.QueryOptions)
+
+	handler, err := s.fsStreamingRpcHandler("FileSystem.Upload", allocID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create a pipe connecting the (possibly remote) handler to the request
+	httpPipe, handlerPipe := net.Pipe()
+	decoder := codec.NewDecoder(httpPipe, structs.MsgpackHandle)
+	encoder := codec.NewEncoder(httpPipe, structs.MsgpackHandle)
+
+	// Create a goroutine that closes the pipe if the connection closes.
+	ctx, cancel := context.WithCancel(req.Context())
+	defer cancel()
+	go func() {
+		<-ctx.Done()
+		httpPipe.Close()
+	}()
+
+	go handler(handlerPipe)
+
+	// Send the request followed by the archive
+	go func() {
+		if err := encoder.Encode(fsReq); err != nil {
+			return
+		}
+
+		buf := make([]byte, uploadFrameSize)
+		for {
+			n, err := req.Body.Read(buf)
+			if n > 0 {
+				if err := encoder.Encode(&cstructs.FsUploadFrame{Data: buf[:n]}); err != nil {
+					return
+				}
+			}
+			if err == io.EOF {
+				encoder.Encode(&cstructs.FsUploadFrame{EOF: true})
+				return
+			}
+			if err != nil {
+				// Abort the upload without marking the end of the archive
+				cancel()
+				return
+			}
+		}
+	}()
+
+	// Wait for the result of the upload
+	var res cstructs.StreamErrWrapper
+	if err := decoder.Decode(&res); err != nil {
+		return nil, CodedError(500, err.Error())
+	}
+	if err := res.Error; err != nil {
+		if err.Code != nil {
+			return nil, CodedError(int(*err.Code), err.Error())
+		}
+		return nil, err
+	}
+	return nil, nil
+}
+
 // fsStreamImpl is used to make a streaming filesystem call that serializes the
 // args and then expects a stream of StreamErrWrapper results where the payload
 // is copied to the response body.
 func (s *HTTPServer) fsStreamImpl(resp http.ResponseWriter,
 	req *http.Request, method string, args interface{}, allocID string) (interface{}, error) {
 
+	handler, err := s.fsStreamingRpcHandler(method, allocID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.streamingRpcImpl(resp, req, handler, args)
+}
+
+// fsStreamingRpcHandler returns the handler of the streaming RPC for the
+// allocation.
+func (s *HTTPServer) fsStreamingRpcHandler(method, allocID string) (structs.StreamingRpcHandler, error) {
 	// Get the correct handler
 	localClient, remoteClient, localServer := s.rpcHandlerForAlloc(allocID)
 	var handler structs.StreamingRpcHandler
@@ -346,6 +471,15 @@ func (s *HTTPServer) fsStreamImpl(resp http.ResponseWriter,
 		return nil, CodedError(500, handlerErr.Error())
 	}
 
+	return handler, nil
+}
+
+// streamingRpcImpl serializes the args to the streaming RPC handler and then
+// expects a stream of StreamErrWrapper results where the payload is copied to
+// the response body.
+func (s *HTTPServer) streamingRpcImpl(resp http.ResponseWriter,
+	req *http.Request, handler structs.StreamingRpcHandler, args interface{}) (interface{}, error) {
+
 	// Create a pipe connecting the (possibly remote) handler to the http response
 	httpPipe, handlerPipe := net.Pipe()
 	decoder := codec.NewDecoder(httpPipe, structs.MsgpackHandle)
@@ -1,6 +1,8 @@
 package agent
 
 import (
+	"archive/tar"
+	"bytes"
 	"encoding/base64"
 	"fmt"
 	"io"
@@ -341,6 +343,61 @@ func TestHTTP_FS_Cat(t *testing.T) {
 	})
 }
 
+func TestHTTP_FS_UploadDownload(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	httpTest(t, nil, func(s *TestAgent) {
+		a := mockFSAlloc(s.client.NodeID(), nil)
+		addAllocToClient(s, a, terminalClientAlloc)
+
+		// Create an archive with a file
+		var buf bytes.Buffer
+		tw := tar.NewWriter(&buf)
+		require.Nil(tw.WriteHeader(&tar.Header{Name: "heap", Mode: 0644, Size: 3, Typeflag: tar.TypeReg}))
+		_, err := tw.Write([]byte("foo"))
+		require.Nil(err)
+		require.Nil(tw.Close())
+
+		// Upload the archive
+		path := fmt.Sprintf("/v1/client/fs/upload/%s?path=alloc/data", a.ID)
+		req, err := http.NewRequest("PUT", path, &buf)
+		require.Nil(err)
+		respW := httptest.NewRecorder()
+		_, err = s.Server.Upload(respW, req)
+		require.Nil(err)
+
+		// Download the file
+		path = fmt.Sprintf("/v1/client/fs/download/%s?path=alloc/data/heap", a.ID)
+		req, err = http.NewRequest("GET", path, nil)
+		require.Nil(err)
+		respW = httptest.NewRecorder()
+		_, err = s.Server.Download(respW, req)
+		require.Nil(err)
+
+		tr := tar.NewReader(respW.Result().Body)
+		hdr, err := tr.Next()
+		require.Nil(err)
+		require.Equal("heap", hdr.Name)
+		output, err := ioutil.ReadAll(tr)
+		require.Nil(err)
+		require.EqualValues("foo", output)
+
+		// Upload requires a PUT
+		req, err = http.NewRequest("GET", path, nil)
+		require.Nil(err)
+		_, err = s.Server.Upload(httptest.NewRecorder(), req)
+		require.EqualError(err, ErrInvalidMethod)
+
+		// Uploading into a file fails
+		path = fmt.Sprintf("/v1/client/fs/upload/%s?path=alloc/data/heap", a.ID)
+		req, err = http.NewRequest("PUT", path, bytes.NewReader(nil))
+		require.Nil(err)
+		_, err = s.Server.Upload(httptest.NewRecorder(), req)
+		require.Error(err)
+		require.Contains(err.Error(), "not a directory")
+	})
+}
+
 func TestHTTP_FS_Stream_NoFollow(t *testing.T) {
 	t.Parallel()
 	require := require.New(t)
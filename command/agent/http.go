@@ -17,6 +17,7 @@ import (
 	assetfs "github.com/elazarl/go-bindata-assetfs"
 	"github.com/gorilla/websocket"
 	log "github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/nomad/helper/filter"
 	"github.com/hashicorp/nomad/helper/tlsutil"
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/rs/cors"
@@ -171,6 +172,16 @@ func (s *HTTPServer) registerHandlers(enableDebug bool) {
 	s.mux.HandleFunc("/v1/acl/token", s.wrap(s.ACLTokenSpecificRequest))
 	s.mux.HandleFunc("/v1/acl/token/", s.wrap(s.ACLTokenSpecificRequest))
 
+	s.mux.HandleFunc("/v1/namespaces", s.wrap(s.NamespacesRequest))
+	s.mux.HandleFunc("/v1/namespace", s.wrap(s.NamespaceCreateRequest))
+	s.mux.HandleFunc("/v1/namespace/", s.wrap(s.NamespaceSpecificRequest))
+
+	s.mux.HandleFunc("/v1/admission/policies", s.wrap(s.AdmissionPoliciesRequest))
+	s.mux.HandleFunc("/v1/admission/policy/", s.wrap(s.AdmissionPolicySpecificRequest))
+
+	s.mux.HandleFunc("/v1/scaling/policies", s.wrap(s.ScalingPoliciesRequest))
+	s.mux.HandleFunc("/v1/scaling/policy/", s.wrap(s.ScalingPolicySpecificRequest))
+
 	s.mux.Handle("/v1/client/fs/", wrapCORS(s.wrap(s.FsRequest)))
 	s.mux.HandleFunc("/v1/client/gc", s.wrap(s.ClientGCRequest))
 	s.mux.Handle("/v1/client/stats", wrapCORS(s.wrap(s.ClientStatsRequest)))
@@ -179,6 +190,8 @@ func (s *HTTPServer) registerHandlers(enableDebug bool) {
 	s.mux.HandleFunc("/v1/agent/self", s.wrap(s.AgentSelfRequest))
 	s.mux.HandleFunc("/v1/agent/join", s.wrap(s.AgentJoinRequest))
 	s.mux.HandleFunc("/v1/agent/members", s.wrap(s.AgentMembersRequest))
+	s.mux.HandleFunc("/v1/agent/monitor", s.wrap(s.AgentMonitor))
+	s.mux.HandleFunc("/v1/agent/pprof/", s.wrap(s.AgentPprofRequest))
 	s.mux.HandleFunc("/v1/agent/force-leave", s.wrap(s.AgentForceLeaveRequest))
 	s.mux.HandleFunc("/v1/agent/servers", s.wrap(s.AgentServersRequest))
 	s.mux.HandleFunc("/v1/agent/keyring/", s.wrap(s.KeyringOperationRequest))
@@ -193,6 +206,7 @@ func (s *HTTPServer) registerHandlers(enableDebug bool) {
 	s.mux.HandleFunc("/v1/status/leader", s.wrap(s.StatusLeaderRequest))
 	s.mux.HandleFunc("/v1/status/peers", s.wrap(s.StatusPeersRequest))
 
+	s.mux.HandleFunc("/v1/search/fuzzy", s.wrap(s.FuzzySearchRequest))
 	s.mux.HandleFunc("/v1/search", s.wrap(s.SearchRequest))
 
 	s.mux.HandleFunc("/v1/operator/raft/", s.wrap(s.OperatorRequest))
@@ -203,6 +217,7 @@ func (s *HTTPServer) registerHandlers(enableDebug bool) {
 	s.mux.HandleFunc("/v1/system/reconcile/summaries", s.wrap(s.ReconcileJobSummaries))
 
 	s.mux.HandleFunc("/v1/operator/scheduler/configuration", s.wrap(s.OperatorSchedulerConfiguration))
+	s.mux.HandleFunc("/v1/operator/evals/broker", s.wrap(s.OperatorEvalBroker))
 
 	if uiEnabled {
 		s.mux.Handle("/ui/", http.StripPrefix("/ui/", handleUI(http.FileServer(&UIAssetWrapper{FileSystem: assetFS()}))))
@@ -378,6 +393,9 @@ func setMeta(resp http.ResponseWriter, m *structs.QueryMeta) {
 	setIndex(resp, m.Index)
 	setLastContact(resp, m.LastContact)
 	setKnownLeader(resp, m.KnownLeader)
+	if m.NextToken != "" {
+		resp.Header().Set("X-Nomad-NextToken", m.NextToken)
+	}
 }
 
 // setHeaders is used to set canonical response header fields
@@ -428,6 +446,31 @@ func parsePrefix(req *http.Request, b *structs.QueryOptions) {
 	}
 }
 
+// parsePagination is used to parse the ?filter, ?per_page and ?next_token
+// query params. Returns true on error
+func parsePagination(resp http.ResponseWriter, req *http.Request, b *structs.QueryOptions) bool {
+	query := req.URL.Query()
+	if expr := query.Get("filter"); expr != "" {
+		if _, err := filter.Parse(expr); err != nil {
+			resp.WriteHeader(400)
+			resp.Write([]byte(fmt.Sprintf("Invalid filter: %v", err)))
+			return true
+		}
+		b.Filter = expr
+	}
+	if perPage := query.Get("per_page"); perPage != "" {
+		n, err := strconv.ParseInt(perPage, 10, 32)
+		if err != nil || n < 0 {
+			resp.WriteHeader(400)
+			resp.Write([]byte("Invalid per_page"))
+			return true
+		}
+		b.PerPage = int32(n)
+	}
+	b.NextToken = query.Get("next_token")
+	return false
+}
+
 // parseRegion is used to parse the ?region query param
 func (s *HTTPServer) parseRegion(req *http.Request, r *string) {
 	if other := req.URL.Query().Get("region"); other != "" {
@@ -461,7 +504,10 @@ func (s *HTTPServer) parse(resp http.ResponseWriter, req *http.Request, r *strin
 	parseConsistency(req, b)
 	parsePrefix(req, b)
 	parseNamespace(req, &b.Namespace)
-	return parseWait(resp, req, b)
+	if parseWait(resp, req, b) {
+		return true
+	}
+	return parsePagination(resp, req, b)
 }
 
 // parseWriteRequest is a convenience method for endpoints that need to parse a
@@ -21,6 +21,7 @@ import (
 	"github.com/hashicorp/nomad/nomad/structs/config"
 	"github.com/hashicorp/nomad/testutil"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/ugorji/go/codec"
 )
 
@@ -329,6 +330,41 @@ func TestParseWait_InvalidIndex(t *testing.T) {
 	}
 }
 
+func TestParsePagination(t *testing.T) {
+	t.Parallel()
+	resp := httptest.NewRecorder()
+	var b structs.QueryOptions
+
+	req, err := http.NewRequest("GET",
+		`/v1/jobs?filter=Status+%3D%3D+"running"&per_page=10&next_token=abc`, nil)
+	require.NoError(t, err)
+
+	require.False(t, parsePagination(resp, req, &b))
+	require.Equal(t, `Status == "running"`, b.Filter)
+	require.Equal(t, int32(10), b.PerPage)
+	require.Equal(t, "abc", b.NextToken)
+}
+
+func TestParsePagination_Invalid(t *testing.T) {
+	t.Parallel()
+	cases := []string{
+		"/v1/jobs?filter=Status+%3D%3D",
+		"/v1/jobs?per_page=foo",
+		"/v1/jobs?per_page=-1",
+	}
+
+	for _, c := range cases {
+		resp := httptest.NewRecorder()
+		var b structs.QueryOptions
+
+		req, err := http.NewRequest("GET", c, nil)
+		require.NoError(t, err)
+
+		require.True(t, parsePagination(resp, req, &b), c)
+		require.Equal(t, 400, resp.Code, c)
+	}
+}
+
 func TestParseConsistency(t *testing.T) {
 	t.Parallel()
 	var b structs.QueryOptions
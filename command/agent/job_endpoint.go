@@ -13,6 +13,12 @@ import (
 	"github.com/hashicorp/nomad/nomad/structs"
 )
 
+const (
+	// defaultPeriodicStatusNext is the number of upcoming launches returned
+	// by the periodic status endpoint when none is requested.
+	defaultPeriodicStatusNext = 5
+)
+
 func (s *HTTPServer) JobsRequest(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
 	switch req.Method {
 	case "GET":
@@ -57,6 +63,9 @@ func (s *HTTPServer) JobSpecificRequest(resp http.ResponseWriter, req *http.Requ
 	case strings.HasSuffix(path, "/periodic/force"):
 		jobName := strings.TrimSuffix(path, "/periodic/force")
 		return s.periodicForceRequest(resp, req, jobName)
+	case strings.HasSuffix(path, "/periodic/status"):
+		jobName := strings.TrimSuffix(path, "/periodic/status")
+		return s.periodicStatusRequest(resp, req, jobName)
 	case strings.HasSuffix(path, "/plan"):
 		jobName := strings.TrimSuffix(path, "/plan")
 		return s.jobPlan(resp, req, jobName)
@@ -69,6 +78,12 @@ func (s *HTTPServer) JobSpecificRequest(resp http.ResponseWriter, req *http.Requ
 	case strings.HasSuffix(path, "/versions"):
 		jobName := strings.TrimSuffix(path, "/versions")
 		return s.jobVersions(resp, req, jobName)
+	case strings.HasSuffix(path, "/diff"):
+		jobName := strings.TrimSuffix(path, "/diff")
+		return s.jobDiff(resp, req, jobName)
+	case strings.HasSuffix(path, "/submission"):
+		jobName := strings.TrimSuffix(path, "/submission")
+		return s.jobSubmission(resp, req, jobName)
 	case strings.HasSuffix(path, "/revert"):
 		jobName := strings.TrimSuffix(path, "/revert")
 		return s.jobRevert(resp, req, jobName)
@@ -81,6 +96,12 @@ func (s *HTTPServer) JobSpecificRequest(resp http.ResponseWriter, req *http.Requ
 	case strings.HasSuffix(path, "/stable"):
 		jobName := strings.TrimSuffix(path, "/stable")
 		return s.jobStable(resp, req, jobName)
+	case strings.HasSuffix(path, "/tag"):
+		jobName := strings.TrimSuffix(path, "/tag")
+		return s.jobTag(resp, req, jobName)
+	case strings.HasSuffix(path, "/scale"):
+		jobName := strings.TrimSuffix(path, "/scale")
+		return s.jobScale(resp, req, jobName)
 	default:
 		return s.jobCRUD(resp, req, path)
 	}
@@ -226,6 +247,39 @@ func (s *HTTPServer) periodicForceRequest(resp http.ResponseWriter, req *http.Re
 	return out, nil
 }
 
+func (s *HTTPServer) periodicStatusRequest(resp http.ResponseWriter, req *http.Request,
+	jobName string) (interface{}, error) {
+	if req.Method != "GET" {
+		return nil, CodedError(405, ErrInvalidMethod)
+	}
+
+	args := structs.PeriodicStatusRequest{
+		JobID: jobName,
+		Next:  defaultPeriodicStatusNext,
+	}
+	if nextStr := req.URL.Query().Get("next"); nextStr != "" {
+		next, err := strconv.Atoi(nextStr)
+		if err != nil {
+			return nil, CodedError(400, fmt.Sprintf("Failed to parse value of %q (%v) as an int: %v", "next", nextStr, err))
+		}
+		if next < 0 || next > structs.PeriodicStatusMaxNext {
+			return nil, CodedError(400, fmt.Sprintf("Value of %q must be between 0 and %d", "next", structs.PeriodicStatusMaxNext))
+		}
+		args.Next = next
+	}
+	if s.parse(resp, req, &args.Region, &args.QueryOptions) {
+		return nil, nil
+	}
+
+	var out structs.PeriodicStatusResponse
+	if err := s.agent.RPC("Periodic.Status", &args, &out); err != nil {
+		return nil, err
+	}
+
+	setMeta(resp, &out.QueryMeta)
+	return out, nil
+}
+
 func (s *HTTPServer) jobAllocations(resp http.ResponseWriter, req *http.Request,
 	jobName string) (interface{}, error) {
 	if req.Method != "GET" {
@@ -405,6 +459,7 @@ func (s *HTTPServer) jobUpdate(resp http.ResponseWriter, req *http.Request,
 
 	regReq := structs.JobRegisterRequest{
 		Job:            sJob,
+		Submission:     apiJobSubmissionToStructs(args.Submission),
 		EnforceIndex:   args.EnforceIndex,
 		JobModifyIndex: args.JobModifyIndex,
 		PolicyOverride: args.PolicyOverride,
@@ -487,6 +542,120 @@ func (s *HTTPServer) jobVersions(resp http.ResponseWriter, req *http.Request,
 	return out, nil
 }
 
+func (s *HTTPServer) jobDiff(resp http.ResponseWriter, req *http.Request,
+	jobName string) (interface{}, error) {
+
+	args := structs.JobDiffRequest{
+		JobID: jobName,
+	}
+
+	switch req.Method {
+	case "GET":
+		// Diff two versions of the job
+		query := req.URL.Query()
+		for param, version := range map[string]**uint64{"from": &args.FromVersion, "to": &args.ToVersion} {
+			str := query.Get(param)
+			if str == "" {
+				continue
+			}
+			v, err := strconv.ParseUint(str, 10, 64)
+			if err != nil {
+				return nil, CodedError(400, fmt.Sprintf("Failed to parse value of %q (%v) as a uint64: %v", param, str, err))
+			}
+			*version = &v
+		}
+
+		if str := query.Get("contextual"); str != "" {
+			contextual, err := strconv.ParseBool(str)
+			if err != nil {
+				return nil, CodedError(400, fmt.Sprintf("Failed to parse value of %q (%v) as a bool: %v", "contextual", str, err))
+			}
+			args.Contextual = contextual
+		}
+
+		if s.parse(resp, req, &args.Region, &args.QueryOptions) {
+			return nil, nil
+		}
+	case "PUT", "POST":
+		// Diff a version of the job against an unregistered job
+		var diffReq api.JobDiffRequest
+		if err := decodeBody(req, &diffReq); err != nil {
+			return nil, CodedError(400, err.Error())
+		}
+		if diffReq.Job == nil {
+			return nil, CodedError(400, "Job must be specified")
+		}
+		if diffReq.Job.ID == nil || *diffReq.Job.ID != jobName {
+			return nil, CodedError(400, "Job ID does not match")
+		}
+
+		if s.parse(resp, req, &args.Region, &args.QueryOptions) {
+			return nil, nil
+		}
+
+		// Default to the region of the agent, as when registering the job
+		if diffReq.Job.Region == nil || *diffReq.Job.Region == "" || *diffReq.Job.Region == api.GlobalRegion {
+			diffReq.Job.Region = &s.agent.config.Region
+		}
+
+		args.Job = ApiJobToStructJob(diffReq.Job)
+		args.FromVersion = diffReq.FromVersion
+		args.Contextual = diffReq.Contextual
+		args.Namespace = args.Job.Namespace
+	default:
+		return nil, CodedError(405, ErrInvalidMethod)
+	}
+
+	var out structs.JobDiffResponse
+	if err := s.agent.RPC("Job.Diff", &args, &out); err != nil {
+		return nil, err
+	}
+
+	setMeta(resp, &out.QueryMeta)
+	if out.Diff == nil {
+		return nil, CodedError(404, "job version not found")
+	}
+
+	return out.Diff, nil
+}
+
+func (s *HTTPServer) jobSubmission(resp http.ResponseWriter, req *http.Request,
+	jobName string) (interface{}, error) {
+
+	if req.Method != "GET" {
+		return nil, CodedError(405, ErrInvalidMethod)
+	}
+
+	versionStr := req.URL.Query().Get("version")
+	if versionStr == "" {
+		return nil, CodedError(400, "missing version")
+	}
+	version, err := strconv.ParseUint(versionStr, 10, 64)
+	if err != nil {
+		return nil, CodedError(400, fmt.Sprintf("Failed to parse value of %q (%v) as a uint64: %v", "version", versionStr, err))
+	}
+
+	args := structs.JobSubmissionRequest{
+		JobID:   jobName,
+		Version: version,
+	}
+	if s.parse(resp, req, &args.Region, &args.QueryOptions) {
+		return nil, nil
+	}
+
+	var out structs.JobSubmissionResponse
+	if err := s.agent.RPC("Job.GetJobSubmission", &args, &out); err != nil {
+		return nil, err
+	}
+
+	setMeta(resp, &out.QueryMeta)
+	if out.Submission == nil {
+		return nil, CodedError(404, "job submission not found")
+	}
+
+	return out.Submission, nil
+}
+
 func (s *HTTPServer) jobRevert(resp http.ResponseWriter, req *http.Request,
 	jobName string) (interface{}, error) {
 
@@ -545,6 +714,109 @@ func (s *HTTPServer) jobStable(resp http.ResponseWriter, req *http.Request,
 	return out, nil
 }
 
+func (s *HTTPServer) jobTag(resp http.ResponseWriter, req *http.Request,
+	jobName string) (interface{}, error) {
+
+	var tagRequest structs.JobTagRequest
+	switch req.Method {
+	case "PUT", "POST":
+		if err := decodeBody(req, &tagRequest); err != nil {
+			return nil, CodedError(400, err.Error())
+		}
+		if tagRequest.JobID == "" {
+			return nil, CodedError(400, "JobID must be specified")
+		}
+		if tagRequest.JobID != jobName {
+			return nil, CodedError(400, "Job ID does not match")
+		}
+		tagRequest.Unset = false
+	case "DELETE":
+		tagRequest.JobID = jobName
+		tagRequest.Name = req.URL.Query().Get("name")
+		tagRequest.Unset = true
+	default:
+		return nil, CodedError(405, ErrInvalidMethod)
+	}
+
+	if tagRequest.Name == "" {
+		return nil, CodedError(400, "Tag name must be specified")
+	}
+
+	s.parseWriteRequest(req, &tagRequest.WriteRequest)
+
+	var out structs.JobTagResponse
+	if err := s.agent.RPC("Job.TagVersion", &tagRequest, &out); err != nil {
+		return nil, err
+	}
+
+	setIndex(resp, out.Index)
+	return out, nil
+}
+
+func (s *HTTPServer) jobScale(resp http.ResponseWriter, req *http.Request,
+	jobName string) (interface{}, error) {
+
+	switch req.Method {
+	case "GET":
+		return s.jobScaleStatus(resp, req, jobName)
+	case "PUT", "POST":
+		return s.jobScaleAction(resp, req, jobName)
+	default:
+		return nil, CodedError(405, ErrInvalidMethod)
+	}
+}
+
+func (s *HTTPServer) jobScaleStatus(resp http.ResponseWriter, req *http.Request,
+	jobName string) (interface{}, error) {
+
+	args := structs.JobScaleStatusRequest{
+		JobID: jobName,
+	}
+	if s.parse(resp, req, &args.Region, &args.QueryOptions) {
+		return nil, nil
+	}
+
+	var out structs.JobScaleStatusResponse
+	if err := s.agent.RPC("Job.ScaleStatus", &args, &out); err != nil {
+		return nil, err
+	}
+
+	setMeta(resp, &out.QueryMeta)
+	if out.JobScaleStatus == nil {
+		return nil, CodedError(404, "job not found")
+	}
+
+	return out.JobScaleStatus, nil
+}
+
+func (s *HTTPServer) jobScaleAction(resp http.ResponseWriter, req *http.Request,
+	jobName string) (interface{}, error) {
+
+	var scaleRequest structs.JobScaleRequest
+	if err := decodeBody(req, &scaleRequest); err != nil {
+		return nil, CodedError(400, err.Error())
+	}
+	if scaleRequest.JobID == "" {
+		scaleRequest.JobID = jobName
+	}
+	if scaleRequest.JobID != jobName {
+		return nil, CodedError(400, "Job ID does not match")
+	}
+	if scaleRequest.Target[structs.ScalingTargetGroup] == "" {
+		return nil, CodedError(400, "Task group must be specified in the scaling target")
+	}
+
+	s.parseWriteRequest(req, &scaleRequest.WriteRequest)
+
+	var out structs.JobRegisterResponse
+	if err := s.agent.RPC("Job.Scale", &scaleRequest, &out); err != nil {
+		return nil, err
+	}
+
+	setIndex(resp, out.Index)
+	return out, nil
+}
+
 func (s *HTTPServer) jobSummaryRequest(resp http.ResponseWriter, req *http.Request, name string) (interface{}, error) {
 	args := structs.JobSummaryRequest{
 		JobID: name,
@@ -617,6 +889,19 @@ func (s *HTTPServer) JobsParseRequest(resp http.ResponseWriter, req *http.Reques
 	return jobStruct, nil
 }
 
+// apiJobSubmissionToStructs converts the source of a submitted job.
+func apiJobSubmissionToStructs(submission *api.JobSubmission) *structs.JobSubmission {
+	if submission == nil {
+		return nil
+	}
+	return &structs.JobSubmission{
+		Source:        submission.Source,
+		Format:        submission.Format,
+		VariableFlags: submission.VariableFlags,
+		Variables:     submission.Variables,
+	}
+}
+
 func ApiJobToStructJob(job *api.Job) *structs.Job {
 	job.Canonicalize()
 
@@ -670,13 +955,41 @@ func ApiJobToStructJob(job *api.Job) *structs.Job {
 		if job.Periodic.Spec != nil {
 			j.Periodic.Spec = *job.Periodic.Spec
 		}
+		j.Periodic.Specs = job.Periodic.Specs
+		if job.Periodic.Catchup != nil {
+			j.Periodic.Catchup = *job.Periodic.Catchup
+		}
+		if job.Periodic.CatchupWindow != nil {
+			j.Periodic.CatchupWindow = *job.Periodic.CatchupWindow
+		}
 	}
 
 	if job.ParameterizedJob != nil {
 		j.ParameterizedJob = &structs.ParameterizedJobConfig{
-			Payload:      job.ParameterizedJob.Payload,
-			MetaRequired: job.ParameterizedJob.MetaRequired,
-			MetaOptional: job.ParameterizedJob.MetaOptional,
+			Payload:       job.ParameterizedJob.Payload,
+			MetaRequired:  job.ParameterizedJob.MetaRequired,
+			MetaOptional:  job.ParameterizedJob.MetaOptional,
+			MaxConcurrent: job.ParameterizedJob.MaxConcurrent,
+		}
+	}
+
+	if job.Multiregion != nil {
+		j.Multiregion = &structs.Multiregion{
+			Strategy: &structs.MultiregionStrategy{
+				MaxParallel: *job.Multiregion.Strategy.MaxParallel,
+			},
+		}
+
+		if l := len(job.Multiregion.Regions); l != 0 {
+			j.Multiregion.Regions = make([]*structs.MultiregionRegion, l)
+			for i, region := range job.Multiregion.Regions {
+				j.Multiregion.Regions[i] = &structs.MultiregionRegion{
+					Name:        region.Name,
+					Count:       *region.Count,
+					Datacenters: region.Datacenters,
+					Meta:        region.Meta,
+				}
+			}
 		}
 	}
 
@@ -692,6 +1005,29 @@ func ApiJobToStructJob(job *api.Job) *structs.Job {
 	return j
 }
 
+// ApiScalingPolicyToStructs converts the scaling policy of a task group. An
+// unset maximum is rejected by validation, an unset minimum defaults to the
+// count of the task group and policies are enabled by default.
+func ApiScalingPolicyToStructs(count int, ap *api.ScalingPolicy) *structs.ScalingPolicy {
+	p := &structs.ScalingPolicy{
+		ID:      ap.ID,
+		Policy:  ap.Policy,
+		Enabled: true,
+		Max:     -1,
+		Min:     int64(count),
+	}
+	if ap.Enabled != nil {
+		p.Enabled = *ap.Enabled
+	}
+	if ap.Max != nil {
+		p.Max = *ap.Max
+	}
+	if ap.Min != nil {
+		p.Min = *ap.Min
+	}
+	return p
+}
+
 func ApiTgToStructsTG(taskGroup *api.TaskGroup, tg *structs.TaskGroup) {
 	tg.Name = *taskGroup.Name
 	tg.Count = *taskGroup.Count
@@ -701,6 +1037,14 @@ func ApiTgToStructsTG(taskGroup *api.TaskGroup, tg *structs.TaskGroup) {
 	tg.Networks = ApiNetworkResourceToStructs(taskGroup.Networks)
 	tg.Services = ApiServicesToStructs(taskGroup.Services)
 
+	if taskGroup.MaxClientDisconnect != nil {
+		tg.MaxClientDisconnect = helper.TimeToPtr(*taskGroup.MaxClientDisconnect)
+	}
+
+	if taskGroup.Scaling != nil {
+		tg.Scaling = ApiScalingPolicyToStructs(tg.Count, taskGroup.Scaling)
+	}
+
 	tg.RestartPolicy = &structs.RestartPolicy{
 		Attempts: *taskGroup.RestartPolicy.Attempts,
 		Interval: *taskGroup.RestartPolicy.Interval,
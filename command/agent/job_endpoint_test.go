@@ -963,6 +963,62 @@ func TestHTTP_JobDeployment(t *testing.T) {
 	})
 }
 
+func TestHTTP_JobSubmission(t *testing.T) {
+	t.Parallel()
+	httpTest(t, nil, func(s *TestAgent) {
+		require := require.New(t)
+
+		// Register the job with its source
+		job := MockJob()
+		args := api.JobRegisterRequest{
+			Job: job,
+			Submission: &api.JobSubmission{
+				Source:        `job "foo" {}`,
+				Format:        "hcl2",
+				VariableFlags: map[string]string{"dc": "dc1"},
+				Variables:     `priority = 50`,
+			},
+			WriteRequest: api.WriteRequest{
+				Region:    "global",
+				Namespace: api.DefaultNamespace,
+			},
+		}
+		req, err := http.NewRequest("PUT", "/v1/job/"+*job.ID, encodeReq(args))
+		require.NoError(err)
+		_, err = s.Server.JobSpecificRequest(httptest.NewRecorder(), req)
+		require.NoError(err)
+
+		// Fetch the source of the version
+		req, err = http.NewRequest("GET", "/v1/job/"+*job.ID+"/submission?version=0", nil)
+		require.NoError(err)
+		respW := httptest.NewRecorder()
+		obj, err := s.Server.JobSpecificRequest(respW, req)
+		require.NoError(err)
+		require.NotEmpty(respW.HeaderMap.Get("X-Nomad-Index"))
+
+		sub := obj.(*structs.JobSubmission)
+		require.Equal(`job "foo" {}`, sub.Source)
+		require.Equal("hcl2", sub.Format)
+		require.Equal(map[string]string{"dc": "dc1"}, sub.VariableFlags)
+		require.Equal(`priority = 50`, sub.Variables)
+		require.Equal(*job.ID, sub.JobID)
+
+		// Unknown versions are not found
+		req, err = http.NewRequest("GET", "/v1/job/"+*job.ID+"/submission?version=1", nil)
+		require.NoError(err)
+		_, err = s.Server.JobSpecificRequest(httptest.NewRecorder(), req)
+		require.Error(err)
+		require.Equal(404, err.(HTTPCodedError).Code())
+
+		// The version is required
+		req, err = http.NewRequest("GET", "/v1/job/"+*job.ID+"/submission", nil)
+		require.NoError(err)
+		_, err = s.Server.JobSpecificRequest(httptest.NewRecorder(), req)
+		require.Error(err)
+		require.Equal(400, err.(HTTPCodedError).Code())
+	})
+}
+
 func TestHTTP_JobVersions(t *testing.T) {
 	t.Parallel()
 	httpTest(t, nil, func(s *TestAgent) {
@@ -1084,6 +1140,56 @@ func TestHTTP_PeriodicForce(t *testing.T) {
 	})
 }
 
+func TestHTTP_PeriodicStatus(t *testing.T) {
+	t.Parallel()
+	httpTest(t, nil, func(s *TestAgent) {
+		require := require.New(t)
+
+		// Create and register a periodic job.
+		job := mock.PeriodicJob()
+		args := structs.JobRegisterRequest{
+			Job: job,
+			WriteRequest: structs.WriteRequest{
+				Region:    "global",
+				Namespace: structs.DefaultNamespace,
+			},
+		}
+		var resp structs.JobRegisterResponse
+		require.NoError(s.Agent.RPC("Job.Register", &args, &resp))
+
+		// Make the HTTP request
+		req, err := http.NewRequest("GET", "/v1/job/"+job.ID+"/periodic/status?next=2", nil)
+		require.NoError(err)
+		respW := httptest.NewRecorder()
+
+		// Make the request
+		obj, err := s.Server.JobSpecificRequest(respW, req)
+		require.NoError(err)
+
+		// Check for the index
+		require.NotEmpty(respW.HeaderMap.Get("X-Nomad-Index"))
+
+		// Check the response
+		r := obj.(structs.PeriodicStatusResponse)
+		require.Len(r.Next, 2)
+		require.NotNil(r.LastLaunch)
+		require.Empty(r.Launches)
+
+		// An invalid count is rejected
+		req, err = http.NewRequest("GET", "/v1/job/"+job.ID+"/periodic/status?next=foo", nil)
+		require.NoError(err)
+		_, err = s.Server.JobSpecificRequest(httptest.NewRecorder(), req)
+		require.Error(err)
+
+		// Counts above the limit are rejected
+		req, err = http.NewRequest("GET", "/v1/job/"+job.ID+"/periodic/status?next=1000000000", nil)
+		require.NoError(err)
+		_, err = s.Server.JobSpecificRequest(httptest.NewRecorder(), req)
+		require.Error(err)
+		require.Contains(err.Error(), "must be between 0 and")
+	})
+}
+
 func TestHTTP_JobPlan(t *testing.T) {
 	t.Parallel()
 	httpTest(t, nil, func(s *TestAgent) {
@@ -1370,6 +1476,199 @@ func TestHTTP_JobStable(t *testing.T) {
 	})
 }
 
+func TestHTTP_JobDiff(t *testing.T) {
+	t.Parallel()
+	httpTest(t, nil, func(s *TestAgent) {
+		require := require.New(t)
+
+		// Register two versions of the job
+		job := mock.Job()
+		regReq := structs.JobRegisterRequest{
+			Job: job,
+			WriteRequest: structs.WriteRequest{
+				Region:    "global",
+				Namespace: structs.DefaultNamespace,
+			},
+		}
+		var regResp structs.JobRegisterResponse
+		require.NoError(s.Agent.RPC("Job.Register", &regReq, &regResp))
+
+		regReq.Job = job.Copy()
+		regReq.Job.Priority = 60
+		require.NoError(s.Agent.RPC("Job.Register", &regReq, &regResp))
+
+		// Diff the two versions
+		req, err := http.NewRequest("GET", "/v1/job/"+job.ID+"/diff?from=0&to=1", nil)
+		require.NoError(err)
+		respW := httptest.NewRecorder()
+		obj, err := s.Server.JobSpecificRequest(respW, req)
+		require.NoError(err)
+		diff := obj.(*structs.JobDiff)
+		require.Equal(structs.DiffTypeEdited, diff.Type)
+		require.Len(diff.Fields, 1)
+		require.Equal("Priority", diff.Fields[0].Name)
+		require.NotEmpty(respW.HeaderMap.Get("X-Nomad-Index"))
+
+		// Invalid versions are rejected
+		req, err = http.NewRequest("GET", "/v1/job/"+job.ID+"/diff?from=foo", nil)
+		require.NoError(err)
+		_, err = s.Server.JobSpecificRequest(httptest.NewRecorder(), req)
+		require.Error(err)
+		require.Equal(400, err.(HTTPCodedError).Code())
+
+		// Missing versions are not found
+		req, err = http.NewRequest("GET", "/v1/job/"+job.ID+"/diff?from=5", nil)
+		require.NoError(err)
+		_, err = s.Server.JobSpecificRequest(httptest.NewRecorder(), req)
+		require.Error(err)
+		require.Equal(404, err.(HTTPCodedError).Code())
+
+		// Diff the first version against a local job
+		local := MockJob()
+		local.ID = &job.ID
+		args := api.JobDiffRequest{
+			Job:         local,
+			FromVersion: helper.Uint64ToPtr(0),
+		}
+		req, err = http.NewRequest("POST", "/v1/job/"+job.ID+"/diff", encodeReq(args))
+		require.NoError(err)
+		obj, err = s.Server.JobSpecificRequest(httptest.NewRecorder(), req)
+		require.NoError(err)
+		require.Equal(structs.DiffTypeEdited, obj.(*structs.JobDiff).Type)
+
+		// The local job must match the job ID
+		args.Job = MockJob()
+		req, err = http.NewRequest("POST", "/v1/job/"+job.ID+"/diff", encodeReq(args))
+		require.NoError(err)
+		_, err = s.Server.JobSpecificRequest(httptest.NewRecorder(), req)
+		require.Error(err)
+		require.Equal(400, err.(HTTPCodedError).Code())
+	})
+}
+
+func TestHTTP_JobTag(t *testing.T) {
+	t.Parallel()
+	httpTest(t, nil, func(s *TestAgent) {
+		require := require.New(t)
+
+		// Create the job
+		job := mock.Job()
+		regReq := structs.JobRegisterRequest{
+			Job: job,
+			WriteRequest: structs.WriteRequest{
+				Region:    "global",
+				Namespace: structs.DefaultNamespace,
+			},
+		}
+		var regResp structs.JobRegisterResponse
+		require.NoError(s.Agent.RPC("Job.Register", &regReq, &regResp))
+
+		// Tag the current version
+		args := structs.JobTagRequest{
+			JobID:       job.ID,
+			Name:        "release",
+			Description: "first release",
+		}
+		req, err := http.NewRequest("PUT", "/v1/job/"+job.ID+"/tag", encodeReq(args))
+		require.NoError(err)
+		respW := httptest.NewRecorder()
+		obj, err := s.Server.JobSpecificRequest(respW, req)
+		require.NoError(err)
+		require.NotZero(obj.(structs.JobTagResponse).Index)
+		require.NotEmpty(respW.HeaderMap.Get("X-Nomad-Index"))
+
+		out, err := s.Agent.server.State().JobByID(nil, job.Namespace, job.ID)
+		require.NoError(err)
+		require.Equal("release", out.VersionTag.Name)
+		require.Equal("first release", out.VersionTag.Description)
+
+		// The tag name is required
+		req, err = http.NewRequest("DELETE", "/v1/job/"+job.ID+"/tag", nil)
+		require.NoError(err)
+		_, err = s.Server.JobSpecificRequest(httptest.NewRecorder(), req)
+		require.Error(err)
+		require.Equal(400, err.(HTTPCodedError).Code())
+
+		// Remove the tag
+		req, err = http.NewRequest("DELETE", "/v1/job/"+job.ID+"/tag?name=release", nil)
+		require.NoError(err)
+		_, err = s.Server.JobSpecificRequest(httptest.NewRecorder(), req)
+		require.NoError(err)
+
+		out, err = s.Agent.server.State().JobByID(nil, job.Namespace, job.ID)
+		require.NoError(err)
+		require.Nil(out.VersionTag)
+	})
+}
+
+func TestHTTP_JobScale(t *testing.T) {
+	t.Parallel()
+	httpTest(t, nil, func(s *TestAgent) {
+		require := require.New(t)
+
+		// Create the job
+		job := mock.Job()
+		regReq := structs.JobRegisterRequest{
+			Job: job,
+			WriteRequest: structs.WriteRequest{
+				Region:    "global",
+				Namespace: structs.DefaultNamespace,
+			},
+		}
+		var regResp structs.JobRegisterResponse
+		require.NoError(s.Agent.RPC("Job.Register", &regReq, &regResp))
+
+		// Scale the task group
+		args := structs.JobScaleRequest{
+			Target: map[string]string{
+				structs.ScalingTargetGroup: job.TaskGroups[0].Name,
+			},
+			Count:   helper.Int64ToPtr(4),
+			Message: "scale in",
+		}
+		req, err := http.NewRequest("PUT", "/v1/job/"+job.ID+"/scale", encodeReq(args))
+		require.NoError(err)
+		respW := httptest.NewRecorder()
+		obj, err := s.Server.JobSpecificRequest(respW, req)
+		require.NoError(err)
+		resp := obj.(structs.JobRegisterResponse)
+		require.NotEmpty(resp.EvalID)
+		require.NotEmpty(respW.HeaderMap.Get("X-Nomad-Index"))
+
+		out, err := s.Agent.server.State().JobByID(nil, job.Namespace, job.ID)
+		require.NoError(err)
+		require.Equal(4, out.TaskGroups[0].Count)
+
+		// The task group is required
+		req, err = http.NewRequest("PUT", "/v1/job/"+job.ID+"/scale", encodeReq(structs.JobScaleRequest{}))
+		require.NoError(err)
+		_, err = s.Server.JobSpecificRequest(httptest.NewRecorder(), req)
+		require.Error(err)
+		require.Equal(400, err.(HTTPCodedError).Code())
+
+		// Read the scaling status
+		req, err = http.NewRequest("GET", "/v1/job/"+job.ID+"/scale", nil)
+		require.NoError(err)
+		respW = httptest.NewRecorder()
+		obj, err = s.Server.JobSpecificRequest(respW, req)
+		require.NoError(err)
+		require.NotEmpty(respW.HeaderMap.Get("X-Nomad-Index"))
+
+		status := obj.(*structs.JobScaleStatus)
+		tgStatus := status.TaskGroups[job.TaskGroups[0].Name]
+		require.Equal(4, tgStatus.Desired)
+		require.Len(tgStatus.Events, 1)
+		require.Equal("scale in", tgStatus.Events[0].Message)
+
+		// Unknown jobs are not found
+		req, err = http.NewRequest("GET", "/v1/job/foo/scale", nil)
+		require.NoError(err)
+		_, err = s.Server.JobSpecificRequest(httptest.NewRecorder(), req)
+		require.Error(err)
+		require.Equal(404, err.(HTTPCodedError).Code())
+	})
+}
+
 func TestJobs_ApiJobToStructsJob(t *testing.T) {
 	apiJob := &api.Job{
 		Stop:        helper.BoolToPtr(true),
@@ -1422,14 +1721,28 @@ func TestJobs_ApiJobToStructsJob(t *testing.T) {
 		Periodic: &api.PeriodicConfig{
 			Enabled:         helper.BoolToPtr(true),
 			Spec:            helper.StringToPtr("spec"),
+			Specs:           []string{"spec2"},
 			SpecType:        helper.StringToPtr("cron"),
 			ProhibitOverlap: helper.BoolToPtr(true),
 			TimeZone:        helper.StringToPtr("test zone"),
+			Catchup:         helper.StringToPtr("all"),
+			CatchupWindow:   helper.TimeToPtr(time.Hour),
 		},
 		ParameterizedJob: &api.ParameterizedJobConfig{
-			Payload:      "payload",
-			MetaRequired: []string{"a", "b"},
-			MetaOptional: []string{"c", "d"},
+			Payload:       "payload",
+			MetaRequired:  []string{"a", "b"},
+			MetaOptional:  []string{"c", "d"},
+			MaxConcurrent: 3,
+		},
+		Multiregion: &api.Multiregion{
+			Regions: []*api.MultiregionRegion{
+				{
+					Name:        "west",
+					Count:       helper.IntToPtr(2),
+					Datacenters: []string{"west-1"},
+					Meta:        map[string]string{"region_code": "W"},
+				},
+			},
 		},
 		Payload: []byte("payload"),
 		Meta: map[string]string{
@@ -1761,14 +2074,31 @@ func TestJobs_ApiJobToStructsJob(t *testing.T) {
 		Periodic: &structs.PeriodicConfig{
 			Enabled:         true,
 			Spec:            "spec",
+			Specs:           []string{"spec2"},
 			SpecType:        "cron",
 			ProhibitOverlap: true,
 			TimeZone:        "test zone",
+			Catchup:         "all",
+			CatchupWindow:   time.Hour,
 		},
 		ParameterizedJob: &structs.ParameterizedJobConfig{
-			Payload:      "payload",
-			MetaRequired: []string{"a", "b"},
-			MetaOptional: []string{"c", "d"},
+			Payload:       "payload",
+			MetaRequired:  []string{"a", "b"},
+			MetaOptional:  []string{"c", "d"},
+			MaxConcurrent: 3,
+		},
+		Multiregion: &structs.Multiregion{
+			Strategy: &structs.MultiregionStrategy{
+				MaxParallel: 1,
+			},
+			Regions: []*structs.MultiregionRegion{
+				{
+					Name:        "west",
+					Count:       2,
+					Datacenters: []string{"west-1"},
+					Meta:        map[string]string{"region_code": "W"},
+				},
+			},
 		},
 		Payload: []byte("payload"),
 		Meta: map[string]string{
@@ -0,0 +1,257 @@
+package monitor
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	log "github.com/hashicorp/go-hclog"
+	sframer "github.com/hashicorp/nomad/client/lib/streamframer"
+	cstructs "github.com/hashicorp/nomad/client/structs"
+	"github.com/hashicorp/nomad/helper/logging"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/ugorji/go/codec"
+)
+
+const (
+	// streamFramesBuffer is the number of stream frames that will be buffered
+	// before back pressure is applied on the stream framer.
+	streamFramesBuffer = 32
+
+	// streamFrameSize is the maximum number of bytes to send in a single frame
+	streamFrameSize = 64 * 1024
+
+	// streamHeartbeatRate is the rate at which a heartbeat will occur to detect
+	// a closed connection without sending any additional data
+	streamHeartbeatRate = 1 * time.Second
+
+	// streamBatchWindow is the window in which log lines are batched before
+	// being flushed if the frame size has not been hit.
+	streamBatchWindow = 200 * time.Millisecond
+
+	// defaultDroppedInterval is the interval at which a message is sent when
+	// log lines were dropped because the consumer is too slow.
+	defaultDroppedInterval = 3 * time.Second
+)
+
+// Monitor provides a mechanism to stream the logs of an agent at a level
+// independent of the level of its logger.
+type Monitor interface {
+	// Start registers the monitor with the logger and returns the channel
+	// receiving the formatted log lines.
+	Start() <-chan []byte
+
+	// Stop deregisters the monitor from the logger and stops sending log
+	// lines.
+	Stop()
+}
+
+// monitor implements Monitor. It is registered as a sink of an
+// InterceptLogger and formats the intercepted messages with its own logger.
+type monitor struct {
+	// sink formats the intercepted messages and writes them to the monitor
+	sink  log.Logger
+	level log.Level
+
+	// logger is the logger being monitored
+	logger logging.InterceptLogger
+
+	// logCh receives the formatted log lines
+	logCh chan []byte
+
+	// droppedCount is the number of log lines dropped since the last time
+	// it was reported
+	droppedCount    int
+	droppedInterval time.Duration
+	droppedLock     sync.Mutex
+
+	doneCh   chan struct{}
+	stopOnce sync.Once
+}
+
+// New returns a Monitor of the logger, buffering up to buf log lines. The
+// options set the level and the format of the log lines.
+func New(buf int, logger logging.InterceptLogger, opts *log.LoggerOptions) Monitor {
+	m := &monitor{
+		level:           opts.Level,
+		logger:          logger,
+		logCh:           make(chan []byte, buf),
+		droppedInterval: defaultDroppedInterval,
+		doneCh:          make(chan struct{}),
+	}
+
+	opts.Output = m
+	m.sink = log.New(opts)
+	return m
+}
+
+func (m *monitor) Start() <-chan []byte {
+	m.logger.RegisterSink(m)
+
+	// Periodically report the dropped log lines
+	go func() {
+		ticker := time.NewTicker(m.droppedInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				m.droppedLock.Lock()
+				dropped := m.droppedCount
+				m.droppedCount = 0
+				m.droppedLock.Unlock()
+
+				if dropped == 0 {
+					continue
+				}
+
+				msg := fmt.Sprintf("[WARN] Monitor dropped %d logs during monitor request\n", dropped)
+				select {
+				case m.logCh <- []byte(msg):
+				case <-m.doneCh:
+					return
+				}
+			case <-m.doneCh:
+				return
+			}
+		}
+	}()
+
+	return m.logCh
+}
+
+func (m *monitor) Stop() {
+	m.stopOnce.Do(func() {
+		m.logger.DeregisterSink(m)
+		close(m.doneCh)
+	})
+}
+
+// Level returns the level of the monitored logs.
+func (m *monitor) Level() log.Level {
+	return m.level
+}
+
+// Accept formats an intercepted message with the sink logger.
+func (m *monitor) Accept(name string, level log.Level, msg string, args ...interface{}) {
+	logger := m.sink.ResetNamed(name)
+	switch level {
+	case log.Trace:
+		logger.Trace(msg, args...)
+	case log.Debug:
+		logger.Debug(msg, args...)
+	case log.Info:
+		logger.Info(msg, args...)
+	case log.Warn:
+		logger.Warn(msg, args...)
+	case log.Error:
+		logger.Error(msg, args...)
+	}
+}
+
+// Write is called by the sink logger with each formatted log line. Log
+// lines are dropped rather than blocking the logger when the buffer is full.
+func (m *monitor) Write(p []byte) (n int, err error) {
+	// The logger reuses its buffer
+	line := make([]byte, len(p))
+	copy(line, p)
+
+	select {
+	case m.logCh <- line:
+	default:
+		m.droppedLock.Lock()
+		m.droppedCount++
+		m.droppedLock.Unlock()
+	}
+	return len(p), nil
+}
+
+// Stream sends the log lines of the monitor on a streaming RPC connection
+// until the remote side closes it. Log lines are sent as JSON encoded stream
+// frames, or unframed when plainText is set.
+func Stream(conn io.ReadWriteCloser, encoder *codec.Encoder, m Monitor, plainText bool) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	logCh := m.Start()
+	defer m.Stop()
+
+	frames := make(chan *sframer.StreamFrame, streamFramesBuffer)
+	framer := sframer.NewStreamFramer(frames, streamHeartbeatRate, streamBatchWindow, streamFrameSize)
+	framer.Run()
+	defer framer.Destroy()
+
+	errCh := make(chan error)
+
+	// Create a goroutine to detect the remote side closing
+	go func() {
+		for {
+			if _, err := conn.Read(nil); err != nil {
+				if err == io.EOF || err == io.ErrClosedPipe {
+					// One end of the pipe was explicitly closed, exit cleanly
+					cancel()
+					return
+				}
+				select {
+				case errCh <- err:
+				case <-ctx.Done():
+				}
+				return
+			}
+		}
+	}()
+
+	// Send the log lines to the framer
+	go func() {
+		for {
+			select {
+			case line := <-logCh:
+				if err := framer.Send("", "log", line, 0); err != nil {
+					select {
+					case errCh <- err:
+					case <-ctx.Done():
+					}
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	buf := new(bytes.Buffer)
+	frameCodec := codec.NewEncoder(buf, structs.JsonHandle)
+	for {
+		select {
+		case err := <-errCh:
+			return err
+		case <-ctx.Done():
+			return nil
+		case frame, ok := <-frames:
+			if !ok {
+				return nil
+			}
+
+			var resp cstructs.StreamErrWrapper
+			if plainText {
+				resp.Payload = frame.Data
+			} else {
+				if err := frameCodec.Encode(frame); err != nil {
+					return err
+				}
+				frameCodec.Reset(buf)
+
+				resp.Payload = buf.Bytes()
+				buf.Reset()
+			}
+
+			if err := encoder.Encode(resp); err != nil {
+				return err
+			}
+			encoder.Reset(conn)
+		}
+	}
+}
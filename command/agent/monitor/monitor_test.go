@@ -0,0 +1,145 @@
+package monitor
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	log "github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/nomad/helper/logging"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMonitor_Start(t *testing.T) {
+	t.Parallel()
+
+	logger := logging.NewInterceptLogger(&log.LoggerOptions{
+		Level: log.Error,
+	})
+
+	m := New(512, logger, &log.LoggerOptions{
+		Level: log.Debug,
+	})
+
+	logCh := m.Start()
+	defer m.Stop()
+
+	go func() {
+		logger.Debug("test log")
+		time.Sleep(10 * time.Millisecond)
+	}()
+
+	for {
+		select {
+		case log := <-logCh:
+			require.Contains(t, string(log), "[DEBUG] test log")
+			return
+		case <-time.After(3 * time.Second):
+			t.Fatal("Expected to receive from log channel")
+		}
+	}
+}
+
+func TestMonitor_Level(t *testing.T) {
+	t.Parallel()
+
+	logger := logging.NewInterceptLogger(&log.LoggerOptions{
+		Level: log.Error,
+	})
+
+	m := New(512, logger, &log.LoggerOptions{
+		Level: log.Info,
+	})
+
+	logCh := m.Start()
+	defer m.Stop()
+
+	logger.Named("sub").Debug("debug log")
+	logger.Named("sub").Info("info log", "key", "value")
+
+	select {
+	case log := <-logCh:
+		line := string(log)
+		require.Contains(t, line, "[INFO ] sub: info log: key=value")
+		require.NotContains(t, line, "debug log")
+	case <-time.After(3 * time.Second):
+		t.Fatal("Expected to receive from log channel")
+	}
+}
+
+// Ensure number of dropped messages are logged
+func TestMonitor_DroppedMessages(t *testing.T) {
+	t.Parallel()
+
+	logger := logging.NewInterceptLogger(&log.LoggerOptions{
+		Level: log.Warn,
+	})
+
+	m := New(5, logger, &log.LoggerOptions{
+		Level: log.Debug,
+	}).(*monitor)
+	m.droppedInterval = 5 * time.Millisecond
+
+	doneCh := make(chan struct{})
+	defer close(doneCh)
+
+	logCh := m.Start()
+	defer m.Stop()
+
+	for i := 0; i <= 100; i++ {
+		logger.Debug(fmt.Sprintf("test message %d", i))
+	}
+
+	received := ""
+
+	passed := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case recv := <-logCh:
+				received += string(recv)
+				if strings.Contains(received, "[WARN] Monitor dropped 96 logs during monitor request") {
+					close(passed)
+					return
+				}
+			case <-doneCh:
+				return
+			}
+		}
+	}()
+
+	select {
+	case <-passed:
+	case <-time.After(2 * time.Second):
+		require.Fail(t, "expected to see warn dropped messages")
+	}
+}
+
+func TestMonitor_Stop(t *testing.T) {
+	t.Parallel()
+
+	logger := logging.NewInterceptLogger(&log.LoggerOptions{
+		Level: log.Error,
+	})
+
+	m := New(512, logger, &log.LoggerOptions{
+		Level: log.Debug,
+	})
+
+	logCh := m.Start()
+	m.Stop()
+
+	// Stopping twice is safe
+	m.Stop()
+
+	// The logger doesn't send messages to a stopped monitor
+	require.False(t, logger.IsDebug())
+	logger.Debug("test log")
+
+	select {
+	case log := <-logCh:
+		t.Fatalf("unexpected log after stop: %s", log)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
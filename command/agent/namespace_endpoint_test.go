@@ -0,0 +1,161 @@
+package agent
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTP_NamespaceList(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	httpTest(t, nil, func(s *TestAgent) {
+		ns1 := mock.Namespace()
+		ns2 := mock.Namespace()
+		args := structs.NamespaceUpsertRequest{
+			Namespaces:   []*structs.Namespace{ns1, ns2},
+			WriteRequest: structs.WriteRequest{Region: "global"},
+		}
+		var resp structs.GenericResponse
+		require.NoError(s.Agent.RPC("Namespace.UpsertNamespaces", &args, &resp))
+
+		// Make the HTTP request
+		req, err := http.NewRequest("GET", "/v1/namespaces", nil)
+		require.NoError(err)
+		respW := httptest.NewRecorder()
+
+		// Make the request
+		obj, err := s.Server.NamespacesRequest(respW, req)
+		require.NoError(err)
+
+		// Check for the index
+		require.NotZero(respW.HeaderMap.Get("X-Nomad-Index"))
+		require.Equal("true", respW.HeaderMap.Get("X-Nomad-KnownLeader"))
+		require.NotZero(respW.HeaderMap.Get("X-Nomad-LastContact"))
+
+		// Check the output, which includes the default namespace
+		require.Len(obj.([]*structs.Namespace), 3)
+	})
+}
+
+func TestHTTP_NamespaceQuery(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	httpTest(t, nil, func(s *TestAgent) {
+		ns1 := mock.Namespace()
+		args := structs.NamespaceUpsertRequest{
+			Namespaces:   []*structs.Namespace{ns1},
+			WriteRequest: structs.WriteRequest{Region: "global"},
+		}
+		var resp structs.GenericResponse
+		require.NoError(s.Agent.RPC("Namespace.UpsertNamespaces", &args, &resp))
+
+		// Make the HTTP request
+		req, err := http.NewRequest("GET", "/v1/namespace/"+ns1.Name, nil)
+		require.NoError(err)
+		respW := httptest.NewRecorder()
+
+		// Make the request
+		obj, err := s.Server.NamespaceSpecificRequest(respW, req)
+		require.NoError(err)
+		require.NotZero(respW.HeaderMap.Get("X-Nomad-Index"))
+		require.Equal(ns1.Name, obj.(*structs.Namespace).Name)
+
+		// Query a missing namespace
+		req, err = http.NewRequest("GET", "/v1/namespace/missing", nil)
+		require.NoError(err)
+		respW = httptest.NewRecorder()
+		_, err = s.Server.NamespaceSpecificRequest(respW, req)
+		require.Error(err)
+		require.Equal(404, err.(HTTPCodedError).Code())
+	})
+}
+
+func TestHTTP_NamespaceCreate(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	httpTest(t, nil, func(s *TestAgent) {
+		// Make the HTTP request
+		ns1 := mock.Namespace()
+		buf := encodeReq(ns1)
+		req, err := http.NewRequest("PUT", "/v1/namespace", buf)
+		require.NoError(err)
+		respW := httptest.NewRecorder()
+
+		// Make the request
+		obj, err := s.Server.NamespaceCreateRequest(respW, req)
+		require.NoError(err)
+		require.Nil(obj)
+		require.NotZero(respW.HeaderMap.Get("X-Nomad-Index"))
+
+		// Check the namespace was created
+		out, err := s.Agent.server.State().NamespaceByName(nil, ns1.Name)
+		require.NoError(err)
+		require.NotNil(out)
+		require.Equal(ns1.Description, out.Description)
+	})
+}
+
+func TestHTTP_NamespaceUpdate(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	httpTest(t, nil, func(s *TestAgent) {
+		// Make the HTTP request
+		ns1 := mock.Namespace()
+		buf := encodeReq(ns1)
+		req, err := http.NewRequest("PUT", "/v1/namespace/"+ns1.Name, buf)
+		require.NoError(err)
+		respW := httptest.NewRecorder()
+
+		// Make the request
+		obj, err := s.Server.NamespaceSpecificRequest(respW, req)
+		require.NoError(err)
+		require.Nil(obj)
+		require.NotZero(respW.HeaderMap.Get("X-Nomad-Index"))
+
+		out, err := s.Agent.server.State().NamespaceByName(nil, ns1.Name)
+		require.NoError(err)
+		require.NotNil(out)
+
+		// The name must match the path
+		req, err = http.NewRequest("PUT", "/v1/namespace/other", encodeReq(ns1))
+		require.NoError(err)
+		respW = httptest.NewRecorder()
+		_, err = s.Server.NamespaceSpecificRequest(respW, req)
+		require.Error(err)
+		require.Equal(400, err.(HTTPCodedError).Code())
+	})
+}
+
+func TestHTTP_NamespaceDelete(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	httpTest(t, nil, func(s *TestAgent) {
+		ns1 := mock.Namespace()
+		args := structs.NamespaceUpsertRequest{
+			Namespaces:   []*structs.Namespace{ns1},
+			WriteRequest: structs.WriteRequest{Region: "global"},
+		}
+		var resp structs.GenericResponse
+		require.NoError(s.Agent.RPC("Namespace.UpsertNamespaces", &args, &resp))
+
+		// Make the HTTP request
+		req, err := http.NewRequest("DELETE", "/v1/namespace/"+ns1.Name, nil)
+		require.NoError(err)
+		respW := httptest.NewRecorder()
+
+		// Make the request
+		obj, err := s.Server.NamespaceSpecificRequest(respW, req)
+		require.NoError(err)
+		require.Nil(obj)
+		require.NotZero(respW.HeaderMap.Get("X-Nomad-Index"))
+
+		out, err := s.Agent.server.State().NamespaceByName(nil, ns1.Name)
+		require.NoError(err)
+		require.Nil(out)
+	})
+}
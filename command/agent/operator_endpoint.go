@@ -274,3 +274,56 @@ func (s *HTTPServer) schedulerUpdateConfig(resp http.ResponseWriter, req *http.R
 	setIndex(resp, reply.Index)
 	return reply, nil
 }
+
+// OperatorEvalBroker is used to inspect the evaluations held by the eval broker
+// and to drop or re-enqueue specific evaluations.
+func (s *HTTPServer) OperatorEvalBroker(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	switch req.Method {
+	case "GET":
+		return s.evalBrokerInspect(resp, req)
+
+	case "PUT", "POST":
+		return s.evalBrokerUpdate(resp, req)
+
+	default:
+		return nil, CodedError(405, ErrInvalidMethod)
+	}
+}
+
+func (s *HTTPServer) evalBrokerInspect(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	var args structs.GenericRequest
+	if done := s.parse(resp, req, &args.Region, &args.QueryOptions); done {
+		return nil, nil
+	}
+
+	var reply structs.EvalBrokerInspectResponse
+	if err := s.agent.RPC("Operator.EvalBrokerInspect", &args, &reply); err != nil {
+		return nil, err
+	}
+	setMeta(resp, &reply.QueryMeta)
+
+	return reply, nil
+}
+
+func (s *HTTPServer) evalBrokerUpdate(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	var update api.EvalBrokerUpdateRequest
+	if err := decodeBody(req, &update); err != nil {
+		return nil, CodedError(http.StatusBadRequest, fmt.Sprintf("Error parsing eval broker update: %v", err))
+	}
+	if len(update.EvalIDs) == 0 {
+		return nil, CodedError(http.StatusBadRequest, "Must specify at least one evaluation ID")
+	}
+
+	args := structs.EvalBrokerUpdateRequest{
+		Action:  update.Action,
+		EvalIDs: update.EvalIDs,
+	}
+	s.parseWriteRequest(req, &args.WriteRequest)
+
+	var reply structs.EvalBrokerUpdateResponse
+	if err := s.agent.RPC("Operator.EvalBrokerUpdate", &args, &reply); err != nil {
+		return nil, err
+	}
+	setIndex(resp, reply.Index)
+	return reply, nil
+}
@@ -382,3 +382,43 @@ func TestOperator_SchedulerCASConfiguration(t *testing.T) {
 		require.False(reply.SchedulerConfig.PreemptionConfig.BatchSchedulerEnabled)
 	})
 }
+
+func TestOperator_EvalBroker(t *testing.T) {
+	t.Parallel()
+	httpTest(t, nil, func(s *TestAgent) {
+		require := require.New(t)
+
+		// Inspect the empty broker
+		req, _ := http.NewRequest("GET", "/v1/operator/evals/broker", nil)
+		resp := httptest.NewRecorder()
+		obj, err := s.Server.OperatorEvalBroker(resp, req)
+		require.NoError(err)
+		require.Equal(200, resp.Code)
+		out, ok := obj.(structs.EvalBrokerInspectResponse)
+		require.True(ok)
+		require.Empty(out.Ready)
+		require.NotZero(resp.Header().Get("X-Nomad-Index"))
+
+		// Updates require evaluation IDs
+		body := bytes.NewBuffer([]byte(`{"Action": "drop"}`))
+		req, _ = http.NewRequest("PUT", "/v1/operator/evals/broker", body)
+		resp = httptest.NewRecorder()
+		_, err = s.Server.OperatorEvalBroker(resp, req)
+		require.Error(err)
+		require.Contains(err.Error(), "at least one evaluation ID")
+
+		// Dropping an unknown evaluation fails
+		body = bytes.NewBuffer([]byte(`{"Action": "drop", "EvalIDs": ["foo"]}`))
+		req, _ = http.NewRequest("PUT", "/v1/operator/evals/broker", body)
+		resp = httptest.NewRecorder()
+		_, err = s.Server.OperatorEvalBroker(resp, req)
+		require.Error(err)
+		require.Contains(err.Error(), "not found")
+
+		// Other methods are not allowed
+		req, _ = http.NewRequest("DELETE", "/v1/operator/evals/broker", nil)
+		resp = httptest.NewRecorder()
+		_, err = s.Server.OperatorEvalBroker(resp, req)
+		require.Error(err)
+	})
+}
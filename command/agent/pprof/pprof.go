@@ -0,0 +1,127 @@
+// Package pprof provides the runtime profiles of an agent as byte slices, so
+// that they can be returned over RPC rather than written to an HTTP response.
+package pprof
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"runtime"
+	"runtime/pprof"
+	"runtime/trace"
+	"time"
+)
+
+// ReqType is the type of a profile request
+type ReqType string
+
+const (
+	// CPUReq requests a CPU profile
+	CPUReq ReqType = "profile"
+
+	// TraceReq requests an execution trace
+	TraceReq ReqType = "trace"
+
+	// LookupReq requests a named profile, such as the goroutine or heap
+	// profiles
+	LookupReq ReqType = "lookup"
+)
+
+// ErrProfileNotFound is returned when looking up an unknown profile
+type ErrProfileNotFound struct {
+	Profile string
+}
+
+func (e *ErrProfileNotFound) Error() string {
+	return fmt.Sprintf("Profile not found: %s", e.Profile)
+}
+
+// IsErrProfileNotFound returns whether the error is an ErrProfileNotFound
+func IsErrProfileNotFound(err error) bool {
+	_, ok := err.(*ErrProfileNotFound)
+	return ok
+}
+
+// Profile returns the named profile in the format given by debug, 0 being
+// the binary format. When gc is set, a garbage collection is run before
+// taking a heap profile.
+func Profile(profile string, debug int, gc bool) ([]byte, map[string]string, error) {
+	p := pprof.Lookup(profile)
+	if p == nil {
+		return nil, nil, &ErrProfileNotFound{Profile: profile}
+	}
+
+	if profile == "heap" && gc {
+		runtime.GC()
+	}
+
+	var buf bytes.Buffer
+	if err := p.WriteTo(&buf, debug); err != nil {
+		return nil, nil, err
+	}
+
+	headers := map[string]string{
+		"X-Content-Type-Options": "nosniff",
+	}
+	if debug != 0 {
+		headers["Content-Type"] = "text/plain; charset=utf-8"
+	} else {
+		headers["Content-Type"] = "application/octet-stream"
+		headers["Content-Disposition"] = fmt.Sprintf(`attachment; filename="%s"`, profile)
+	}
+	return buf.Bytes(), headers, nil
+}
+
+// CPUProfile returns a CPU profile of the given number of seconds. The
+// profile stops early when the context is done.
+func CPUProfile(ctx context.Context, sec int) ([]byte, map[string]string, error) {
+	if sec <= 0 {
+		sec = 1
+	}
+
+	var buf bytes.Buffer
+	if err := pprof.StartCPUProfile(&buf); err != nil {
+		// A profile may already be running
+		return nil, nil, err
+	}
+
+	sleep(ctx, time.Duration(sec)*time.Second)
+	pprof.StopCPUProfile()
+
+	return buf.Bytes(), map[string]string{
+		"X-Content-Type-Options": "nosniff",
+		"Content-Type":           "application/octet-stream",
+		"Content-Disposition":    `attachment; filename="profile"`,
+	}, nil
+}
+
+// Trace returns an execution trace of the given number of seconds. The trace
+// stops early when the context is done.
+func Trace(ctx context.Context, sec int) ([]byte, map[string]string, error) {
+	if sec <= 0 {
+		sec = 1
+	}
+
+	var buf bytes.Buffer
+	if err := trace.Start(&buf); err != nil {
+		// A trace may already be running
+		return nil, nil, err
+	}
+
+	sleep(ctx, time.Duration(sec)*time.Second)
+	trace.Stop()
+
+	return buf.Bytes(), map[string]string{
+		"X-Content-Type-Options": "nosniff",
+		"Content-Type":           "application/octet-stream",
+		"Content-Disposition":    `attachment; filename="trace"`,
+	}, nil
+}
+
+// sleep waits for the duration or until the context is done.
+func sleep(ctx context.Context, d time.Duration) {
+	select {
+	case <-time.After(d):
+	case <-ctx.Done():
+	}
+}
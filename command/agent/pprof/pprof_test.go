@@ -0,0 +1,78 @@
+package pprof
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestProfile(t *testing.T) {
+	cases := []struct {
+		name            string
+		profile         string
+		debug           int
+		gc              bool
+		expectedHeaders map[string]string
+		expectedErr     error
+	}{
+		{
+			name:    "binary goroutine profile",
+			profile: "goroutine",
+			expectedHeaders: map[string]string{
+				"X-Content-Type-Options": "nosniff",
+				"Content-Type":           "application/octet-stream",
+				"Content-Disposition":    `attachment; filename="goroutine"`,
+			},
+		},
+		{
+			name:    "text heap profile with gc",
+			profile: "heap",
+			debug:   1,
+			gc:      true,
+			expectedHeaders: map[string]string{
+				"X-Content-Type-Options": "nosniff",
+				"Content-Type":           "text/plain; charset=utf-8",
+			},
+		},
+		{
+			name:        "unknown profile",
+			profile:     "unknown",
+			expectedErr: &ErrProfileNotFound{Profile: "unknown"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			resp, headers, err := Profile(tc.profile, tc.debug, tc.gc)
+			require.Equal(t, tc.expectedHeaders, headers)
+
+			if tc.expectedErr != nil {
+				require.Nil(t, resp)
+				require.Equal(t, tc.expectedErr, err)
+				require.True(t, IsErrProfileNotFound(err))
+			} else {
+				require.NoError(t, err)
+				require.NotEmpty(t, resp)
+			}
+		})
+	}
+}
+
+func TestCPUProfile(t *testing.T) {
+	resp, headers, err := CPUProfile(context.Background(), 1)
+	require.NoError(t, err)
+	require.NotEmpty(t, resp)
+	require.Equal(t, `attachment; filename="profile"`, headers["Content-Disposition"])
+}
+
+func TestTrace(t *testing.T) {
+	// The trace stops when the context is done
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	resp, headers, err := Trace(ctx, 30)
+	require.NoError(t, err)
+	require.NotEmpty(t, resp)
+	require.Equal(t, `attachment; filename="trace"`, headers["Content-Disposition"])
+}
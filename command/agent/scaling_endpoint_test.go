@@ -0,0 +1,103 @@
+package agent
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTP_ScalingPoliciesList(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	httpTest(t, nil, func(s *TestAgent) {
+		for i := 0; i < 3; i++ {
+			job := mock.Job()
+			job.TaskGroups[0].Scaling = &structs.ScalingPolicy{
+				Min:     1,
+				Max:     20,
+				Enabled: true,
+			}
+			args := structs.JobRegisterRequest{
+				Job: job,
+				WriteRequest: structs.WriteRequest{
+					Region:    "global",
+					Namespace: structs.DefaultNamespace,
+				},
+			}
+			var resp structs.JobRegisterResponse
+			require.NoError(s.Agent.RPC("Job.Register", &args, &resp))
+		}
+
+		// Make the HTTP request
+		req, err := http.NewRequest("GET", "/v1/scaling/policies", nil)
+		require.NoError(err)
+		respW := httptest.NewRecorder()
+
+		// Make the request
+		obj, err := s.Server.ScalingPoliciesRequest(respW, req)
+		require.NoError(err)
+
+		// Check for the index
+		require.NotZero(respW.HeaderMap.Get("X-Nomad-Index"))
+		require.Equal("true", respW.HeaderMap.Get("X-Nomad-KnownLeader"))
+
+		// Check the output
+		require.Len(obj.([]*structs.ScalingPolicyListStub), 3)
+	})
+}
+
+func TestHTTP_ScalingPolicyQuery(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	httpTest(t, nil, func(s *TestAgent) {
+		job := mock.Job()
+		job.TaskGroups[0].Scaling = &structs.ScalingPolicy{
+			Min:     1,
+			Max:     20,
+			Enabled: true,
+		}
+		args := structs.JobRegisterRequest{
+			Job: job,
+			WriteRequest: structs.WriteRequest{
+				Region:    "global",
+				Namespace: structs.DefaultNamespace,
+			},
+		}
+		var resp structs.JobRegisterResponse
+		require.NoError(s.Agent.RPC("Job.Register", &args, &resp))
+
+		// Lookup the ID of the policy
+		list, err := s.Agent.Server().State().ScalingPoliciesByJob(nil, job.Namespace, job.ID)
+		require.NoError(err)
+		policy := list.Next().(*structs.ScalingPolicy)
+
+		// Make the HTTP request
+		req, err := http.NewRequest("GET", "/v1/scaling/policy/"+policy.ID, nil)
+		require.NoError(err)
+		respW := httptest.NewRecorder()
+
+		// Make the request
+		obj, err := s.Server.ScalingPolicySpecificRequest(respW, req)
+		require.NoError(err)
+
+		// Check for the index
+		require.NotZero(respW.HeaderMap.Get("X-Nomad-Index"))
+		require.Equal("true", respW.HeaderMap.Get("X-Nomad-KnownLeader"))
+
+		// Check the output
+		out := obj.(*structs.ScalingPolicy)
+		require.Equal(policy.ID, out.ID)
+		require.Equal(job.ID, out.Target[structs.ScalingTargetJob])
+
+		// Lookup a missing policy
+		req, err = http.NewRequest("GET", "/v1/scaling/policy/missing", nil)
+		require.NoError(err)
+		_, err = s.Server.ScalingPolicySpecificRequest(httptest.NewRecorder(), req)
+		require.Error(err)
+		require.Contains(err.Error(), "Scaling policy not found")
+	})
+}
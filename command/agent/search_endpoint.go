@@ -34,3 +34,28 @@ func (s *HTTPServer) newSearchRequest(resp http.ResponseWriter, req *http.Reques
 	setMeta(resp, &out.QueryMeta)
 	return out, nil
 }
+
+// FuzzySearchRequest accepts text and a context and returns the fields of
+// objects in that context containing the text.
+func (s *HTTPServer) FuzzySearchRequest(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	if req.Method != "POST" && req.Method != "PUT" {
+		return nil, CodedError(405, ErrInvalidMethod)
+	}
+
+	args := structs.FuzzySearchRequest{}
+	if err := decodeBody(req, &args); err != nil {
+		return nil, CodedError(400, err.Error())
+	}
+
+	if s.parse(resp, req, &args.Region, &args.QueryOptions) {
+		return nil, nil
+	}
+
+	var out structs.FuzzySearchResponse
+	if err := s.agent.RPC("Search.FuzzySearch", &args, &out); err != nil {
+		return nil, err
+	}
+
+	setMeta(resp, &out.QueryMeta)
+	return out, nil
+}
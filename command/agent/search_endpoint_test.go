@@ -333,3 +333,36 @@ func TestHTTP_Search_AllContext(t *testing.T) {
 		assert.Equal("8000", respW.HeaderMap.Get("X-Nomad-Index"))
 	})
 }
+
+func TestHTTP_FuzzySearch(t *testing.T) {
+	assert := assert.New(t)
+	t.Parallel()
+	httpTest(t, nil, func(s *TestAgent) {
+		createJobForTest("example-job", s, t)
+
+		// Only POST and PUT are accepted
+		req, err := http.NewRequest("GET", "/v1/search/fuzzy", nil)
+		assert.Nil(err)
+		_, err = s.Server.FuzzySearchRequest(httptest.NewRecorder(), req)
+		assert.NotNil(err)
+
+		data := structs.FuzzySearchRequest{Text: "web", Context: structs.Tasks}
+		req, err = http.NewRequest("POST", "/v1/search/fuzzy", encodeReq(data))
+		assert.Nil(err)
+
+		respW := httptest.NewRecorder()
+		resp, err := s.Server.FuzzySearchRequest(respW, req)
+		assert.Nil(err)
+
+		res := resp.(structs.FuzzySearchResponse)
+		assert.Equal(1, len(res.Matches))
+
+		tasks := res.Matches[structs.Tasks]
+		assert.Equal(1, len(tasks))
+		assert.Equal("web", tasks[0].ID)
+		assert.Equal([]string{structs.DefaultNamespace, "example-job", "web"}, tasks[0].Scope)
+		assert.False(res.Truncations[structs.Tasks])
+
+		assert.Equal("1000", respW.HeaderMap.Get("X-Nomad-Index"))
+	})
+}
@@ -109,6 +109,7 @@ server {
   job_gc_threshold          = "12h"
   eval_gc_threshold         = "12h"
   deployment_gc_threshold   = "12h"
+  dispatch_token_retention  = "48h"
   heartbeat_grace           = "30s"
   min_heartbeat_ttl         = "33s"
   max_heartbeats_per_second = 11.0
@@ -127,6 +128,15 @@ server {
     retry_max      = 3
     retry_interval = "15s"
   }
+
+  admission_webhook "labels" {
+    url             = "https://admission.example.com/labels"
+    operations      = ["register"]
+    timeout         = "2s"
+    failure_policy  = "fail_open"
+    ca_file         = "/path/to/ca"
+    tls_skip_verify = true
+  }
 }
 
 acl {
@@ -0,0 +1,179 @@
+package command
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/posener/complete"
+)
+
+type MonitorCommand struct {
+	Meta
+}
+
+func (c *MonitorCommand) Help() string {
+	helpText := `
+Usage: nomad monitor [options]
+
+  Stream log messages of a Nomad agent. The monitor command lets you listen
+  for log levels that may be filtered out of the Nomad agent. For example your
+  agent may only be logging at INFO level, but with the monitor command you
+  can set -log-level DEBUG.
+
+  If ACLs are enabled, this command requires a token with the 'agent:read'
+  capability.
+
+General Options:
+
+  ` + generalOptionsUsage() + `
+
+Monitor Options:
+
+  -log-level <level>
+    Sets the log level to monitor (default: INFO)
+
+  -node-id <node-id>
+    Sets the specific node to monitor
+
+  -server-id <server-id>
+    Sets the specific server to monitor, by name or ID. The value "leader"
+    monitors the current leader.
+
+  -json
+    Sets log output to JSON format
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *MonitorCommand) Synopsis() string {
+	return "Stream logs from a Nomad agent"
+}
+
+func (c *MonitorCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-log-level": complete.PredictSet("TRACE", "DEBUG", "INFO", "WARN", "ERROR"),
+			"-node-id":   complete.PredictAnything,
+			"-server-id": complete.PredictAnything,
+			"-json":      complete.PredictNothing,
+		})
+}
+
+func (c *MonitorCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *MonitorCommand) Name() string { return "monitor" }
+
+func (c *MonitorCommand) Run(args []string) int {
+	var logLevel string
+	var nodeID string
+	var serverID string
+	var logJSON bool
+
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.StringVar(&logLevel, "log-level", "", "")
+	flags.StringVar(&nodeID, "node-id", "", "")
+	flags.StringVar(&serverID, "server-id", "", "")
+	flags.BoolVar(&logJSON, "json", false, "")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Check that we got no arguments
+	args = flags.Args()
+	if l := len(args); l != 0 {
+		c.Ui.Error("This command takes no arguments")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	if nodeID != "" && serverID != "" {
+		c.Ui.Error("Cannot target node and server simultaneously")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	// Query the node info and lookup prefix
+	if nodeID != "" {
+		if len(nodeID) == 1 {
+			c.Ui.Error("Node identifier must contain at least two characters.")
+			return 1
+		}
+
+		nodeID = sanitizeUUIDPrefix(nodeID)
+		nodes, _, err := client.Nodes().PrefixList(nodeID)
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Error querying node: %v", err))
+			return 1
+		}
+
+		if len(nodes) == 0 {
+			c.Ui.Error(fmt.Sprintf("No node(s) with prefix or id %q found", nodeID))
+			return 1
+		}
+
+		if len(nodes) > 1 {
+			out := formatNodeStubList(nodes, false)
+			c.Ui.Output(fmt.Sprintf("Prefix matched multiple nodes\n\n%s", out))
+			return 1
+		}
+		nodeID = nodes[0].ID
+	}
+
+	params := map[string]string{
+		"log_level": logLevel,
+		"node_id":   nodeID,
+		"server_id": serverID,
+		"log_json":  strconv.FormatBool(logJSON),
+	}
+
+	query := &api.QueryOptions{
+		Params: params,
+	}
+
+	eventDoneCh := make(chan struct{})
+	frames, errCh := client.Agent().Monitor(eventDoneCh, query)
+	select {
+	case err := <-errCh:
+		c.Ui.Error(fmt.Sprintf("Error starting monitor: %s", err))
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	default:
+	}
+
+	// Create a reader
+	r := api.NewFrameReader(frames, errCh, eventDoneCh)
+	defer r.Close()
+
+	signalCh := make(chan os.Signal, 1)
+	signal.Notify(signalCh, os.Interrupt, syscall.SIGTERM)
+
+	go func() {
+		<-signalCh
+		// End the streaming
+		r.Close()
+	}()
+
+	_, err = io.Copy(os.Stdout, r)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error monitoring logs: %s", err))
+		return 1
+	}
+
+	return 0
+}
@@ -0,0 +1,390 @@
+package command
+
+import (
+	"archive/tar"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/api/contexts"
+	"github.com/posener/complete"
+)
+
+type AllocCpCommand struct {
+	Meta
+}
+
+func (c *AllocCpCommand) Help() string {
+	helpText := `
+Usage: nomad alloc cp [options] <source> <destination>
+
+  Copy files or directories between the local filesystem and the directory of
+  an allocation. One of the source or destination must be given as
+  <allocation>:<path>, where the path is relative to the root of the
+  allocation directory, such as "alloc/logs" or "<task>/local". The other is a
+  local path.
+
+  When downloading, the remote file or directory is copied into the local
+  destination if it is an existing directory, and to the destination path
+  otherwise. When uploading, the local file or directory is copied into the
+  remote destination if it is an existing directory, and to the destination
+  path otherwise. Directories are copied recursively.
+
+  A local path of "-" reads a tar archive from stdin to extract into the
+  remote directory, or writes a tar archive of the remote path to stdout.
+
+  Files in the secrets directory of tasks cannot be copied.
+
+  When ACLs are enabled, downloading requires a token with the 'read-fs'
+  capability and uploading requires a token with the 'alloc-write-fs'
+  capability for the allocation's namespace.
+
+General Options:
+
+  ` + generalOptionsUsage() + `
+
+Copy Options:
+
+  -job
+    Use a random allocation from the job ID given as the allocation.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *AllocCpCommand) Synopsis() string {
+	return "Copy files into or out of an allocation"
+}
+
+func (c *AllocCpCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-job": complete.PredictAnything,
+		})
+}
+
+func (c *AllocCpCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictOr(
+		complete.PredictFiles("*"),
+		complete.PredictFunc(func(a complete.Args) []string {
+			client, err := c.Meta.Client()
+			if err != nil {
+				return nil
+			}
+
+			resp, _, err := client.Search().PrefixSearch(a.Last, contexts.Allocs, nil)
+			if err != nil {
+				return []string{}
+			}
+			return resp.Matches[contexts.Allocs]
+		}))
+}
+
+func (c *AllocCpCommand) Name() string { return "alloc cp" }
+
+func (c *AllocCpCommand) Run(args []string) int {
+	var job bool
+
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.BoolVar(&job, "job", false, "")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Check that we got a source and a destination
+	args = flags.Args()
+	if len(args) != 2 {
+		c.Ui.Error("This command takes two arguments: <source> <destination>")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	src, dst := args[0], args[1]
+	srcAlloc, srcPath, srcRemote := parseAllocPath(src)
+	dstAlloc, dstPath, dstRemote := parseAllocPath(dst)
+	if srcRemote == dstRemote {
+		c.Ui.Error("Exactly one of the source or destination must be given as <allocation>:<path>")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	allocID, remotePath := srcAlloc, srcPath
+	if dstRemote {
+		allocID, remotePath = dstAlloc, dstPath
+	}
+	if remotePath == "" {
+		remotePath = "/"
+	}
+
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %v", err))
+		return 1
+	}
+
+	// If -job is specified, use random allocation, otherwise use provided allocation
+	if job {
+		allocID, err = getRandomJobAlloc(client, allocID)
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Error fetching allocations: %v", err))
+			return 1
+		}
+	}
+
+	// Query the allocation info
+	if len(allocID) == 1 {
+		c.Ui.Error(fmt.Sprintf("Alloc ID must contain at least two characters."))
+		return 1
+	}
+
+	allocID = sanitizeUUIDPrefix(allocID)
+	allocs, _, err := client.Allocations().PrefixList(allocID)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error querying allocation: %v", err))
+		return 1
+	}
+	if len(allocs) == 0 {
+		c.Ui.Error(fmt.Sprintf("No allocation(s) with prefix or id %q found", allocID))
+		return 1
+	}
+	if len(allocs) > 1 {
+		// Format the allocs
+		out := formatAllocListStubs(allocs, false, shortId)
+		c.Ui.Error(fmt.Sprintf("Prefix matched multiple allocations\n\n%s", out))
+		return 1
+	}
+	// Prefix lookup matched a single allocation
+	alloc, _, err := client.Allocations().Info(allocs[0].ID, nil)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error querying allocation: %s", err))
+		return 1
+	}
+
+	if srcRemote {
+		if err := c.download(client, alloc, remotePath, dst); err != nil {
+			c.Ui.Error(fmt.Sprintf("Error copying %q from allocation: %v", remotePath, err))
+			return 1
+		}
+		return 0
+	}
+
+	if err := c.upload(client, alloc, src, remotePath); err != nil {
+		c.Ui.Error(fmt.Sprintf("Error copying %q to allocation: %v", src, err))
+		return 1
+	}
+	return 0
+}
+
+// download copies the remote path of the allocation to the local
+// destination.
+func (c *AllocCpCommand) download(client *api.Client, alloc *api.Allocation, remotePath, dst string) error {
+	r, err := client.AllocFS().Download(alloc, remotePath, nil)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	if dst == "-" {
+		_, err := io.Copy(os.Stdout, r)
+		return err
+	}
+
+	// Copy into the destination if it is a directory, otherwise rename the
+	// copied file or directory to the destination
+	if fi, err := os.Stat(dst); err == nil && fi.IsDir() {
+		return extractTar(r, dst, "")
+	}
+	return extractTar(r, filepath.Dir(dst), filepath.Base(dst))
+}
+
+// upload copies the local source to the remote path of the allocation.
+func (c *AllocCpCommand) upload(client *api.Client, alloc *api.Allocation, src, remotePath string) error {
+	if src == "-" {
+		return client.AllocFS().Upload(alloc, remotePath, os.Stdin, nil)
+	}
+
+	// Copy into the remote path if it is a directory, otherwise rename the
+	// copied file or directory to the remote path
+	dir, name := remotePath, filepath.Base(src)
+	if info, _, err := client.AllocFS().Stat(alloc, remotePath, nil); err != nil || !info.IsDir {
+		dir, name = path.Dir(remotePath), path.Base(remotePath)
+	}
+
+	// Copy the target of the source if it is a symlink
+	src, err := filepath.EvalSymlinks(src)
+	if err != nil {
+		return err
+	}
+
+	pr, pw := io.Pipe()
+	go func() {
+		pw.CloseWithError(writeTar(pw, src, name))
+	}()
+	defer pr.Close()
+
+	return client.AllocFS().Upload(alloc, dir, pr, nil)
+}
+
+// parseAllocPath parses an argument of the form <allocation>:<path>. Local
+// paths, which are absolute, start with a dot or contain no colon, are
+// returned as not remote.
+func parseAllocPath(arg string) (string, string, bool) {
+	if arg == "-" || filepath.IsAbs(arg) || strings.HasPrefix(arg, ".") {
+		return "", "", false
+	}
+
+	i := strings.Index(arg, ":")
+	if i < 1 {
+		return "", "", false
+	}
+	return arg[:i], arg[i+1:], true
+}
+
+// writeTar writes a tar archive of the file or directory at src to w. The
+// entries are rooted at the given name. Symlinks are archived as symlinks and
+// special files are skipped.
+func writeTar(w io.Writer, src, name string) error {
+	tw := tar.NewWriter(w)
+	walkFn := func(p string, fileInfo os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		link := ""
+		switch mode := fileInfo.Mode(); {
+		case mode.IsDir(), mode.IsRegular():
+		case mode&os.ModeSymlink != 0:
+			target, err := os.Readlink(p)
+			if err != nil {
+				return fmt.Errorf("error reading symlink: %v", err)
+			}
+			link = target
+		default:
+			// Skip sockets, devices and named pipes
+			return nil
+		}
+
+		relPath, err := filepath.Rel(src, p)
+		if err != nil {
+			return err
+		}
+		hdr, err := tar.FileInfoHeader(fileInfo, link)
+		if err != nil {
+			return fmt.Errorf("error creating file header: %v", err)
+		}
+		hdr.Name = filepath.ToSlash(filepath.Join(name, relPath))
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+
+		// If it's a directory or symlink we just write the header into the tar
+		if !fileInfo.Mode().IsRegular() {
+			return nil
+		}
+
+		file, err := os.Open(p)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+
+		_, err = io.Copy(tw, file)
+		return err
+	}
+
+	if err := filepath.Walk(src, walkFn); err != nil {
+		return err
+	}
+	return tw.Close()
+}
+
+// extractTar extracts the tar archive read from r into dir. If root is set
+// the top level entry of the archive is renamed to it. Entries escaping dir,
+// either by their name or through a symlink of the archive, are rejected.
+func extractTar(r io.Reader, dir, root string) error {
+	dir = filepath.Clean(dir)
+
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		name := filepath.Clean(filepath.FromSlash(hdr.Name))
+		if filepath.IsAbs(name) || name == ".." || strings.HasPrefix(name, ".."+string(filepath.Separator)) {
+			return fmt.Errorf("archive entry %q escapes the destination", hdr.Name)
+		}
+
+		// Rename the top level entry
+		if root != "" {
+			parts := strings.SplitN(name, string(filepath.Separator), 2)
+			parts[0] = root
+			name = filepath.Join(parts...)
+		}
+		target := filepath.Join(dir, name)
+
+		// Don't follow symlinks within the destination
+		for p := filepath.Dir(target); p != dir && p != filepath.Dir(p); p = filepath.Dir(p) {
+			if fi, err := os.Lstat(p); err == nil && fi.Mode()&os.ModeSymlink != 0 {
+				return fmt.Errorf("archive entry %q is within a symlink", hdr.Name)
+			}
+		}
+
+		if err := extractTarEntry(tr, hdr, target); err != nil {
+			return fmt.Errorf("failed to extract %q: %v", hdr.Name, err)
+		}
+	}
+}
+
+// extractTarEntry writes the archive entry to target, replacing any existing
+// file or symlink.
+func extractTarEntry(tr *tar.Reader, hdr *tar.Header, target string) error {
+	perm := os.FileMode(hdr.Mode).Perm()
+
+	existing, err := os.Lstat(target)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if existing != nil && existing.IsDir() {
+		if hdr.Typeflag == tar.TypeDir {
+			return os.Chmod(target, perm)
+		}
+		return fmt.Errorf("a directory exists at the path")
+	}
+	if existing != nil {
+		if err := os.Remove(target); err != nil {
+			return err
+		}
+	}
+
+	switch hdr.Typeflag {
+	case tar.TypeDir:
+		return os.Mkdir(target, perm)
+
+	case tar.TypeReg, tar.TypeRegA:
+		f, err := os.OpenFile(target, os.O_CREATE|os.O_EXCL|os.O_WRONLY, perm)
+		if err != nil {
+			return err
+		}
+		if _, err := io.Copy(f, tr); err != nil {
+			f.Close()
+			return err
+		}
+		return f.Close()
+
+	case tar.TypeSymlink:
+		return os.Symlink(hdr.Linkname, target)
+
+	default:
+		return fmt.Errorf("unsupported file type %q", hdr.Typeflag)
+	}
+}
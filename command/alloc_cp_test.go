@@ -0,0 +1,213 @@
+package command
+
+import (
+	"archive/tar"
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/mitchellh/cli"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAllocCpCommand_Implements(t *testing.T) {
+	t.Parallel()
+	var _ cli.Command = &AllocCpCommand{}
+}
+
+func TestAllocCpCommand_Fails(t *testing.T) {
+	t.Parallel()
+	srv, _, url := testServer(t, false, nil)
+	defer srv.Shutdown()
+
+	ui := new(cli.MockUi)
+	cmd := &AllocCpCommand{Meta: Meta{Ui: ui}}
+
+	// Fails on misuse
+	if code := cmd.Run([]string{"some", "bad", "args"}); code != 1 {
+		t.Fatalf("expected exit code 1, got: %d", code)
+	}
+	if out := ui.ErrorWriter.String(); !strings.Contains(out, commandErrorText(cmd)) {
+		t.Fatalf("expected help output, got: %s", out)
+	}
+	ui.ErrorWriter.Reset()
+
+	// Fails without a remote path
+	if code := cmd.Run([]string{"-address=" + url, "foo", "bar"}); code != 1 {
+		t.Fatalf("expected exit code 1, got: %d", code)
+	}
+	if out := ui.ErrorWriter.String(); !strings.Contains(out, "Exactly one of the source or destination") {
+		t.Fatalf("expected remote path error, got: %s", out)
+	}
+	ui.ErrorWriter.Reset()
+
+	// Fails with two remote paths
+	if code := cmd.Run([]string{"-address=" + url, "foo:a", "bar:b"}); code != 1 {
+		t.Fatalf("expected exit code 1, got: %d", code)
+	}
+	if out := ui.ErrorWriter.String(); !strings.Contains(out, "Exactly one of the source or destination") {
+		t.Fatalf("expected remote path error, got: %s", out)
+	}
+	ui.ErrorWriter.Reset()
+
+	// Fails on connection failure
+	if code := cmd.Run([]string{"-address=nope", "foobar:alloc/logs", "./logs"}); code != 1 {
+		t.Fatalf("expected exit code 1, got: %d", code)
+	}
+	if out := ui.ErrorWriter.String(); !strings.Contains(out, "Error querying allocation") {
+		t.Fatalf("expected failed query error, got: %s", out)
+	}
+	ui.ErrorWriter.Reset()
+
+	// Fails on missing alloc
+	if code := cmd.Run([]string{"-address=" + url, "26470238-5CF2-438F-8772-DC67CFB0705C:alloc/logs", "./logs"}); code != 1 {
+		t.Fatalf("expected exit 1, got: %d", code)
+	}
+	if out := ui.ErrorWriter.String(); !strings.Contains(out, "No allocation(s) with prefix or id") {
+		t.Fatalf("expected not found error, got: %s", out)
+	}
+}
+
+func TestAllocCpCommand_Run(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	srv, client, url := testServer(t, true, nil)
+	defer srv.Shutdown()
+
+	job := testJob("job1")
+	job.TaskGroups[0].Tasks[0].Config["run_for"] = "60s"
+	_, _, err := client.Jobs().Register(job, nil)
+	require.NoError(err)
+
+	var allocID string
+	testutil.WaitForResult(func() (bool, error) {
+		allocs, _, err := client.Jobs().Allocations("job1", false, nil)
+		if err != nil {
+			return false, err
+		}
+		if len(allocs) != 1 || allocs[0].ClientStatus != api.AllocClientStatusRunning {
+			return false, fmt.Errorf("alloc not running")
+		}
+		allocID = allocs[0].ID
+		return true, nil
+	}, func(err error) {
+		t.Fatalf("err: %v", err)
+	})
+
+	tmp, err := ioutil.TempDir("", "nomad-alloc-cp")
+	require.NoError(err)
+	defer os.RemoveAll(tmp)
+
+	// Create a local directory to upload
+	src := filepath.Join(tmp, "dump")
+	require.NoError(os.MkdirAll(filepath.Join(src, "sub"), 0755))
+	require.NoError(ioutil.WriteFile(filepath.Join(src, "heap"), []byte("foo"), 0644))
+	require.NoError(ioutil.WriteFile(filepath.Join(src, "sub", "stack"), []byte("bar"), 0644))
+
+	ui := new(cli.MockUi)
+	cmd := &AllocCpCommand{Meta: Meta{Ui: ui}}
+
+	// Upload the directory into an existing remote directory
+	code := cmd.Run([]string{"-address=" + url, src, allocID + ":alloc/data"})
+	require.Zero(code)
+
+	// Upload a file to a new remote path
+	code = cmd.Run([]string{"-address=" + url, filepath.Join(src, "heap"), allocID + ":alloc/data/heap.copy"})
+	require.Zero(code)
+
+	// Download the directory to a new local path
+	dst := filepath.Join(tmp, "out")
+	code = cmd.Run([]string{"-address=" + url, allocID + ":alloc/data/dump", dst})
+	require.Zero(code)
+
+	out, err := ioutil.ReadFile(filepath.Join(dst, "heap"))
+	require.NoError(err)
+	require.Equal("foo", string(out))
+
+	out, err = ioutil.ReadFile(filepath.Join(dst, "sub", "stack"))
+	require.NoError(err)
+	require.Equal("bar", string(out))
+
+	// Download a file into an existing local directory
+	code = cmd.Run([]string{"-address=" + url, allocID + ":alloc/data/heap.copy", dst})
+	require.Zero(code)
+
+	out, err = ioutil.ReadFile(filepath.Join(dst, "heap.copy"))
+	require.NoError(err)
+	require.Equal("foo", string(out))
+
+	// Fails to download secrets
+	code = cmd.Run([]string{"-address=" + url, allocID + ":task1/secrets", dst})
+	require.Equal(1, code)
+	require.Contains(ui.ErrorWriter.String(), "secret file prohibited")
+}
+
+func TestAllocCpCommand_ParseAllocPath(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		input  string
+		alloc  string
+		path   string
+		remote bool
+	}{
+		{input: "abcd:alloc/logs", alloc: "abcd", path: "alloc/logs", remote: true},
+		{input: "abcd:", alloc: "abcd", path: "", remote: true},
+		{input: "abcd:/web/local/a:b", alloc: "abcd", path: "/web/local/a:b", remote: true},
+		{input: "logs"},
+		{input: "-"},
+		{input: ":logs"},
+		{input: "./a:b"},
+		{input: "/tmp/a:b"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.input, func(t *testing.T) {
+			alloc, path, remote := parseAllocPath(c.input)
+			require.Equal(t, c.remote, remote)
+			require.Equal(t, c.alloc, alloc)
+			require.Equal(t, c.path, path)
+		})
+	}
+}
+
+func TestAllocCpCommand_ExtractTar_Escape(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	tmp, err := ioutil.TempDir("", "nomad-alloc-cp")
+	require.NoError(err)
+	defer os.RemoveAll(tmp)
+
+	dir := filepath.Join(tmp, "dst")
+	require.NoError(os.Mkdir(dir, 0755))
+
+	// Entries escaping the destination are rejected
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	require.NoError(tw.WriteHeader(&tar.Header{Name: "../foo", Mode: 0644, Typeflag: tar.TypeReg}))
+	require.NoError(tw.Close())
+
+	err = extractTar(&buf, dir, "")
+	require.Error(err)
+	require.Contains(err.Error(), "escapes the destination")
+
+	// Entries are not written through symlinks of the archive
+	buf.Reset()
+	tw = tar.NewWriter(&buf)
+	require.NoError(tw.WriteHeader(&tar.Header{Name: "link", Linkname: tmp, Typeflag: tar.TypeSymlink}))
+	require.NoError(tw.WriteHeader(&tar.Header{Name: "link/foo", Mode: 0644, Typeflag: tar.TypeReg}))
+	require.NoError(tw.Close())
+
+	err = extractTar(&buf, dir, "")
+	require.Error(err)
+	require.Contains(err.Error(), "within a symlink")
+
+	_, err = os.Stat(filepath.Join(tmp, "foo"))
+	require.True(os.IsNotExist(err))
+}
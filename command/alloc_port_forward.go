@@ -0,0 +1,236 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/api/contexts"
+	"github.com/posener/complete"
+)
+
+type AllocPortForwardCommand struct {
+	Meta
+}
+
+func (c *AllocPortForwardCommand) Help() string {
+	helpText := `
+Usage: nomad alloc port-forward [options] <allocation> [<local port>:]<port>
+
+  Forward connections to a local port to a port of the given allocation. The
+  port of the allocation is given by its label or number. In allocations with
+  their own network namespace, such as in bridge networking mode, any port of
+  the namespace can be forwarded to. Otherwise the port must be allocated to
+  the allocation.
+
+  The local port defaults to the port number, or to a random port if the port
+  is given by label. Connections are forwarded until the command is
+  interrupted.
+
+  When ACLs are enabled, this command requires a token with the 'alloc-exec'
+  capability for the allocation's namespace.
+
+General Options:
+
+  ` + generalOptionsUsage() + `
+
+Port Forward Options:
+
+  -job
+    Use a random allocation from the specified job ID.
+
+  -bind <address>
+    Address to listen on locally. Defaults to 127.0.0.1.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *AllocPortForwardCommand) Synopsis() string {
+	return "Forward a local port to a port of an allocation"
+}
+
+func (c *AllocPortForwardCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-job":  complete.PredictAnything,
+			"-bind": complete.PredictAnything,
+		})
+}
+
+func (c *AllocPortForwardCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictFunc(func(a complete.Args) []string {
+		client, err := c.Meta.Client()
+		if err != nil {
+			return nil
+		}
+
+		resp, _, err := client.Search().PrefixSearch(a.Last, contexts.Allocs, nil)
+		if err != nil {
+			return []string{}
+		}
+		return resp.Matches[contexts.Allocs]
+	})
+}
+
+func (c *AllocPortForwardCommand) Name() string { return "alloc port-forward" }
+
+func (c *AllocPortForwardCommand) Run(args []string) int {
+	var job bool
+	var bind string
+
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.BoolVar(&job, "job", false, "")
+	flags.StringVar(&bind, "bind", "127.0.0.1", "")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Check that we got an allocation and a port
+	args = flags.Args()
+	if len(args) != 2 {
+		c.Ui.Error("This command takes two arguments: <allocation> [<local port>:]<port>")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	localPort, port, err := parsePortForwardSpec(args[1])
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error parsing port %q: %v", args[1], err))
+		return 1
+	}
+
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %v", err))
+		return 1
+	}
+
+	// If -job is specified, use random allocation, otherwise use provided allocation
+	allocID := args[0]
+	if job {
+		allocID, err = getRandomJobAlloc(client, args[0])
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Error fetching allocations: %v", err))
+			return 1
+		}
+	}
+
+	// Query the allocation info
+	if len(allocID) == 1 {
+		c.Ui.Error(fmt.Sprintf("Alloc ID must contain at least two characters."))
+		return 1
+	}
+
+	allocID = sanitizeUUIDPrefix(allocID)
+	allocs, _, err := client.Allocations().PrefixList(allocID)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error querying allocation: %v", err))
+		return 1
+	}
+	if len(allocs) == 0 {
+		c.Ui.Error(fmt.Sprintf("No allocation(s) with prefix or id %q found", allocID))
+		return 1
+	}
+	if len(allocs) > 1 {
+		// Format the allocs
+		out := formatAllocListStubs(allocs, false, shortId)
+		c.Ui.Error(fmt.Sprintf("Prefix matched multiple allocations\n\n%s", out))
+		return 1
+	}
+	// Prefix lookup matched a single allocation
+	alloc, _, err := client.Allocations().Info(allocs[0].ID, nil)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error querying allocation: %s", err))
+		return 1
+	}
+
+	l, err := net.Listen("tcp", net.JoinHostPort(bind, strconv.Itoa(localPort)))
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error listening on local port: %v", err))
+		return 1
+	}
+	defer l.Close()
+
+	// Stop forwarding on interrupt
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	signalCh := make(chan os.Signal, 1)
+	signal.Notify(signalCh, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(signalCh)
+
+	go func() {
+		select {
+		case <-signalCh:
+			cancel()
+			l.Close()
+		case <-ctx.Done():
+		}
+	}()
+
+	c.Ui.Output(fmt.Sprintf("Forwarding from %s -> %s of allocation %q",
+		l.Addr(), port, limit(alloc.ID, shortId)))
+
+	return c.forward(ctx, l, client, alloc, port)
+}
+
+// forward forwards the connections accepted by the listener to the port of
+// the allocation until the context is done.
+func (c *AllocPortForwardCommand) forward(ctx context.Context, l net.Listener, client *api.Client, alloc *api.Allocation, port string) int {
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			if ctx.Err() != nil {
+				return 0
+			}
+			c.Ui.Error(fmt.Sprintf("Error accepting connection: %v", err))
+			return 1
+		}
+
+		go func() {
+			defer conn.Close()
+			if err := client.Allocations().PortForward(ctx, alloc, port, conn, nil); err != nil && ctx.Err() == nil {
+				c.Ui.Error(fmt.Sprintf("Error forwarding connection from %s: %v", conn.RemoteAddr(), err))
+			}
+		}()
+	}
+}
+
+// parsePortForwardSpec parses a port forwarding specification of the form
+// [<local port>:]<port> and returns the local port and the port of the
+// allocation. The local port defaults to the port number, or to zero to pick
+// a random port if the port is a label.
+func parsePortForwardSpec(spec string) (int, string, error) {
+	local, port := "", spec
+	if i := strings.LastIndex(spec, ":"); i != -1 {
+		local, port = spec[:i], spec[i+1:]
+	}
+	if port == "" {
+		return 0, "", fmt.Errorf("port must be given")
+	}
+
+	if local == "" {
+		if n, err := strconv.Atoi(port); err == nil {
+			local = strconv.Itoa(n)
+		} else {
+			return 0, port, nil
+		}
+	}
+
+	localPort, err := strconv.Atoi(local)
+	if err != nil {
+		return 0, "", fmt.Errorf("invalid local port %q", local)
+	}
+	if localPort < 0 || localPort > 65535 {
+		return 0, "", fmt.Errorf("local port %d out of range", localPort)
+	}
+	return localPort, port, nil
+}
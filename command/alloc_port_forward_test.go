@@ -0,0 +1,177 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/helper"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/mitchellh/cli"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAllocPortForwardCommand_Implements(t *testing.T) {
+	t.Parallel()
+	var _ cli.Command = &AllocPortForwardCommand{}
+}
+
+func TestAllocPortForwardCommand_Fails(t *testing.T) {
+	t.Parallel()
+	srv, _, url := testServer(t, false, nil)
+	defer srv.Shutdown()
+
+	ui := new(cli.MockUi)
+	cmd := &AllocPortForwardCommand{Meta: Meta{Ui: ui}}
+
+	// Fails on misuse
+	if code := cmd.Run([]string{"some", "bad", "args"}); code != 1 {
+		t.Fatalf("expected exit code 1, got: %d", code)
+	}
+	if out := ui.ErrorWriter.String(); !strings.Contains(out, commandErrorText(cmd)) {
+		t.Fatalf("expected help output, got: %s", out)
+	}
+	ui.ErrorWriter.Reset()
+
+	// Fails on invalid port
+	if code := cmd.Run([]string{"-address=" + url, "26470238-5CF2-438F-8772-DC67CFB0705C", "http:"}); code != 1 {
+		t.Fatalf("expected exit code 1, got: %d", code)
+	}
+	if out := ui.ErrorWriter.String(); !strings.Contains(out, "Error parsing port") {
+		t.Fatalf("expected port error, got: %s", out)
+	}
+	ui.ErrorWriter.Reset()
+
+	// Fails on connection failure
+	if code := cmd.Run([]string{"-address=nope", "foobar", "8080"}); code != 1 {
+		t.Fatalf("expected exit code 1, got: %d", code)
+	}
+	if out := ui.ErrorWriter.String(); !strings.Contains(out, "Error querying allocation") {
+		t.Fatalf("expected failed query error, got: %s", out)
+	}
+	ui.ErrorWriter.Reset()
+
+	// Fails on missing alloc
+	if code := cmd.Run([]string{"-address=" + url, "26470238-5CF2-438F-8772-DC67CFB0705C", "8080"}); code != 1 {
+		t.Fatalf("expected exit 1, got: %d", code)
+	}
+	if out := ui.ErrorWriter.String(); !strings.Contains(out, "No allocation(s) with prefix or id") {
+		t.Fatalf("expected not found error, got: %s", out)
+	}
+}
+
+func TestAllocPortForwardCommand_Forward(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	srv, client, _ := testServer(t, true, nil)
+	defer srv.Shutdown()
+
+	// Register a job with a dynamic port
+	job := testJob("job1")
+	job.TaskGroups[0].Tasks[0].Config["run_for"] = "60s"
+	job.TaskGroups[0].Tasks[0].Resources.Networks = []*api.NetworkResource{
+		{
+			MBits:        helper.IntToPtr(10),
+			DynamicPorts: []api.Port{{Label: "http"}},
+		},
+	}
+	_, _, err := client.Jobs().Register(job, nil)
+	require.NoError(err)
+
+	var alloc *api.Allocation
+	testutil.WaitForResult(func() (bool, error) {
+		allocs, _, err := client.Jobs().Allocations("job1", false, nil)
+		if err != nil {
+			return false, err
+		}
+		if len(allocs) != 1 || allocs[0].ClientStatus != api.AllocClientStatusRunning {
+			return false, fmt.Errorf("alloc not running")
+		}
+		alloc, _, err = client.Allocations().Info(allocs[0].ID, nil)
+		return err == nil, err
+	}, func(err error) {
+		t.Fatalf("err: %v", err)
+	})
+
+	// Listen on the allocated port in place of the task, echoing the data
+	network := alloc.AllocatedResources.Tasks["task1"].Networks[0]
+	target, err := net.Listen("tcp", net.JoinHostPort(network.IP, fmt.Sprint(network.DynamicPorts[0].Value)))
+	require.NoError(err)
+	defer target.Close()
+	go func() {
+		conn, err := target.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		io.Copy(conn, conn)
+	}()
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(err)
+	defer l.Close()
+
+	ui := new(cli.MockUi)
+	cmd := &AllocPortForwardCommand{Meta: Meta{Ui: ui}}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	codeCh := make(chan int, 1)
+	go func() {
+		codeCh <- cmd.forward(ctx, l, client, alloc, "http")
+	}()
+
+	// Send data through the forwarded port and read it back
+	conn, err := net.Dial("tcp", l.Addr().String())
+	require.NoError(err)
+	_, err = conn.Write([]byte("hello"))
+	require.NoError(err)
+	require.NoError(conn.(*net.TCPConn).CloseWrite())
+
+	out, err := ioutil.ReadAll(conn)
+	require.NoError(err)
+	require.Equal("hello", string(out))
+	conn.Close()
+
+	// Stop forwarding
+	cancel()
+	l.Close()
+	require.Zero(<-codeCh)
+}
+
+func TestAllocPortForwardCommand_ParsePortForwardSpec(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		spec  string
+		local int
+		port  string
+		err   bool
+	}{
+		{spec: "8080", local: 8080, port: "8080"},
+		{spec: "http", local: 0, port: "http"},
+		{spec: "9000:http", local: 9000, port: "http"},
+		{spec: "9000:5432", local: 9000, port: "5432"},
+		{spec: ":http", local: 0, port: "http"},
+		{spec: "http:", err: true},
+		{spec: "web:http", err: true},
+		{spec: "70000:http", err: true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.spec, func(t *testing.T) {
+			local, port, err := parsePortForwardSpec(c.spec)
+			if c.err {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, c.local, local)
+			require.Equal(t, c.port, port)
+		})
+	}
+}
@@ -144,11 +144,21 @@ func Commands(metaPtr *Meta, agentUi cli.Ui) map[string]cli.CommandFactory {
 				Meta: meta,
 			}, nil
 		},
+		"alloc cp": func() (cli.Command, error) {
+			return &AllocCpCommand{
+				Meta: meta,
+			}, nil
+		},
 		"alloc exec": func() (cli.Command, error) {
 			return &AllocExecCommand{
 				Meta: meta,
 			}, nil
 		},
+		"alloc port-forward": func() (cli.Command, error) {
+			return &AllocPortForwardCommand{
+				Meta: meta,
+			}, nil
+		},
 		"alloc signal": func() (cli.Command, error) {
 			return &AllocSignalCommand{
 				Meta: meta,
@@ -241,6 +251,11 @@ func Commands(metaPtr *Meta, agentUi cli.Ui) map[string]cli.CommandFactory {
 				Meta: meta,
 			}, nil
 		},
+		"eval delete": func() (cli.Command, error) {
+			return &EvalDeleteCommand{
+				Meta: meta,
+			}, nil
+		},
 		"eval status": func() (cli.Command, error) {
 			return &EvalStatusCommand{
 				Meta: meta,
@@ -301,6 +316,11 @@ func Commands(metaPtr *Meta, agentUi cli.Ui) map[string]cli.CommandFactory {
 				Meta: meta,
 			}, nil
 		},
+		"job diff": func() (cli.Command, error) {
+			return &JobDiffCommand{
+				Meta: meta,
+			}, nil
+		},
 		"job history": func() (cli.Command, error) {
 			return &JobHistoryCommand{
 				Meta: meta,
@@ -326,6 +346,11 @@ func Commands(metaPtr *Meta, agentUi cli.Ui) map[string]cli.CommandFactory {
 				Meta: meta,
 			}, nil
 		},
+		"job periodic status": func() (cli.Command, error) {
+			return &JobPeriodicStatusCommand{
+				Meta: meta,
+			}, nil
+		},
 		"job plan": func() (cli.Command, error) {
 			return &JobPlanCommand{
 				Meta: meta,
@@ -341,11 +366,21 @@ func Commands(metaPtr *Meta, agentUi cli.Ui) map[string]cli.CommandFactory {
 				Meta: meta,
 			}, nil
 		},
+		"job restart": func() (cli.Command, error) {
+			return &JobRestartCommand{
+				Meta: meta,
+			}, nil
+		},
 		"job run": func() (cli.Command, error) {
 			return &JobRunCommand{
 				Meta: meta,
 			}, nil
 		},
+		"job scale": func() (cli.Command, error) {
+			return &JobScaleCommand{
+				Meta: meta,
+			}, nil
+		},
 		"job status": func() (cli.Command, error) {
 			return &JobStatusCommand{
 				Meta: meta,
@@ -356,6 +391,21 @@ func Commands(metaPtr *Meta, agentUi cli.Ui) map[string]cli.CommandFactory {
 				Meta: meta,
 			}, nil
 		},
+		"job tag": func() (cli.Command, error) {
+			return &JobTagCommand{
+				Meta: meta,
+			}, nil
+		},
+		"job tag apply": func() (cli.Command, error) {
+			return &JobTagApplyCommand{
+				Meta: meta,
+			}, nil
+		},
+		"job tag unset": func() (cli.Command, error) {
+			return &JobTagUnsetCommand{
+				Meta: meta,
+			}, nil
+		},
 		"job validate": func() (cli.Command, error) {
 			return &JobValidateCommand{
 				Meta: meta,
@@ -366,6 +416,11 @@ func Commands(metaPtr *Meta, agentUi cli.Ui) map[string]cli.CommandFactory {
 				Meta: meta,
 			}, nil
 		},
+		"monitor": func() (cli.Command, error) {
+			return &MonitorCommand{
+				Meta: meta,
+			}, nil
+		},
 		"namespace": func() (cli.Command, error) {
 			return &NamespaceCommand{
 				Meta: meta,
@@ -454,6 +509,31 @@ func Commands(metaPtr *Meta, agentUi cli.Ui) map[string]cli.CommandFactory {
 				Meta: meta,
 			}, nil
 		},
+		"operator debug": func() (cli.Command, error) {
+			return &OperatorDebugCommand{
+				Meta: meta,
+			}, nil
+		},
+		"operator evals": func() (cli.Command, error) {
+			return &OperatorEvalsCommand{
+				Meta: meta,
+			}, nil
+		},
+		"operator evals broker": func() (cli.Command, error) {
+			return &OperatorEvalsBrokerCommand{
+				Meta: meta,
+			}, nil
+		},
+		"operator evals drop": func() (cli.Command, error) {
+			return &OperatorEvalsDropCommand{
+				Meta: meta,
+			}, nil
+		},
+		"operator evals reenqueue": func() (cli.Command, error) {
+			return &OperatorEvalsReenqueueCommand{
+				Meta: meta,
+			}, nil
+		},
 		"operator keygen": func() (cli.Command, error) {
 			return &OperatorKeygenCommand{
 				Meta: meta,
@@ -488,6 +568,27 @@ func Commands(metaPtr *Meta, agentUi cli.Ui) map[string]cli.CommandFactory {
 			}, nil
 		},
 
+		"policy": func() (cli.Command, error) {
+			return &PolicyCommand{
+				Meta: meta,
+			}, nil
+		},
+		"policy apply": func() (cli.Command, error) {
+			return &PolicyApplyCommand{
+				Meta: meta,
+			}, nil
+		},
+		"policy delete": func() (cli.Command, error) {
+			return &PolicyDeleteCommand{
+				Meta: meta,
+			}, nil
+		},
+		"policy list": func() (cli.Command, error) {
+			return &PolicyListCommand{
+				Meta: meta,
+			}, nil
+		},
+
 		"quota": func() (cli.Command, error) {
 			return &QuotaCommand{
 				Meta: meta,
@@ -535,6 +636,11 @@ func Commands(metaPtr *Meta, agentUi cli.Ui) map[string]cli.CommandFactory {
 				Meta: meta,
 			}, nil
 		},
+		"search": func() (cli.Command, error) {
+			return &SearchCommand{
+				Meta: meta,
+			}, nil
+		},
 		"sentinel": func() (cli.Command, error) {
 			return &SentinelCommand{
 				Meta: meta,
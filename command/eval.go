@@ -23,6 +23,10 @@ Usage: nomad eval <subcommand> [options] [args]
 
       $ nomad eval status <eval-id>
 
+  Delete the pending evaluations of a job:
+
+      $ nomad eval delete -job <job-id> -status pending
+
   Please see the individual subcommand help for detailed usage information.
 `
 
@@ -0,0 +1,129 @@
+package command
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/api/contexts"
+	"github.com/posener/complete"
+)
+
+type EvalDeleteCommand struct {
+	Meta
+}
+
+func (c *EvalDeleteCommand) Help() string {
+	helpText := `
+Usage: nomad eval delete [options]
+
+  Delete the evaluations matching the given filters. At least one of the -job,
+  -status or -older-than filters must be given. Matching evaluations are also
+  removed from the evaluation broker and blocked evaluations tracker.
+  Evaluations that are currently being processed by a scheduler are skipped.
+
+  Deletions are applied in rate limited batches, so purging a large number of
+  evaluations may take some time.
+
+General Options:
+
+  ` + generalOptionsUsage() + `
+
+Eval Delete Options:
+
+  -job <job-id>
+    Only delete evaluations for the given job.
+
+  -status <status>
+    Only delete evaluations with the given status. One of "pending",
+    "blocked", "complete", "failed" or "canceled".
+
+  -older-than <duration>
+    Only delete evaluations created at least the given duration ago, such as
+    "1h".
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *EvalDeleteCommand) Synopsis() string {
+	return "Delete evaluations matching a set of filters"
+}
+
+func (c *EvalDeleteCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-job": complete.PredictFunc(func(a complete.Args) []string {
+				client, err := c.Meta.Client()
+				if err != nil {
+					return nil
+				}
+
+				resp, _, err := client.Search().PrefixSearch(a.Last, contexts.Jobs, nil)
+				if err != nil {
+					return []string{}
+				}
+				return resp.Matches[contexts.Jobs]
+			}),
+			"-status":     complete.PredictSet("pending", "blocked", "complete", "failed", "canceled"),
+			"-older-than": complete.PredictAnything,
+		})
+}
+
+func (c *EvalDeleteCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *EvalDeleteCommand) Name() string { return "eval delete" }
+
+func (c *EvalDeleteCommand) Run(args []string) int {
+	var jobID, status string
+	var olderThan time.Duration
+
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.StringVar(&jobID, "job", "", "")
+	flags.StringVar(&status, "status", "", "")
+	flags.DurationVar(&olderThan, "older-than", 0, "")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Check that we got no arguments
+	if len(flags.Args()) != 0 {
+		c.Ui.Error("This command takes no arguments")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	if jobID == "" && status == "" && olderThan == 0 {
+		c.Ui.Error("At least one of -job, -status or -older-than must be set")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	// Get the HTTP client
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	opts := &api.EvalDeleteOptions{
+		JobID:     jobID,
+		Status:    status,
+		OlderThan: olderThan,
+	}
+	resp, _, err := client.Evaluations().Delete(opts, nil)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error deleting evaluations: %s", err))
+		return 1
+	}
+
+	c.Ui.Output(fmt.Sprintf("Deleted %d evaluation(s)", resp.Deleted))
+	if resp.Skipped != 0 {
+		c.Ui.Output(fmt.Sprintf("Skipped %d evaluation(s) being processed by a scheduler", resp.Skipped))
+	}
+	return 0
+}
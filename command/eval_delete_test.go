@@ -0,0 +1,66 @@
+package command
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/mitchellh/cli"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEvalDeleteCommand_Implements(t *testing.T) {
+	t.Parallel()
+	var _ cli.Command = &EvalDeleteCommand{}
+}
+
+func TestEvalDeleteCommand_Fails(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	srv, _, url := testServer(t, false, nil)
+	defer srv.Shutdown()
+
+	ui := new(cli.MockUi)
+	cmd := &EvalDeleteCommand{Meta: Meta{Ui: ui}}
+
+	// Fails on misuse
+	code := cmd.Run([]string{"-address=" + url, "foo"})
+	require.Equal(1, code)
+	require.Contains(ui.ErrorWriter.String(), commandErrorText(cmd))
+	ui.ErrorWriter.Reset()
+
+	// Fails without filters
+	code = cmd.Run([]string{"-address=" + url})
+	require.Equal(1, code)
+	require.Contains(ui.ErrorWriter.String(), "At least one of")
+	ui.ErrorWriter.Reset()
+
+	// Fails on invalid status
+	code = cmd.Run([]string{"-address=" + url, "-status=foo"})
+	require.Equal(1, code)
+	require.Contains(ui.ErrorWriter.String(), "invalid evaluation status")
+}
+
+func TestEvalDeleteCommand_Run(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	srv, _, url := testServer(t, false, nil)
+	defer srv.Shutdown()
+
+	// Create an eval directly in the state store
+	state := srv.Agent.Server().State()
+	eval := mock.Eval()
+	eval.Status = structs.EvalStatusComplete
+	require.NoError(state.UpsertEvals(1000, []*structs.Evaluation{eval}))
+
+	ui := new(cli.MockUi)
+	cmd := &EvalDeleteCommand{Meta: Meta{Ui: ui}}
+
+	code := cmd.Run([]string{"-address=" + url, "-job=" + eval.JobID, "-status=complete"})
+	require.Equal(0, code, ui.ErrorWriter.String())
+	require.Contains(ui.OutputWriter.String(), "Deleted 1 evaluation(s)")
+
+	out, err := state.EvalByID(nil, eval.ID)
+	require.NoError(err)
+	require.Nil(out)
+}
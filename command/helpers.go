@@ -2,6 +2,8 @@ package command
 
 import (
 	"bytes"
+	"encoding/json"
+	"flag"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -12,6 +14,7 @@ import (
 
 	gg "github.com/hashicorp/go-getter"
 	"github.com/hashicorp/nomad/api"
+	flaghelper "github.com/hashicorp/nomad/helper/flag-helpers"
 	"github.com/hashicorp/nomad/jobspec"
 	"github.com/kr/text"
 	"github.com/posener/complete"
@@ -376,12 +379,40 @@ READ:
 }
 
 type JobGetter struct {
+	// hcl2 parses the job file as HCL2. It is implied by vars and varFiles.
+	hcl2     bool
+	vars     []string
+	varFiles []string
+
 	// The fields below can be overwritten for tests
 	testStdin io.Reader
 }
 
+// setHCL2Flags registers the flags configuring the parsing of job files
+// written in HCL2.
+func (j *JobGetter) setHCL2Flags(flags *flag.FlagSet) {
+	flags.BoolVar(&j.hcl2, "hcl2", false, "")
+	flags.Var((*flaghelper.StringFlag)(&j.vars), "var", "")
+	flags.Var((*flaghelper.StringFlag)(&j.varFiles), "var-file", "")
+}
+
+// hcl2AutocompleteFlags returns the autocomplete flags of setHCL2Flags.
+func hcl2AutocompleteFlags() complete.Flags {
+	return complete.Flags{
+		"-hcl2":     complete.PredictNothing,
+		"-var":      complete.PredictAnything,
+		"-var-file": complete.PredictFiles("*"),
+	}
+}
+
 // StructJob returns the Job struct from jobfile.
 func (j *JobGetter) ApiJob(jpath string) (*api.Job, error) {
+	_, job, err := j.Get(jpath)
+	return job, err
+}
+
+// Get returns the source and the Job struct from jobfile.
+func (j *JobGetter) Get(jpath string) (*api.JobSubmission, *api.Job, error) {
 	var jobfile io.Reader
 	switch jpath {
 	case "-":
@@ -392,23 +423,23 @@ func (j *JobGetter) ApiJob(jpath string) (*api.Job, error) {
 		}
 	default:
 		if len(jpath) == 0 {
-			return nil, fmt.Errorf("Error jobfile path has to be specified.")
+			return nil, nil, fmt.Errorf("Error jobfile path has to be specified.")
 		}
 
 		job, err := ioutil.TempFile("", "jobfile")
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 		defer os.Remove(job.Name())
 
 		if err := job.Close(); err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 
 		// Get the pwd
 		pwd, err := os.Getwd()
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 
 		client := &gg.Client{
@@ -418,24 +449,67 @@ func (j *JobGetter) ApiJob(jpath string) (*api.Job, error) {
 		}
 
 		if err := client.Get(); err != nil {
-			return nil, fmt.Errorf("Error getting jobfile from %q: %v", jpath, err)
+			return nil, nil, fmt.Errorf("Error getting jobfile from %q: %v", jpath, err)
 		} else {
 			file, err := os.Open(job.Name())
 			defer file.Close()
 			if err != nil {
-				return nil, fmt.Errorf("Error opening file %q: %v", jpath, err)
+				return nil, nil, fmt.Errorf("Error opening file %q: %v", jpath, err)
 			}
 			jobfile = file
 		}
 	}
 
+	source, err := ioutil.ReadAll(jobfile)
+	if err != nil {
+		return nil, nil, fmt.Errorf("Error reading job file from %s: %v", jpath, err)
+	}
+	submission := &api.JobSubmission{
+		Source: string(source),
+		Format: "hcl1",
+	}
+
 	// Parse the JobFile
-	jobStruct, err := jobspec.Parse(jobfile)
+	var jobStruct *api.Job
+	if j.hcl2 || len(j.vars) != 0 || len(j.varFiles) != 0 {
+		jobStruct, err = jobspec.ParseHCL2(bytes.NewReader(source), &jobspec.HCL2Config{
+			Filename: jpath,
+			ArgVars:  j.vars,
+			VarFiles: j.varFiles,
+		})
+		submission.Format = "hcl2"
+
+		// Record the variables the job was parsed with
+		if len(j.vars) != 0 {
+			submission.VariableFlags = make(map[string]string, len(j.vars))
+			for _, v := range j.vars {
+				if idx := strings.Index(v, "="); idx > 0 {
+					submission.VariableFlags[v[:idx]] = v[idx+1:]
+				}
+			}
+		}
+		var variables []string
+		for _, path := range j.varFiles {
+			content, err := ioutil.ReadFile(path)
+			if err != nil {
+				return nil, nil, fmt.Errorf("Error reading variable file %s: %v", path, err)
+			}
+			variables = append(variables, string(content))
+		}
+		submission.Variables = strings.Join(variables, "\n")
+	} else {
+		jobStruct, err = jobspec.Parse(bytes.NewReader(source))
+
+		// The HCL1 parser also accepts jobs written in JSON
+		if json.Valid(source) {
+			submission.Format = "json"
+		}
+	}
 	if err != nil {
-		return nil, fmt.Errorf("Error parsing job file from %s: %v", jpath, err)
+		return nil, nil, fmt.Errorf("Error parsing job file from %s: %v", jpath, err)
 	}
 
-	return jobStruct, nil
+	return submission, jobStruct, nil
 }
 
 // mergeAutocompleteFlags is used to join multiple flag completion sets.
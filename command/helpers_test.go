@@ -271,6 +271,124 @@ func TestJobGetter_LocalFile(t *testing.T) {
 	}
 }
 
+// Test APIJob with a local HCL2 jobfile and variables
+func TestJobGetter_HCL2(t *testing.T) {
+	t.Parallel()
+	hcl2Job := `variable "datacenter" {}
+
+variable "attempts" {
+  type    = number
+  default = 3
+}
+
+job "job1" {
+  type        = "service"
+  datacenters = [var.datacenter]
+  group "group1" {
+    count = 1
+    task "task1" {
+      driver    = "exec"
+      resources = {}
+    }
+    restart {
+      attempts = var.attempts
+      mode     = "delay"
+      interval = "15s"
+    }
+  }
+}`
+
+	fh, err := ioutil.TempFile("", "nomad")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	defer os.Remove(fh.Name())
+	_, err = fh.WriteString(hcl2Job)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	j := &JobGetter{vars: []string{"datacenter=dc1", "attempts=10"}}
+	aj, err := j.ApiJob(fh.Name())
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if !reflect.DeepEqual(expectedApiJob, aj) {
+		for _, d := range pretty.Diff(expectedApiJob, aj) {
+			t.Log(d)
+		}
+		t.Fatalf("Unexpected job")
+	}
+
+	// The variables are recorded in the submission, including the content of
+	// the variable files
+	vf, err := ioutil.TempFile("", "nomad*.hcl")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	defer os.Remove(vf.Name())
+	if _, err := vf.WriteString(`attempts = 10`); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	j = &JobGetter{vars: []string{"datacenter=dc1"}, varFiles: []string{vf.Name()}}
+	sub, aj, err := j.Get(fh.Name())
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if !reflect.DeepEqual(expectedApiJob, aj) {
+		t.Fatalf("Unexpected job")
+	}
+	if sub.Format != "hcl2" {
+		t.Fatalf("expected format hcl2, got %q", sub.Format)
+	}
+	if !reflect.DeepEqual(map[string]string{"datacenter": "dc1"}, sub.VariableFlags) {
+		t.Fatalf("unexpected variable flags %v", sub.VariableFlags)
+	}
+	if sub.Variables != `attempts = 10` {
+		t.Fatalf("unexpected variables %q", sub.Variables)
+	}
+
+	// Without the variable values the file fails to parse
+	j = &JobGetter{hcl2: true}
+	if _, err := j.ApiJob(fh.Name()); err == nil || !strings.Contains(err.Error(), `missing value for variable "datacenter"`) {
+		t.Fatalf("expected missing variable error, got: %v", err)
+	}
+}
+
+// Test the format of the submission of HCL1 and JSON jobfiles
+func TestJobGetter_SubmissionFormat(t *testing.T) {
+	t.Parallel()
+	cases := map[string]string{
+		"hcl1": job,
+		"json": `{"job": {"job1": {"type": "service", "datacenters": ["dc1"]}}}`,
+	}
+
+	for format, src := range cases {
+		fh, err := ioutil.TempFile("", "nomad")
+		if err != nil {
+			t.Fatalf("err: %s", err)
+		}
+		defer os.Remove(fh.Name())
+		if _, err := fh.WriteString(src); err != nil {
+			t.Fatalf("err: %s", err)
+		}
+
+		j := &JobGetter{}
+		sub, _, err := j.Get(fh.Name())
+		if err != nil {
+			t.Fatalf("err: %s", err)
+		}
+		if sub.Format != format {
+			t.Fatalf("expected format %q, got %q", format, sub.Format)
+		}
+		if sub.Source != src {
+			t.Fatalf("unexpected source %q", sub.Source)
+		}
+	}
+}
+
 // Test StructJob with jobfile from HTTP Server
 func TestJobGetter_HTTPServer(t *testing.T) {
 	t.Parallel()
@@ -0,0 +1,141 @@
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/posener/complete"
+)
+
+type JobDiffCommand struct {
+	Meta
+	JobGetter
+}
+
+func (c *JobDiffCommand) Help() string {
+	helpText := `
+Usage: nomad job diff [options] <path>
+
+  Diff displays the differences between a version of a registered job and the
+  job in the given job file. Unlike "nomad job plan", it doesn't invoke a
+  dry-run of the scheduler.
+
+  If the supplied path is "-", the jobfile is read from stdin. Otherwise
+  it is read from the file at the supplied path or downloaded and
+  read from URL specified.
+
+  If the job has specified the region, the -region flag and NOMAD_REGION
+  environment variable are overridden and the job's region is used.
+
+General Options:
+
+  ` + generalOptionsUsage() + `
+
+Diff Options:
+
+  -version <job version>
+    The version of the registered job to diff against. Defaults to the current
+    version.
+
+  -hcl2
+    Parses the job file as HCL2, which supports variable and locals blocks,
+    functions and dynamic blocks. Implied by the -var and -var-file flags.
+
+  -var 'key=value'
+    Sets the value of a variable declared in the job file. Values of list,
+    map and object variables are HCL expressions, such as '["dc1", "dc2"]'.
+    Can be specified multiple times.
+
+  -var-file=path
+    Sets the values of the variables declared in the job file from an HCL or
+    JSON file. Can be specified multiple times. Values set with -var take
+    precedence.
+
+  -verbose
+    Increase diff verbosity.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *JobDiffCommand) Synopsis() string {
+	return "Diff a job file against a version of the registered job"
+}
+
+func (c *JobDiffCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-version": complete.PredictAnything,
+			"-verbose": complete.PredictNothing,
+		},
+		hcl2AutocompleteFlags())
+}
+
+func (c *JobDiffCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictOr(complete.PredictFiles("*.nomad"), complete.PredictFiles("*.hcl"))
+}
+
+func (c *JobDiffCommand) Name() string { return "job diff" }
+
+func (c *JobDiffCommand) Run(args []string) int {
+	var verbose bool
+	var versionStr string
+
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.BoolVar(&verbose, "verbose", false, "")
+	flags.StringVar(&versionStr, "version", "", "")
+	c.JobGetter.setHCL2Flags(flags)
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Check that we got exactly one job
+	args = flags.Args()
+	if len(args) != 1 {
+		c.Ui.Error("This command takes one argument: <path>")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	var version *uint64
+	if v, ok, err := parseVersion(versionStr); err != nil {
+		c.Ui.Error(fmt.Sprintf("Error parsing version value %q: %v", versionStr, err))
+		return 1
+	} else if ok {
+		version = &v
+	}
+
+	// Get Job struct from Jobfile
+	job, err := c.JobGetter.ApiJob(args[0])
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error getting job struct: %s", err))
+		return 1
+	}
+
+	// Get the HTTP client
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	// Force the region to be that of the job.
+	if r := job.Region; r != nil {
+		client.SetRegion(*r)
+	}
+
+	// Force the namespace to be that of the job.
+	if n := job.Namespace; n != nil {
+		client.SetNamespace(*n)
+	}
+
+	diff, _, err := client.Jobs().DiffJob(job, version, verbose, nil)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error diffing job: %s", err))
+		return 1
+	}
+
+	c.Ui.Output(c.Colorize().Color(strings.TrimSpace(formatJobDiff(diff, verbose))))
+	return 0
+}
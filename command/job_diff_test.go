@@ -0,0 +1,118 @@
+package command
+
+import (
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/nomad/jobspec"
+	"github.com/mitchellh/cli"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJobDiffCommand_Implements(t *testing.T) {
+	t.Parallel()
+	var _ cli.Command = &JobDiffCommand{}
+}
+
+func TestJobDiffCommand_Fails(t *testing.T) {
+	t.Parallel()
+	ui := new(cli.MockUi)
+	cmd := &JobDiffCommand{Meta: Meta{Ui: ui}}
+
+	// Fails on misuse
+	if code := cmd.Run([]string{"some", "bad", "args"}); code != 1 {
+		t.Fatalf("expected exit code 1, got: %d", code)
+	}
+	if out := ui.ErrorWriter.String(); !strings.Contains(out, commandErrorText(cmd)) {
+		t.Fatalf("expected help output, got: %s", out)
+	}
+	ui.ErrorWriter.Reset()
+
+	// Fails on invalid version
+	if code := cmd.Run([]string{"-version=foo", "job.nomad"}); code != 1 {
+		t.Fatalf("expected exit code 1, got: %d", code)
+	}
+	if out := ui.ErrorWriter.String(); !strings.Contains(out, "Error parsing version") {
+		t.Fatalf("expected version parsing error, got: %s", out)
+	}
+	ui.ErrorWriter.Reset()
+
+	// Fails when specified file does not exist
+	if code := cmd.Run([]string{"/unicorns/leprechauns"}); code != 1 {
+		t.Fatalf("expected exit code 1, got: %d", code)
+	}
+	if out := ui.ErrorWriter.String(); !strings.Contains(out, "Error getting job struct") {
+		t.Fatalf("expected getting job struct error, got: %s", out)
+	}
+	ui.ErrorWriter.Reset()
+}
+
+func TestJobDiffCommand_Run(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	srv, client, url := testServer(t, false, nil)
+	defer srv.Shutdown()
+
+	jobFile := func(priority string) string {
+		fh, err := ioutil.TempFile("", "nomad")
+		require.NoError(err)
+		defer fh.Close()
+		_, err = fh.WriteString(`
+job "job1" {
+	type = "service"
+	datacenters = [ "dc1" ]
+	priority = ` + priority + `
+	group "group1" {
+		count = 1
+		task "task1" {
+			driver = "exec"
+			resources = {
+				cpu = 1000
+				memory = 512
+			}
+		}
+	}
+}`)
+		require.NoError(err)
+		return fh.Name()
+	}
+
+	// Register two versions of the job
+	for _, priority := range []string{"50", "60"} {
+		path := jobFile(priority)
+		defer os.Remove(path)
+		job, err := jobspec.ParseFile(path)
+		require.NoError(err)
+		_, _, err = client.Jobs().Register(job, nil)
+		require.NoError(err)
+	}
+
+	local := jobFile("80")
+	defer os.Remove(local)
+
+	// Diff against the current version
+	ui := new(cli.MockUi)
+	cmd := &JobDiffCommand{Meta: Meta{Ui: ui}}
+	code := cmd.Run([]string{"-address=" + url, local})
+	require.Equal(0, code, ui.ErrorWriter.String())
+	out := ui.OutputWriter.String()
+	require.Contains(out, "Priority")
+	require.Contains(out, `"60" => "80"`)
+
+	// Diff against the first version
+	ui = new(cli.MockUi)
+	cmd = &JobDiffCommand{Meta: Meta{Ui: ui}}
+	code = cmd.Run([]string{"-address=" + url, "-version=0", local})
+	require.Equal(0, code, ui.ErrorWriter.String())
+	require.Contains(ui.OutputWriter.String(), `"50" => "80"`)
+
+	// Diff against a missing version
+	ui = new(cli.MockUi)
+	cmd = &JobDiffCommand{Meta: Meta{Ui: ui}}
+	code = cmd.Run([]string{"-address=" + url, "-version=5", local})
+	require.Equal(1, code)
+	require.Contains(ui.ErrorWriter.String(), "Error diffing job")
+}
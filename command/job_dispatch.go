@@ -41,6 +41,11 @@ Dispatch Options:
     once to inject multiple metadata key/value pairs. Arbitrary keys are not
     allowed. The parameterized job must allow the key to be merged.
 
+  -idempotency-token <token>
+    Optional identifier used to prevent more than one instance of the job from
+    being dispatched. If the token was already used to dispatch the job within
+    the servers' retention period, the previously dispatched job is returned.
+
   -detach
     Return immediately instead of entering monitor mode. After job dispatch,
     the evaluation ID will be printed to the screen, which can be used to
@@ -59,9 +64,10 @@ func (c *JobDispatchCommand) Synopsis() string {
 func (c *JobDispatchCommand) AutocompleteFlags() complete.Flags {
 	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
 		complete.Flags{
-			"-meta":    complete.PredictAnything,
-			"-detach":  complete.PredictNothing,
-			"-verbose": complete.PredictNothing,
+			"-meta":              complete.PredictAnything,
+			"-idempotency-token": complete.PredictAnything,
+			"-detach":            complete.PredictNothing,
+			"-verbose":           complete.PredictNothing,
 		})
 }
 
@@ -84,6 +90,7 @@ func (c *JobDispatchCommand) Name() string { return "job dispatch" }
 
 func (c *JobDispatchCommand) Run(args []string) int {
 	var detach, verbose bool
+	var idempotencyToken string
 	var meta []string
 
 	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
@@ -91,6 +98,7 @@ func (c *JobDispatchCommand) Run(args []string) int {
 	flags.BoolVar(&detach, "detach", false, "")
 	flags.BoolVar(&verbose, "verbose", false, "")
 	flags.Var((*flaghelper.StringFlag)(&meta), "meta", "")
+	flags.StringVar(&idempotencyToken, "idempotency-token", "", "")
 
 	if err := flags.Parse(args); err != nil {
 		return 1
@@ -148,7 +156,7 @@ func (c *JobDispatchCommand) Run(args []string) int {
 	}
 
 	// Dispatch the job
-	resp, _, err := client.Jobs().Dispatch(job, metaMap, payload, nil)
+	resp, _, err := client.Jobs().DispatchWithIdempotencyToken(job, metaMap, payload, idempotencyToken, nil)
 	if err != nil {
 		c.Ui.Error(fmt.Sprintf("Failed to dispatch job: %s", err))
 		return 1
@@ -166,6 +174,16 @@ func (c *JobDispatchCommand) Run(args []string) int {
 	}
 	c.Ui.Output(formatKV(basic))
 
+	if resp.Existing {
+		c.Ui.Output("\nJob was already dispatched with the idempotency token")
+	}
+
+	// The job is at its concurrency limit so there is nothing to monitor
+	if resp.Queued {
+		c.Ui.Output("\nJob is at its concurrency limit; the dispatched job was queued")
+		return 0
+	}
+
 	// Nothing to do
 	if detach || !evalCreated {
 		return 0
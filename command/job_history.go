@@ -35,6 +35,10 @@ History Options:
   -p
     Display the difference between each job and its predecessor.
 
+  -diff-version <job version>
+    Display the difference between each job and the given job version instead
+    of its predecessor. Implies -p.
+
   -full
     Display the full job definition for each version.
 
@@ -57,11 +61,12 @@ func (c *JobHistoryCommand) Synopsis() string {
 func (c *JobHistoryCommand) AutocompleteFlags() complete.Flags {
 	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
 		complete.Flags{
-			"-p":       complete.PredictNothing,
-			"-full":    complete.PredictNothing,
-			"-version": complete.PredictAnything,
-			"-json":    complete.PredictNothing,
-			"-t":       complete.PredictAnything,
+			"-p":            complete.PredictNothing,
+			"-diff-version": complete.PredictAnything,
+			"-full":         complete.PredictNothing,
+			"-version":      complete.PredictAnything,
+			"-json":         complete.PredictNothing,
+			"-t":            complete.PredictAnything,
 		})
 }
 
@@ -84,7 +89,7 @@ func (c *JobHistoryCommand) Name() string { return "job history" }
 
 func (c *JobHistoryCommand) Run(args []string) int {
 	var json, diff, full bool
-	var tmpl, versionStr string
+	var tmpl, versionStr, diffVersionStr string
 
 	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
 	flags.Usage = func() { c.Ui.Output(c.Help()) }
@@ -92,6 +97,7 @@ func (c *JobHistoryCommand) Run(args []string) int {
 	flags.BoolVar(&full, "full", false, "")
 	flags.BoolVar(&json, "json", false, "")
 	flags.StringVar(&versionStr, "version", "", "")
+	flags.StringVar(&diffVersionStr, "diff-version", "", "")
 	flags.StringVar(&tmpl, "t", "", "")
 
 	if err := flags.Parse(args); err != nil {
@@ -106,8 +112,16 @@ func (c *JobHistoryCommand) Run(args []string) int {
 		return 1
 	}
 
-	if (json || len(tmpl) != 0) && (diff || full) {
-		c.Ui.Error("-json and -t are exclusive with -p and -full")
+	var diffVersion *uint64
+	if v, ok, err := parseVersion(diffVersionStr); err != nil {
+		c.Ui.Error(fmt.Sprintf("Error parsing diff version value %q: %v", diffVersionStr, err))
+		return 1
+	} else if ok {
+		diffVersion = &v
+	}
+
+	if (json || len(tmpl) != 0) && (diff || full || diffVersion != nil) {
+		c.Ui.Error("-json and -t are exclusive with -p, -diff-version and -full")
 		return 1
 	}
 
@@ -136,12 +150,28 @@ func (c *JobHistoryCommand) Run(args []string) int {
 	}
 
 	// Prefix lookup matched a single job
-	versions, diffs, _, err := client.Jobs().Versions(jobs[0].ID, diff, nil)
+	versions, diffs, _, err := client.Jobs().Versions(jobs[0].ID, diff && diffVersion == nil, nil)
 	if err != nil {
 		c.Ui.Error(fmt.Sprintf("Error retrieving job versions: %s", err))
 		return 1
 	}
 
+	// Diff each version against the given version instead of its predecessor
+	if diffVersion != nil {
+		diffs = make([]*api.JobDiff, len(versions))
+		for i, v := range versions {
+			if *v.Version == *diffVersion {
+				continue
+			}
+			d, _, err := client.Jobs().Diff(jobs[0].ID, diffVersion, v.Version, true, nil)
+			if err != nil {
+				c.Ui.Error(fmt.Sprintf("Error diffing job version %d against version %d: %s", *v.Version, *diffVersion, err))
+				return 1
+			}
+			diffs[i] = d
+		}
+	}
+
 	f, err := DataFormat("json", "")
 	if err != nil {
 		c.Ui.Error(fmt.Sprintf("Error getting formatter: %s", err))
@@ -158,16 +188,14 @@ func (c *JobHistoryCommand) Run(args []string) int {
 
 		var job *api.Job
 		var diff *api.JobDiff
-		var nextVersion uint64
 		for i, v := range versions {
 			if *v.Version != version {
 				continue
 			}
 
 			job = v
-			if i+1 <= len(diffs) {
+			if i < len(diffs) {
 				diff = diffs[i]
-				nextVersion = *versions[i+1].Version
 			}
 		}
 
@@ -182,7 +210,7 @@ func (c *JobHistoryCommand) Run(args []string) int {
 			return 0
 		}
 
-		if err := c.formatJobVersion(job, diff, nextVersion, full); err != nil {
+		if err := c.formatJobVersion(job, diff, full); err != nil {
 			c.Ui.Error(err.Error())
 			return 1
 		}
@@ -219,22 +247,22 @@ func parseVersion(input string) (uint64, bool, error) {
 	return u, true, err
 }
 
+// formatJobVersions formats the job versions. If diffs are given, the diff at
+// each index is displayed with the version at the same index.
 func (c *JobHistoryCommand) formatJobVersions(versions []*api.Job, diffs []*api.JobDiff, full bool) error {
 	vLen := len(versions)
 	dLen := len(diffs)
-	if dLen != 0 && vLen != dLen+1 {
+	if dLen > vLen {
 		return fmt.Errorf("Number of job versions %d doesn't match number of diffs %d", vLen, dLen)
 	}
 
 	for i, version := range versions {
 		var diff *api.JobDiff
-		var nextVersion uint64
-		if i+1 <= dLen {
+		if i < dLen {
 			diff = diffs[i]
-			nextVersion = *versions[i+1].Version
 		}
 
-		if err := c.formatJobVersion(version, diff, nextVersion, full); err != nil {
+		if err := c.formatJobVersion(version, diff, full); err != nil {
 			return err
 		}
 
@@ -247,7 +275,7 @@ func (c *JobHistoryCommand) formatJobVersions(versions []*api.Job, diffs []*api.
 	return nil
 }
 
-func (c *JobHistoryCommand) formatJobVersion(job *api.Job, diff *api.JobDiff, nextVersion uint64, full bool) error {
+func (c *JobHistoryCommand) formatJobVersion(job *api.Job, diff *api.JobDiff, full bool) error {
 	if job == nil {
 		return fmt.Errorf("Error printing job history for non-existing job or job version")
 	}
@@ -258,8 +286,14 @@ func (c *JobHistoryCommand) formatJobVersion(job *api.Job, diff *api.JobDiff, ne
 		fmt.Sprintf("Submit Date|%v", formatTime(time.Unix(0, *job.SubmitTime))),
 	}
 
+	if tag := job.VersionTag; tag != nil {
+		basic = append(basic, fmt.Sprintf("Tag Name|%s", tag.Name))
+		if tag.Description != "" {
+			basic = append(basic, fmt.Sprintf("Tag Description|%s", tag.Description))
+		}
+	}
+
 	if diff != nil {
-		//diffStr := fmt.Sprintf("Difference between version %d and %d:", *job.Version, nextVersion)
 		basic = append(basic, fmt.Sprintf("Diff|\n%s", strings.TrimSpace(formatJobDiff(diff, false))))
 	}
 
@@ -4,10 +4,12 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/hashicorp/nomad/helper"
 	"github.com/hashicorp/nomad/nomad/mock"
 	"github.com/mitchellh/cli"
 	"github.com/posener/complete"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestJobHistoryCommand_Implements(t *testing.T) {
@@ -61,3 +63,45 @@ func TestJobHistoryCommand_AutocompleteArgs(t *testing.T) {
 	assert.Equal(1, len(res))
 	assert.Equal(j.ID, res[0])
 }
+
+func TestJobHistoryCommand_DiffVersion(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	srv, client, url := testServer(t, false, nil)
+	defer srv.Shutdown()
+
+	// Register three versions of the job
+	job := testJob("job1")
+	for _, priority := range []int{50, 60, 70} {
+		job.Priority = helper.IntToPtr(priority)
+		_, _, err := client.Jobs().Register(job, nil)
+		require.NoError(err)
+	}
+
+	// Diff every version against the first
+	ui := new(cli.MockUi)
+	cmd := &JobHistoryCommand{Meta: Meta{Ui: ui}}
+	code := cmd.Run([]string{"-address=" + url, "-diff-version=0", "job1"})
+	require.Equal(0, code, ui.ErrorWriter.String())
+	out := ui.OutputWriter.String()
+	require.Contains(out, `"50" => "70"`)
+	require.Contains(out, `"50" => "60"`)
+	require.NotContains(out, `"60" => "70"`)
+
+	// Diff a single version
+	ui = new(cli.MockUi)
+	cmd = &JobHistoryCommand{Meta: Meta{Ui: ui}}
+	code = cmd.Run([]string{"-address=" + url, "-diff-version=2", "-version=0", "job1"})
+	require.Equal(0, code, ui.ErrorWriter.String())
+	out = ui.OutputWriter.String()
+	require.Contains(out, `"70" => "50"`)
+	require.NotContains(out, "Version     = 1")
+
+	// Fails on a missing version
+	ui = new(cli.MockUi)
+	cmd = &JobHistoryCommand{Meta: Meta{Ui: ui}}
+	code = cmd.Run([]string{"-address=" + url, "-diff-version=9", "job1"})
+	require.Equal(1, code)
+	require.Contains(ui.ErrorWriter.String(), "Error diffing job version")
+}
@@ -30,6 +30,10 @@ Usage: nomad job periodic <subcommand> [options] [args]
 
       $ nomad job periodic force <job_id>
 
+  Display the upcoming launches and launch history of a periodic job:
+
+      $ nomad job periodic status <job_id>
+
   Please see the individual subcommand help for detailed usage information.
 `
 	return strings.TrimSpace(helpText)
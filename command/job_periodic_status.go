@@ -0,0 +1,208 @@
+package command
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/posener/complete"
+)
+
+const (
+	// defaultPeriodicStatusNext is the default number of upcoming launches
+	// displayed by the periodic status command.
+	defaultPeriodicStatusNext = 5
+)
+
+type JobPeriodicStatusCommand struct {
+	Meta
+}
+
+func (c *JobPeriodicStatusCommand) Help() string {
+	helpText := `
+Usage: nomad job periodic status [options] <job id>
+
+  Display the upcoming launch times of a periodic job along with the history
+  of its launches.
+
+General Options:
+
+  ` + generalOptionsUsage() + `
+
+Periodic Status Options:
+
+  -next <count>
+    Number of upcoming launch times to display. Defaults to 5.
+`
+
+	return strings.TrimSpace(helpText)
+}
+
+func (c *JobPeriodicStatusCommand) Synopsis() string {
+	return "Display the launch schedule and history of a periodic job"
+}
+
+func (c *JobPeriodicStatusCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-next": complete.PredictAnything,
+		})
+}
+
+func (c *JobPeriodicStatusCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictFunc(func(a complete.Args) []string {
+		client, err := c.Meta.Client()
+		if err != nil {
+			return nil
+		}
+
+		resp, _, err := client.Jobs().PrefixList(a.Last)
+		if err != nil {
+			return []string{}
+		}
+
+		// filter this by periodic jobs
+		matches := make([]string, 0, len(resp))
+		for _, job := range resp {
+			if job.Periodic {
+				matches = append(matches, job.ID)
+			}
+		}
+		return matches
+	})
+}
+
+func (c *JobPeriodicStatusCommand) Name() string { return "job periodic status" }
+
+func (c *JobPeriodicStatusCommand) Run(args []string) int {
+	var next int
+
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.IntVar(&next, "next", defaultPeriodicStatusNext, "")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Check that we got exactly one argument
+	args = flags.Args()
+	if l := len(args); l != 1 {
+		c.Ui.Error("This command takes one argument: <job id>")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	if next < 0 {
+		c.Ui.Error("The -next flag must be non-negative")
+		return 1
+	}
+
+	// Get the HTTP client
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	// Check if the job exists
+	jobID := args[0]
+	jobs, _, err := client.Jobs().PrefixList(jobID)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error querying periodic job: %s", err))
+		return 1
+	}
+	// filter non-periodic jobs
+	periodicJobs := make([]*api.JobListStub, 0, len(jobs))
+	for _, j := range jobs {
+		if j.Periodic {
+			periodicJobs = append(periodicJobs, j)
+		}
+	}
+	if len(periodicJobs) == 0 {
+		c.Ui.Error(fmt.Sprintf("No periodic job(s) with prefix or id %q found", jobID))
+		return 1
+	}
+	if len(periodicJobs) > 1 {
+		c.Ui.Error(fmt.Sprintf("Prefix matched multiple periodic jobs\n\n%s", createStatusListOutput(periodicJobs)))
+		return 1
+	}
+	jobID = periodicJobs[0].ID
+
+	job, _, err := client.Jobs().Info(jobID, nil)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error querying job: %s", err))
+		return 1
+	}
+
+	status, _, err := client.Jobs().PeriodicStatus(jobID, next, nil)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error querying periodic status of job %q: %s", jobID, err))
+		return 1
+	}
+
+	c.Ui.Output(c.formatPeriodicStatus(job, status))
+	return 0
+}
+
+// formatPeriodicStatus formats the periodic configuration, upcoming launches
+// and launch history of a periodic job.
+func (c *JobPeriodicStatusCommand) formatPeriodicStatus(job *api.Job, status *api.PeriodicStatus) string {
+	p := job.Periodic
+	specs := p.Specs
+	if p.Spec != nil && *p.Spec != "" {
+		specs = append([]string{*p.Spec}, specs...)
+	}
+
+	catchup := *p.Catchup
+	if p.CatchupWindow != nil && *p.CatchupWindow > 0 {
+		catchup = fmt.Sprintf("%s (window %v)", catchup, *p.CatchupWindow)
+	}
+
+	basic := []string{
+		fmt.Sprintf("ID|%s", *job.ID),
+		fmt.Sprintf("Specs|%s", strings.Join(specs, ", ")),
+		fmt.Sprintf("Time Zone|%s", *p.TimeZone),
+		fmt.Sprintf("Prohibit Overlap|%v", *p.ProhibitOverlap),
+		fmt.Sprintf("Catchup|%s", catchup),
+	}
+	if status.LastLaunch != nil {
+		basic = append(basic, fmt.Sprintf("Last Launch|%s", formatTime(status.LastLaunch.Launch)))
+	}
+
+	var out strings.Builder
+	out.WriteString(formatKV(basic))
+
+	out.WriteString(c.Colorize().Color("\n\n[bold]Upcoming Launches[reset]\n"))
+	if *job.Stop || !*p.Enabled {
+		out.WriteString("No upcoming launches (job stopped or periodic disabled)")
+	} else if len(status.Next) == 0 {
+		out.WriteString("No upcoming launches")
+	} else {
+		now := time.Now()
+		next := make([]string, len(status.Next))
+		for i, t := range status.Next {
+			next[i] = fmt.Sprintf("%s (%s from now)", formatTime(t), formatTimeDifference(now, t, time.Second))
+		}
+		out.WriteString(strings.Join(next, "\n"))
+	}
+
+	out.WriteString(c.Colorize().Color("\n\n[bold]Launch History[reset]\n"))
+	if len(status.Launches) == 0 {
+		out.WriteString("No instances of periodic job found")
+		return out.String()
+	}
+
+	launches := make([]string, len(status.Launches)+1)
+	launches[0] = "ID|Launch Time|Submit Date|Status"
+	for i, l := range status.Launches {
+		launches[i+1] = fmt.Sprintf("%s|%s|%s|%s",
+			l.JobID,
+			formatTime(l.Launch),
+			formatTime(time.Unix(0, l.SubmitTime)),
+			l.Status)
+	}
+	out.WriteString(formatList(launches))
+	return out.String()
+}
@@ -0,0 +1,72 @@
+package command
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/helper"
+	"github.com/mitchellh/cli"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJobPeriodicStatusCommand_Implements(t *testing.T) {
+	t.Parallel()
+	var _ cli.Command = &JobPeriodicStatusCommand{}
+}
+
+func TestJobPeriodicStatusCommand_Fails(t *testing.T) {
+	t.Parallel()
+	ui := new(cli.MockUi)
+	cmd := &JobPeriodicStatusCommand{Meta: Meta{Ui: ui}}
+
+	// Fails on misuse
+	code := cmd.Run([]string{"some", "bad", "args"})
+	require.Equal(t, 1, code, "expected error")
+	out := ui.ErrorWriter.String()
+	require.Contains(t, out, commandErrorText(cmd), "expected help output")
+	ui.ErrorWriter.Reset()
+
+	code = cmd.Run([]string{"-next=-1", "12"})
+	require.Equal(t, 1, code, "expected error")
+	require.Contains(t, ui.ErrorWriter.String(), "must be non-negative")
+	ui.ErrorWriter.Reset()
+
+	code = cmd.Run([]string{"-address=nope", "12"})
+	require.Equal(t, 1, code, "expected error")
+	out = ui.ErrorWriter.String()
+	require.Contains(t, out, "Error querying periodic job", "expected query error")
+}
+
+func TestJobPeriodicStatusCommand_Run(t *testing.T) {
+	t.Parallel()
+	srv, client, url := testServer(t, false, nil)
+	defer srv.Shutdown()
+
+	// Register a periodic job that launches on weekdays and weekends
+	j := testJob("job1_is_periodic")
+	j.Periodic = &api.PeriodicConfig{
+		SpecType: helper.StringToPtr(api.PeriodicSpecCron),
+		Specs:    []string{"0 9 * * 1-5", "0 12 * * 6,0"},
+		Catchup:  helper.StringToPtr(api.PeriodicCatchupNone),
+	}
+	_, _, err := client.Jobs().Register(j, nil)
+	require.NoError(t, err)
+
+	// Force a launch so there is some history
+	_, _, err = client.Jobs().PeriodicForce("job1_is_periodic", nil)
+	require.NoError(t, err)
+
+	ui := new(cli.MockUi)
+	cmd := &JobPeriodicStatusCommand{Meta: Meta{Ui: ui, flagAddress: url}}
+
+	code := cmd.Run([]string{"-address=" + url, "-next=3", "job1_is_periodic"})
+	require.Equal(t, 0, code, ui.ErrorWriter.String())
+	out := ui.OutputWriter.String()
+	require.Contains(t, out, "0 9 * * 1-5, 0 12 * * 6,0")
+	require.Regexp(t, `Catchup\s+= none`, out)
+	require.Contains(t, out, "Upcoming Launches")
+	require.Equal(t, 3, strings.Count(out, "from now"))
+	require.Contains(t, out, "Launch History")
+	require.Contains(t, out, "job1_is_periodic/periodic-")
+}
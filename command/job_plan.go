@@ -71,6 +71,20 @@ Plan Options:
     Determines whether the diff between the remote job and planned job is shown.
     Defaults to true.
 
+  -hcl2
+    Parses the job file as HCL2, which supports variable and locals blocks,
+    functions and dynamic blocks. Implied by the -var and -var-file flags.
+
+  -var 'key=value'
+    Sets the value of a variable declared in the job file. Values of list,
+    map and object variables are HCL expressions, such as '["dc1", "dc2"]'.
+    Can be specified multiple times.
+
+  -var-file=path
+    Sets the values of the variables declared in the job file from an HCL or
+    JSON file. Can be specified multiple times. Values set with -var take
+    precedence.
+
   -policy-override
     Sets the flag to force override any soft mandatory Sentinel policies.
 
@@ -90,7 +104,8 @@ func (c *JobPlanCommand) AutocompleteFlags() complete.Flags {
 			"-diff":            complete.PredictNothing,
 			"-policy-override": complete.PredictNothing,
 			"-verbose":         complete.PredictNothing,
-		})
+		},
+		hcl2AutocompleteFlags())
 }
 
 func (c *JobPlanCommand) AutocompleteArgs() complete.Predictor {
@@ -106,6 +121,7 @@ func (c *JobPlanCommand) Run(args []string) int {
 	flags.BoolVar(&diff, "diff", true, "")
 	flags.BoolVar(&policyOverride, "policy-override", false, "")
 	flags.BoolVar(&verbose, "verbose", false, "")
+	c.JobGetter.setHCL2Flags(flags)
 
 	if err := flags.Parse(args); err != nil {
 		return 255
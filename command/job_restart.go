@@ -0,0 +1,537 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"os"
+	"os/signal"
+	"sort"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/api/contexts"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/posener/complete"
+)
+
+// jobRestartPollInterval is how often the allocations of a batch are checked
+// while waiting for them to become healthy.
+var jobRestartPollInterval = time.Second
+
+type JobRestartCommand struct {
+	Meta
+
+	client          *api.Client
+	task            string
+	reschedule      bool
+	healthyDeadline time.Duration
+}
+
+func (c *JobRestartCommand) Help() string {
+	helpText := `
+Usage: nomad job restart [options] <job>
+
+  Restart restarts the running allocations of a job in batches, without
+  registering a new version of the job. By default the tasks of the
+  allocations are restarted in place. With -reschedule the allocations are
+  stopped instead and replaced by the scheduler, possibly on other nodes.
+
+  Each batch must become healthy before the next batch is restarted. An
+  in-place restart is healthy once the restarted tasks are running again; a
+  rescheduled allocation is healthy once its replacement is running, or
+  marked healthy by its deployment.
+
+  The restart can be interrupted with ctrl+c, in which case no new batch is
+  started. The command prints the time the restart began; passing it to
+  -resume skips the allocations that were restarted or replaced since then.
+
+General Options:
+
+  ` + generalOptionsUsage() + `
+
+Restart Options:
+
+  -batch-size <n|n%>
+    Number of allocations to restart at once. It may be given as a
+    percentage of the allocations to restart. Defaults to 1.
+
+  -batch-wait <duration>
+    Time to wait between a batch becoming healthy and the next batch being
+    restarted. Defaults to 0.
+
+  -group <group>
+    Only restart the allocations of the given task group.
+
+  -task <task>
+    Only restart the given task of the allocations. This cannot be used with
+    -reschedule.
+
+  -reschedule
+    Stop the allocations and let the scheduler replace them instead of
+    restarting their tasks in place.
+
+  -healthy-deadline <duration>
+    Time a batch has to become healthy before the restart is aborted.
+    Defaults to 5m.
+
+  -resume <time>
+    Resume an interrupted restart. Allocations restarted or replaced after the
+    given time, as printed when the interrupted restart began, are skipped.
+
+  -verbose
+    Display full information.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *JobRestartCommand) Synopsis() string {
+	return "Restart the allocations of a job in batches"
+}
+
+func (c *JobRestartCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-batch-size":       complete.PredictAnything,
+			"-batch-wait":       complete.PredictAnything,
+			"-group":            complete.PredictAnything,
+			"-task":             complete.PredictAnything,
+			"-reschedule":       complete.PredictNothing,
+			"-healthy-deadline": complete.PredictAnything,
+			"-resume":           complete.PredictAnything,
+			"-verbose":          complete.PredictNothing,
+		})
+}
+
+func (c *JobRestartCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictFunc(func(a complete.Args) []string {
+		client, err := c.Meta.Client()
+		if err != nil {
+			return nil
+		}
+
+		resp, _, err := client.Search().PrefixSearch(a.Last, contexts.Jobs, nil)
+		if err != nil {
+			return []string{}
+		}
+		return resp.Matches[contexts.Jobs]
+	})
+}
+
+func (c *JobRestartCommand) Name() string { return "job restart" }
+
+func (c *JobRestartCommand) Run(args []string) int {
+	var verbose bool
+	var batchSizeStr, group, resume string
+	var batchWait time.Duration
+
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.StringVar(&batchSizeStr, "batch-size", "1", "")
+	flags.DurationVar(&batchWait, "batch-wait", 0, "")
+	flags.StringVar(&group, "group", "", "")
+	flags.StringVar(&c.task, "task", "", "")
+	flags.BoolVar(&c.reschedule, "reschedule", false, "")
+	flags.DurationVar(&c.healthyDeadline, "healthy-deadline", 5*time.Minute, "")
+	flags.StringVar(&resume, "resume", "", "")
+	flags.BoolVar(&verbose, "verbose", false, "")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Truncate the id unless full length is requested
+	length := shortId
+	if verbose {
+		length = fullId
+	}
+
+	// Check that we got exactly one job
+	args = flags.Args()
+	if len(args) != 1 {
+		c.Ui.Error("This command takes one argument: <job>")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	if c.task != "" && c.reschedule {
+		c.Ui.Error("The -task and -reschedule options are mutually exclusive")
+		return 1
+	}
+	if _, err := parseBatchSize(batchSizeStr, 1); err != nil {
+		c.Ui.Error(fmt.Sprintf("Error parsing batch size %q: %v", batchSizeStr, err))
+		return 1
+	}
+	if batchWait < 0 || c.healthyDeadline <= 0 {
+		c.Ui.Error("The -batch-wait option must be non-negative and -healthy-deadline positive")
+		return 1
+	}
+
+	// The restart began at the resumed time, if any
+	started := time.Now().UTC()
+	if resume != "" {
+		t, err := time.Parse(time.RFC3339Nano, resume)
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Error parsing resume time %q: %v", resume, err))
+			return 1
+		}
+		started = t
+	}
+
+	// Get the HTTP client
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+	c.client = client
+
+	// Check if the job exists
+	jobID := args[0]
+	jobs, _, err := client.Jobs().PrefixList(jobID)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error listing jobs: %s", err))
+		return 1
+	}
+	if len(jobs) == 0 {
+		c.Ui.Error(fmt.Sprintf("No job(s) with prefix or id %q found", jobID))
+		return 1
+	}
+	if len(jobs) > 1 && strings.TrimSpace(jobID) != jobs[0].ID {
+		c.Ui.Error(fmt.Sprintf("Prefix matched multiple jobs\n\n%s", createStatusListOutput(jobs)))
+		return 1
+	}
+	jobID = jobs[0].ID
+
+	job, _, err := client.Jobs().Info(jobID, nil)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error querying job: %s", err))
+		return 1
+	}
+	if err := validateJobRestartTarget(job, group, c.task); err != nil {
+		c.Ui.Error(err.Error())
+		return 1
+	}
+
+	// Find the allocations that remain to be restarted
+	stubs, _, err := client.Jobs().Allocations(jobID, false, nil)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error querying job allocations: %s", err))
+		return 1
+	}
+	allocs := filterRestartAllocs(stubs, group, c.task, started)
+	if len(allocs) == 0 {
+		c.Ui.Output(fmt.Sprintf("No allocations of job %q to restart", jobID))
+		return 0
+	}
+
+	batchSize, _ := parseBatchSize(batchSizeStr, len(allocs))
+
+	// Stop starting new batches on interrupt
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	signalCh := make(chan os.Signal, 1)
+	signal.Notify(signalCh, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(signalCh)
+
+	go func() {
+		select {
+		case <-signalCh:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	resumeHint := fmt.Sprintf("Run the command again with -resume=%s to resume the restart",
+		started.Format(time.RFC3339Nano))
+
+	c.Ui.Output(fmt.Sprintf("==> Restarting %d allocation(s) of job %q in batches of %d (started %s)",
+		len(allocs), jobID, batchSize, started.Format(time.RFC3339Nano)))
+
+	for i := 0; i < len(allocs); i += batchSize {
+		if i > 0 && batchWait > 0 {
+			c.Ui.Output(fmt.Sprintf("    Waiting %s before the next batch", batchWait))
+			select {
+			case <-ctx.Done():
+			case <-time.After(batchWait):
+			}
+		}
+		if ctx.Err() != nil {
+			c.Ui.Error("Restart interrupted")
+			c.Ui.Error(resumeHint)
+			return 1
+		}
+
+		end := i + batchSize
+		if end > len(allocs) {
+			end = len(allocs)
+		}
+		batch := allocs[i:end]
+
+		if err := c.restartBatch(ctx, batch, length); err != nil {
+			if ctx.Err() != nil {
+				c.Ui.Error("Restart interrupted")
+			} else {
+				c.Ui.Error(fmt.Sprintf("Error restarting allocations: %v", err))
+			}
+			c.Ui.Error(resumeHint)
+			return 1
+		}
+		c.Ui.Output(fmt.Sprintf("    Batch %d/%d healthy",
+			i/batchSize+1, (len(allocs)+batchSize-1)/batchSize))
+	}
+
+	c.Ui.Output(fmt.Sprintf("==> Restarted %d allocation(s) of job %q", len(allocs), jobID))
+	return 0
+}
+
+// restartBatch restarts or stops the allocations of the batch and waits for
+// them to become healthy.
+func (c *JobRestartCommand) restartBatch(ctx context.Context, batch []*api.AllocationListStub, length int) error {
+	targets := make([]*restartTarget, len(batch))
+	for i, stub := range batch {
+		alloc := &api.Allocation{ID: stub.ID}
+		t := &restartTarget{allocID: stub.ID, lastRestart: runningTasks(stub.TaskStates, c.task)}
+		if c.reschedule {
+			c.Ui.Output(fmt.Sprintf("    Stopping allocation %q", limit(stub.ID, length)))
+			if _, err := c.client.Allocations().Stop(alloc, nil); err != nil {
+				return fmt.Errorf("failed to stop allocation %q: %v", limit(stub.ID, length), err)
+			}
+		} else {
+			c.Ui.Output(fmt.Sprintf("    Restarting allocation %q", limit(stub.ID, length)))
+			if err := c.client.Allocations().Restart(alloc, c.task, nil); err != nil {
+				return fmt.Errorf("failed to restart allocation %q: %v", limit(stub.ID, length), err)
+			}
+		}
+		targets[i] = t
+	}
+
+	deadline := time.NewTimer(c.healthyDeadline)
+	defer deadline.Stop()
+
+	for {
+		pending := 0
+		for _, t := range targets {
+			healthy, err := c.checkHealth(t)
+			if err != nil {
+				return err
+			}
+			if !healthy {
+				pending++
+			}
+		}
+		if pending == 0 {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-deadline.C:
+			return fmt.Errorf("%d allocation(s) not healthy after %s", pending, c.healthyDeadline)
+		case <-time.After(jobRestartPollInterval):
+		}
+	}
+}
+
+// restartTarget tracks an allocation of a batch until it is healthy again.
+type restartTarget struct {
+	allocID string
+
+	// lastRestart is the time the restarted tasks of the allocation last
+	// restarted before the restart was requested
+	lastRestart map[string]time.Time
+
+	// replacementID is the ID of the allocation replacing a rescheduled
+	// allocation
+	replacementID string
+}
+
+// checkHealth returns whether the allocation was restarted and is healthy,
+// or an error if it failed.
+func (c *JobRestartCommand) checkHealth(t *restartTarget) (bool, error) {
+	if c.reschedule {
+		return c.checkReplacementHealth(t)
+	}
+
+	alloc, _, err := c.client.Allocations().Info(t.allocID, nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to query allocation %q: %v", t.allocID, err)
+	}
+	switch alloc.ClientStatus {
+	case api.AllocClientStatusFailed, api.AllocClientStatusLost, api.AllocClientStatusComplete:
+		return false, fmt.Errorf("allocation %q is %s", t.allocID, alloc.ClientStatus)
+	}
+
+	for name, last := range t.lastRestart {
+		state := alloc.TaskStates[name]
+		if state == nil {
+			return false, nil
+		}
+		if state.Failed {
+			return false, fmt.Errorf("task %q of allocation %q failed", name, t.allocID)
+		}
+		if !state.LastRestart.After(last) || state.State != structs.TaskStateRunning {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// checkReplacementHealth returns whether the replacement of a stopped
+// allocation is healthy, or an error if it failed.
+func (c *JobRestartCommand) checkReplacementHealth(t *restartTarget) (bool, error) {
+	if t.replacementID == "" {
+		alloc, _, err := c.client.Allocations().Info(t.allocID, nil)
+		if err != nil {
+			return false, fmt.Errorf("failed to query allocation %q: %v", t.allocID, err)
+		}
+		if alloc.NextAllocation == "" {
+			return false, nil
+		}
+		t.replacementID = alloc.NextAllocation
+	}
+
+	alloc, _, err := c.client.Allocations().Info(t.replacementID, nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to query allocation %q: %v", t.replacementID, err)
+	}
+	if ds := alloc.DeploymentStatus; ds != nil && ds.Healthy != nil {
+		if !*ds.Healthy {
+			return false, fmt.Errorf("replacement allocation %q is unhealthy", t.replacementID)
+		}
+		return true, nil
+	}
+	switch alloc.ClientStatus {
+	case api.AllocClientStatusFailed, api.AllocClientStatusLost:
+		return false, fmt.Errorf("replacement allocation %q is %s", t.replacementID, alloc.ClientStatus)
+	case api.AllocClientStatusRunning:
+	default:
+		return false, nil
+	}
+
+	for _, state := range alloc.TaskStates {
+		if state.State != structs.TaskStateRunning {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// validateJobRestartTarget validates that the group and task to restart
+// exist in the job.
+func validateJobRestartTarget(job *api.Job, group, task string) error {
+	if group == "" && task == "" {
+		return nil
+	}
+
+	for _, tg := range job.TaskGroups {
+		if group != "" && *tg.Name != group {
+			continue
+		}
+		if task == "" {
+			return nil
+		}
+		for _, t := range tg.Tasks {
+			if t.Name == task {
+				return nil
+			}
+		}
+	}
+
+	if task == "" {
+		return fmt.Errorf("Task group %q not found in job %q", group, *job.ID)
+	}
+	return fmt.Errorf("Task %q not found in job %q", task, *job.ID)
+}
+
+// filterRestartAllocs returns the running allocations that were neither
+// created nor restarted since the restart started, ordered by name.
+func filterRestartAllocs(stubs []*api.AllocationListStub, group, task string, started time.Time) []*api.AllocationListStub {
+	var allocs []*api.AllocationListStub
+	for _, stub := range stubs {
+		if stub.DesiredStatus != api.AllocDesiredStatusRun || stub.ClientStatus != api.AllocClientStatusRunning {
+			continue
+		}
+		if group != "" && stub.TaskGroup != group {
+			continue
+		}
+		if stub.CreateTime >= started.UnixNano() {
+			continue
+		}
+		if restartedSince(stub.TaskStates, task, started) {
+			continue
+		}
+		allocs = append(allocs, stub)
+	}
+
+	sort.Slice(allocs, func(i, j int) bool {
+		return allocs[i].Name < allocs[j].Name
+	})
+	return allocs
+}
+
+// restartedSince returns whether the tasks were signaled to restart since
+// the given time.
+func restartedSince(states map[string]*api.TaskState, task string, since time.Time) bool {
+	restarted := false
+	for name, state := range states {
+		if task != "" && name != task {
+			continue
+		}
+		signaled := false
+		for _, e := range state.Events {
+			if e.Type == api.TaskRestartSignal && e.Time >= since.UnixNano() {
+				signaled = true
+			}
+		}
+		if !signaled {
+			return false
+		}
+		restarted = true
+	}
+	return restarted
+}
+
+// runningTasks returns the time each running task, or only the given task,
+// last restarted.
+func runningTasks(states map[string]*api.TaskState, task string) map[string]time.Time {
+	restarts := make(map[string]time.Time, len(states))
+	for name, state := range states {
+		if task != "" && name != task {
+			continue
+		}
+		if state.State == structs.TaskStateRunning {
+			restarts[name] = state.LastRestart
+		}
+	}
+	return restarts
+}
+
+// parseBatchSize parses a batch size given as a number of allocations or as
+// a percentage of the total number of allocations.
+func parseBatchSize(s string, total int) (int, error) {
+	if strings.HasSuffix(s, "%") {
+		pct, err := strconv.ParseFloat(strings.TrimSuffix(s, "%"), 64)
+		if err != nil {
+			return 0, err
+		}
+		if pct <= 0 || pct > 100 {
+			return 0, fmt.Errorf("percentage must be between 0 and 100")
+		}
+		return int(math.Ceil(pct / 100 * float64(total))), nil
+	}
+
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, err
+	}
+	if n < 1 {
+		return 0, fmt.Errorf("batch size must be positive")
+	}
+	return n, nil
+}
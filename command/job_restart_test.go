@@ -0,0 +1,254 @@
+package command
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/helper"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/mitchellh/cli"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJobRestartCommand_Implements(t *testing.T) {
+	t.Parallel()
+	var _ cli.Command = &JobRestartCommand{}
+}
+
+func TestJobRestartCommand_Fails(t *testing.T) {
+	t.Parallel()
+	ui := new(cli.MockUi)
+	cmd := &JobRestartCommand{Meta: Meta{Ui: ui}}
+
+	// Fails on misuse
+	if code := cmd.Run([]string{"some", "bad", "args"}); code != 1 {
+		t.Fatalf("expected exit code 1, got: %d", code)
+	}
+	if out := ui.ErrorWriter.String(); !strings.Contains(out, commandErrorText(cmd)) {
+		t.Fatalf("expected help output, got: %s", out)
+	}
+	ui.ErrorWriter.Reset()
+
+	// Fails on mutually exclusive options
+	if code := cmd.Run([]string{"-task=web", "-reschedule", "foo"}); code != 1 {
+		t.Fatalf("expected exit code 1, got: %d", code)
+	}
+	if out := ui.ErrorWriter.String(); !strings.Contains(out, "mutually exclusive") {
+		t.Fatalf("expected mutually exclusive error, got: %s", out)
+	}
+	ui.ErrorWriter.Reset()
+
+	// Fails on invalid batch size
+	if code := cmd.Run([]string{"-batch-size=0", "foo"}); code != 1 {
+		t.Fatalf("expected exit code 1, got: %d", code)
+	}
+	if out := ui.ErrorWriter.String(); !strings.Contains(out, "Error parsing batch size") {
+		t.Fatalf("expected batch size error, got: %s", out)
+	}
+	ui.ErrorWriter.Reset()
+
+	// Fails on invalid resume time
+	if code := cmd.Run([]string{"-resume=yesterday", "foo"}); code != 1 {
+		t.Fatalf("expected exit code 1, got: %d", code)
+	}
+	if out := ui.ErrorWriter.String(); !strings.Contains(out, "Error parsing resume time") {
+		t.Fatalf("expected resume time error, got: %s", out)
+	}
+	ui.ErrorWriter.Reset()
+
+	// Fails on connection failure
+	if code := cmd.Run([]string{"-address=nope", "foo"}); code != 1 {
+		t.Fatalf("expected exit code 1, got: %d", code)
+	}
+	if out := ui.ErrorWriter.String(); !strings.Contains(out, "Error listing jobs") {
+		t.Fatalf("expected failed query error, got: %s", out)
+	}
+}
+
+// testRestartJob registers a service job with the given count and waits for
+// its allocations to be running.
+func testRestartJob(t *testing.T, client *api.Client, jobID string, count int) []*api.AllocationListStub {
+	// Wait for a node to be ready
+	testutil.WaitForResult(func() (bool, error) {
+		nodes, _, err := client.Nodes().List(nil)
+		if err != nil {
+			return false, err
+		}
+		for _, node := range nodes {
+			if _, ok := node.Drivers["mock_driver"]; ok &&
+				node.Status == structs.NodeStatusReady {
+				return true, nil
+			}
+		}
+		return false, fmt.Errorf("no ready nodes")
+	}, func(err error) {
+		t.Fatalf("err: %v", err)
+	})
+
+	job := testJob(jobID)
+	job.Type = helper.StringToPtr(api.JobTypeService)
+	job.TaskGroups[0].Count = helper.IntToPtr(count)
+	job.TaskGroups[0].Tasks[0].Config["run_for"] = "60s"
+	_, _, err := client.Jobs().Register(job, nil)
+	require.NoError(t, err)
+
+	var allocs []*api.AllocationListStub
+	testutil.WaitForResult(func() (bool, error) {
+		allocs, _, err = client.Jobs().Allocations(jobID, false, nil)
+		if err != nil {
+			return false, err
+		}
+		if len(allocs) != count {
+			return false, fmt.Errorf("expected %d allocs, got %d", count, len(allocs))
+		}
+		for _, alloc := range allocs {
+			if alloc.ClientStatus != api.AllocClientStatusRunning {
+				return false, fmt.Errorf("alloc %q is %s", alloc.ID, alloc.ClientStatus)
+			}
+		}
+		return true, nil
+	}, func(err error) {
+		t.Fatalf("err: %v", err)
+	})
+	return allocs
+}
+
+func TestJobRestartCommand_Run(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	srv, client, url := testServer(t, true, nil)
+	defer srv.Shutdown()
+
+	allocs := testRestartJob(t, client, "job1_sfx", 2)
+	started := time.Now().UTC()
+
+	ui := new(cli.MockUi)
+	cmd := &JobRestartCommand{Meta: Meta{Ui: ui}}
+
+	// Fails on unknown task
+	code := cmd.Run([]string{"-address=" + url, "-task=nope", "job1"})
+	require.Equal(1, code)
+	require.Contains(ui.ErrorWriter.String(), `Task "nope" not found`)
+	ui.ErrorWriter.Reset()
+
+	code = cmd.Run([]string{"-address=" + url, "-batch-size=50%", "job1"})
+	require.Zero(code, ui.ErrorWriter.String())
+	out := ui.OutputWriter.String()
+	require.Contains(out, "in batches of 1")
+	require.Contains(out, "Batch 2/2 healthy")
+	require.Contains(out, `Restarted 2 allocation(s) of job "job1_sfx"`)
+	ui.OutputWriter.Reset()
+
+	// The tasks were restarted in place
+	for _, stub := range allocs {
+		alloc, _, err := client.Allocations().Info(stub.ID, nil)
+		require.NoError(err)
+		require.Equal(api.AllocClientStatusRunning, alloc.ClientStatus)
+		require.True(alloc.TaskStates["task1"].LastRestart.After(started))
+	}
+
+	// Resuming skips the allocations restarted since the start
+	code = cmd.Run([]string{"-address=" + url, "-resume=" + started.Format(time.RFC3339Nano), "job1"})
+	require.Zero(code, ui.ErrorWriter.String())
+	require.Contains(ui.OutputWriter.String(), `No allocations of job "job1_sfx" to restart`)
+}
+
+func TestJobRestartCommand_Run_Reschedule(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	srv, client, url := testServer(t, true, nil)
+	defer srv.Shutdown()
+
+	allocs := testRestartJob(t, client, "job1_sfx", 1)
+
+	ui := new(cli.MockUi)
+	cmd := &JobRestartCommand{Meta: Meta{Ui: ui}}
+
+	code := cmd.Run([]string{"-address=" + url, "-reschedule", "job1"})
+	require.Zero(code, ui.ErrorWriter.String())
+	require.Contains(ui.OutputWriter.String(), `Restarted 1 allocation(s) of job "job1_sfx"`)
+
+	// The allocation was replaced
+	alloc, _, err := client.Allocations().Info(allocs[0].ID, nil)
+	require.NoError(err)
+	require.Equal(api.AllocDesiredStatusStop, alloc.DesiredStatus)
+	require.NotEmpty(alloc.NextAllocation)
+}
+
+func TestJobRestartCommand_FilterRestartAllocs(t *testing.T) {
+	t.Parallel()
+	started := time.Now()
+	before := started.Add(-time.Minute).UnixNano()
+	after := started.Add(time.Minute).UnixNano()
+
+	signaled := func(at int64) map[string]*api.TaskState {
+		return map[string]*api.TaskState{
+			"web": {
+				State:  structs.TaskStateRunning,
+				Events: []*api.TaskEvent{{Type: api.TaskRestartSignal, Time: at}},
+			},
+		}
+	}
+
+	stubs := []*api.AllocationListStub{
+		{ID: "b", Name: "job.web[1]", TaskGroup: "web", DesiredStatus: api.AllocDesiredStatusRun,
+			ClientStatus: api.AllocClientStatusRunning, CreateTime: before, TaskStates: signaled(before)},
+		{ID: "a", Name: "job.web[0]", TaskGroup: "web", DesiredStatus: api.AllocDesiredStatusRun,
+			ClientStatus: api.AllocClientStatusRunning, CreateTime: before},
+		// Restarted since the start
+		{ID: "c", Name: "job.web[2]", TaskGroup: "web", DesiredStatus: api.AllocDesiredStatusRun,
+			ClientStatus: api.AllocClientStatusRunning, CreateTime: before, TaskStates: signaled(after)},
+		// Created since the start
+		{ID: "d", Name: "job.web[3]", TaskGroup: "web", DesiredStatus: api.AllocDesiredStatusRun,
+			ClientStatus: api.AllocClientStatusRunning, CreateTime: after},
+		// Not running
+		{ID: "e", Name: "job.web[4]", TaskGroup: "web", DesiredStatus: api.AllocDesiredStatusStop,
+			ClientStatus: api.AllocClientStatusComplete, CreateTime: before},
+		// Other group
+		{ID: "f", Name: "job.api[0]", TaskGroup: "api", DesiredStatus: api.AllocDesiredStatusRun,
+			ClientStatus: api.AllocClientStatusRunning, CreateTime: before},
+	}
+
+	var ids []string
+	for _, alloc := range filterRestartAllocs(stubs, "web", "", started) {
+		ids = append(ids, alloc.ID)
+	}
+	require.Equal(t, []string{"a", "b"}, ids)
+}
+
+func TestJobRestartCommand_ParseBatchSize(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		input    string
+		total    int
+		expected int
+		err      bool
+	}{
+		{input: "1", total: 10, expected: 1},
+		{input: "4", total: 2, expected: 4},
+		{input: "25%", total: 10, expected: 3},
+		{input: "100%", total: 10, expected: 10},
+		{input: "1%", total: 3, expected: 1},
+		{input: "0", err: true},
+		{input: "-2", err: true},
+		{input: "0%", err: true},
+		{input: "150%", err: true},
+		{input: "many", err: true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.input, func(t *testing.T) {
+			n, err := parseBatchSize(c.input, c.total)
+			if c.err {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, c.expected, n)
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"os"
 	"strings"
 
+	"github.com/hashicorp/nomad/api"
 	"github.com/hashicorp/nomad/api/contexts"
 	"github.com/posener/complete"
 )
@@ -15,9 +16,10 @@ type JobRevertCommand struct {
 
 func (c *JobRevertCommand) Help() string {
 	helpText := `
-Usage: nomad job revert [options] <job> <version>
+Usage: nomad job revert [options] <job> <version|tag>
 
-  Revert is used to revert a job to a prior version of the job. The available
+  Revert is used to revert a job to a prior version of the job. The version to
+  revert to can be given by number or by the name of its tag. The available
   versions to revert to can be found using "nomad job history" command.
 
 General Options:
@@ -93,7 +95,7 @@ func (c *JobRevertCommand) Run(args []string) int {
 	// Check that we got two args
 	args = flags.Args()
 	if l := len(args); l != 2 {
-		c.Ui.Error("This command takes two arguments: <job> <version>")
+		c.Ui.Error("This command takes two arguments: <job> <version|tag>")
 		c.Ui.Error(commandErrorText(c))
 		return 1
 	}
@@ -111,15 +113,16 @@ func (c *JobRevertCommand) Run(args []string) int {
 		vaultToken = os.Getenv("VAULT_TOKEN")
 	}
 
+	// The version is either a version number or the name of a version tag
 	jobID := args[0]
 	revertVersion, ok, err := parseVersion(args[1])
 	if !ok {
-		c.Ui.Error("The job version to revert to must be specified using the -job-version flag")
+		c.Ui.Error("The job version to revert to must be specified")
 		return 1
 	}
+	tagName := ""
 	if err != nil {
-		c.Ui.Error(fmt.Sprintf("Failed to parse job-version flag: %v", err))
-		return 1
+		tagName = args[1]
 	}
 
 	// Check if the job exists
@@ -138,7 +141,12 @@ func (c *JobRevertCommand) Run(args []string) int {
 	}
 
 	// Prefix lookup matched a single job
-	resp, _, err := client.Jobs().Revert(jobs[0].ID, revertVersion, nil, nil, vaultToken)
+	var resp *api.JobRegisterResponse
+	if tagName != "" {
+		resp, _, err = client.Jobs().RevertToTag(jobs[0].ID, tagName, nil, nil, vaultToken)
+	} else {
+		resp, _, err = client.Jobs().Revert(jobs[0].ID, revertVersion, nil, nil, vaultToken)
+	}
 	if err != nil {
 		c.Ui.Error(fmt.Sprintf("Error retrieving job versions: %s", err))
 		return 1
@@ -75,6 +75,20 @@ Run Options:
     the evaluation ID will be printed to the screen, which can be used to
     examine the evaluation using the eval-status command.
 
+  -hcl2
+    Parses the job file as HCL2, which supports variable and locals blocks,
+    functions and dynamic blocks. Implied by the -var and -var-file flags.
+
+  -var 'key=value'
+    Sets the value of a variable declared in the job file. Values of list,
+    map and object variables are HCL expressions, such as '["dc1", "dc2"]'.
+    Can be specified multiple times.
+
+  -var-file=path
+    Sets the values of the variables declared in the job file from an HCL or
+    JSON file. Can be specified multiple times. Values set with -var take
+    precedence.
+
   -output
     Output the JSON that would be submitted to the HTTP API without submitting
     the job.
@@ -107,7 +121,8 @@ func (c *JobRunCommand) AutocompleteFlags() complete.Flags {
 			"-vault-token":     complete.PredictAnything,
 			"-output":          complete.PredictNothing,
 			"-policy-override": complete.PredictNothing,
-		})
+		},
+		hcl2AutocompleteFlags())
 }
 
 func (c *JobRunCommand) AutocompleteArgs() complete.Predictor {
@@ -128,6 +143,7 @@ func (c *JobRunCommand) Run(args []string) int {
 	flags.BoolVar(&override, "policy-override", false, "")
 	flags.StringVar(&checkIndexStr, "check-index", "", "")
 	flags.StringVar(&vaultToken, "vault-token", "", "")
+	c.JobGetter.setHCL2Flags(flags)
 
 	if err := flags.Parse(args); err != nil {
 		return 1
@@ -148,7 +164,7 @@ func (c *JobRunCommand) Run(args []string) int {
 	}
 
 	// Get Job struct from Jobfile
-	job, err := c.JobGetter.ApiJob(args[0])
+	submission, job, err := c.JobGetter.Get(args[0])
 	if err != nil {
 		c.Ui.Error(fmt.Sprintf("Error getting job struct: %s", err))
 		return 1
@@ -205,7 +221,7 @@ func (c *JobRunCommand) Run(args []string) int {
 	}
 
 	// Set the register options
-	opts := &api.RegisterOptions{}
+	opts := &api.RegisterOptions{Submission: submission}
 	if enforce {
 		opts.EnforceIndex = true
 		opts.ModifyIndex = checkIndex
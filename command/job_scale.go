@@ -0,0 +1,218 @@
+package command
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/api/contexts"
+	"github.com/posener/complete"
+)
+
+type JobScaleCommand struct {
+	Meta
+}
+
+func (c *JobScaleCommand) Help() string {
+	helpText := `
+Usage: nomad job scale [options] <job> [<group>] <count>
+
+  Scale is used to change the count of a task group of a job without
+  resubmitting the job. The group may be omitted if the job has a single task
+  group. A scaling event is recorded for the group along with the optional
+  message; the recent scaling events can be read from the job scale API.
+
+  Upon successful scaling, an interactive monitor session will start to
+  display log lines as the job starts or stops its allocations. It is safe to
+  exit the monitor early using ctrl+c.
+
+General Options:
+
+  ` + generalOptionsUsage() + `
+
+Scale Options:
+
+  -check-index
+    If set, the job is only scaled if the passed job modify index matches the
+    server side version. This ensures that the job is being scaled from a known
+    state.
+
+  -detach
+    Return immediately instead of entering monitor mode. After the scaling
+    request is accepted, the evaluation ID will be printed to the screen, which
+    can be used to examine the evaluation using the eval-status command.
+
+  -message
+    A message describing the scaling event.
+
+  -verbose
+    Display full information.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *JobScaleCommand) Synopsis() string {
+	return "Change the count of a task group of a job"
+}
+
+func (c *JobScaleCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-check-index": complete.PredictNothing,
+			"-detach":      complete.PredictNothing,
+			"-message":     complete.PredictAnything,
+			"-verbose":     complete.PredictNothing,
+		})
+}
+
+func (c *JobScaleCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictFunc(func(a complete.Args) []string {
+		client, err := c.Meta.Client()
+		if err != nil {
+			return nil
+		}
+
+		resp, _, err := client.Search().PrefixSearch(a.Last, contexts.Jobs, nil)
+		if err != nil {
+			return []string{}
+		}
+		return resp.Matches[contexts.Jobs]
+	})
+}
+
+func (c *JobScaleCommand) Name() string { return "job scale" }
+
+func (c *JobScaleCommand) Run(args []string) int {
+	var detach, verbose bool
+	var checkIndexStr, message string
+
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.BoolVar(&detach, "detach", false, "")
+	flags.BoolVar(&verbose, "verbose", false, "")
+	flags.StringVar(&checkIndexStr, "check-index", "", "")
+	flags.StringVar(&message, "message", "", "")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Truncate the id unless full length is requested
+	length := shortId
+	if verbose {
+		length = fullId
+	}
+
+	// Check that we got two or three args
+	args = flags.Args()
+	if l := len(args); l != 2 && l != 3 {
+		c.Ui.Error("This command takes two or three arguments: <job> [<group>] <count>")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	// Parse the count
+	countStr := args[len(args)-1]
+	count, err := strconv.Atoi(countStr)
+	if err != nil || count < 0 {
+		c.Ui.Error(fmt.Sprintf("Invalid count %q: must be a non-negative integer", countStr))
+		return 1
+	}
+
+	// Parse the check-index
+	checkIndex, enforce, err := parseCheckIndex(checkIndexStr)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error parsing check-index value %q: %v", checkIndexStr, err))
+		return 1
+	}
+
+	// Get the HTTP client
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	// Check if the job exists
+	jobID := args[0]
+	jobs, _, err := client.Jobs().PrefixList(jobID)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error listing jobs: %s", err))
+		return 1
+	}
+	if len(jobs) == 0 {
+		c.Ui.Error(fmt.Sprintf("No job(s) with prefix or id %q found", jobID))
+		return 1
+	}
+	if len(jobs) > 1 && strings.TrimSpace(jobID) != jobs[0].ID {
+		c.Ui.Error(fmt.Sprintf("Prefix matched multiple jobs\n\n%s", createStatusListOutput(jobs)))
+		return 1
+	}
+	jobID = jobs[0].ID
+
+	// Default to the single task group of the job
+	var group string
+	if len(args) == 3 {
+		group = args[1]
+	} else {
+		job, _, err := client.Jobs().Info(jobID, nil)
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Error querying job: %s", err))
+			return 1
+		}
+		if len(job.TaskGroups) != 1 {
+			c.Ui.Error(fmt.Sprintf("Job %q has %d task groups, the group to scale must be specified",
+				jobID, len(job.TaskGroups)))
+			return 1
+		}
+		group = *job.TaskGroups[0].Name
+	}
+
+	opts := &api.ScaleOptions{
+		Message: message,
+	}
+	if enforce {
+		opts.EnforceIndex = true
+		opts.ModifyIndex = checkIndex
+	}
+
+	resp, _, err := client.Jobs().ScaleOpts(jobID, group, &count, opts, nil)
+	if err != nil {
+		if strings.Contains(err.Error(), api.RegisterEnforceIndexErrPrefix) {
+			// Format the error specially if the error is due to index
+			// enforcement
+			matches := enforceIndexRegex.FindStringSubmatch(err.Error())
+			if len(matches) == 2 {
+				c.Ui.Error(matches[1]) // The matched group
+				c.Ui.Error("Job not scaled")
+				return 1
+			}
+		}
+
+		c.Ui.Error(fmt.Sprintf("Error scaling job: %s", err))
+		return 1
+	}
+
+	// Print any warnings if there are any
+	if resp.Warnings != "" {
+		c.Ui.Output(
+			c.Colorize().Color(fmt.Sprintf("[bold][yellow]Job Warnings:\n%s[reset]\n", resp.Warnings)))
+	}
+
+	// Nothing to do
+	evalCreated := resp.EvalID != ""
+	if !evalCreated {
+		c.Ui.Output(fmt.Sprintf("Task group %q of job %q scaled to %d", group, jobID, count))
+		return 0
+	}
+
+	if detach {
+		c.Ui.Output(fmt.Sprintf("Task group %q of job %q scaled to %d", group, jobID, count))
+		c.Ui.Output("Evaluation ID: " + resp.EvalID)
+		return 0
+	}
+
+	mon := newMonitor(c.Ui, client, length)
+	return mon.monitor(resp.EvalID, false)
+}
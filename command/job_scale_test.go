@@ -0,0 +1,111 @@
+package command
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/nomad/helper"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/mitchellh/cli"
+	"github.com/posener/complete"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJobScaleCommand_Implements(t *testing.T) {
+	t.Parallel()
+	var _ cli.Command = &JobScaleCommand{}
+}
+
+func TestJobScaleCommand_Fails(t *testing.T) {
+	t.Parallel()
+	ui := new(cli.MockUi)
+	cmd := &JobScaleCommand{Meta: Meta{Ui: ui}}
+
+	// Fails on misuse
+	if code := cmd.Run([]string{"some", "bad", "args", "here"}); code != 1 {
+		t.Fatalf("expected exit code 1, got: %d", code)
+	}
+	if out := ui.ErrorWriter.String(); !strings.Contains(out, commandErrorText(cmd)) {
+		t.Fatalf("expected help output, got: %s", out)
+	}
+	ui.ErrorWriter.Reset()
+
+	// Fails on invalid count
+	if code := cmd.Run([]string{"foo", "web", "-1"}); code != 1 {
+		t.Fatalf("expected exit code 1, got: %d", code)
+	}
+	if out := ui.ErrorWriter.String(); !strings.Contains(out, "Invalid count") {
+		t.Fatalf("expected invalid count error, got: %s", out)
+	}
+	ui.ErrorWriter.Reset()
+
+	if code := cmd.Run([]string{"-address=nope", "foo", "1"}); code != 1 {
+		t.Fatalf("expected exit code 1, got: %d", code)
+	}
+	if out := ui.ErrorWriter.String(); !strings.Contains(out, "Error listing jobs") {
+		t.Fatalf("expected failed query error, got: %s", out)
+	}
+	ui.ErrorWriter.Reset()
+}
+
+func TestJobScaleCommand_Run(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	srv, client, url := testServer(t, true, nil)
+	defer srv.Shutdown()
+
+	// Register a job with two task groups
+	job := testJob("job1_sfx")
+	group2 := *job.TaskGroups[0]
+	group2.Name = helper.StringToPtr("group2")
+	job.TaskGroups = append(job.TaskGroups, &group2)
+	_, _, err := client.Jobs().Register(job, nil)
+	require.NoError(err)
+
+	ui := new(cli.MockUi)
+	cmd := &JobScaleCommand{Meta: Meta{Ui: ui}}
+
+	// The group is required for jobs with several task groups
+	code := cmd.Run([]string{"-address=" + url, "job1", "3"})
+	require.Equal(1, code)
+	require.Contains(ui.ErrorWriter.String(), "the group to scale must be specified")
+	ui.ErrorWriter.Reset()
+
+	code = cmd.Run([]string{"-address=" + url, "-detach", "-message=more", "job1", "group2", "3"})
+	require.Zero(code, ui.ErrorWriter.String())
+	require.Contains(ui.OutputWriter.String(), "Evaluation ID")
+
+	out, _, err := client.Jobs().Info("job1_sfx", nil)
+	require.NoError(err)
+	require.Equal(1, *out.TaskGroups[0].Count)
+	require.Equal(3, *out.TaskGroups[1].Count)
+
+	status, _, err := client.Jobs().ScaleStatus("job1_sfx", nil)
+	require.NoError(err)
+	require.Len(status.TaskGroups["group2"].Events, 1)
+	require.Equal("more", status.TaskGroups["group2"].Events[0].Message)
+}
+
+func TestJobScaleCommand_AutocompleteArgs(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	srv, _, url := testServer(t, true, nil)
+	defer srv.Shutdown()
+
+	ui := new(cli.MockUi)
+	cmd := &JobScaleCommand{Meta: Meta{Ui: ui, flagAddress: url}}
+
+	// Create a fake job
+	state := srv.Agent.Server().State()
+	j := mock.Job()
+	require.Nil(state.UpsertJob(1000, j))
+
+	prefix := j.ID[:len(j.ID)-5]
+	args := complete.Args{Last: prefix}
+	predictor := cmd.AutocompleteArgs()
+
+	res := predictor.Predict(args)
+	require.Equal(1, len(res))
+	require.Equal(j.ID, res[0])
+}
@@ -0,0 +1,42 @@
+package command
+
+import (
+	"strings"
+
+	"github.com/mitchellh/cli"
+)
+
+type JobTagCommand struct {
+	Meta
+}
+
+func (c *JobTagCommand) Name() string { return "job tag" }
+
+func (c *JobTagCommand) Run(args []string) int {
+	return cli.RunResultHelp
+}
+
+func (c *JobTagCommand) Synopsis() string {
+	return "Tag job versions"
+}
+
+func (c *JobTagCommand) Help() string {
+	helpText := `
+Usage: nomad job tag <subcommand> [options] [args]
+
+  This command groups subcommands for tagging job versions. A tagged version
+  can be referred to by its tag name, for example when reverting the job, and
+  is never garbage collected from the job history.
+
+  Tag the current version of a job:
+
+      $ nomad job tag apply -name release-2026.10 <job_id>
+
+  Remove a version tag:
+
+      $ nomad job tag unset -name release-2026.10 <job_id>
+
+  Please see the individual subcommand help for detailed usage information.
+`
+	return strings.TrimSpace(helpText)
+}
@@ -0,0 +1,142 @@
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/nomad/api/contexts"
+	"github.com/posener/complete"
+)
+
+type JobTagApplyCommand struct {
+	Meta
+}
+
+func (c *JobTagApplyCommand) Help() string {
+	helpText := `
+Usage: nomad job tag apply [options] <job>
+
+  Apply is used to tag a version of a job with a name and a description. The
+  tag name must be unique amongst the versions of the job. Tagging a version
+  that is already tagged replaces its tag. Tagged versions are never garbage
+  collected from the job history and can be reverted to by name using the
+  "nomad job revert" command. A job can have at most 10 tagged versions.
+
+General Options:
+
+  ` + generalOptionsUsage() + `
+
+Tag Apply Options:
+
+  -name
+    The name of the tag. Required.
+
+  -description
+    A human readable description of the tagged version.
+
+  -version
+    The version of the job to tag. Defaults to the current version.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *JobTagApplyCommand) Synopsis() string {
+	return "Tag a job version"
+}
+
+func (c *JobTagApplyCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-name":        complete.PredictAnything,
+			"-description": complete.PredictAnything,
+			"-version":     complete.PredictAnything,
+		})
+}
+
+func (c *JobTagApplyCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictFunc(func(a complete.Args) []string {
+		client, err := c.Meta.Client()
+		if err != nil {
+			return nil
+		}
+
+		resp, _, err := client.Search().PrefixSearch(a.Last, contexts.Jobs, nil)
+		if err != nil {
+			return []string{}
+		}
+		return resp.Matches[contexts.Jobs]
+	})
+}
+
+func (c *JobTagApplyCommand) Name() string { return "job tag apply" }
+
+func (c *JobTagApplyCommand) Run(args []string) int {
+	var name, description, versionStr string
+
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.StringVar(&name, "name", "", "")
+	flags.StringVar(&description, "description", "", "")
+	flags.StringVar(&versionStr, "version", "", "")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Check that we got exactly one argument
+	args = flags.Args()
+	if l := len(args); l != 1 {
+		c.Ui.Error("This command takes one argument: <job>")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	if name == "" {
+		c.Ui.Error("The tag name must be specified using the -name flag")
+		return 1
+	}
+
+	var version *uint64
+	if v, ok, err := parseVersion(versionStr); err != nil {
+		c.Ui.Error(fmt.Sprintf("Error parsing version value %q: %v", versionStr, err))
+		return 1
+	} else if ok {
+		version = &v
+	}
+
+	// Get the HTTP client
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	// Check if the job exists
+	jobID := args[0]
+	jobs, _, err := client.Jobs().PrefixList(jobID)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error listing jobs: %s", err))
+		return 1
+	}
+	if len(jobs) == 0 {
+		c.Ui.Error(fmt.Sprintf("No job(s) with prefix or id %q found", jobID))
+		return 1
+	}
+	if len(jobs) > 1 && strings.TrimSpace(jobID) != jobs[0].ID {
+		c.Ui.Error(fmt.Sprintf("Prefix matched multiple jobs\n\n%s", createStatusListOutput(jobs)))
+		return 1
+	}
+	jobID = jobs[0].ID
+
+	if _, _, err := client.Jobs().TagVersion(jobID, name, description, version, nil); err != nil {
+		c.Ui.Error(fmt.Sprintf("Error tagging job version: %s", err))
+		return 1
+	}
+
+	if version != nil {
+		c.Ui.Output(fmt.Sprintf("Version %d of job %q tagged %q", *version, jobID, name))
+	} else {
+		c.Ui.Output(fmt.Sprintf("Current version of job %q tagged %q", jobID, name))
+	}
+	return 0
+}
@@ -0,0 +1,90 @@
+package command
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/mitchellh/cli"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJobTagApplyCommand_Implements(t *testing.T) {
+	t.Parallel()
+	var _ cli.Command = &JobTagApplyCommand{}
+}
+
+func TestJobTagApplyCommand_Fails(t *testing.T) {
+	t.Parallel()
+	ui := new(cli.MockUi)
+	cmd := &JobTagApplyCommand{Meta: Meta{Ui: ui}}
+
+	// Fails on misuse
+	if code := cmd.Run([]string{"some", "bad", "args"}); code != 1 {
+		t.Fatalf("expected exit code 1, got: %d", code)
+	}
+	if out := ui.ErrorWriter.String(); !strings.Contains(out, commandErrorText(cmd)) {
+		t.Fatalf("expected help output, got: %s", out)
+	}
+	ui.ErrorWriter.Reset()
+
+	// Fails without a tag name
+	if code := cmd.Run([]string{"foo"}); code != 1 {
+		t.Fatalf("expected exit code 1, got: %d", code)
+	}
+	if out := ui.ErrorWriter.String(); !strings.Contains(out, "-name flag") {
+		t.Fatalf("expected missing name error, got: %s", out)
+	}
+	ui.ErrorWriter.Reset()
+
+	if code := cmd.Run([]string{"-address=nope", "-name=release", "foo"}); code != 1 {
+		t.Fatalf("expected exit code 1, got: %d", code)
+	}
+	if out := ui.ErrorWriter.String(); !strings.Contains(out, "Error listing jobs") {
+		t.Fatalf("expected failed query error, got: %s", out)
+	}
+	ui.ErrorWriter.Reset()
+}
+
+func TestJobTagCommands_Run(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	srv, client, url := testServer(t, false, nil)
+	defer srv.Shutdown()
+
+	// Create a job
+	state := srv.Agent.Server().State()
+	j := mock.Job()
+	require.NoError(state.UpsertJob(1000, j))
+
+	// Tag the current version
+	ui := new(cli.MockUi)
+	cmd := &JobTagApplyCommand{Meta: Meta{Ui: ui}}
+	code := cmd.Run([]string{"-address=" + url, "-name=release", "-description=first release", j.ID})
+	require.Equal(0, code, ui.ErrorWriter.String())
+	require.Contains(ui.OutputWriter.String(), `tagged "release"`)
+
+	job, _, err := client.Jobs().Info(j.ID, nil)
+	require.NoError(err)
+	require.NotNil(job.VersionTag)
+	require.Equal("release", job.VersionTag.Name)
+	require.Equal("first release", job.VersionTag.Description)
+
+	// Remove the tag
+	ui = new(cli.MockUi)
+	unset := &JobTagUnsetCommand{Meta: Meta{Ui: ui}}
+	code = unset.Run([]string{"-address=" + url, "-name=release", j.ID})
+	require.Equal(0, code, ui.ErrorWriter.String())
+
+	job, _, err = client.Jobs().Info(j.ID, nil)
+	require.NoError(err)
+	require.Nil(job.VersionTag)
+
+	// Removing a missing tag fails
+	ui = new(cli.MockUi)
+	unset = &JobTagUnsetCommand{Meta: Meta{Ui: ui}}
+	code = unset.Run([]string{"-address=" + url, "-name=release", j.ID})
+	require.Equal(1, code)
+	require.Contains(ui.ErrorWriter.String(), "Error removing job version tag")
+}
@@ -0,0 +1,117 @@
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/nomad/api/contexts"
+	"github.com/posener/complete"
+)
+
+type JobTagUnsetCommand struct {
+	Meta
+}
+
+func (c *JobTagUnsetCommand) Help() string {
+	helpText := `
+Usage: nomad job tag unset [options] <job>
+
+  Unset is used to remove a tag from the job version it is attached to. The
+  version is then subject to garbage collection like any untagged version.
+
+General Options:
+
+  ` + generalOptionsUsage() + `
+
+Tag Unset Options:
+
+  -name
+    The name of the tag to remove. Required.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *JobTagUnsetCommand) Synopsis() string {
+	return "Remove a job version tag"
+}
+
+func (c *JobTagUnsetCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-name": complete.PredictAnything,
+		})
+}
+
+func (c *JobTagUnsetCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictFunc(func(a complete.Args) []string {
+		client, err := c.Meta.Client()
+		if err != nil {
+			return nil
+		}
+
+		resp, _, err := client.Search().PrefixSearch(a.Last, contexts.Jobs, nil)
+		if err != nil {
+			return []string{}
+		}
+		return resp.Matches[contexts.Jobs]
+	})
+}
+
+func (c *JobTagUnsetCommand) Name() string { return "job tag unset" }
+
+func (c *JobTagUnsetCommand) Run(args []string) int {
+	var name string
+
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.StringVar(&name, "name", "", "")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Check that we got exactly one argument
+	args = flags.Args()
+	if l := len(args); l != 1 {
+		c.Ui.Error("This command takes one argument: <job>")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	if name == "" {
+		c.Ui.Error("The tag name must be specified using the -name flag")
+		return 1
+	}
+
+	// Get the HTTP client
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	// Check if the job exists
+	jobID := args[0]
+	jobs, _, err := client.Jobs().PrefixList(jobID)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error listing jobs: %s", err))
+		return 1
+	}
+	if len(jobs) == 0 {
+		c.Ui.Error(fmt.Sprintf("No job(s) with prefix or id %q found", jobID))
+		return 1
+	}
+	if len(jobs) > 1 && strings.TrimSpace(jobID) != jobs[0].ID {
+		c.Ui.Error(fmt.Sprintf("Prefix matched multiple jobs\n\n%s", createStatusListOutput(jobs)))
+		return 1
+	}
+	jobID = jobs[0].ID
+
+	if _, err := client.Jobs().UntagVersion(jobID, name, nil); err != nil {
+		c.Ui.Error(fmt.Sprintf("Error removing job version tag: %s", err))
+		return 1
+	}
+
+	c.Ui.Output(fmt.Sprintf("Removed tag %q from job %q", name, jobID))
+	return 0
+}
@@ -1,4 +1,3 @@
-// +build pro ent
 
 package command
 
@@ -1,4 +1,3 @@
-// +build ent
 
 package command
 
@@ -1,5 +1,3 @@
-// +build ent
-
 package command
 
 import (
@@ -69,46 +67,6 @@ func TestNamespaceStatusCommand_Good(t *testing.T) {
 	}
 }
 
-func TestNamespaceStatusCommand_Good_Quota(t *testing.T) {
-	t.Parallel()
-
-	// Create a server
-	srv, client, url := testServer(t, true, nil)
-	defer srv.Shutdown()
-
-	ui := new(cli.MockUi)
-	cmd := &NamespaceStatusCommand{Meta: Meta{Ui: ui}}
-
-	// Create a quota to delete
-	qs := testQuotaSpec()
-	_, err := client.Quotas().Register(qs, nil)
-	assert.Nil(t, err)
-
-	// Create a namespace
-	ns := &api.Namespace{
-		Name:  "foo",
-		Quota: qs.Name,
-	}
-	_, err = client.Namespaces().Register(ns, nil)
-	assert.Nil(t, err)
-
-	// Check status on namespace
-	if code := cmd.Run([]string{"-address=" + url, ns.Name}); code != 0 {
-		t.Fatalf("expected exit 0, got: %d; %v", code, ui.ErrorWriter.String())
-	}
-
-	// Check for basic spec
-	out := ui.OutputWriter.String()
-	if !strings.Contains(out, "= foo") {
-		t.Fatalf("expected quota, got: %s", out)
-	}
-
-	// Check for usage
-	if !strings.Contains(out, "0 / 100") {
-		t.Fatalf("expected quota, got: %s", out)
-	}
-}
-
 func TestNamespaceStatusCommand_AutocompleteArgs(t *testing.T) {
 	assert := assert.New(t)
 	t.Parallel()
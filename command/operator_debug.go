@@ -0,0 +1,699 @@
+package command
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/posener/complete"
+)
+
+const (
+	// debugRedacted replaces the secrets written in the archive
+	debugRedacted = "<redacted>"
+
+	// debugMaxProfileDuration is the maximum duration of a CPU profile
+	debugMaxProfileDuration = 30 * time.Second
+)
+
+// debugSecretKeys are the lowercased keys whose values are secrets but that
+// don't look like tokens, such as the basic auth credentials of Consul.
+var debugSecretKeys = map[string]bool{
+	"auth":       true,
+	"encrypt":    true,
+	"encryptkey": true,
+	"password":   true,
+}
+
+type OperatorDebugCommand struct {
+	Meta
+
+	// collectDir is the directory the collected files are written to before
+	// being archived
+	collectDir string
+
+	duration  time.Duration
+	interval  time.Duration
+	logLevel  string
+	nodeIDs   []string
+	serverIDs []string
+}
+
+func (c *OperatorDebugCommand) Help() string {
+	helpText := `
+Usage: nomad operator debug [options]
+
+  Build an archive containing the configuration and the state of a Nomad
+  cluster to troubleshoot it. The archive contains the agent information, the
+  members, the Raft peers, periodic snapshots of the metrics, nodes, jobs,
+  allocations, evaluations and deployments, and the logs and the goroutine,
+  heap and CPU profiles of the selected servers and client nodes.
+
+  Tokens, secret IDs and credentials, such as the Consul auth, are redacted
+  from the archive. If ACLs are enabled, this command requires a management
+  token. Otherwise, the profiles are only captured from the agents with
+  enable_debug set.
+
+General Options:
+
+  ` + generalOptionsUsage() + `
+
+Debug Options:
+
+  -duration=<duration>
+    The duration of the log capture. Defaults to 2m.
+
+  -interval=<interval>
+    The interval between snapshots of the cluster state. Defaults to 30s.
+
+  -log-level=<level>
+    The log level of the captured logs. Defaults to DEBUG.
+
+  -max-nodes=<count>
+    The maximum number of client nodes to capture. Defaults to 10.
+
+  -node-id=<node>,<node>
+    Comma separated list of client node IDs or ID prefixes to capture the logs
+    and profiles of. The value "all" captures all the client nodes.
+
+  -server-id=<server>,<server>
+    Comma separated list of server names or IDs to capture the logs and
+    profiles of. The value "leader" captures the leader and "all" captures all
+    the servers of the region. Defaults to "all".
+
+  -output=<path>
+    The directory the archive is written to. Defaults to the current
+    directory.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *OperatorDebugCommand) Synopsis() string {
+	return "Build a debug archive"
+}
+
+func (c *OperatorDebugCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-duration":  complete.PredictAnything,
+			"-interval":  complete.PredictAnything,
+			"-log-level": complete.PredictSet("TRACE", "DEBUG", "INFO", "WARN", "ERROR"),
+			"-max-nodes": complete.PredictAnything,
+			"-node-id":   complete.PredictAnything,
+			"-server-id": complete.PredictAnything,
+			"-output":    complete.PredictDirs("*"),
+		})
+}
+
+func (c *OperatorDebugCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *OperatorDebugCommand) Name() string { return "operator debug" }
+
+func (c *OperatorDebugCommand) Run(args []string) int {
+	var duration, interval, nodeIDs, serverIDs, output string
+	var maxNodes int
+
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.StringVar(&duration, "duration", "2m", "")
+	flags.StringVar(&interval, "interval", "30s", "")
+	flags.StringVar(&c.logLevel, "log-level", "DEBUG", "")
+	flags.IntVar(&maxNodes, "max-nodes", 10, "")
+	flags.StringVar(&nodeIDs, "node-id", "", "")
+	flags.StringVar(&serverIDs, "server-id", "all", "")
+	flags.StringVar(&output, "output", "", "")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Check that we got no arguments
+	args = flags.Args()
+	if l := len(args); l != 0 {
+		c.Ui.Error("This command takes no arguments")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	d, err := time.ParseDuration(duration)
+	if err != nil || d <= 0 {
+		c.Ui.Error(fmt.Sprintf("Invalid duration %q", duration))
+		return 1
+	}
+	c.duration = d
+
+	i, err := time.ParseDuration(interval)
+	if err != nil || i <= 0 {
+		c.Ui.Error(fmt.Sprintf("Invalid interval %q", interval))
+		return 1
+	}
+	c.interval = i
+
+	if maxNodes < 0 {
+		c.Ui.Error(fmt.Sprintf("Invalid max nodes %d", maxNodes))
+		return 1
+	}
+
+	if output == "" {
+		output, err = os.Getwd()
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Error getting the current directory: %s", err))
+			return 1
+		}
+	}
+
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	// Resolve the client nodes and the servers to capture
+	c.nodeIDs, err = c.resolveNodes(client, nodeIDs, maxNodes)
+	if err != nil {
+		c.Ui.Error(err.Error())
+		return 1
+	}
+
+	c.serverIDs, err = c.resolveServers(client, serverIDs)
+	if err != nil {
+		c.Ui.Error(err.Error())
+		return 1
+	}
+
+	stamp := time.Now().UTC().Format("2006-01-02-150405Z")
+	name := "nomad-debug-" + stamp
+
+	tmp, err := ioutil.TempDir("", "nomad-debug")
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error creating the temporary directory: %s", err))
+		return 1
+	}
+	defer os.RemoveAll(tmp)
+	c.collectDir = filepath.Join(tmp, name)
+
+	c.Ui.Output("Starting debugger and capturing cluster data...")
+	c.Ui.Output(fmt.Sprintf("       Servers: (%d) %v", len(c.serverIDs), c.serverIDs))
+	c.Ui.Output(fmt.Sprintf("       Clients: (%d) %v", len(c.nodeIDs), c.nodeIDs))
+	c.Ui.Output(fmt.Sprintf("      Interval: %s", c.interval))
+	c.Ui.Output(fmt.Sprintf("      Duration: %s", c.duration))
+
+	// Stop capturing early on interrupt, the data captured so far is still
+	// archived
+	ctx, cancel := context.WithTimeout(context.Background(), c.duration)
+	defer cancel()
+
+	signalCh := make(chan os.Signal, 1)
+	signal.Notify(signalCh, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(signalCh)
+
+	go func() {
+		select {
+		case <-signalCh:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	if err := c.collect(ctx, client); err != nil {
+		c.Ui.Error(fmt.Sprintf("Error collecting data: %s", err))
+		return 1
+	}
+
+	archive := filepath.Join(output, name+".tar.gz")
+	if err := tarDirectory(archive, tmp, name); err != nil {
+		c.Ui.Error(fmt.Sprintf("Error creating the archive: %s", err))
+		return 1
+	}
+
+	c.Ui.Output(fmt.Sprintf("Created debug archive: %s", archive))
+	return 0
+}
+
+// resolveNodes returns the IDs of the client nodes to capture from the comma
+// separated list of node IDs or ID prefixes, capped at maxNodes.
+func (c *OperatorDebugCommand) resolveNodes(client *api.Client, nodeIDs string, maxNodes int) ([]string, error) {
+	if nodeIDs == "" {
+		return nil, nil
+	}
+
+	var prefixes []string
+	if nodeIDs == "all" {
+		prefixes = []string{""}
+	} else {
+		for _, id := range strings.Split(nodeIDs, ",") {
+			id = strings.TrimSpace(id)
+			if len(id) == 1 {
+				return nil, fmt.Errorf("Node identifier must contain at least two characters.")
+			}
+			prefixes = append(prefixes, sanitizeUUIDPrefix(id))
+		}
+	}
+
+	var ids []string
+	seen := make(map[string]struct{})
+	for _, prefix := range prefixes {
+		nodes, _, err := client.Nodes().PrefixList(prefix)
+		if err != nil {
+			return nil, fmt.Errorf("Error querying node: %v", err)
+		}
+
+		if len(nodes) == 0 {
+			return nil, fmt.Errorf("No node(s) with prefix or id %q found", prefix)
+		}
+
+		for _, n := range nodes {
+			if _, ok := seen[n.ID]; ok {
+				continue
+			}
+			seen[n.ID] = struct{}{}
+			ids = append(ids, n.ID)
+		}
+	}
+
+	if len(ids) > maxNodes {
+		c.Ui.Warn(fmt.Sprintf("Capturing %d of the %d matching nodes, increase -max-nodes to capture more", maxNodes, len(ids)))
+		ids = ids[:maxNodes]
+	}
+
+	return ids, nil
+}
+
+// resolveServers returns the servers to capture from the comma separated
+// list of server names or IDs. The value "all" designates the alive servers
+// of the region of the agent.
+func (c *OperatorDebugCommand) resolveServers(client *api.Client, serverIDs string) ([]string, error) {
+	if serverIDs == "" {
+		return nil, nil
+	}
+
+	if serverIDs != "all" {
+		var ids []string
+		for _, id := range strings.Split(serverIDs, ",") {
+			ids = append(ids, strings.TrimSpace(id))
+		}
+		return ids, nil
+	}
+
+	region, err := client.Agent().Region()
+	if err != nil {
+		return nil, fmt.Errorf("Error querying agent region: %v", err)
+	}
+
+	members, err := client.Agent().Members()
+	if err != nil {
+		return nil, fmt.Errorf("Error querying server members: %v", err)
+	}
+
+	var ids []string
+	for _, m := range members.Members {
+		if m.Tags["region"] == region && m.Status == "alive" {
+			ids = append(ids, m.Name)
+		}
+	}
+	return ids, nil
+}
+
+// collect captures the cluster data until the context is done.
+func (c *OperatorDebugCommand) collect(ctx context.Context, client *api.Client) error {
+	if err := c.collectAgent(client); err != nil {
+		return err
+	}
+
+	var wg sync.WaitGroup
+
+	for _, id := range c.serverIDs {
+		dir := filepath.Join("server", id)
+
+		wg.Add(2)
+		go func(id string) {
+			defer wg.Done()
+			c.collectMonitor(ctx, client, dir, map[string]string{"server_id": id})
+		}(id)
+		go func(id string) {
+			defer wg.Done()
+			c.collectProfiles(client, dir, api.PprofOptions{ServerID: id})
+		}(id)
+	}
+
+	for _, id := range c.nodeIDs {
+		dir := filepath.Join("client", id)
+		c.collectNode(client, id)
+
+		wg.Add(2)
+		go func(id string) {
+			defer wg.Done()
+			c.collectMonitor(ctx, client, dir, map[string]string{"node_id": id})
+		}(id)
+		go func(id string) {
+			defer wg.Done()
+			c.collectProfiles(client, dir, api.PprofOptions{NodeID: id})
+		}(id)
+	}
+
+	c.collectPeriodic(ctx, client)
+	wg.Wait()
+	return nil
+}
+
+// collectAgent captures the information of the agent and of the members of
+// the cluster.
+func (c *OperatorDebugCommand) collectAgent(client *api.Client) error {
+	dir := "cluster"
+
+	self, err := client.Agent().Self()
+	if err != nil {
+		return fmt.Errorf("querying agent self: %v", err)
+	}
+	if err := c.writeJSON(dir, "agent-self.json", self); err != nil {
+		return err
+	}
+
+	members, err := client.Agent().Members()
+	c.writeResult(dir, "members.json", members, err)
+
+	leader, err := client.Status().Leader()
+	c.writeResult(dir, "leader.json", leader, err)
+
+	regions, err := client.Regions().List()
+	c.writeResult(dir, "regions.json", regions, err)
+
+	raft, err := client.Operator().RaftGetConfiguration(nil)
+	c.writeResult(dir, "operator-raft.json", raft, err)
+
+	return nil
+}
+
+// collectNode captures the status of a client node and of its allocations.
+func (c *OperatorDebugCommand) collectNode(client *api.Client, nodeID string) {
+	dir := filepath.Join("client", nodeID)
+
+	node, _, err := client.Nodes().Info(nodeID, nil)
+	c.writeResult(dir, "node.json", node, err)
+
+	allocs, _, err := client.Nodes().Allocations(nodeID, nil)
+	c.writeResult(dir, "allocations.json", allocs, err)
+}
+
+// collectMonitor writes the logs of an agent to the directory until the
+// context is done.
+func (c *OperatorDebugCommand) collectMonitor(ctx context.Context, client *api.Client, dir string, params map[string]string) {
+	if err := c.mkdir(dir); err != nil {
+		c.Ui.Error(err.Error())
+		return
+	}
+
+	fh, err := os.Create(filepath.Join(c.collectDir, dir, "monitor.log"))
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error creating the log file: %s", err))
+		return
+	}
+	defer fh.Close()
+
+	params["log_level"] = c.logLevel
+	q := &api.QueryOptions{Params: params}
+
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+
+	frames, errCh := client.Agent().Monitor(stopCh, q)
+	for {
+		select {
+		case frame, ok := <-frames:
+			if !ok {
+				return
+			}
+			fh.Write(frame.Data)
+		case err := <-errCh:
+			if err != io.EOF {
+				fh.WriteString(fmt.Sprintf("monitor: %s\n", err))
+			}
+			return
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// collectProfiles captures the goroutine, heap and CPU profiles of a server
+// or a client. The CPU profile lasts for the duration of the capture, up to
+// debugMaxProfileDuration.
+func (c *OperatorDebugCommand) collectProfiles(client *api.Client, dir string, opts api.PprofOptions) {
+	if err := c.mkdir(dir); err != nil {
+		c.Ui.Error(err.Error())
+		return
+	}
+
+	for _, profile := range []string{"goroutine", "heap"} {
+		buf, err := client.Agent().Lookup(profile, opts, nil)
+		if err != nil {
+			c.Ui.Warn(fmt.Sprintf("%s: Unable to capture %s profile: %s", dir, profile, err))
+			continue
+		}
+		c.writeBytes(dir, profile+".prof", buf)
+	}
+
+	opts.Seconds = int(c.duration / time.Second)
+	if c.duration > debugMaxProfileDuration {
+		opts.Seconds = int(debugMaxProfileDuration / time.Second)
+	}
+
+	buf, err := client.Agent().CPUProfile(opts, nil)
+	if err != nil {
+		c.Ui.Warn(fmt.Sprintf("%s: Unable to capture CPU profile: %s", dir, err))
+		return
+	}
+	c.writeBytes(dir, "profile.prof", buf)
+}
+
+// collectPeriodic captures snapshots of the metrics and of the cluster state
+// at each interval until the context is done.
+func (c *OperatorDebugCommand) collectPeriodic(ctx context.Context, client *api.Client) {
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for i := 0; ; i++ {
+		dir := filepath.Join("nomad", fmt.Sprintf("%04d", i))
+		c.collectNomad(client, dir)
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// collectNomad captures a snapshot of the metrics and of the cluster state.
+func (c *OperatorDebugCommand) collectNomad(client *api.Client, dir string) {
+	if err := c.writeResponse(client, dir, "metrics.json", "/v1/metrics", nil); err != nil {
+		c.writeError(dir, "metrics.json", err)
+	}
+
+	nodes, _, err := client.Nodes().List(nil)
+	c.writeResult(dir, "nodes.json", nodes, err)
+
+	jobs, _, err := client.Jobs().List(nil)
+	c.writeResult(dir, "jobs.json", jobs, err)
+
+	allocs, _, err := client.Allocations().List(nil)
+	c.writeResult(dir, "allocations.json", allocs, err)
+
+	evals, _, err := client.Evaluations().List(nil)
+	c.writeResult(dir, "evaluations.json", evals, err)
+
+	deployments, _, err := client.Deployments().List(nil)
+	c.writeResult(dir, "deployments.json", deployments, err)
+}
+
+// mkdir creates a directory of the collect directory.
+func (c *OperatorDebugCommand) mkdir(dir string) error {
+	if err := os.MkdirAll(filepath.Join(c.collectDir, dir), 0755); err != nil {
+		return fmt.Errorf("Error creating directory %s: %s", dir, err)
+	}
+	return nil
+}
+
+// writeResult writes the result of a query, or the error of the query when
+// it failed.
+func (c *OperatorDebugCommand) writeResult(dir, file string, obj interface{}, err error) {
+	if err != nil {
+		c.writeError(dir, file, err)
+		return
+	}
+
+	if err := c.writeJSON(dir, file, obj); err != nil {
+		c.Ui.Error(err.Error())
+	}
+}
+
+// writeError writes the error of a failed query in place of its result.
+func (c *OperatorDebugCommand) writeError(dir, file string, err error) {
+	obj := map[string]string{"Error": err.Error()}
+	if err := c.writeJSON(dir, file, obj); err != nil {
+		c.Ui.Error(err.Error())
+	}
+}
+
+// writeJSON writes the object as indented JSON with its secrets redacted.
+func (c *OperatorDebugCommand) writeJSON(dir, file string, obj interface{}) error {
+	buf, err := json.Marshal(obj)
+	if err != nil {
+		return fmt.Errorf("Error encoding %s: %s", file, err)
+	}
+
+	var out interface{}
+	if err := json.Unmarshal(buf, &out); err != nil {
+		return fmt.Errorf("Error decoding %s: %s", file, err)
+	}
+
+	buf, err = json.MarshalIndent(redactSecrets(out), "", "  ")
+	if err != nil {
+		return fmt.Errorf("Error encoding %s: %s", file, err)
+	}
+
+	if err := c.mkdir(dir); err != nil {
+		return err
+	}
+
+	path := filepath.Join(c.collectDir, dir, file)
+	if err := ioutil.WriteFile(path, buf, 0644); err != nil {
+		return fmt.Errorf("Error writing %s: %s", path, err)
+	}
+	return nil
+}
+
+// writeResponse writes the raw response of an endpoint.
+func (c *OperatorDebugCommand) writeResponse(client *api.Client, dir, file, endpoint string, q *api.QueryOptions) error {
+	body, err := client.Raw().Response(endpoint, q)
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+
+	if err := c.mkdir(dir); err != nil {
+		return err
+	}
+
+	fh, err := os.Create(filepath.Join(c.collectDir, dir, file))
+	if err != nil {
+		return err
+	}
+	defer fh.Close()
+
+	_, err = io.Copy(fh, body)
+	return err
+}
+
+// writeBytes writes the bytes to a file of the directory.
+func (c *OperatorDebugCommand) writeBytes(dir, file string, buf []byte) {
+	path := filepath.Join(c.collectDir, dir, file)
+	if err := ioutil.WriteFile(path, buf, 0644); err != nil {
+		c.Ui.Error(fmt.Sprintf("Error writing %s: %s", path, err))
+	}
+}
+
+// redactSecrets replaces the tokens and secret IDs of a decoded JSON object.
+func redactSecrets(obj interface{}) interface{} {
+	switch v := obj.(type) {
+	case map[string]interface{}:
+		for k, e := range v {
+			if s, ok := e.(string); ok {
+				if s != "" && isSecretKey(k) {
+					v[k] = debugRedacted
+				}
+				continue
+			}
+			v[k] = redactSecrets(e)
+		}
+	case []interface{}:
+		for i, e := range v {
+			v[i] = redactSecrets(e)
+		}
+	}
+	return obj
+}
+
+// isSecretKey returns whether the value of the key is a secret. Tokens, such
+// as the Vault, Consul and ACL replication tokens, secret IDs and the keys of
+// debugSecretKeys are secrets.
+func isSecretKey(key string) bool {
+	key = strings.ToLower(key)
+	if debugSecretKeys[key] {
+		return true
+	}
+	return strings.HasSuffix(key, "token") || strings.HasSuffix(key, "secretid") ||
+		strings.HasSuffix(key, "secret_id")
+}
+
+// tarDirectory writes a gzipped tar archive of the directory name of the
+// base directory.
+func tarDirectory(archive, base, name string) error {
+	fh, err := os.Create(archive)
+	if err != nil {
+		return err
+	}
+	defer fh.Close()
+
+	zw := gzip.NewWriter(fh)
+	tw := tar.NewWriter(zw)
+
+	err = filepath.Walk(filepath.Join(base, name), func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		hdr, err := tar.FileInfoHeader(info, "")
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(base, path)
+		if err != nil {
+			return err
+		}
+		hdr.Name = filepath.ToSlash(rel)
+
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		_, err = io.Copy(tw, f)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	if err := zw.Close(); err != nil {
+		return err
+	}
+	return fh.Close()
+}
@@ -0,0 +1,213 @@
+package command
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/nomad/command/agent"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/mitchellh/cli"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOperatorDebugCommand_Implements(t *testing.T) {
+	t.Parallel()
+	var _ cli.Command = &OperatorDebugCommand{}
+}
+
+func TestOperatorDebugCommand_Fails(t *testing.T) {
+	t.Parallel()
+	ui := new(cli.MockUi)
+	cmd := &OperatorDebugCommand{Meta: Meta{Ui: ui}}
+
+	// Fails on misuse
+	if code := cmd.Run([]string{"some", "bad", "args"}); code != 1 {
+		t.Fatalf("expected exit code 1, got: %d", code)
+	}
+	if out := ui.ErrorWriter.String(); !strings.Contains(out, commandErrorText(cmd)) {
+		t.Fatalf("expected help output, got: %s", out)
+	}
+	ui.ErrorWriter.Reset()
+
+	// Fails on invalid duration
+	if code := cmd.Run([]string{"-duration=foo"}); code != 1 {
+		t.Fatalf("expected exit code 1, got: %d", code)
+	}
+	if out := ui.ErrorWriter.String(); !strings.Contains(out, "Invalid duration") {
+		t.Fatalf("expected duration error, got: %s", out)
+	}
+	ui.ErrorWriter.Reset()
+
+	// Fails on invalid interval
+	if code := cmd.Run([]string{"-interval=0s"}); code != 1 {
+		t.Fatalf("expected exit code 1, got: %d", code)
+	}
+	if out := ui.ErrorWriter.String(); !strings.Contains(out, "Invalid interval") {
+		t.Fatalf("expected interval error, got: %s", out)
+	}
+	ui.ErrorWriter.Reset()
+
+	// Fails on connection failure
+	if code := cmd.Run([]string{"-address=nope"}); code != 1 {
+		t.Fatalf("expected exit code 1, got: %d", code)
+	}
+	if out := ui.ErrorWriter.String(); !strings.Contains(out, "Error querying") {
+		t.Fatalf("expected failed query error, got: %s", out)
+	}
+}
+
+func TestOperatorDebugCommand_Run(t *testing.T) {
+	t.Parallel()
+	srv, client, url := testServer(t, true, nil)
+	defer srv.Shutdown()
+
+	// Wait for a node to appear
+	testutil.WaitForResult(func() (bool, error) {
+		nodes, _, err := client.Nodes().List(nil)
+		if err != nil {
+			return false, err
+		}
+		if len(nodes) == 0 {
+			return false, fmt.Errorf("missing node")
+		}
+		return true, nil
+	}, func(err error) {
+		t.Fatalf("err: %s", err)
+	})
+
+	dir, err := ioutil.TempDir("", "nomad-debug-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	ui := new(cli.MockUi)
+	cmd := &OperatorDebugCommand{Meta: Meta{Ui: ui}}
+
+	code := cmd.Run([]string{
+		"-address=" + url,
+		"-duration=1s",
+		"-interval=500ms",
+		"-node-id=all",
+		"-output=" + dir,
+	})
+	require.Equal(t, 0, code, ui.ErrorWriter.String())
+	require.Contains(t, ui.OutputWriter.String(), "Created debug archive")
+
+	archives, err := filepath.Glob(filepath.Join(dir, "nomad-debug-*.tar.gz"))
+	require.NoError(t, err)
+	require.Len(t, archives, 1)
+
+	fh, err := os.Open(archives[0])
+	require.NoError(t, err)
+	defer fh.Close()
+
+	zr, err := gzip.NewReader(fh)
+	require.NoError(t, err)
+	tr := tar.NewReader(zr)
+
+	nodeID := srv.Agent.Client().NodeID()
+	serverID := srv.Agent.Server().LocalMember().Name
+
+	var files []string
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		files = append(files, strings.SplitN(hdr.Name, "/", 2)[1])
+	}
+
+	expected := []string{
+		"cluster/agent-self.json",
+		"cluster/members.json",
+		"cluster/operator-raft.json",
+		"client/" + nodeID + "/node.json",
+		"client/" + nodeID + "/allocations.json",
+		"client/" + nodeID + "/monitor.log",
+		"client/" + nodeID + "/goroutine.prof",
+		"client/" + nodeID + "/heap.prof",
+		"server/" + serverID + "/monitor.log",
+		"server/" + serverID + "/goroutine.prof",
+		"server/" + serverID + "/heap.prof",
+		"nomad/0000/metrics.json",
+		"nomad/0000/nodes.json",
+		"nomad/0000/allocations.json",
+	}
+	for _, f := range expected {
+		require.Contains(t, files, f)
+	}
+}
+
+func TestOperatorDebugCommand_RedactSecrets(t *testing.T) {
+	t.Parallel()
+
+	obj := map[string]interface{}{
+		"Vault": map[string]interface{}{
+			"Token":   "vault-secret",
+			"Enabled": true,
+		},
+		"ACL": map[string]interface{}{
+			"ReplicationToken": "acl-secret",
+			"TokenTTL":         "30s",
+		},
+		"Jobs": []interface{}{
+			map[string]interface{}{
+				"ID":         "example",
+				"VaultToken": "",
+			},
+		},
+		"SecretID": "node-secret",
+	}
+
+	redactSecrets(obj)
+
+	require.Equal(t, debugRedacted, obj["Vault"].(map[string]interface{})["Token"])
+	require.Equal(t, true, obj["Vault"].(map[string]interface{})["Enabled"])
+	require.Equal(t, debugRedacted, obj["ACL"].(map[string]interface{})["ReplicationToken"])
+	require.Equal(t, "30s", obj["ACL"].(map[string]interface{})["TokenTTL"])
+	require.Equal(t, "", obj["Jobs"].([]interface{})[0].(map[string]interface{})["VaultToken"])
+	require.Equal(t, debugRedacted, obj["SecretID"])
+}
+
+func TestOperatorDebugCommand_RedactAgentSelf(t *testing.T) {
+	t.Parallel()
+	srv, client, _ := testServer(t, false, func(c *agent.Config) {
+		c.Consul.Auth = "consul-user:consul-password"
+		c.Consul.Token = "consul-secret"
+		c.ACL.ReplicationToken = "acl-secret"
+	})
+	defer srv.Shutdown()
+
+	self, err := client.Agent().Self()
+	require.NoError(t, err)
+
+	buf, err := json.Marshal(self)
+	require.NoError(t, err)
+	var obj interface{}
+	require.NoError(t, json.Unmarshal(buf, &obj))
+
+	redactSecrets(obj)
+	config := obj.(map[string]interface{})["config"].(map[string]interface{})
+	consul := config["Consul"].(map[string]interface{})
+	require.Equal(t, debugRedacted, consul["Auth"])
+	require.Equal(t, debugRedacted, consul["Token"])
+	require.Equal(t, debugRedacted, config["ACL"].(map[string]interface{})["ReplicationToken"])
+
+	buf, err = json.Marshal(obj)
+	require.NoError(t, err)
+	out := string(buf)
+	for _, secret := range []string{"consul-password", "consul-secret", "acl-secret"} {
+		require.NotContains(t, out, secret)
+	}
+}
@@ -0,0 +1,72 @@
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/mitchellh/cli"
+)
+
+type OperatorEvalsCommand struct {
+	Meta
+}
+
+func (c *OperatorEvalsCommand) Help() string {
+	helpText := `
+Usage: nomad operator evals <subcommand> [options]
+
+  This command groups subcommands for inspecting and managing the evaluations
+  held by the leader's evaluation broker and blocked evaluations tracker.
+
+  List the evaluations held by the broker:
+
+      $ nomad operator evals broker
+
+  Drop an evaluation from the broker, marking it as cancelled:
+
+      $ nomad operator evals drop <eval_id>
+
+  Re-enqueue a waiting, failed or blocked evaluation:
+
+      $ nomad operator evals reenqueue <eval_id>
+
+  Please see the individual subcommand help for detailed usage information.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *OperatorEvalsCommand) Synopsis() string {
+	return "Provides access to the evaluation broker"
+}
+
+func (c *OperatorEvalsCommand) Name() string { return "operator evals" }
+
+func (c *OperatorEvalsCommand) Run(args []string) int {
+	return cli.RunResultHelp
+}
+
+// resolveEvalIDs expands evaluation ID prefixes into full evaluation IDs. Each
+// prefix must match exactly one evaluation.
+func resolveEvalIDs(client *api.Client, prefixes []string) ([]string, error) {
+	ids := make([]string, 0, len(prefixes))
+	for _, prefix := range prefixes {
+		if len(prefix) == 1 {
+			return nil, fmt.Errorf("Identifier %q must contain at least two characters.", prefix)
+		}
+
+		evals, _, err := client.Evaluations().PrefixList(sanitizeUUIDPrefix(prefix))
+		if err != nil {
+			return nil, fmt.Errorf("Error querying evaluation %q: %v", prefix, err)
+		}
+		switch len(evals) {
+		case 0:
+			return nil, fmt.Errorf("No evaluation(s) with prefix or id %q found", prefix)
+		case 1:
+			ids = append(ids, evals[0].ID)
+		default:
+			return nil, fmt.Errorf("Prefix %q matched multiple evaluations", prefix)
+		}
+	}
+	return ids, nil
+}
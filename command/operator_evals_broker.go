@@ -0,0 +1,168 @@
+package command
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/posener/complete"
+)
+
+type OperatorEvalsBrokerCommand struct {
+	Meta
+}
+
+func (c *OperatorEvalsBrokerCommand) Help() string {
+	helpText := `
+Usage: nomad operator evals broker [options]
+
+  Displays the evaluations held by the leader's evaluation broker and blocked
+  evaluations tracker. Evaluations are grouped by their state:
+
+  * Ready: waiting to be dequeued by a scheduler.
+  * Unacked: dequeued by a scheduler but not yet acknowledged.
+  * Waiting: delayed until their wait time elapses.
+  * Pending: serialized behind another evaluation for the same job.
+  * Blocked (Captured): waiting on capacity for a computed node class.
+  * Blocked (Escaped): waiting on capacity of any node.
+
+General Options:
+
+  ` + generalOptionsUsage() + `
+
+Broker Options:
+
+  -json
+    Output the evaluations in their JSON format.
+
+  -t
+    Format and display the evaluations using a Go template.
+
+  -verbose
+    Display full information.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *OperatorEvalsBrokerCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-json":    complete.PredictNothing,
+			"-t":       complete.PredictAnything,
+			"-verbose": complete.PredictNothing,
+		})
+}
+
+func (c *OperatorEvalsBrokerCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *OperatorEvalsBrokerCommand) Synopsis() string {
+	return "Display the evaluations held by the evaluation broker"
+}
+
+func (c *OperatorEvalsBrokerCommand) Name() string { return "operator evals broker" }
+
+func (c *OperatorEvalsBrokerCommand) Run(args []string) int {
+	var json, verbose bool
+	var tmpl string
+
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.BoolVar(&json, "json", false, "")
+	flags.StringVar(&tmpl, "t", "", "")
+	flags.BoolVar(&verbose, "verbose", false, "")
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Check that we got no arguments
+	if len(flags.Args()) != 0 {
+		c.Ui.Error("This command takes no arguments")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	// Set up a client.
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	broker, _, err := client.Operator().EvalBrokerInspect(nil)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error querying evaluation broker: %s", err))
+		return 1
+	}
+
+	if json || len(tmpl) > 0 {
+		out, err := Format(json, tmpl, broker)
+		if err != nil {
+			c.Ui.Error(err.Error())
+			return 1
+		}
+		c.Ui.Output(out)
+		return 0
+	}
+
+	length := shortId
+	if verbose {
+		length = fullId
+	}
+
+	c.Ui.Output(formatKV([]string{
+		fmt.Sprintf("Ready|%d", len(broker.Ready)),
+		fmt.Sprintf("Unacked|%d", len(broker.Unacked)),
+		fmt.Sprintf("Waiting|%d", len(broker.Waiting)),
+		fmt.Sprintf("Pending|%d", len(broker.Pending)),
+		fmt.Sprintf("Blocked (Captured)|%d", len(broker.Captured)),
+		fmt.Sprintf("Blocked (Escaped)|%d", len(broker.Escaped)),
+	}))
+
+	sections := []struct {
+		name  string
+		evals []*api.BrokerEval
+	}{
+		{"Ready", broker.Ready},
+		{"Unacked", broker.Unacked},
+		{"Waiting", broker.Waiting},
+		{"Pending", broker.Pending},
+		{"Blocked (Captured)", broker.Captured},
+		{"Blocked (Escaped)", broker.Escaped},
+	}
+	for _, section := range sections {
+		if len(section.evals) == 0 {
+			continue
+		}
+		c.Ui.Output(c.Colorize().Color(fmt.Sprintf("\n[bold]%s[reset]", section.name)))
+		c.Ui.Output(formatBrokerEvals(section.evals, length))
+	}
+	return 0
+}
+
+// formatBrokerEvals formats the evaluations held by the broker as a table.
+func formatBrokerEvals(evals []*api.BrokerEval, length int) string {
+	now := time.Now()
+	out := make([]string, len(evals)+1)
+	out[0] = "ID|Job ID|Namespace|Type|Priority|Triggered By|Queue|Dequeues|Wait Until|Created"
+	for i, eval := range evals {
+		waitUntil := ""
+		if !eval.WaitUntil.IsZero() {
+			waitUntil = prettyTimeDiff(eval.WaitUntil, now)
+		}
+		out[i+1] = fmt.Sprintf("%s|%s|%s|%s|%d|%s|%s|%d|%s|%s",
+			limit(eval.ID, length),
+			eval.JobID,
+			eval.Namespace,
+			eval.Type,
+			eval.Priority,
+			eval.TriggeredBy,
+			eval.Queue,
+			eval.Dequeues,
+			waitUntil,
+			prettyTimeDiff(time.Unix(0, eval.CreateTime), now))
+	}
+	return formatList(out)
+}
@@ -0,0 +1,57 @@
+package command
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mitchellh/cli"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOperatorEvalsBrokerCommand_Implements(t *testing.T) {
+	t.Parallel()
+	var _ cli.Command = &OperatorEvalsBrokerCommand{}
+}
+
+func TestOperatorEvalsBrokerCommand_Run(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	srv, _, url := testServer(t, false, nil)
+	defer srv.Shutdown()
+
+	ui := new(cli.MockUi)
+	cmd := &OperatorEvalsBrokerCommand{Meta: Meta{Ui: ui}}
+
+	// Fails on extra arguments
+	code := cmd.Run([]string{"-address=" + url, "foo"})
+	require.Equal(1, code)
+	require.Contains(ui.ErrorWriter.String(), "takes no arguments")
+	ui.ErrorWriter.Reset()
+
+	code = cmd.Run([]string{"-address=" + url})
+	require.Equal(0, code, ui.ErrorWriter.String())
+	out := ui.OutputWriter.String()
+	require.True(strings.Contains(out, "Ready"))
+	require.True(strings.Contains(out, "Blocked (Escaped)"))
+}
+
+func TestOperatorEvalsDropCommand_Fails(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	srv, _, url := testServer(t, false, nil)
+	defer srv.Shutdown()
+
+	ui := new(cli.MockUi)
+	cmd := &OperatorEvalsDropCommand{Meta: Meta{Ui: ui}}
+
+	// Fails without arguments
+	code := cmd.Run([]string{"-address=" + url})
+	require.Equal(1, code)
+	require.Contains(ui.ErrorWriter.String(), "at least one argument")
+	ui.ErrorWriter.Reset()
+
+	// Fails on unknown evaluations
+	code = cmd.Run([]string{"-address=" + url, "12345678"})
+	require.Equal(1, code)
+	require.Contains(ui.ErrorWriter.String(), "No evaluation(s) with prefix or id")
+}
@@ -0,0 +1,93 @@
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/nomad/api/contexts"
+	"github.com/posener/complete"
+)
+
+type OperatorEvalsDropCommand struct {
+	Meta
+}
+
+func (c *OperatorEvalsDropCommand) Help() string {
+	helpText := `
+Usage: nomad operator evals drop [options] <eval_id> [<eval_id>...]
+
+  Drop removes the given evaluations from the leader's evaluation broker or
+  blocked evaluations tracker and marks them as cancelled. Evaluations that are
+  currently being processed by a scheduler can not be dropped.
+
+General Options:
+
+  ` + generalOptionsUsage()
+	return strings.TrimSpace(helpText)
+}
+
+func (c *OperatorEvalsDropCommand) AutocompleteFlags() complete.Flags {
+	return c.Meta.AutocompleteFlags(FlagSetClient)
+}
+
+func (c *OperatorEvalsDropCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictFunc(func(a complete.Args) []string {
+		client, err := c.Meta.Client()
+		if err != nil {
+			return nil
+		}
+
+		resp, _, err := client.Search().PrefixSearch(a.Last, contexts.Evals, nil)
+		if err != nil {
+			return []string{}
+		}
+		return resp.Matches[contexts.Evals]
+	})
+}
+
+func (c *OperatorEvalsDropCommand) Synopsis() string {
+	return "Drop evaluations from the evaluation broker"
+}
+
+func (c *OperatorEvalsDropCommand) Name() string { return "operator evals drop" }
+
+func (c *OperatorEvalsDropCommand) Run(args []string) int {
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Check that we got at least one evaluation ID
+	args = flags.Args()
+	if len(args) == 0 {
+		c.Ui.Error("This command takes at least one argument: <eval_id>")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	// Set up a client.
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	evalIDs, err := resolveEvalIDs(client, args)
+	if err != nil {
+		c.Ui.Error(err.Error())
+		return 1
+	}
+
+	resp, _, err := client.Operator().EvalBrokerDrop(evalIDs, nil)
+	if resp != nil {
+		for _, evalID := range resp.Updated {
+			c.Ui.Output(fmt.Sprintf("Dropped evaluation %q", evalID))
+		}
+	}
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error dropping evaluations: %s", err))
+		return 1
+	}
+	return 0
+}
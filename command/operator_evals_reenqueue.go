@@ -0,0 +1,94 @@
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/nomad/api/contexts"
+	"github.com/posener/complete"
+)
+
+type OperatorEvalsReenqueueCommand struct {
+	Meta
+}
+
+func (c *OperatorEvalsReenqueueCommand) Help() string {
+	helpText := `
+Usage: nomad operator evals reenqueue [options] <eval_id> [<eval_id>...]
+
+  Reenqueue moves the given evaluations back into the ready queue of their
+  scheduler. Evaluations that are waiting for a delay to elapse, that have
+  exceeded the delivery limit, or that are blocked waiting on cluster capacity
+  can be re-enqueued.
+
+General Options:
+
+  ` + generalOptionsUsage()
+	return strings.TrimSpace(helpText)
+}
+
+func (c *OperatorEvalsReenqueueCommand) AutocompleteFlags() complete.Flags {
+	return c.Meta.AutocompleteFlags(FlagSetClient)
+}
+
+func (c *OperatorEvalsReenqueueCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictFunc(func(a complete.Args) []string {
+		client, err := c.Meta.Client()
+		if err != nil {
+			return nil
+		}
+
+		resp, _, err := client.Search().PrefixSearch(a.Last, contexts.Evals, nil)
+		if err != nil {
+			return []string{}
+		}
+		return resp.Matches[contexts.Evals]
+	})
+}
+
+func (c *OperatorEvalsReenqueueCommand) Synopsis() string {
+	return "Re-enqueue waiting, failed or blocked evaluations"
+}
+
+func (c *OperatorEvalsReenqueueCommand) Name() string { return "operator evals reenqueue" }
+
+func (c *OperatorEvalsReenqueueCommand) Run(args []string) int {
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Check that we got at least one evaluation ID
+	args = flags.Args()
+	if len(args) == 0 {
+		c.Ui.Error("This command takes at least one argument: <eval_id>")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	// Set up a client.
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	evalIDs, err := resolveEvalIDs(client, args)
+	if err != nil {
+		c.Ui.Error(err.Error())
+		return 1
+	}
+
+	resp, _, err := client.Operator().EvalBrokerReenqueue(evalIDs, nil)
+	if resp != nil {
+		for _, evalID := range resp.Updated {
+			c.Ui.Output(fmt.Sprintf("Re-enqueued evaluation %q", evalID))
+		}
+	}
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error re-enqueuing evaluations: %s", err))
+		return 1
+	}
+	return 0
+}
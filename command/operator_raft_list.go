@@ -81,14 +81,20 @@ func (c *OperatorRaftListCommand) Run(args []string) int {
 	}
 
 	// Format it as a nice table.
-	result := []string{"Node|ID|Address|State|Voter|RaftProtocol"}
+	result := []string{"Node|ID|Address|State|Voter|Role|RaftProtocol"}
 	for _, s := range reply.Servers {
 		state := "follower"
 		if s.Leader {
 			state = "leader"
 		}
-		result = append(result, fmt.Sprintf("%s|%s|%s|%s|%v|%s",
-			s.Node, s.ID, s.Address, state, s.Voter, s.RaftProtocol))
+		role := "voter"
+		if s.ReadReplica {
+			role = "read-replica"
+		} else if !s.Voter {
+			role = "staging"
+		}
+		result = append(result, fmt.Sprintf("%s|%s|%s|%s|%v|%s|%s",
+			s.Node, s.ID, s.Address, state, s.Voter, role, s.RaftProtocol))
 	}
 	c.Ui.Output(columnize.SimpleFormat(result))
 
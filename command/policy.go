@@ -0,0 +1,49 @@
+package command
+
+import (
+	"strings"
+
+	"github.com/mitchellh/cli"
+)
+
+type PolicyCommand struct {
+	Meta
+}
+
+func (f *PolicyCommand) Help() string {
+	helpText := `
+Usage: nomad policy <subcommand> [options] [args]
+
+  This command groups subcommands for interacting with admission policies.
+  Admission policies are rules written in HCL that are evaluated against jobs
+  when they are registered or planned. Failed policies either warn or reject
+  the job, depending on their enforcement level. Users can create new
+  policies, delete and list existing policies.
+
+  List existing policies:
+
+      $ nomad policy list
+
+  Create a new admission policy:
+
+      $ nomad policy apply <name> <path>
+
+  Delete an admission policy:
+
+      $ nomad policy delete <name>
+
+  Please see the individual subcommand help for detailed usage information.
+`
+
+	return strings.TrimSpace(helpText)
+}
+
+func (f *PolicyCommand) Synopsis() string {
+	return "Interact with admission policies"
+}
+
+func (f *PolicyCommand) Name() string { return "policy" }
+
+func (f *PolicyCommand) Run(args []string) int {
+	return cli.RunResultHelp
+}
@@ -0,0 +1,126 @@
+package command
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/posener/complete"
+)
+
+type PolicyApplyCommand struct {
+	Meta
+}
+
+func (c *PolicyApplyCommand) Help() string {
+	helpText := `
+Usage: nomad policy apply [options] <name> <file>
+
+  Apply is used to write a new admission policy or update an existing one.
+  The name of the policy and file must be specified. The file will be read
+  from stdin by specifying "-".
+
+  If ACLs are enabled, this command requires a management token.
+
+General Options:
+
+  ` + generalOptionsUsage() + `
+
+Apply Options:
+
+  -description
+    Sets a human readable description for the policy.
+
+  -level (default: advisory)
+    Sets the enforcement level of the policy. Must be one of advisory,
+    soft-mandatory, hard-mandatory.
+
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *PolicyApplyCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-description": complete.PredictAnything,
+			"-level":       complete.PredictSet("advisory", "soft-mandatory", "hard-mandatory"),
+		})
+}
+
+func (c *PolicyApplyCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *PolicyApplyCommand) Synopsis() string {
+	return "Create a new or update existing admission policies"
+}
+
+func (c *PolicyApplyCommand) Name() string { return "policy apply" }
+
+func (c *PolicyApplyCommand) Run(args []string) int {
+	var description, enfLevel string
+	var err error
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.StringVar(&description, "description", "", "")
+	flags.StringVar(&enfLevel, "level", "advisory", "")
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Check that we got exactly two arguments
+	args = flags.Args()
+	if l := len(args); l != 2 {
+		c.Ui.Error("This command takes exactly two arguments: <name> <file>")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	// Get the name and file
+	policyName := args[0]
+
+	// Read the file contents
+	file := args[1]
+	var rawPolicy []byte
+	if file == "-" {
+		rawPolicy, err = ioutil.ReadAll(os.Stdin)
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Failed to read stdin: %v", err))
+			return 1
+		}
+	} else {
+		rawPolicy, err = ioutil.ReadFile(file)
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Failed to read file: %v", err))
+			return 1
+		}
+	}
+
+	// Construct the policy
+	ap := &api.AdmissionPolicy{
+		Name:             policyName,
+		Description:      description,
+		EnforcementLevel: enfLevel,
+		Policy:           string(rawPolicy),
+	}
+
+	// Get the HTTP client
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	// Upsert the policy
+	_, err = client.AdmissionPolicies().Upsert(ap, nil)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error writing admission policy: %s", err))
+		return 1
+	}
+
+	c.Ui.Output(fmt.Sprintf("Successfully wrote %q admission policy!",
+		policyName))
+	return 0
+}
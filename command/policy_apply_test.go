@@ -0,0 +1,77 @@
+package command
+
+import (
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/mitchellh/cli"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPolicyApplyCommand_Implements(t *testing.T) {
+	t.Parallel()
+	var _ cli.Command = &PolicyApplyCommand{}
+}
+
+func TestPolicyApplyCommand_Fails(t *testing.T) {
+	t.Parallel()
+	ui := new(cli.MockUi)
+	cmd := &PolicyApplyCommand{Meta: Meta{Ui: ui}}
+
+	// Fails on misuse
+	if code := cmd.Run([]string{"foo"}); code != 1 {
+		t.Fatalf("expected exit code 1, got: %d", code)
+	}
+	if out := ui.ErrorWriter.String(); !strings.Contains(out, commandErrorText(cmd)) {
+		t.Fatalf("expected help output, got: %s", out)
+	}
+	ui.ErrorWriter.Reset()
+
+	if code := cmd.Run([]string{"foo", "/unicorns/leprechauns"}); code != 1 {
+		t.Fatalf("expected exit code 1, got: %d", code)
+	}
+	if out := ui.ErrorWriter.String(); !strings.Contains(out, "Failed to read file") {
+		t.Fatalf("expected read error, got: %s", out)
+	}
+}
+
+func TestPolicyApplyCommand_Good(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	// Create a server
+	srv, client, url := testServer(t, true, nil)
+	defer srv.Shutdown()
+
+	f, err := ioutil.TempFile("", "nomad-test")
+	require.NoError(err)
+	defer os.Remove(f.Name())
+	_, err = f.WriteString(`
+rule "team" {
+  condition = lookup(job.Meta, "team", "") != ""
+}
+`)
+	require.NoError(err)
+	f.Close()
+
+	ui := new(cli.MockUi)
+	cmd := &PolicyApplyCommand{Meta: Meta{Ui: ui}}
+
+	// Create a policy
+	code := cmd.Run([]string{"-address=" + url, "-description=teams", "-level=soft-mandatory", "team", f.Name()})
+	require.Equal(0, code, ui.ErrorWriter.String())
+	require.Contains(ui.OutputWriter.String(), `Successfully wrote "team" admission policy`)
+
+	policy, _, err := client.AdmissionPolicies().Info("team", nil)
+	require.NoError(err)
+	require.Equal("teams", policy.Description)
+	require.Equal("soft-mandatory", policy.EnforcementLevel)
+
+	// An invalid policy is rejected
+	ui.ErrorWriter.Reset()
+	code = cmd.Run([]string{"-address=" + url, "-level=mandatory", "team", f.Name()})
+	require.Equal(1, code)
+	require.Contains(ui.ErrorWriter.String(), "invalid enforcement level")
+}
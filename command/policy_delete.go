@@ -0,0 +1,79 @@
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/posener/complete"
+)
+
+type PolicyDeleteCommand struct {
+	Meta
+}
+
+func (c *PolicyDeleteCommand) Help() string {
+	helpText := `
+Usage: nomad policy delete [options] <name>
+
+  Delete is used to delete an existing admission policy.
+
+  If ACLs are enabled, this command requires a management token.
+
+General Options:
+
+  ` + generalOptionsUsage() + `
+
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *PolicyDeleteCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{})
+}
+
+func (c *PolicyDeleteCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *PolicyDeleteCommand) Synopsis() string {
+	return "Delete an existing admission policy"
+}
+
+func (c *PolicyDeleteCommand) Name() string { return "policy delete" }
+
+func (c *PolicyDeleteCommand) Run(args []string) int {
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Check that we got exactly one argument
+	args = flags.Args()
+	if l := len(args); l != 1 {
+		c.Ui.Error("This command takes one argument: <name>")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	policyName := args[0]
+
+	// Get the HTTP client
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	// Delete the policy
+	_, err = client.AdmissionPolicies().Delete(policyName, nil)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error deleting admission policy: %s", err))
+		return 1
+	}
+
+	c.Ui.Output(fmt.Sprintf("Successfully deleted %q admission policy!",
+		policyName))
+	return 0
+}
@@ -0,0 +1,63 @@
+package command
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/mitchellh/cli"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPolicyDeleteCommand_Implements(t *testing.T) {
+	t.Parallel()
+	var _ cli.Command = &PolicyDeleteCommand{}
+}
+
+func TestPolicyDeleteCommand_Fails(t *testing.T) {
+	t.Parallel()
+	ui := new(cli.MockUi)
+	cmd := &PolicyDeleteCommand{Meta: Meta{Ui: ui}}
+
+	// Fails on misuse
+	if code := cmd.Run([]string{"some", "bad", "args"}); code != 1 {
+		t.Fatalf("expected exit code 1, got: %d", code)
+	}
+	if out := ui.ErrorWriter.String(); !strings.Contains(out, commandErrorText(cmd)) {
+		t.Fatalf("expected help output, got: %s", out)
+	}
+	ui.ErrorWriter.Reset()
+
+	if code := cmd.Run([]string{"-address=nope", "foo"}); code != 1 {
+		t.Fatalf("expected exit code 1, got: %d", code)
+	}
+	if out := ui.ErrorWriter.String(); !strings.Contains(out, "Error deleting admission policy") {
+		t.Fatalf("connection error, got: %s", out)
+	}
+}
+
+func TestPolicyDeleteCommand_Good(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	// Create a server
+	srv, client, url := testServer(t, true, nil)
+	defer srv.Shutdown()
+
+	_, err := client.AdmissionPolicies().Upsert(&api.AdmissionPolicy{
+		Name:             "team",
+		EnforcementLevel: "advisory",
+		Policy:           `rule "team" { condition = lookup(job.Meta, "team", "") != "" }`,
+	}, nil)
+	require.NoError(err)
+
+	ui := new(cli.MockUi)
+	cmd := &PolicyDeleteCommand{Meta: Meta{Ui: ui}}
+
+	require.Equal(0, cmd.Run([]string{"-address=" + url, "team"}), ui.ErrorWriter.String())
+	require.Contains(ui.OutputWriter.String(), `Successfully deleted "team" admission policy`)
+
+	policies, _, err := client.AdmissionPolicies().List(nil)
+	require.NoError(err)
+	require.Empty(policies)
+}
@@ -0,0 +1,85 @@
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/posener/complete"
+)
+
+type PolicyListCommand struct {
+	Meta
+}
+
+func (c *PolicyListCommand) Help() string {
+	helpText := `
+Usage: nomad policy list [options]
+
+  List is used to display all the admission policies.
+
+  If ACLs are enabled, this command requires a management token.
+
+General Options:
+
+  ` + generalOptionsUsage() + `
+
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *PolicyListCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{})
+}
+
+func (c *PolicyListCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *PolicyListCommand) Synopsis() string {
+	return "Display all admission policies"
+}
+
+func (c *PolicyListCommand) Name() string { return "policy list" }
+
+func (c *PolicyListCommand) Run(args []string) int {
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	if args = flags.Args(); len(args) > 0 {
+		c.Ui.Error("This command takes no arguments")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	// Get the HTTP client
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	// Get the list of policies
+	policies, _, err := client.AdmissionPolicies().List(nil)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error listing admission policies: %s", err))
+		return 1
+	}
+
+	if len(policies) == 0 {
+		c.Ui.Output("No policies found")
+		return 0
+	}
+
+	out := []string{}
+	out = append(out, "Name|Enforcement Level|Description")
+	for _, p := range policies {
+		line := fmt.Sprintf("%s|%s|%s", p.Name, p.EnforcementLevel, p.Description)
+		out = append(out, line)
+	}
+	c.Ui.Output(formatList(out))
+	return 0
+}
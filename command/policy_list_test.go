@@ -0,0 +1,68 @@
+package command
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/mitchellh/cli"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPolicyListCommand_Implements(t *testing.T) {
+	t.Parallel()
+	var _ cli.Command = &PolicyListCommand{}
+}
+
+func TestPolicyListCommand_Fails(t *testing.T) {
+	t.Parallel()
+	ui := new(cli.MockUi)
+	cmd := &PolicyListCommand{Meta: Meta{Ui: ui}}
+
+	// Fails on misuse
+	if code := cmd.Run([]string{"some", "bad", "args"}); code != 1 {
+		t.Fatalf("expected exit code 1, got: %d", code)
+	}
+	if out := ui.ErrorWriter.String(); !strings.Contains(out, commandErrorText(cmd)) {
+		t.Fatalf("expected help output, got: %s", out)
+	}
+	ui.ErrorWriter.Reset()
+
+	if code := cmd.Run([]string{"-address=nope"}); code != 1 {
+		t.Fatalf("expected exit code 1, got: %d", code)
+	}
+	if out := ui.ErrorWriter.String(); !strings.Contains(out, "Error listing admission policies") {
+		t.Fatalf("expected failed query error, got: %s", out)
+	}
+}
+
+func TestPolicyListCommand_List(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	// Create a server
+	srv, client, url := testServer(t, true, nil)
+	defer srv.Shutdown()
+
+	ui := new(cli.MockUi)
+	cmd := &PolicyListCommand{Meta: Meta{Ui: ui}}
+
+	// List without policies
+	require.Equal(0, cmd.Run([]string{"-address=" + url}), ui.ErrorWriter.String())
+	require.Contains(ui.OutputWriter.String(), "No policies found")
+	ui.OutputWriter.Reset()
+
+	_, err := client.AdmissionPolicies().Upsert(&api.AdmissionPolicy{
+		Name:             "team",
+		Description:      "jobs must be owned by a team",
+		EnforcementLevel: "hard-mandatory",
+		Policy:           `rule "team" { condition = lookup(job.Meta, "team", "") != "" }`,
+	}, nil)
+	require.NoError(err)
+
+	require.Equal(0, cmd.Run([]string{"-address=" + url}), ui.ErrorWriter.String())
+	out := ui.OutputWriter.String()
+	require.Contains(out, "team")
+	require.Contains(out, "hard-mandatory")
+	require.Contains(out, "jobs must be owned by a team")
+}
@@ -0,0 +1,130 @@
+package command
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/nomad/api/contexts"
+	"github.com/posener/complete"
+)
+
+type SearchCommand struct {
+	Meta
+}
+
+func (c *SearchCommand) Help() string {
+	helpText := `
+Usage: nomad search [options] <text>
+
+  Search for jobs, task groups, tasks, services, images, nodes and node
+  metadata whose names or values contain the given text. Fields that contain
+  the characters of the text in order are listed after the fields containing
+  the text.
+
+General Options:
+
+  ` + generalOptionsUsage() + `
+
+Search Options:
+
+  -context <context>
+    Limit the search to a single context. One of "jobs", "groups", "tasks",
+    "services", "images", "nodes" or "node_meta". Defaults to searching all
+    contexts the token may read.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *SearchCommand) Synopsis() string {
+	return "Search for objects by name or attribute"
+}
+
+func (c *SearchCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-context": complete.PredictSet(
+				string(contexts.Jobs),
+				string(contexts.Groups),
+				string(contexts.Tasks),
+				string(contexts.Services),
+				string(contexts.Images),
+				string(contexts.Nodes),
+				string(contexts.NodeMeta),
+			),
+		})
+}
+
+func (c *SearchCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *SearchCommand) Name() string { return "search" }
+
+func (c *SearchCommand) Run(args []string) int {
+	var context string
+
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.StringVar(&context, "context", string(contexts.All), "")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Check that we got exactly one argument
+	args = flags.Args()
+	if len(args) != 1 {
+		c.Ui.Error("This command takes one argument: <text>")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	// Get the HTTP client
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	resp, _, err := client.Search().FuzzySearch(args[0], contexts.Context(context), nil)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error searching: %s", err))
+		return 1
+	}
+
+	// Output the matches of each context in a stable order
+	ctxs := make([]string, 0, len(resp.Matches))
+	for ctx, matches := range resp.Matches {
+		if len(matches) != 0 {
+			ctxs = append(ctxs, string(ctx))
+		}
+	}
+	sort.Strings(ctxs)
+
+	if len(ctxs) == 0 {
+		c.Ui.Output(fmt.Sprintf("No matches found for %q", args[0]))
+		return 0
+	}
+
+	for i, ctx := range ctxs {
+		if i != 0 {
+			c.Ui.Output("")
+		}
+
+		title := fmt.Sprintf("[bold]%s[reset]", strings.Title(strings.Replace(ctx, "_", " ", -1)))
+		if resp.Truncations[contexts.Context(ctx)] {
+			title += " (truncated)"
+		}
+		c.Ui.Output(c.Colorize().Color(title))
+
+		out := make([]string, 0, len(resp.Matches[contexts.Context(ctx)])+1)
+		out = append(out, "Match|Scope")
+		for _, match := range resp.Matches[contexts.Context(ctx)] {
+			out = append(out, fmt.Sprintf("%s|%s", match.ID, strings.Join(match.Scope, "/")))
+		}
+		c.Ui.Output(formatList(out))
+	}
+
+	return 0
+}
@@ -0,0 +1,67 @@
+package command
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/mitchellh/cli"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchCommand_Implements(t *testing.T) {
+	t.Parallel()
+	var _ cli.Command = &SearchCommand{}
+}
+
+func TestSearchCommand_Fails(t *testing.T) {
+	t.Parallel()
+	ui := new(cli.MockUi)
+	cmd := &SearchCommand{Meta: Meta{Ui: ui}}
+
+	// Fails on misuse
+	if code := cmd.Run([]string{"some", "bad", "args"}); code != 1 {
+		t.Fatalf("expected exit code 1, got: %d", code)
+	}
+	if out := ui.ErrorWriter.String(); !strings.Contains(out, commandErrorText(cmd)) {
+		t.Fatalf("expected help output, got: %s", out)
+	}
+	ui.ErrorWriter.Reset()
+
+	// Fails on connection failure
+	if code := cmd.Run([]string{"-address=nope", "foo"}); code != 1 {
+		t.Fatalf("expected exit code 1, got: %d", code)
+	}
+	if out := ui.ErrorWriter.String(); !strings.Contains(out, "Error searching") {
+		t.Fatalf("expected failed query error, got: %s", out)
+	}
+}
+
+func TestSearchCommand_Run(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	srv, _, url := testServer(t, true, nil)
+	defer srv.Shutdown()
+
+	state := srv.Agent.Server().State()
+	job := mock.Job()
+	job.Name = "checkout-service"
+	require.NoError(state.UpsertJob(1000, job))
+
+	ui := new(cli.MockUi)
+	cmd := &SearchCommand{Meta: Meta{Ui: ui}}
+
+	code := cmd.Run([]string{"-address=" + url, "-context=jobs", "checkout"})
+	require.Equal(0, code, ui.ErrorWriter.String())
+
+	out := ui.OutputWriter.String()
+	require.Contains(out, "Jobs")
+	require.Contains(out, "checkout-service")
+	require.Contains(out, "default/"+job.ID)
+	ui.OutputWriter.Reset()
+
+	code = cmd.Run([]string{"-address=" + url, "nothing-matches"})
+	require.Equal(0, code, ui.ErrorWriter.String())
+	require.Contains(ui.OutputWriter.String(), "No matches found")
+}
@@ -0,0 +1,549 @@
+// Package filter implements the small expression language used to filter the
+// objects returned by list endpoints.
+//
+// An expression is made of matches combined with "and", "or", "not" and
+// parentheses. A match compares the value found at a selector, a dotted path
+// of struct field names and map keys such as "Meta.team", against a literal:
+//
+//	Status == "running"
+//	Status != "dead"
+//	Name contains "cache"
+//	Name not contains "cache"
+//	Name matches "^web-[0-9]+$"
+//	Datacenters contains "dc1"
+//	"dc1" in Datacenters
+//	"dc1" not in Datacenters
+//	Meta.team is empty
+//	Meta.team is not empty
+//
+// Literals are double quoted strings, or bare words such as numbers and
+// booleans. Scalar values are compared by their string representation.
+package filter
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Filter is a parsed filter expression.
+type Filter struct {
+	expr string
+	root node
+}
+
+// Parse parses the filter expression.
+func Parse(expr string) (*Filter, error) {
+	tokens, err := lex(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens}
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse filter %q: %v", expr, err)
+	}
+	if t := p.peek(); t.kind != tokenEOF {
+		return nil, fmt.Errorf("failed to parse filter %q: unexpected %s", expr, t)
+	}
+
+	return &Filter{expr: expr, root: root}, nil
+}
+
+// String returns the filter expression.
+func (f *Filter) String() string {
+	return f.expr
+}
+
+// Match returns whether the object matches the filter. An error is returned
+// if a selector does not exist on the object or selects a value the operator
+// can not be applied to.
+func (f *Filter) Match(obj interface{}) (bool, error) {
+	return f.root.eval(reflect.ValueOf(obj))
+}
+
+// node is a node of the parsed expression tree.
+type node interface {
+	eval(obj reflect.Value) (bool, error)
+}
+
+type andNode struct{ left, right node }
+
+func (n *andNode) eval(obj reflect.Value) (bool, error) {
+	ok, err := n.left.eval(obj)
+	if err != nil || !ok {
+		return false, err
+	}
+	return n.right.eval(obj)
+}
+
+type orNode struct{ left, right node }
+
+func (n *orNode) eval(obj reflect.Value) (bool, error) {
+	ok, err := n.left.eval(obj)
+	if err != nil || ok {
+		return ok, err
+	}
+	return n.right.eval(obj)
+}
+
+type notNode struct{ inner node }
+
+func (n *notNode) eval(obj reflect.Value) (bool, error) {
+	ok, err := n.inner.eval(obj)
+	return !ok, err
+}
+
+// operator is a comparison between the value at a selector and a literal.
+type operator int
+
+const (
+	opEqual operator = iota
+	opNotEqual
+	opContains
+	opMatches
+	opEmpty
+)
+
+type matchNode struct {
+	selector []string
+	op       operator
+	negate   bool
+	value    string
+	re       *regexp.Regexp
+}
+
+func (n *matchNode) eval(obj reflect.Value) (bool, error) {
+	v, err := resolve(obj, n.selector)
+	if err != nil {
+		return false, err
+	}
+
+	var ok bool
+	switch n.op {
+	case opEqual, opNotEqual:
+		s, err := n.scalar(v)
+		if err != nil {
+			return false, err
+		}
+		ok = s == n.value
+		if n.op == opNotEqual {
+			ok = !ok
+		}
+	case opMatches:
+		s, err := n.scalar(v)
+		if err != nil {
+			return false, err
+		}
+		ok = n.re.MatchString(s)
+	case opContains:
+		ok, err = n.contains(v)
+		if err != nil {
+			return false, err
+		}
+	case opEmpty:
+		ok = isEmpty(v)
+	}
+
+	if n.negate {
+		ok = !ok
+	}
+	return ok, nil
+}
+
+// scalar returns the string representation of a scalar value.
+func (n *matchNode) scalar(v reflect.Value) (string, error) {
+	s, ok := scalarString(v)
+	if !ok {
+		return "", fmt.Errorf("selector %q does not select a scalar value", strings.Join(n.selector, "."))
+	}
+	return s, nil
+}
+
+// contains returns whether the string value contains the literal, the list
+// value contains an element equal to the literal or the map value contains
+// the literal as a key.
+func (n *matchNode) contains(v reflect.Value) (bool, error) {
+	if !v.IsValid() {
+		return false, nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		return strings.Contains(v.String(), n.value), nil
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			if s, ok := scalarString(indirect(v.Index(i))); ok && s == n.value {
+				return true, nil
+			}
+		}
+		return false, nil
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String {
+			break
+		}
+		return v.MapIndex(reflect.ValueOf(n.value).Convert(v.Type().Key())).IsValid(), nil
+	}
+
+	return false, fmt.Errorf("selector %q does not select a string, list or map", strings.Join(n.selector, "."))
+}
+
+// indirect dereferences pointers and interfaces, returning an invalid value
+// for nil.
+func indirect(v reflect.Value) reflect.Value {
+	for v.IsValid() && (v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface) {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+		v = v.Elem()
+	}
+	return v
+}
+
+// resolve returns the value at the selector. Missing map keys and nil
+// pointers resolve to an invalid value, which is treated as empty.
+func resolve(obj reflect.Value, selector []string) (reflect.Value, error) {
+	v := indirect(obj)
+	for i, part := range selector {
+		if !v.IsValid() {
+			return v, nil
+		}
+
+		switch v.Kind() {
+		case reflect.Struct:
+			field, ok := v.Type().FieldByName(part)
+			if !ok || field.PkgPath != "" {
+				return reflect.Value{}, fmt.Errorf("selector %q: unknown field %q",
+					strings.Join(selector, "."), strings.Join(selector[:i+1], "."))
+			}
+			v = v.FieldByIndex(field.Index)
+		case reflect.Map:
+			if v.Type().Key().Kind() != reflect.String {
+				return reflect.Value{}, fmt.Errorf("selector %q: %q is not a map with string keys",
+					strings.Join(selector, "."), strings.Join(selector[:i], "."))
+			}
+			v = v.MapIndex(reflect.ValueOf(part).Convert(v.Type().Key()))
+		default:
+			return reflect.Value{}, fmt.Errorf("selector %q: %q is not a struct or map",
+				strings.Join(selector, "."), strings.Join(selector[:i], "."))
+		}
+		v = indirect(v)
+	}
+	return v, nil
+}
+
+// scalarString returns the string representation of a scalar value. An
+// invalid value is represented by the empty string.
+func scalarString(v reflect.Value) (string, bool) {
+	if !v.IsValid() {
+		return "", true
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), true
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool()), true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10), true
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'f', -1, 64), true
+	}
+	return "", false
+}
+
+// isEmpty returns whether the value is missing, empty or the zero value.
+func isEmpty(v reflect.Value) bool {
+	if !v.IsValid() {
+		return true
+	}
+
+	switch v.Kind() {
+	case reflect.String, reflect.Slice, reflect.Array, reflect.Map:
+		return v.Len() == 0
+	case reflect.Struct:
+		return reflect.DeepEqual(v.Interface(), reflect.Zero(v.Type()).Interface())
+	}
+
+	s, _ := scalarString(v)
+	return s == "" || s == "0" || s == "false"
+}
+
+// tokenKind is the kind of a lexed token.
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenWord
+	tokenString
+	tokenEqual
+	tokenNotEqual
+	tokenLParen
+	tokenRParen
+)
+
+type token struct {
+	kind tokenKind
+	text string
+}
+
+func (t token) String() string {
+	switch t.kind {
+	case tokenEOF:
+		return "end of filter"
+	case tokenString:
+		return strconv.Quote(t.text)
+	default:
+		return fmt.Sprintf("%q", t.text)
+	}
+}
+
+// is returns whether the token is the given keyword.
+func (t token) is(keyword string) bool {
+	return t.kind == tokenWord && t.text == keyword
+}
+
+// lex splits the expression into tokens.
+func lex(expr string) ([]token, error) {
+	var tokens []token
+	runes := []rune(expr)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(':
+			tokens = append(tokens, token{kind: tokenLParen, text: "("})
+			i++
+		case r == ')':
+			tokens = append(tokens, token{kind: tokenRParen, text: ")"})
+			i++
+		case r == '=' || r == '!':
+			if i+1 >= len(runes) || runes[i+1] != '=' {
+				return nil, fmt.Errorf("failed to parse filter %q: unexpected %q at position %d", expr, r, i)
+			}
+			if r == '=' {
+				tokens = append(tokens, token{kind: tokenEqual, text: "=="})
+			} else {
+				tokens = append(tokens, token{kind: tokenNotEqual, text: "!="})
+			}
+			i += 2
+		case r == '"':
+			// Find the closing quote, skipping escaped characters
+			j := i + 1
+			for ; j < len(runes) && runes[j] != '"'; j++ {
+				if runes[j] == '\\' {
+					j++
+				}
+			}
+			if j >= len(runes) {
+				return nil, fmt.Errorf("failed to parse filter %q: unterminated string at position %d", expr, i)
+			}
+			s, err := strconv.Unquote(string(runes[i : j+1]))
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse filter %q: invalid string at position %d: %v", expr, i, err)
+			}
+			tokens = append(tokens, token{kind: tokenString, text: s})
+			i = j + 1
+		case isWordRune(r):
+			j := i
+			for j < len(runes) && isWordRune(runes[j]) {
+				j++
+			}
+			tokens = append(tokens, token{kind: tokenWord, text: string(runes[i:j])})
+			i = j
+		default:
+			return nil, fmt.Errorf("failed to parse filter %q: unexpected %q at position %d", expr, r, i)
+		}
+	}
+	return append(tokens, token{kind: tokenEOF}), nil
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || strings.ContainsRune("_.-/:", r)
+}
+
+// parser is a recursive descent parser of the tokens of an expression.
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) peekAt(offset int) token {
+	if i := p.pos + offset; i < len(p.tokens) {
+		return p.tokens[i]
+	}
+	return token{kind: tokenEOF}
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+// expectKeyword consumes the keyword or returns an error.
+func (p *parser) expectKeyword(keyword string) error {
+	if t := p.next(); !t.is(keyword) {
+		return fmt.Errorf("expected %q but found %s", keyword, t)
+	}
+	return nil
+}
+
+func (p *parser) parseOr() (node, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().is("or") {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &orNode{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAnd() (node, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().is("and") {
+		p.next()
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = &andNode{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseNot() (node, error) {
+	switch t := p.peek(); {
+	case t.is("not"):
+		p.next()
+		inner, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return &notNode{inner: inner}, nil
+	case t.kind == tokenLParen:
+		p.next()
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if t := p.next(); t.kind != tokenRParen {
+			return nil, fmt.Errorf("expected \")\" but found %s", t)
+		}
+		return inner, nil
+	}
+	return p.parseMatch()
+}
+
+func (p *parser) parseMatch() (node, error) {
+	// Handle the <value> [not] in <selector> form
+	first := p.peek()
+	if first.kind == tokenString || (first.kind == tokenWord &&
+		(p.peekAt(1).is("in") || (p.peekAt(1).is("not") && p.peekAt(2).is("in")))) {
+		p.next()
+		negate := false
+		if p.peek().is("not") {
+			p.next()
+			negate = true
+		}
+		if err := p.expectKeyword("in"); err != nil {
+			return nil, err
+		}
+		selector, err := p.parseSelector()
+		if err != nil {
+			return nil, err
+		}
+		return &matchNode{selector: selector, op: opContains, negate: negate, value: first.text}, nil
+	}
+
+	selector, err := p.parseSelector()
+	if err != nil {
+		return nil, err
+	}
+
+	n := &matchNode{selector: selector}
+	switch t := p.next(); {
+	case t.kind == tokenEqual:
+		n.op = opEqual
+	case t.kind == tokenNotEqual:
+		n.op = opNotEqual
+	case t.is("is"):
+		n.op = opEmpty
+		if p.peek().is("not") {
+			p.next()
+			n.negate = true
+		}
+		if err := p.expectKeyword("empty"); err != nil {
+			return nil, err
+		}
+		return n, nil
+	case t.is("not"):
+		n.negate = true
+		switch t := p.next(); {
+		case t.is("contains"):
+			n.op = opContains
+		case t.is("matches"):
+			n.op = opMatches
+		default:
+			return nil, fmt.Errorf("expected \"contains\" or \"matches\" but found %s", t)
+		}
+	case t.is("contains"):
+		n.op = opContains
+	case t.is("matches"):
+		n.op = opMatches
+	default:
+		return nil, fmt.Errorf("expected an operator after selector %q but found %s", strings.Join(selector, "."), t)
+	}
+
+	value := p.next()
+	if value.kind != tokenString && value.kind != tokenWord {
+		return nil, fmt.Errorf("expected a value but found %s", value)
+	}
+	n.value = value.text
+
+	if n.op == opMatches {
+		re, err := regexp.Compile(n.value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid regular expression %q: %v", n.value, err)
+		}
+		n.re = re
+	}
+	return n, nil
+}
+
+func (p *parser) parseSelector() ([]string, error) {
+	t := p.next()
+	if t.kind != tokenWord {
+		return nil, fmt.Errorf("expected a selector but found %s", t)
+	}
+
+	parts := strings.Split(t.text, ".")
+	for _, part := range parts {
+		if part == "" {
+			return nil, fmt.Errorf("invalid selector %q", t.text)
+		}
+	}
+	return parts, nil
+}
@@ -0,0 +1,147 @@
+package filter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type testSummary struct {
+	Running int
+}
+
+type testStub struct {
+	ID          string
+	Status      string
+	Priority    int
+	Stop        bool
+	Datacenters []string
+	Meta        map[string]string
+	Summary     map[string]testSummary
+	Parent      *testStub
+	unexported  string
+}
+
+func TestFilter_Match(t *testing.T) {
+	stub := &testStub{
+		ID:          "example-web",
+		Status:      "running",
+		Priority:    50,
+		Datacenters: []string{"dc1", "dc2"},
+		Meta: map[string]string{
+			"team":    "payments",
+			"pci-dss": "true",
+		},
+		Summary: map[string]testSummary{
+			"web": {Running: 3},
+		},
+	}
+
+	cases := []struct {
+		expr  string
+		match bool
+	}{
+		{`Status == "running"`, true},
+		{`Status == running`, true},
+		{`Status != "running"`, false},
+		{`Priority == 50`, true},
+		{`Stop == false`, true},
+		{`Meta.team == "payments"`, true},
+		{`Meta.pci-dss == "true"`, true},
+		{`Meta.missing == ""`, true},
+		{`Summary.web.Running == 3`, true},
+		{`Status == "running" and Meta.team == "payments"`, true},
+		{`Status == "dead" or Meta.team == "payments"`, true},
+		{`Status == "dead" or Meta.team == "search"`, false},
+		{`not Status == "dead"`, true},
+		{`not (Status == "running" and Priority == 50)`, false},
+		{`Status == "dead" or (Priority == 50 and Stop == false)`, true},
+		{`ID contains "web"`, true},
+		{`ID not contains "web"`, false},
+		{`Datacenters contains "dc2"`, true},
+		{`Datacenters contains "dc3"`, false},
+		{`"dc1" in Datacenters`, true},
+		{`"dc3" not in Datacenters`, true},
+		{`team in Meta`, true},
+		{`"owner" in Meta`, false},
+		{`ID matches "^example-[a-z]+$"`, true},
+		{`ID not matches "^example"`, false},
+		{`Meta.owner is empty`, true},
+		{`Meta.team is not empty`, true},
+		{`Parent is empty`, true},
+		{`Parent.ID is empty`, true},
+		{`Datacenters is empty`, false},
+		{`Status == "esc\"aped"`, false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.expr, func(t *testing.T) {
+			f, err := Parse(c.expr)
+			require.NoError(t, err)
+			require.Equal(t, c.expr, f.String())
+
+			match, err := f.Match(stub)
+			require.NoError(t, err)
+			require.Equal(t, c.match, match)
+		})
+	}
+}
+
+func TestFilter_Parse_Invalid(t *testing.T) {
+	cases := []struct {
+		expr string
+		err  string
+	}{
+		{``, "expected a selector"},
+		{`Status =`, `unexpected '='`},
+		{`Status == "running`, "unterminated string"},
+		{`Status "running"`, "expected an operator"},
+		{`Status ==`, "expected a value"},
+		{`Status == "running" and`, "expected a selector"},
+		{`(Status == "running"`, `expected ")"`},
+		{`Status == "running")`, `unexpected ")"`},
+		{`Meta is full`, `expected "empty"`},
+		{`ID matches "["`, "invalid regular expression"},
+		{`"dc1" within Datacenters`, `expected "in"`},
+		{`Meta..team == "x"`, "invalid selector"},
+		{`Status == "a" & Stop == true`, `unexpected '&'`},
+	}
+
+	for _, c := range cases {
+		t.Run(c.expr, func(t *testing.T) {
+			_, err := Parse(c.expr)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), c.err)
+		})
+	}
+}
+
+func TestFilter_Match_Invalid(t *testing.T) {
+	stub := &testStub{
+		Datacenters: []string{"dc1"},
+		Meta:        map[string]string{"team": "payments"},
+	}
+
+	cases := []struct {
+		expr string
+		err  string
+	}{
+		{`Unknown == "x"`, `unknown field "Unknown"`},
+		{`unexported == "x"`, `unknown field "unexported"`},
+		{`Status.Foo == "x"`, `"Status" is not a struct or map`},
+		{`Datacenters == "dc1"`, "does not select a scalar value"},
+		{`Meta matches "x"`, "does not select a scalar value"},
+		{`Priority contains "5"`, "does not select a string, list or map"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.expr, func(t *testing.T) {
+			f, err := Parse(c.expr)
+			require.NoError(t, err)
+
+			_, err = f.Match(stub)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), c.err)
+		})
+	}
+}
@@ -300,6 +300,20 @@ func CopyMapStringFloat64(m map[string]float64) map[string]float64 {
 	return c
 }
 
+// CopyMapStringInterface makes a shallow copy of a map of strings to values
+func CopyMapStringInterface(m map[string]interface{}) map[string]interface{} {
+	l := len(m)
+	if l == 0 {
+		return nil
+	}
+
+	c := make(map[string]interface{}, l)
+	for k, v := range m {
+		c[k] = v
+	}
+	return c
+}
+
 // CopyMapStringSliceString copies a map of strings to string slices such as
 // http.Header
 func CopyMapStringSliceString(m map[string][]string) map[string][]string {
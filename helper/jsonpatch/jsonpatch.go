@@ -0,0 +1,352 @@
+// Package jsonpatch applies JSON patches, as defined by RFC 6902, to JSON
+// documents.
+//
+// A patch is a list of operations applied in order to the document. Each
+// operation targets a location of the document given as a JSON pointer, as
+// defined by RFC 6901, such as "/Meta/team" or "/TaskGroups/0/Count":
+//
+//	[
+//	  {"op": "add", "path": "/Meta/team", "value": "api"},
+//	  {"op": "replace", "path": "/TaskGroups/0/Count", "value": 3},
+//	  {"op": "remove", "path": "/Constraints/0"}
+//	]
+//
+// The supported operations are "add", "remove", "replace", "move", "copy" and
+// "test". The patch is applied atomically: if an operation fails, the error
+// is returned and the document is left unmodified. Numbers of the document
+// are kept as written, so integers don't lose precision.
+package jsonpatch
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Operation is a single operation of a JSON patch.
+type Operation struct {
+	// Op is the operation to apply.
+	Op string `json:"op"`
+
+	// Path is the JSON pointer to the location the operation targets.
+	Path string `json:"path"`
+
+	// From is the JSON pointer to the source location of the move and copy
+	// operations.
+	From string `json:"from,omitempty"`
+
+	// Value is the value of the add, replace and test operations.
+	Value interface{} `json:"value,omitempty"`
+}
+
+// Apply applies the operations of a patch to a JSON document and returns the
+// patched document.
+func Apply(doc []byte, patch []*Operation) ([]byte, error) {
+	if len(patch) == 0 {
+		return doc, nil
+	}
+
+	root, err := decode(doc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode document: %v", err)
+	}
+
+	for i, op := range patch {
+		var err error
+		root, err = apply(root, op)
+		if err != nil {
+			return nil, fmt.Errorf("operation %d (%s %q) failed: %v", i, op.Op, op.Path, err)
+		}
+	}
+
+	return json.Marshal(root)
+}
+
+// apply applies a single operation to the decoded document and returns the
+// resulting document.
+func apply(root interface{}, op *Operation) (interface{}, error) {
+	path, err := parsePointer(op.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	switch op.Op {
+	case "add":
+		return add(root, path, copyValue(op.Value))
+	case "remove":
+		root, _, err := remove(root, path)
+		return root, err
+	case "replace":
+		root, _, err := remove(root, path)
+		if err != nil {
+			return nil, err
+		}
+		return add(root, path, copyValue(op.Value))
+	case "move":
+		from, err := parsePointer(op.From)
+		if err != nil {
+			return nil, err
+		}
+		if isPrefix(from, path) && len(from) < len(path) {
+			return nil, fmt.Errorf("can't move %q into one of its children", op.From)
+		}
+		root, val, err := remove(root, from)
+		if err != nil {
+			return nil, err
+		}
+		return add(root, path, val)
+	case "copy":
+		from, err := parsePointer(op.From)
+		if err != nil {
+			return nil, err
+		}
+		val, err := get(root, from)
+		if err != nil {
+			return nil, err
+		}
+		return add(root, path, copyValue(val))
+	case "test":
+		val, err := get(root, path)
+		if err != nil {
+			return nil, err
+		}
+		if !reflect.DeepEqual(normalize(val), normalize(op.Value)) {
+			return nil, fmt.Errorf("value at %q doesn't match", op.Path)
+		}
+		return root, nil
+	default:
+		return nil, fmt.Errorf("unknown operation %q", op.Op)
+	}
+}
+
+// parsePointer splits a JSON pointer into its unescaped reference tokens.
+func parsePointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("invalid JSON pointer %q, must start with a slash", pointer)
+	}
+
+	tokens := strings.Split(pointer[1:], "/")
+	for i, t := range tokens {
+		t = strings.Replace(t, "~1", "/", -1)
+		tokens[i] = strings.Replace(t, "~0", "~", -1)
+	}
+	return tokens, nil
+}
+
+// isPrefix returns whether the path a is a prefix of the path b.
+func isPrefix(a, b []string) bool {
+	if len(a) > len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// get returns the value at a path.
+func get(root interface{}, path []string) (interface{}, error) {
+	cur := root
+	for i, t := range path {
+		switch c := cur.(type) {
+		case map[string]interface{}:
+			val, ok := c[t]
+			if !ok {
+				return nil, fmt.Errorf("missing key %q", pointer(path[:i+1]))
+			}
+			cur = val
+		case []interface{}:
+			idx, err := index(t, len(c)-1)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %v", pointer(path[:i+1]), err)
+			}
+			cur = c[idx]
+		default:
+			return nil, fmt.Errorf("%q isn't an object or an array", pointer(path[:i]))
+		}
+	}
+	return cur, nil
+}
+
+// add adds a value at a path and returns the resulting document. Adding to an
+// array inserts the value at the index, or appends it if the index is "-".
+func add(root interface{}, path []string, val interface{}) (interface{}, error) {
+	if len(path) == 0 {
+		return val, nil
+	}
+
+	parent, err := get(root, path[:len(path)-1])
+	if err != nil {
+		return nil, err
+	}
+
+	last := path[len(path)-1]
+	switch p := parent.(type) {
+	case map[string]interface{}:
+		p[last] = val
+		return root, nil
+	case []interface{}:
+		idx := len(p)
+		if last != "-" {
+			idx, err = index(last, len(p))
+			if err != nil {
+				return nil, fmt.Errorf("%s: %v", pointer(path), err)
+			}
+		}
+		arr := make([]interface{}, 0, len(p)+1)
+		arr = append(arr, p[:idx]...)
+		arr = append(arr, val)
+		arr = append(arr, p[idx:]...)
+		return set(root, path[:len(path)-1], arr)
+	case nil:
+		return nil, fmt.Errorf("%q is null", pointer(path[:len(path)-1]))
+	default:
+		return nil, fmt.Errorf("%q isn't an object or an array", pointer(path[:len(path)-1]))
+	}
+}
+
+// remove removes the value at a path and returns the resulting document and
+// the removed value.
+func remove(root interface{}, path []string) (interface{}, interface{}, error) {
+	if len(path) == 0 {
+		return nil, root, nil
+	}
+
+	parent, err := get(root, path[:len(path)-1])
+	if err != nil {
+		return nil, nil, err
+	}
+
+	last := path[len(path)-1]
+	switch p := parent.(type) {
+	case map[string]interface{}:
+		val, ok := p[last]
+		if !ok {
+			return nil, nil, fmt.Errorf("missing key %q", pointer(path))
+		}
+		delete(p, last)
+		return root, val, nil
+	case []interface{}:
+		idx, err := index(last, len(p)-1)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s: %v", pointer(path), err)
+		}
+		val := p[idx]
+		arr := make([]interface{}, 0, len(p)-1)
+		arr = append(arr, p[:idx]...)
+		arr = append(arr, p[idx+1:]...)
+		root, err = set(root, path[:len(path)-1], arr)
+		return root, val, err
+	default:
+		return nil, nil, fmt.Errorf("%q isn't an object or an array", pointer(path[:len(path)-1]))
+	}
+}
+
+// set replaces the value at an existing path and returns the resulting
+// document.
+func set(root interface{}, path []string, val interface{}) (interface{}, error) {
+	if len(path) == 0 {
+		return val, nil
+	}
+
+	parent, err := get(root, path[:len(path)-1])
+	if err != nil {
+		return nil, err
+	}
+
+	last := path[len(path)-1]
+	switch p := parent.(type) {
+	case map[string]interface{}:
+		p[last] = val
+	case []interface{}:
+		idx, err := index(last, len(p)-1)
+		if err != nil {
+			return nil, err
+		}
+		p[idx] = val
+	}
+	return root, nil
+}
+
+// index parses an array index and checks it is at most max.
+func index(token string, max int) (int, error) {
+	if token == "-" {
+		return 0, fmt.Errorf("index \"-\" refers to a nonexistent element")
+	}
+	if len(token) > 1 && token[0] == '0' {
+		return 0, fmt.Errorf("invalid array index %q", token)
+	}
+	idx, err := strconv.Atoi(token)
+	if err != nil || idx < 0 {
+		return 0, fmt.Errorf("invalid array index %q", token)
+	}
+	if idx > max {
+		return 0, fmt.Errorf("array index %d out of bounds", idx)
+	}
+	return idx, nil
+}
+
+// pointer formats a path as a JSON pointer.
+func pointer(path []string) string {
+	var b strings.Builder
+	for _, t := range path {
+		t = strings.Replace(t, "~", "~0", -1)
+		b.WriteString("/")
+		b.WriteString(strings.Replace(t, "/", "~1", -1))
+	}
+	return b.String()
+}
+
+// copyValue deep copies a decoded JSON value so that patched documents don't
+// share values with the patch.
+func copyValue(val interface{}) interface{} {
+	switch v := val.(type) {
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for k, e := range v {
+			m[k] = copyValue(e)
+		}
+		return m
+	case []interface{}:
+		a := make([]interface{}, len(v))
+		for i, e := range v {
+			a[i] = copyValue(e)
+		}
+		return a
+	default:
+		return v
+	}
+}
+
+// decode decodes a JSON document, keeping its numbers as json.Number.
+func decode(doc []byte) (interface{}, error) {
+	dec := json.NewDecoder(bytes.NewReader(doc))
+	dec.UseNumber()
+	var out interface{}
+	if err := dec.Decode(&out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// normalize converts a value to its decoded JSON form, so values given as Go
+// types compare equal to the values of the document.
+func normalize(val interface{}) interface{} {
+	buf, err := json.Marshal(val)
+	if err != nil {
+		return val
+	}
+	out, err := decode(buf)
+	if err != nil {
+		return val
+	}
+	return out
+}
@@ -0,0 +1,146 @@
+package jsonpatch
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestApply(t *testing.T) {
+	doc := `{"ID":"example","Meta":{"team":"web"},"Tags":["a","b"],"Group":{"Count":1}}`
+
+	cases := []struct {
+		Name     string
+		Patch    string
+		Expected string
+		Err      string
+	}{
+		{
+			Name:     "empty patch",
+			Patch:    `[]`,
+			Expected: doc,
+		},
+		{
+			Name:     "add object key",
+			Patch:    `[{"op":"add","path":"/Meta/owner","value":"platform"}]`,
+			Expected: `{"ID":"example","Meta":{"team":"web","owner":"platform"},"Tags":["a","b"],"Group":{"Count":1}}`,
+		},
+		{
+			Name:     "add array element",
+			Patch:    `[{"op":"add","path":"/Tags/1","value":"c"}]`,
+			Expected: `{"ID":"example","Meta":{"team":"web"},"Tags":["a","c","b"],"Group":{"Count":1}}`,
+		},
+		{
+			Name:     "append array element",
+			Patch:    `[{"op":"add","path":"/Tags/-","value":"c"}]`,
+			Expected: `{"ID":"example","Meta":{"team":"web"},"Tags":["a","b","c"],"Group":{"Count":1}}`,
+		},
+		{
+			Name:     "remove",
+			Patch:    `[{"op":"remove","path":"/Tags/0"},{"op":"remove","path":"/Meta/team"}]`,
+			Expected: `{"ID":"example","Meta":{},"Tags":["b"],"Group":{"Count":1}}`,
+		},
+		{
+			Name:     "replace",
+			Patch:    `[{"op":"replace","path":"/Group/Count","value":3}]`,
+			Expected: `{"ID":"example","Meta":{"team":"web"},"Tags":["a","b"],"Group":{"Count":3}}`,
+		},
+		{
+			Name:     "move",
+			Patch:    `[{"op":"move","from":"/Meta/team","path":"/Group/team"}]`,
+			Expected: `{"ID":"example","Meta":{},"Tags":["a","b"],"Group":{"Count":1,"team":"web"}}`,
+		},
+		{
+			Name:     "copy",
+			Patch:    `[{"op":"copy","from":"/Tags","path":"/Meta/tags"}]`,
+			Expected: `{"ID":"example","Meta":{"team":"web","tags":["a","b"]},"Tags":["a","b"],"Group":{"Count":1}}`,
+		},
+		{
+			Name:     "test",
+			Patch:    `[{"op":"test","path":"/Group","value":{"Count":1}}]`,
+			Expected: doc,
+		},
+		{
+			Name:     "escaped pointer",
+			Patch:    `[{"op":"add","path":"/Meta/a~1b~0c","value":"x"}]`,
+			Expected: `{"ID":"example","Meta":{"team":"web","a/b~c":"x"},"Tags":["a","b"],"Group":{"Count":1}}`,
+		},
+		{
+			Name:  "failed test",
+			Patch: `[{"op":"test","path":"/ID","value":"other"}]`,
+			Err:   "doesn't match",
+		},
+		{
+			Name:  "missing key",
+			Patch: `[{"op":"replace","path":"/Meta/missing","value":"x"}]`,
+			Err:   "missing key",
+		},
+		{
+			Name:  "out of bounds",
+			Patch: `[{"op":"remove","path":"/Tags/2"}]`,
+			Err:   "out of bounds",
+		},
+		{
+			Name:  "missing parent",
+			Patch: `[{"op":"add","path":"/Missing/key","value":"x"}]`,
+			Err:   "missing key",
+		},
+		{
+			Name:  "move into child",
+			Patch: `[{"op":"move","from":"/Meta","path":"/Meta/child"}]`,
+			Err:   "into one of its children",
+		},
+		{
+			Name:  "invalid pointer",
+			Patch: `[{"op":"add","path":"Meta","value":"x"}]`,
+			Err:   "must start with a slash",
+		},
+		{
+			Name:  "unknown operation",
+			Patch: `[{"op":"merge","path":"/Meta","value":{}}]`,
+			Err:   "unknown operation",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			var patch []*Operation
+			require.NoError(t, json.Unmarshal([]byte(c.Patch), &patch))
+
+			out, err := Apply([]byte(doc), patch)
+			if c.Err != "" {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), c.Err)
+				return
+			}
+			require.NoError(t, err)
+			require.JSONEq(t, c.Expected, string(out))
+		})
+	}
+}
+
+func TestApply_Atomic(t *testing.T) {
+	doc := []byte(`{"Meta":{"team":"web"}}`)
+	patch := []*Operation{
+		{Op: "add", Path: "/Meta/owner", Value: "platform"},
+		{Op: "remove", Path: "/Missing"},
+	}
+
+	_, err := Apply(doc, patch)
+	require.Error(t, err)
+	require.JSONEq(t, `{"Meta":{"team":"web"}}`, string(doc))
+}
+
+func TestApply_Numbers(t *testing.T) {
+	doc := []byte(`{"Count":9007199254740993,"Ratio":0.5}`)
+	patch := []*Operation{
+		{Op: "test", Path: "/Ratio", Value: 0.5},
+		{Op: "add", Path: "/Port", Value: 8080},
+	}
+
+	// Numbers are kept as written instead of being converted to float64
+	out, err := Apply(doc, patch)
+	require.NoError(t, err)
+	require.Equal(t, `{"Count":9007199254740993,"Port":8080,"Ratio":0.5}`, string(out))
+}
@@ -0,0 +1,252 @@
+package logging
+
+import (
+	"bytes"
+	"log"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/go-hclog"
+)
+
+// SinkAdapter receives the messages logged by an InterceptLogger, including
+// the messages filtered out by the level of the logger.
+type SinkAdapter interface {
+	// Level is the minimum level of the messages accepted by the sink.
+	Level() hclog.Level
+
+	// Accept is called with each message at or above the level of the sink.
+	// The name is the full name of the logger that emitted the message.
+	Accept(name string, level hclog.Level, msg string, args ...interface{})
+}
+
+// InterceptLogger is an hclog.Logger that also sends its messages, and the
+// messages of the loggers derived from it, to the registered sinks.
+type InterceptLogger interface {
+	hclog.Logger
+
+	// RegisterSink starts sending messages to the sink.
+	RegisterSink(sink SinkAdapter)
+
+	// DeregisterSink stops sending messages to the sink.
+	DeregisterSink(sink SinkAdapter)
+}
+
+// sinks is the set of sinks shared by an intercept logger and the loggers
+// derived from it.
+type sinks struct {
+	l     sync.RWMutex
+	sinks map[SinkAdapter]struct{}
+}
+
+// levelLogger is an hclog.Logger that can log a message at a given level.
+// The loggers returned by hclog.New implement it.
+type levelLogger interface {
+	hclog.Logger
+	Log(level hclog.Level, msg string, args ...interface{})
+}
+
+// interceptLogger wraps an hclog.Logger to implement InterceptLogger. It
+// logs with Log rather than the level methods of the wrapped logger, so the
+// location reported with the IncludeLocation option is still the caller's.
+type interceptLogger struct {
+	levelLogger
+
+	name    string
+	implied []interface{}
+	sinks   *sinks
+}
+
+// NewInterceptLogger returns an InterceptLogger created with the options.
+func NewInterceptLogger(opts *hclog.LoggerOptions) InterceptLogger {
+	return &interceptLogger{
+		levelLogger: hclog.New(opts).(levelLogger),
+		name:        opts.Name,
+		sinks: &sinks{
+			sinks: make(map[SinkAdapter]struct{}),
+		},
+	}
+}
+
+func (i *interceptLogger) RegisterSink(sink SinkAdapter) {
+	i.sinks.l.Lock()
+	defer i.sinks.l.Unlock()
+	i.sinks.sinks[sink] = struct{}{}
+}
+
+func (i *interceptLogger) DeregisterSink(sink SinkAdapter) {
+	i.sinks.l.Lock()
+	defer i.sinks.l.Unlock()
+	delete(i.sinks.sinks, sink)
+}
+
+// intercept sends the message to the sinks accepting its level.
+func (i *interceptLogger) intercept(level hclog.Level, msg string, args []interface{}) {
+	i.sinks.l.RLock()
+	defer i.sinks.l.RUnlock()
+
+	if len(i.sinks.sinks) == 0 {
+		return
+	}
+
+	if len(i.implied) != 0 {
+		args = append(append(make([]interface{}, 0, len(i.implied)+len(args)), i.implied...), args...)
+	}
+	for sink := range i.sinks.sinks {
+		if level >= sink.Level() {
+			sink.Accept(i.name, level, msg, args...)
+		}
+	}
+}
+
+// sinksAccept returns whether a sink accepts messages of the level.
+func (i *interceptLogger) sinksAccept(level hclog.Level) bool {
+	i.sinks.l.RLock()
+	defer i.sinks.l.RUnlock()
+
+	for sink := range i.sinks.sinks {
+		if level >= sink.Level() {
+			return true
+		}
+	}
+	return false
+}
+
+func (i *interceptLogger) Trace(msg string, args ...interface{}) {
+	i.Log(hclog.Trace, msg, args...)
+	i.intercept(hclog.Trace, msg, args)
+}
+
+func (i *interceptLogger) Debug(msg string, args ...interface{}) {
+	i.Log(hclog.Debug, msg, args...)
+	i.intercept(hclog.Debug, msg, args)
+}
+
+func (i *interceptLogger) Info(msg string, args ...interface{}) {
+	i.Log(hclog.Info, msg, args...)
+	i.intercept(hclog.Info, msg, args)
+}
+
+func (i *interceptLogger) Warn(msg string, args ...interface{}) {
+	i.Log(hclog.Warn, msg, args...)
+	i.intercept(hclog.Warn, msg, args)
+}
+
+func (i *interceptLogger) Error(msg string, args ...interface{}) {
+	i.Log(hclog.Error, msg, args...)
+	i.intercept(hclog.Error, msg, args)
+}
+
+func (i *interceptLogger) IsTrace() bool {
+	return i.levelLogger.IsTrace() || i.sinksAccept(hclog.Trace)
+}
+
+func (i *interceptLogger) IsDebug() bool {
+	return i.levelLogger.IsDebug() || i.sinksAccept(hclog.Debug)
+}
+
+func (i *interceptLogger) IsInfo() bool {
+	return i.levelLogger.IsInfo() || i.sinksAccept(hclog.Info)
+}
+
+func (i *interceptLogger) IsWarn() bool {
+	return i.levelLogger.IsWarn() || i.sinksAccept(hclog.Warn)
+}
+
+func (i *interceptLogger) IsError() bool {
+	return i.levelLogger.IsError() || i.sinksAccept(hclog.Error)
+}
+
+func (i *interceptLogger) With(args ...interface{}) hclog.Logger {
+	implied := make([]interface{}, 0, len(i.implied)+len(args))
+	implied = append(implied, i.implied...)
+	implied = append(implied, args...)
+	return &interceptLogger{
+		levelLogger: i.levelLogger.With(args...).(levelLogger),
+		name:        i.name,
+		implied:     implied,
+		sinks:       i.sinks,
+	}
+}
+
+func (i *interceptLogger) Named(name string) hclog.Logger {
+	full := name
+	if i.name != "" {
+		full = i.name + "." + name
+	}
+	return &interceptLogger{
+		levelLogger: i.levelLogger.Named(name).(levelLogger),
+		name:        full,
+		implied:     i.implied,
+		sinks:       i.sinks,
+	}
+}
+
+func (i *interceptLogger) ResetNamed(name string) hclog.Logger {
+	return &interceptLogger{
+		levelLogger: i.levelLogger.ResetNamed(name).(levelLogger),
+		name:        name,
+		implied:     i.implied,
+		sinks:       i.sinks,
+	}
+}
+
+// StandardLogger returns a *log.Logger sending its output through the
+// intercept logger, so it also reaches the sinks.
+func (i *interceptLogger) StandardLogger(opts *hclog.StandardLoggerOptions) *log.Logger {
+	if opts == nil {
+		opts = &hclog.StandardLoggerOptions{}
+	}
+	return log.New(&stdlogAdapter{i, opts.InferLevels}, "", 0)
+}
+
+// stdlogAdapter is an io.Writer sending the output of a *log.Logger to an
+// hclog.Logger.
+type stdlogAdapter struct {
+	hl          hclog.Logger
+	inferLevels bool
+}
+
+func (s *stdlogAdapter) Write(data []byte) (int, error) {
+	str := string(bytes.TrimRight(data, " \t\n"))
+
+	level := hclog.Info
+	if s.inferLevels {
+		level, str = pickLevel(str)
+	}
+
+	switch level {
+	case hclog.Trace:
+		s.hl.Trace(str)
+	case hclog.Debug:
+		s.hl.Debug(str)
+	case hclog.Warn:
+		s.hl.Warn(str)
+	case hclog.Error:
+		s.hl.Error(str)
+	default:
+		s.hl.Info(str)
+	}
+	return len(data), nil
+}
+
+// pickLevel detects the level of a message based on the conventional
+// prefixes of the standard library loggers.
+func pickLevel(str string) (hclog.Level, string) {
+	switch {
+	case strings.HasPrefix(str, "[DEBUG]"):
+		return hclog.Debug, strings.TrimSpace(str[7:])
+	case strings.HasPrefix(str, "[TRACE]"):
+		return hclog.Trace, strings.TrimSpace(str[7:])
+	case strings.HasPrefix(str, "[INFO]"):
+		return hclog.Info, strings.TrimSpace(str[6:])
+	case strings.HasPrefix(str, "[WARN]"):
+		return hclog.Warn, strings.TrimSpace(str[7:])
+	case strings.HasPrefix(str, "[ERROR]"):
+		return hclog.Error, strings.TrimSpace(str[7:])
+	case strings.HasPrefix(str, "[ERR]"):
+		return hclog.Error, strings.TrimSpace(str[5:])
+	default:
+		return hclog.Info, str
+	}
+}
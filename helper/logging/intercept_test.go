@@ -0,0 +1,73 @@
+package logging
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/require"
+)
+
+// testMessage is a message accepted by a testSink.
+type testMessage struct {
+	name  string
+	level hclog.Level
+	msg   string
+	args  []interface{}
+}
+
+// testSink records the messages it accepts.
+type testSink struct {
+	level    hclog.Level
+	messages []testMessage
+}
+
+func (s *testSink) Level() hclog.Level {
+	return s.level
+}
+
+func (s *testSink) Accept(name string, level hclog.Level, msg string, args ...interface{}) {
+	s.messages = append(s.messages, testMessage{name, level, msg, args})
+}
+
+func TestInterceptLogger(t *testing.T) {
+	require := require.New(t)
+
+	var buf bytes.Buffer
+	logger := NewInterceptLogger(&hclog.LoggerOptions{
+		Name:   "agent",
+		Level:  hclog.Info,
+		Output: &buf,
+	})
+
+	sink := &testSink{level: hclog.Debug}
+	logger.RegisterSink(sink)
+
+	// Derived loggers send their messages to the sink
+	sub := logger.Named("client").With("key", "value")
+	require.True(sub.IsDebug())
+	require.False(sub.IsTrace())
+
+	sub.Trace("trace")
+	sub.Debug("debug")
+	sub.Info("info")
+
+	// The sink receives messages filtered out by the logger
+	require.NotContains(buf.String(), "debug")
+	require.Contains(buf.String(), "agent.client: info: key=value")
+	require.Equal([]testMessage{
+		{"agent.client", hclog.Debug, "debug", []interface{}{"key", "value"}},
+		{"agent.client", hclog.Info, "info", []interface{}{"key", "value"}},
+	}, sink.messages)
+
+	// Standard loggers send their messages to the sink
+	std := logger.StandardLogger(&hclog.StandardLoggerOptions{InferLevels: true})
+	std.Printf("[DEBUG] standard")
+	require.Len(sink.messages, 3)
+	require.Equal(testMessage{"agent", hclog.Debug, "standard", nil}, sink.messages[2])
+
+	logger.DeregisterSink(sink)
+	require.False(sub.IsDebug())
+	sub.Debug("debug")
+	require.Len(sink.messages, 3)
+}
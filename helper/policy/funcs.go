@@ -0,0 +1,288 @@
+package policy
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/function"
+	"github.com/zclconf/go-cty/cty/function/stdlib"
+)
+
+// Functions returns the functions available to the conditions of policies.
+func Functions() map[string]function.Function {
+	return map[string]function.Function{
+		"abs":        stdlib.AbsoluteFunc,
+		"alltrue":    allTrueFunc,
+		"anytrue":    anyTrueFunc,
+		"coalesce":   stdlib.CoalesceFunc,
+		"concat":     stdlib.ConcatFunc,
+		"contains":   containsFunc,
+		"endswith":   endsWithFunc,
+		"flatten":    flattenFunc,
+		"format":     stdlib.FormatFunc,
+		"int":        stdlib.IntFunc,
+		"jsonencode": stdlib.JSONEncodeFunc,
+		"keys":       keysFunc,
+		"length":     lengthFunc,
+		"lookup":     lookupFunc,
+		"lower":      stdlib.LowerFunc,
+		"max":        stdlib.MaxFunc,
+		"min":        stdlib.MinFunc,
+		"regexmatch": regexMatchFunc,
+		"startswith": startsWithFunc,
+		"strlen":     stdlib.StrlenFunc,
+		"substr":     stdlib.SubstrFunc,
+		"upper":      stdlib.UpperFunc,
+	}
+}
+
+// elements returns the elements of a list, set or tuple. A null collection
+// has no elements.
+func elements(v cty.Value) ([]cty.Value, error) {
+	if v.IsNull() {
+		return nil, nil
+	}
+	ty := v.Type()
+	if !ty.IsListType() && !ty.IsSetType() && !ty.IsTupleType() {
+		return nil, fmt.Errorf("expected a list, got %s", ty.FriendlyName())
+	}
+
+	var elems []cty.Value
+	for it := v.ElementIterator(); it.Next(); {
+		_, e := it.Element()
+		elems = append(elems, e)
+	}
+	return elems, nil
+}
+
+// boolReduce returns a function reducing a list of booleans.
+func boolReduce(init bool) function.Function {
+	return function.New(&function.Spec{
+		Params: []function.Parameter{
+			{Name: "list", Type: cty.DynamicPseudoType, AllowNull: true},
+		},
+		Type: function.StaticReturnType(cty.Bool),
+		Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+			elems, err := elements(args[0])
+			if err != nil {
+				return cty.NilVal, err
+			}
+			for _, e := range elems {
+				if e.IsNull() || e.Type() != cty.Bool {
+					return cty.NilVal, fmt.Errorf("expected a list of booleans")
+				}
+				if e.True() != init {
+					return cty.BoolVal(!init), nil
+				}
+			}
+			return cty.BoolVal(init), nil
+		},
+	})
+}
+
+// allTrueFunc returns whether all the elements of a list are true. It returns
+// true for an empty list.
+var allTrueFunc = boolReduce(true)
+
+// anyTrueFunc returns whether any element of a list is true. It returns
+// false for an empty list.
+var anyTrueFunc = boolReduce(false)
+
+// containsFunc returns whether a list contains a value.
+var containsFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{Name: "list", Type: cty.DynamicPseudoType, AllowNull: true},
+		{Name: "value", Type: cty.DynamicPseudoType, AllowNull: true},
+	},
+	Type: function.StaticReturnType(cty.Bool),
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		elems, err := elements(args[0])
+		if err != nil {
+			return cty.NilVal, err
+		}
+		for _, e := range elems {
+			if e.RawEquals(args[1]) {
+				return cty.True, nil
+			}
+		}
+		return cty.False, nil
+	},
+})
+
+// flattenFunc flattens nested lists into a single list.
+var flattenFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{Name: "list", Type: cty.DynamicPseudoType, AllowNull: true},
+	},
+	Type: function.StaticReturnType(cty.DynamicPseudoType),
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		var flat []cty.Value
+		var walk func(v cty.Value) error
+		walk = func(v cty.Value) error {
+			elems, err := elements(v)
+			if err != nil {
+				return err
+			}
+			for _, e := range elems {
+				ty := e.Type()
+				if !e.IsNull() && (ty.IsListType() || ty.IsSetType() || ty.IsTupleType()) {
+					if err := walk(e); err != nil {
+						return err
+					}
+					continue
+				}
+				flat = append(flat, e)
+			}
+			return nil
+		}
+		if err := walk(args[0]); err != nil {
+			return cty.NilVal, err
+		}
+		if len(flat) == 0 {
+			return cty.EmptyTupleVal, nil
+		}
+		return cty.TupleVal(flat), nil
+	},
+})
+
+// keysFunc returns the sorted keys of a map or object. A null map has no
+// keys.
+var keysFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{Name: "map", Type: cty.DynamicPseudoType, AllowNull: true},
+	},
+	Type: function.StaticReturnType(cty.List(cty.String)),
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		m := args[0]
+		if m.IsNull() {
+			return cty.ListValEmpty(cty.String), nil
+		}
+
+		var keys []string
+		switch ty := m.Type(); {
+		case ty.IsObjectType():
+			for k := range ty.AttributeTypes() {
+				keys = append(keys, k)
+			}
+		case ty.IsMapType():
+			for k := range m.AsValueMap() {
+				keys = append(keys, k)
+			}
+		default:
+			return cty.NilVal, fmt.Errorf("expected a map, got %s", ty.FriendlyName())
+		}
+		if len(keys) == 0 {
+			return cty.ListValEmpty(cty.String), nil
+		}
+
+		sort.Strings(keys)
+		vals := make([]cty.Value, 0, len(keys))
+		for _, k := range keys {
+			vals = append(vals, cty.StringVal(k))
+		}
+		return cty.ListVal(vals), nil
+	},
+})
+
+// lengthFunc returns the length of a string, list or map. Null values have a
+// length of zero.
+var lengthFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{Name: "value", Type: cty.DynamicPseudoType, AllowNull: true},
+	},
+	Type: function.StaticReturnType(cty.Number),
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		v := args[0]
+		if v.IsNull() {
+			return cty.NumberIntVal(0), nil
+		}
+		switch ty := v.Type(); {
+		case ty == cty.String:
+			return stdlib.Strlen(v)
+		case ty.IsObjectType():
+			return cty.NumberIntVal(int64(len(ty.AttributeTypes()))), nil
+		case ty.IsListType(), ty.IsSetType(), ty.IsTupleType(), ty.IsMapType():
+			return v.Length(), nil
+		default:
+			return cty.NilVal, fmt.Errorf("can't compute the length of %s", ty.FriendlyName())
+		}
+	},
+})
+
+// lookupFunc returns the value of a key of a map or object, or the default
+// if the map is null or doesn't have the key.
+var lookupFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{Name: "map", Type: cty.DynamicPseudoType, AllowNull: true},
+		{Name: "key", Type: cty.String},
+		{Name: "default", Type: cty.DynamicPseudoType, AllowNull: true},
+	},
+	Type: function.StaticReturnType(cty.DynamicPseudoType),
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		m, key, def := args[0], args[1].AsString(), args[2]
+		if m.IsNull() {
+			return def, nil
+		}
+
+		var v cty.Value
+		switch ty := m.Type(); {
+		case ty.IsObjectType():
+			if !ty.HasAttribute(key) {
+				return def, nil
+			}
+			v = m.GetAttr(key)
+		case ty.IsMapType():
+			if !m.HasIndex(cty.StringVal(key)).True() {
+				return def, nil
+			}
+			v = m.Index(cty.StringVal(key))
+		default:
+			return cty.NilVal, fmt.Errorf("expected a map, got %s", ty.FriendlyName())
+		}
+
+		if v.IsNull() {
+			return def, nil
+		}
+		return v, nil
+	},
+})
+
+// stringPredicate returns a function applying a predicate to two strings.
+func stringPredicate(a, b string, pred func(a, b string) (bool, error)) function.Function {
+	return function.New(&function.Spec{
+		Params: []function.Parameter{
+			{Name: a, Type: cty.String},
+			{Name: b, Type: cty.String},
+		},
+		Type: function.StaticReturnType(cty.Bool),
+		Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+			ok, err := pred(args[0].AsString(), args[1].AsString())
+			if err != nil {
+				return cty.NilVal, err
+			}
+			return cty.BoolVal(ok), nil
+		},
+	})
+}
+
+// startsWithFunc returns whether a string starts with a prefix.
+var startsWithFunc = stringPredicate("str", "prefix", func(s, prefix string) (bool, error) {
+	return strings.HasPrefix(s, prefix), nil
+})
+
+// endsWithFunc returns whether a string ends with a suffix.
+var endsWithFunc = stringPredicate("str", "suffix", func(s, suffix string) (bool, error) {
+	return strings.HasSuffix(s, suffix), nil
+})
+
+// regexMatchFunc returns whether a string matches a regular expression.
+var regexMatchFunc = stringPredicate("pattern", "str", func(pattern, s string) (bool, error) {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return false, fmt.Errorf("invalid regular expression %q: %v", pattern, err)
+	}
+	return re.MatchString(s), nil
+})
@@ -0,0 +1,245 @@
+// Package policy implements the language of the job admission policies.
+//
+// A policy is written in HCL2 and made of one or more rule blocks. Each rule
+// has a condition, an HCL2 expression evaluated against the submitted job,
+// and an optional message returned when the condition is false:
+//
+//	rule "team-label" {
+//	  condition = lookup(job.Meta, "team", "") != ""
+//	  message   = "jobs must set the team meta key"
+//	}
+//
+//	rule "registry" {
+//	  condition = alltrue(flatten([
+//	    for tg in job.TaskGroups : [
+//	      for t in tg.Tasks : startswith(lookup(t.Config, "image", ""), "registry.example.com/")
+//	      if t.Driver == "docker"
+//	    ]
+//	  ]))
+//	  message = "docker images must be pulled from registry.example.com"
+//	}
+//
+// The job is available as the "job" variable, with the same fields as its
+// JSON representation. A policy passes when the conditions of all its rules
+// are true. A condition that can't be evaluated, for example because it reads
+// a field that is null, fails its rule.
+package policy
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/hcl2/hcl"
+	"github.com/hashicorp/hcl2/hcl/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// Policy is a parsed admission policy.
+type Policy struct {
+	rules []*rule
+}
+
+// rule is a rule of a policy.
+type rule struct {
+	name      string
+	condition hcl.Expression
+	message   hcl.Expression
+}
+
+// Violation is a rule of a policy whose condition is not met.
+type Violation struct {
+	// Rule is the name of the rule.
+	Rule string
+
+	// Message is the message of the rule, or the reason its condition
+	// couldn't be evaluated.
+	Message string
+}
+
+func (v *Violation) String() string {
+	return fmt.Sprintf("rule %q: %s", v.Rule, v.Message)
+}
+
+// Parse parses the source of a policy. The name of the policy is used in
+// error messages.
+func Parse(name, src string) (*Policy, error) {
+	file, diags := hclsyntax.ParseConfig([]byte(src), name, hcl.Pos{Line: 1, Column: 1})
+	if diags.HasErrors() {
+		return nil, diags
+	}
+	body := file.Body.(*hclsyntax.Body)
+
+	for _, attr := range body.Attributes {
+		return nil, fmt.Errorf("%s: unexpected attribute %q, only rule blocks are allowed",
+			attr.SrcRange, attr.Name)
+	}
+
+	p := &Policy{}
+	seen := make(map[string]struct{}, len(body.Blocks))
+	for _, block := range body.Blocks {
+		if block.Type != "rule" {
+			return nil, fmt.Errorf("%s: unexpected block %q, only rule blocks are allowed",
+				block.DefRange(), block.Type)
+		}
+		if len(block.Labels) != 1 {
+			return nil, fmt.Errorf("%s: rule blocks must have exactly one label, the rule name",
+				block.DefRange())
+		}
+
+		r := &rule{name: block.Labels[0]}
+		if _, ok := seen[r.name]; ok {
+			return nil, fmt.Errorf("%s: duplicate rule %q", block.DefRange(), r.name)
+		}
+		seen[r.name] = struct{}{}
+
+		if len(block.Body.Blocks) != 0 {
+			return nil, fmt.Errorf("%s: rule %q can't contain blocks", block.DefRange(), r.name)
+		}
+		for _, attr := range block.Body.Attributes {
+			switch attr.Name {
+			case "condition":
+				r.condition = attr.Expr
+			case "message":
+				r.message = attr.Expr
+			default:
+				return nil, fmt.Errorf("%s: invalid key %q in rule %q", attr.SrcRange, attr.Name, r.name)
+			}
+		}
+		if r.condition == nil {
+			return nil, fmt.Errorf("%s: rule %q is missing a condition", block.DefRange(), r.name)
+		}
+
+		// Only the job can be referenced
+		for _, traversal := range r.condition.Variables() {
+			if root := traversal.RootName(); root != "job" {
+				return nil, fmt.Errorf("%s: unknown variable %q, only job can be referenced",
+					traversal.SourceRange(), root)
+			}
+		}
+
+		p.rules = append(p.rules, r)
+	}
+
+	if len(p.rules) == 0 {
+		return nil, fmt.Errorf("policy %q must contain at least one rule", name)
+	}
+
+	return p, nil
+}
+
+// Eval evaluates the rules of the policy against the job, which must be
+// encodable to JSON, and returns the violated rules.
+func (p *Policy) Eval(job interface{}) ([]*Violation, error) {
+	val, err := jsonValue(job)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert job: %v", err)
+	}
+
+	ctx := &hcl.EvalContext{
+		Variables: map[string]cty.Value{
+			"job": val,
+		},
+		Functions: Functions(),
+	}
+
+	var violations []*Violation
+	for _, r := range p.rules {
+		if v := r.eval(ctx); v != nil {
+			violations = append(violations, v)
+		}
+	}
+	return violations, nil
+}
+
+// eval evaluates the condition of the rule and returns a violation if it is
+// not met.
+func (r *rule) eval(ctx *hcl.EvalContext) *Violation {
+	cond, diags := r.condition.Value(ctx)
+	if diags.HasErrors() {
+		return &Violation{Rule: r.name, Message: fmt.Sprintf("failed to evaluate condition: %v", diags)}
+	}
+	if cond.IsNull() || !cond.IsKnown() || cond.Type() != cty.Bool {
+		return &Violation{Rule: r.name, Message: "condition must be a boolean"}
+	}
+	if cond.True() {
+		return nil
+	}
+
+	return &Violation{Rule: r.name, Message: r.evalMessage(ctx)}
+}
+
+// evalMessage returns the message of a violated rule.
+func (r *rule) evalMessage(ctx *hcl.EvalContext) string {
+	if r.message == nil {
+		return "condition is false"
+	}
+
+	msg, diags := r.message.Value(ctx)
+	if diags.HasErrors() {
+		return fmt.Sprintf("condition is false, failed to evaluate message: %v", diags)
+	}
+	if msg.IsNull() || !msg.IsKnown() || msg.Type() != cty.String {
+		return "condition is false"
+	}
+	return msg.AsString()
+}
+
+// jsonValue converts a value to the cty value of its JSON representation.
+func jsonValue(v interface{}) (cty.Value, error) {
+	buf, err := json.Marshal(v)
+	if err != nil {
+		return cty.NilVal, err
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(buf))
+	dec.UseNumber()
+	var raw interface{}
+	if err := dec.Decode(&raw); err != nil {
+		return cty.NilVal, err
+	}
+	return ctyValue(raw)
+}
+
+// ctyValue converts a decoded JSON value to a cty value. Objects are
+// converted to cty objects and arrays to cty tuples.
+func ctyValue(raw interface{}) (cty.Value, error) {
+	switch v := raw.(type) {
+	case nil:
+		return cty.NullVal(cty.DynamicPseudoType), nil
+	case bool:
+		return cty.BoolVal(v), nil
+	case string:
+		return cty.StringVal(v), nil
+	case json.Number:
+		return cty.ParseNumberVal(v.String())
+	case []interface{}:
+		if len(v) == 0 {
+			return cty.EmptyTupleVal, nil
+		}
+		elems := make([]cty.Value, len(v))
+		for i, e := range v {
+			val, err := ctyValue(e)
+			if err != nil {
+				return cty.NilVal, err
+			}
+			elems[i] = val
+		}
+		return cty.TupleVal(elems), nil
+	case map[string]interface{}:
+		if len(v) == 0 {
+			return cty.EmptyObjectVal, nil
+		}
+		attrs := make(map[string]cty.Value, len(v))
+		for k, e := range v {
+			val, err := ctyValue(e)
+			if err != nil {
+				return cty.NilVal, err
+			}
+			attrs[k] = val
+		}
+		return cty.ObjectVal(attrs), nil
+	default:
+		return cty.NilVal, fmt.Errorf("unexpected JSON value of type %T", raw)
+	}
+}
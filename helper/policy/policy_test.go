@@ -0,0 +1,207 @@
+package policy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type testTask struct {
+	Name   string
+	Driver string
+	Config map[string]interface{}
+}
+
+type testGroup struct {
+	Name  string
+	Count int
+	Tasks []*testTask
+}
+
+type testJob struct {
+	ID         string
+	Meta       map[string]string
+	TaskGroups []*testGroup
+}
+
+func testJobValue() *testJob {
+	return &testJob{
+		ID:   "example",
+		Meta: map[string]string{"team": "payments"},
+		TaskGroups: []*testGroup{
+			{
+				Name:  "web",
+				Count: 3,
+				Tasks: []*testTask{
+					{
+						Name:   "server",
+						Driver: "docker",
+						Config: map[string]interface{}{"image": "registry.example.com/web:1.0"},
+					},
+					{
+						Name:   "logs",
+						Driver: "exec",
+						Config: map[string]interface{}{"command": "/bin/logs"},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestPolicy_Eval(t *testing.T) {
+	cases := []struct {
+		Name      string
+		Condition string
+		Violated  bool
+		Message   string
+	}{
+		{
+			Name:      "attribute",
+			Condition: `job.ID == "example"`,
+		},
+		{
+			Name:      "lookup",
+			Condition: `lookup(job.Meta, "team", "") != ""`,
+		},
+		{
+			Name:      "lookup missing",
+			Condition: `lookup(job.Meta, "owner", "none") == "none"`,
+		},
+		{
+			Name:      "contains keys",
+			Condition: `contains(keys(job.Meta), "team")`,
+		},
+		{
+			Name: "nested for",
+			Condition: `alltrue(flatten([
+				for tg in job.TaskGroups : [
+					for t in tg.Tasks : startswith(lookup(t.Config, "image", ""), "registry.example.com/")
+					if t.Driver == "docker"
+				]
+			]))`,
+		},
+		{
+			Name:      "anytrue",
+			Condition: `anytrue([for tg in job.TaskGroups : tg.Count > 5])`,
+			Violated:  true,
+			Message:   "condition is false",
+		},
+		{
+			Name:      "regexmatch",
+			Condition: `regexmatch("^[a-z]+$", job.ID)`,
+		},
+		{
+			Name:      "length",
+			Condition: `length(job.TaskGroups[0].Tasks) == 2 && length(job.ID) == 7`,
+		},
+		{
+			Name:      "missing attribute",
+			Condition: `job.Missing == "x"`,
+			Violated:  true,
+			Message:   "failed to evaluate condition",
+		},
+		{
+			Name:      "not a boolean",
+			Condition: `job.ID`,
+			Violated:  true,
+			Message:   "condition must be a boolean",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			src := "rule \"test\" {\n  condition = " + c.Condition + "\n}\n"
+			p, err := Parse("test.hcl", src)
+			require.NoError(t, err)
+
+			violations, err := p.Eval(testJobValue())
+			require.NoError(t, err)
+			if !c.Violated {
+				require.Empty(t, violations)
+				return
+			}
+			require.Len(t, violations, 1)
+			require.Equal(t, "test", violations[0].Rule)
+			require.Contains(t, violations[0].Message, c.Message)
+		})
+	}
+}
+
+func TestPolicy_Eval_Messages(t *testing.T) {
+	src := `
+rule "count" {
+  condition = alltrue([for tg in job.TaskGroups : tg.Count <= 2])
+  message   = format("job %s runs more than 2 instances of a group", job.ID)
+}
+
+rule "team" {
+  condition = lookup(job.Meta, "team", "") == "payments"
+  message   = "not reported"
+}
+`
+	p, err := Parse("limits.hcl", src)
+	require.NoError(t, err)
+
+	violations, err := p.Eval(testJobValue())
+	require.NoError(t, err)
+	require.Len(t, violations, 1)
+	require.Equal(t, `rule "count": job example runs more than 2 instances of a group`, violations[0].String())
+}
+
+func TestPolicy_Parse_Errors(t *testing.T) {
+	cases := []struct {
+		Name string
+		Src  string
+		Err  string
+	}{
+		{
+			Name: "syntax",
+			Src:  `rule "a" {`,
+			Err:  "test.hcl:1",
+		},
+		{
+			Name: "no rules",
+			Src:  ``,
+			Err:  "must contain at least one rule",
+		},
+		{
+			Name: "attribute",
+			Src:  `condition = true`,
+			Err:  "only rule blocks are allowed",
+		},
+		{
+			Name: "other block",
+			Src:  `main {}`,
+			Err:  "only rule blocks are allowed",
+		},
+		{
+			Name: "missing condition",
+			Src:  `rule "a" { message = "x" }`,
+			Err:  "missing a condition",
+		},
+		{
+			Name: "invalid key",
+			Src:  "rule \"a\" {\n condition = true\n level = \"x\"\n}",
+			Err:  `invalid key "level"`,
+		},
+		{
+			Name: "duplicate",
+			Src:  "rule \"a\" {\n condition = true\n}\nrule \"a\" {\n condition = true\n}",
+			Err:  `duplicate rule "a"`,
+		},
+		{
+			Name: "unknown variable",
+			Src:  "rule \"a\" {\n condition = var.x\n}",
+			Err:  `unknown variable "var"`,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			_, err := Parse("test.hcl", c.Src)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), c.Err)
+		})
+	}
+}
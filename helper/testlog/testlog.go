@@ -10,6 +10,7 @@ import (
 	"os"
 
 	hclog "github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/nomad/helper/logging"
 )
 
 // UseStdout returns true if NOMAD_TEST_STDOUT=1 and sends logs to stdout.
@@ -72,7 +73,7 @@ func Logger(t LogPrinter) *log.Logger {
 }
 
 //HCLogger returns a new test hc-logger.
-func HCLogger(t LogPrinter) hclog.Logger {
+func HCLogger(t LogPrinter) logging.InterceptLogger {
 	level := hclog.Trace
 	envLogLevel := os.Getenv("NOMAD_TEST_LOG_LEVEL")
 	if envLogLevel != "" {
@@ -83,7 +84,7 @@ func HCLogger(t LogPrinter) hclog.Logger {
 		Output:          NewWriter(t),
 		IncludeLocation: true,
 	}
-	return hclog.New(opts)
+	return logging.NewInterceptLogger(opts)
 }
 
 type prefixStdout struct {
@@ -54,6 +54,8 @@ func parseGroups(result *api.Job, list *ast.ObjectList) error {
 			"network",
 			"service",
 			"volume",
+			"scaling",
+			"max_client_disconnect",
 		}
 		if err := helper.CheckHCLKeys(listVal, valid); err != nil {
 			return multierror.Prefix(err, fmt.Sprintf("'%s' ->", n))
@@ -76,11 +78,20 @@ func parseGroups(result *api.Job, list *ast.ObjectList) error {
 		delete(m, "network")
 		delete(m, "service")
 		delete(m, "volume")
+		delete(m, "scaling")
 
 		// Build the group with the basic decode
 		var g api.TaskGroup
 		g.Name = helper.StringToPtr(n)
-		if err := mapstructure.WeakDecode(m, &g); err != nil {
+		dec, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+			DecodeHook:       mapstructure.StringToTimeDurationHookFunc(),
+			WeaklyTypedInput: true,
+			Result:           &g,
+		})
+		if err != nil {
+			return err
+		}
+		if err := dec.Decode(m); err != nil {
 			return err
 		}
 
@@ -163,6 +174,13 @@ func parseGroups(result *api.Job, list *ast.ObjectList) error {
 			}
 		}
 
+		// Parse the scaling policy
+		if o := listVal.Filter("scaling"); len(o.Items) > 0 {
+			if err := parseScalingPolicy(&g.Scaling, o); err != nil {
+				return multierror.Prefix(err, fmt.Sprintf("'%s', scaling ->", n))
+			}
+		}
+
 		// Parse any volume declarations
 		if o := listVal.Filter("volume"); len(o.Items) > 0 {
 			if err := parseVolumes(&g.Volumes, o); err != nil {
@@ -242,6 +260,61 @@ func parseEphemeralDisk(result **api.EphemeralDisk, list *ast.ObjectList) error
 	return nil
 }
 
+func parseScalingPolicy(result **api.ScalingPolicy, list *ast.ObjectList) error {
+	list = list.Elem()
+	if len(list.Items) > 1 {
+		return fmt.Errorf("only one 'scaling' block allowed")
+	}
+
+	// Get our scaling object
+	obj := list.Items[0]
+
+	// We need this later
+	var listVal *ast.ObjectList
+	if ot, ok := obj.Val.(*ast.ObjectType); ok {
+		listVal = ot.List
+	} else {
+		return fmt.Errorf("scaling should be an object")
+	}
+
+	// Check for invalid keys
+	valid := []string{
+		"min",
+		"max",
+		"enabled",
+		"policy",
+	}
+	if err := helper.CheckHCLKeys(obj.Val, valid); err != nil {
+		return err
+	}
+
+	var m map[string]interface{}
+	if err := hcl.DecodeObject(&m, obj.Val); err != nil {
+		return err
+	}
+	delete(m, "policy")
+
+	var policy api.ScalingPolicy
+	if err := mapstructure.WeakDecode(m, &policy); err != nil {
+		return err
+	}
+
+	// The policy is opaque to Nomad and decoded as is
+	if o := listVal.Filter("policy"); len(o.Items) > 0 {
+		if len(o.Elem().Items) > 1 {
+			return fmt.Errorf("only one 'policy' block allowed per 'scaling' block")
+		}
+		var pm map[string]interface{}
+		if err := hcl.DecodeObject(&pm, o.Elem().Items[0].Val); err != nil {
+			return err
+		}
+		policy.Policy = pm
+	}
+
+	*result = &policy
+	return nil
+}
+
 func parseRestartPolicy(final **api.RestartPolicy, list *ast.ObjectList) error {
 	list = list.Elem()
 	if len(list.Items) > 1 {
@@ -0,0 +1,389 @@
+package jobspec
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl2/hcl"
+	"github.com/hashicorp/hcl2/hcl/hclsyntax"
+	hcljson "github.com/hashicorp/hcl2/hcl/json"
+	"github.com/hashicorp/nomad/api"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/convert"
+)
+
+// HCL2Config configures the parsing of a job file written in HCL2.
+type HCL2Config struct {
+	// Filename is the name of the job file used in error messages.
+	Filename string
+
+	// ArgVars are variable values in the "name=value" form, as given by the
+	// -var flag. They take precedence over the values of the VarFiles.
+	ArgVars []string
+
+	// VarFiles are the paths of HCL or JSON files assigning variable values.
+	// Later files take precedence over earlier ones.
+	VarFiles []string
+}
+
+// ParseHCL2 parses a job file written in HCL2. The file can declare input
+// variables with variable blocks and local values with locals blocks, call
+// functions and generate blocks with dynamic blocks. Interpolations of Nomad
+// runtime variables, such as "${attr.kernel.name}" or "${NOMAD_ALLOC_DIR}",
+// are left as is. The job is evaluated into its equivalent HCL1 form before
+// being parsed, so the returned job is identical to the one parsed from an
+// equivalent HCL1 file.
+func ParseHCL2(r io.Reader, config *HCL2Config) (*api.Job, error) {
+	if config == nil {
+		config = &HCL2Config{}
+	}
+
+	src, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	hcl1, err := renderHCL2(src, config)
+	if err != nil {
+		return nil, err
+	}
+
+	return Parse(bytes.NewReader(hcl1))
+}
+
+// renderHCL2 evaluates a job file written in HCL2 and returns its equivalent
+// HCL1 source.
+func renderHCL2(src []byte, config *HCL2Config) ([]byte, error) {
+	filename := config.Filename
+	if filename == "" {
+		filename = "job.hcl"
+	}
+
+	file, diags := hclsyntax.ParseConfig(src, filename, hcl.Pos{Line: 1, Column: 1})
+	if diags.HasErrors() {
+		return nil, diags
+	}
+	body := file.Body.(*hclsyntax.Body)
+
+	if len(body.Attributes) != 0 {
+		for _, attr := range body.Attributes {
+			return nil, fmt.Errorf("%s: unexpected attribute %q, only blocks are allowed at the top level",
+				attr.SrcRange, attr.Name)
+		}
+	}
+
+	// Separate the variable and locals blocks from the job
+	var variables, locals, blocks []*hclsyntax.Block
+	for _, block := range body.Blocks {
+		switch block.Type {
+		case "variable":
+			variables = append(variables, block)
+		case "locals":
+			locals = append(locals, block)
+		default:
+			blocks = append(blocks, block)
+		}
+	}
+
+	vars, err := parseHCL2Variables(variables, config)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := &hcl.EvalContext{
+		Variables: map[string]cty.Value{
+			"var": vars,
+		},
+		Functions: hcl2Functions(),
+	}
+
+	r := &hcl2Renderer{src: src, filename: filename}
+
+	localVals, err := r.locals(locals, ctx)
+	if err != nil {
+		return nil, err
+	}
+	ctx.Variables["local"] = localVals
+
+	for _, block := range blocks {
+		if err := r.block(block, ctx, 0); err != nil {
+			return nil, err
+		}
+	}
+
+	return r.buf.Bytes(), nil
+}
+
+// hcl2Variable is an input variable declared by a variable block.
+type hcl2Variable struct {
+	name       string
+	typ        cty.Type
+	def        cty.Value
+	hasDefault bool
+}
+
+// parseHCL2Variables parses the variable blocks and returns the object of
+// the variable values, assigned from the variable files, the -var flags and
+// the variable defaults in order of precedence.
+func parseHCL2Variables(blocks []*hclsyntax.Block, config *HCL2Config) (cty.Value, error) {
+	declared := make(map[string]*hcl2Variable, len(blocks))
+	for _, block := range blocks {
+		if len(block.Labels) != 1 {
+			return cty.NilVal, fmt.Errorf("%s: variable blocks must have exactly one label, the variable name",
+				block.DefRange())
+		}
+		name := block.Labels[0]
+		if _, ok := declared[name]; ok {
+			return cty.NilVal, fmt.Errorf("%s: duplicate variable %q", block.DefRange(), name)
+		}
+		if !hclsyntax.ValidIdentifier(name) {
+			return cty.NilVal, fmt.Errorf("%s: invalid variable name %q", block.DefRange(), name)
+		}
+		if len(block.Body.Blocks) != 0 {
+			return cty.NilVal, fmt.Errorf("%s: variable %q can't contain blocks", block.DefRange(), name)
+		}
+
+		v := &hcl2Variable{
+			name: name,
+			typ:  cty.DynamicPseudoType,
+		}
+		for _, attr := range block.Body.Attributes {
+			switch attr.Name {
+			case "description":
+			case "type":
+				typ, diags := hcl2TypeExpr(attr.Expr)
+				if diags.HasErrors() {
+					return cty.NilVal, diags
+				}
+				v.typ = typ
+			case "default":
+				val, diags := attr.Expr.Value(&hcl.EvalContext{Functions: hcl2Functions()})
+				if diags.HasErrors() {
+					return cty.NilVal, diags
+				}
+				v.def = val
+				v.hasDefault = true
+			default:
+				return cty.NilVal, fmt.Errorf("%s: invalid key %q in variable %q",
+					attr.SrcRange, attr.Name, name)
+			}
+		}
+
+		if v.hasDefault {
+			def, err := convert.Convert(v.def, v.typ)
+			if err != nil {
+				return cty.NilVal, fmt.Errorf("invalid default value for variable %q: %v", name, err)
+			}
+			v.def = def
+		}
+
+		declared[name] = v
+	}
+
+	values := make(map[string]cty.Value, len(declared))
+
+	// Apply the variable files
+	for _, path := range config.VarFiles {
+		fileVals, err := parseHCL2VarFile(path)
+		if err != nil {
+			return cty.NilVal, err
+		}
+		for name, val := range fileVals {
+			v, ok := declared[name]
+			if !ok {
+				return cty.NilVal, fmt.Errorf("%s: undefined variable %q", path, name)
+			}
+			converted, err := convert.Convert(val, v.typ)
+			if err != nil {
+				return cty.NilVal, fmt.Errorf("%s: invalid value for variable %q: %v", path, name, err)
+			}
+			values[name] = converted
+		}
+	}
+
+	// Apply the -var flags
+	for _, arg := range config.ArgVars {
+		idx := strings.Index(arg, "=")
+		if idx < 1 {
+			return cty.NilVal, fmt.Errorf("invalid variable %q, expected the name=value form", arg)
+		}
+		name, raw := arg[:idx], arg[idx+1:]
+		v, ok := declared[name]
+		if !ok {
+			return cty.NilVal, fmt.Errorf("undefined variable %q", name)
+		}
+		val, err := parseHCL2VarArg(name, raw, v.typ)
+		if err != nil {
+			return cty.NilVal, err
+		}
+		values[name] = val
+	}
+
+	// Apply the defaults
+	names := make([]string, 0, len(declared))
+	for name := range declared {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if _, ok := values[name]; ok {
+			continue
+		}
+		v := declared[name]
+		if !v.hasDefault {
+			return cty.NilVal, fmt.Errorf("missing value for variable %q", name)
+		}
+		values[name] = v.def
+	}
+
+	return cty.ObjectVal(values), nil
+}
+
+// parseHCL2VarFile parses a file assigning variable values.
+func parseHCL2VarFile(path string) (map[string]cty.Value, error) {
+	src, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read variable file: %v", err)
+	}
+
+	var file *hcl.File
+	var diags hcl.Diagnostics
+	if filepath.Ext(path) == ".json" {
+		file, diags = hcljson.Parse(src, path)
+	} else {
+		file, diags = hclsyntax.ParseConfig(src, path, hcl.Pos{Line: 1, Column: 1})
+	}
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	attrs, diags := file.Body.JustAttributes()
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	values := make(map[string]cty.Value, len(attrs))
+	for name, attr := range attrs {
+		val, diags := attr.Expr.Value(&hcl.EvalContext{Functions: hcl2Functions()})
+		if diags.HasErrors() {
+			return nil, diags
+		}
+		values[name] = val
+	}
+	return values, nil
+}
+
+// parseHCL2VarArg parses the value of a -var flag. Values of primitive
+// variables are taken literally while values of collection and structural
+// variables are parsed as HCL expressions.
+func parseHCL2VarArg(name, raw string, typ cty.Type) (cty.Value, error) {
+	var val cty.Value
+	if typ.IsPrimitiveType() || typ == cty.DynamicPseudoType {
+		val = cty.StringVal(raw)
+	} else {
+		expr, diags := hclsyntax.ParseExpression([]byte(raw), "<value for var."+name+">", hcl.Pos{Line: 1, Column: 1})
+		if diags.HasErrors() {
+			return cty.NilVal, diags
+		}
+		val, diags = expr.Value(&hcl.EvalContext{Functions: hcl2Functions()})
+		if diags.HasErrors() {
+			return cty.NilVal, diags
+		}
+	}
+
+	converted, err := convert.Convert(val, typ)
+	if err != nil {
+		return cty.NilVal, fmt.Errorf("invalid value for variable %q: %v", name, err)
+	}
+	return converted, nil
+}
+
+// hcl2TypeExpr parses a type constraint such as string or map(list(number)).
+func hcl2TypeExpr(expr hcl.Expression) (cty.Type, hcl.Diagnostics) {
+	invalid := func(detail string) (cty.Type, hcl.Diagnostics) {
+		return cty.DynamicPseudoType, hcl.Diagnostics{{
+			Severity: hcl.DiagError,
+			Summary:  "Invalid type specification",
+			Detail:   detail,
+			Subject:  expr.Range().Ptr(),
+		}}
+	}
+
+	switch kw := hcl.ExprAsKeyword(expr); kw {
+	case "string":
+		return cty.String, nil
+	case "number":
+		return cty.Number, nil
+	case "bool":
+		return cty.Bool, nil
+	case "any":
+		return cty.DynamicPseudoType, nil
+	case "":
+	default:
+		return invalid(fmt.Sprintf("The keyword %q is not a valid type.", kw))
+	}
+
+	call, diags := hcl.ExprCall(expr)
+	if diags.HasErrors() {
+		return invalid("A type must be a primitive type keyword or a type constructor call.")
+	}
+	if len(call.Arguments) != 1 {
+		return invalid(fmt.Sprintf("The %s type constructor requires one argument.", call.Name))
+	}
+	arg := call.Arguments[0]
+
+	switch call.Name {
+	case "list", "set", "map":
+		ety, diags := hcl2TypeExpr(arg)
+		if diags.HasErrors() {
+			return cty.DynamicPseudoType, diags
+		}
+		switch call.Name {
+		case "list":
+			return cty.List(ety), nil
+		case "set":
+			return cty.Set(ety), nil
+		default:
+			return cty.Map(ety), nil
+		}
+	case "object":
+		pairs, diags := hcl.ExprMap(arg)
+		if diags.HasErrors() {
+			return invalid("The object type constructor requires an object of attribute types.")
+		}
+		atys := make(map[string]cty.Type, len(pairs))
+		for _, pair := range pairs {
+			name := hcl.ExprAsKeyword(pair.Key)
+			if name == "" {
+				return invalid("Object attribute names must be identifiers.")
+			}
+			aty, diags := hcl2TypeExpr(pair.Value)
+			if diags.HasErrors() {
+				return cty.DynamicPseudoType, diags
+			}
+			atys[name] = aty
+		}
+		return cty.Object(atys), nil
+	case "tuple":
+		elems, diags := hcl.ExprList(arg)
+		if diags.HasErrors() {
+			return invalid("The tuple type constructor requires a list of element types.")
+		}
+		etys := make([]cty.Type, 0, len(elems))
+		for _, elem := range elems {
+			ety, diags := hcl2TypeExpr(elem)
+			if diags.HasErrors() {
+				return cty.DynamicPseudoType, diags
+			}
+			etys = append(etys, ety)
+		}
+		return cty.Tuple(etys), nil
+	default:
+		return invalid(fmt.Sprintf("%q is not a valid type constructor.", call.Name))
+	}
+}
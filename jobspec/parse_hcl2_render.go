@@ -0,0 +1,454 @@
+package jobspec
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl2/hcl"
+	"github.com/hashicorp/hcl2/hcl/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// hcl2Renderer evaluates the blocks of a job file written in HCL2 and writes
+// their equivalent HCL1 source.
+type hcl2Renderer struct {
+	// src is the source of the job file, used to copy the Nomad runtime
+	// interpolations as is.
+	src []byte
+
+	// filename is the name of the job file used in error messages.
+	filename string
+
+	buf bytes.Buffer
+}
+
+// locals evaluates the attributes of the locals blocks, in the order of their
+// references to each other, and returns the object of the local values.
+func (r *hcl2Renderer) locals(blocks []*hclsyntax.Block, ctx *hcl.EvalContext) (cty.Value, error) {
+	pending := make(map[string]*hclsyntax.Attribute)
+	for _, block := range blocks {
+		if len(block.Labels) != 0 || len(block.Body.Blocks) != 0 {
+			return cty.NilVal, fmt.Errorf("%s: locals blocks can only contain attributes", block.DefRange())
+		}
+		for name, attr := range block.Body.Attributes {
+			if _, ok := pending[name]; ok {
+				return cty.NilVal, fmt.Errorf("%s: duplicate local value %q", attr.SrcRange, name)
+			}
+			pending[name] = attr
+		}
+	}
+
+	values := make(map[string]cty.Value, len(pending))
+	for len(pending) != 0 {
+		progress := false
+
+		names := make([]string, 0, len(pending))
+		for name := range pending {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+	LOCALS:
+		for _, name := range names {
+			attr := pending[name]
+
+			// Wait for the local values this one refers to
+			for _, traversal := range attr.Expr.Variables() {
+				if traversal.RootName() != "local" || len(traversal) < 2 {
+					continue
+				}
+				step, ok := traversal[1].(hcl.TraverseAttr)
+				if !ok {
+					continue
+				}
+				if _, ok := values[step.Name]; ok {
+					continue
+				}
+				if _, ok := pending[step.Name]; !ok {
+					return cty.NilVal, fmt.Errorf("%s: undefined local value %q", traversal.SourceRange(), step.Name)
+				}
+				continue LOCALS
+			}
+
+			ctx.Variables["local"] = cty.ObjectVal(values)
+			val, err := r.eval(attr.Expr, ctx)
+			if err != nil {
+				return cty.NilVal, err
+			}
+			values[name] = val
+			delete(pending, name)
+			progress = true
+		}
+
+		if !progress {
+			return cty.NilVal, fmt.Errorf("cycle between the local values %s", strings.Join(names, ", "))
+		}
+	}
+
+	return cty.ObjectVal(values), nil
+}
+
+// block writes a block and its evaluated body.
+func (r *hcl2Renderer) block(block *hclsyntax.Block, ctx *hcl.EvalContext, indent int) error {
+	if block.Type == "dynamic" {
+		return r.dynamic(block, ctx, indent)
+	}
+
+	r.indent(indent)
+	r.buf.WriteString(block.Type)
+	for _, label := range block.Labels {
+		r.buf.WriteByte(' ')
+		r.buf.WriteString(hcl1Quote(label))
+	}
+	r.buf.WriteString(" {\n")
+	if err := r.body(block.Body, ctx, indent+1); err != nil {
+		return err
+	}
+	r.indent(indent)
+	r.buf.WriteString("}\n")
+	return nil
+}
+
+// body writes the evaluated attributes and the blocks of a body in their
+// source order.
+func (r *hcl2Renderer) body(body *hclsyntax.Body, ctx *hcl.EvalContext, indent int) error {
+	items := make([]hclsyntax.Node, 0, len(body.Attributes)+len(body.Blocks))
+	for _, attr := range body.Attributes {
+		items = append(items, attr)
+	}
+	for _, block := range body.Blocks {
+		items = append(items, block)
+	}
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].Range().Start.Byte < items[j].Range().Start.Byte
+	})
+
+	for _, item := range items {
+		switch item := item.(type) {
+		case *hclsyntax.Attribute:
+			val, err := r.eval(item.Expr, ctx)
+			if err != nil {
+				return err
+			}
+
+			// Null attributes are the same as unset ones
+			if val.IsNull() {
+				continue
+			}
+
+			r.indent(indent)
+			r.buf.WriteString(item.Name)
+			r.buf.WriteString(" = ")
+			if err := r.value(val, indent); err != nil {
+				return fmt.Errorf("%s: %v", item.SrcRange, err)
+			}
+			r.buf.WriteByte('\n')
+		case *hclsyntax.Block:
+			if err := r.block(item, ctx, indent); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// dynamic writes the blocks generated by a dynamic block, one per element of
+// its for_each collection.
+func (r *hcl2Renderer) dynamic(block *hclsyntax.Block, ctx *hcl.EvalContext, indent int) error {
+	if len(block.Labels) != 1 {
+		return fmt.Errorf("%s: dynamic blocks must have exactly one label, the type of the generated blocks",
+			block.DefRange())
+	}
+	blockType := block.Labels[0]
+	iterator := blockType
+
+	var forEach, labels hcl.Expression
+	for name, attr := range block.Body.Attributes {
+		switch name {
+		case "for_each":
+			forEach = attr.Expr
+		case "labels":
+			labels = attr.Expr
+		case "iterator":
+			iterator = hcl.ExprAsKeyword(attr.Expr)
+			if iterator == "" {
+				return fmt.Errorf("%s: the iterator must be an identifier", attr.SrcRange)
+			}
+		default:
+			return fmt.Errorf("%s: invalid key %q in dynamic block", attr.SrcRange, name)
+		}
+	}
+	if forEach == nil {
+		return fmt.Errorf("%s: dynamic block %q is missing for_each", block.DefRange(), blockType)
+	}
+
+	var content *hclsyntax.Body
+	for _, b := range block.Body.Blocks {
+		if b.Type != "content" || content != nil || len(b.Labels) != 0 {
+			return fmt.Errorf("%s: dynamic blocks must contain exactly one content block", b.DefRange())
+		}
+		content = b.Body
+	}
+	if content == nil {
+		return fmt.Errorf("%s: dynamic block %q is missing its content block", block.DefRange(), blockType)
+	}
+
+	collection, err := r.eval(forEach, ctx)
+	if err != nil {
+		return err
+	}
+	if collection.IsNull() {
+		return nil
+	}
+	ty := collection.Type()
+	if !ty.IsListType() && !ty.IsSetType() && !ty.IsTupleType() && !ty.IsMapType() && !ty.IsObjectType() {
+		return fmt.Errorf("%s: for_each must be a collection, not %s", forEach.Range(), ty.FriendlyName())
+	}
+
+	for it := collection.ElementIterator(); it.Next(); {
+		key, value := it.Element()
+
+		child := ctx.NewChild()
+		child.Variables = map[string]cty.Value{
+			iterator: cty.ObjectVal(map[string]cty.Value{
+				"key":   key,
+				"value": value,
+			}),
+		}
+
+		generated := &hclsyntax.Block{
+			Type: blockType,
+			Body: content,
+		}
+		if labels != nil {
+			labelVals, err := r.eval(labels, child)
+			if err != nil {
+				return err
+			}
+			if !labelVals.CanIterateElements() {
+				return fmt.Errorf("%s: labels must be a list of strings", labels.Range())
+			}
+			for lit := labelVals.ElementIterator(); lit.Next(); {
+				_, label := lit.Element()
+				if label.IsNull() || label.Type() != cty.String {
+					return fmt.Errorf("%s: labels must be a list of strings", labels.Range())
+				}
+				generated.Labels = append(generated.Labels, label.AsString())
+			}
+		}
+
+		if err := r.block(generated, child, indent); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// eval evaluates an expression. The template interpolations that only refer
+// to Nomad runtime variables, such as "${attr.kernel.name}", are interpolated
+// by Nomad at runtime and are kept as is.
+func (r *hcl2Renderer) eval(expr hcl.Expression, ctx *hcl.EvalContext) (cty.Value, error) {
+	if node, ok := expr.(hclsyntax.Expression); ok && r.hasRuntimeInterpolation(node, ctx) {
+		// The expression is shared by every evaluation of its block, such as
+		// the ones of a dynamic block, so the interpolations are replaced in a
+		// copy parsed again from the source.
+		rng := node.Range()
+		copied, diags := hclsyntax.ParseExpression(r.src[rng.Start.Byte:rng.End.Byte], r.filename, rng.Start)
+		if diags.HasErrors() {
+			return cty.NilVal, diags
+		}
+		hclsyntax.VisitAll(copied, func(node hclsyntax.Node) hcl.Diagnostics {
+			switch node := node.(type) {
+			case *hclsyntax.TemplateExpr:
+				for i, part := range node.Parts {
+					if lit, ok := r.runtimeInterpolation(part, ctx); ok {
+						node.Parts[i] = lit
+					}
+				}
+			case *hclsyntax.TemplateWrapExpr:
+				if lit, ok := r.runtimeInterpolation(node.Wrapped, ctx); ok {
+					node.Wrapped = lit
+				}
+			}
+			return nil
+		})
+		expr = copied
+	}
+
+	val, diags := expr.Value(ctx)
+	if diags.HasErrors() {
+		return cty.NilVal, diags
+	}
+	if !val.IsWhollyKnown() {
+		return cty.NilVal, fmt.Errorf("%s: value is not known", expr.Range())
+	}
+	return val, nil
+}
+
+// hasRuntimeInterpolation returns whether any template of the expression
+// contains a runtime interpolation.
+func (r *hcl2Renderer) hasRuntimeInterpolation(expr hclsyntax.Expression, ctx *hcl.EvalContext) bool {
+	found := false
+	hclsyntax.VisitAll(expr, func(node hclsyntax.Node) hcl.Diagnostics {
+		switch node := node.(type) {
+		case *hclsyntax.TemplateExpr:
+			for _, part := range node.Parts {
+				if _, ok := r.runtimeInterpolation(part, ctx); ok {
+					found = true
+				}
+			}
+		case *hclsyntax.TemplateWrapExpr:
+			if _, ok := r.runtimeInterpolation(node.Wrapped, ctx); ok {
+				found = true
+			}
+		}
+		return nil
+	})
+	return found
+}
+
+// runtimeInterpolation returns the literal "${...}" string of a template
+// interpolation if all the variables it refers to are Nomad runtime variables
+// unknown to the context and it calls no functions. Interpolations of other
+// variables are left to fail their evaluation.
+func (r *hcl2Renderer) runtimeInterpolation(expr hclsyntax.Expression, ctx *hcl.EvalContext) (hclsyntax.Expression, bool) {
+	if _, ok := expr.(*hclsyntax.LiteralValueExpr); ok {
+		return nil, false
+	}
+
+	traversals := expr.Variables()
+	if len(traversals) == 0 {
+		return nil, false
+	}
+	for _, traversal := range traversals {
+		root := traversal.RootName()
+		if hcl2HasVariable(ctx, root) || !isRuntimeVariable(root) {
+			return nil, false
+		}
+	}
+
+	calls := false
+	hclsyntax.VisitAll(expr, func(node hclsyntax.Node) hcl.Diagnostics {
+		if _, ok := node.(*hclsyntax.FunctionCallExpr); ok {
+			calls = true
+		}
+		return nil
+	})
+	if calls {
+		return nil, false
+	}
+
+	rng := expr.Range()
+	return &hclsyntax.LiteralValueExpr{
+		Val:      cty.StringVal("${" + string(r.src[rng.Start.Byte:rng.End.Byte]) + "}"),
+		SrcRange: rng,
+	}, true
+}
+
+// isRuntimeVariable returns whether name is the root of a variable
+// interpolated by Nomad at runtime.
+func isRuntimeVariable(name string) bool {
+	switch name {
+	case "node", "attr", "meta", "env", "device":
+		return true
+	}
+	return strings.HasPrefix(name, "NOMAD_")
+}
+
+// hcl2HasVariable returns whether the context or its parents define the
+// variable.
+func hcl2HasVariable(ctx *hcl.EvalContext, name string) bool {
+	for ; ctx != nil; ctx = ctx.Parent() {
+		if _, ok := ctx.Variables[name]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// value writes a value in its HCL1 form.
+func (r *hcl2Renderer) value(val cty.Value, indent int) error {
+	if val.IsNull() {
+		return fmt.Errorf("null values are only allowed as attribute values")
+	}
+
+	ty := val.Type()
+	switch {
+	case ty == cty.String:
+		r.buf.WriteString(hcl1Quote(val.AsString()))
+	case ty == cty.Number:
+		r.buf.WriteString(val.AsBigFloat().Text('f', -1))
+	case ty == cty.Bool:
+		if val.True() {
+			r.buf.WriteString("true")
+		} else {
+			r.buf.WriteString("false")
+		}
+	case ty.IsListType() || ty.IsSetType() || ty.IsTupleType():
+		r.buf.WriteByte('[')
+		first := true
+		for it := val.ElementIterator(); it.Next(); {
+			if !first {
+				r.buf.WriteString(", ")
+			}
+			first = false
+			_, elem := it.Element()
+			if err := r.value(elem, indent); err != nil {
+				return err
+			}
+		}
+		r.buf.WriteByte(']')
+	case ty.IsMapType() || ty.IsObjectType():
+		r.buf.WriteString("{\n")
+		for it := val.ElementIterator(); it.Next(); {
+			key, elem := it.Element()
+			if elem.IsNull() {
+				continue
+			}
+			r.indent(indent + 1)
+			r.buf.WriteString(hcl1Quote(key.AsString()))
+			r.buf.WriteString(" = ")
+			if err := r.value(elem, indent+1); err != nil {
+				return err
+			}
+			r.buf.WriteByte('\n')
+		}
+		r.indent(indent)
+		r.buf.WriteByte('}')
+	default:
+		return fmt.Errorf("unsupported value of type %s", ty.FriendlyName())
+	}
+	return nil
+}
+
+func (r *hcl2Renderer) indent(n int) {
+	for i := 0; i < n; i++ {
+		r.buf.WriteString("  ")
+	}
+}
+
+// hcl1Quote returns the HCL1 quoted string literal of s.
+func hcl1Quote(s string) string {
+	var buf strings.Builder
+	buf.WriteByte('"')
+	for _, c := range s {
+		switch c {
+		case '"':
+			buf.WriteString(`\"`)
+		case '\\':
+			buf.WriteString(`\\`)
+		case '\n':
+			buf.WriteString(`\n`)
+		case '\r':
+			buf.WriteString(`\r`)
+		case '\t':
+			buf.WriteString(`\t`)
+		default:
+			buf.WriteRune(c)
+		}
+	}
+	buf.WriteByte('"')
+	return buf.String()
+}
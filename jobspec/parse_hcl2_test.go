@@ -0,0 +1,170 @@
+package jobspec
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/hcl2/hcl"
+	"github.com/hashicorp/hcl2/hcl/hclsyntax"
+	"github.com/hashicorp/nomad/api"
+	"github.com/stretchr/testify/require"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func parseHCL2Fixture(t *testing.T, path string, config *HCL2Config) (*api.Job, error) {
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+	return ParseHCL2(f, config)
+}
+
+// TestParseHCL2_Fixtures asserts that the HCL1 fixtures that are also valid
+// HCL2 are parsed into the same job, or fail with the same error.
+func TestParseHCL2_Fixtures(t *testing.T) {
+	// These fixtures use syntax that is only valid in HCL1
+	hcl1Only := map[string]bool{
+		"multi-vault.hcl":              true,
+		"reschedule-job.hcl":           true,
+		"reschedule-job-unlimited.hcl": true,
+	}
+
+	paths, err := filepath.Glob("test-fixtures/*.hcl")
+	require.NoError(t, err)
+	require.NotEmpty(t, paths)
+
+	for _, path := range paths {
+		if hcl1Only[filepath.Base(path)] {
+			continue
+		}
+
+		t.Run(filepath.Base(path), func(t *testing.T) {
+			expected, expectedErr := ParseFile(path)
+			actual, err := parseHCL2Fixture(t, path, &HCL2Config{Filename: path})
+			if expectedErr != nil {
+				require.EqualError(t, err, expectedErr.Error())
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, expected, actual)
+		})
+	}
+}
+
+func TestParseHCL2_Variables(t *testing.T) {
+	expected, err := ParseFile("test-fixtures/hcl2/variables-hcl1.hcl")
+	require.NoError(t, err)
+
+	actual, err := parseHCL2Fixture(t, "test-fixtures/hcl2/variables.hcl", &HCL2Config{
+		VarFiles: []string{"test-fixtures/hcl2/variables.vars.hcl"},
+		ArgVars:  []string{"image=redis:5.0", "count=3"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, expected, actual)
+}
+
+func TestParseHCL2_Invalid(t *testing.T) {
+	cases := []struct {
+		name   string
+		src    string
+		config *HCL2Config
+		err    string
+	}{
+		{
+			name: "missing variable",
+			src:  `variable "dc" {}`,
+			err:  `missing value for variable "dc"`,
+		},
+		{
+			name:   "undefined variable",
+			src:    `job "example" {}`,
+			config: &HCL2Config{ArgVars: []string{"dc=dc1"}},
+			err:    `undefined variable "dc"`,
+		},
+		{
+			name:   "invalid variable flag",
+			src:    `variable "dc" {}`,
+			config: &HCL2Config{ArgVars: []string{"dc"}},
+			err:    "expected the name=value form",
+		},
+		{
+			name:   "mistyped variable",
+			src:    `variable "count" { type = number }`,
+			config: &HCL2Config{ArgVars: []string{"count=many"}},
+			err:    `invalid value for variable "count"`,
+		},
+		{
+			name: "invalid type",
+			src:  `variable "count" { type = integer }`,
+			err:  "Invalid type specification",
+		},
+		{
+			name: "local cycle",
+			src:  "locals {\n a = local.b\n b = local.a\n}",
+			err:  "cycle between the local values a, b",
+		},
+		{
+			name: "undefined local",
+			src:  "locals {\n a = local.b\n}",
+			err:  `undefined local value "b"`,
+		},
+		{
+			name: "undeclared variable reference",
+			src:  `job "example" { datacenters = var.dcs }`,
+			err:  "Unsupported attribute",
+		},
+		{
+			name: "unknown runtime variable",
+			src:  `job "example" { datacenters = ["${vars.dc}"] }`,
+			err:  "Unknown variable",
+		},
+		{
+			name: "dynamic without content",
+			src:  "job \"example\" {\n dynamic \"constraint\" {\n for_each = []\n }\n}",
+			err:  "missing its content block",
+		},
+		{
+			name: "top level attribute",
+			src:  `region = "global"`,
+			err:  `unexpected attribute "region"`,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			_, err := ParseHCL2(strings.NewReader(c.src), c.config)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), c.err)
+		})
+	}
+}
+
+// TestHCL2Renderer_EvalCopiesTemplates asserts that keeping the runtime
+// interpolations as is leaves the evaluated expression untouched.
+func TestHCL2Renderer_EvalCopiesTemplates(t *testing.T) {
+	src := []byte(`"${attr.kernel.name}-${var.dc}"`)
+	expr, diags := hclsyntax.ParseExpression(src, "job.hcl", hcl.Pos{Line: 1, Column: 1})
+	require.False(t, diags.HasErrors())
+
+	ctx := &hcl.EvalContext{
+		Variables: map[string]cty.Value{
+			"var": cty.ObjectVal(map[string]cty.Value{"dc": cty.StringVal("dc1")}),
+		},
+	}
+	r := &hcl2Renderer{src: src, filename: "job.hcl"}
+	val, err := r.eval(expr, ctx)
+	require.NoError(t, err)
+	require.Equal(t, "${attr.kernel.name}-dc1", val.AsString())
+
+	template := expr.(*hclsyntax.TemplateExpr)
+	require.IsType(t, &hclsyntax.ScopeTraversalExpr{}, template.Parts[0])
+
+	// The attr variable is known to this context, so it is evaluated
+	ctx.Variables["attr"] = cty.ObjectVal(map[string]cty.Value{
+		"kernel": cty.ObjectVal(map[string]cty.Value{"name": cty.StringVal("linux")}),
+	})
+	val, err = r.eval(expr, ctx)
+	require.NoError(t, err)
+	require.Equal(t, "linux-dc1", val.AsString())
+}
@@ -32,6 +32,7 @@ func parseJob(result *api.Job, list *ast.ObjectList) error {
 	delete(m, "affinity")
 	delete(m, "meta")
 	delete(m, "migrate")
+	delete(m, "multiregion")
 	delete(m, "parameterized")
 	delete(m, "periodic")
 	delete(m, "reschedule")
@@ -67,6 +68,7 @@ func parseJob(result *api.Job, list *ast.ObjectList) error {
 		"id",
 		"meta",
 		"migrate",
+		"multiregion",
 		"name",
 		"namespace",
 		"parameterized",
@@ -126,6 +128,13 @@ func parseJob(result *api.Job, list *ast.ObjectList) error {
 		}
 	}
 
+	// If we have a multiregion definition, then parse that
+	if o := listVal.Filter("multiregion"); len(o.Items) > 0 {
+		if err := parseMultiregion(&result.Multiregion, o); err != nil {
+			return multierror.Prefix(err, "multiregion ->")
+		}
+	}
+
 	// If we have a reschedule stanza, then parse that
 	if o := listVal.Filter("reschedule"); len(o.Items) > 0 {
 		if err := parseReschedulePolicy(&result.Reschedule, o); err != nil {
@@ -219,8 +228,11 @@ func parsePeriodic(result **api.PeriodicConfig, list *ast.ObjectList) error {
 	valid := []string{
 		"enabled",
 		"cron",
+		"crons",
 		"prohibit_overlap",
 		"time_zone",
+		"catchup",
+		"catchup_window",
 	}
 	if err := helper.CheckHCLKeys(o.Val, valid); err != nil {
 		return err
@@ -240,9 +252,23 @@ func parsePeriodic(result **api.PeriodicConfig, list *ast.ObjectList) error {
 		m["Spec"] = cron
 	}
 
+	// If "crons" is provided, set the type to "cron" and store the specs.
+	if crons, ok := m["crons"]; ok {
+		m["SpecType"] = api.PeriodicSpecCron
+		m["Specs"] = crons
+	}
+
 	// Build the constraint
 	var p api.PeriodicConfig
-	if err := mapstructure.WeakDecode(m, &p); err != nil {
+	dec, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		DecodeHook:       mapstructure.StringToTimeDurationHookFunc(),
+		WeaklyTypedInput: true,
+		Result:           &p,
+	})
+	if err != nil {
+		return err
+	}
+	if err := dec.Decode(m); err != nil {
 		return err
 	}
 	*result = &p
@@ -268,6 +294,7 @@ func parseParameterizedJob(result **api.ParameterizedJobConfig, list *ast.Object
 		"payload",
 		"meta_required",
 		"meta_optional",
+		"max_concurrent",
 	}
 	if err := helper.CheckHCLKeys(o.Val, valid); err != nil {
 		return err
@@ -282,3 +309,109 @@ func parseParameterizedJob(result **api.ParameterizedJobConfig, list *ast.Object
 	*result = &d
 	return nil
 }
+
+func parseMultiregion(result **api.Multiregion, list *ast.ObjectList) error {
+	list = list.Elem()
+	if len(list.Items) > 1 {
+		return fmt.Errorf("only one 'multiregion' block allowed per job")
+	}
+
+	// Get our multiregion object
+	obj := list.Items[0]
+
+	// Value should be an object
+	var listVal *ast.ObjectList
+	if ot, ok := obj.Val.(*ast.ObjectType); ok {
+		listVal = ot.List
+	} else {
+		return fmt.Errorf("multiregion should be an object")
+	}
+
+	// Check for invalid keys
+	valid := []string{
+		"strategy",
+		"region",
+	}
+	if err := helper.CheckHCLKeys(listVal, valid); err != nil {
+		return err
+	}
+
+	var mr api.Multiregion
+
+	// Parse the strategy
+	if o := listVal.Filter("strategy"); len(o.Items) > 0 {
+		if len(o.Items) > 1 {
+			return fmt.Errorf("only one 'strategy' block allowed per multiregion")
+		}
+
+		so := o.Items[0]
+		var m map[string]interface{}
+		if err := hcl.DecodeObject(&m, so.Val); err != nil {
+			return err
+		}
+
+		valid := []string{
+			"max_parallel",
+		}
+		if err := helper.CheckHCLKeys(so.Val, valid); err != nil {
+			return multierror.Prefix(err, "strategy ->")
+		}
+
+		var strategy api.MultiregionStrategy
+		if err := mapstructure.WeakDecode(m, &strategy); err != nil {
+			return err
+		}
+		mr.Strategy = &strategy
+	}
+
+	// Parse the regions, keeping the order they were defined in as it is the
+	// order they are deployed in
+	for _, o := range listVal.Filter("region").Items {
+		if len(o.Keys) == 0 {
+			return fmt.Errorf("region block missing name")
+		}
+		name := o.Keys[0].Token.Value().(string)
+
+		// Check for invalid keys
+		valid := []string{
+			"count",
+			"datacenters",
+			"meta",
+		}
+		if err := helper.CheckHCLKeys(o.Val, valid); err != nil {
+			return multierror.Prefix(err, fmt.Sprintf("region '%s' ->", name))
+		}
+
+		var m map[string]interface{}
+		if err := hcl.DecodeObject(&m, o.Val); err != nil {
+			return err
+		}
+		delete(m, "meta")
+
+		region := &api.MultiregionRegion{Name: name}
+		if err := mapstructure.WeakDecode(m, region); err != nil {
+			return err
+		}
+
+		// Parse out meta fields. These are in HCL as a list so we need
+		// to iterate over them and merge them.
+		if ot, ok := o.Val.(*ast.ObjectType); ok {
+			if metaO := ot.List.Filter("meta"); len(metaO.Items) > 0 {
+				for _, mo := range metaO.Elem().Items {
+					var m map[string]interface{}
+					if err := hcl.DecodeObject(&m, mo.Val); err != nil {
+						return err
+					}
+					if err := mapstructure.WeakDecode(m, &region.Meta); err != nil {
+						return err
+					}
+				}
+			}
+		}
+
+		mr.Regions = append(mr.Regions, region)
+	}
+
+	*result = &mr
+	return nil
+}
@@ -474,6 +474,21 @@ func TestParse(t *testing.T) {
 			false,
 		},
 
+		{
+			"periodic-crons.hcl",
+			&api.Job{
+				ID:   helper.StringToPtr("foo"),
+				Name: helper.StringToPtr("foo"),
+				Periodic: &api.PeriodicConfig{
+					SpecType:      helper.StringToPtr(api.PeriodicSpecCron),
+					Specs:         []string{"0 9 * * 1-5", "0 12 * * 6,0"},
+					Catchup:       helper.StringToPtr(api.PeriodicCatchupAll),
+					CatchupWindow: helper.TimeToPtr(6 * time.Hour),
+				},
+			},
+			false,
+		},
+
 		{
 			"specify-job.hcl",
 			&api.Job{
@@ -655,9 +670,10 @@ func TestParse(t *testing.T) {
 				Name: helper.StringToPtr("parameterized_job"),
 
 				ParameterizedJob: &api.ParameterizedJobConfig{
-					Payload:      "required",
-					MetaRequired: []string{"foo", "bar"},
-					MetaOptional: []string{"baz", "bam"},
+					Payload:       "required",
+					MetaRequired:  []string{"foo", "bar"},
+					MetaOptional:  []string{"baz", "bam"},
+					MaxConcurrent: 5,
 				},
 
 				TaskGroups: []*api.TaskGroup{
@@ -988,6 +1004,101 @@ func TestParse(t *testing.T) {
 			false,
 		},
 
+		{
+			"tg-max-client-disconnect.hcl",
+			&api.Job{
+				ID:          helper.StringToPtr("foo"),
+				Name:        helper.StringToPtr("foo"),
+				Datacenters: []string{"dc1"},
+				TaskGroups: []*api.TaskGroup{
+					{
+						Name:                helper.StringToPtr("bar"),
+						Count:               helper.IntToPtr(3),
+						MaxClientDisconnect: helper.TimeToPtr(2 * time.Hour),
+						Tasks: []*api.Task{
+							{
+								Name:   "bar",
+								Driver: "raw_exec",
+								Config: map[string]interface{}{
+									"command": "bash",
+									"args":    []interface{}{"-c", "echo hi"},
+								},
+							},
+						},
+					},
+				},
+			},
+			false,
+		},
+
+		{
+			"tg-scaling-policy.hcl",
+			&api.Job{
+				ID:   helper.StringToPtr("elastic"),
+				Name: helper.StringToPtr("elastic"),
+				TaskGroups: []*api.TaskGroup{
+					{
+						Name: helper.StringToPtr("group"),
+						Scaling: &api.ScalingPolicy{
+							Min:     helper.Int64ToPtr(5),
+							Max:     helper.Int64ToPtr(100),
+							Enabled: helper.BoolToPtr(false),
+							Policy: map[string]interface{}{
+								"foo": "bar",
+								"b":   true,
+								"val": 5,
+								"f":   .1,
+							},
+						},
+					},
+				},
+			},
+			false,
+		},
+
+		{
+			"multiregion.hcl",
+			&api.Job{
+				ID:          helper.StringToPtr("foo"),
+				Name:        helper.StringToPtr("foo"),
+				Datacenters: []string{"dc1"},
+				Multiregion: &api.Multiregion{
+					Strategy: &api.MultiregionStrategy{
+						MaxParallel: helper.IntToPtr(1),
+					},
+					Regions: []*api.MultiregionRegion{
+						{
+							Name:        "west",
+							Count:       helper.IntToPtr(2),
+							Datacenters: []string{"west-1"},
+							Meta:        map[string]string{"region_code": "W"},
+						},
+						{
+							Name:        "east",
+							Count:       helper.IntToPtr(1),
+							Datacenters: []string{"east-1", "east-2"},
+						},
+					},
+				},
+				TaskGroups: []*api.TaskGroup{
+					{
+						Name: helper.StringToPtr("bar"),
+						Tasks: []*api.Task{
+							{
+								Name:   "bar",
+								Driver: "raw_exec",
+								Config: map[string]interface{}{
+									"command": "bash",
+									"args":    []interface{}{"-c", "echo hi"},
+								},
+							},
+						},
+					},
+				},
+			},
+			false,
+		},
+
 		{
 			"tg-service-check.hcl",
 			&api.Job{
@@ -0,0 +1,46 @@
+job "cache" {
+  datacenters = ["dc1", "dc2"]
+  name        = "cache-prod"
+
+  meta {
+    image_tag = "5.0"
+  }
+
+  constraint {
+    attribute = "${attr.unique.network.ip-address}"
+    operator  = "!="
+    value     = "${meta.bad_ip}"
+  }
+
+  group "cache" {
+    count = 3
+
+    task "redis" {
+      driver = "docker"
+
+      config {
+        image = "redis:5.0"
+        args  = ["--port", "${NOMAD_PORT_db}", "--name=cache-prod"]
+      }
+
+      env {
+        ENVIRONMENT = "PROD"
+        ALLOC_DIR   = "${NOMAD_ALLOC_DIR}/prod"
+      }
+
+      resources {
+        network {
+          mbits = 10
+
+          port "admin" {
+            static = 8080
+          }
+
+          port "db" {
+            static = 6380
+          }
+        }
+      }
+    }
+  }
+}
@@ -0,0 +1,80 @@
+variable "datacenters" {
+  type        = list(string)
+  description = "The datacenters to run the job in"
+}
+
+variable "image" {
+  type    = string
+  default = "redis:3.2"
+}
+
+variable "count" {
+  type    = number
+  default = 1
+}
+
+variable "ports" {
+  type = map(number)
+  default = {
+    db = 6379
+  }
+}
+
+locals {
+  name      = "cache-${local.env}"
+  env       = lower(var.env_name)
+  image_tag = split(":", var.image)[1]
+}
+
+variable "env_name" {
+  type    = string
+  default = "Dev"
+}
+
+job "cache" {
+  datacenters = var.datacenters
+  name        = local.name
+
+  meta {
+    image_tag = local.image_tag
+  }
+
+  constraint {
+    attribute = "${attr.unique.network.ip-address}"
+    operator  = "!="
+    value     = "${meta.bad_ip}"
+  }
+
+  group "cache" {
+    count = var.count
+
+    task "redis" {
+      driver = "docker"
+
+      config {
+        image = var.image
+        args  = ["--port", "${NOMAD_PORT_db}", format("--name=%s", local.name)]
+      }
+
+      env {
+        ENVIRONMENT = upper(local.env)
+        ALLOC_DIR   = "${NOMAD_ALLOC_DIR}/${local.env}"
+      }
+
+      resources {
+        network {
+          mbits = 10
+
+          dynamic "port" {
+            for_each = var.ports
+            labels   = [port.key]
+
+            content {
+              static = port.value
+            }
+          }
+        }
+      }
+    }
+  }
+}
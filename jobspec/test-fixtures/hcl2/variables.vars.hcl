@@ -0,0 +1,7 @@
+datacenters = ["dc1", "dc2"]
+env_name    = "Prod"
+image       = "redis:4.0"
+ports = {
+  db    = 6380
+  admin = 8080
+}
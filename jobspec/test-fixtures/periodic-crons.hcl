@@ -0,0 +1,11 @@
+job "foo" {
+  periodic {
+    crons = [
+      "0 9 * * 1-5",
+      "0 12 * * 6,0",
+    ]
+
+    catchup        = "all"
+    catchup_window = "6h"
+  }
+}
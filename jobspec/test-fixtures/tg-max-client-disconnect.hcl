@@ -0,0 +1,17 @@
+job "foo" {
+  datacenters = ["dc1"]
+
+  group "bar" {
+    count                 = 3
+    max_client_disconnect = "2h"
+
+    task "bar" {
+      driver = "raw_exec"
+
+      config {
+        command = "bash"
+        args    = ["-c", "echo hi"]
+      }
+    }
+  }
+}
@@ -162,6 +162,15 @@ func (p *DelayHeap) Remove(heapNode HeapNode) error {
 	return fmt.Errorf("heap doesn't contain object with ID %q (%s)", heapNode.ID(), heapNode.Namespace())
 }
 
+// Nodes returns the nodes currently stored in the heap in no particular order.
+func (p *DelayHeap) Nodes() []HeapNode {
+	nodes := make([]HeapNode, 0, len(p.heap))
+	for _, node := range p.heap {
+		nodes = append(nodes, node.Node)
+	}
+	return nodes
+}
+
 func (p *DelayHeap) Length() int {
 	return len(p.heap)
 }
@@ -113,3 +113,31 @@ func getHeapEntries(delayHeap *DelayHeap, now time.Time) []*delayHeapNode {
 	}
 	return entries
 }
+
+func TestDelayHeap_Nodes(t *testing.T) {
+	delayHeap := NewDelayHeap()
+	now := time.Now()
+	require := require.New(t)
+
+	dataNode1 := &heapNodeImpl{
+		dataObject: "foo",
+		id:         "101",
+		namespace:  "default",
+	}
+	delayHeap.Push(dataNode1, now.Add(10*time.Minute))
+
+	dataNode2 := &heapNodeImpl{
+		dataObject: "bar",
+		id:         "102",
+		namespace:  "default",
+	}
+	delayHeap.Push(dataNode2, now.Add(-10*time.Minute))
+
+	nodes := delayHeap.Nodes()
+	require.Len(nodes, 2)
+	require.ElementsMatch([]HeapNode{dataNode1, dataNode2}, nodes)
+
+	// The returned nodes can be used to remove entries
+	require.NoError(delayHeap.Remove(nodes[0]))
+	require.Equal(1, delayHeap.Length())
+}
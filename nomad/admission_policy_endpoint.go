@@ -0,0 +1,204 @@
+package nomad
+
+import (
+	"fmt"
+	"time"
+
+	metrics "github.com/armon/go-metrics"
+	log "github.com/hashicorp/go-hclog"
+	memdb "github.com/hashicorp/go-memdb"
+	"github.com/hashicorp/nomad/nomad/state"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// AdmissionPolicy endpoint is used for manipulating the policies enforced
+// when jobs are registered or planned
+type AdmissionPolicy struct {
+	srv    *Server
+	logger log.Logger
+}
+
+// UpsertPolicies is used to create or update a set of policies
+func (a *AdmissionPolicy) UpsertPolicies(args *structs.AdmissionPolicyUpsertRequest, reply *structs.GenericResponse) error {
+	if done, err := a.srv.forward("AdmissionPolicy.UpsertPolicies", args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "admission_policy", "upsert_policies"}, time.Now())
+
+	// Check management level permissions
+	if aclObj, err := a.srv.ResolveToken(args.AuthToken); err != nil {
+		return err
+	} else if aclObj != nil && !aclObj.IsManagement() {
+		return structs.ErrPermissionDenied
+	}
+
+	// Validate there is at least one policy
+	if len(args.Policies) == 0 {
+		return fmt.Errorf("must specify at least one policy")
+	}
+
+	// Validate each policy and compute the hash
+	for _, policy := range args.Policies {
+		if err := policy.Validate(); err != nil {
+			return fmt.Errorf("policy %q invalid: %v", policy.Name, err)
+		}
+		policy.SetHash()
+	}
+
+	// Update via Raft
+	out, index, err := a.srv.raftApply(structs.AdmissionPolicyUpsertRequestType, args)
+	if err != nil {
+		return err
+	}
+
+	// Check if there was an error when applying.
+	if err, ok := out.(error); ok && err != nil {
+		return err
+	}
+
+	// Update the index
+	reply.Index = index
+	return nil
+}
+
+// DeletePolicies is used to delete a set of policies
+func (a *AdmissionPolicy) DeletePolicies(args *structs.AdmissionPolicyDeleteRequest, reply *structs.GenericResponse) error {
+	if done, err := a.srv.forward("AdmissionPolicy.DeletePolicies", args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "admission_policy", "delete_policies"}, time.Now())
+
+	// Check management level permissions
+	if aclObj, err := a.srv.ResolveToken(args.AuthToken); err != nil {
+		return err
+	} else if aclObj != nil && !aclObj.IsManagement() {
+		return structs.ErrPermissionDenied
+	}
+
+	// Validate there is at least one policy
+	if len(args.Names) == 0 {
+		return fmt.Errorf("must specify at least one policy to delete")
+	}
+
+	// Update via Raft
+	out, index, err := a.srv.raftApply(structs.AdmissionPolicyDeleteRequestType, args)
+	if err != nil {
+		return err
+	}
+
+	// Check if there was an error when applying.
+	if err, ok := out.(error); ok && err != nil {
+		return err
+	}
+
+	// Update the index
+	reply.Index = index
+	return nil
+}
+
+// ListPolicies is used to list the policies
+func (a *AdmissionPolicy) ListPolicies(args *structs.AdmissionPolicyListRequest, reply *structs.AdmissionPolicyListResponse) error {
+	if done, err := a.srv.forward("AdmissionPolicy.ListPolicies", args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "admission_policy", "list_policies"}, time.Now())
+
+	// Check management level permissions
+	if aclObj, err := a.srv.ResolveToken(args.AuthToken); err != nil {
+		return err
+	} else if aclObj != nil && !aclObj.IsManagement() {
+		return structs.ErrPermissionDenied
+	}
+
+	// Setup the blocking query
+	opts := blockingOptions{
+		queryOpts: &args.QueryOptions,
+		queryMeta: &reply.QueryMeta,
+		run: func(ws memdb.WatchSet, s *state.StateStore) error {
+			// Iterate over all the policies
+			var err error
+			var iter memdb.ResultIterator
+			if prefix := args.QueryOptions.Prefix; prefix != "" {
+				iter, err = s.AdmissionPolicyByNamePrefix(ws, prefix)
+			} else {
+				iter, err = s.AdmissionPolicies(ws)
+			}
+			if err != nil {
+				return err
+			}
+
+			// Convert all the policies to a list stub
+			reply.Policies = nil
+			for {
+				raw := iter.Next()
+				if raw == nil {
+					break
+				}
+				policy := raw.(*structs.AdmissionPolicy)
+				reply.Policies = append(reply.Policies, policy.Stub())
+			}
+
+			// Use the last index that affected the policy table
+			index, err := s.Index("admission_policy")
+			if err != nil {
+				return err
+			}
+
+			// Ensure we never set the index to zero, otherwise a blocking query cannot be used.
+			// We floor the index at one, since realistically the first write must have a higher index.
+			if index == 0 {
+				index = 1
+			}
+			reply.Index = index
+			return nil
+		}}
+	return a.srv.blockingRPC(&opts)
+}
+
+// GetPolicy is used to get a specific policy
+func (a *AdmissionPolicy) GetPolicy(args *structs.AdmissionPolicySpecificRequest, reply *structs.SingleAdmissionPolicyResponse) error {
+	if done, err := a.srv.forward("AdmissionPolicy.GetPolicy", args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "admission_policy", "get_policy"}, time.Now())
+
+	// Check management level permissions
+	if aclObj, err := a.srv.ResolveToken(args.AuthToken); err != nil {
+		return err
+	} else if aclObj != nil && !aclObj.IsManagement() {
+		return structs.ErrPermissionDenied
+	}
+
+	// Setup the blocking query
+	opts := blockingOptions{
+		queryOpts: &args.QueryOptions,
+		queryMeta: &reply.QueryMeta,
+		run: func(ws memdb.WatchSet, s *state.StateStore) error {
+			// Look for the policy
+			out, err := s.AdmissionPolicyByName(ws, args.Name)
+			if err != nil {
+				return err
+			}
+
+			// Setup the output
+			reply.Policy = out
+			if out != nil {
+				reply.Index = out.ModifyIndex
+			} else {
+				// Use the last index that affected the policy table
+				index, err := s.Index("admission_policy")
+				if err != nil {
+					return err
+				}
+
+				// Ensure we never set the index to zero, otherwise a blocking query cannot be used.
+				// We floor the index at one, since realistically the first write must have a higher index.
+				if index == 0 {
+					index = 1
+				}
+				reply.Index = index
+			}
+			return nil
+		}}
+	return a.srv.blockingRPC(&opts)
+}
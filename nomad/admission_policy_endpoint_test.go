@@ -0,0 +1,239 @@
+package nomad
+
+import (
+	"testing"
+
+	msgpackrpc "github.com/hashicorp/net-rpc-msgpackrpc"
+	"github.com/hashicorp/nomad/acl"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdmissionPolicyEndpoint_GetPolicy(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	s1 := TestServer(t, nil)
+	defer s1.Shutdown()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	policy := mock.AdmissionPolicy()
+	s1.fsm.State().UpsertAdmissionPolicies(1000, []*structs.AdmissionPolicy{policy})
+
+	// Lookup the policy
+	get := &structs.AdmissionPolicySpecificRequest{
+		Name:         policy.Name,
+		QueryOptions: structs.QueryOptions{Region: "global"},
+	}
+	var resp structs.SingleAdmissionPolicyResponse
+	require.NoError(msgpackrpc.CallWithCodec(codec, "AdmissionPolicy.GetPolicy", get, &resp))
+	require.EqualValues(1000, resp.Index)
+	require.Equal(policy, resp.Policy)
+
+	// Lookup a missing policy
+	get.Name = "missing"
+	var resp2 structs.SingleAdmissionPolicyResponse
+	require.NoError(msgpackrpc.CallWithCodec(codec, "AdmissionPolicy.GetPolicy", get, &resp2))
+	require.EqualValues(1000, resp2.Index)
+	require.Nil(resp2.Policy)
+}
+
+func TestAdmissionPolicyEndpoint_ListPolicies(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	s1 := TestServer(t, nil)
+	defer s1.Shutdown()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	p1 := mock.AdmissionPolicy()
+	p2 := mock.AdmissionPolicy()
+	p1.Name = "aaaaaaaa-3350-4b4b-d185-0e1992ed43e9"
+	p2.Name = "bbbbbbbb-3350-4b4b-d185-0e1992ed43e9"
+	s1.fsm.State().UpsertAdmissionPolicies(1000, []*structs.AdmissionPolicy{p1, p2})
+
+	// List the policies
+	get := &structs.AdmissionPolicyListRequest{
+		QueryOptions: structs.QueryOptions{Region: "global"},
+	}
+	var resp structs.AdmissionPolicyListResponse
+	require.NoError(msgpackrpc.CallWithCodec(codec, "AdmissionPolicy.ListPolicies", get, &resp))
+	require.EqualValues(1000, resp.Index)
+	require.Len(resp.Policies, 2)
+
+	// List the policies with a prefix
+	get.Prefix = "aaaa"
+	var resp2 structs.AdmissionPolicyListResponse
+	require.NoError(msgpackrpc.CallWithCodec(codec, "AdmissionPolicy.ListPolicies", get, &resp2))
+	require.Len(resp2.Policies, 1)
+	require.Equal(p1.Name, resp2.Policies[0].Name)
+	require.Equal(p1.EnforcementLevel, resp2.Policies[0].EnforcementLevel)
+}
+
+func TestAdmissionPolicyEndpoint_UpsertPolicies(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	s1 := TestServer(t, nil)
+	defer s1.Shutdown()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	policy := mock.AdmissionPolicy()
+	policy.Hash = nil
+	req := &structs.AdmissionPolicyUpsertRequest{
+		Policies:     []*structs.AdmissionPolicy{policy},
+		WriteRequest: structs.WriteRequest{Region: "global"},
+	}
+	var resp structs.GenericResponse
+	require.NoError(msgpackrpc.CallWithCodec(codec, "AdmissionPolicy.UpsertPolicies", req, &resp))
+	require.NotZero(resp.Index)
+
+	out, err := s1.fsm.State().AdmissionPolicyByName(nil, policy.Name)
+	require.NoError(err)
+	require.NotNil(out)
+	require.NotEmpty(out.Hash)
+	require.Equal(policy.Policy, out.Policy)
+}
+
+func TestAdmissionPolicyEndpoint_UpsertPolicies_Invalid(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	s1 := TestServer(t, nil)
+	defer s1.Shutdown()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	cases := []struct {
+		name   string
+		mutate func(*structs.AdmissionPolicy)
+		err    string
+	}{
+		{
+			name:   "bad level",
+			mutate: func(p *structs.AdmissionPolicy) { p.EnforcementLevel = "mandatory" },
+			err:    "invalid enforcement level",
+		},
+		{
+			name:   "bad rules",
+			mutate: func(p *structs.AdmissionPolicy) { p.Policy = `rule "foo" { message = "bar" }` },
+			err:    "missing a condition",
+		},
+		{
+			name:   "bad name",
+			mutate: func(p *structs.AdmissionPolicy) { p.Name = "bad name" },
+			err:    "invalid name",
+		},
+	}
+
+	for _, c := range cases {
+		policy := mock.AdmissionPolicy()
+		c.mutate(policy)
+		req := &structs.AdmissionPolicyUpsertRequest{
+			Policies:     []*structs.AdmissionPolicy{policy},
+			WriteRequest: structs.WriteRequest{Region: "global"},
+		}
+		var resp structs.GenericResponse
+		err := msgpackrpc.CallWithCodec(codec, "AdmissionPolicy.UpsertPolicies", req, &resp)
+		require.Error(err, c.name)
+		require.Contains(err.Error(), c.err, c.name)
+	}
+}
+
+func TestAdmissionPolicyEndpoint_DeletePolicies(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	s1 := TestServer(t, nil)
+	defer s1.Shutdown()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	p1 := mock.AdmissionPolicy()
+	p2 := mock.AdmissionPolicy()
+	s1.fsm.State().UpsertAdmissionPolicies(1000, []*structs.AdmissionPolicy{p1, p2})
+
+	req := &structs.AdmissionPolicyDeleteRequest{
+		Names:        []string{p1.Name},
+		WriteRequest: structs.WriteRequest{Region: "global"},
+	}
+	var resp structs.GenericResponse
+	require.NoError(msgpackrpc.CallWithCodec(codec, "AdmissionPolicy.DeletePolicies", req, &resp))
+	require.NotZero(resp.Index)
+
+	out, err := s1.fsm.State().AdmissionPolicyByName(nil, p1.Name)
+	require.NoError(err)
+	require.Nil(out)
+
+	out, err = s1.fsm.State().AdmissionPolicyByName(nil, p2.Name)
+	require.NoError(err)
+	require.NotNil(out)
+}
+
+func TestAdmissionPolicyEndpoint_ACL(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	s1, root := TestACLServer(t, nil)
+	defer s1.Shutdown()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	state := s1.fsm.State()
+	policy := mock.AdmissionPolicy()
+	state.UpsertAdmissionPolicies(1000, []*structs.AdmissionPolicy{policy})
+
+	// A token with full namespace access isn't enough
+	token := mock.CreatePolicyAndToken(t, state, 1001, "test-invalid",
+		mock.NamespacePolicy(structs.DefaultNamespace, acl.PolicyWrite, nil))
+
+	list := &structs.AdmissionPolicyListRequest{
+		QueryOptions: structs.QueryOptions{Region: "global"},
+	}
+	get := &structs.AdmissionPolicySpecificRequest{
+		Name:         policy.Name,
+		QueryOptions: structs.QueryOptions{Region: "global"},
+	}
+	upsert := &structs.AdmissionPolicyUpsertRequest{
+		Policies:     []*structs.AdmissionPolicy{mock.AdmissionPolicy()},
+		WriteRequest: structs.WriteRequest{Region: "global"},
+	}
+	del := &structs.AdmissionPolicyDeleteRequest{
+		Names:        []string{policy.Name},
+		WriteRequest: structs.WriteRequest{Region: "global"},
+	}
+
+	for _, secret := range []string{"", token.SecretID} {
+		list.AuthToken = secret
+		err := msgpackrpc.CallWithCodec(codec, "AdmissionPolicy.ListPolicies", list, &structs.AdmissionPolicyListResponse{})
+		require.EqualError(err, structs.ErrPermissionDenied.Error())
+
+		get.AuthToken = secret
+		err = msgpackrpc.CallWithCodec(codec, "AdmissionPolicy.GetPolicy", get, &structs.SingleAdmissionPolicyResponse{})
+		require.EqualError(err, structs.ErrPermissionDenied.Error())
+
+		upsert.AuthToken = secret
+		err = msgpackrpc.CallWithCodec(codec, "AdmissionPolicy.UpsertPolicies", upsert, &structs.GenericResponse{})
+		require.EqualError(err, structs.ErrPermissionDenied.Error())
+
+		del.AuthToken = secret
+		err = msgpackrpc.CallWithCodec(codec, "AdmissionPolicy.DeletePolicies", del, &structs.GenericResponse{})
+		require.EqualError(err, structs.ErrPermissionDenied.Error())
+	}
+
+	// The management token is allowed
+	list.AuthToken = root.SecretID
+	var listResp structs.AdmissionPolicyListResponse
+	require.NoError(msgpackrpc.CallWithCodec(codec, "AdmissionPolicy.ListPolicies", list, &listResp))
+	require.Len(listResp.Policies, 1)
+
+	get.AuthToken = root.SecretID
+	var getResp structs.SingleAdmissionPolicyResponse
+	require.NoError(msgpackrpc.CallWithCodec(codec, "AdmissionPolicy.GetPolicy", get, &getResp))
+	require.Equal(policy, getResp.Policy)
+
+	upsert.AuthToken = root.SecretID
+	require.NoError(msgpackrpc.CallWithCodec(codec, "AdmissionPolicy.UpsertPolicies", upsert, &structs.GenericResponse{}))
+
+	del.AuthToken = root.SecretID
+	require.NoError(msgpackrpc.CallWithCodec(codec, "AdmissionPolicy.DeletePolicies", del, &structs.GenericResponse{}))
+}
@@ -53,16 +53,28 @@ func (a *Alloc) List(args *structs.AllocListRequest, reply *structs.AllocListRes
 				return err
 			}
 
+			paginator, err := newPaginator(iter, args.QueryOptions,
+				func(raw interface{}) string {
+					return raw.(*structs.Allocation).ID
+				},
+				func(raw interface{}) (interface{}, error) {
+					return raw.(*structs.Allocation).Stub(), nil
+				})
+			if err != nil {
+				return err
+			}
+
+			stubs, nextToken, err := paginator.page()
+			if err != nil {
+				return err
+			}
+
 			var allocs []*structs.AllocListStub
-			for {
-				raw := iter.Next()
-				if raw == nil {
-					break
-				}
-				alloc := raw.(*structs.Allocation)
-				allocs = append(allocs, alloc.Stub())
+			for _, stub := range stubs {
+				allocs = append(allocs, stub.(*structs.AllocListStub))
 			}
 			reply.Allocations = allocs
+			reply.NextToken = nextToken
 
 			// Use the last index that affected the jobs table
 			index, err := state.Index("allocs")
@@ -86,8 +98,10 @@ func (a *Alloc) GetAlloc(args *structs.AllocSpecificRequest,
 	}
 	defer metrics.MeasureSince([]string{"nomad", "alloc", "get_alloc"}, time.Now())
 
-	// Check namespace read-job permissions
-	if aclObj, err := a.srv.ResolveToken(args.AuthToken); err != nil {
+	// Resolve the token, the namespace read-job permissions are checked once
+	// the allocation is found
+	aclObj, err := a.srv.ResolveToken(args.AuthToken)
+	if err != nil {
 		// If ResolveToken had an unexpected error return that
 		if err != structs.ErrTokenNotFound {
 			return err
@@ -107,8 +121,6 @@ func (a *Alloc) GetAlloc(args *structs.AllocSpecificRequest,
 		if node == nil {
 			return structs.ErrTokenNotFound
 		}
-	} else if aclObj != nil && !aclObj.AllowNsOp(args.RequestNamespace(), acl.NamespaceCapabilityReadJob) {
-		return structs.ErrPermissionDenied
 	}
 
 	// Setup the blocking query
@@ -122,6 +134,18 @@ func (a *Alloc) GetAlloc(args *structs.AllocSpecificRequest,
 				return err
 			}
 
+			// Check the permissions against the namespace of the allocation
+			// rather than the namespace of the request
+			if aclObj != nil {
+				ns := args.RequestNamespace()
+				if out != nil {
+					ns = out.Namespace
+				}
+				if !aclObj.AllowNsOp(ns, acl.NamespaceCapabilityReadJob) {
+					return structs.ErrPermissionDenied
+				}
+			}
+
 			// Setup the output
 			reply.Alloc = out
 			if out != nil {
@@ -214,11 +238,11 @@ func (a *Alloc) Stop(args *structs.AllocStopRequest, reply *structs.AllocStopRes
 	}
 	defer metrics.MeasureSince([]string{"nomad", "alloc", "stop"}, time.Now())
 
-	// Check that it is a management token.
+	// Check namespace alloc-lifecycle permissions
 	if aclObj, err := a.srv.ResolveToken(args.AuthToken); err != nil {
 		return err
-	} else if aclObj != nil && !aclObj.AllowNsOp(args.Namespace, acl.NamespaceCapabilityAllocLifecycle) {
-		return structs.ErrPermissionDenied
+	} else if err := checkAllocNsOp(a.srv.State(), aclObj, args.AllocID, args.Namespace, acl.NamespaceCapabilityAllocLifecycle); err != nil {
+		return err
 	}
 
 	if args.AllocID == "" {
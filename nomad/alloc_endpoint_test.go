@@ -1,6 +1,7 @@
 package nomad
 
 import (
+	"fmt"
 	"reflect"
 	"testing"
 	"time"
@@ -82,6 +83,51 @@ func TestAllocEndpoint_List(t *testing.T) {
 	}
 }
 
+func TestAllocEndpoint_List_FilterPaginate(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	s1 := TestServer(t, nil)
+	defer s1.Shutdown()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	// Create allocations with known IDs and alternating client statuses
+	state := s1.fsm.State()
+	var allocs []*structs.Allocation
+	for i := 0; i < 5; i++ {
+		alloc := mock.Alloc()
+		alloc.ID = fmt.Sprintf("aaaaaaaa-3350-4b4b-d185-0e1992ed43e%d", i)
+		if i%2 == 0 {
+			alloc.ClientStatus = structs.AllocClientStatusRunning
+		}
+		allocs = append(allocs, alloc)
+	}
+	require.NoError(state.UpsertJobSummary(999, mock.JobSummary(allocs[0].JobID)))
+	require.NoError(state.UpsertAllocs(1000, allocs))
+
+	get := &structs.AllocListRequest{
+		QueryOptions: structs.QueryOptions{
+			Region:    "global",
+			Namespace: structs.DefaultNamespace,
+			Filter:    `ClientStatus == "running"`,
+			PerPage:   2,
+		},
+	}
+	var resp structs.AllocListResponse
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Alloc.List", get, &resp))
+	require.Len(resp.Allocations, 2)
+	require.Equal(allocs[0].ID, resp.Allocations[0].ID)
+	require.Equal(allocs[2].ID, resp.Allocations[1].ID)
+	require.Equal(allocs[4].ID, resp.NextToken)
+
+	get.NextToken = resp.NextToken
+	var resp2 structs.AllocListResponse
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Alloc.List", get, &resp2))
+	require.Len(resp2.Allocations, 1)
+	require.Equal(allocs[4].ID, resp2.Allocations[0].ID)
+	require.Empty(resp2.NextToken)
+}
+
 func TestAllocEndpoint_List_ACL(t *testing.T) {
 	t.Parallel()
 	s1, root := TestACLServer(t, nil)
@@ -253,6 +299,60 @@ func TestAllocEndpoint_GetAlloc(t *testing.T) {
 	}
 }
 
+// TestAllocEndpoint_CrossNamespace_ACL asserts that the operations on an
+// allocation by ID are authorized against the namespace of the allocation.
+func TestAllocEndpoint_CrossNamespace_ACL(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	s1, _ := TestACLServer(t, nil)
+	defer s1.Shutdown()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	state := s1.fsm.State()
+	alloc := testAllocInNamespace(t, state, 1000)
+
+	caps := []string{acl.NamespaceCapabilityReadJob, acl.NamespaceCapabilityAllocLifecycle}
+	defaultToken := mock.CreatePolicyAndToken(t, state, 1005, "default",
+		mock.NamespacePolicy(structs.DefaultNamespace, "", caps))
+	allocToken := mock.CreatePolicyAndToken(t, state, 1007, "alloc",
+		mock.NamespacePolicy(alloc.Namespace, "", caps))
+
+	// A token of the namespace of the request can't read the allocation
+	get := &structs.AllocSpecificRequest{
+		AllocID: alloc.ID,
+		QueryOptions: structs.QueryOptions{
+			Region:    "global",
+			Namespace: structs.DefaultNamespace,
+			AuthToken: defaultToken.SecretID,
+		},
+	}
+	var getResp structs.SingleAllocResponse
+	err := msgpackrpc.CallWithCodec(codec, "Alloc.GetAlloc", get, &getResp)
+	require.EqualError(err, structs.ErrPermissionDenied.Error())
+	require.Nil(getResp.Alloc)
+
+	get.AuthToken = allocToken.SecretID
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Alloc.GetAlloc", get, &getResp))
+	require.Equal(alloc.ID, getResp.Alloc.ID)
+
+	// Nor stop it
+	stop := &structs.AllocStopRequest{
+		AllocID: alloc.ID,
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: structs.DefaultNamespace,
+			AuthToken: defaultToken.SecretID,
+		},
+	}
+	var stopResp structs.AllocStopResponse
+	err = msgpackrpc.CallWithCodec(codec, "Alloc.Stop", stop, &stopResp)
+	require.EqualError(err, structs.ErrPermissionDenied.Error())
+
+	stop.AuthToken = allocToken.SecretID
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Alloc.Stop", stop, &stopResp))
+}
+
 func TestAllocEndpoint_GetAlloc_ACL(t *testing.T) {
 	t.Parallel()
 	s1, root := TestACLServer(t, nil)
@@ -89,7 +89,17 @@ func (d *AutopilotDelegate) PromoteNonVoters(conf *autopilot.Config, health auto
 		return nil, fmt.Errorf("failed to get raft configuration: %v", err)
 	}
 
-	return autopilot.PromoteStableServers(conf, health, future.Configuration().Servers), nil
+	// Read replicas are configured as non-voters and must never be promoted
+	readReplicas := d.server.readReplicaIDs()
+	servers := make([]raft.Server, 0, len(future.Configuration().Servers))
+	for _, server := range future.Configuration().Servers {
+		if _, ok := readReplicas[server.ID]; ok {
+			continue
+		}
+		servers = append(servers, server)
+	}
+
+	return autopilot.PromoteStableServers(conf, health, servers), nil
 }
 
 func (d *AutopilotDelegate) Raft() *raft.Raft {
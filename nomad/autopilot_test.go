@@ -349,3 +349,57 @@ func TestAutopilot_PromoteNonVoter(t *testing.T) {
 		}
 	})
 }
+
+func TestAutopilot_ReadReplicaNotPromoted(t *testing.T) {
+	t.Parallel()
+	s1 := TestServer(t, func(c *Config) {
+		c.RaftConfig.ProtocolVersion = 3
+	})
+	defer s1.Shutdown()
+	testutil.WaitForLeader(t, s1.RPC)
+
+	s2 := TestServer(t, func(c *Config) {
+		c.DevDisableBootstrap = true
+		c.RaftConfig.ProtocolVersion = 3
+		c.NonVoter = true
+	})
+	defer s2.Shutdown()
+	TestJoin(t, s1, s2)
+
+	// Wait until the read replica has been healthy for well past the
+	// stabilization period.
+	retry.Run(t, func(r *retry.R) {
+		future := s1.raft.GetConfiguration()
+		if err := future.Error(); err != nil {
+			r.Fatal(err)
+		}
+
+		servers := future.Configuration().Servers
+		if len(servers) != 2 {
+			r.Fatalf("bad: %v", servers)
+		}
+		health := s1.autopilot.GetServerHealth(string(servers[1].ID))
+		if health == nil {
+			r.Fatalf("nil health, %v", s1.autopilot.GetClusterHealth())
+		}
+		if !health.Healthy {
+			r.Fatalf("bad: %v", health)
+		}
+		if time.Since(health.StableSince) < 3*s1.config.AutopilotConfig.ServerStabilizationTime {
+			r.Fatal("stable period not elapsed")
+		}
+	})
+
+	// Make sure it was never promoted.
+	future := s1.raft.GetConfiguration()
+	if err := future.Error(); err != nil {
+		t.Fatal(err)
+	}
+	servers := future.Configuration().Servers
+	if len(servers) != 2 {
+		t.Fatalf("bad: %v", servers)
+	}
+	if servers[1].Suffrage != raft.Nonvoter {
+		t.Fatalf("read replica was promoted: %v", servers)
+	}
+}
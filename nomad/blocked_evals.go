@@ -674,6 +674,77 @@ SCAN:
 	}
 }
 
+// Inspect returns the evaluations that are captured by computed node classes
+// and the evaluations that have escaped them.
+func (b *BlockedEvals) Inspect() (captured, escaped []*structs.BrokerEval) {
+	b.l.RLock()
+	defer b.l.RUnlock()
+
+	for _, wrapped := range b.captured {
+		captured = append(captured, structs.NewBrokerEval(wrapped.eval, "", 0))
+	}
+	for _, wrapped := range b.escaped {
+		escaped = append(escaped, structs.NewBrokerEval(wrapped.eval, "", 0))
+	}
+	return captured, escaped
+}
+
+// Drop stops tracking the blocked evaluation with the given ID. The dropped
+// evaluation is returned, or nil if it is not blocked.
+func (b *BlockedEvals) Drop(evalID string) *structs.Evaluation {
+	b.l.Lock()
+	defer b.l.Unlock()
+
+	wrapped, ok := b.removeLocked(evalID)
+	if !ok {
+		return nil
+	}
+	return wrapped.eval
+}
+
+// Reenqueue unblocks the blocked evaluation with the given ID, enqueuing it
+// into the eval broker regardless of capacity changes. It returns whether the
+// evaluation was blocked.
+func (b *BlockedEvals) Reenqueue(evalID string) bool {
+	b.l.Lock()
+	defer b.l.Unlock()
+
+	wrapped, ok := b.removeLocked(evalID)
+	if !ok {
+		return false
+	}
+
+	b.evalBroker.EnqueueAll(map[*structs.Evaluation]string{wrapped.eval: wrapped.token})
+	return true
+}
+
+// removeLocked removes the evaluation with the given ID from the captured or
+// escaped set and updates the stats. It must be called with the lock held.
+func (b *BlockedEvals) removeLocked(evalID string) (wrappedEval, bool) {
+	wrapped, ok := b.captured[evalID]
+	if ok {
+		delete(b.captured, evalID)
+		if wrapped.eval.Type == structs.JobTypeSystem {
+			b.system.Remove(wrapped.eval)
+		}
+	} else if wrapped, ok = b.escaped[evalID]; ok {
+		delete(b.escaped, evalID)
+		b.stats.TotalEscaped--
+	} else {
+		return wrappedEval{}, false
+	}
+
+	nsID := structs.NewNamespacedID(wrapped.eval.JobID, wrapped.eval.Namespace)
+	if b.jobs[nsID] == evalID {
+		delete(b.jobs, nsID)
+	}
+	b.stats.TotalBlocked--
+	if wrapped.eval.QuotaLimitReached != "" {
+		b.stats.TotalQuotaLimit--
+	}
+	return wrapped, true
+}
+
 // Flush is used to clear the state of blocked evaluations.
 func (b *BlockedEvals) Flush() {
 	b.l.Lock()
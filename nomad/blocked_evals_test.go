@@ -671,3 +671,53 @@ func TestBlockedEvals_SystemDisableFlush(t *testing.T) {
 	require.Empty(t, blocked.system.byJob)
 	require.Empty(t, blocked.system.byNode)
 }
+
+func TestBlockedEvals_Inspect_Drop(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	blocked, _ := testBlockedEvals(t)
+
+	captured := mock.Eval()
+	captured.Status = structs.EvalStatusBlocked
+	captured.QuotaLimitReached = "foo"
+	escaped := mock.Eval()
+	escaped.Status = structs.EvalStatusBlocked
+	escaped.EscapedComputedClass = true
+	blocked.Block(captured)
+	blocked.Block(escaped)
+
+	c, e := blocked.Inspect()
+	require.Len(c, 1)
+	require.Equal(captured.ID, c[0].ID)
+	require.Len(e, 1)
+	require.Equal(escaped.ID, e[0].ID)
+
+	require.Nil(blocked.Drop("foo"))
+	require.Equal(captured, blocked.Drop(captured.ID))
+	require.Equal(escaped, blocked.Drop(escaped.ID))
+
+	stats := blocked.Stats()
+	require.Zero(stats.TotalBlocked)
+	require.Zero(stats.TotalEscaped)
+	require.Zero(stats.TotalQuotaLimit)
+
+	// The jobs are no longer tracked so new blocked evals are accepted
+	blocked.Block(captured.Copy())
+	require.Equal(1, blocked.Stats().TotalBlocked)
+}
+
+func TestBlockedEvals_Reenqueue(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	blocked, broker := testBlockedEvals(t)
+
+	e := mock.Eval()
+	e.Status = structs.EvalStatusBlocked
+	blocked.Block(e)
+
+	require.False(blocked.Reenqueue("foo"))
+	require.True(blocked.Reenqueue(e.ID))
+
+	require.Zero(blocked.Stats().TotalBlocked)
+	require.Equal(1, broker.Stats().TotalReady)
+}
@@ -0,0 +1,342 @@
+package nomad
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net"
+	"time"
+
+	metrics "github.com/armon/go-metrics"
+	log "github.com/hashicorp/go-hclog"
+	cstructs "github.com/hashicorp/nomad/client/structs"
+	"github.com/hashicorp/nomad/command/agent/monitor"
+	"github.com/hashicorp/nomad/command/agent/pprof"
+	"github.com/hashicorp/nomad/helper"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/ugorji/go/codec"
+)
+
+// Agent endpoint is used for accessing the agents of the servers, and of the
+// clients through the servers.
+type Agent struct {
+	srv    *Server
+	logger log.Logger
+}
+
+func (a *Agent) register() {
+	a.srv.streamingRpcs.Register("Agent.Monitor", a.monitor)
+}
+
+// monitor is used to stream the logs of the agent of a server or a client
+func (a *Agent) monitor(conn io.ReadWriteCloser) {
+	defer conn.Close()
+	defer metrics.MeasureSince([]string{"nomad", "agent", "monitor"}, time.Now())
+
+	// Decode the arguments
+	var args cstructs.MonitorRequest
+	decoder := codec.NewDecoder(conn, structs.MsgpackHandle)
+	encoder := codec.NewEncoder(conn, structs.MsgpackHandle)
+
+	if err := decoder.Decode(&args); err != nil {
+		handleStreamResultError(err, helper.Int64ToPtr(500), encoder)
+		return
+	}
+
+	// Check if we need to forward to a different region
+	if r := args.RequestRegion(); r != a.srv.Region() {
+		a.forwardRegionMonitor(conn, encoder, &args)
+		return
+	}
+
+	// Check agent read permissions
+	if aclObj, err := a.srv.ResolveToken(args.AuthToken); err != nil {
+		handleStreamResultError(err, nil, encoder)
+		return
+	} else if aclObj != nil && !aclObj.AllowAgentRead() {
+		handleStreamResultError(structs.ErrPermissionDenied, helper.Int64ToPtr(403), encoder)
+		return
+	}
+
+	logLevel := log.LevelFromString(args.LogLevel)
+	if args.LogLevel == "" {
+		logLevel = log.LevelFromString("INFO")
+	}
+
+	if logLevel == log.NoLevel {
+		handleStreamResultError(errors.New("Unknown log level"), helper.Int64ToPtr(400), encoder)
+		return
+	}
+
+	// Forward the request to the client
+	if args.NodeID != "" {
+		a.forwardMonitorClient(conn, encoder, &args)
+		return
+	}
+
+	// Forward the request to another server
+	if args.ServerID != "" {
+		srv, err := a.findServer(args.ServerID)
+		if err != nil {
+			handleStreamResultError(err, helper.Int64ToPtr(400), encoder)
+			return
+		}
+		if srv != nil {
+			// The leader may change before the request is received
+			args.ServerID = srv.Name
+			a.forwardMonitorServer(conn, encoder, srv, &args)
+			return
+		}
+	}
+
+	m := monitor.New(512, a.srv.config.Logger, &log.LoggerOptions{
+		JSONFormat: args.LogJSON,
+		Level:      logLevel,
+	})
+
+	if err := monitor.Stream(conn, encoder, m, args.PlainText); err != nil {
+		handleStreamResultError(err, helper.Int64ToPtr(500), encoder)
+		return
+	}
+}
+
+// Profile is used to retrieve a runtime profile of a server, or of a client
+// through the server connected to it.
+func (a *Agent) Profile(args *cstructs.AgentPprofRequest, reply *cstructs.AgentPprofResponse) error {
+	// The request targets a specific agent rather than the leader, so it is
+	// only forwarded to a different region.
+	args.QueryOptions.AllowStale = true
+
+	// Potentially forward to a different region.
+	if done, err := a.srv.forward("Agent.Profile", args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "agent", "profile"}, time.Now())
+
+	// Check agent write permissions
+	aclObj, err := a.srv.ResolveToken(args.AuthToken)
+	if err != nil {
+		return err
+	} else if aclObj != nil && !aclObj.AllowAgentWrite() {
+		return structs.ErrPermissionDenied
+	}
+
+	// Forward the request to the client
+	if args.NodeID != "" {
+		return a.forwardProfileClient(args, reply)
+	}
+
+	// Forward the request to another server
+	if args.ServerID != "" {
+		srv, err := a.findServer(args.ServerID)
+		if err != nil {
+			return err
+		}
+		if srv != nil {
+			// The leader may change before the request is received
+			args.ServerID = srv.Name
+			return a.srv.forwardServer(srv, "Agent.Profile", args, reply)
+		}
+	}
+
+	// Without ACLs, profiling requires enable_debug
+	if aclObj == nil && !a.srv.config.EnableDebug {
+		return structs.ErrPermissionDenied
+	}
+
+	var payload []byte
+	var headers map[string]string
+
+	switch args.ReqType {
+	case pprof.CPUReq:
+		payload, headers, err = pprof.CPUProfile(a.srv.shutdownCtx, args.Seconds)
+	case pprof.TraceReq:
+		payload, headers, err = pprof.Trace(a.srv.shutdownCtx, args.Seconds)
+	case pprof.LookupReq:
+		payload, headers, err = pprof.Profile(args.Profile, args.Debug, args.GC)
+	default:
+		err = fmt.Errorf("unknown profile type %q", args.ReqType)
+	}
+	if err != nil {
+		return err
+	}
+
+	reply.AgentID = a.srv.config.NodeID
+	reply.Payload = payload
+	reply.HTTPHeaders = headers
+	return nil
+}
+
+// forwardProfileClient forwards the request to the client of the node, either
+// directly or through the server connected to it.
+func (a *Agent) forwardProfileClient(args *cstructs.AgentPprofRequest, reply *cstructs.AgentPprofResponse) error {
+	// Make sure Node is valid and new enough to support RPC
+	snap, err := a.srv.State().Snapshot()
+	if err != nil {
+		return err
+	}
+
+	if _, err := getNodeForRpc(snap, args.NodeID); err != nil {
+		return err
+	}
+
+	// Get the connection to the client
+	state, ok := a.srv.getNodeConn(args.NodeID)
+	if !ok {
+		// Determine the Server that has a connection to the node.
+		srv, err := a.srv.serverWithNodeConn(args.NodeID, a.srv.Region())
+		if err != nil {
+			return err
+		}
+
+		return a.srv.forwardServer(srv, "Agent.Profile", args, reply)
+	}
+
+	// Make the RPC
+	return NodeRpc(state.Session, "Agent.Profile", args, reply)
+}
+
+// findServer returns the server of the region with the given node name, serf
+// member name or ID. The server ID "leader" designates the leader. It returns
+// nil if the server is the local server.
+func (a *Agent) findServer(serverID string) (*serverParts, error) {
+	if serverID == "leader" {
+		isLeader, leader := a.srv.getLeader()
+		if isLeader {
+			return nil, nil
+		}
+		if leader == nil {
+			return nil, structs.ErrNoLeader
+		}
+		return leader, nil
+	}
+
+	if serverID == a.srv.config.NodeName ||
+		serverID == a.srv.LocalMember().Name ||
+		serverID == a.srv.config.NodeID {
+		return nil, nil
+	}
+
+	// Servers are named after their node name and their region
+	region := a.srv.Region()
+	a.srv.peerLock.RLock()
+	defer a.srv.peerLock.RUnlock()
+	for _, srv := range a.srv.peers[region] {
+		if srv.Name == serverID || srv.Name == serverID+"."+region || srv.ID == serverID {
+			return srv, nil
+		}
+	}
+
+	return nil, fmt.Errorf("unknown nomad server %s", serverID)
+}
+
+// forwardRegionMonitor forwards the request to a server of the region of the
+// request.
+func (a *Agent) forwardRegionMonitor(conn io.ReadWriteCloser, encoder *codec.Encoder, args *cstructs.MonitorRequest) {
+	region := args.RequestRegion()
+
+	a.srv.peerLock.RLock()
+	servers := a.srv.peers[region]
+	if len(servers) == 0 {
+		a.srv.peerLock.RUnlock()
+		handleStreamResultError(structs.ErrNoRegionPath, nil, encoder)
+		return
+	}
+	srv := servers[rand.Intn(len(servers))]
+	a.srv.peerLock.RUnlock()
+
+	a.forwardMonitorServer(conn, encoder, srv, args)
+}
+
+// forwardMonitorServer forwards the request to another server.
+func (a *Agent) forwardMonitorServer(conn io.ReadWriteCloser, encoder *codec.Encoder, srv *serverParts, args *cstructs.MonitorRequest) {
+	srvConn, err := a.srv.streamingRpc(srv, "Agent.Monitor")
+	if err != nil {
+		handleStreamResultError(err, nil, encoder)
+		return
+	}
+	defer srvConn.Close()
+
+	// Send the request.
+	outEncoder := codec.NewEncoder(srvConn, structs.MsgpackHandle)
+	if err := outEncoder.Encode(args); err != nil {
+		handleStreamResultError(err, nil, encoder)
+		return
+	}
+
+	structs.Bridge(conn, srvConn)
+}
+
+// forwardMonitorClient forwards the request to the client of the node, either
+// directly or through the server connected to it.
+func (a *Agent) forwardMonitorClient(conn io.ReadWriteCloser, encoder *codec.Encoder, args *cstructs.MonitorRequest) {
+	nodeID := args.NodeID
+
+	// Make sure Node is valid and new enough to support RPC
+	snap, err := a.srv.State().Snapshot()
+	if err != nil {
+		handleStreamResultError(err, nil, encoder)
+		return
+	}
+
+	node, err := snap.NodeByID(nil, nodeID)
+	if err != nil {
+		handleStreamResultError(err, helper.Int64ToPtr(500), encoder)
+		return
+	}
+
+	if node == nil {
+		err := fmt.Errorf("Unknown node %q", nodeID)
+		handleStreamResultError(err, helper.Int64ToPtr(400), encoder)
+		return
+	}
+
+	if err := nodeSupportsRpc(node); err != nil {
+		handleStreamResultError(err, helper.Int64ToPtr(400), encoder)
+		return
+	}
+
+	// Get the connection to the client either by forwarding to another server
+	// or creating a direct stream
+	var clientConn net.Conn
+	state, ok := a.srv.getNodeConn(nodeID)
+	if !ok {
+		// Determine the Server that has a connection to the node.
+		srv, err := a.srv.serverWithNodeConn(nodeID, a.srv.Region())
+		if err != nil {
+			var code *int64
+			if structs.IsErrNoNodeConn(err) {
+				code = helper.Int64ToPtr(404)
+			}
+			handleStreamResultError(err, code, encoder)
+			return
+		}
+
+		// Get a connection to the server
+		conn, err := a.srv.streamingRpc(srv, "Agent.Monitor")
+		if err != nil {
+			handleStreamResultError(err, nil, encoder)
+			return
+		}
+
+		clientConn = conn
+	} else {
+		stream, err := NodeStreamingRpc(state.Session, "Agent.Monitor")
+		if err != nil {
+			handleStreamResultError(err, nil, encoder)
+			return
+		}
+		clientConn = stream
+	}
+	defer clientConn.Close()
+
+	// Send the request.
+	outEncoder := codec.NewEncoder(clientConn, structs.MsgpackHandle)
+	if err := outEncoder.Encode(args); err != nil {
+		handleStreamResultError(err, nil, encoder)
+		return
+	}
+
+	structs.Bridge(conn, clientConn)
+}
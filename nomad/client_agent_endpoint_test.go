@@ -0,0 +1,512 @@
+package nomad
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/acl"
+	"github.com/hashicorp/nomad/client"
+	"github.com/hashicorp/nomad/client/config"
+	sframer "github.com/hashicorp/nomad/client/lib/streamframer"
+	cstructs "github.com/hashicorp/nomad/client/structs"
+	"github.com/hashicorp/nomad/command/agent/pprof"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/stretchr/testify/require"
+	"github.com/ugorji/go/codec"
+)
+
+// testMonitor makes the monitor request to the handler and calls emit
+// periodically until the streamed logs contain the expected string or an
+// error is received. It returns the error.
+func testMonitor(t *testing.T, handler structs.StreamingRpcHandler,
+	req *cstructs.MonitorRequest, emit func(), expected string) *cstructs.RpcError {
+
+	// Create a pipe
+	p1, p2 := net.Pipe()
+	defer p1.Close()
+	defer p2.Close()
+
+	errCh := make(chan error)
+	streamMsg := make(chan *cstructs.StreamErrWrapper)
+
+	// Start the handler
+	go handler(p2)
+
+	// Start the decoder
+	go func() {
+		decoder := codec.NewDecoder(p1, structs.MsgpackHandle)
+		for {
+			var msg cstructs.StreamErrWrapper
+			if err := decoder.Decode(&msg); err != nil {
+				if err == io.EOF || strings.Contains(err.Error(), "closed") {
+					return
+				}
+				errCh <- fmt.Errorf("error decoding: %v", err)
+			}
+
+			streamMsg <- &msg
+		}
+	}()
+
+	// Send the request
+	encoder := codec.NewEncoder(p1, structs.MsgpackHandle)
+	require.Nil(t, encoder.Encode(req))
+
+	// Emit logs until the monitor receives them
+	doneCh := make(chan struct{})
+	defer close(doneCh)
+	go func() {
+		for {
+			select {
+			case <-doneCh:
+				return
+			case <-time.After(10 * time.Millisecond):
+				emit()
+			}
+		}
+	}()
+
+	timeout := time.After(5 * time.Second)
+	received := ""
+	for {
+		select {
+		case <-timeout:
+			t.Fatalf("timeout waiting for %q, received %q", expected, received)
+		case err := <-errCh:
+			t.Fatal(err)
+		case msg := <-streamMsg:
+			if msg.Error != nil {
+				return msg.Error
+			}
+
+			var frame sframer.StreamFrame
+			err := codec.NewDecoderBytes(msg.Payload, structs.JsonHandle).Decode(&frame)
+			require.NoError(t, err)
+
+			received += string(frame.Data)
+			if strings.Contains(received, expected) {
+				return nil
+			}
+		}
+	}
+}
+
+func TestMonitor_Monitor_Remote_Client(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	// start server and client
+	s1 := TestServer(t, nil)
+	defer s1.Shutdown()
+	s2 := TestServer(t, func(c *Config) {
+		c.DevDisableBootstrap = true
+	})
+	defer s2.Shutdown()
+	TestJoin(t, s1, s2)
+	testutil.WaitForLeader(t, s1.RPC)
+	testutil.WaitForLeader(t, s2.RPC)
+
+	c, cleanup := client.TestClient(t, func(c *config.Config) {
+		c.Servers = []string{s2.GetConfig().RPCAddr.String()}
+	})
+	defer cleanup()
+
+	testutil.WaitForResult(func() (bool, error) {
+		nodes := s2.connectedNodes()
+		return len(nodes) == 1, nil
+	}, func(err error) {
+		t.Fatalf("should have a clients")
+	})
+
+	// Monitor the client through the server it isn't connected to
+	req := &cstructs.MonitorRequest{
+		LogLevel:     "debug",
+		NodeID:       c.NodeID(),
+		QueryOptions: structs.QueryOptions{Region: "global"},
+	}
+
+	handler, err := s1.StreamingRpcHandler("Agent.Monitor")
+	require.Nil(err)
+
+	logger := c.GetConfig().Logger.Named("monitor_test")
+	rpcErr := testMonitor(t, handler, req, func() {
+		logger.Debug("test client log")
+	}, "monitor_test: test client log")
+	require.Nil(rpcErr)
+}
+
+func TestMonitor_Monitor_RemoteServer(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	// start servers
+	s1 := TestServer(t, nil)
+	defer s1.Shutdown()
+	s2 := TestServer(t, func(c *Config) {
+		c.DevDisableBootstrap = true
+	})
+	defer s2.Shutdown()
+	TestJoin(t, s1, s2)
+	testutil.WaitForLeader(t, s1.RPC)
+	testutil.WaitForLeader(t, s2.RPC)
+
+	// Monitor each server through the other one, by name and by ID
+	cases := []struct {
+		name   string
+		via    *Server
+		target *Server
+		id     string
+	}{
+		{
+			name:   "by name",
+			via:    s1,
+			target: s2,
+			id:     s2.config.NodeName,
+		},
+		{
+			name:   "by id",
+			via:    s2,
+			target: s1,
+			id:     s1.config.NodeID,
+		},
+		{
+			name:   "local",
+			via:    s1,
+			target: s1,
+			id:     s1.config.NodeName,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := &cstructs.MonitorRequest{
+				LogLevel:     "debug",
+				ServerID:     tc.id,
+				QueryOptions: structs.QueryOptions{Region: "global"},
+			}
+
+			handler, err := tc.via.StreamingRpcHandler("Agent.Monitor")
+			require.Nil(err)
+
+			logger := tc.target.config.Logger.Named("monitor_test")
+			expected := fmt.Sprintf("test log from %s", tc.target.config.NodeName)
+			rpcErr := testMonitor(t, handler, req, func() {
+				logger.Debug(expected)
+			}, expected)
+			require.Nil(rpcErr)
+		})
+	}
+}
+
+func TestMonitor_Monitor_UnknownServer(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	s := TestServer(t, nil)
+	defer s.Shutdown()
+	testutil.WaitForLeader(t, s.RPC)
+
+	req := &cstructs.MonitorRequest{
+		LogLevel:     "debug",
+		ServerID:     "unknown",
+		QueryOptions: structs.QueryOptions{Region: "global"},
+	}
+
+	handler, err := s.StreamingRpcHandler("Agent.Monitor")
+	require.Nil(err)
+
+	rpcErr := testMonitor(t, handler, req, func() {}, "")
+	require.NotNil(rpcErr)
+	require.Contains(rpcErr.Error(), "unknown nomad server unknown")
+}
+
+func TestMonitor_MonitorServer(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	// start server
+	s := TestServer(t, nil)
+	defer s.Shutdown()
+	testutil.WaitForLeader(t, s.RPC)
+
+	// No node ID to monitor the remote server
+	req := &cstructs.MonitorRequest{
+		LogLevel:     "debug",
+		QueryOptions: structs.QueryOptions{Region: "global"},
+	}
+
+	handler, err := s.StreamingRpcHandler("Agent.Monitor")
+	require.Nil(err)
+
+	rpcErr := testMonitor(t, handler, req, func() {
+		s.logger.Debug("test server log")
+	}, "[DEBUG] nomad: test server log")
+	require.Nil(rpcErr)
+}
+
+func TestMonitor_Monitor_ACL(t *testing.T) {
+	t.Parallel()
+
+	// start server
+	s, root := TestACLServer(t, nil)
+	defer s.Shutdown()
+	testutil.WaitForLeader(t, s.RPC)
+
+	policyBad := mock.NamespacePolicy("other", "", []string{acl.NamespaceCapabilityReadFS})
+	tokenBad := mock.CreatePolicyAndToken(t, s.State(), 1005, "invalid", policyBad)
+
+	policyGood := mock.AgentPolicy(acl.PolicyRead)
+	tokenGood := mock.CreatePolicyAndToken(t, s.State(), 1009, "valid", policyGood)
+
+	cases := []struct {
+		Name        string
+		Token       string
+		ExpectedErr string
+	}{
+		{
+			Name:        "bad token",
+			Token:       tokenBad.SecretID,
+			ExpectedErr: structs.ErrPermissionDenied.Error(),
+		},
+		{
+			Name:        "good token",
+			Token:       tokenGood.SecretID,
+			ExpectedErr: "Unknown log level",
+		},
+		{
+			Name:        "root token",
+			Token:       root.SecretID,
+			ExpectedErr: "Unknown log level",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			req := &cstructs.MonitorRequest{
+				LogLevel: "unknown",
+				QueryOptions: structs.QueryOptions{
+					Namespace: structs.DefaultNamespace,
+					Region:    "global",
+					AuthToken: tc.Token,
+				},
+			}
+
+			handler, err := s.StreamingRpcHandler("Agent.Monitor")
+			require.Nil(t, err)
+
+			rpcErr := testMonitor(t, handler, req, func() {}, "")
+			require.NotNil(t, rpcErr)
+			require.Contains(t, rpcErr.Error(), tc.ExpectedErr)
+		})
+	}
+}
+
+func TestAgentProfile_RemoteClient(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	// start server and client
+	s1 := TestServer(t, func(c *Config) {
+		c.EnableDebug = true
+	})
+	defer s1.Shutdown()
+	s2 := TestServer(t, func(c *Config) {
+		c.DevDisableBootstrap = true
+		c.EnableDebug = true
+	})
+	defer s2.Shutdown()
+	TestJoin(t, s1, s2)
+	testutil.WaitForLeader(t, s1.RPC)
+	testutil.WaitForLeader(t, s2.RPC)
+
+	c, cleanup := client.TestClient(t, func(c *config.Config) {
+		c.Servers = []string{s2.GetConfig().RPCAddr.String()}
+		c.EnableDebug = true
+	})
+	defer cleanup()
+
+	testutil.WaitForResult(func() (bool, error) {
+		nodes := s2.connectedNodes()
+		return len(nodes) == 1, nil
+	}, func(err error) {
+		t.Fatalf("should have a clients")
+	})
+
+	// Profile the client through the server it isn't connected to
+	req := cstructs.AgentPprofRequest{
+		ReqType:      pprof.LookupReq,
+		Profile:      "goroutine",
+		NodeID:       c.NodeID(),
+		QueryOptions: structs.QueryOptions{Region: "global"},
+	}
+
+	reply := cstructs.AgentPprofResponse{}
+
+	err := s1.RPC("Agent.Profile", &req, &reply)
+	require.NoError(err)
+
+	require.NotNil(reply.Payload)
+	require.Equal(c.NodeID(), reply.AgentID)
+}
+
+func TestAgentProfile_RemoteServer(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	// start servers
+	s1 := TestServer(t, func(c *Config) {
+		c.EnableDebug = true
+	})
+	defer s1.Shutdown()
+	s2 := TestServer(t, func(c *Config) {
+		c.DevDisableBootstrap = true
+		c.EnableDebug = true
+	})
+	defer s2.Shutdown()
+	TestJoin(t, s1, s2)
+	testutil.WaitForLeader(t, s1.RPC)
+	testutil.WaitForLeader(t, s2.RPC)
+
+	// Profile each server through the other one, by name and by ID
+	cases := []struct {
+		name   string
+		via    *Server
+		target *Server
+		id     string
+	}{
+		{
+			name:   "by name",
+			via:    s1,
+			target: s2,
+			id:     s2.config.NodeName,
+		},
+		{
+			name:   "by id",
+			via:    s2,
+			target: s1,
+			id:     s1.config.NodeID,
+		},
+		{
+			name:   "local",
+			via:    s1,
+			target: s1,
+			id:     "",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := cstructs.AgentPprofRequest{
+				ReqType:      pprof.LookupReq,
+				Profile:      "heap",
+				ServerID:     tc.id,
+				QueryOptions: structs.QueryOptions{Region: "global"},
+			}
+
+			reply := cstructs.AgentPprofResponse{}
+
+			err := tc.via.RPC("Agent.Profile", &req, &reply)
+			require.NoError(err)
+
+			require.NotNil(reply.Payload)
+			require.Equal(tc.target.config.NodeID, reply.AgentID)
+		})
+	}
+
+	// Unknown servers are rejected
+	req := cstructs.AgentPprofRequest{
+		ReqType:      pprof.LookupReq,
+		Profile:      "heap",
+		ServerID:     "unknown",
+		QueryOptions: structs.QueryOptions{Region: "global"},
+	}
+
+	reply := cstructs.AgentPprofResponse{}
+	err := s1.RPC("Agent.Profile", &req, &reply)
+	require.EqualError(err, "unknown nomad server unknown")
+}
+
+func TestAgentProfile_DefaultDisabled(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	// start server
+	s := TestServer(t, nil)
+	defer s.Shutdown()
+	testutil.WaitForLeader(t, s.RPC)
+
+	req := cstructs.AgentPprofRequest{
+		ReqType:      pprof.CPUReq,
+		QueryOptions: structs.QueryOptions{Region: "global"},
+	}
+
+	reply := cstructs.AgentPprofResponse{}
+
+	err := s.RPC("Agent.Profile", &req, &reply)
+	require.EqualError(err, structs.ErrPermissionDenied.Error())
+}
+
+func TestAgentProfile_ACL(t *testing.T) {
+	t.Parallel()
+
+	// start server
+	s, root := TestACLServer(t, nil)
+	defer s.Shutdown()
+	testutil.WaitForLeader(t, s.RPC)
+
+	policyBad := mock.AgentPolicy(acl.PolicyRead)
+	tokenBad := mock.CreatePolicyAndToken(t, s.State(), 1005, "invalid", policyBad)
+
+	policyGood := mock.AgentPolicy(acl.PolicyWrite)
+	tokenGood := mock.CreatePolicyAndToken(t, s.State(), 1009, "valid", policyGood)
+
+	cases := []struct {
+		Name    string
+		Token   string
+		authErr bool
+	}{
+		{
+			Name:    "bad token",
+			Token:   tokenBad.SecretID,
+			authErr: true,
+		},
+		{
+			Name:  "good token",
+			Token: tokenGood.SecretID,
+		},
+		{
+			Name:  "root token",
+			Token: root.SecretID,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			req := &cstructs.AgentPprofRequest{
+				ReqType: pprof.LookupReq,
+				Profile: "goroutine",
+				QueryOptions: structs.QueryOptions{
+					Namespace: structs.DefaultNamespace,
+					Region:    "global",
+					AuthToken: tc.Token,
+				},
+			}
+
+			reply := &cstructs.AgentPprofResponse{}
+
+			err := s.RPC("Agent.Profile", req, reply)
+			if tc.authErr {
+				require.EqualError(t, err, structs.ErrPermissionDenied.Error())
+			} else {
+				require.NoError(t, err)
+				require.NotNil(t, reply.Payload)
+			}
+		})
+	}
+}
@@ -26,6 +26,7 @@ type ClientAllocations struct {
 
 func (a *ClientAllocations) register() {
 	a.srv.streamingRpcs.Register("Allocations.Exec", a.exec)
+	a.srv.streamingRpcs.Register("Allocations.PortForward", a.portForward)
 }
 
 // GarbageCollectAll is used to garbage collect all allocations on a client.
@@ -90,8 +91,8 @@ func (a *ClientAllocations) Signal(args *structs.AllocSignalRequest, reply *stru
 	// Check node read permissions
 	if aclObj, err := a.srv.ResolveToken(args.AuthToken); err != nil {
 		return err
-	} else if aclObj != nil && !aclObj.AllowNsOp(args.Namespace, acl.NamespaceCapabilityAllocLifecycle) {
-		return structs.ErrPermissionDenied
+	} else if err := checkAllocNsOp(a.srv.State(), aclObj, args.AllocID, args.Namespace, acl.NamespaceCapabilityAllocLifecycle); err != nil {
+		return err
 	}
 
 	// Verify the arguments.
@@ -146,8 +147,8 @@ func (a *ClientAllocations) GarbageCollect(args *structs.AllocSpecificRequest, r
 	// Check node read permissions
 	if aclObj, err := a.srv.ResolveToken(args.AuthToken); err != nil {
 		return err
-	} else if aclObj != nil && !aclObj.AllowNsOp(args.Namespace, acl.NamespaceCapabilitySubmitJob) {
-		return structs.ErrPermissionDenied
+	} else if err := checkAllocNsOp(a.srv.State(), aclObj, args.AllocID, args.Namespace, acl.NamespaceCapabilitySubmitJob); err != nil {
+		return err
 	}
 
 	// Verify the arguments.
@@ -201,8 +202,8 @@ func (a *ClientAllocations) Restart(args *structs.AllocRestartRequest, reply *st
 
 	if aclObj, err := a.srv.ResolveToken(args.AuthToken); err != nil {
 		return err
-	} else if aclObj != nil && !aclObj.AllowNsOp(args.Namespace, acl.NamespaceCapabilityAllocLifecycle) {
-		return structs.ErrPermissionDenied
+	} else if err := checkAllocNsOp(a.srv.State(), aclObj, args.AllocID, args.Namespace, acl.NamespaceCapabilityAllocLifecycle); err != nil {
+		return err
 	}
 
 	// Verify the arguments.
@@ -257,8 +258,8 @@ func (a *ClientAllocations) Stats(args *cstructs.AllocStatsRequest, reply *cstru
 	// Check node read permissions
 	if aclObj, err := a.srv.ResolveToken(args.AuthToken); err != nil {
 		return err
-	} else if aclObj != nil && !aclObj.AllowNsOp(args.Namespace, acl.NamespaceCapabilityReadJob) {
-		return structs.ErrPermissionDenied
+	} else if err := checkAllocNsOp(a.srv.State(), aclObj, args.AllocID, args.Namespace, acl.NamespaceCapabilityReadJob); err != nil {
+		return err
 	}
 
 	// Verify the arguments.
@@ -312,28 +313,52 @@ func (a *ClientAllocations) exec(conn io.ReadWriteCloser) {
 		return
 	}
 
+	a.forwardExecCapable(conn, encoder, "Allocations.Exec", args.AllocID, &args, &args.QueryOptions)
+}
+
+// portForward is used to forward a connection to a port of an allocation
+func (a *ClientAllocations) portForward(conn io.ReadWriteCloser) {
+	defer conn.Close()
+	defer metrics.MeasureSince([]string{"nomad", "alloc", "port_forward"}, time.Now())
+
+	// Decode the arguments
+	var args cstructs.AllocPortForwardRequest
+	decoder := codec.NewDecoder(conn, structs.MsgpackHandle)
+	encoder := codec.NewEncoder(conn, structs.MsgpackHandle)
+
+	if err := decoder.Decode(&args); err != nil {
+		handleStreamResultError(err, helper.Int64ToPtr(500), encoder)
+		return
+	}
+
+	a.forwardExecCapable(conn, encoder, "Allocations.PortForward", args.AllocID, &args, &args.QueryOptions)
+}
+
+// forwardExecCapable forwards a streaming RPC requiring the alloc-exec
+// capability to the client running the allocation, and bridges the
+// connection to the client with the given connection.
+func (a *ClientAllocations) forwardExecCapable(conn io.ReadWriteCloser, encoder *codec.Encoder,
+	method, allocID string, args interface{}, qo *structs.QueryOptions) {
+
 	// Check if we need to forward to a different region
-	if r := args.RequestRegion(); r != a.srv.Region() {
-		forwardRegionStreamingRpc(a.srv, conn, encoder, &args, "Allocations.Exec",
-			args.AllocID, &args.QueryOptions)
+	if r := qo.RequestRegion(); r != a.srv.Region() {
+		forwardRegionStreamingRpc(a.srv, conn, encoder, args, method,
+			allocID, qo)
 		return
 	}
 
 	// Check node read permissions
-	if aclObj, err := a.srv.ResolveToken(args.AuthToken); err != nil {
+	if aclObj, err := a.srv.ResolveToken(qo.AuthToken); err != nil {
 		handleStreamResultError(err, nil, encoder)
 		return
-	} else if aclObj != nil {
+	} else if err := checkAllocNsOp(a.srv.State(), aclObj, allocID, qo.Namespace, acl.NamespaceCapabilityAllocExec); err != nil {
 		// client ultimately checks if AllocNodeExec is required
-		exec := aclObj.AllowNsOp(args.QueryOptions.Namespace, acl.NamespaceCapabilityAllocExec)
-		if !exec {
-			handleStreamResultError(structs.ErrPermissionDenied, nil, encoder)
-			return
-		}
+		handleStreamResultError(err, nil, encoder)
+		return
 	}
 
 	// Verify the arguments.
-	if args.AllocID == "" {
+	if allocID == "" {
 		handleStreamResultError(errors.New("missing AllocID"), helper.Int64ToPtr(400), encoder)
 		return
 	}
@@ -345,13 +370,13 @@ func (a *ClientAllocations) exec(conn io.ReadWriteCloser) {
 		return
 	}
 
-	alloc, err := snap.AllocByID(nil, args.AllocID)
+	alloc, err := snap.AllocByID(nil, allocID)
 	if err != nil {
 		handleStreamResultError(err, nil, encoder)
 		return
 	}
 	if alloc == nil {
-		handleStreamResultError(structs.NewErrUnknownAllocation(args.AllocID), helper.Int64ToPtr(404), encoder)
+		handleStreamResultError(structs.NewErrUnknownAllocation(allocID), helper.Int64ToPtr(404), encoder)
 		return
 	}
 	nodeID := alloc.NodeID
@@ -391,7 +416,7 @@ func (a *ClientAllocations) exec(conn io.ReadWriteCloser) {
 		}
 
 		// Get a connection to the server
-		conn, err := a.srv.streamingRpc(srv, "Allocations.Exec")
+		conn, err := a.srv.streamingRpc(srv, method)
 		if err != nil {
 			handleStreamResultError(err, nil, encoder)
 			return
@@ -399,7 +424,7 @@ func (a *ClientAllocations) exec(conn io.ReadWriteCloser) {
 
 		clientConn = conn
 	} else {
-		stream, err := NodeStreamingRpc(state.Session, "Allocations.Exec")
+		stream, err := NodeStreamingRpc(state.Session, method)
 		if err != nil {
 			handleStreamResultError(err, nil, encoder)
 			return
@@ -991,6 +991,141 @@ func TestClientAllocations_Restart_Remote(t *testing.T) {
 	require.Nil(err)
 }
 
+// TestClientAllocations_CrossNamespace_ACL asserts that the operations on an
+// allocation by ID are authorized against the namespace of the allocation.
+func TestClientAllocations_CrossNamespace_ACL(t *testing.T) {
+	t.Parallel()
+
+	s, _ := TestACLServer(t, nil)
+	defer s.Shutdown()
+	codec := rpcClient(t, s)
+	testutil.WaitForLeader(t, s.RPC)
+
+	alloc := testAllocInNamespace(t, s.State(), 1000)
+
+	caps := []string{acl.NamespaceCapabilityReadJob, acl.NamespaceCapabilitySubmitJob,
+		acl.NamespaceCapabilityAllocLifecycle}
+	defaultToken := mock.CreatePolicyAndToken(t, s.State(), 1005, "default",
+		mock.NamespacePolicy(structs.DefaultNamespace, "", caps))
+	allocToken := mock.CreatePolicyAndToken(t, s.State(), 1007, "alloc",
+		mock.NamespacePolicy(alloc.Namespace, "", caps))
+
+	qo := structs.QueryOptions{
+		Region:    "global",
+		Namespace: structs.DefaultNamespace,
+	}
+	cases := []struct {
+		Method string
+		Req    func(token string) interface{}
+		Resp   interface{}
+	}{
+		{
+			Method: "ClientAllocations.Signal",
+			Req: func(token string) interface{} {
+				req := &structs.AllocSignalRequest{AllocID: alloc.ID, QueryOptions: qo}
+				req.AuthToken = token
+				return req
+			},
+			Resp: &structs.GenericResponse{},
+		},
+		{
+			Method: "ClientAllocations.GarbageCollect",
+			Req: func(token string) interface{} {
+				req := &structs.AllocSpecificRequest{AllocID: alloc.ID, QueryOptions: qo}
+				req.AuthToken = token
+				return req
+			},
+			Resp: &structs.GenericResponse{},
+		},
+		{
+			Method: "ClientAllocations.Restart",
+			Req: func(token string) interface{} {
+				req := &structs.AllocRestartRequest{AllocID: alloc.ID, QueryOptions: qo}
+				req.AuthToken = token
+				return req
+			},
+			Resp: &structs.GenericResponse{},
+		},
+		{
+			Method: "ClientAllocations.Stats",
+			Req: func(token string) interface{} {
+				req := &cstructs.AllocStatsRequest{AllocID: alloc.ID, QueryOptions: qo}
+				req.AuthToken = token
+				return req
+			},
+			Resp: &cstructs.AllocStatsResponse{},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.Method, func(t *testing.T) {
+			// A token of the namespace of the request is denied
+			err := msgpackrpc.CallWithCodec(codec, c.Method, c.Req(defaultToken.SecretID), c.Resp)
+			require.EqualError(t, err, structs.ErrPermissionDenied.Error())
+
+			// A token of the namespace of the allocation is allowed, and the
+			// request fails on the missing node of the allocation
+			err = msgpackrpc.CallWithCodec(codec, c.Method, c.Req(allocToken.SecretID), c.Resp)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), "Unknown node")
+		})
+	}
+}
+
+func TestClientAllocations_CrossNamespace_Exec_ACL(t *testing.T) {
+	t.Parallel()
+
+	s, _ := TestACLServer(t, nil)
+	defer s.Shutdown()
+	testutil.WaitForLeader(t, s.RPC)
+
+	alloc := testAllocInNamespace(t, s.State(), 1000)
+
+	caps := []string{acl.NamespaceCapabilityAllocExec}
+	defaultToken := mock.CreatePolicyAndToken(t, s.State(), 1005, "default",
+		mock.NamespacePolicy(structs.DefaultNamespace, "", caps))
+	allocToken := mock.CreatePolicyAndToken(t, s.State(), 1007, "alloc",
+		mock.NamespacePolicy(alloc.Namespace, "", caps))
+
+	qo := func(token string) structs.QueryOptions {
+		return structs.QueryOptions{
+			Region:    "global",
+			Namespace: structs.DefaultNamespace,
+			AuthToken: token,
+		}
+	}
+	cases := []struct {
+		Method string
+		Req    func(token string) interface{}
+	}{
+		{
+			Method: "Allocations.Exec",
+			Req: func(token string) interface{} {
+				return &cstructs.AllocExecRequest{AllocID: alloc.ID, Task: "web", Cmd: []string{"ls"}, QueryOptions: qo(token)}
+			},
+		},
+		{
+			Method: "Allocations.PortForward",
+			Req: func(token string) interface{} {
+				return &cstructs.AllocPortForwardRequest{AllocID: alloc.ID, Port: "http", QueryOptions: qo(token)}
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.Method, func(t *testing.T) {
+			// A token of the namespace of the request is denied
+			err := testStreamingRpcError(t, s, c.Method, c.Req(defaultToken.SecretID))
+			require.Contains(t, err.Error(), structs.ErrPermissionDenied.Error())
+
+			// A token of the namespace of the allocation is allowed, and the
+			// request fails on the missing node of the allocation
+			err = testStreamingRpcError(t, s, c.Method, c.Req(allocToken.SecretID))
+			require.Contains(t, err.Error(), "Unknown node")
+		})
+	}
+}
+
 func TestClientAllocations_Restart_ACL(t *testing.T) {
 	// Start a server
 	s, root := TestACLServer(t, nil)
@@ -1196,6 +1331,81 @@ func TestAlloc_ExecStreaming(t *testing.T) {
 	}
 }
 
+func TestAlloc_PortForward_ACL(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	// Start a server
+	s, root := TestACLServer(t, nil)
+	defer s.Shutdown()
+	testutil.WaitForLeader(t, s.RPC)
+
+	policyBad := mock.NamespacePolicy(structs.DefaultNamespace, "", []string{acl.NamespaceCapabilityReadJob})
+	tokenBad := mock.CreatePolicyAndToken(t, s.State(), 1005, "invalid", policyBad)
+
+	policyGood := mock.NamespacePolicy(structs.DefaultNamespace, "", []string{acl.NamespaceCapabilityAllocExec})
+	tokenGood := mock.CreatePolicyAndToken(t, s.State(), 1009, "valid", policyGood)
+
+	cases := []struct {
+		Name          string
+		Token         string
+		ExpectedError string
+	}{
+		{
+			Name:          "bad token",
+			Token:         tokenBad.SecretID,
+			ExpectedError: structs.ErrPermissionDenied.Error(),
+		},
+		{
+			Name:          "good token",
+			Token:         tokenGood.SecretID,
+			ExpectedError: structs.ErrUnknownAllocationPrefix,
+		},
+		{
+			Name:          "root token",
+			Token:         root.SecretID,
+			ExpectedError: structs.ErrUnknownAllocationPrefix,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			// Make the request
+			req := &cstructs.AllocPortForwardRequest{
+				AllocID: uuid.Generate(),
+				Port:    "http",
+				QueryOptions: structs.QueryOptions{
+					Region:    "global",
+					AuthToken: c.Token,
+					Namespace: structs.DefaultNamespace,
+				},
+			}
+
+			// Get the handler
+			handler, err := s.StreamingRpcHandler("Allocations.PortForward")
+			require.Nil(err)
+
+			// Create a pipe
+			p1, p2 := net.Pipe()
+			defer p1.Close()
+			defer p2.Close()
+
+			// Start the handler
+			go handler(p2)
+
+			// Send the request
+			encoder := codec.NewEncoder(p1, structs.MsgpackHandle)
+			require.Nil(encoder.Encode(req))
+
+			var msg cstructs.StreamErrWrapper
+			decoder := codec.NewDecoder(p1, structs.MsgpackHandle)
+			require.NoError(decoder.Decode(&msg))
+			require.NotNil(msg.Error)
+			require.Contains(msg.Error.Error(), c.ExpectedError)
+		})
+	}
+}
+
 func decodeFrames(t *testing.T, p1 net.Conn, frames chan<- *drivers.ExecTaskStreamingResponseMsg, errCh chan<- error) {
 	// Start the decoder
 	decoder := codec.NewDecoder(p1, nstructs.MsgpackHandle)
@@ -28,6 +28,8 @@ type FileSystem struct {
 func (f *FileSystem) register() {
 	f.srv.streamingRpcs.Register("FileSystem.Logs", f.logs)
 	f.srv.streamingRpcs.Register("FileSystem.Stream", f.stream)
+	f.srv.streamingRpcs.Register("FileSystem.Download", f.download)
+	f.srv.streamingRpcs.Register("FileSystem.Upload", f.upload)
 }
 
 // handleStreamResultError is a helper for sending an error with a potential
@@ -111,8 +113,8 @@ func (f *FileSystem) List(args *cstructs.FsListRequest, reply *cstructs.FsListRe
 	// Check filesystem read permissions
 	if aclObj, err := f.srv.ResolveToken(args.AuthToken); err != nil {
 		return err
-	} else if aclObj != nil && !aclObj.AllowNsOp(args.Namespace, acl.NamespaceCapabilityReadFS) {
-		return structs.ErrPermissionDenied
+	} else if err := checkAllocNsOp(f.srv.State(), aclObj, args.AllocID, args.Namespace, acl.NamespaceCapabilityReadFS); err != nil {
+		return err
 	}
 
 	// Verify the arguments.
@@ -166,8 +168,8 @@ func (f *FileSystem) Stat(args *cstructs.FsStatRequest, reply *cstructs.FsStatRe
 	// Check filesystem read permissions
 	if aclObj, err := f.srv.ResolveToken(args.AuthToken); err != nil {
 		return err
-	} else if aclObj != nil && !aclObj.AllowNsOp(args.Namespace, acl.NamespaceCapabilityReadFS) {
-		return structs.ErrPermissionDenied
+	} else if err := checkAllocNsOp(f.srv.State(), aclObj, args.AllocID, args.Namespace, acl.NamespaceCapabilityReadFS); err != nil {
+		return err
 	}
 
 	// Verify the arguments.
@@ -232,8 +234,8 @@ func (f *FileSystem) stream(conn io.ReadWriteCloser) {
 	if aclObj, err := f.srv.ResolveToken(args.AuthToken); err != nil {
 		handleStreamResultError(err, nil, encoder)
 		return
-	} else if aclObj != nil && !aclObj.AllowNsOp(args.Namespace, acl.NamespaceCapabilityReadFS) {
-		handleStreamResultError(structs.ErrPermissionDenied, nil, encoder)
+	} else if err := checkAllocNsOp(f.srv.State(), aclObj, args.AllocID, args.Namespace, acl.NamespaceCapabilityReadFS); err != nil {
+		handleStreamResultError(err, nil, encoder)
 		return
 	}
 
@@ -350,13 +352,10 @@ func (f *FileSystem) logs(conn io.ReadWriteCloser) {
 	if aclObj, err := f.srv.ResolveToken(args.AuthToken); err != nil {
 		handleStreamResultError(err, nil, encoder)
 		return
-	} else if aclObj != nil {
-		readfs := aclObj.AllowNsOp(args.QueryOptions.Namespace, acl.NamespaceCapabilityReadFS)
-		logs := aclObj.AllowNsOp(args.QueryOptions.Namespace, acl.NamespaceCapabilityReadLogs)
-		if !readfs && !logs {
-			handleStreamResultError(structs.ErrPermissionDenied, nil, encoder)
-			return
-		}
+	} else if err := checkAllocNsOp(f.srv.State(), aclObj, args.AllocID, args.QueryOptions.Namespace,
+		acl.NamespaceCapabilityReadFS, acl.NamespaceCapabilityReadLogs); err != nil {
+		handleStreamResultError(err, nil, encoder)
+		return
 	}
 
 	// Verify the arguments.
@@ -445,3 +444,151 @@ func (f *FileSystem) logs(conn io.ReadWriteCloser) {
 	structs.Bridge(conn, clientConn)
 	return
 }
+
+// download is used to download a tar archive of a file or directory in an
+// allocation's directory.
+func (f *FileSystem) download(conn io.ReadWriteCloser) {
+	defer conn.Close()
+	defer metrics.MeasureSince([]string{"nomad", "file_system", "download"}, time.Now())
+
+	// Decode the arguments
+	var args cstructs.FsDownloadRequest
+	decoder := codec.NewDecoder(conn, structs.MsgpackHandle)
+	encoder := codec.NewEncoder(conn, structs.MsgpackHandle)
+
+	if err := decoder.Decode(&args); err != nil {
+		handleStreamResultError(err, helper.Int64ToPtr(500), encoder)
+		return
+	}
+
+	f.forwardFileSystemRpc(conn, encoder, "FileSystem.Download", acl.NamespaceCapabilityReadFS,
+		args.AllocID, &args, &args.QueryOptions)
+}
+
+// upload is used to upload a tar archive into a directory of an allocation.
+func (f *FileSystem) upload(conn io.ReadWriteCloser) {
+	defer conn.Close()
+	defer metrics.MeasureSince([]string{"nomad", "file_system", "upload"}, time.Now())
+
+	// Decode the arguments
+	var args cstructs.FsUploadRequest
+	decoder := codec.NewDecoder(conn, structs.MsgpackHandle)
+	encoder := codec.NewEncoder(conn, structs.MsgpackHandle)
+
+	if err := decoder.Decode(&args); err != nil {
+		handleStreamResultError(err, helper.Int64ToPtr(500), encoder)
+		return
+	}
+
+	f.forwardFileSystemRpc(conn, encoder, "FileSystem.Upload", acl.NamespaceCapabilityAllocWriteFS,
+		args.AllocID, &args, &args.QueryOptions)
+}
+
+// forwardFileSystemRpc forwards a streaming RPC requiring the given
+// capability to the client running the allocation, and bridges the
+// connection to the client with the given connection.
+func (f *FileSystem) forwardFileSystemRpc(conn io.ReadWriteCloser, encoder *codec.Encoder,
+	method, capability, allocID string, args interface{}, qo *structs.QueryOptions) {
+
+	// Check if we need to forward to a different region
+	if r := qo.RequestRegion(); r != f.srv.Region() {
+		forwardRegionStreamingRpc(f.srv, conn, encoder, args, method,
+			allocID, qo)
+		return
+	}
+
+	// Check namespace permissions
+	if aclObj, err := f.srv.ResolveToken(qo.AuthToken); err != nil {
+		handleStreamResultError(err, helper.Int64ToPtr(403), encoder)
+		return
+	} else if err := checkAllocNsOp(f.srv.State(), aclObj, allocID, qo.Namespace, capability); err != nil {
+		handleStreamResultError(err, helper.Int64ToPtr(403), encoder)
+		return
+	}
+
+	// Verify the arguments.
+	if allocID == "" {
+		handleStreamResultError(errors.New("missing AllocID"), helper.Int64ToPtr(400), encoder)
+		return
+	}
+
+	// Retrieve the allocation
+	snap, err := f.srv.State().Snapshot()
+	if err != nil {
+		handleStreamResultError(err, helper.Int64ToPtr(500), encoder)
+		return
+	}
+
+	alloc, err := snap.AllocByID(nil, allocID)
+	if err != nil {
+		handleStreamResultError(err, helper.Int64ToPtr(500), encoder)
+		return
+	}
+	if alloc == nil {
+		handleStreamResultError(structs.NewErrUnknownAllocation(allocID), helper.Int64ToPtr(404), encoder)
+		return
+	}
+	nodeID := alloc.NodeID
+
+	// Make sure Node is valid and new enough to support RPC
+	node, err := snap.NodeByID(nil, nodeID)
+	if err != nil {
+		handleStreamResultError(err, helper.Int64ToPtr(500), encoder)
+		return
+	}
+
+	if node == nil {
+		err := fmt.Errorf("Unknown node %q", nodeID)
+		handleStreamResultError(err, helper.Int64ToPtr(400), encoder)
+		return
+	}
+
+	if err := nodeSupportsRpc(node); err != nil {
+		handleStreamResultError(err, helper.Int64ToPtr(400), encoder)
+		return
+	}
+
+	// Get the connection to the client either by forwarding to another server
+	// or creating a direct stream
+	var clientConn net.Conn
+	state, ok := f.srv.getNodeConn(nodeID)
+	if !ok {
+		// Determine the Server that has a connection to the node.
+		srv, err := f.srv.serverWithNodeConn(nodeID, f.srv.Region())
+		if err != nil {
+			code := helper.Int64ToPtr(500)
+			if structs.IsErrNoNodeConn(err) {
+				code = helper.Int64ToPtr(404)
+			}
+			handleStreamResultError(err, code, encoder)
+			return
+		}
+
+		// Get a connection to the server
+		conn, err := f.srv.streamingRpc(srv, method)
+		if err != nil {
+			handleStreamResultError(err, helper.Int64ToPtr(500), encoder)
+			return
+		}
+
+		clientConn = conn
+	} else {
+		stream, err := NodeStreamingRpc(state.Session, method)
+		if err != nil {
+			handleStreamResultError(err, helper.Int64ToPtr(500), encoder)
+			return
+		}
+		clientConn = stream
+	}
+	defer clientConn.Close()
+
+	// Send the request.
+	outEncoder := codec.NewEncoder(clientConn, structs.MsgpackHandle)
+	if err := outEncoder.Encode(args); err != nil {
+		handleStreamResultError(err, helper.Int64ToPtr(500), encoder)
+		return
+	}
+
+	structs.Bridge(conn, clientConn)
+	return
+}
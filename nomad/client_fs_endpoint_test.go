@@ -167,6 +167,126 @@ func TestClientFS_List_ACL(t *testing.T) {
 	}
 }
 
+// TestClientFS_CrossNamespace_ACL asserts that the file system operations on
+// an allocation are authorized against the namespace of the allocation.
+func TestClientFS_CrossNamespace_ACL(t *testing.T) {
+	t.Parallel()
+
+	s, _ := TestACLServer(t, nil)
+	defer s.Shutdown()
+	codec := rpcClient(t, s)
+	testutil.WaitForLeader(t, s.RPC)
+
+	alloc := testAllocInNamespace(t, s.State(), 1000)
+
+	caps := []string{acl.NamespaceCapabilityReadFS, acl.NamespaceCapabilityReadLogs,
+		acl.NamespaceCapabilityAllocWriteFS}
+	defaultToken := mock.CreatePolicyAndToken(t, s.State(), 1005, "default",
+		mock.NamespacePolicy(structs.DefaultNamespace, "", caps))
+	allocToken := mock.CreatePolicyAndToken(t, s.State(), 1007, "alloc",
+		mock.NamespacePolicy(alloc.Namespace, "", caps))
+
+	qo := func(token string) structs.QueryOptions {
+		return structs.QueryOptions{
+			Region:    "global",
+			Namespace: structs.DefaultNamespace,
+			AuthToken: token,
+		}
+	}
+	cases := []struct {
+		Method    string
+		Streaming bool
+		Req       func(token string) interface{}
+		Resp      interface{}
+	}{
+		{
+			Method: "FileSystem.List",
+			Req: func(token string) interface{} {
+				return &cstructs.FsListRequest{AllocID: alloc.ID, Path: "/", QueryOptions: qo(token)}
+			},
+			Resp: &cstructs.FsListResponse{},
+		},
+		{
+			Method: "FileSystem.Stat",
+			Req: func(token string) interface{} {
+				return &cstructs.FsStatRequest{AllocID: alloc.ID, Path: "/", QueryOptions: qo(token)}
+			},
+			Resp: &cstructs.FsStatResponse{},
+		},
+		{
+			Method:    "FileSystem.Stream",
+			Streaming: true,
+			Req: func(token string) interface{} {
+				return &cstructs.FsStreamRequest{AllocID: alloc.ID, Path: "alloc/logs", QueryOptions: qo(token)}
+			},
+		},
+		{
+			Method:    "FileSystem.Logs",
+			Streaming: true,
+			Req: func(token string) interface{} {
+				return &cstructs.FsLogsRequest{AllocID: alloc.ID, Task: "web", LogType: "stdout", QueryOptions: qo(token)}
+			},
+		},
+		{
+			Method:    "FileSystem.Download",
+			Streaming: true,
+			Req: func(token string) interface{} {
+				return &cstructs.FsDownloadRequest{AllocID: alloc.ID, Path: "alloc/logs", QueryOptions: qo(token)}
+			},
+		},
+		{
+			Method:    "FileSystem.Upload",
+			Streaming: true,
+			Req: func(token string) interface{} {
+				return &cstructs.FsUploadRequest{AllocID: alloc.ID, Path: "alloc/data", QueryOptions: qo(token)}
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.Method, func(t *testing.T) {
+			call := func(token string) error {
+				if c.Streaming {
+					return testStreamingRpcError(t, s, c.Method, c.Req(token))
+				}
+				return msgpackrpc.CallWithCodec(codec, c.Method, c.Req(token), c.Resp)
+			}
+
+			// A token of the namespace of the request is denied
+			err := call(defaultToken.SecretID)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), structs.ErrPermissionDenied.Error())
+
+			// A token of the namespace of the allocation is allowed, and the
+			// request fails on the missing node of the allocation
+			err = call(allocToken.SecretID)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), "Unknown node")
+		})
+	}
+}
+
+// testStreamingRpcError sends the request to the streaming RPC handler of the
+// server and returns the error of the first message streamed back.
+func testStreamingRpcError(t *testing.T, s *Server, method string, req interface{}) error {
+	handler, err := s.StreamingRpcHandler(method)
+	require.NoError(t, err)
+
+	p1, p2 := net.Pipe()
+	defer p1.Close()
+	defer p2.Close()
+	go handler(p2)
+
+	encoder := codec.NewEncoder(p1, structs.MsgpackHandle)
+	require.NoError(t, encoder.Encode(req))
+
+	var msg cstructs.StreamErrWrapper
+	decoder := codec.NewDecoder(p1, structs.MsgpackHandle)
+	require.NoError(t, decoder.Decode(&msg))
+	require.NotNil(t, msg.Error)
+	return msg.Error
+}
+
 func TestClientFS_List_Remote(t *testing.T) {
 	t.Parallel()
 	require := require.New(t)
@@ -1270,6 +1390,120 @@ OUTER:
 	}
 }
 
+func TestClientFS_UploadDownload_ACL(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	// Start a server
+	s, root := TestACLServer(t, nil)
+	defer s.Shutdown()
+	testutil.WaitForLeader(t, s.RPC)
+
+	policyRead := mock.NamespacePolicy(structs.DefaultNamespace, "", []string{acl.NamespaceCapabilityReadFS})
+	tokenRead := mock.CreatePolicyAndToken(t, s.State(), 1005, "read", policyRead)
+
+	policyWrite := mock.NamespacePolicy(structs.DefaultNamespace, "", []string{acl.NamespaceCapabilityAllocWriteFS})
+	tokenWrite := mock.CreatePolicyAndToken(t, s.State(), 1009, "write", policyWrite)
+
+	cases := []struct {
+		Name          string
+		Method        string
+		Token         string
+		ExpectedError string
+	}{
+		{
+			Name:          "download without read-fs",
+			Method:        "FileSystem.Download",
+			Token:         tokenWrite.SecretID,
+			ExpectedError: structs.ErrPermissionDenied.Error(),
+		},
+		{
+			Name:          "download with read-fs",
+			Method:        "FileSystem.Download",
+			Token:         tokenRead.SecretID,
+			ExpectedError: structs.ErrUnknownAllocationPrefix,
+		},
+		{
+			Name:          "upload without alloc-write-fs",
+			Method:        "FileSystem.Upload",
+			Token:         tokenRead.SecretID,
+			ExpectedError: structs.ErrPermissionDenied.Error(),
+		},
+		{
+			Name:          "upload with alloc-write-fs",
+			Method:        "FileSystem.Upload",
+			Token:         tokenWrite.SecretID,
+			ExpectedError: structs.ErrUnknownAllocationPrefix,
+		},
+		{
+			Name:          "root token",
+			Method:        "FileSystem.Upload",
+			Token:         root.SecretID,
+			ExpectedError: structs.ErrUnknownAllocationPrefix,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			// Make the request with bad allocation id
+			qo := structs.QueryOptions{
+				Namespace: structs.DefaultNamespace,
+				Region:    "global",
+				AuthToken: c.Token,
+			}
+			var req interface{} = &cstructs.FsDownloadRequest{AllocID: uuid.Generate(), QueryOptions: qo}
+			if c.Method == "FileSystem.Upload" {
+				req = &cstructs.FsUploadRequest{AllocID: uuid.Generate(), QueryOptions: qo}
+			}
+
+			// Get the handler
+			handler, err := s.StreamingRpcHandler(c.Method)
+			require.Nil(err)
+
+			// Create a pipe
+			p1, p2 := net.Pipe()
+			defer p1.Close()
+			defer p2.Close()
+
+			errCh := make(chan error)
+			streamMsg := make(chan *cstructs.StreamErrWrapper)
+
+			// Start the handler
+			go handler(p2)
+
+			// Start the decoder
+			go func() {
+				decoder := codec.NewDecoder(p1, structs.MsgpackHandle)
+				for {
+					var msg cstructs.StreamErrWrapper
+					if err := decoder.Decode(&msg); err != nil {
+						if err == io.EOF || strings.Contains(err.Error(), "closed") {
+							return
+						}
+						errCh <- fmt.Errorf("error decoding: %v", err)
+					}
+
+					streamMsg <- &msg
+				}
+			}()
+
+			// Send the request
+			encoder := codec.NewEncoder(p1, structs.MsgpackHandle)
+			require.Nil(encoder.Encode(req))
+
+			select {
+			case <-time.After(5 * time.Second):
+				t.Fatal("timeout")
+			case err := <-errCh:
+				t.Fatal(err)
+			case msg := <-streamMsg:
+				require.NotNil(msg.Error)
+				require.Contains(msg.Error.Error(), c.ExpectedError)
+			}
+		})
+	}
+}
+
 func TestClientFS_Logs_NoAlloc(t *testing.T) {
 	t.Parallel()
 	require := require.New(t)
@@ -8,9 +8,8 @@ import (
 	"runtime"
 	"time"
 
-	log "github.com/hashicorp/go-hclog"
-
 	"github.com/hashicorp/memberlist"
+	"github.com/hashicorp/nomad/helper/logging"
 	"github.com/hashicorp/nomad/helper/pluginutils/loader"
 	"github.com/hashicorp/nomad/helper/uuid"
 	"github.com/hashicorp/nomad/nomad/structs"
@@ -63,6 +62,10 @@ type Config struct {
 	// DataDir is the directory to store our state in
 	DataDir string
 
+	// EnableDebug is used to enable debugging RPC endpoints
+	// in the absence of ACLs
+	EnableDebug bool
+
 	// DevMode is used for development purposes only and limits the
 	// use of persistence or state.
 	DevMode bool
@@ -76,7 +79,7 @@ type Config struct {
 	LogOutput io.Writer
 
 	// Logger is the logger used by the server.
-	Logger log.Logger
+	Logger logging.InterceptLogger
 
 	// ProtocolVersion is the protocol version to speak. This must be between
 	// ProtocolVersionMin and ProtocolVersionMax.
@@ -104,8 +107,8 @@ type Config struct {
 	// RaftTimeout is applied to any network traffic for raft. Defaults to 10s.
 	RaftTimeout time.Duration
 
-	// (Enterprise-only) NonVoter is used to prevent this server from being added
-	// as a voting member of the Raft cluster.
+	// NonVoter is used to prevent this server from being added as a voting
+	// member of the Raft cluster. Non-voting servers act as read replicas.
 	NonVoter bool
 
 	// (Enterprise-only) RedundancyZone is the redundancy zone to use for this server.
@@ -169,6 +172,11 @@ type Config struct {
 	// the user time to inspect the job.
 	JobGCThreshold time.Duration
 
+	// DispatchTokenRetention is how long an idempotency token used to
+	// dispatch a parameterized job is honored. Dispatching again with the
+	// token within this period returns the previously dispatched job.
+	DispatchTokenRetention time.Duration
+
 	// NodeGCInterval is how often we dispatch a job to GC failed nodes.
 	NodeGCInterval time.Duration
 
@@ -272,6 +280,10 @@ type Config struct {
 	// SentinelConfig is this Agent's Sentinel configuration
 	SentinelConfig *config.SentinelConfig
 
+	// AdmissionWebhooks are the external webhooks called, in order, to
+	// mutate or validate jobs when they are registered or planned.
+	AdmissionWebhooks []*config.AdmissionWebhookConfig
+
 	// StatsCollectionInterval is the interval at which the Nomad server
 	// publishes metrics which are periodic in nature like updating gauges
 	StatsCollectionInterval time.Duration
@@ -346,6 +358,7 @@ func DefaultConfig() *Config {
 		EvalGCThreshold:                  1 * time.Hour,
 		JobGCInterval:                    5 * time.Minute,
 		JobGCThreshold:                   4 * time.Hour,
+		DispatchTokenRetention:           24 * time.Hour,
 		NodeGCInterval:                   5 * time.Minute,
 		NodeGCThreshold:                  24 * time.Hour,
 		DeploymentGCInterval:             5 * time.Minute,
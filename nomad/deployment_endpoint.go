@@ -27,11 +27,11 @@ func (d *Deployment) GetDeployment(args *structs.DeploymentSpecificRequest,
 	}
 	defer metrics.MeasureSince([]string{"nomad", "deployment", "get_deployment"}, time.Now())
 
-	// Check namespace read-job permissions
-	if aclObj, err := d.srv.ResolveToken(args.AuthToken); err != nil {
+	// Resolve the token, the namespace read-job permissions are checked once
+	// the deployment is found
+	aclObj, err := d.srv.ResolveToken(args.AuthToken)
+	if err != nil {
 		return err
-	} else if aclObj != nil && !aclObj.AllowNsOp(args.RequestNamespace(), acl.NamespaceCapabilityReadJob) {
-		return structs.ErrPermissionDenied
 	}
 
 	// Setup the blocking query
@@ -50,6 +50,18 @@ func (d *Deployment) GetDeployment(args *structs.DeploymentSpecificRequest,
 				return err
 			}
 
+			// Check the permissions against the namespace of the deployment
+			// rather than the namespace of the request
+			if aclObj != nil {
+				ns := args.RequestNamespace()
+				if out != nil {
+					ns = out.Namespace
+				}
+				if !aclObj.AllowNsOp(ns, acl.NamespaceCapabilityReadJob) {
+					return structs.ErrPermissionDenied
+				}
+			}
+
 			// Setup the output
 			reply.Deployment = out
 			if out != nil {
@@ -77,11 +89,11 @@ func (d *Deployment) Fail(args *structs.DeploymentFailRequest, reply *structs.De
 	}
 	defer metrics.MeasureSince([]string{"nomad", "deployment", "fail"}, time.Now())
 
-	// Check namespace submit-job permissions
-	if aclObj, err := d.srv.ResolveToken(args.AuthToken); err != nil {
+	// Resolve the token, the namespace submit-job permissions are checked
+	// once the deployment is found
+	aclObj, err := d.srv.ResolveToken(args.AuthToken)
+	if err != nil {
 		return err
-	} else if aclObj != nil && !aclObj.AllowNsOp(args.RequestNamespace(), acl.NamespaceCapabilitySubmitJob) {
-		return structs.ErrPermissionDenied
 	}
 
 	// Validate the arguments
@@ -100,6 +112,18 @@ func (d *Deployment) Fail(args *structs.DeploymentFailRequest, reply *structs.De
 	if err != nil {
 		return err
 	}
+
+	// Check the permissions against the namespace of the deployment rather
+	// than the namespace of the request
+	if aclObj != nil {
+		ns := args.RequestNamespace()
+		if deploy != nil {
+			ns = deploy.Namespace
+		}
+		if !aclObj.AllowNsOp(ns, acl.NamespaceCapabilitySubmitJob) {
+			return structs.ErrPermissionDenied
+		}
+	}
 	if deploy == nil {
 		return fmt.Errorf("deployment not found")
 	}
@@ -119,11 +143,11 @@ func (d *Deployment) Pause(args *structs.DeploymentPauseRequest, reply *structs.
 	}
 	defer metrics.MeasureSince([]string{"nomad", "deployment", "pause"}, time.Now())
 
-	// Check namespace submit-job permissions
-	if aclObj, err := d.srv.ResolveToken(args.AuthToken); err != nil {
+	// Resolve the token, the namespace submit-job permissions are checked
+	// once the deployment is found
+	aclObj, err := d.srv.ResolveToken(args.AuthToken)
+	if err != nil {
 		return err
-	} else if aclObj != nil && !aclObj.AllowNsOp(args.RequestNamespace(), acl.NamespaceCapabilitySubmitJob) {
-		return structs.ErrPermissionDenied
 	}
 
 	// Validate the arguments
@@ -142,6 +166,18 @@ func (d *Deployment) Pause(args *structs.DeploymentPauseRequest, reply *structs.
 	if err != nil {
 		return err
 	}
+
+	// Check the permissions against the namespace of the deployment rather
+	// than the namespace of the request
+	if aclObj != nil {
+		ns := args.RequestNamespace()
+		if deploy != nil {
+			ns = deploy.Namespace
+		}
+		if !aclObj.AllowNsOp(ns, acl.NamespaceCapabilitySubmitJob) {
+			return structs.ErrPermissionDenied
+		}
+	}
 	if deploy == nil {
 		return fmt.Errorf("deployment not found")
 	}
@@ -165,11 +201,11 @@ func (d *Deployment) Promote(args *structs.DeploymentPromoteRequest, reply *stru
 	}
 	defer metrics.MeasureSince([]string{"nomad", "deployment", "promote"}, time.Now())
 
-	// Check namespace submit-job permissions
-	if aclObj, err := d.srv.ResolveToken(args.AuthToken); err != nil {
+	// Resolve the token, the namespace submit-job permissions are checked
+	// once the deployment is found
+	aclObj, err := d.srv.ResolveToken(args.AuthToken)
+	if err != nil {
 		return err
-	} else if aclObj != nil && !aclObj.AllowNsOp(args.RequestNamespace(), acl.NamespaceCapabilitySubmitJob) {
-		return structs.ErrPermissionDenied
 	}
 
 	// Validate the arguments
@@ -188,6 +224,18 @@ func (d *Deployment) Promote(args *structs.DeploymentPromoteRequest, reply *stru
 	if err != nil {
 		return err
 	}
+
+	// Check the permissions against the namespace of the deployment rather
+	// than the namespace of the request
+	if aclObj != nil {
+		ns := args.RequestNamespace()
+		if deploy != nil {
+			ns = deploy.Namespace
+		}
+		if !aclObj.AllowNsOp(ns, acl.NamespaceCapabilitySubmitJob) {
+			return structs.ErrPermissionDenied
+		}
+	}
 	if deploy == nil {
 		return fmt.Errorf("deployment not found")
 	}
@@ -208,11 +256,11 @@ func (d *Deployment) SetAllocHealth(args *structs.DeploymentAllocHealthRequest,
 	}
 	defer metrics.MeasureSince([]string{"nomad", "deployment", "set_alloc_health"}, time.Now())
 
-	// Check namespace submit-job permissions
-	if aclObj, err := d.srv.ResolveToken(args.AuthToken); err != nil {
+	// Resolve the token, the namespace submit-job permissions are checked
+	// once the deployment is found
+	aclObj, err := d.srv.ResolveToken(args.AuthToken)
+	if err != nil {
 		return err
-	} else if aclObj != nil && !aclObj.AllowNsOp(args.RequestNamespace(), acl.NamespaceCapabilitySubmitJob) {
-		return structs.ErrPermissionDenied
 	}
 
 	// Validate the arguments
@@ -235,6 +283,18 @@ func (d *Deployment) SetAllocHealth(args *structs.DeploymentAllocHealthRequest,
 	if err != nil {
 		return err
 	}
+
+	// Check the permissions against the namespace of the deployment rather
+	// than the namespace of the request
+	if aclObj != nil {
+		ns := args.RequestNamespace()
+		if deploy != nil {
+			ns = deploy.Namespace
+		}
+		if !aclObj.AllowNsOp(ns, acl.NamespaceCapabilitySubmitJob) {
+			return structs.ErrPermissionDenied
+		}
+	}
 	if deploy == nil {
 		return fmt.Errorf("deployment not found")
 	}
@@ -278,16 +338,28 @@ func (d *Deployment) List(args *structs.DeploymentListRequest, reply *structs.De
 				return err
 			}
 
+			paginator, err := newPaginator(iter, args.QueryOptions,
+				func(raw interface{}) string {
+					return raw.(*structs.Deployment).ID
+				},
+				func(raw interface{}) (interface{}, error) {
+					return raw, nil
+				})
+			if err != nil {
+				return err
+			}
+
+			stubs, nextToken, err := paginator.page()
+			if err != nil {
+				return err
+			}
+
 			var deploys []*structs.Deployment
-			for {
-				raw := iter.Next()
-				if raw == nil {
-					break
-				}
-				deploy := raw.(*structs.Deployment)
-				deploys = append(deploys, deploy)
+			for _, stub := range stubs {
+				deploys = append(deploys, stub.(*structs.Deployment))
 			}
 			reply.Deployments = deploys
+			reply.NextToken = nextToken
 
 			// Use the last index that affected the deployment table
 			index, err := state.Index("deployment")
@@ -310,11 +382,11 @@ func (d *Deployment) Allocations(args *structs.DeploymentSpecificRequest, reply
 	}
 	defer metrics.MeasureSince([]string{"nomad", "deployment", "allocations"}, time.Now())
 
-	// Check namespace read-job permissions
-	if aclObj, err := d.srv.ResolveToken(args.AuthToken); err != nil {
+	// Resolve the token, the namespace read-job permissions are checked once
+	// the deployment is found
+	aclObj, err := d.srv.ResolveToken(args.AuthToken)
+	if err != nil {
 		return err
-	} else if aclObj != nil && !aclObj.AllowNsOp(args.RequestNamespace(), acl.NamespaceCapabilityReadJob) {
-		return structs.ErrPermissionDenied
 	}
 
 	// Setup the blocking query
@@ -322,6 +394,22 @@ func (d *Deployment) Allocations(args *structs.DeploymentSpecificRequest, reply
 		queryOpts: &args.QueryOptions,
 		queryMeta: &reply.QueryMeta,
 		run: func(ws memdb.WatchSet, state *state.StateStore) error {
+			// Check the permissions against the namespace of the deployment
+			// rather than the namespace of the request
+			if aclObj != nil {
+				deploy, err := state.DeploymentByID(ws, args.DeploymentID)
+				if err != nil {
+					return err
+				}
+				ns := args.RequestNamespace()
+				if deploy != nil {
+					ns = deploy.Namespace
+				}
+				if !aclObj.AllowNsOp(ns, acl.NamespaceCapabilityReadJob) {
+					return structs.ErrPermissionDenied
+				}
+			}
+
 			// Capture all the allocations
 			allocs, err := state.AllocsByDeployment(ws, args.DeploymentID)
 			if err != nil {
@@ -12,6 +12,7 @@ import (
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/hashicorp/nomad/testutil"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestDeploymentEndpoint_GetDeployment(t *testing.T) {
@@ -390,6 +391,70 @@ func TestDeploymentEndpoint_Pause(t *testing.T) {
 	assert.Equal(dout.ModifyIndex, resp.DeploymentModifyIndex, "wrong modify index")
 }
 
+func TestDeploymentWatcherRPCShim_RunRegionDeployment(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	s1 := TestServer(t, func(c *Config) {
+		c.Region = "west"
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer s1.Shutdown()
+	s2 := TestServer(t, func(c *Config) {
+		c.Region = "east"
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer s2.Shutdown()
+	TestJoin(t, s1, s2)
+	testutil.WaitForLeader(t, s1.RPC)
+	testutil.WaitForLeader(t, s2.RPC)
+
+	// Create a deployment in the east region that is pending the west region
+	j := mock.Job()
+	j.Region = "east"
+	state := s2.fsm.State()
+	require.NoError(state.UpsertJob(999, j))
+
+	d := mock.Deployment()
+	d.JobID = j.ID
+	d.JobCreateIndex = j.CreateIndex
+	d.Status = structs.DeploymentStatusPaused
+	d.StatusDescription = structs.DeploymentStatusDescriptionPendingRegion
+	require.NoError(state.UpsertDeployment(1000, d))
+
+	shim := &deploymentWatcherRPCShim{srv: s1}
+
+	// A region without the job has no deployment to start
+	active, err := shim.RunRegionDeployment("west", j.Namespace, j.ID)
+	require.NoError(err)
+	require.False(active)
+
+	// The pending deployment is resumed
+	active, err = shim.RunRegionDeployment("east", j.Namespace, j.ID)
+	require.NoError(err)
+	require.True(active)
+
+	dout, err := state.DeploymentByID(nil, d.ID)
+	require.NoError(err)
+	require.Equal(structs.DeploymentStatusRunning, dout.Status)
+
+	// Deployments paused by an operator are left alone
+	d2 := mock.Deployment()
+	d2.JobID = j.ID
+	d2.JobCreateIndex = j.CreateIndex
+	d2.Status = structs.DeploymentStatusPaused
+	d2.StatusDescription = structs.DeploymentStatusDescriptionPaused
+	require.NoError(state.UpsertDeployment(dout.ModifyIndex+1, d2))
+
+	active, err = shim.RunRegionDeployment("east", j.Namespace, j.ID)
+	require.NoError(err)
+	require.True(active)
+
+	dout, err = state.DeploymentByID(nil, d2.ID)
+	require.NoError(err)
+	require.Equal(structs.DeploymentStatusPaused, dout.Status)
+}
+
 func TestDeploymentEndpoint_Pause_ACL(t *testing.T) {
 	t.Parallel()
 	s1, _ := TestACLServer(t, func(c *Config) {
@@ -1323,3 +1388,76 @@ func TestDeploymentEndpoint_Reap(t *testing.T) {
 	assert.Nil(err, "DeploymentByID")
 	assert.Nil(outD, "Deleted Deployment")
 }
+
+// TestDeploymentEndpoint_CrossNamespace_ACL asserts deployments are authorized
+// against their own namespace rather than the namespace of the request.
+func TestDeploymentEndpoint_CrossNamespace_ACL(t *testing.T) {
+	t.Parallel()
+	s1, _ := TestACLServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer s1.Shutdown()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+	require := require.New(t)
+
+	// Create the deployment in another namespace
+	ns := mock.Namespace()
+	j := mock.Job()
+	j.Namespace = ns.Name
+	d := mock.Deployment()
+	d.Namespace = ns.Name
+	d.JobID = j.ID
+	a := mock.Alloc()
+	a.Namespace = ns.Name
+	a.JobID = j.ID
+	a.DeploymentID = d.ID
+	state := s1.fsm.State()
+	require.NoError(state.UpsertNamespaces(998, []*structs.Namespace{ns}))
+	require.NoError(state.UpsertJob(999, j))
+	require.NoError(state.UpsertDeployment(1000, d))
+	require.NoError(state.UpsertAllocs(1001, []*structs.Allocation{a}))
+
+	// The token may operate on the default namespace only
+	caps := []string{acl.NamespaceCapabilityReadJob, acl.NamespaceCapabilitySubmitJob}
+	defaultToken := mock.CreatePolicyAndToken(t, state, 1002, "test-default",
+		mock.NamespacePolicy(structs.DefaultNamespace, "", caps))
+	nsToken := mock.CreatePolicyAndToken(t, state, 1003, "test-ns",
+		mock.NamespacePolicy(ns.Name, "", caps))
+
+	write := structs.WriteRequest{Region: "global", AuthToken: defaultToken.SecretID}
+	query := structs.QueryOptions{Region: "global", AuthToken: defaultToken.SecretID}
+	cases := map[string]interface{}{
+		"Deployment.GetDeployment": &structs.DeploymentSpecificRequest{DeploymentID: d.ID, QueryOptions: query},
+		"Deployment.Allocations":   &structs.DeploymentSpecificRequest{DeploymentID: d.ID, QueryOptions: query},
+		"Deployment.Fail":          &structs.DeploymentFailRequest{DeploymentID: d.ID, WriteRequest: write},
+		"Deployment.Pause":         &structs.DeploymentPauseRequest{DeploymentID: d.ID, Pause: true, WriteRequest: write},
+		"Deployment.Promote":       &structs.DeploymentPromoteRequest{DeploymentID: d.ID, All: true, WriteRequest: write},
+		"Deployment.SetAllocHealth": &structs.DeploymentAllocHealthRequest{
+			DeploymentID:         d.ID,
+			HealthyAllocationIDs: []string{a.ID},
+			WriteRequest:         write,
+		},
+	}
+	for method, req := range cases {
+		var resp structs.GenericResponse
+		err := msgpackrpc.CallWithCodec(codec, method, req, &resp)
+		require.Error(err, method)
+		require.Equal(structs.ErrPermissionDenied.Error(), err.Error(), method)
+	}
+
+	// A token for the namespace of the deployment may read it even though
+	// the request is for the default namespace
+	get := &structs.DeploymentSpecificRequest{
+		DeploymentID: d.ID,
+		QueryOptions: structs.QueryOptions{Region: "global", AuthToken: nsToken.SecretID},
+	}
+	var resp structs.SingleDeploymentResponse
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Deployment.GetDeployment", get, &resp))
+	require.NotNil(resp.Deployment)
+	require.Equal(d.ID, resp.Deployment.ID)
+
+	var allocs structs.AllocListResponse
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Deployment.Allocations", get, &allocs))
+	require.Len(allocs.Allocations, 1)
+}
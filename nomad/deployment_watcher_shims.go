@@ -53,3 +53,51 @@ func (d *deploymentWatcherRaftShim) UpdateAllocDesiredTransition(req *structs.Al
 	fsmErrIntf, index, raftErr := d.apply(structs.AllocUpdateDesiredTransitionRequestType, req)
 	return d.convertApplyErrors(fsmErrIntf, index, raftErr)
 }
+
+// deploymentWatcherRPCShim is the shim that provides the deployment watcher
+// with RPCs against the other regions of multiregion jobs.
+type deploymentWatcherRPCShim struct {
+	srv *Server
+}
+
+func (d *deploymentWatcherRPCShim) RunRegionDeployment(region, namespace, jobID string) (bool, error) {
+	args := &structs.JobSpecificRequest{
+		JobID: jobID,
+		QueryOptions: structs.QueryOptions{
+			Region:    region,
+			Namespace: namespace,
+			AuthToken: d.srv.ReplicationToken(),
+		},
+	}
+	var resp structs.SingleDeploymentResponse
+	if err := d.srv.RPC("Job.LatestDeployment", args, &resp); err != nil {
+		return false, err
+	}
+
+	deploy := resp.Deployment
+	if deploy == nil || !deploy.Active() {
+		return false, nil
+	}
+
+	// Only resume deployments that are waiting on an earlier region rather
+	// than ones paused by an operator
+	if deploy.Status != structs.DeploymentStatusPaused ||
+		deploy.StatusDescription != structs.DeploymentStatusDescriptionPendingRegion {
+		return true, nil
+	}
+
+	req := &structs.DeploymentPauseRequest{
+		DeploymentID: deploy.ID,
+		Pause:        false,
+		WriteRequest: structs.WriteRequest{
+			Region:    region,
+			Namespace: namespace,
+			AuthToken: d.srv.ReplicationToken(),
+		},
+	}
+	var presp structs.DeploymentUpdateResponse
+	if err := d.srv.RPC("Deployment.Pause", req, &presp); err != nil {
+		return false, err
+	}
+	return true, nil
+}
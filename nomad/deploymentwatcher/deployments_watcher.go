@@ -24,6 +24,16 @@ const (
 	// desired transition and evaluation creation updates are batched across
 	// all deployment watchers before committing to Raft.
 	CrossDeploymentUpdateBatchDuration = 250 * time.Millisecond
+
+	// multiregionRunAttempts is the number of times the next region of a
+	// multiregion job is checked for a pending deployment before it is
+	// skipped. The region's scheduler may not have created the deployment by
+	// the time the previous region completes.
+	multiregionRunAttempts = 6
+
+	// multiregionRunInterval is the interval between attempts to start the
+	// deployment of the next region of a multiregion job.
+	multiregionRunInterval = 5 * time.Second
 )
 
 var (
@@ -54,6 +64,15 @@ type DeploymentRaftEndpoints interface {
 	UpdateAllocDesiredTransition(req *structs.AllocUpdateDesiredTransitionRequest) (uint64, error)
 }
 
+// DeploymentRPC exposes the deployment watcher to a set of functions that are
+// made against other regions as RPCs.
+type DeploymentRPC interface {
+	// RunRegionDeployment resumes the deployment of the job in the given
+	// region if it is pending the completion of an earlier region. It returns
+	// whether the region has an active deployment of the job.
+	RunRegionDeployment(region, namespace, jobID string) (bool, error)
+}
+
 // Watcher is used to watch deployments and their allocations created
 // by the scheduler and trigger the scheduler when allocation health
 // transitions.
@@ -72,6 +91,10 @@ type Watcher struct {
 	// deployments watcher
 	raft DeploymentRaftEndpoints
 
+	// rpc is used to start the deployments of the next region of multiregion
+	// jobs
+	rpc DeploymentRPC
+
 	// state is the state that is watched for state changes.
 	state *state.StateStore
 
@@ -92,11 +115,12 @@ type Watcher struct {
 // NewDeploymentsWatcher returns a deployments watcher that is used to watch
 // deployments and trigger the scheduler as needed.
 func NewDeploymentsWatcher(logger log.Logger,
-	raft DeploymentRaftEndpoints, stateQueriesPerSecond float64,
+	raft DeploymentRaftEndpoints, rpc DeploymentRPC, stateQueriesPerSecond float64,
 	updateBatchDuration time.Duration) *Watcher {
 
 	return &Watcher{
 		raft:                raft,
+		rpc:                 rpc,
 		queryLimiter:        rate.NewLimiter(rate.Limit(stateQueriesPerSecond), 100),
 		updateBatchDuration: updateBatchDuration,
 		logger:              logger.Named("deployments_watcher"),
@@ -271,7 +295,53 @@ func (w *Watcher) remove(d *structs.Deployment) {
 	if watcher, ok := w.watchers[d.ID]; ok {
 		watcher.StopWatch()
 		delete(w.watchers, d.ID)
+
+		// Completing the deployment of one region of a multiregion job starts
+		// the deployment in the next region
+		if d.Status == structs.DeploymentStatusSuccessful && watcher.j.IsMultiregion() {
+			go w.runNextRegion(w.ctx, watcher.j)
+		}
+	}
+}
+
+// runNextRegion starts the deployment of the region that follows the job's
+// region in its multiregion rollout. Regions without a pending deployment of
+// the job, such as those where the job did not change, are skipped.
+func (w *Watcher) runNextRegion(ctx context.Context, job *structs.Job) {
+	if w.rpc == nil {
+		return
 	}
+
+	mr := job.Multiregion
+	for region := mr.Successor(job.Region); region != ""; region = mr.Successor(region) {
+		if w.runRegion(ctx, job, region) || ctx.Err() != nil {
+			return
+		}
+	}
+}
+
+// runRegion attempts to start the pending deployment of the job in the given
+// region and returns whether the region has an active deployment.
+func (w *Watcher) runRegion(ctx context.Context, job *structs.Job, region string) bool {
+	logger := w.logger.With("job", job.NamespacedID(), "region", region)
+	for attempt := 0; attempt < multiregionRunAttempts; attempt++ {
+		active, err := w.rpc.RunRegionDeployment(region, job.Namespace, job.ID)
+		if err != nil {
+			logger.Warn("failed to start multiregion deployment", "error", err)
+		} else if active {
+			logger.Debug("started multiregion deployment")
+			return true
+		}
+
+		select {
+		case <-ctx.Done():
+			return false
+		case <-time.After(multiregionRunInterval):
+		}
+	}
+
+	logger.Warn("skipping region without a pending multiregion deployment")
+	return false
 }
 
 // forceAdd is used to force a lookup of the given deployment object and create
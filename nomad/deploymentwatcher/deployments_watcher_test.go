@@ -19,7 +19,7 @@ import (
 
 func testDeploymentWatcher(t *testing.T, qps float64, batchDur time.Duration) (*Watcher, *mockBackend) {
 	m := newMockBackend(t)
-	w := NewDeploymentsWatcher(testlog.HCLogger(t), m, qps, batchDur)
+	w := NewDeploymentsWatcher(testlog.HCLogger(t), m, m, qps, batchDur)
 	return w, m
 }
 
@@ -78,6 +78,49 @@ func TestWatcher_WatchDeployments(t *testing.T) {
 		func(err error) { require.Equal(3, watchersCount(w), "3 deployment returned - 1 terminal") })
 }
 
+// Tests that completing the deployment of one region of a multiregion job
+// starts the deployment in the next region
+func TestWatcher_MultiregionRunNextRegion(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	w, m := defaultTestDeploymentWatcher(t)
+
+	j := mock.Job()
+	j.Region = "west"
+	j.Multiregion = &structs.Multiregion{
+		Strategy: &structs.MultiregionStrategy{MaxParallel: 1},
+		Regions: []*structs.MultiregionRegion{
+			{Name: "west"},
+			{Name: "east"},
+		},
+	}
+	require.Nil(m.state.UpsertJob(100, j))
+
+	d := mock.Deployment()
+	d.JobID = j.ID
+	require.Nil(m.state.UpsertDeployment(101, d))
+
+	called := make(chan struct{})
+	m.On("RunRegionDeployment", "east", j.Namespace, j.ID).Return(true, nil).Once().
+		Run(func(mocker.Arguments) { close(called) })
+
+	w.SetEnabled(true, m.state)
+	testutil.WaitForResult(func() (bool, error) { return 1 == watchersCount(w), nil },
+		func(err error) { require.Equal(1, watchersCount(w), "1 deployment returned") })
+
+	// Complete the deployment
+	dsuccessful := d.Copy()
+	dsuccessful.Status = structs.DeploymentStatusSuccessful
+	require.Nil(m.state.UpsertDeployment(102, dsuccessful))
+
+	select {
+	case <-called:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("next region's deployment was not started")
+	}
+	require.Equal(0, watchersCount(w))
+}
+
 // Tests that calls against an unknown deployment fail
 func TestWatcher_UnknownDeployment(t *testing.T) {
 	t.Parallel()
@@ -84,6 +84,11 @@ func matchUpdateAllocDesiredTransitionReschedule(allocIDs []string) func(update
 	}
 }
 
+func (m *mockBackend) RunRegionDeployment(region, namespace, jobID string) (bool, error) {
+	args := m.Called(region, namespace, jobID)
+	return args.Bool(0), args.Error(1)
+}
+
 func (m *mockBackend) UpsertJob(job *structs.Job) (uint64, error) {
 	m.Called(job)
 	i := m.nextIndex()
@@ -0,0 +1,214 @@
+package nomad
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	memdb "github.com/hashicorp/go-memdb"
+
+	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/hashicorp/nomad/nomad/state"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+const (
+	// dispatchQueueRetryInterval is how long to wait before retrying to
+	// release queued dispatched jobs after a failure.
+	dispatchQueueRetryInterval = 5 * time.Second
+)
+
+// dispatchQueueFull returns whether a new dispatch of the parameterized job
+// must be queued, either because the job is at its concurrency limit or
+// because earlier dispatches are already queued. It must be called with the
+// dispatchLock held.
+func dispatchQueueFull(snap *state.StateStore, job *structs.Job) (bool, error) {
+	limit := job.ParameterizedJob.MaxConcurrent
+	if limit <= 0 {
+		return false, nil
+	}
+
+	summary, err := snap.JobSummaryByID(nil, job.Namespace, job.ID)
+	if err != nil {
+		return false, err
+	}
+	if summary == nil || summary.Children == nil {
+		return false, nil
+	}
+
+	children := summary.Children
+	return children.Queued > 0 || children.Pending+children.Running >= int64(limit), nil
+}
+
+// registerDispatchedJob registers a dispatched job and creates an evaluation
+// for it, filling in the reply.
+func (s *Server) registerDispatchedJob(job *structs.Job, wr structs.WriteRequest, reply *structs.JobDispatchResponse) error {
+	regReq := &structs.JobRegisterRequest{
+		Job:          job,
+		WriteRequest: wr,
+	}
+
+	// Commit this update via Raft
+	fsmErr, jobCreateIndex, err := s.raftApply(structs.JobRegisterRequestType, regReq)
+	if err, ok := fsmErr.(error); ok && err != nil {
+		s.logger.Error("dispatched job register failed", "error", err, "fsm", true)
+		return err
+	}
+	if err != nil {
+		s.logger.Error("dispatched job register failed", "error", err, "raft", true)
+		return err
+	}
+
+	reply.JobCreateIndex = jobCreateIndex
+	reply.DispatchedJobID = job.ID
+	reply.Index = jobCreateIndex
+
+	// If the job is periodic, we don't create an eval.
+	if job.IsPeriodic() {
+		return nil
+	}
+
+	// Create a new evaluation
+	now := time.Now().UTC().UnixNano()
+	eval := &structs.Evaluation{
+		ID:             uuid.Generate(),
+		Namespace:      job.Namespace,
+		Priority:       job.Priority,
+		Type:           job.Type,
+		TriggeredBy:    structs.EvalTriggerJobRegister,
+		JobID:          job.ID,
+		JobModifyIndex: jobCreateIndex,
+		Status:         structs.EvalStatusPending,
+		CreateTime:     now,
+		ModifyTime:     now,
+	}
+	update := &structs.EvalUpdateRequest{
+		Evals:        []*structs.Evaluation{eval},
+		WriteRequest: structs.WriteRequest{Region: wr.Region},
+	}
+
+	// Commit this evaluation via Raft
+	_, evalIndex, err := s.raftApply(structs.EvalUpdateRequestType, update)
+	if err != nil {
+		s.logger.Error("eval create failed", "error", err, "method", "dispatch")
+		return err
+	}
+
+	// Setup the reply
+	reply.EvalID = eval.ID
+	reply.EvalCreateIndex = evalIndex
+	reply.Index = evalIndex
+	return nil
+}
+
+// releaseQueuedDispatches is a long lived function run on the leader that
+// registers queued dispatched jobs, in the order they were queued, as their
+// parameterized jobs fall below their concurrency limit.
+func (s *Server) releaseQueuedDispatches(stopCh chan struct{}) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		select {
+		case <-stopCh:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	for {
+		ws := memdb.NewWatchSet()
+		if err := s.releaseQueuedDispatchesOnce(ws); err != nil {
+			s.logger.Error("failed to release queued dispatched jobs", "error", err)
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(dispatchQueueRetryInterval):
+				continue
+			}
+		}
+
+		// Wait for the queue or the children of a parameterized job with
+		// queued dispatches to change.
+		if err := ws.WatchCtx(ctx); err != nil {
+			return
+		}
+	}
+}
+
+// releaseQueuedDispatchesOnce releases the queued dispatched jobs of every
+// parameterized job that is below its concurrency limit. The passed watch set
+// is populated with the state the decision was based on.
+func (s *Server) releaseQueuedDispatchesOnce(ws memdb.WatchSet) error {
+	iter, err := s.fsm.State().QueuedDispatches(ws)
+	if err != nil {
+		return err
+	}
+
+	// Collect the parameterized jobs with queued dispatches
+	parents := make(map[structs.NamespacedID]struct{})
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		dispatch := raw.(*structs.QueuedDispatch)
+		parents[structs.NamespacedID{ID: dispatch.ParentID, Namespace: dispatch.Namespace}] = struct{}{}
+	}
+
+	for parent := range parents {
+		if err := s.releaseParentQueue(ws, parent); err != nil {
+			return fmt.Errorf("failed to release queued dispatches of job %q in namespace %q: %v",
+				parent.ID, parent.Namespace, err)
+		}
+	}
+
+	return nil
+}
+
+// releaseParentQueue registers as many of the queued dispatched jobs of the
+// parameterized job as its concurrency limit allows. Dispatched jobs of a
+// stopped parameterized job remain queued.
+func (s *Server) releaseParentQueue(ws memdb.WatchSet, parent structs.NamespacedID) error {
+	s.dispatchLock.Lock()
+	defer s.dispatchLock.Unlock()
+
+	snap := s.fsm.State()
+	job, err := snap.JobByID(ws, parent.Namespace, parent.ID)
+	if err != nil {
+		return err
+	}
+	if job == nil || job.Stop {
+		return nil
+	}
+
+	queued, err := snap.QueuedDispatchesByParent(ws, parent.Namespace, parent.ID)
+	if err != nil {
+		return err
+	}
+
+	// Determine how many dispatched jobs may be released. If the job no
+	// longer has a limit, all of them are.
+	release := len(queued)
+	if job.IsParameterized() && job.ParameterizedJob.MaxConcurrent > 0 {
+		summary, err := snap.JobSummaryByID(ws, parent.Namespace, parent.ID)
+		if err != nil {
+			return err
+		}
+		if summary != nil && summary.Children != nil {
+			active := int(summary.Children.Pending + summary.Children.Running)
+			if free := job.ParameterizedJob.MaxConcurrent - active; free < release {
+				release = free
+			}
+		}
+	}
+
+	wr := structs.WriteRequest{
+		Region:    s.config.Region,
+		Namespace: parent.Namespace,
+	}
+	for i := 0; i < release; i++ {
+		var reply structs.JobDispatchResponse
+		if err := s.registerDispatchedJob(queued[i].Job, wr, &reply); err != nil {
+			return err
+		}
+		s.logger.Debug("released queued dispatched job", "job_id", queued[i].ID, "namespace", parent.Namespace)
+	}
+
+	return nil
+}
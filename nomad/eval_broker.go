@@ -78,7 +78,7 @@ type EvalBroker struct {
 	requeue map[string]*structs.Evaluation
 
 	// timeWait has evaluations that are waiting for time to elapse
-	timeWait map[string]*time.Timer
+	timeWait map[string]*waitingEval
 
 	// delayedEvalCancelFunc is used to stop the long running go routine
 	// that processes delayed evaluations
@@ -111,6 +111,14 @@ type unackEval struct {
 	NackTimer *time.Timer
 }
 
+// waitingEval tracks an evaluation that is waiting for its Wait duration to
+// elapse along with the timer that will enqueue it.
+type waitingEval struct {
+	eval      *structs.Evaluation
+	timer     *time.Timer
+	waitUntil time.Time
+}
+
 // PendingEvaluations is a list of waiting evaluations.
 // We implement the container/heap interface so that this is a
 // priority queue
@@ -139,7 +147,7 @@ func NewEvalBroker(timeout, initialNackDelay, subsequentNackDelay time.Duration,
 		unack:                make(map[string]*unackEval),
 		waiting:              make(map[string]chan struct{}),
 		requeue:              make(map[string]*structs.Evaluation),
-		timeWait:             make(map[string]*time.Timer),
+		timeWait:             make(map[string]*waitingEval),
 		initialNackDelay:     initialNackDelay,
 		subsequentNackDelay:  subsequentNackDelay,
 		delayHeap:            delayheap.NewDelayHeap(),
@@ -258,7 +266,11 @@ func (b *EvalBroker) processWaitingEnqueue(eval *structs.Evaluation) {
 	timer := time.AfterFunc(eval.Wait, func() {
 		b.enqueueWaiting(eval)
 	})
-	b.timeWait[eval.ID] = timer
+	b.timeWait[eval.ID] = &waitingEval{
+		eval:      eval,
+		timer:     timer,
+		waitUntil: time.Now().Add(eval.Wait),
+	}
 	b.stats.TotalWaiting += 1
 }
 
@@ -267,6 +279,12 @@ func (b *EvalBroker) enqueueWaiting(eval *structs.Evaluation) {
 	b.l.Lock()
 	defer b.l.Unlock()
 
+	// The evaluation may have been dropped or re-enqueued by an operator
+	// while the timer was firing.
+	if w, ok := b.timeWait[eval.ID]; !ok || w.eval != eval {
+		return
+	}
+
 	delete(b.timeWait, eval.ID)
 	b.stats.TotalWaiting -= 1
 
@@ -571,17 +589,7 @@ func (b *EvalBroker) Ack(evalID, token string) error {
 	delete(b.jobEvals, namespacedID)
 
 	// Check if there are any blocked evaluations
-	if blocked := b.blocked[namespacedID]; len(blocked) != 0 {
-		raw := heap.Pop(&blocked)
-		if len(blocked) > 0 {
-			b.blocked[namespacedID] = blocked
-		} else {
-			delete(b.blocked, namespacedID)
-		}
-		eval := raw.(*structs.Evaluation)
-		b.stats.TotalBlocked -= 1
-		b.enqueueLocked(eval, eval.Type)
-	}
+	b.enqueueNextBlockedLocked(namespacedID)
 
 	// Re-enqueue the evaluation.
 	if eval, ok := b.requeue[token]; ok {
@@ -591,6 +599,26 @@ func (b *EvalBroker) Ack(evalID, token string) error {
 	return nil
 }
 
+// enqueueNextBlockedLocked enqueues the highest priority evaluation that was
+// blocked behind another evaluation for the same job. It must be called with
+// the lock held and after the job's pending evaluation has been cleared.
+func (b *EvalBroker) enqueueNextBlockedLocked(namespacedID structs.NamespacedID) {
+	blocked := b.blocked[namespacedID]
+	if len(blocked) == 0 {
+		return
+	}
+
+	raw := heap.Pop(&blocked)
+	if len(blocked) > 0 {
+		b.blocked[namespacedID] = blocked
+	} else {
+		delete(b.blocked, namespacedID)
+	}
+	eval := raw.(*structs.Evaluation)
+	b.stats.TotalBlocked -= 1
+	b.enqueueLocked(eval, eval.Type)
+}
+
 // Nack is used to negatively acknowledge handling an evaluation
 func (b *EvalBroker) Nack(evalID, token string) error {
 	b.l.Lock()
@@ -687,6 +715,180 @@ func (b *EvalBroker) ResumeNackTimeout(evalID, token string) error {
 	return nil
 }
 
+// Inspect returns a point in time listing of the evaluations held by the
+// broker.
+func (b *EvalBroker) Inspect() *BrokerInspection {
+	b.l.RLock()
+	defer b.l.RUnlock()
+
+	out := &BrokerInspection{}
+	for queue, pending := range b.ready {
+		for _, eval := range pending {
+			out.Ready = append(out.Ready, structs.NewBrokerEval(eval, queue, b.evals[eval.ID]))
+		}
+	}
+	for _, unack := range b.unack {
+		queue := unack.Eval.Type
+		if b.evals[unack.Eval.ID] > b.deliveryLimit {
+			queue = failedQueue
+		}
+		out.Unacked = append(out.Unacked, structs.NewBrokerEval(unack.Eval, queue, b.evals[unack.Eval.ID]))
+	}
+	for _, wait := range b.timeWait {
+		stub := structs.NewBrokerEval(wait.eval, wait.eval.Type, b.evals[wait.eval.ID])
+		stub.WaitUntil = wait.waitUntil
+		out.Waiting = append(out.Waiting, stub)
+	}
+	for _, node := range b.delayHeap.Nodes() {
+		eval := node.Data().(*structs.Evaluation)
+		out.Waiting = append(out.Waiting, structs.NewBrokerEval(eval, eval.Type, b.evals[eval.ID]))
+	}
+	for _, pending := range b.blocked {
+		for _, eval := range pending {
+			out.Pending = append(out.Pending, structs.NewBrokerEval(eval, eval.Type, b.evals[eval.ID]))
+		}
+	}
+	return out
+}
+
+// Drop removes an evaluation that is ready, waiting or pending behind another
+// evaluation for the same job. Evaluations that are currently being processed
+// by a scheduler can not be dropped. The dropped evaluation is returned, or nil
+// if the broker is not tracking it.
+func (b *EvalBroker) Drop(evalID string) (*structs.Evaluation, error) {
+	b.l.Lock()
+	defer b.l.Unlock()
+
+	if _, ok := b.evals[evalID]; !ok {
+		return nil, nil
+	}
+	if _, ok := b.unack[evalID]; ok {
+		return nil, fmt.Errorf("evaluation %q is being processed by a scheduler", evalID)
+	}
+
+	eval := b.removeLocked(evalID)
+	if eval == nil {
+		return nil, nil
+	}
+	delete(b.evals, evalID)
+
+	// Release the job so that any evaluation pending behind the dropped one
+	// can make progress.
+	namespacedID := structs.NewNamespacedID(eval.JobID, eval.Namespace)
+	if b.jobEvals[namespacedID] == evalID {
+		delete(b.jobEvals, namespacedID)
+		b.enqueueNextBlockedLocked(namespacedID)
+	}
+	return eval, nil
+}
+
+// Reenqueue moves an evaluation that is waiting or has exceeded its delivery
+// limit back into the ready queue of its scheduler, resetting its delivery
+// count. It returns whether the evaluation was re-enqueued.
+func (b *EvalBroker) Reenqueue(evalID string) (bool, error) {
+	b.l.Lock()
+	defer b.l.Unlock()
+
+	if _, ok := b.unack[evalID]; ok {
+		return false, fmt.Errorf("evaluation %q is being processed by a scheduler", evalID)
+	}
+
+	eval := b.removeWaitingLocked(evalID)
+	if eval == nil {
+		eval = b.removeReadyLocked(evalID, failedQueue)
+	}
+	if eval == nil {
+		return false, nil
+	}
+
+	b.evals[evalID] = 0
+	b.enqueueLocked(eval, eval.Type)
+	return true, nil
+}
+
+// removeLocked removes the evaluation from whichever ready, waiting or pending
+// queue holds it. It must be called with the lock held.
+func (b *EvalBroker) removeLocked(evalID string) *structs.Evaluation {
+	for queue := range b.ready {
+		if eval := b.removeReadyLocked(evalID, queue); eval != nil {
+			return eval
+		}
+	}
+
+	if eval := b.removeWaitingLocked(evalID); eval != nil {
+		return eval
+	}
+
+	for namespacedID, pending := range b.blocked {
+		for i, eval := range pending {
+			if eval.ID != evalID {
+				continue
+			}
+
+			heap.Remove(&pending, i)
+			if len(pending) > 0 {
+				b.blocked[namespacedID] = pending
+			} else {
+				delete(b.blocked, namespacedID)
+			}
+			b.stats.TotalBlocked -= 1
+			return eval
+		}
+	}
+	return nil
+}
+
+// removeReadyLocked removes the evaluation from the given ready queue. It must
+// be called with the lock held.
+func (b *EvalBroker) removeReadyLocked(evalID, queue string) *structs.Evaluation {
+	pending := b.ready[queue]
+	for i, eval := range pending {
+		if eval.ID != evalID {
+			continue
+		}
+
+		heap.Remove(&pending, i)
+		b.ready[queue] = pending
+		b.stats.TotalReady -= 1
+		if bySched, ok := b.stats.ByScheduler[queue]; ok {
+			bySched.Ready -= 1
+		}
+		return eval
+	}
+	return nil
+}
+
+// removeWaitingLocked removes the evaluation if it is waiting for its wait
+// duration to elapse or delayed until its WaitUntil time. It must be called
+// with the lock held.
+func (b *EvalBroker) removeWaitingLocked(evalID string) *structs.Evaluation {
+	if wait, ok := b.timeWait[evalID]; ok {
+		wait.timer.Stop()
+		delete(b.timeWait, evalID)
+		b.stats.TotalWaiting -= 1
+		return wait.eval
+	}
+
+	for _, node := range b.delayHeap.Nodes() {
+		eval := node.Data().(*structs.Evaluation)
+		if eval.ID != evalID {
+			continue
+		}
+
+		b.delayHeap.Remove(node)
+		b.stats.TotalWaiting -= 1
+
+		// Signal the delayed evaluations watcher as it may be waiting on the
+		// removed evaluation.
+		select {
+		case b.delayedEvalsUpdateCh <- struct{}{}:
+		default:
+		}
+		return eval
+	}
+	return nil
+}
+
 // Flush is used to clear the state of the broker. It must be called from within
 // the lock.
 func (b *EvalBroker) flush() {
@@ -703,7 +905,7 @@ func (b *EvalBroker) flush() {
 
 	// Cancel any time wait evals
 	for _, wait := range b.timeWait {
-		wait.Stop()
+		wait.timer.Stop()
 	}
 
 	// Cancel the delayed evaluations goroutine
@@ -725,7 +927,7 @@ func (b *EvalBroker) flush() {
 	b.blocked = make(map[structs.NamespacedID]PendingEvaluations)
 	b.ready = make(map[string]PendingEvaluations)
 	b.unack = make(map[string]*unackEval)
-	b.timeWait = make(map[string]*time.Timer)
+	b.timeWait = make(map[string]*waitingEval)
 	b.delayHeap = delayheap.NewDelayHeap()
 }
 
@@ -769,11 +971,13 @@ func (b *EvalBroker) runDelayedEvalsWatcher(ctx context.Context, updateCh <-chan
 		case <-ctx.Done():
 			return
 		case <-timerChannel:
-			// remove from the heap since we can enqueue it now
+			// remove from the heap since we can enqueue it now. The
+			// evaluation may have already been removed by an operator.
 			b.l.Lock()
-			b.delayHeap.Remove(&evalWrapper{eval})
-			b.stats.TotalWaiting -= 1
-			b.enqueueLocked(eval, eval.Type)
+			if err := b.delayHeap.Remove(&evalWrapper{eval}); err == nil {
+				b.stats.TotalWaiting -= 1
+				b.enqueueLocked(eval, eval.Type)
+			}
 			b.l.Unlock()
 		case <-updateCh:
 			continue
@@ -851,6 +1055,14 @@ type BrokerStats struct {
 	ByScheduler  map[string]*SchedulerStats
 }
 
+// BrokerInspection lists the evaluations held by the broker
+type BrokerInspection struct {
+	Ready   []*structs.BrokerEval
+	Unacked []*structs.BrokerEval
+	Waiting []*structs.BrokerEval
+	Pending []*structs.BrokerEval
+}
+
 // SchedulerStats returns the stats per scheduler
 type SchedulerStats struct {
 	Ready   int
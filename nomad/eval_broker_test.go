@@ -1442,3 +1442,185 @@ func TestEvalBroker_NamespacedJobs(t *testing.T) {
 	require.Equal(1, len(b.blocked))
 
 }
+
+func TestEvalBroker_Inspect(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	b := testBroker(t, 0)
+	b.SetEnabled(true)
+
+	// Create a ready eval, a pending eval for the same job, and a waiting eval
+	ready := mock.Eval()
+	pending := mock.Eval()
+	pending.JobID = ready.JobID
+	waiting := mock.Eval()
+	waiting.Wait = time.Hour
+	delayed := mock.Eval()
+	delayed.WaitUntil = time.Now().Add(time.Hour)
+	b.Enqueue(ready)
+	b.Enqueue(pending)
+	b.Enqueue(waiting)
+	b.Enqueue(delayed)
+
+	out := b.Inspect()
+	require.Len(out.Ready, 1)
+	require.Equal(ready.ID, out.Ready[0].ID)
+	require.Equal(ready.Type, out.Ready[0].Queue)
+	require.Len(out.Pending, 1)
+	require.Equal(pending.ID, out.Pending[0].ID)
+	require.Len(out.Waiting, 2)
+	require.Empty(out.Unacked)
+	for _, e := range out.Waiting {
+		require.False(e.WaitUntil.IsZero())
+	}
+
+	// Dequeue the ready eval and ensure it is reported as unacked
+	out1, _, err := b.Dequeue(defaultSched, time.Second)
+	require.NoError(err)
+	require.Equal(ready.ID, out1.ID)
+
+	out = b.Inspect()
+	require.Empty(out.Ready)
+	require.Len(out.Unacked, 1)
+	require.Equal(ready.ID, out.Unacked[0].ID)
+	require.Equal(1, out.Unacked[0].Dequeues)
+}
+
+func TestEvalBroker_Drop(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	b := testBroker(t, 0)
+	b.SetEnabled(true)
+
+	ready := mock.Eval()
+	pending := mock.Eval()
+	pending.JobID = ready.JobID
+	waiting := mock.Eval()
+	waiting.Wait = time.Hour
+	delayed := mock.Eval()
+	delayed.WaitUntil = time.Now().Add(time.Hour)
+	b.Enqueue(ready)
+	b.Enqueue(pending)
+	b.Enqueue(waiting)
+	b.Enqueue(delayed)
+
+	// Dropping an unknown eval is a no-op
+	out, err := b.Drop("foo")
+	require.NoError(err)
+	require.Nil(out)
+
+	// Dropping the ready eval should release the pending eval for the job
+	out, err = b.Drop(ready.ID)
+	require.NoError(err)
+	require.Equal(ready, out)
+
+	stats := b.Stats()
+	require.Equal(1, stats.TotalReady)
+	require.Equal(0, stats.TotalBlocked)
+	require.Equal(2, stats.TotalWaiting)
+
+	// Drop the waiting and delayed evals
+	out, err = b.Drop(waiting.ID)
+	require.NoError(err)
+	require.Equal(waiting, out)
+	out, err = b.Drop(delayed.ID)
+	require.NoError(err)
+	require.Equal(delayed, out)
+
+	stats = b.Stats()
+	require.Equal(0, stats.TotalWaiting)
+
+	// Outstanding evals can't be dropped
+	out1, _, err := b.Dequeue(defaultSched, time.Second)
+	require.NoError(err)
+	require.Equal(pending.ID, out1.ID)
+
+	_, err = b.Drop(pending.ID)
+	require.Error(err)
+	require.Contains(err.Error(), "being processed")
+
+	// A dropped eval can be enqueued again
+	b.Enqueue(waiting.Copy())
+	require.Equal(1, b.Stats().TotalWaiting)
+}
+
+func TestEvalBroker_Reenqueue(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	b := testBroker(t, 0)
+	b.SetEnabled(true)
+
+	ready := mock.Eval()
+	waiting := mock.Eval()
+	waiting.Wait = time.Hour
+	delayed := mock.Eval()
+	delayed.WaitUntil = time.Now().Add(time.Hour)
+	b.Enqueue(ready)
+	b.Enqueue(waiting)
+	b.Enqueue(delayed)
+
+	// Ready evals can't be re-enqueued
+	ok, err := b.Reenqueue(ready.ID)
+	require.NoError(err)
+	require.False(ok)
+
+	ok, err = b.Reenqueue(waiting.ID)
+	require.NoError(err)
+	require.True(ok)
+	ok, err = b.Reenqueue(delayed.ID)
+	require.NoError(err)
+	require.True(ok)
+
+	stats := b.Stats()
+	require.Equal(3, stats.TotalReady)
+	require.Equal(0, stats.TotalWaiting)
+
+	// Ensure that all three evals are dequeued
+	seen := make(map[string]struct{})
+	for i := 0; i < 3; i++ {
+		out, _, err := b.Dequeue(defaultSched, time.Second)
+		require.NoError(err)
+		require.NotNil(out)
+		seen[out.ID] = struct{}{}
+	}
+	require.Contains(seen, ready.ID)
+	require.Contains(seen, waiting.ID)
+	require.Contains(seen, delayed.ID)
+}
+
+func TestEvalBroker_Reenqueue_Failed(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	b := testBroker(t, 0)
+	b.SetEnabled(true)
+
+	eval := mock.Eval()
+	b.Enqueue(eval)
+
+	// Nack the eval until it reaches the failed queue
+	for i := 0; i < 3; i++ {
+		testutil.WaitForResult(func() (bool, error) {
+			return b.Stats().TotalReady == 1, fmt.Errorf("eval not ready: %#v", b.Stats())
+		}, func(err error) {
+			t.Fatal(err)
+		})
+
+		out, token, err := b.Dequeue(defaultSched, time.Second)
+		require.NoError(err)
+		require.Equal(eval.ID, out.ID)
+		require.NoError(b.Nack(eval.ID, token))
+	}
+
+	out := b.Inspect()
+	require.Len(out.Ready, 1)
+	require.Equal(failedQueue, out.Ready[0].Queue)
+
+	ok, err := b.Reenqueue(eval.ID)
+	require.NoError(err)
+	require.True(ok)
+
+	out = b.Inspect()
+	require.Len(out.Ready, 1)
+	require.Equal(eval.Type, out.Ready[0].Queue)
+	require.Equal(0, out.Ready[0].Dequeues)
+}
@@ -13,11 +13,20 @@ import (
 	"github.com/hashicorp/nomad/nomad/state"
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/hashicorp/nomad/scheduler"
+	"golang.org/x/time/rate"
 )
 
 const (
 	// DefaultDequeueTimeout is used if no dequeue timeout is provided
 	DefaultDequeueTimeout = time.Second
+
+	// evalDeleteBatchSize is the maximum number of evaluations deleted in a
+	// single Raft transaction by Eval.Delete.
+	evalDeleteBatchSize = 1000
+
+	// evalDeleteRateLimit is the maximum number of Raft transactions per
+	// second applied by Eval.Delete.
+	evalDeleteRateLimit rate.Limit = 10.0
 )
 
 // Eval endpoint is used for eval interactions
@@ -34,11 +43,11 @@ func (e *Eval) GetEval(args *structs.EvalSpecificRequest,
 	}
 	defer metrics.MeasureSince([]string{"nomad", "eval", "get_eval"}, time.Now())
 
-	// Check for read-job permissions
-	if aclObj, err := e.srv.ResolveToken(args.AuthToken); err != nil {
+	// Resolve the token, the namespace read-job permissions are checked once
+	// the evaluation is found
+	aclObj, err := e.srv.ResolveToken(args.AuthToken)
+	if err != nil {
 		return err
-	} else if aclObj != nil && !aclObj.AllowNsOp(args.RequestNamespace(), acl.NamespaceCapabilityReadJob) {
-		return structs.ErrPermissionDenied
 	}
 
 	// Setup the blocking query
@@ -52,6 +61,18 @@ func (e *Eval) GetEval(args *structs.EvalSpecificRequest,
 				return err
 			}
 
+			// Check the permissions against the namespace of the evaluation
+			// rather than the namespace of the request
+			if aclObj != nil {
+				ns := args.RequestNamespace()
+				if out != nil {
+					ns = out.Namespace
+				}
+				if !aclObj.AllowNsOp(ns, acl.NamespaceCapabilityReadJob) {
+					return structs.ErrPermissionDenied
+				}
+			}
+
 			// Setup the output
 			reply.Eval = out
 			if out != nil {
@@ -323,6 +344,158 @@ func (e *Eval) Reap(args *structs.EvalDeleteRequest,
 	return nil
 }
 
+// Delete is used to delete the evaluations matching the request's filters.
+// Evaluations that are being processed by a scheduler are skipped. The
+// deletions are applied in rate limited batches so that purging a large number
+// of evaluations does not overwhelm Raft.
+func (e *Eval) Delete(args *structs.EvalBatchDeleteRequest,
+	reply *structs.EvalBatchDeleteResponse) error {
+	if done, err := e.srv.forward("Eval.Delete", args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "eval", "delete"}, time.Now())
+
+	// Check for operator write permissions
+	if aclObj, err := e.srv.ResolveToken(args.AuthToken); err != nil {
+		return err
+	} else if aclObj != nil && !aclObj.AllowOperatorWrite() {
+		return structs.ErrPermissionDenied
+	}
+
+	// Validate the filters
+	if args.JobID == "" && args.Status == "" && args.OlderThan == 0 {
+		return fmt.Errorf("at least one of the job, status or older than filters must be set")
+	}
+	switch args.Status {
+	case "", structs.EvalStatusBlocked, structs.EvalStatusPending, structs.EvalStatusComplete,
+		structs.EvalStatusFailed, structs.EvalStatusCancelled:
+	default:
+		return fmt.Errorf("invalid evaluation status %q", args.Status)
+	}
+	if args.OlderThan < 0 {
+		return fmt.Errorf("older than filter must not be negative")
+	}
+
+	snap, err := e.srv.fsm.State().Snapshot()
+	if err != nil {
+		return err
+	}
+
+	var evals []*structs.Evaluation
+	if args.JobID != "" {
+		evals, err = snap.EvalsByJob(nil, args.RequestNamespace(), args.JobID)
+		if err != nil {
+			return err
+		}
+	} else {
+		iter, err := snap.EvalsByNamespace(nil, args.RequestNamespace())
+		if err != nil {
+			return err
+		}
+		for raw := iter.Next(); raw != nil; raw = iter.Next() {
+			evals = append(evals, raw.(*structs.Evaluation))
+		}
+	}
+
+	// Collect the matching evaluations
+	cutoff := time.Now().Add(-args.OlderThan).UnixNano()
+	var ids []string
+	for _, eval := range evals {
+		if args.Status != "" && eval.Status != args.Status {
+			continue
+		}
+		if args.OlderThan != 0 && eval.CreateTime > cutoff {
+			continue
+		}
+		if _, ok := e.srv.evalBroker.Outstanding(eval.ID); ok {
+			reply.Skipped++
+			continue
+		}
+		ids = append(ids, eval.ID)
+	}
+
+	// Delete the evaluations in batches
+	limiter := rate.NewLimiter(evalDeleteRateLimit, 1)
+	for start := 0; start < len(ids); start += evalDeleteBatchSize {
+		if err := limiter.Wait(e.srv.shutdownCtx); err != nil {
+			return err
+		}
+
+		end := start + evalDeleteBatchSize
+		if end > len(ids) {
+			end = len(ids)
+		}
+
+		// Stop tracking the evaluations before deleting them so that they
+		// can not be dequeued by a scheduler while the deletion is applied.
+		batch, claimed, skipped := e.claimEvals(ids[start:end])
+		reply.Skipped += skipped
+		if len(batch) == 0 {
+			continue
+		}
+
+		req := structs.EvalDeleteRequest{
+			Evals:        batch,
+			WriteRequest: args.WriteRequest,
+		}
+		_, index, err := e.srv.raftApply(structs.EvalDeleteRequestType, &req)
+		if err != nil {
+			e.logger.Error("eval delete failed", "error", err)
+			e.restoreEvals(claimed)
+			return err
+		}
+		reply.Deleted += len(batch)
+		reply.Index = index
+	}
+
+	// Use the current evals index if nothing was deleted
+	if reply.Index == 0 {
+		index, err := snap.Index("evals")
+		if err != nil {
+			return err
+		}
+		reply.Index = index
+	}
+	return nil
+}
+
+// claimEvals removes the evaluations with the given IDs from the eval broker
+// and blocked evals tracker. Evaluations being processed by a scheduler can
+// not be removed and are skipped. It returns the IDs of the evaluations that
+// may be deleted, the removed evaluations and the number skipped.
+func (e *Eval) claimEvals(ids []string) ([]string, []*structs.Evaluation, int) {
+	batch := make([]string, 0, len(ids))
+	var claimed []*structs.Evaluation
+	skipped := 0
+	for _, id := range ids {
+		eval, err := e.srv.evalBroker.Drop(id)
+		if err != nil {
+			skipped++
+			continue
+		}
+		if eval == nil {
+			eval = e.srv.blockedEvals.Drop(id)
+		}
+		if eval != nil {
+			claimed = append(claimed, eval)
+		}
+		batch = append(batch, id)
+	}
+	return batch, claimed, skipped
+}
+
+// restoreEvals tracks the evaluations removed by claimEvals again after their
+// deletion failed.
+func (e *Eval) restoreEvals(evals []*structs.Evaluation) {
+	for _, eval := range evals {
+		if eval.ShouldBlock() {
+			e.srv.blockedEvals.Block(eval)
+		} else {
+			e.srv.evalBroker.Enqueue(eval)
+		}
+	}
+}
+
 // List is used to get a list of the evaluations in the system
 func (e *Eval) List(args *structs.EvalListRequest,
 	reply *structs.EvalListResponse) error {
@@ -355,16 +528,28 @@ func (e *Eval) List(args *structs.EvalListRequest,
 				return err
 			}
 
+			paginator, err := newPaginator(iter, args.QueryOptions,
+				func(raw interface{}) string {
+					return raw.(*structs.Evaluation).ID
+				},
+				func(raw interface{}) (interface{}, error) {
+					return raw, nil
+				})
+			if err != nil {
+				return err
+			}
+
+			stubs, nextToken, err := paginator.page()
+			if err != nil {
+				return err
+			}
+
 			var evals []*structs.Evaluation
-			for {
-				raw := iter.Next()
-				if raw == nil {
-					break
-				}
-				eval := raw.(*structs.Evaluation)
-				evals = append(evals, eval)
+			for _, stub := range stubs {
+				evals = append(evals, stub.(*structs.Evaluation))
 			}
 			reply.Evaluations = evals
+			reply.NextToken = nextToken
 
 			// Use the last index that affected the jobs table
 			index, err := state.Index("evals")
@@ -388,11 +573,11 @@ func (e *Eval) Allocations(args *structs.EvalSpecificRequest,
 	}
 	defer metrics.MeasureSince([]string{"nomad", "eval", "allocations"}, time.Now())
 
-	// Check for read-job permissions
-	if aclObj, err := e.srv.ResolveToken(args.AuthToken); err != nil {
+	// Resolve the token, the namespace read-job permissions are checked once
+	// the evaluation is found
+	aclObj, err := e.srv.ResolveToken(args.AuthToken)
+	if err != nil {
 		return err
-	} else if aclObj != nil && !aclObj.AllowNsOp(args.RequestNamespace(), acl.NamespaceCapabilityReadJob) {
-		return structs.ErrPermissionDenied
 	}
 
 	// Setup the blocking query
@@ -400,6 +585,22 @@ func (e *Eval) Allocations(args *structs.EvalSpecificRequest,
 		queryOpts: &args.QueryOptions,
 		queryMeta: &reply.QueryMeta,
 		run: func(ws memdb.WatchSet, state *state.StateStore) error {
+			// Check the permissions against the namespace of the evaluation
+			// rather than the namespace of the request
+			if aclObj != nil {
+				eval, err := state.EvalByID(ws, args.EvalID)
+				if err != nil {
+					return err
+				}
+				ns := args.RequestNamespace()
+				if eval != nil {
+					ns = eval.Namespace
+				}
+				if !aclObj.AllowNsOp(ns, acl.NamespaceCapabilityReadJob) {
+					return structs.ErrPermissionDenied
+				}
+			}
+
 			// Capture the allocations
 			allocs, err := state.AllocsByEval(ws, args.EvalID)
 			if err != nil {
@@ -651,6 +651,129 @@ func TestEvalEndpoint_Reap(t *testing.T) {
 	}
 }
 
+func TestEvalEndpoint_Delete(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	s1 := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer s1.Shutdown()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	// Create a pending and a blocked eval for one job, and an old complete
+	// eval for another job.
+	pending := mock.Eval()
+	blocked := mock.Eval()
+	blocked.JobID = pending.JobID
+	blocked.Status = structs.EvalStatusBlocked
+	complete := mock.Eval()
+	complete.Status = structs.EvalStatusComplete
+	complete.CreateTime = time.Now().Add(-2 * time.Hour).UnixNano()
+	evals := []*structs.Evaluation{pending, blocked, complete}
+	req := &structs.EvalUpdateRequest{Evals: evals}
+	_, _, err := s1.raftApply(structs.EvalUpdateRequestType, req)
+	require.NoError(err)
+	require.Equal(1, s1.evalBroker.Stats().TotalReady)
+	require.Equal(1, s1.blockedEvals.Stats().TotalBlocked)
+
+	// Filters are required
+	del := &structs.EvalBatchDeleteRequest{
+		WriteRequest: structs.WriteRequest{Region: "global"},
+	}
+	var resp structs.EvalBatchDeleteResponse
+	err = msgpackrpc.CallWithCodec(codec, "Eval.Delete", del, &resp)
+	require.Error(err)
+	require.Contains(err.Error(), "at least one")
+
+	// Invalid statuses are rejected
+	del.Status = "foo"
+	err = msgpackrpc.CallWithCodec(codec, "Eval.Delete", del, &resp)
+	require.Error(err)
+	require.Contains(err.Error(), "invalid evaluation status")
+
+	// Delete evals older than an hour
+	del.Status = ""
+	del.OlderThan = time.Hour
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Eval.Delete", del, &resp))
+	require.Equal(1, resp.Deleted)
+	require.NotZero(resp.Index)
+
+	out, err := s1.fsm.State().EvalByID(nil, complete.ID)
+	require.NoError(err)
+	require.Nil(out)
+
+	// Delete the blocked eval of the job
+	del.OlderThan = 0
+	del.JobID = pending.JobID
+	del.Status = structs.EvalStatusBlocked
+	resp = structs.EvalBatchDeleteResponse{}
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Eval.Delete", del, &resp))
+	require.Equal(1, resp.Deleted)
+	require.Zero(s1.blockedEvals.Stats().TotalBlocked)
+	require.Equal(1, s1.evalBroker.Stats().TotalReady)
+
+	// Outstanding evals are skipped
+	outEval, _, err := s1.evalBroker.Dequeue(defaultSched, time.Second)
+	require.NoError(err)
+	require.Equal(pending.ID, outEval.ID)
+
+	del.Status = ""
+	resp = structs.EvalBatchDeleteResponse{}
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Eval.Delete", del, &resp))
+	require.Zero(resp.Deleted)
+	require.Equal(1, resp.Skipped)
+
+	out, err = s1.fsm.State().EvalByID(nil, pending.ID)
+	require.NoError(err)
+	require.NotNil(out)
+}
+
+func TestEvalEndpoint_Delete_ACL(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	s1, root := TestACLServer(t, nil)
+	defer s1.Shutdown()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+	state := s1.fsm.State()
+
+	eval := mock.Eval()
+	require.NoError(state.UpsertEvals(1000, []*structs.Evaluation{eval}))
+
+	// Create ACL tokens
+	validToken := mock.CreatePolicyAndToken(t, state, 1003, "test-valid",
+		mock.OperatorPolicy(acl.PolicyWrite))
+	invalidToken := mock.CreatePolicyAndToken(t, state, 1005, "test-invalid",
+		mock.NamespacePolicy(structs.DefaultNamespace, "", []string{acl.NamespaceCapabilitySubmitJob}))
+
+	del := &structs.EvalBatchDeleteRequest{
+		JobID:        eval.JobID,
+		WriteRequest: structs.WriteRequest{Region: "global"},
+	}
+	var resp structs.EvalBatchDeleteResponse
+
+	// Try without a token and expect failure
+	err := msgpackrpc.CallWithCodec(codec, "Eval.Delete", del, &resp)
+	require.EqualError(err, structs.ErrPermissionDenied.Error())
+
+	// Try with an invalid token and expect failure
+	del.AuthToken = invalidToken.SecretID
+	err = msgpackrpc.CallWithCodec(codec, "Eval.Delete", del, &resp)
+	require.EqualError(err, structs.ErrPermissionDenied.Error())
+
+	// Try with a valid token
+	del.AuthToken = validToken.SecretID
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Eval.Delete", del, &resp))
+	require.Equal(1, resp.Deleted)
+
+	// Try with a root token
+	del.AuthToken = root.SecretID
+	resp = structs.EvalBatchDeleteResponse{}
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Eval.Delete", del, &resp))
+	require.Zero(resp.Deleted)
+}
+
 func TestEvalEndpoint_List(t *testing.T) {
 	t.Parallel()
 	s1 := TestServer(t, nil)
@@ -1120,3 +1243,65 @@ func TestEvalEndpoint_Reblock(t *testing.T) {
 		t.Fatalf("ReblockEval didn't insert eval into the blocked eval tracker")
 	}
 }
+
+// TestEvalEndpoint_CrossNamespace_ACL asserts evaluations are authorized
+// against their own namespace rather than the namespace of the request.
+func TestEvalEndpoint_CrossNamespace_ACL(t *testing.T) {
+	t.Parallel()
+	s1, _ := TestACLServer(t, nil)
+	defer s1.Shutdown()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+	require := require.New(t)
+
+	// Create the evaluation in another namespace
+	ns := mock.Namespace()
+	eval := mock.Eval()
+	eval.Namespace = ns.Name
+	alloc := mock.Alloc()
+	alloc.Namespace = ns.Name
+	alloc.EvalID = eval.ID
+	state := s1.fsm.State()
+	require.NoError(state.UpsertNamespaces(998, []*structs.Namespace{ns}))
+	require.NoError(state.UpsertEvals(999, []*structs.Evaluation{eval}))
+	require.NoError(state.UpsertJobSummary(1000, mock.JobSummary(alloc.JobID)))
+	require.NoError(state.UpsertAllocs(1001, []*structs.Allocation{alloc}))
+
+	defaultToken := mock.CreatePolicyAndToken(t, state, 1002, "test-default",
+		mock.NamespacePolicy(structs.DefaultNamespace, "", []string{acl.NamespaceCapabilityReadJob}))
+	nsToken := mock.CreatePolicyAndToken(t, state, 1003, "test-ns",
+		mock.NamespacePolicy(ns.Name, "", []string{acl.NamespaceCapabilityReadJob}))
+
+	get := &structs.EvalSpecificRequest{
+		EvalID:       eval.ID,
+		QueryOptions: structs.QueryOptions{Region: "global", AuthToken: defaultToken.SecretID},
+	}
+
+	// A token for the namespace of the request can't read the evaluation
+	{
+		var resp structs.SingleEvalResponse
+		err := msgpackrpc.CallWithCodec(codec, "Eval.GetEval", get, &resp)
+		require.Error(err)
+		require.Equal(structs.ErrPermissionDenied.Error(), err.Error())
+	}
+	{
+		var resp structs.EvalAllocationsResponse
+		err := msgpackrpc.CallWithCodec(codec, "Eval.Allocations", get, &resp)
+		require.Error(err)
+		require.Equal(structs.ErrPermissionDenied.Error(), err.Error())
+	}
+
+	// A token for the namespace of the evaluation can
+	get.AuthToken = nsToken.SecretID
+	{
+		var resp structs.SingleEvalResponse
+		require.NoError(msgpackrpc.CallWithCodec(codec, "Eval.GetEval", get, &resp))
+		require.NotNil(resp.Eval)
+		require.Equal(eval.ID, resp.Eval.ID)
+	}
+	{
+		var resp structs.EvalAllocationsResponse
+		require.NoError(msgpackrpc.CallWithCodec(codec, "Eval.Allocations", get, &resp))
+		require.Len(resp.Allocations, 1)
+	}
+}
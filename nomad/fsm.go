@@ -45,6 +45,13 @@ const (
 	ACLPolicySnapshot
 	ACLTokenSnapshot
 	SchedulerConfigSnapshot
+	QueuedDispatchSnapshot
+	DispatchTokenSnapshot
+	JobSubmissionSnapshot
+	NamespaceSnapshot
+	AdmissionPolicySnapshot
+	ScalingEventsSnapshot
+	ScalingPolicySnapshot
 )
 
 // LogApplier is the definition of a function that can apply a Raft log
@@ -251,6 +258,20 @@ func (n *nomadFSM) Apply(log *raft.Log) interface{} {
 		return n.applySchedulerConfigUpdate(buf[1:], log.Index)
 	case structs.NodeBatchDeregisterRequestType:
 		return n.applyDeregisterNodeBatch(buf[1:], log.Index)
+	case structs.JobDispatchQueueRequestType:
+		return n.applyQueueDispatch(buf[1:], log.Index)
+	case structs.JobVersionTagRequestType:
+		return n.applyJobVersionTag(buf[1:], log.Index)
+	case structs.NamespaceUpsertRequestType:
+		return n.applyNamespaceUpsert(buf[1:], log.Index)
+	case structs.NamespaceDeleteRequestType:
+		return n.applyNamespaceDelete(buf[1:], log.Index)
+	case structs.AdmissionPolicyUpsertRequestType:
+		return n.applyAdmissionPolicyUpsert(buf[1:], log.Index)
+	case structs.AdmissionPolicyDeleteRequestType:
+		return n.applyAdmissionPolicyDelete(buf[1:], log.Index)
+	case structs.ScalingEventRegisterRequestType:
+		return n.applyUpsertScalingEvent(buf[1:], log.Index)
 	}
 
 	// Check enterprise only message types.
@@ -443,6 +464,18 @@ func (n *nomadFSM) applyUpsertJob(buf []byte, index uint64) interface{} {
 		return err
 	}
 
+	// Store the source of the new job version
+	if req.Submission != nil {
+		submission := req.Submission.Copy()
+		submission.Namespace = req.Job.Namespace
+		submission.JobID = req.Job.ID
+		submission.Version = req.Job.Version
+		if err := n.state.UpsertJobSubmission(index, submission); err != nil {
+			n.logger.Error("UpsertJobSubmission failed", "error", err)
+			return err
+		}
+	}
+
 	// We always add the job to the periodic dispatcher because there is the
 	// possibility that the periodic spec was removed and then we should stop
 	// tracking it.
@@ -666,6 +699,20 @@ func (n *nomadFSM) applyDeleteEval(buf []byte, index uint64) interface{} {
 		n.logger.Error("DeleteEval failed", "error", err)
 		return err
 	}
+
+	// Stop tracking any deleted evaluations that are still queued or blocked.
+	// The deletion must be applied identically on every server, so it can not
+	// depend on the eval broker which is only enabled on the leader. Instead
+	// Eval.Delete stops tracking the evaluations before deleting them, which
+	// leaves evaluations that were dequeued since then to their scheduler.
+	for _, evalID := range req.Evals {
+		if _, ok := n.evalBroker.Outstanding(evalID); ok {
+			n.logger.Warn("deleted eval is being processed by a scheduler", "eval_id", evalID)
+			continue
+		}
+		n.evalBroker.Drop(evalID)
+		n.blockedEvals.Drop(evalID)
+	}
 	return nil
 }
 
@@ -947,6 +994,105 @@ func (n *nomadFSM) applyJobStability(buf []byte, index uint64) interface{} {
 	return nil
 }
 
+// applyJobVersionTag is used to tag a job version or remove a version tag
+func (n *nomadFSM) applyJobVersionTag(buf []byte, index uint64) interface{} {
+	defer metrics.MeasureSince([]string{"nomad", "fsm", "apply_job_version_tag"}, time.Now())
+	var req structs.JobTagRequest
+	if err := structs.Decode(buf, &req); err != nil {
+		panic(fmt.Errorf("failed to decode request: %v", err))
+	}
+
+	if err := n.state.UpdateJobVersionTag(index, req.RequestNamespace(), &req); err != nil {
+		n.logger.Error("UpdateJobVersionTag failed", "error", err)
+		return err
+	}
+
+	return nil
+}
+
+// applyNamespaceUpsert is used to upsert a set of namespaces
+func (n *nomadFSM) applyNamespaceUpsert(buf []byte, index uint64) interface{} {
+	defer metrics.MeasureSince([]string{"nomad", "fsm", "apply_namespace_upsert"}, time.Now())
+	var req structs.NamespaceUpsertRequest
+	if err := structs.Decode(buf, &req); err != nil {
+		panic(fmt.Errorf("failed to decode request: %v", err))
+	}
+
+	if err := n.state.UpsertNamespaces(index, req.Namespaces); err != nil {
+		n.logger.Error("UpsertNamespaces failed", "error", err)
+		return err
+	}
+	return nil
+}
+
+// applyNamespaceDelete is used to delete a set of namespaces
+func (n *nomadFSM) applyNamespaceDelete(buf []byte, index uint64) interface{} {
+	defer metrics.MeasureSince([]string{"nomad", "fsm", "apply_namespace_delete"}, time.Now())
+	var req structs.NamespaceDeleteRequest
+	if err := structs.Decode(buf, &req); err != nil {
+		panic(fmt.Errorf("failed to decode request: %v", err))
+	}
+
+	if err := n.state.DeleteNamespaces(index, req.Namespaces); err != nil {
+		n.logger.Error("DeleteNamespaces failed", "error", err)
+		return err
+	}
+	return nil
+}
+
+// applyAdmissionPolicyUpsert is used to upsert a set of admission policies
+func (n *nomadFSM) applyAdmissionPolicyUpsert(buf []byte, index uint64) interface{} {
+	defer metrics.MeasureSince([]string{"nomad", "fsm", "apply_admission_policy_upsert"}, time.Now())
+	var req structs.AdmissionPolicyUpsertRequest
+	if err := structs.Decode(buf, &req); err != nil {
+		panic(fmt.Errorf("failed to decode request: %v", err))
+	}
+
+	if err := n.state.UpsertAdmissionPolicies(index, req.Policies); err != nil {
+		n.logger.Error("UpsertAdmissionPolicies failed", "error", err)
+		return err
+	}
+	return nil
+}
+
+// applyAdmissionPolicyDelete is used to delete a set of admission policies
+func (n *nomadFSM) applyAdmissionPolicyDelete(buf []byte, index uint64) interface{} {
+	defer metrics.MeasureSince([]string{"nomad", "fsm", "apply_admission_policy_delete"}, time.Now())
+	var req structs.AdmissionPolicyDeleteRequest
+	if err := structs.Decode(buf, &req); err != nil {
+		panic(fmt.Errorf("failed to decode request: %v", err))
+	}
+
+	if err := n.state.DeleteAdmissionPolicies(index, req.Names); err != nil {
+		n.logger.Error("DeleteAdmissionPolicies failed", "error", err)
+		return err
+	}
+	return nil
+}
+
+// applyUpsertScalingEvent is used to record a scaling event of a task group
+func (n *nomadFSM) applyUpsertScalingEvent(buf []byte, index uint64) interface{} {
+	defer metrics.MeasureSince([]string{"nomad", "fsm", "upsert_scaling_event"}, time.Now())
+	var req structs.ScalingEventRequest
+	if err := structs.Decode(buf, &req); err != nil {
+		panic(fmt.Errorf("failed to decode request: %v", err))
+	}
+
+	if err := n.state.UpsertScalingEvent(index, &req); err != nil {
+		n.logger.Error("UpsertScalingEvent failed", "error", err)
+		return err
+	}
+
+	// Create the evaluation of the new count
+	if req.Eval != nil {
+		req.Eval.JobModifyIndex = index
+		if err := n.upsertEvals(index, []*structs.Evaluation{req.Eval}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // applyACLPolicyUpsert is used to upsert a set of policies
 func (n *nomadFSM) applyACLPolicyUpsert(buf []byte, index uint64) interface{} {
 	defer metrics.MeasureSince([]string{"nomad", "fsm", "apply_acl_policy_upsert"}, time.Now())
@@ -1056,6 +1202,23 @@ func (n *nomadFSM) applySchedulerConfigUpdate(buf []byte, index uint64) interfac
 	return n.state.SchedulerSetConfig(index, &req.Config)
 }
 
+// applyQueueDispatch queues a dispatched job until its parameterized job is
+// below its concurrency limit.
+func (n *nomadFSM) applyQueueDispatch(buf []byte, index uint64) interface{} {
+	defer metrics.MeasureSince([]string{"nomad", "fsm", "queue_dispatch"}, time.Now())
+	var req structs.JobDispatchQueueRequest
+	if err := structs.Decode(buf, &req); err != nil {
+		panic(fmt.Errorf("failed to decode request: %v", err))
+	}
+
+	if err := n.state.UpsertQueuedDispatch(index, req.Dispatch); err != nil {
+		n.logger.Error("UpsertQueuedDispatch failed", "error", err)
+		return err
+	}
+
+	return nil
+}
+
 func (n *nomadFSM) Snapshot() (raft.FSMSnapshot, error) {
 	// Create a new snapshot
 	snap, err := n.state.Snapshot()
@@ -1255,6 +1418,69 @@ func (n *nomadFSM) Restore(old io.ReadCloser) error {
 				return err
 			}
 
+		case QueuedDispatchSnapshot:
+			dispatch := new(structs.QueuedDispatch)
+			if err := dec.Decode(dispatch); err != nil {
+				return err
+			}
+			if err := restore.QueuedDispatchRestore(dispatch); err != nil {
+				return err
+			}
+
+		case DispatchTokenSnapshot:
+			token := new(structs.DispatchToken)
+			if err := dec.Decode(token); err != nil {
+				return err
+			}
+			if err := restore.DispatchTokenRestore(token); err != nil {
+				return err
+			}
+
+		case JobSubmissionSnapshot:
+			submission := new(structs.JobSubmission)
+			if err := dec.Decode(submission); err != nil {
+				return err
+			}
+			if err := restore.JobSubmissionRestore(submission); err != nil {
+				return err
+			}
+
+		case NamespaceSnapshot:
+			namespace := new(structs.Namespace)
+			if err := dec.Decode(namespace); err != nil {
+				return err
+			}
+			if err := restore.NamespaceRestore(namespace); err != nil {
+				return err
+			}
+
+		case AdmissionPolicySnapshot:
+			policy := new(structs.AdmissionPolicy)
+			if err := dec.Decode(policy); err != nil {
+				return err
+			}
+			if err := restore.AdmissionPolicyRestore(policy); err != nil {
+				return err
+			}
+
+		case ScalingEventsSnapshot:
+			events := new(structs.JobScalingEvents)
+			if err := dec.Decode(events); err != nil {
+				return err
+			}
+			if err := restore.ScalingEventsRestore(events); err != nil {
+				return err
+			}
+
+		case ScalingPolicySnapshot:
+			policy := new(structs.ScalingPolicy)
+			if err := dec.Decode(policy); err != nil {
+				return err
+			}
+			if err := restore.ScalingPolicyRestore(policy); err != nil {
+				return err
+			}
+
 		default:
 			// Check if this is an enterprise only object being restored
 			restorer, ok := n.enterpriseRestorers[snapType]
@@ -1527,6 +1753,34 @@ func (s *nomadSnapshot) Persist(sink raft.SnapshotSink) error {
 		sink.Cancel()
 		return err
 	}
+	if err := s.persistQueuedDispatches(sink, encoder); err != nil {
+		sink.Cancel()
+		return err
+	}
+	if err := s.persistDispatchTokens(sink, encoder); err != nil {
+		sink.Cancel()
+		return err
+	}
+	if err := s.persistJobSubmissions(sink, encoder); err != nil {
+		sink.Cancel()
+		return err
+	}
+	if err := s.persistNamespaces(sink, encoder); err != nil {
+		sink.Cancel()
+		return err
+	}
+	if err := s.persistAdmissionPolicies(sink, encoder); err != nil {
+		sink.Cancel()
+		return err
+	}
+	if err := s.persistScalingEvents(sink, encoder); err != nil {
+		sink.Cancel()
+		return err
+	}
+	if err := s.persistScalingPolicies(sink, encoder); err != nil {
+		sink.Cancel()
+		return err
+	}
 	return nil
 }
 
@@ -1697,6 +1951,153 @@ func (s *nomadSnapshot) persistPeriodicLaunches(sink raft.SnapshotSink,
 	return nil
 }
 
+func (s *nomadSnapshot) persistQueuedDispatches(sink raft.SnapshotSink,
+	encoder *codec.Encoder) error {
+	// Get all the queued dispatches
+	ws := memdb.NewWatchSet()
+	dispatches, err := s.snap.QueuedDispatches(ws)
+	if err != nil {
+		return err
+	}
+
+	for raw := dispatches.Next(); raw != nil; raw = dispatches.Next() {
+		dispatch := raw.(*structs.QueuedDispatch)
+
+		// Write out the queued dispatch
+		sink.Write([]byte{byte(QueuedDispatchSnapshot)})
+		if err := encoder.Encode(dispatch); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *nomadSnapshot) persistDispatchTokens(sink raft.SnapshotSink,
+	encoder *codec.Encoder) error {
+	// Get all the dispatch idempotency tokens
+	ws := memdb.NewWatchSet()
+	tokens, err := s.snap.DispatchTokens(ws)
+	if err != nil {
+		return err
+	}
+
+	for raw := tokens.Next(); raw != nil; raw = tokens.Next() {
+		token := raw.(*structs.DispatchToken)
+
+		// Write out the dispatch token
+		sink.Write([]byte{byte(DispatchTokenSnapshot)})
+		if err := encoder.Encode(token); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *nomadSnapshot) persistJobSubmissions(sink raft.SnapshotSink,
+	encoder *codec.Encoder) error {
+	// Get the sources of all the job versions
+	ws := memdb.NewWatchSet()
+	submissions, err := s.snap.JobSubmissions(ws)
+	if err != nil {
+		return err
+	}
+
+	for raw := submissions.Next(); raw != nil; raw = submissions.Next() {
+		submission := raw.(*structs.JobSubmission)
+
+		// Write out the job submission
+		sink.Write([]byte{byte(JobSubmissionSnapshot)})
+		if err := encoder.Encode(submission); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *nomadSnapshot) persistNamespaces(sink raft.SnapshotSink,
+	encoder *codec.Encoder) error {
+	// Get all the namespaces
+	ws := memdb.NewWatchSet()
+	namespaces, err := s.snap.Namespaces(ws)
+	if err != nil {
+		return err
+	}
+
+	for raw := namespaces.Next(); raw != nil; raw = namespaces.Next() {
+		namespace := raw.(*structs.Namespace)
+
+		// Write out the namespace
+		sink.Write([]byte{byte(NamespaceSnapshot)})
+		if err := encoder.Encode(namespace); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *nomadSnapshot) persistAdmissionPolicies(sink raft.SnapshotSink,
+	encoder *codec.Encoder) error {
+	// Get all the policies
+	ws := memdb.NewWatchSet()
+	policies, err := s.snap.AdmissionPolicies(ws)
+	if err != nil {
+		return err
+	}
+
+	for raw := policies.Next(); raw != nil; raw = policies.Next() {
+		policy := raw.(*structs.AdmissionPolicy)
+
+		// Write out the policy
+		sink.Write([]byte{byte(AdmissionPolicySnapshot)})
+		if err := encoder.Encode(policy); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *nomadSnapshot) persistScalingEvents(sink raft.SnapshotSink,
+	encoder *codec.Encoder) error {
+	// Get the scaling events of all the jobs
+	ws := memdb.NewWatchSet()
+	iter, err := s.snap.ScalingEvents(ws)
+	if err != nil {
+		return err
+	}
+
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		events := raw.(*structs.JobScalingEvents)
+
+		// Write out the scaling events
+		sink.Write([]byte{byte(ScalingEventsSnapshot)})
+		if err := encoder.Encode(events); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *nomadSnapshot) persistScalingPolicies(sink raft.SnapshotSink,
+	encoder *codec.Encoder) error {
+	// Get all the scaling policies
+	ws := memdb.NewWatchSet()
+	iter, err := s.snap.ScalingPolicies(ws)
+	if err != nil {
+		return err
+	}
+
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		policy := raw.(*structs.ScalingPolicy)
+
+		// Write out the scaling policy
+		sink.Write([]byte{byte(ScalingPolicySnapshot)})
+		if err := encoder.Encode(policy); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (s *nomadSnapshot) persistJobSummaries(sink raft.SnapshotSink,
 	encoder *codec.Encoder) error {
 
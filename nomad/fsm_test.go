@@ -558,6 +558,35 @@ func TestFSM_UpdateNodeEligibility_Unblock(t *testing.T) {
 	})
 }
 
+func TestFSM_RegisterJob_Submission(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	fsm := testFSM(t)
+
+	job := mock.Job()
+	req := structs.JobRegisterRequest{
+		Job: job,
+		Submission: &structs.JobSubmission{
+			Source: `job "example" {}`,
+			Format: structs.JobSubmissionFormatHCL1,
+		},
+		WriteRequest: structs.WriteRequest{
+			Namespace: job.Namespace,
+		},
+	}
+	buf, err := structs.Encode(structs.JobRegisterRequestType, req)
+	require.NoError(err)
+	require.Nil(fsm.Apply(makeLog(buf)))
+
+	// Verify the source was stored with the job version
+	out, err := fsm.State().JobSubmission(nil, job.Namespace, job.ID, 0)
+	require.NoError(err)
+	require.NotNil(out)
+	require.Equal(`job "example" {}`, out.Source)
+	require.Equal(job.ID, out.JobID)
+	require.Equal(uint64(1), out.JobModifyIndex)
+}
+
 func TestFSM_RegisterJob(t *testing.T) {
 	t.Parallel()
 	fsm := testFSM(t)
@@ -1169,6 +1198,75 @@ func TestFSM_DeleteEval(t *testing.T) {
 	}
 }
 
+func TestFSM_DeleteEval_Tracked(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	fsm := testFSM(t)
+	fsm.evalBroker.SetEnabled(true)
+	fsm.blockedEvals.SetEnabled(true)
+
+	// Upsert a pending and a blocked evaluation
+	pending := mock.Eval()
+	blocked := mock.Eval()
+	blocked.Status = structs.EvalStatusBlocked
+	req := structs.EvalUpdateRequest{
+		Evals: []*structs.Evaluation{pending, blocked},
+	}
+	buf, err := structs.Encode(structs.EvalUpdateRequestType, req)
+	require.NoError(err)
+	require.Nil(fsm.Apply(makeLog(buf)))
+
+	require.Equal(1, fsm.evalBroker.Stats().TotalReady)
+	require.Equal(1, fsm.blockedEvals.Stats().TotalBlocked)
+
+	// Delete both evaluations
+	req2 := structs.EvalDeleteRequest{
+		Evals: []string{pending.ID, blocked.ID},
+	}
+	buf, err = structs.Encode(structs.EvalDeleteRequestType, req2)
+	require.NoError(err)
+	require.Nil(fsm.Apply(makeLog(buf)))
+
+	// Verify they are no longer tracked
+	require.Zero(fsm.evalBroker.Stats().TotalReady)
+	require.Zero(fsm.blockedEvals.Stats().TotalBlocked)
+}
+
+func TestFSM_DeleteEval_Outstanding(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	fsm := testFSM(t)
+	fsm.evalBroker.SetEnabled(true)
+
+	// Upsert a pending evaluation and dequeue it
+	eval := mock.Eval()
+	req := structs.EvalUpdateRequest{
+		Evals: []*structs.Evaluation{eval},
+	}
+	buf, err := structs.Encode(structs.EvalUpdateRequestType, req)
+	require.NoError(err)
+	require.Nil(fsm.Apply(makeLog(buf)))
+
+	out, token, err := fsm.evalBroker.Dequeue([]string{eval.Type}, time.Second)
+	require.NoError(err)
+	require.Equal(eval.ID, out.ID)
+
+	// Delete the evaluation
+	req2 := structs.EvalDeleteRequest{
+		Evals: []string{eval.ID},
+	}
+	buf, err = structs.Encode(structs.EvalDeleteRequestType, req2)
+	require.NoError(err)
+	require.Nil(fsm.Apply(makeLog(buf)))
+
+	// The evaluation is deleted but left to its scheduler
+	stored, err := fsm.State().EvalByID(nil, eval.ID)
+	require.NoError(err)
+	require.Nil(stored)
+	require.Equal(1, fsm.evalBroker.Stats().TotalUnacked)
+	require.NoError(fsm.evalBroker.Ack(eval.ID, token))
+}
+
 func TestFSM_UpsertAllocs(t *testing.T) {
 	t.Parallel()
 	fsm := testFSM(t)
@@ -2531,6 +2629,94 @@ func TestFSM_SnapshotRestore_PeriodicLaunches(t *testing.T) {
 	}
 }
 
+func TestFSM_SnapshotRestore_QueuedDispatches(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	// Add some state
+	fsm := testFSM(t)
+	state := fsm.State()
+	parent := mock.BatchJob()
+	parent.ParameterizedJob = &structs.ParameterizedJobConfig{
+		MaxConcurrent: 1,
+	}
+	require.NoError(state.UpsertJob(1000, parent))
+
+	child := parent.Copy()
+	child.ID = structs.DispatchedID(parent.ID, time.Now())
+	child.ParentID = parent.ID
+	child.Dispatched = true
+	dispatch := &structs.QueuedDispatch{
+		ID:        child.ID,
+		Namespace: child.Namespace,
+		ParentID:  parent.ID,
+		Job:       child,
+	}
+	require.NoError(state.UpsertQueuedDispatch(1001, dispatch))
+
+	// Verify the contents
+	fsm2 := testSnapshotRestore(t, fsm)
+	state2 := fsm2.State()
+	out, err := state2.QueuedDispatchesByParent(nil, parent.Namespace, parent.ID)
+	require.NoError(err)
+	require.Equal([]*structs.QueuedDispatch{dispatch}, out)
+}
+
+func TestFSM_SnapshotRestore_DispatchTokens(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	// Add some state
+	fsm := testFSM(t)
+	state := fsm.State()
+	parent := mock.BatchJob()
+	parent.ParameterizedJob = &structs.ParameterizedJobConfig{}
+	require.NoError(state.UpsertJob(1000, parent))
+
+	child := parent.Copy()
+	child.ID = structs.DispatchedID(parent.ID, time.Now())
+	child.ParentID = parent.ID
+	child.Dispatched = true
+	child.DispatchIdempotencyToken = "foo"
+	require.NoError(state.UpsertJob(1001, child))
+
+	token, err := state.DispatchTokenByID(nil, parent.Namespace, parent.ID, "foo")
+	require.NoError(err)
+	require.NotNil(token)
+
+	// Verify the contents
+	fsm2 := testSnapshotRestore(t, fsm)
+	state2 := fsm2.State()
+	out, err := state2.DispatchTokenByID(nil, parent.Namespace, parent.ID, "foo")
+	require.NoError(err)
+	require.Equal(token, out)
+}
+
+func TestFSM_SnapshotRestore_JobSubmissions(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	// Add some state
+	fsm := testFSM(t)
+	state := fsm.State()
+	job := mock.Job()
+	require.NoError(state.UpsertJob(1000, job))
+	sub := &structs.JobSubmission{
+		Source:        `job "example" {}`,
+		Format:        structs.JobSubmissionFormatHCL2,
+		VariableFlags: map[string]string{"dc": "dc1"},
+		Namespace:     job.Namespace,
+		JobID:         job.ID,
+	}
+	require.NoError(state.UpsertJobSubmission(1001, sub))
+
+	// Verify the contents
+	fsm2 := testSnapshotRestore(t, fsm)
+	out, err := fsm2.State().JobSubmission(nil, job.Namespace, job.ID, 0)
+	require.NoError(err)
+	require.Equal(sub, out)
+}
+
 func TestFSM_SnapshotRestore_JobSummary(t *testing.T) {
 	t.Parallel()
 	// Add some state
@@ -2972,3 +3158,231 @@ func TestFSM_SchedulerConfig(t *testing.T) {
 	require.True(config.PreemptionConfig.SystemSchedulerEnabled)
 	require.True(config.PreemptionConfig.BatchSchedulerEnabled)
 }
+
+func TestFSM_UpsertNamespaces(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	fsm := testFSM(t)
+
+	ns1 := mock.Namespace()
+	ns2 := mock.Namespace()
+	req := structs.NamespaceUpsertRequest{
+		Namespaces: []*structs.Namespace{ns1, ns2},
+	}
+	buf, err := structs.Encode(structs.NamespaceUpsertRequestType, req)
+	require.NoError(err)
+	require.Nil(fsm.Apply(makeLog(buf)))
+
+	ws := memdb.NewWatchSet()
+	out, err := fsm.State().NamespaceByName(ws, ns1.Name)
+	require.NoError(err)
+	require.NotNil(out)
+
+	out, err = fsm.State().NamespaceByName(ws, ns2.Name)
+	require.NoError(err)
+	require.NotNil(out)
+}
+
+func TestFSM_DeleteNamespaces(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	fsm := testFSM(t)
+
+	ns1 := mock.Namespace()
+	ns2 := mock.Namespace()
+	require.NoError(fsm.State().UpsertNamespaces(1000, []*structs.Namespace{ns1, ns2}))
+
+	req := structs.NamespaceDeleteRequest{
+		Namespaces: []string{ns1.Name, ns2.Name},
+	}
+	buf, err := structs.Encode(structs.NamespaceDeleteRequestType, req)
+	require.NoError(err)
+	require.Nil(fsm.Apply(makeLog(buf)))
+
+	ws := memdb.NewWatchSet()
+	out, err := fsm.State().NamespaceByName(ws, ns1.Name)
+	require.NoError(err)
+	require.Nil(out)
+
+	out, err = fsm.State().NamespaceByName(ws, ns2.Name)
+	require.NoError(err)
+	require.Nil(out)
+}
+
+func TestFSM_SnapshotRestore_Namespaces(t *testing.T) {
+	t.Parallel()
+	// Add some state
+	fsm := testFSM(t)
+	state := fsm.State()
+	ns1 := mock.Namespace()
+	ns2 := mock.Namespace()
+	state.UpsertNamespaces(1000, []*structs.Namespace{ns1, ns2})
+
+	// Verify the contents
+	fsm2 := testSnapshotRestore(t, fsm)
+	state2 := fsm2.State()
+	ws := memdb.NewWatchSet()
+	out1, _ := state2.NamespaceByName(ws, ns1.Name)
+	out2, _ := state2.NamespaceByName(ws, ns2.Name)
+	require.Equal(t, ns1, out1)
+	require.Equal(t, ns2, out2)
+}
+
+func TestFSM_UpsertAdmissionPolicies(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	fsm := testFSM(t)
+
+	policy := mock.AdmissionPolicy()
+	req := structs.AdmissionPolicyUpsertRequest{
+		Policies: []*structs.AdmissionPolicy{policy},
+	}
+	buf, err := structs.Encode(structs.AdmissionPolicyUpsertRequestType, req)
+	require.NoError(err)
+	require.Nil(fsm.Apply(makeLog(buf)))
+
+	out, err := fsm.State().AdmissionPolicyByName(nil, policy.Name)
+	require.NoError(err)
+	require.NotNil(out)
+}
+
+func TestFSM_DeleteAdmissionPolicies(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	fsm := testFSM(t)
+
+	policy := mock.AdmissionPolicy()
+	require.NoError(fsm.State().UpsertAdmissionPolicies(1000, []*structs.AdmissionPolicy{policy}))
+
+	req := structs.AdmissionPolicyDeleteRequest{
+		Names: []string{policy.Name},
+	}
+	buf, err := structs.Encode(structs.AdmissionPolicyDeleteRequestType, req)
+	require.NoError(err)
+	require.Nil(fsm.Apply(makeLog(buf)))
+
+	out, err := fsm.State().AdmissionPolicyByName(nil, policy.Name)
+	require.NoError(err)
+	require.Nil(out)
+}
+
+func TestFSM_SnapshotRestore_AdmissionPolicies(t *testing.T) {
+	t.Parallel()
+	// Add some state
+	fsm := testFSM(t)
+	state := fsm.State()
+	p1 := mock.AdmissionPolicy()
+	p2 := mock.AdmissionPolicy()
+	state.UpsertAdmissionPolicies(1000, []*structs.AdmissionPolicy{p1, p2})
+
+	// Verify the contents
+	fsm2 := testSnapshotRestore(t, fsm)
+	state2 := fsm2.State()
+	out1, _ := state2.AdmissionPolicyByName(nil, p1.Name)
+	out2, _ := state2.AdmissionPolicyByName(nil, p2.Name)
+	require.Equal(t, p1, out1)
+	require.Equal(t, p2, out2)
+}
+
+func TestFSM_UpsertScalingEvent(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	fsm := testFSM(t)
+
+	job := mock.Job()
+	require.NoError(fsm.State().UpsertJob(1000, job))
+
+	req := structs.ScalingEventRequest{
+		Namespace: job.Namespace,
+		JobID:     job.ID,
+		TaskGroup: job.TaskGroups[0].Name,
+		ScalingEvent: &structs.ScalingEvent{
+			Count:   helper.Int64ToPtr(3),
+			Message: "scale out",
+		},
+		JobModifyIndex: 1000,
+		Eval:           mock.Eval(),
+	}
+	buf, err := structs.Encode(structs.ScalingEventRegisterRequestType, req)
+	require.NoError(err)
+	require.Nil(fsm.Apply(makeLog(buf)))
+
+	events, _, err := fsm.State().ScalingEventsByJob(nil, job.Namespace, job.ID)
+	require.NoError(err)
+	require.Len(events[job.TaskGroups[0].Name], 1)
+
+	// The count is updated without creating a new version of the job
+	out, err := fsm.State().JobByID(nil, job.Namespace, job.ID)
+	require.NoError(err)
+	require.Equal(3, out.TaskGroups[0].Count)
+	require.Zero(out.Version)
+	require.EqualValues(1, out.JobModifyIndex)
+
+	eval, err := fsm.State().EvalByID(nil, req.Eval.ID)
+	require.NoError(err)
+	require.NotNil(eval)
+	require.EqualValues(1, eval.JobModifyIndex)
+
+	// Scaling a job modified since it was looked up fails
+	req.JobModifyIndex = 1000
+	req.Eval = nil
+	buf, err = structs.Encode(structs.ScalingEventRegisterRequestType, req)
+	require.NoError(err)
+	resp := fsm.Apply(makeLog(buf))
+	require.Error(resp.(error))
+	require.Contains(resp.(error).Error(), "was modified while being scaled")
+}
+
+func TestFSM_SnapshotRestore_ScalingEvents(t *testing.T) {
+	t.Parallel()
+	// Add some state
+	fsm := testFSM(t)
+	state := fsm.State()
+	job := mock.Job()
+	state.UpsertJob(1000, job)
+	state.UpsertScalingEvent(1001, &structs.ScalingEventRequest{
+		Namespace: job.Namespace,
+		JobID:     job.ID,
+		TaskGroup: job.TaskGroups[0].Name,
+		ScalingEvent: &structs.ScalingEvent{
+			Count:   helper.Int64ToPtr(3),
+			Message: "scale out",
+		},
+		JobModifyIndex: 1000,
+	})
+
+	// Verify the contents
+	fsm2 := testSnapshotRestore(t, fsm)
+	state2 := fsm2.State()
+	out1, idx1, _ := state.ScalingEventsByJob(nil, job.Namespace, job.ID)
+	out2, idx2, _ := state2.ScalingEventsByJob(nil, job.Namespace, job.ID)
+	require.Equal(t, out1, out2)
+	require.Equal(t, idx1, idx2)
+}
+
+func TestFSM_SnapshotRestore_ScalingPolicies(t *testing.T) {
+	t.Parallel()
+	// Add some state
+	fsm := testFSM(t)
+	state := fsm.State()
+	job := mock.Job()
+	job.TaskGroups[0].Scaling = &structs.ScalingPolicy{
+		ID:      uuid.Generate(),
+		Min:     1,
+		Max:     10,
+		Enabled: true,
+		Policy: map[string]interface{}{
+			"target": "cpu",
+		},
+	}
+	state.UpsertJob(1000, job)
+
+	// Verify the contents
+	fsm2 := testSnapshotRestore(t, fsm)
+	state2 := fsm2.State()
+	id := job.TaskGroups[0].Scaling.ID
+	out1, _ := state.ScalingPolicyByID(nil, id)
+	out2, _ := state2.ScalingPolicyByID(nil, id)
+	require.NotNil(t, out2)
+	require.Equal(t, out1, out2)
+}
@@ -150,12 +150,29 @@ func (h *nodeHeartbeater) invalidateHeartbeat(id string) {
 		return
 	}
 
-	h.logger.Warn("node TTL expired", "node_id", id)
+	// Determine whether the node should be marked as disconnected rather
+	// than down because some of its allocations tolerate a disconnect.
+	status, window, err := h.disconnectState(id)
+	if err != nil {
+		h.logger.Error("failed to determine node disconnect state", "node_id", id, "error", err)
+		status, window = structs.NodeStatusDown, 0
+	}
+
+	// The node is already disconnected and still within the disconnect window
+	// of its allocations so wait until the window passes.
+	if status == "" {
+		h.heartbeatTimersLock.Lock()
+		h.resetHeartbeatTimerLocked(id, window)
+		h.heartbeatTimersLock.Unlock()
+		return
+	}
+
+	h.logger.Warn("node TTL expired", "node_id", id, "status", status)
 
 	// Make a request to update the node status
 	req := structs.NodeUpdateStatusRequest{
 		NodeID:    id,
-		Status:    structs.NodeStatusDown,
+		Status:    status,
 		NodeEvent: structs.NewNodeEvent().SetSubsystem(structs.NodeEventSubsystemCluster).SetMessage(NodeHeartbeatEventMissed),
 		WriteRequest: structs.WriteRequest{
 			Region: h.config.Region,
@@ -164,7 +181,73 @@ func (h *nodeHeartbeater) invalidateHeartbeat(id string) {
 	var resp structs.NodeUpdateResponse
 	if err := h.staticEndpoints.Node.UpdateStatus(&req, &resp); err != nil {
 		h.logger.Error("update node status failed", "error", err)
+		return
+	}
+
+	// Track the disconnected node so it is marked down once the disconnect
+	// window of its allocations has passed.
+	if status == structs.NodeStatusDisconnected {
+		h.heartbeatTimersLock.Lock()
+		h.resetHeartbeatTimerLocked(id, window)
+		h.heartbeatTimersLock.Unlock()
+	}
+}
+
+// disconnectState returns the status a node whose heartbeat has expired
+// should transition to. A node running allocations with a max_client_disconnect
+// window is marked as disconnected until the longest window has passed, after
+// which it is marked down. An empty status is returned if the node is already
+// disconnected and should remain so. The returned duration is the time
+// remaining before the node should be marked down.
+func (h *nodeHeartbeater) disconnectState(id string) (string, time.Duration, error) {
+	snap, err := h.fsm.State().Snapshot()
+	if err != nil {
+		return "", 0, err
+	}
+
+	ws := memdb.NewWatchSet()
+	node, err := snap.NodeByID(ws, id)
+	if err != nil {
+		return "", 0, err
 	}
+	if node == nil {
+		return structs.NodeStatusDown, 0, nil
+	}
+
+	allocs, err := snap.AllocsByNodeTerminal(ws, id, false)
+	if err != nil {
+		return "", 0, err
+	}
+
+	var window time.Duration
+	for _, alloc := range allocs {
+		if alloc.Job == nil {
+			continue
+		}
+		tg := alloc.Job.LookupTaskGroup(alloc.TaskGroup)
+		if tg == nil || tg.MaxClientDisconnect == nil {
+			continue
+		}
+		if *tg.MaxClientDisconnect > window {
+			window = *tg.MaxClientDisconnect
+		}
+	}
+
+	if window == 0 {
+		return structs.NodeStatusDown, 0, nil
+	}
+
+	// Compute the remaining window if the node is already disconnected
+	if node.Status == structs.NodeStatusDisconnected {
+		disconnectedAt := time.Unix(node.StatusUpdatedAt, 0)
+		remaining := disconnectedAt.Add(window).Sub(time.Now())
+		if remaining <= 0 {
+			return structs.NodeStatusDown, 0, nil
+		}
+		return "", remaining, nil
+	}
+
+	return structs.NodeStatusDisconnected, window, nil
 }
 
 // clearHeartbeatTimer is used to clear the heartbeat time for
@@ -7,6 +7,7 @@ import (
 
 	memdb "github.com/hashicorp/go-memdb"
 	msgpackrpc "github.com/hashicorp/net-rpc-msgpackrpc"
+	"github.com/hashicorp/nomad/helper"
 	"github.com/hashicorp/nomad/nomad/mock"
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/hashicorp/nomad/testutil"
@@ -162,6 +163,53 @@ func TestHeartbeat_InvalidateHeartbeat(t *testing.T) {
 	require.Equal(NodeHeartbeatEventMissed, out.Events[1].Message)
 }
 
+func TestHeartbeat_InvalidateHeartbeat_Disconnect(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	s1 := TestServer(t, nil)
+	defer s1.Shutdown()
+	testutil.WaitForLeader(t, s1.RPC)
+
+	// Create a node
+	node := mock.Node()
+	state := s1.fsm.State()
+	require.NoError(state.UpsertNode(1, node))
+
+	// Create an allocation that tolerates its node disconnecting
+	job := mock.Job()
+	job.TaskGroups[0].MaxClientDisconnect = helper.TimeToPtr(time.Hour)
+	require.NoError(state.UpsertJob(2, job))
+
+	alloc := mock.Alloc()
+	alloc.Job = job
+	alloc.JobID = job.ID
+	alloc.NodeID = node.ID
+	require.NoError(state.UpsertJobSummary(3, mock.JobSummary(alloc.JobID)))
+	require.NoError(state.UpsertAllocs(4, []*structs.Allocation{alloc}))
+
+	// This should mark the node as disconnected rather than down
+	s1.invalidateHeartbeat(node.ID)
+
+	ws := memdb.NewWatchSet()
+	out, err := state.NodeByID(ws, node.ID)
+	require.NoError(err)
+	require.Equal(structs.NodeStatusDisconnected, out.Status)
+	require.False(out.TerminalStatus())
+
+	// A timer should be tracking the disconnect window
+	s1.heartbeatTimersLock.Lock()
+	_, ok := s1.heartbeatTimers[node.ID]
+	s1.heartbeatTimersLock.Unlock()
+	require.True(ok)
+
+	// Expiring the heartbeat again within the window should not mark the
+	// node down
+	s1.invalidateHeartbeat(node.ID)
+	out, err = state.NodeByID(ws, node.ID)
+	require.NoError(err)
+	require.Equal(structs.NodeStatusDisconnected, out.Status)
+}
+
 func TestHeartbeat_ClearHeartbeatTimer(t *testing.T) {
 	t.Parallel()
 	s1 := TestServer(t, nil)
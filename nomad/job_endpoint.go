@@ -19,6 +19,7 @@ import (
 	"github.com/hashicorp/nomad/helper/uuid"
 	"github.com/hashicorp/nomad/nomad/state"
 	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/nomad/structs/config"
 	"github.com/hashicorp/nomad/scheduler"
 )
 
@@ -57,9 +58,16 @@ type Job struct {
 	// builtin admission controllers
 	mutators   []jobMutator
 	validators []jobValidator
+
+	// webhooks are the configured external admission controllers
+	webhooks []*jobAdmissionWebhook
+
+	// policies enforces the admission policies
+	policies jobAdmissionPolicies
 }
 
-// NewJobEndpoints creates a new job endpoint with builtin admission controllers
+// NewJobEndpoints creates a new job endpoint with builtin admission
+// controllers and the admission webhooks of the server
 func NewJobEndpoints(s *Server) *Job {
 	return &Job{
 		srv:    s,
@@ -73,6 +81,8 @@ func NewJobEndpoints(s *Server) *Job {
 			jobConnectHook{},
 			jobValidate{},
 		},
+		webhooks: s.admissionWebhooks,
+		policies: jobAdmissionPolicies{srv: s},
 	}
 }
 
@@ -88,24 +98,52 @@ func (j *Job) Register(args *structs.JobRegisterRequest, reply *structs.JobRegis
 		return fmt.Errorf("missing job for registration")
 	}
 
-	// Run admission controllers
-	job, warnings, err := j.admissionControllers(args.Job)
+	// Check job submission permissions before the job reaches the admission
+	// controllers
+	aclObj, err := j.srv.ResolveToken(args.AuthToken)
 	if err != nil {
 		return err
-	}
-	args.Job = job
-
-	// Set the warning message
-	reply.Warnings = structs.MergeMultierrorWarnings(warnings...)
-
-	// Check job submission permissions
-	if aclObj, err := j.srv.ResolveToken(args.AuthToken); err != nil {
-		return err
 	} else if aclObj != nil {
 		if !aclObj.AllowNsOp(args.RequestNamespace(), acl.NamespaceCapabilitySubmitJob) {
 			return structs.ErrPermissionDenied
 		}
-		// Validate Volume Permsissions
+
+		// Check if override is set and we do not have permissions
+		if args.PolicyOverride {
+			if !aclObj.AllowNsOp(args.RequestNamespace(), acl.NamespaceCapabilitySentinelOverride) {
+				j.logger.Warn("policy override attempted without permissions for job", "job", args.Job.ID)
+				return structs.ErrPermissionDenied
+			}
+			j.logger.Warn("policy override set for job", "job", args.Job.ID)
+		}
+	}
+
+	// Run admission controllers. Multiregion jobs are only checked by the
+	// builtin admission controllers before being registered in each of their
+	// regions, where every admission controller is run on the copy of the job
+	// for that region. A request may claim to be from a peer region, so the
+	// webhooks and admission policies are never skipped for it.
+	var job *structs.Job
+	var warnings []error
+	if args.Job.IsMultiregion() && !args.MultiregionPeer {
+		job, warnings, err = j.admissionBuiltin(args.Job)
+	} else {
+		job, warnings, err = j.admissionControllers(config.AdmissionWebhookOperationRegister, args.PolicyOverride, args.Job)
+	}
+	if err != nil {
+		return err
+	}
+	args.Job = job
+
+	// The job is authorized and registered in the namespace of the request,
+	// which is checked against the job as the admission controllers left it
+	if args.Job.Namespace != args.RequestNamespace() {
+		return fmt.Errorf("job namespace %q does not match the request namespace %q",
+			args.Job.Namespace, args.RequestNamespace())
+	}
+
+	// Validate Volume Permsissions of the mutated job
+	if aclObj != nil {
 		for _, tg := range args.Job.TaskGroups {
 			for _, vol := range tg.Volumes {
 				if vol.Type != structs.VolumeTypeHost {
@@ -127,22 +165,40 @@ func (j *Job) Register(args *structs.JobRegisterRequest, reply *structs.JobRegis
 				}
 			}
 		}
+	}
 
-		// Check if override is set and we do not have permissions
-		if args.PolicyOverride {
-			if !aclObj.AllowNsOp(args.RequestNamespace(), acl.NamespaceCapabilitySentinelOverride) {
-				j.logger.Warn("policy override attempted without permissions for job", "job", args.Job.ID)
-				return structs.ErrPermissionDenied
-			}
-			j.logger.Warn("policy override set for job", "job", args.Job.ID)
+	// Validate the job submission, dropping it if it is too large to store
+	if args.Submission != nil {
+		if err := args.Submission.Validate(); err != nil {
+			return err
+		}
+		if size := args.Submission.Size(); size > structs.JobSubmissionMaxSize {
+			warnings = append(warnings, fmt.Errorf("job source of %d bytes exceeds the %d bytes limit and was not stored",
+				size, structs.JobSubmissionMaxSize))
+			args.Submission = nil
 		}
 	}
 
-	// Lookup the job
+	// Set the warning message
+	reply.Warnings = structs.MergeMultierrorWarnings(warnings...)
+
 	snap, err := j.srv.State().Snapshot()
 	if err != nil {
 		return err
 	}
+
+	// Ensure the namespace of the job exists
+	if err := validateJobNamespace(snap, args.Job); err != nil {
+		return err
+	}
+
+	// Multiregion jobs are registered in each of their regions by the region
+	// they were submitted to
+	if args.Job.IsMultiregion() && !args.MultiregionPeer {
+		return j.multiregionRegister(args, reply)
+	}
+
+	// Lookup the job
 	ws := memdb.NewWatchSet()
 	existingJob, err := snap.JobByID(ws, args.RequestNamespace(), args.Job.ID)
 	if err != nil {
@@ -169,6 +225,9 @@ func (j *Job) Register(args *structs.JobRegisterRequest, reply *structs.JobRegis
 		return err
 	}
 
+	// Keep the IDs of the scaling policies across versions of the job
+	propagateScalingPolicyIDs(existingJob, args.Job)
+
 	// Ensure that the job has permissions for the requested Vault tokens
 	policies := args.Job.VaultPolicies()
 	if len(policies) != 0 {
@@ -219,6 +278,9 @@ func (j *Job) Register(args *structs.JobRegisterRequest, reply *structs.JobRegis
 	// Clear the Vault token
 	args.Job.VaultToken = ""
 
+	// Clear the version tag, only existing versions can be tagged
+	args.Job.VersionTag = nil
+
 	// Check if the job has changed at all
 	if existingJob == nil || existingJob.SpecChanged(args.Job) {
 		// Set the submit time
@@ -281,6 +343,62 @@ func (j *Job) Register(args *structs.JobRegisterRequest, reply *structs.JobRegis
 	return nil
 }
 
+// multiregionRegister registers a copy of a multiregion job in each of its
+// regions, forwarding the registrations of remote regions to them. The reply
+// is that of the local region if the job runs in it and otherwise that of the
+// first region the job was registered in. Registrations that already succeeded
+// are not rolled back if another region fails, instead the error names the
+// regions the job was registered in so that the registration can be retried.
+func (j *Job) multiregionRegister(args *structs.JobRegisterRequest, reply *structs.JobRegisterResponse) error {
+	// Ensure every region is known before registering the job anywhere so
+	// that a misspelled region doesn't leave the job partially registered
+	known := helper.SliceStringToSet(j.srv.Regions())
+	for _, region := range args.Job.Multiregion.Regions {
+		if _, ok := known[region.Name]; !ok {
+			return fmt.Errorf("multiregion job references unknown region %q", region.Name)
+		}
+	}
+
+	var mErr multierror.Error
+	var registered []string
+	replied := false
+	for _, region := range args.Job.Multiregion.Regions {
+		req := *args
+		req.Job = args.Job.RegionalCopy(region)
+		req.Region = region.Name
+		req.MultiregionPeer = true
+
+		var resp structs.JobRegisterResponse
+		var err error
+		if region.Name == j.srv.config.Region {
+			err = j.Register(&req, &resp)
+		} else {
+			// The job modify index is only meaningful in the local region
+			req.EnforceIndex = false
+			req.JobModifyIndex = 0
+			err = j.srv.forwardRegion(region.Name, "Job.Register", &req, &resp)
+		}
+		if err != nil {
+			j.logger.Error("multiregion job registration failed", "job", args.Job.ID, "region", region.Name, "error", err)
+			multierror.Append(&mErr, fmt.Errorf("region %q: %v", region.Name, err))
+			continue
+		}
+		registered = append(registered, region.Name)
+
+		if !replied || region.Name == j.srv.config.Region {
+			*reply = resp
+			replied = true
+		}
+	}
+
+	err := mErr.ErrorOrNil()
+	if err != nil && len(registered) != 0 {
+		return fmt.Errorf("multiregion job was only registered in regions %s: %v",
+			strings.Join(registered, ", "), err)
+	}
+	return err
+}
+
 // getSignalConstraint builds a suitable constraint based on the required
 // signals
 func getSignalConstraint(signals []string) *structs.Constraint {
@@ -357,7 +475,7 @@ func (j *Job) Validate(args *structs.JobValidateRequest, reply *structs.JobValid
 	}
 
 	// Validate the job and capture any warnings
-	validateWarnings, err := j.admissionValidators(args.Job)
+	validateWarnings, err := j.admissionValidators(args.Job, false)
 	if err != nil {
 		if merr, ok := err.(*multierror.Error); ok {
 			for _, err := range merr.Errors {
@@ -411,6 +529,19 @@ func (j *Job) Revert(args *structs.JobRevertRequest, reply *structs.JobRegisterR
 	if cur == nil {
 		return fmt.Errorf("job %q not found", args.JobID)
 	}
+
+	// Resolve the version of the tag being reverted to
+	if args.TagName != "" {
+		tagged, err := snap.JobVersionByTagName(ws, args.RequestNamespace(), args.JobID, args.TagName)
+		if err != nil {
+			return err
+		}
+		if tagged == nil {
+			return fmt.Errorf("job %q in namespace %q has no version tagged %q", args.JobID, args.RequestNamespace(), args.TagName)
+		}
+		args.JobVersion = tagged.Version
+	}
+
 	if args.JobVersion == cur.Version {
 		return fmt.Errorf("can't revert to current version")
 	}
@@ -423,12 +554,19 @@ func (j *Job) Revert(args *structs.JobRevertRequest, reply *structs.JobRegisterR
 		return fmt.Errorf("job %q in namespace %q at version %d not found", args.JobID, args.RequestNamespace(), args.JobVersion)
 	}
 
+	// Carry over the source of the reverted version
+	submission, err := snap.JobSubmission(ws, args.RequestNamespace(), args.JobID, args.JobVersion)
+	if err != nil {
+		return err
+	}
+
 	// Build the register request
 	revJob := jobV.Copy()
 	// Use Vault Token from revert request to perform registration of reverted job.
 	revJob.VaultToken = args.VaultToken
 	reg := &structs.JobRegisterRequest{
 		Job:          revJob,
+		Submission:   submission.Copy(),
 		WriteRequest: args.WriteRequest,
 	}
 
@@ -492,6 +630,255 @@ func (j *Job) Stable(args *structs.JobStabilityRequest, reply *structs.JobStabil
 	return nil
 }
 
+// TagVersion is used to tag a job version, or to remove a version tag
+func (j *Job) TagVersion(args *structs.JobTagRequest, reply *structs.JobTagResponse) error {
+	if done, err := j.srv.forward("Job.TagVersion", args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "job", "tag_version"}, time.Now())
+
+	// Check for submit-job permissions
+	if aclObj, err := j.srv.ResolveToken(args.AuthToken); err != nil {
+		return err
+	} else if aclObj != nil && !aclObj.AllowNsOp(args.RequestNamespace(), acl.NamespaceCapabilitySubmitJob) {
+		return structs.ErrPermissionDenied
+	}
+
+	// Validate the arguments
+	if args.JobID == "" {
+		return fmt.Errorf("missing job ID for tagging")
+	}
+	if args.Name == "" {
+		return fmt.Errorf("missing tag name")
+	}
+
+	snap, err := j.srv.fsm.State().Snapshot()
+	if err != nil {
+		return err
+	}
+
+	ws := memdb.NewWatchSet()
+	tagged, err := snap.JobVersionByTagName(ws, args.RequestNamespace(), args.JobID, args.Name)
+	if err != nil {
+		return err
+	}
+
+	if args.Unset {
+		if tagged == nil {
+			return fmt.Errorf("job %q in namespace %q has no version tagged %q", args.JobID, args.RequestNamespace(), args.Name)
+		}
+	} else {
+		tag := &structs.JobVersionTag{
+			Name:        args.Name,
+			Description: args.Description,
+		}
+		if err := tag.Validate(); err != nil {
+			return err
+		}
+
+		// Default to tagging the current version
+		if args.Version == nil {
+			cur, err := snap.JobByID(ws, args.RequestNamespace(), args.JobID)
+			if err != nil {
+				return err
+			}
+			if cur == nil {
+				return fmt.Errorf("job %q not found", args.JobID)
+			}
+			args.Version = helper.Uint64ToPtr(cur.Version)
+		}
+
+		jobV, err := snap.JobByIDAndVersion(ws, args.RequestNamespace(), args.JobID, *args.Version)
+		if err != nil {
+			return err
+		}
+		if jobV == nil {
+			return fmt.Errorf("job %q in namespace %q at version %d not found", args.JobID, args.RequestNamespace(), *args.Version)
+		}
+		if tagged != nil && tagged.Version != jobV.Version {
+			return fmt.Errorf("tag %q already exists on version %d of job %q", args.Name, tagged.Version, args.JobID)
+		}
+		if jobV.VersionTag == nil {
+			versions, err := snap.JobVersionsByID(ws, args.RequestNamespace(), args.JobID)
+			if err != nil {
+				return err
+			}
+			if structs.TaggedJobVersions(versions) >= structs.JobMaxTaggedVersions {
+				return fmt.Errorf("job %q already has the maximum of %d tagged versions", args.JobID, structs.JobMaxTaggedVersions)
+			}
+		}
+
+		args.TaggedTime = time.Now().UTC().UnixNano()
+	}
+
+	// Commit this tag request via Raft
+	fsmErr, modifyIndex, err := j.srv.raftApply(structs.JobVersionTagRequestType, args)
+	if err, ok := fsmErr.(error); ok && err != nil {
+		j.logger.Error("tagging job version failed", "error", err, "fsm", true)
+		return err
+	}
+	if err != nil {
+		j.logger.Error("submitting job version tag request failed", "error", err)
+		return err
+	}
+
+	// Setup the reply
+	reply.Index = modifyIndex
+	return nil
+}
+
+// Scale is used to change the count of a task group of a job, recording a
+// scaling event for it. If the request has no count, only the scaling event is
+// recorded.
+func (j *Job) Scale(args *structs.JobScaleRequest, reply *structs.JobRegisterResponse) error {
+	if done, err := j.srv.forward("Job.Scale", args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "job", "scale"}, time.Now())
+
+	// Check for scale-job or submit-job permissions
+	if aclObj, err := j.srv.ResolveToken(args.AuthToken); err != nil {
+		return err
+	} else if aclObj != nil {
+		if !aclObj.AllowNsOp(args.RequestNamespace(), acl.NamespaceCapabilityScaleJob) &&
+			!aclObj.AllowNsOp(args.RequestNamespace(), acl.NamespaceCapabilitySubmitJob) {
+			return structs.ErrPermissionDenied
+		}
+
+		// Check if override is set and we do not have permissions
+		if args.PolicyOverride && !aclObj.AllowNsOp(args.RequestNamespace(), acl.NamespaceCapabilitySentinelOverride) {
+			j.logger.Warn("policy override attempted without permissions for job", "job", args.JobID)
+			return structs.ErrPermissionDenied
+		}
+	}
+
+	// Validate the arguments
+	if err := args.Validate(); err != nil {
+		return err
+	}
+
+	snap, err := j.srv.State().Snapshot()
+	if err != nil {
+		return err
+	}
+
+	// Lookup the job
+	ws := memdb.NewWatchSet()
+	existingJob, err := snap.JobByID(ws, args.RequestNamespace(), args.JobID)
+	if err != nil {
+		return err
+	}
+	if existingJob == nil {
+		return structs.NewErrUnknownJob(args.JobID)
+	}
+
+	// If EnforceIndex set, check it before trying to apply
+	if args.EnforceIndex && args.JobModifyIndex != existingJob.JobModifyIndex {
+		return fmt.Errorf("%s %d: job exists with conflicting job modify index: %d",
+			RegisterEnforceIndexErrPrefix, args.JobModifyIndex, existingJob.JobModifyIndex)
+	}
+
+	groupName := args.Target[structs.ScalingTargetGroup]
+	group := existingJob.LookupTaskGroup(groupName)
+	if group == nil {
+		return fmt.Errorf("task group %q does not exist in job %q", groupName, args.JobID)
+	}
+
+	event := &structs.ScalingEventRequest{
+		Namespace: existingJob.Namespace,
+		JobID:     existingJob.ID,
+		TaskGroup: groupName,
+		ScalingEvent: &structs.ScalingEvent{
+			Time:          time.Now().UTC().UnixNano(),
+			Count:         args.Count,
+			PreviousCount: int64(group.Count),
+			Message:       args.Message,
+			Error:         args.Error,
+			Meta:          args.Meta,
+		},
+		WriteRequest: structs.WriteRequest{Region: args.Region},
+	}
+
+	// Keep the count within the bounds of the scaling policy
+	if args.Count != nil && group.Scaling != nil {
+		if *args.Count < group.Scaling.Min {
+			return fmt.Errorf("group count was less than scaling policy minimum: %d < %d",
+				*args.Count, group.Scaling.Min)
+		}
+		if *args.Count > group.Scaling.Max {
+			return fmt.Errorf("group count was greater than scaling policy maximum: %d > %d",
+				*args.Count, group.Scaling.Max)
+		}
+	}
+
+	reply.JobModifyIndex = existingJob.JobModifyIndex
+	if args.Count != nil {
+		// Check the new count against the admission controllers and Sentinel
+		// policies. Only the count is applied, along with the scaling event,
+		// so that scaling doesn't create a new version of the job.
+		job := existingJob.Copy()
+		job.LookupTaskGroup(groupName).Count = int(*args.Count)
+
+		// Run admission controllers
+		job, warnings, err := j.admissionControllers(config.AdmissionWebhookOperationRegister, args.PolicyOverride, job)
+		if err != nil {
+			return err
+		}
+
+		// Enforce Sentinel policies
+		policyWarnings, err := j.enforceSubmitJob(args.PolicyOverride, job)
+		if err != nil {
+			return err
+		}
+		if policyWarnings != nil {
+			warnings = append(warnings, policyWarnings)
+		}
+		reply.Warnings = structs.MergeMultierrorWarnings(warnings...)
+
+		// The count is only applied if the job hasn't changed since it was
+		// looked up
+		event.JobModifyIndex = existingJob.JobModifyIndex
+
+		// If the job is periodic or parameterized, we don't create an eval.
+		if !existingJob.IsPeriodic() && !existingJob.IsParameterized() {
+			now := time.Now().UTC().UnixNano()
+			event.Eval = &structs.Evaluation{
+				ID:          uuid.Generate(),
+				Namespace:   args.RequestNamespace(),
+				Priority:    existingJob.Priority,
+				Type:        existingJob.Type,
+				TriggeredBy: structs.EvalTriggerScaling,
+				JobID:       existingJob.ID,
+				Status:      structs.EvalStatusPending,
+				CreateTime:  now,
+				ModifyTime:  now,
+			}
+			event.ScalingEvent.EvalID = helper.StringToPtr(event.Eval.ID)
+		}
+	}
+
+	// Commit the scaling event, and the new count, via Raft
+	fsmErr, index, err := j.srv.raftApply(structs.ScalingEventRegisterRequestType, event)
+	if err, ok := fsmErr.(error); ok && err != nil {
+		j.logger.Error("scaling job failed", "error", err, "fsm", true)
+		return err
+	}
+	if err != nil {
+		j.logger.Error("scaling job failed", "error", err, "raft", true)
+		return err
+	}
+
+	if args.Count != nil {
+		reply.JobModifyIndex = index
+	}
+	if event.Eval != nil {
+		reply.EvalID = event.Eval.ID
+		reply.EvalCreateIndex = index
+	}
+	reply.Index = index
+	return nil
+}
+
 // Evaluate is used to force a job for re-evaluation
 func (j *Job) Evaluate(args *structs.JobEvaluateRequest, reply *structs.JobRegisterResponse) error {
 	if done, err := j.srv.forward("Job.Evaluate", args, args, reply); done {
@@ -857,6 +1244,234 @@ func (j *Job) GetJobVersions(args *structs.JobVersionsRequest,
 	return j.srv.blockingRPC(&opts)
 }
 
+// Diff is used to diff two versions of a job, or a version of a job against a
+// job that isn't registered
+func (j *Job) Diff(args *structs.JobDiffRequest, reply *structs.JobDiffResponse) error {
+	if done, err := j.srv.forward("Job.Diff", args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "job", "diff"}, time.Now())
+
+	// Check for read-job permissions
+	if aclObj, err := j.srv.ResolveToken(args.AuthToken); err != nil {
+		return err
+	} else if aclObj != nil && !aclObj.AllowNsOp(args.RequestNamespace(), acl.NamespaceCapabilityReadJob) {
+		return structs.ErrPermissionDenied
+	}
+
+	// Validate the arguments
+	if args.JobID == "" {
+		return fmt.Errorf("missing job ID for diff")
+	}
+
+	// Run the builtin admission controllers on the unregistered job so it is
+	// diffed as it would be registered. Diffing only requires read-job, so
+	// the admission webhooks and policies are not run.
+	var newJob *structs.Job
+	if args.Job != nil {
+		if args.Job.ID != args.JobID {
+			return fmt.Errorf("job ID %q does not match the job ID %q of the diff", args.Job.ID, args.JobID)
+		}
+		job, _, err := j.admissionBuiltin(args.Job)
+		if err != nil {
+			return err
+		}
+		newJob = job
+	}
+
+	// Setup the blocking query
+	opts := blockingOptions{
+		queryOpts: &args.QueryOptions,
+		queryMeta: &reply.QueryMeta,
+		run: func(ws memdb.WatchSet, state *state.StateStore) error {
+			reply.Diff = nil
+
+			// Use the last index that affected the job_version table
+			index, err := state.Index("job_version")
+			if err != nil {
+				return err
+			}
+			reply.Index = index
+			j.srv.setQueryMeta(&reply.QueryMeta)
+
+			cur, err := state.JobByID(ws, args.RequestNamespace(), args.JobID)
+			if err != nil || cur == nil {
+				return err
+			}
+
+			// Lookup a version, defaulting to the current one
+			lookup := func(version *uint64) (*structs.Job, error) {
+				if version == nil || *version == cur.Version {
+					return cur, nil
+				}
+				return state.JobByIDAndVersion(ws, args.RequestNamespace(), args.JobID, *version)
+			}
+
+			from, err := lookup(args.FromVersion)
+			if err != nil || from == nil {
+				return err
+			}
+
+			to := newJob
+			if to == nil {
+				to, err = lookup(args.ToVersion)
+				if err != nil || to == nil {
+					return err
+				}
+			}
+
+			diff, err := from.Diff(to, args.Contextual)
+			if err != nil {
+				return fmt.Errorf("failed to create job diff: %v", err)
+			}
+			reply.Diff = diff
+			return nil
+		}}
+	return j.srv.blockingRPC(&opts)
+}
+
+// GetJobSubmission is used to retrieve the source of a job version
+func (j *Job) GetJobSubmission(args *structs.JobSubmissionRequest,
+	reply *structs.JobSubmissionResponse) error {
+	if done, err := j.srv.forward("Job.GetJobSubmission", args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "job", "get_job_submission"}, time.Now())
+
+	// Check for read-job permissions
+	if aclObj, err := j.srv.ResolveToken(args.AuthToken); err != nil {
+		return err
+	} else if aclObj != nil && !aclObj.AllowNsOp(args.RequestNamespace(), acl.NamespaceCapabilityReadJob) {
+		return structs.ErrPermissionDenied
+	}
+
+	// Setup the blocking query
+	opts := blockingOptions{
+		queryOpts: &args.QueryOptions,
+		queryMeta: &reply.QueryMeta,
+		run: func(ws memdb.WatchSet, state *state.StateStore) error {
+			// Look for the submission
+			out, err := state.JobSubmission(ws, args.RequestNamespace(), args.JobID, args.Version)
+			if err != nil {
+				return err
+			}
+
+			// Setup the output
+			reply.Submission = out
+
+			// Use the last index that affected the job submission table
+			index, err := state.Index("job_submission")
+			if err != nil {
+				return err
+			}
+			reply.Index = index
+
+			// Set the query response
+			j.srv.setQueryMeta(&reply.QueryMeta)
+			return nil
+		}}
+	return j.srv.blockingRPC(&opts)
+}
+
+// ScaleStatus is used to retrieve the counts of the task groups of a job
+// along with their recent scaling events
+func (j *Job) ScaleStatus(args *structs.JobScaleStatusRequest,
+	reply *structs.JobScaleStatusResponse) error {
+	if done, err := j.srv.forward("Job.ScaleStatus", args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "job", "scale_status"}, time.Now())
+
+	// Check for read-job permissions
+	if aclObj, err := j.srv.ResolveToken(args.AuthToken); err != nil {
+		return err
+	} else if aclObj != nil && !aclObj.AllowNsOp(args.RequestNamespace(), acl.NamespaceCapabilityReadJob) {
+		return structs.ErrPermissionDenied
+	}
+
+	// Setup the blocking query
+	opts := blockingOptions{
+		queryOpts: &args.QueryOptions,
+		queryMeta: &reply.QueryMeta,
+		run: func(ws memdb.WatchSet, state *state.StateStore) error {
+			job, err := state.JobByID(ws, args.RequestNamespace(), args.JobID)
+			if err != nil {
+				return err
+			}
+			if job == nil {
+				reply.JobScaleStatus = nil
+
+				// Use the last index that affected the jobs table
+				index, err := state.Index("jobs")
+				if err != nil {
+					return err
+				}
+				reply.Index = index
+				j.srv.setQueryMeta(&reply.QueryMeta)
+				return nil
+			}
+
+			summary, err := state.JobSummaryByID(ws, args.RequestNamespace(), args.JobID)
+			if err != nil {
+				return err
+			}
+			deployment, err := state.LatestDeploymentByJobID(ws, args.RequestNamespace(), args.JobID)
+			if err != nil {
+				return err
+			}
+			events, eventsIndex, err := state.ScalingEventsByJob(ws, args.RequestNamespace(), args.JobID)
+			if err != nil {
+				return err
+			}
+
+			// Only the deployment of the current job counts
+			if deployment != nil && deployment.JobCreateIndex != job.CreateIndex {
+				deployment = nil
+			}
+
+			status := &structs.JobScaleStatus{
+				JobID:          job.ID,
+				Namespace:      job.Namespace,
+				JobCreateIndex: job.CreateIndex,
+				JobModifyIndex: job.ModifyIndex,
+				JobStopped:     job.Stop,
+				TaskGroups:     make(map[string]*structs.TaskGroupScaleStatus, len(job.TaskGroups)),
+			}
+			for _, tg := range job.TaskGroups {
+				tgStatus := &structs.TaskGroupScaleStatus{
+					Desired: tg.Count,
+					Events:  events[tg.Name],
+				}
+				if summary != nil {
+					tgStatus.Running = summary.Summary[tg.Name].Running
+				}
+				if deployment != nil {
+					if ds, ok := deployment.TaskGroups[tg.Name]; ok {
+						tgStatus.Placed = ds.PlacedAllocs
+						tgStatus.Healthy = ds.HealthyAllocs
+						tgStatus.Unhealthy = ds.UnhealthyAllocs
+					}
+				}
+				status.TaskGroups[tg.Name] = tgStatus
+			}
+			reply.JobScaleStatus = status
+
+			// Use the most recent index of the objects the status is made of
+			reply.Index = helper.Uint64Max(job.ModifyIndex, eventsIndex)
+			if summary != nil {
+				reply.Index = helper.Uint64Max(reply.Index, summary.ModifyIndex)
+			}
+			if deployment != nil {
+				reply.Index = helper.Uint64Max(reply.Index, deployment.ModifyIndex)
+			}
+
+			// Set the query response
+			j.srv.setQueryMeta(&reply.QueryMeta)
+			return nil
+		}}
+	return j.srv.blockingRPC(&opts)
+}
+
 // List is used to list the jobs registered in the system
 func (j *Job) List(args *structs.JobListRequest,
 	reply *structs.JobListResponse) error {
@@ -889,20 +1504,33 @@ func (j *Job) List(args *structs.JobListRequest,
 				return err
 			}
 
+			paginator, err := newPaginator(iter, args.QueryOptions,
+				func(raw interface{}) string {
+					return raw.(*structs.Job).ID
+				},
+				func(raw interface{}) (interface{}, error) {
+					job := raw.(*structs.Job)
+					summary, err := state.JobSummaryByID(ws, args.RequestNamespace(), job.ID)
+					if err != nil {
+						return nil, fmt.Errorf("unable to look up summary for job: %v", job.ID)
+					}
+					return job.Stub(summary), nil
+				})
+			if err != nil {
+				return err
+			}
+
+			stubs, nextToken, err := paginator.page()
+			if err != nil {
+				return err
+			}
+
 			var jobs []*structs.JobListStub
-			for {
-				raw := iter.Next()
-				if raw == nil {
-					break
-				}
-				job := raw.(*structs.Job)
-				summary, err := state.JobSummaryByID(ws, args.RequestNamespace(), job.ID)
-				if err != nil {
-					return fmt.Errorf("unable to look up summary for job: %v", job.ID)
-				}
-				jobs = append(jobs, job.Stub(summary))
+			for _, stub := range stubs {
+				jobs = append(jobs, stub.(*structs.JobListStub))
 			}
 			reply.Jobs = jobs
+			reply.NextToken = nextToken
 
 			// Use the last index that affected the jobs table or summary
 			jindex, err := state.Index("jobs")
@@ -1115,17 +1743,8 @@ func (j *Job) Plan(args *structs.JobPlanRequest, reply *structs.JobPlanResponse)
 		return fmt.Errorf("Job required for plan")
 	}
 
-	// Run admission controllers
-	job, warnings, err := j.admissionControllers(args.Job)
-	if err != nil {
-		return err
-	}
-	args.Job = job
-
-	// Set the warning message
-	reply.Warnings = structs.MergeMultierrorWarnings(warnings...)
-
-	// Check job submission permissions, which we assume is the same for plan
+	// Check job submission permissions, which we assume is the same for plan,
+	// before the job reaches the admission controllers
 	if aclObj, err := j.srv.ResolveToken(args.AuthToken); err != nil {
 		return err
 	} else if aclObj != nil {
@@ -1140,6 +1759,16 @@ func (j *Job) Plan(args *structs.JobPlanRequest, reply *structs.JobPlanResponse)
 		}
 	}
 
+	// Run admission controllers
+	job, warnings, err := j.admissionControllers(config.AdmissionWebhookOperationPlan, args.PolicyOverride, args.Job)
+	if err != nil {
+		return err
+	}
+	args.Job = job
+
+	// Set the warning message
+	reply.Warnings = structs.MergeMultierrorWarnings(warnings...)
+
 	// Enforce Sentinel policies
 	policyWarnings, err := j.enforceSubmitJob(args.PolicyOverride, args.Job)
 	if err != nil {
@@ -1255,6 +1884,41 @@ func (j *Job) Plan(args *structs.JobPlanRequest, reply *structs.JobPlanResponse)
 	return nil
 }
 
+// validateJobNamespace ensures the namespace of a job exists.
+func validateJobNamespace(snap *state.StateSnapshot, job *structs.Job) error {
+	ns, err := snap.NamespaceByName(nil, job.Namespace)
+	if err != nil {
+		return err
+	}
+	if ns == nil {
+		return fmt.Errorf("job %q is in nonexistent namespace %q", job.ID, job.Namespace)
+	}
+	return nil
+}
+
+// propagateScalingPolicyIDs reuses the scaling policy IDs of the old job and generates missing ones.
+func propagateScalingPolicyIDs(old, new *structs.Job) {
+	oldIDs := make(map[string]string)
+	if old != nil {
+		for _, tg := range old.TaskGroups {
+			if tg.Scaling != nil {
+				oldIDs[tg.Name] = tg.Scaling.ID
+			}
+		}
+	}
+
+	for _, tg := range new.TaskGroups {
+		if tg.Scaling == nil {
+			continue
+		}
+		if id, ok := oldIDs[tg.Name]; ok && id != "" {
+			tg.Scaling.ID = id
+		} else {
+			tg.Scaling.ID = uuid.Generate()
+		}
+	}
+}
+
 // validateJobUpdate ensures updates to a job are valid.
 func validateJobUpdate(old, new *structs.Job) error {
 	// Validate Dispatch not set on new Jobs
@@ -1338,6 +2002,26 @@ func (j *Job) Dispatch(args *structs.JobDispatchRequest, reply *structs.JobDispa
 		return err
 	}
 
+	// Dispatches that are checked against the state, either because of an
+	// idempotency token or a concurrency limit, are serialized so that
+	// concurrent dispatches can't both pass the check.
+	if args.IdempotencyToken != "" || parameterizedJob.ParameterizedJob.MaxConcurrent > 0 {
+		j.srv.dispatchLock.Lock()
+		defer j.srv.dispatchLock.Unlock()
+	}
+
+	// Return the job previously dispatched with the idempotency token
+	if args.IdempotencyToken != "" {
+		found, err := existingDispatch(j.srv.fsm.State(), parameterizedJob, args.IdempotencyToken,
+			j.srv.config.DispatchTokenRetention, reply)
+		if err != nil {
+			return err
+		}
+		if found {
+			return nil
+		}
+	}
+
 	// Derive the child job and commit it via Raft
 	dispatchJob := parameterizedJob.Copy()
 	dispatchJob.ID = structs.DispatchedID(parameterizedJob.ID, time.Now())
@@ -1345,6 +2029,7 @@ func (j *Job) Dispatch(args *structs.JobDispatchRequest, reply *structs.JobDispa
 	dispatchJob.Name = dispatchJob.ID
 	dispatchJob.SetSubmitTime()
 	dispatchJob.Dispatched = true
+	dispatchJob.DispatchIdempotencyToken = args.IdempotencyToken
 
 	// Merge in the meta data
 	for k, v := range args.Meta {
@@ -1357,61 +2042,44 @@ func (j *Job) Dispatch(args *structs.JobDispatchRequest, reply *structs.JobDispa
 	// Compress the payload
 	dispatchJob.Payload = snappy.Encode(nil, args.Payload)
 
-	regReq := &structs.JobRegisterRequest{
-		Job:          dispatchJob,
-		WriteRequest: args.WriteRequest,
-	}
-
-	// Commit this update via Raft
-	fsmErr, jobCreateIndex, err := j.srv.raftApply(structs.JobRegisterRequestType, regReq)
-	if err, ok := fsmErr.(error); ok && err != nil {
-		j.logger.Error("dispatched job register failed", "error", err, "fsm", true)
-		return err
-	}
-	if err != nil {
-		j.logger.Error("dispatched job register failed", "error", err, "raft", true)
-		return err
-	}
-
-	reply.JobCreateIndex = jobCreateIndex
-	reply.DispatchedJobID = dispatchJob.ID
-	reply.Index = jobCreateIndex
-
-	// If the job is periodic, we don't create an eval.
-	if !dispatchJob.IsPeriodic() {
-		// Create a new evaluation
-		now := time.Now().UTC().UnixNano()
-		eval := &structs.Evaluation{
-			ID:             uuid.Generate(),
-			Namespace:      args.RequestNamespace(),
-			Priority:       dispatchJob.Priority,
-			Type:           dispatchJob.Type,
-			TriggeredBy:    structs.EvalTriggerJobRegister,
-			JobID:          dispatchJob.ID,
-			JobModifyIndex: jobCreateIndex,
-			Status:         structs.EvalStatusPending,
-			CreateTime:     now,
-			ModifyTime:     now,
-		}
-		update := &structs.EvalUpdateRequest{
-			Evals:        []*structs.Evaluation{eval},
-			WriteRequest: structs.WriteRequest{Region: args.Region},
-		}
-
-		// Commit this evaluation via Raft
-		_, evalIndex, err := j.srv.raftApply(structs.EvalUpdateRequestType, update)
+	// If the parameterized job limits how many dispatched jobs may run at
+	// once, determine whether the dispatch must be queued.
+	if parameterizedJob.ParameterizedJob.MaxConcurrent > 0 {
+		full, err := dispatchQueueFull(j.srv.fsm.State(), parameterizedJob)
 		if err != nil {
-			j.logger.Error("eval create failed", "error", err, "method", "dispatch")
 			return err
 		}
 
-		// Setup the reply
-		reply.EvalID = eval.ID
-		reply.EvalCreateIndex = evalIndex
-		reply.Index = evalIndex
+		if full {
+			req := &structs.JobDispatchQueueRequest{
+				Dispatch: &structs.QueuedDispatch{
+					ID:        dispatchJob.ID,
+					Namespace: dispatchJob.Namespace,
+					ParentID:  parameterizedJob.ID,
+					Job:       dispatchJob,
+				},
+				WriteRequest: args.WriteRequest,
+			}
+
+			// Commit this update via Raft
+			fsmErr, index, err := j.srv.raftApply(structs.JobDispatchQueueRequestType, req)
+			if err, ok := fsmErr.(error); ok && err != nil {
+				j.logger.Error("queueing dispatched job failed", "error", err, "fsm", true)
+				return err
+			}
+			if err != nil {
+				j.logger.Error("queueing dispatched job failed", "error", err, "raft", true)
+				return err
+			}
+
+			reply.DispatchedJobID = dispatchJob.ID
+			reply.Queued = true
+			reply.Index = index
+			return nil
+		}
 	}
 
-	return nil
+	return j.srv.registerDispatchedJob(dispatchJob, args.WriteRequest, reply)
 }
 
 // validateDispatchRequest returns whether the request is valid given the
@@ -1479,3 +2147,65 @@ func validateDispatchRequest(req *structs.JobDispatchRequest, job *structs.Job)
 
 	return nil
 }
+
+// existingDispatch looks up the job dispatched from the parameterized job with
+// the idempotency token. If the token was used within the retention period
+// and its dispatched job still exists, the reply is populated with it and true
+// is returned.
+func existingDispatch(snap *state.StateStore, job *structs.Job, token string,
+	retention time.Duration, reply *structs.JobDispatchResponse) (bool, error) {
+
+	dt, err := snap.DispatchTokenByID(nil, job.Namespace, job.ID, token)
+	if err != nil {
+		return false, err
+	}
+	if dt == nil || dt.Expired(retention, time.Now()) {
+		return false, nil
+	}
+
+	// The dispatched job may still be queued
+	queued, err := snap.QueuedDispatchByID(nil, dt.Namespace, dt.JobID)
+	if err != nil {
+		return false, err
+	}
+	if queued != nil {
+		reply.DispatchedJobID = dt.JobID
+		reply.Queued = true
+		reply.Existing = true
+		reply.Index = dt.ModifyIndex
+		return true, nil
+	}
+
+	dispatched, err := snap.JobByID(nil, dt.Namespace, dt.JobID)
+	if err != nil {
+		return false, err
+	}
+	if dispatched == nil {
+		return false, nil
+	}
+
+	reply.DispatchedJobID = dispatched.ID
+	reply.JobCreateIndex = dispatched.CreateIndex
+	reply.Existing = true
+	reply.Index = dt.ModifyIndex
+
+	// Return the evaluation created when the job was registered
+	evals, err := snap.EvalsByJob(nil, dispatched.Namespace, dispatched.ID)
+	if err != nil {
+		return false, err
+	}
+	for _, eval := range evals {
+		if eval.TriggeredBy != structs.EvalTriggerJobRegister {
+			continue
+		}
+		if reply.EvalID == "" || eval.CreateIndex < reply.EvalCreateIndex {
+			reply.EvalID = eval.ID
+			reply.EvalCreateIndex = eval.CreateIndex
+		}
+	}
+	if reply.EvalCreateIndex > reply.Index {
+		reply.Index = reply.EvalCreateIndex
+	}
+
+	return true, nil
+}
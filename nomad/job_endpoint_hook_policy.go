@@ -0,0 +1,91 @@
+package nomad
+
+import (
+	"fmt"
+	"strings"
+
+	multierror "github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/nomad/helper/policy"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// jobAdmissionPolicies is a validating admission controller enforcing the
+// admission policies stored in the state store.
+type jobAdmissionPolicies struct {
+	srv *Server
+}
+
+func (jobAdmissionPolicies) Name() string {
+	return "admission-policies"
+}
+
+// Enforce evaluates the admission policies against the job. Failed advisory
+// policies, and failed soft-mandatory policies when the policy override is
+// set, are returned as warnings. Other failed policies are returned as an
+// error.
+func (p jobAdmissionPolicies) Enforce(job *structs.Job, override bool) (warnings []error, err error) {
+	iter, err := p.srv.State().AdmissionPolicies(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Never evaluate policies against the Vault token
+	if job.VaultToken != "" {
+		job = job.Copy()
+		job.VaultToken = ""
+	}
+
+	var mErr multierror.Error
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		ap := raw.(*structs.AdmissionPolicy)
+
+		violations, err := p.eval(ap, job)
+		if err != nil {
+			multierror.Append(&mErr, fmt.Errorf("policy %q (%s) failed to evaluate: %v",
+				ap.Name, ap.EnforcementLevel, err))
+			continue
+		}
+		if len(violations) == 0 {
+			continue
+		}
+
+		switch ap.EnforcementLevel {
+		case structs.AdmissionPolicyEnforcementAdvisory:
+			warnings = append(warnings, fmt.Errorf("policy %q (%s) failed: %s",
+				ap.Name, ap.EnforcementLevel, violations))
+		case structs.AdmissionPolicyEnforcementSoftMandatory:
+			if override {
+				warnings = append(warnings, fmt.Errorf("policy %q (%s) failed and was overridden: %s",
+					ap.Name, ap.EnforcementLevel, violations))
+				continue
+			}
+			multierror.Append(&mErr, fmt.Errorf("policy %q (%s) failed: %s",
+				ap.Name, ap.EnforcementLevel, violations))
+		default:
+			multierror.Append(&mErr, fmt.Errorf("policy %q (%s) failed: %s",
+				ap.Name, ap.EnforcementLevel, violations))
+		}
+	}
+
+	return warnings, mErr.ErrorOrNil()
+}
+
+// eval evaluates a policy against the job and returns the messages of the
+// violated rules joined together.
+func (p jobAdmissionPolicies) eval(ap *structs.AdmissionPolicy, job *structs.Job) (string, error) {
+	parsed, err := policy.Parse(ap.Name, ap.Policy)
+	if err != nil {
+		return "", err
+	}
+
+	violations, err := parsed.Eval(job)
+	if err != nil {
+		return "", err
+	}
+
+	msgs := make([]string, 0, len(violations))
+	for _, v := range violations {
+		msgs = append(msgs, v.String())
+	}
+	return strings.Join(msgs, "; "), nil
+}
@@ -0,0 +1,175 @@
+package nomad
+
+import (
+	"testing"
+
+	msgpackrpc "github.com/hashicorp/net-rpc-msgpackrpc"
+	"github.com/hashicorp/nomad/acl"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJobAdmissionPolicies_Enforce(t *testing.T) {
+	t.Parallel()
+	s1 := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer s1.Shutdown()
+	testutil.WaitForLeader(t, s1.RPC)
+
+	// The mock policy requires the team meta key, which the mock job lacks
+	policy := mock.AdmissionPolicy()
+	policies := jobAdmissionPolicies{srv: s1}
+
+	cases := []struct {
+		level    string
+		override bool
+		warning  string
+		err      string
+	}{
+		{
+			level:   structs.AdmissionPolicyEnforcementAdvisory,
+			warning: "(advisory) failed",
+		},
+		{
+			level: structs.AdmissionPolicyEnforcementSoftMandatory,
+			err:   "(soft-mandatory) failed",
+		},
+		{
+			level:    structs.AdmissionPolicyEnforcementSoftMandatory,
+			override: true,
+			warning:  "(soft-mandatory) failed and was overridden",
+		},
+		{
+			level:    structs.AdmissionPolicyEnforcementHardMandatory,
+			override: true,
+			err:      "(hard-mandatory) failed",
+		},
+	}
+
+	for i, c := range cases {
+		policy.EnforcementLevel = c.level
+		require.NoError(t, s1.fsm.State().UpsertAdmissionPolicies(uint64(1000+i), []*structs.AdmissionPolicy{policy}))
+
+		warnings, err := policies.Enforce(mock.Job(), c.override)
+		if c.err != "" {
+			require.Error(t, err, c.level)
+			require.Contains(t, err.Error(), c.err)
+			require.Contains(t, err.Error(), "jobs must set the team meta key")
+			require.Empty(t, warnings)
+		} else {
+			require.NoError(t, err, c.level)
+			require.Len(t, warnings, 1)
+			require.Contains(t, warnings[0].Error(), c.warning)
+		}
+
+		// Jobs passing the policy are admitted without warnings
+		job := mock.Job()
+		job.Meta["team"] = "platform"
+		warnings, err = policies.Enforce(job, false)
+		require.NoError(t, err)
+		require.Empty(t, warnings)
+	}
+}
+
+func TestJobEndpoint_Register_AdmissionPolicies(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	s1 := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer s1.Shutdown()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	policy := mock.AdmissionPolicy()
+	policy.EnforcementLevel = structs.AdmissionPolicyEnforcementSoftMandatory
+	s1.fsm.State().UpsertAdmissionPolicies(1000, []*structs.AdmissionPolicy{policy})
+
+	// Planning and registering the job fail
+	job := mock.Job()
+	planReq := &structs.JobPlanRequest{
+		Job: job,
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: job.Namespace,
+		},
+	}
+	var planResp structs.JobPlanResponse
+	err := msgpackrpc.CallWithCodec(codec, "Job.Plan", planReq, &planResp)
+	require.Error(err)
+	require.Contains(err.Error(), "jobs must set the team meta key")
+
+	req := &structs.JobRegisterRequest{
+		Job: job,
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: job.Namespace,
+		},
+	}
+	var resp structs.JobRegisterResponse
+	err = msgpackrpc.CallWithCodec(codec, "Job.Register", req, &resp)
+	require.Error(err)
+	require.Contains(err.Error(), "jobs must set the team meta key")
+
+	out, err := s1.fsm.State().JobByID(nil, job.Namespace, job.ID)
+	require.NoError(err)
+	require.Nil(out)
+
+	// Overriding the policy registers the job with a warning
+	req.PolicyOverride = true
+	var resp2 structs.JobRegisterResponse
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Job.Register", req, &resp2))
+	require.Contains(resp2.Warnings, "failed and was overridden")
+
+	out, err = s1.fsm.State().JobByID(nil, job.Namespace, job.ID)
+	require.NoError(err)
+	require.NotNil(out)
+}
+
+func TestJobEndpoint_Register_AdmissionPolicies_ACL(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	s1, _ := TestACLServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer s1.Shutdown()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	state := s1.fsm.State()
+	policy := mock.AdmissionPolicy()
+	policy.EnforcementLevel = structs.AdmissionPolicyEnforcementSoftMandatory
+	state.UpsertAdmissionPolicies(1000, []*structs.AdmissionPolicy{policy})
+
+	submit := mock.CreatePolicyAndToken(t, state, 1001, "test-submit",
+		mock.NamespacePolicy(structs.DefaultNamespace, "", []string{acl.NamespaceCapabilitySubmitJob}))
+	override := mock.CreatePolicyAndToken(t, state, 1002, "test-override",
+		mock.NamespacePolicy(structs.DefaultNamespace, "", []string{
+			acl.NamespaceCapabilitySubmitJob,
+			acl.NamespaceCapabilitySentinelOverride,
+		}))
+
+	job := mock.Job()
+	req := &structs.JobRegisterRequest{
+		Job:            job,
+		PolicyOverride: true,
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: job.Namespace,
+			AuthToken: submit.SecretID,
+		},
+	}
+
+	// Overriding the policy requires the sentinel-override capability
+	var resp structs.JobRegisterResponse
+	err := msgpackrpc.CallWithCodec(codec, "Job.Register", req, &resp)
+	require.EqualError(err, structs.ErrPermissionDenied.Error())
+
+	req.AuthToken = override.SecretID
+	var resp2 structs.JobRegisterResponse
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Job.Register", req, &resp2))
+	require.Contains(resp2.Warnings, "failed and was overridden")
+}
@@ -0,0 +1,249 @@
+package nomad
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	metrics "github.com/armon/go-metrics"
+	cleanhttp "github.com/hashicorp/go-cleanhttp"
+	log "github.com/hashicorp/go-hclog"
+	multierror "github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/nomad/helper/jsonpatch"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/nomad/structs/config"
+)
+
+const (
+	// admissionWebhookMaxResponseSize is the maximum size of a webhook
+	// response that is read.
+	admissionWebhookMaxResponseSize = 4 * 1024 * 1024
+)
+
+// AdmissionWebhookRequest is the body POSTed to admission webhooks.
+type AdmissionWebhookRequest struct {
+	// Operation is either register or plan.
+	Operation string
+
+	// Job is the submitted job, after the builtin mutators have run. Its
+	// Vault token is never sent.
+	Job *structs.Job
+}
+
+// AdmissionWebhookResponse is the body returned by admission webhooks.
+type AdmissionWebhookResponse struct {
+	// Allowed must be set for the job to be admitted.
+	Allowed bool
+
+	// Message is the reason the job was denied.
+	Message string
+
+	// Warnings are returned to the submitter of the job.
+	Warnings []string
+
+	// Patch is an optional JSON patch applied to the job.
+	Patch []*jsonpatch.Operation
+}
+
+// jobAdmissionWebhook is an admission controller calling an external HTTP
+// endpoint to mutate and validate jobs.
+type jobAdmissionWebhook struct {
+	config *config.AdmissionWebhookConfig
+	client *http.Client
+	logger log.Logger
+}
+
+// newJobAdmissionWebhook returns an admission controller for the webhook
+// config.
+func newJobAdmissionWebhook(c *config.AdmissionWebhookConfig, logger log.Logger) (*jobAdmissionWebhook, error) {
+	c = c.Copy()
+	c.Canonicalize()
+
+	transport := cleanhttp.DefaultPooledTransport()
+	if c.CAFile != "" || c.TLSSkipVerify {
+		tlsConf := &tls.Config{InsecureSkipVerify: c.TLSSkipVerify}
+		if c.CAFile != "" {
+			pem, err := ioutil.ReadFile(c.CAFile)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read CA file: %v", err)
+			}
+			tlsConf.RootCAs = x509.NewCertPool()
+			if !tlsConf.RootCAs.AppendCertsFromPEM(pem) {
+				return nil, fmt.Errorf("failed to parse CA file %q", c.CAFile)
+			}
+		}
+		transport.TLSClientConfig = tlsConf
+	}
+
+	return &jobAdmissionWebhook{
+		config: c,
+		client: &http.Client{
+			Transport: transport,
+			Timeout:   c.Timeout,
+		},
+		logger: logger.With("webhook", c.Name),
+	}, nil
+}
+
+func (w *jobAdmissionWebhook) Name() string {
+	return "webhook-" + w.config.Name
+}
+
+// Admit calls the webhook for the operation and returns the job patched by the
+// webhook. An error is returned if the webhook denies the job, or if it fails
+// and its failure policy is fail_closed.
+func (w *jobAdmissionWebhook) Admit(op string, job *structs.Job) (_ *structs.Job, warnings []error, err error) {
+	defer metrics.MeasureSince([]string{"nomad", "job", "admission_webhook", w.config.Name}, time.Now())
+
+	resp, err := w.call(op, job)
+	if err == nil {
+		warnings = make([]error, 0, len(resp.Warnings))
+		for _, warn := range resp.Warnings {
+			warnings = append(warnings, fmt.Errorf("admission webhook %q: %s", w.config.Name, warn))
+		}
+
+		if !resp.Allowed {
+			metrics.IncrCounter([]string{"nomad", "job", "admission_webhook", w.config.Name, "denied"}, 1)
+			msg := resp.Message
+			if msg == "" {
+				msg = "no reason given"
+			}
+			mErr := multierror.Append(nil, fmt.Errorf("admission webhook %q denied the job: %s", w.config.Name, msg))
+			return nil, nil, multierror.Append(mErr, warnings...)
+		}
+
+		var patched *structs.Job
+		if patched, err = w.patch(job, resp.Patch); err == nil {
+			return patched, warnings, nil
+		}
+	}
+
+	// The webhook failed, apply the failure policy
+	metrics.IncrCounter([]string{"nomad", "job", "admission_webhook", w.config.Name, "failed"}, 1)
+	if w.config.FailurePolicy == config.AdmissionWebhookFailOpen {
+		w.logger.Warn("admission webhook failed, admitting the job", "job_id", job.ID, "error", err)
+		return job, []error{fmt.Errorf("admission webhook %q failed and was skipped: %v", w.config.Name, err)}, nil
+	}
+
+	w.logger.Error("admission webhook failed, rejecting the job", "job_id", job.ID, "error", err)
+	return nil, nil, fmt.Errorf("admission webhook %q failed: %v", w.config.Name, err)
+}
+
+// call POSTs the job to the webhook and decodes its response.
+func (w *jobAdmissionWebhook) call(op string, job *structs.Job) (*AdmissionWebhookResponse, error) {
+	// Never send the Vault token outside of the cluster
+	sent := job
+	if job.VaultToken != "" {
+		sent = job.Copy()
+		sent.VaultToken = ""
+	}
+
+	body, err := json.Marshal(&AdmissionWebhookRequest{Operation: op, Job: sent})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode request: %v", err)
+	}
+
+	req, err := http.NewRequest("POST", w.config.URL, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	httpResp, err := w.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer httpResp.Body.Close()
+
+	respBody, err := ioutil.ReadAll(io.LimitReader(httpResp.Body, admissionWebhookMaxResponseSize))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %v", err)
+	}
+	if httpResp.StatusCode < 200 || httpResp.StatusCode >= 300 {
+		return nil, fmt.Errorf("unexpected response code %d: %s", httpResp.StatusCode, bytes.TrimSpace(respBody))
+	}
+
+	var resp AdmissionWebhookResponse
+	if err := json.Unmarshal(respBody, &resp); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %v", err)
+	}
+	return &resp, nil
+}
+
+// patch applies a JSON patch returned by the webhook to the job.
+func (w *jobAdmissionWebhook) patch(job *structs.Job, patch []*jsonpatch.Operation) (*structs.Job, error) {
+	if len(patch) == 0 {
+		return job, nil
+	}
+
+	doc, err := json.Marshal(job)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode job: %v", err)
+	}
+
+	doc, err = jsonpatch.Apply(doc, patch)
+	if err != nil {
+		return nil, fmt.Errorf("failed to apply patch: %v", err)
+	}
+
+	// Decode numbers as json.Number so the untyped maps of the job, such as
+	// the task configs, don't have their integers turned into float64
+	var patched *structs.Job
+	dec := json.NewDecoder(bytes.NewReader(doc))
+	dec.UseNumber()
+	if err := dec.Decode(&patched); err != nil {
+		return nil, fmt.Errorf("failed to decode patched job: %v", err)
+	}
+	if patched == nil {
+		return nil, fmt.Errorf("patch removed the job")
+	}
+	for _, tg := range patched.TaskGroups {
+		if tg.Scaling != nil {
+			tg.Scaling.Policy = jsonNumbers(tg.Scaling.Policy).(map[string]interface{})
+		}
+		for _, task := range tg.Tasks {
+			task.Config = jsonNumbers(task.Config).(map[string]interface{})
+		}
+	}
+
+	// The job has already been authorized and its identity can't change
+	if patched.ID != job.ID {
+		return nil, fmt.Errorf("patch can't change the job ID")
+	}
+	if patched.Namespace != job.Namespace {
+		return nil, fmt.Errorf("patch can't change the job namespace")
+	}
+
+	patched.VaultToken = job.VaultToken
+	patched.Canonicalize()
+	return patched, nil
+}
+
+// jsonNumbers converts the json.Number values of a decoded JSON value to int64
+// if they are integers, as they are when decoded from msgpack, and to float64
+// otherwise.
+func jsonNumbers(val interface{}) interface{} {
+	switch v := val.(type) {
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i
+		}
+		f, _ := v.Float64()
+		return f
+	case map[string]interface{}:
+		for k, e := range v {
+			v[k] = jsonNumbers(e)
+		}
+	case []interface{}:
+		for i, e := range v {
+			v[i] = jsonNumbers(e)
+		}
+	}
+	return val
+}
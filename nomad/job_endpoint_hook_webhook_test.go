@@ -0,0 +1,459 @@
+package nomad
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"testing"
+	"time"
+
+	msgpackrpc "github.com/hashicorp/net-rpc-msgpackrpc"
+	"github.com/hashicorp/nomad/acl"
+	"github.com/hashicorp/nomad/helper/jsonpatch"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/nomad/structs/config"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+// testAdmissionWebhook returns a webhook server calling the handler with the
+// decoded request and encoding the returned response.
+func testAdmissionWebhook(t *testing.T, handler func(*AdmissionWebhookRequest) *AdmissionWebhookResponse) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req AdmissionWebhookRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("failed to decode webhook request: %v", err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		json.NewEncoder(w).Encode(handler(&req))
+	}))
+}
+
+func TestJobAdmissionWebhook_Admit(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	var received *AdmissionWebhookRequest
+	srv := testAdmissionWebhook(t, func(req *AdmissionWebhookRequest) *AdmissionWebhookResponse {
+		received = req
+		return &AdmissionWebhookResponse{
+			Allowed:  true,
+			Warnings: []string{"team label added"},
+			Patch: []*jsonpatch.Operation{
+				{Op: "add", Path: "/Meta/team", Value: "platform"},
+				{Op: "replace", Path: "/TaskGroups/0/Count", Value: 3},
+			},
+		}
+	})
+	defer srv.Close()
+
+	webhook, err := newJobAdmissionWebhook(&config.AdmissionWebhookConfig{
+		Name: "labels",
+		URL:  srv.URL,
+	}, testlog.HCLogger(t))
+	require.NoError(err)
+
+	job := mock.Job()
+	job.VaultToken = "secret"
+	job.TaskGroups[0].Tasks[0].Config["port"] = int64(9007199254740993)
+	job.TaskGroups[0].Tasks[0].Config["ratio"] = 0.5
+	out, warnings, err := webhook.Admit(config.AdmissionWebhookOperationRegister, job)
+	require.NoError(err)
+	require.Len(warnings, 1)
+	require.Contains(warnings[0].Error(), "team label added")
+
+	// The job is sent without its Vault token
+	require.Equal(config.AdmissionWebhookOperationRegister, received.Operation)
+	require.Equal(job.ID, received.Job.ID)
+	require.Empty(received.Job.VaultToken)
+
+	// The patch is applied and the Vault token kept, and the numbers of the
+	// task config keep their type
+	require.Equal("platform", out.Meta["team"])
+	require.Equal(3, out.TaskGroups[0].Count)
+	require.Equal("secret", out.VaultToken)
+	require.Equal(job.TaskGroups[0].Tasks, out.TaskGroups[0].Tasks)
+}
+
+func TestJobAdmissionWebhook_Deny(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	srv := testAdmissionWebhook(t, func(*AdmissionWebhookRequest) *AdmissionWebhookResponse {
+		return &AdmissionWebhookResponse{
+			Message:  "images must come from registry.example.com",
+			Warnings: []string{"task web uses docker.io"},
+		}
+	})
+	defer srv.Close()
+
+	webhook, err := newJobAdmissionWebhook(&config.AdmissionWebhookConfig{
+		Name:          "registries",
+		URL:           srv.URL,
+		FailurePolicy: config.AdmissionWebhookFailOpen,
+	}, testlog.HCLogger(t))
+	require.NoError(err)
+
+	// Denials are never skipped, even when failing open
+	_, _, err = webhook.Admit(config.AdmissionWebhookOperationPlan, mock.Job())
+	require.Error(err)
+	require.Contains(err.Error(), `admission webhook "registries" denied the job: images must come from registry.example.com`)
+	require.Contains(err.Error(), "task web uses docker.io")
+}
+
+func TestJobAdmissionWebhook_InvalidPatch(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	srv := testAdmissionWebhook(t, func(*AdmissionWebhookRequest) *AdmissionWebhookResponse {
+		return &AdmissionWebhookResponse{
+			Allowed: true,
+			Patch: []*jsonpatch.Operation{
+				{Op: "replace", Path: "/Namespace", Value: "other"},
+			},
+		}
+	})
+	defer srv.Close()
+
+	webhook, err := newJobAdmissionWebhook(&config.AdmissionWebhookConfig{
+		Name: "namespaces",
+		URL:  srv.URL,
+	}, testlog.HCLogger(t))
+	require.NoError(err)
+
+	_, _, err = webhook.Admit(config.AdmissionWebhookOperationRegister, mock.Job())
+	require.Error(err)
+	require.Contains(err.Error(), "patch can't change the job namespace")
+}
+
+func TestJobAdmissionWebhook_FailurePolicy(t *testing.T) {
+	t.Parallel()
+
+	// The webhook responds after the timeout
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(500 * time.Millisecond)
+		json.NewEncoder(w).Encode(&AdmissionWebhookResponse{Allowed: true})
+	}))
+	defer srv.Close()
+
+	t.Run("fail closed", func(t *testing.T) {
+		webhook, err := newJobAdmissionWebhook(&config.AdmissionWebhookConfig{
+			Name:    "slow",
+			URL:     srv.URL,
+			Timeout: 50 * time.Millisecond,
+		}, testlog.HCLogger(t))
+		require.NoError(t, err)
+
+		_, _, err = webhook.Admit(config.AdmissionWebhookOperationRegister, mock.Job())
+		require.Error(t, err)
+		require.Contains(t, err.Error(), `admission webhook "slow" failed`)
+	})
+
+	t.Run("fail open", func(t *testing.T) {
+		webhook, err := newJobAdmissionWebhook(&config.AdmissionWebhookConfig{
+			Name:          "slow",
+			URL:           srv.URL,
+			Timeout:       50 * time.Millisecond,
+			FailurePolicy: config.AdmissionWebhookFailOpen,
+		}, testlog.HCLogger(t))
+		require.NoError(t, err)
+
+		job := mock.Job()
+		out, warnings, err := webhook.Admit(config.AdmissionWebhookOperationRegister, job)
+		require.NoError(t, err)
+		require.Equal(t, job, out)
+		require.Len(t, warnings, 1)
+		require.Contains(t, warnings[0].Error(), `admission webhook "slow" failed and was skipped`)
+	})
+}
+
+func TestJobEndpoint_Register_AdmissionWebhooks(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	// The first webhook labels jobs and the second denies jobs without labels
+	labels := testAdmissionWebhook(t, func(*AdmissionWebhookRequest) *AdmissionWebhookResponse {
+		return &AdmissionWebhookResponse{
+			Allowed: true,
+			Patch: []*jsonpatch.Operation{
+				{Op: "add", Path: "/Meta/team", Value: "platform"},
+			},
+		}
+	})
+	defer labels.Close()
+
+	var operations []string
+	enforce := testAdmissionWebhook(t, func(req *AdmissionWebhookRequest) *AdmissionWebhookResponse {
+		operations = append(operations, req.Operation)
+		if req.Job.Meta["team"] == "" {
+			return &AdmissionWebhookResponse{Message: "missing team label"}
+		}
+		return &AdmissionWebhookResponse{Allowed: true, Warnings: []string{"labels checked"}}
+	})
+	defer enforce.Close()
+
+	s1 := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+		c.AdmissionWebhooks = []*config.AdmissionWebhookConfig{
+			{
+				Name:       "labels",
+				URL:        labels.URL,
+				Operations: []string{config.AdmissionWebhookOperationRegister},
+			},
+			{
+				Name: "enforce",
+				URL:  enforce.URL,
+			},
+		}
+	})
+	defer s1.Shutdown()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	// Planning the job only calls the enforcing webhook, which denies it
+	job := mock.Job()
+	planReq := &structs.JobPlanRequest{
+		Job: job,
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: job.Namespace,
+		},
+	}
+	var planResp structs.JobPlanResponse
+	err := msgpackrpc.CallWithCodec(codec, "Job.Plan", planReq, &planResp)
+	require.Error(err)
+	require.Contains(err.Error(), "missing team label")
+
+	// Registering the job calls both webhooks in order
+	req := &structs.JobRegisterRequest{
+		Job: job,
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: job.Namespace,
+		},
+	}
+	var resp structs.JobRegisterResponse
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Job.Register", req, &resp))
+	require.Contains(resp.Warnings, "labels checked")
+	require.Equal([]string{"plan", "register"}, operations)
+
+	// The patched job is stored
+	out, err := s1.fsm.State().JobByID(nil, job.Namespace, job.ID)
+	require.NoError(err)
+	require.NotNil(out)
+	require.Equal("platform", out.Meta["team"])
+
+	// Diffing the job only requires read-job and doesn't call the webhooks
+	diffReq := &structs.JobDiffRequest{
+		JobID: job.ID,
+		Job:   job,
+		QueryOptions: structs.QueryOptions{
+			Region:    "global",
+			Namespace: job.Namespace,
+		},
+	}
+	var diffResp structs.JobDiffResponse
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Job.Diff", diffReq, &diffResp))
+	require.Equal([]string{"plan", "register"}, operations)
+}
+
+func TestJobEndpoint_Register_Multiregion_AdmissionWebhooks(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	var regions []string
+	webhook := testAdmissionWebhook(t, func(req *AdmissionWebhookRequest) *AdmissionWebhookResponse {
+		regions = append(regions, req.Job.Region)
+		return &AdmissionWebhookResponse{
+			Allowed: true,
+			Patch: []*jsonpatch.Operation{
+				{Op: "add", Path: "/Meta/team", Value: "platform"},
+			},
+		}
+	})
+	defer webhook.Close()
+
+	webhooks := []*config.AdmissionWebhookConfig{
+		{
+			Name:       "labels",
+			URL:        webhook.URL,
+			Operations: []string{config.AdmissionWebhookOperationRegister},
+		},
+	}
+	s1 := TestServer(t, func(c *Config) {
+		c.Region = "west"
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+		c.AdmissionWebhooks = webhooks
+	})
+	defer s1.Shutdown()
+	s2 := TestServer(t, func(c *Config) {
+		c.Region = "east"
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+		c.AdmissionWebhooks = webhooks
+	})
+	defer s2.Shutdown()
+	TestJoin(t, s1, s2)
+	testutil.WaitForLeader(t, s1.RPC)
+	testutil.WaitForLeader(t, s2.RPC)
+	codec := rpcClient(t, s1)
+
+	job := mock.Job()
+	job.Region = "west"
+	job.Multiregion = &structs.Multiregion{
+		Regions: []*structs.MultiregionRegion{
+			{Name: "west", Datacenters: []string{"west-1"}},
+			{Name: "east", Datacenters: []string{"east-1"}},
+		},
+	}
+	req := &structs.JobRegisterRequest{
+		Job: job,
+		WriteRequest: structs.WriteRequest{
+			Region:    "west",
+			Namespace: job.Namespace,
+		},
+	}
+	var resp structs.JobRegisterResponse
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Job.Register", req, &resp))
+
+	// The webhook is called with the copy of the job for each region
+	sort.Strings(regions)
+	require.Equal([]string{"east", "west"}, regions)
+
+	// The patched job is registered in every region
+	for _, srv := range []*Server{s1, s2} {
+		out, err := srv.fsm.State().JobByID(nil, job.Namespace, job.ID)
+		require.NoError(err)
+		require.NotNil(out)
+		require.Equal("platform", out.Meta["team"])
+	}
+}
+
+// TestJobEndpoint_Register_MultiregionPeer_AdmissionWebhooks asserts a request
+// claiming to be from a peer region can't skip the admission webhooks.
+func TestJobEndpoint_Register_MultiregionPeer_AdmissionWebhooks(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	webhook := testAdmissionWebhook(t, func(*AdmissionWebhookRequest) *AdmissionWebhookResponse {
+		return &AdmissionWebhookResponse{Message: "denied"}
+	})
+	defer webhook.Close()
+
+	s1 := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+		c.AdmissionWebhooks = []*config.AdmissionWebhookConfig{
+			{
+				Name: "deny",
+				URL:  webhook.URL,
+			},
+		}
+	})
+	defer s1.Shutdown()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	job := mock.Job()
+	job.Multiregion = &structs.Multiregion{
+		Regions: []*structs.MultiregionRegion{
+			{Name: "global", Datacenters: []string{"dc1"}},
+		},
+	}
+	req := &structs.JobRegisterRequest{
+		Job:             job,
+		MultiregionPeer: true,
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: job.Namespace,
+		},
+	}
+	var resp structs.JobRegisterResponse
+	err := msgpackrpc.CallWithCodec(codec, "Job.Register", req, &resp)
+	require.Error(err)
+	require.Contains(err.Error(), "denied")
+
+	out, err := s1.fsm.State().JobByID(nil, job.Namespace, job.ID)
+	require.NoError(err)
+	require.Nil(out)
+}
+
+// TestJobEndpoint_Register_AdmissionWebhooks_ACL asserts the admission webhooks
+// are only called for authorized requests and that the job they return is
+// authorized again.
+func TestJobEndpoint_Register_AdmissionWebhooks_ACL(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	// The webhook mounts a host volume into every job
+	calls := 0
+	webhook := testAdmissionWebhook(t, func(*AdmissionWebhookRequest) *AdmissionWebhookResponse {
+		calls++
+		return &AdmissionWebhookResponse{
+			Allowed: true,
+			Patch: []*jsonpatch.Operation{
+				{Op: "add", Path: "/TaskGroups/0/Volumes", Value: map[string]interface{}{
+					"certs": map[string]interface{}{"Type": "host", "Source": "certs"},
+				}},
+			},
+		}
+	})
+	defer webhook.Close()
+
+	s1, _ := TestACLServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+		c.AdmissionWebhooks = []*config.AdmissionWebhookConfig{
+			{
+				Name: "volumes",
+				URL:  webhook.URL,
+			},
+		}
+	})
+	defer s1.Shutdown()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+	state := s1.fsm.State()
+
+	readToken := mock.CreatePolicyAndToken(t, state, 1001, "test-read",
+		mock.NamespacePolicy(structs.DefaultNamespace, "", []string{acl.NamespaceCapabilityReadJob}))
+	submitToken := mock.CreatePolicyAndToken(t, state, 1002, "test-submit",
+		mock.NamespacePolicy(structs.DefaultNamespace, "", []string{acl.NamespaceCapabilitySubmitJob}))
+	volumeToken := mock.CreatePolicyAndToken(t, state, 1003, "test-volume",
+		mock.NamespacePolicy(structs.DefaultNamespace, "", []string{acl.NamespaceCapabilitySubmitJob})+
+			mock.HostVolumePolicy("certs", "", []string{acl.HostVolumeCapabilityMountReadWrite}))
+
+	job := mock.Job()
+	req := &structs.JobRegisterRequest{
+		Job: job,
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: job.Namespace,
+			AuthToken: readToken.SecretID,
+		},
+	}
+
+	// The webhook isn't called for a token that can't submit jobs
+	var resp structs.JobRegisterResponse
+	err := msgpackrpc.CallWithCodec(codec, "Job.Register", req, &resp)
+	require.Error(err)
+	require.Equal(structs.ErrPermissionDenied.Error(), err.Error())
+	require.Zero(calls)
+
+	// The volume added by the webhook must be mountable by the token
+	req.AuthToken = submitToken.SecretID
+	err = msgpackrpc.CallWithCodec(codec, "Job.Register", req, &resp)
+	require.Error(err)
+	require.Equal(structs.ErrPermissionDenied.Error(), err.Error())
+	require.Equal(1, calls)
+
+	req.AuthToken = volumeToken.SecretID
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Job.Register", req, &resp))
+	require.Equal(2, calls)
+
+	out, err := state.JobByID(nil, job.Namespace, job.ID)
+	require.NoError(err)
+	require.NotNil(out)
+	require.Contains(out.TaskGroups[0].Volumes, "certs")
+}
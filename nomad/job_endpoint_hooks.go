@@ -22,13 +22,23 @@ type jobValidator interface {
 	Validate(*structs.Job) (warnings []error, err error)
 }
 
-func (j *Job) admissionControllers(job *structs.Job) (out *structs.Job, warnings []error, err error) {
+// admissionControllers runs the builtin mutators, the admission webhooks
+// configured for the operation and the validators. It returns the mutated job
+// as well as warnings or an error. When policyOverride is set, failed
+// soft-mandatory admission policies are returned as warnings.
+func (j *Job) admissionControllers(op string, policyOverride bool, job *structs.Job) (out *structs.Job, warnings []error, err error) {
 	out, warnings, err = j.admissionMutators(job)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	validateWarnings, err := j.admissionValidators(job)
+	out, webhookWarnings, err := j.admissionWebhooks(op, out)
+	if err != nil {
+		return nil, nil, err
+	}
+	warnings = append(warnings, webhookWarnings...)
+
+	validateWarnings, err := j.admissionValidators(out, policyOverride)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -51,24 +61,78 @@ func (j *Job) admissionMutators(job *structs.Job) (_ *structs.Job, warnings []er
 	return job, warnings, err
 }
 
+// admissionWebhooks calls the admission webhooks configured for the operation
+// in order, and returns the job patched by the webhooks as well as warnings or
+// an error.
+func (j *Job) admissionWebhooks(op string, job *structs.Job) (_ *structs.Job, warnings []error, err error) {
+	var w []error
+	for _, webhook := range j.webhooks {
+		if !webhook.config.HandlesOperation(op) {
+			continue
+		}
+
+		job, w, err = webhook.Admit(op, job)
+		j.logger.Trace("job admission webhook results", "webhook", webhook.Name(), "operation", op, "warnings", w, "error", err)
+		if err != nil {
+			return nil, nil, err
+		}
+		warnings = append(warnings, w...)
+	}
+	return job, warnings, nil
+}
+
+// admissionBuiltin runs only the builtin mutators and validators, skipping the
+// admission webhooks and policies. It is used where those must not run, such
+// as when diffing a job or before the regional copies of a multiregion job are
+// admitted.
+func (j *Job) admissionBuiltin(job *structs.Job) (out *structs.Job, warnings []error, err error) {
+	out, warnings, err = j.admissionMutators(job)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	errs := new(multierror.Error)
+	validateWarnings := j.builtinValidators(out.Copy(), errs)
+	if err := errs.ErrorOrNil(); err != nil {
+		return nil, nil, err
+	}
+	warnings = append(warnings, validateWarnings...)
+
+	return out, warnings, nil
+}
+
 // admissionValidators returns a slice of validation warnings and a multierror
-// of validation failures.
-func (j *Job) admissionValidators(origJob *structs.Job) (warnings []error, err error) {
+// of validation failures, including the failures of the admission policies.
+func (j *Job) admissionValidators(origJob *structs.Job, policyOverride bool) (warnings []error, err error) {
 	// ensure job is not mutated
 	job := origJob.Copy()
 
-	var w []error
 	errs := new(multierror.Error)
+	warnings = j.builtinValidators(job, errs)
+
+	// Enforce the admission policies
+	w, err := j.policies.Enforce(job, policyOverride)
+	j.logger.Trace("job policy results", "validator", j.policies.Name(), "warnings", w, "error", err)
+	if err != nil {
+		multierror.Append(errs, err)
+	}
+	warnings = append(warnings, w...)
+
+	return warnings, errs.ErrorOrNil()
+}
+
+// builtinValidators runs the builtin validators, appending their failures to
+// errs, and returns their warnings.
+func (j *Job) builtinValidators(job *structs.Job, errs *multierror.Error) (warnings []error) {
 	for _, validator := range j.validators {
-		w, err = validator.Validate(job)
+		w, err := validator.Validate(job)
 		j.logger.Trace("job validate results", "validator", validator.Name(), "warnings", w, "error", err)
 		if err != nil {
 			multierror.Append(errs, err)
 		}
 		warnings = append(warnings, w...)
 	}
-	return warnings, err
-
+	return warnings
 }
 
 // jobCanonicalizer calls job.Canonicalize (sets defaults and initializes
@@ -271,6 +271,108 @@ func TestJobEndpoint_Register_ConnectWithSidecarTask(t *testing.T) {
 
 }
 
+func TestJobEndpoint_Register_Multiregion(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	s1 := TestServer(t, func(c *Config) {
+		c.Region = "west"
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer s1.Shutdown()
+	s2 := TestServer(t, func(c *Config) {
+		c.Region = "east"
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer s2.Shutdown()
+	TestJoin(t, s1, s2)
+	testutil.WaitForLeader(t, s1.RPC)
+	testutil.WaitForLeader(t, s2.RPC)
+	codec := rpcClient(t, s1)
+
+	// Create the register request
+	job := mock.Job()
+	job.Region = "west"
+	job.Multiregion = &structs.Multiregion{
+		Regions: []*structs.MultiregionRegion{
+			{
+				Name:        "west",
+				Count:       2,
+				Datacenters: []string{"west-1"},
+			},
+			{
+				Name:        "east",
+				Count:       3,
+				Datacenters: []string{"east-1"},
+			},
+		},
+	}
+	req := &structs.JobRegisterRequest{
+		Job: job,
+		WriteRequest: structs.WriteRequest{
+			Region:    "west",
+			Namespace: job.Namespace,
+		},
+	}
+
+	// Fetch the response
+	var resp structs.JobRegisterResponse
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Job.Register", req, &resp))
+	require.NotEmpty(resp.EvalID)
+
+	// Check each region has its own copy of the job
+	cases := []struct {
+		srv   *Server
+		count int
+		dc    string
+	}{
+		{s1, 2, "west-1"},
+		{s2, 3, "east-1"},
+	}
+	for _, c := range cases {
+		out, err := c.srv.fsm.State().JobByID(nil, job.Namespace, job.ID)
+		require.NoError(err)
+		require.NotNil(out)
+		require.Equal(c.srv.config.Region, out.Region)
+		require.Equal([]string{c.dc}, out.Datacenters)
+		require.Equal(c.count, out.TaskGroups[0].Count)
+		require.Len(out.Multiregion.Regions, 2)
+	}
+
+	// Unknown regions are rejected before the job is registered anywhere
+	job2 := mock.Job()
+	job2.Region = "west"
+	job2.Multiregion = &structs.Multiregion{
+		Regions: []*structs.MultiregionRegion{
+			{Name: "west"},
+			{Name: "north"},
+		},
+	}
+	req.Job = job2
+	err := msgpackrpc.CallWithCodec(codec, "Job.Register", req, &resp)
+	require.Error(err)
+	require.Contains(err.Error(), `unknown region "north"`)
+
+	out, err := s1.fsm.State().JobByID(nil, job2.Namespace, job2.ID)
+	require.NoError(err)
+	require.Nil(out)
+
+	// Failing regions are reported along with the regions the job was
+	// registered in
+	s2.Shutdown()
+	job3 := job.Copy()
+	job3.ID = "partial"
+	req.Job = job3
+	err = msgpackrpc.CallWithCodec(codec, "Job.Register", req, &resp)
+	require.Error(err)
+	require.Contains(err.Error(), "only registered in regions west")
+	require.Contains(err.Error(), `region "east"`)
+
+	out, err = s1.fsm.State().JobByID(nil, job3.Namespace, job3.ID)
+	require.NoError(err)
+	require.NotNil(out)
+}
+
 func TestJobEndpoint_Register_ACL(t *testing.T) {
 	t.Parallel()
 
@@ -407,8 +509,11 @@ func TestJobEndpoint_Register_InvalidNamespace(t *testing.T) {
 	job := mock.Job()
 	job.Namespace = "foo"
 	req := &structs.JobRegisterRequest{
-		Job:          job,
-		WriteRequest: structs.WriteRequest{Region: "global"},
+		Job: job,
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: job.Namespace,
+		},
 	}
 
 	// Try without a token, expect failure
@@ -428,6 +533,49 @@ func TestJobEndpoint_Register_InvalidNamespace(t *testing.T) {
 	if out != nil {
 		t.Fatalf("expected no job")
 	}
+
+	// Create the namespace and register the job again
+	ns := mock.Namespace()
+	ns.Name = job.Namespace
+	require.NoError(t, state.UpsertNamespaces(1000, []*structs.Namespace{ns}))
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "Job.Register", req, &resp))
+
+	out, err = state.JobByID(nil, job.Namespace, job.ID)
+	require.NoError(t, err)
+	require.NotNil(t, out)
+}
+
+func TestJobEndpoint_Register_MismatchedNamespace(t *testing.T) {
+	t.Parallel()
+	s1 := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer s1.Shutdown()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	ns := mock.Namespace()
+	state := s1.fsm.State()
+	require.NoError(t, state.UpsertNamespaces(1000, []*structs.Namespace{ns}))
+
+	// Register a job in another namespace than the request
+	job := mock.Job()
+	job.Namespace = ns.Name
+	req := &structs.JobRegisterRequest{
+		Job: job,
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: structs.DefaultNamespace,
+		},
+	}
+	var resp structs.JobRegisterResponse
+	err := msgpackrpc.CallWithCodec(codec, "Job.Register", req, &resp)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "does not match the request namespace")
+
+	out, err := state.JobByID(nil, job.Namespace, job.ID)
+	require.NoError(t, err)
+	require.Nil(t, out)
 }
 
 func TestJobEndpoint_Register_Payload(t *testing.T) {
@@ -1107,6 +1255,258 @@ func TestJobEndpoint_Register_Vault_Policies(t *testing.T) {
 	}
 }
 
+func TestJobEndpoint_Diff(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	s1 := TestServer(t, nil)
+	defer s1.Shutdown()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	// Register three versions of the job
+	job := mock.Job()
+	regReq := &structs.JobRegisterRequest{
+		Job: job,
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: job.Namespace,
+		},
+	}
+	var regResp structs.JobRegisterResponse
+	for _, priority := range []int{50, 60, 70} {
+		regReq.Job = job.Copy()
+		regReq.Job.Priority = priority
+		require.NoError(msgpackrpc.CallWithCodec(codec, "Job.Register", regReq, &regResp))
+	}
+
+	// Diff the first version against the current one
+	diffReq := &structs.JobDiffRequest{
+		JobID:       job.ID,
+		FromVersion: helper.Uint64ToPtr(0),
+		QueryOptions: structs.QueryOptions{
+			Region:    "global",
+			Namespace: job.Namespace,
+		},
+	}
+	var diffResp structs.JobDiffResponse
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Job.Diff", diffReq, &diffResp))
+	require.NotNil(diffResp.Diff)
+	require.Equal(structs.DiffTypeEdited, diffResp.Diff.Type)
+	require.Len(diffResp.Diff.Fields, 1)
+	require.Equal("Priority", diffResp.Diff.Fields[0].Name)
+	require.Equal("50", diffResp.Diff.Fields[0].Old)
+	require.Equal("70", diffResp.Diff.Fields[0].New)
+	require.NotZero(diffResp.Index)
+
+	// Diff two arbitrary versions
+	diffReq.FromVersion = helper.Uint64ToPtr(2)
+	diffReq.ToVersion = helper.Uint64ToPtr(1)
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Job.Diff", diffReq, &diffResp))
+	require.Len(diffResp.Diff.Fields, 1)
+	require.Equal("70", diffResp.Diff.Fields[0].Old)
+	require.Equal("60", diffResp.Diff.Fields[0].New)
+
+	// Diff a version against an unregistered job
+	local := job.Copy()
+	local.Priority = 80
+	diffReq.ToVersion = nil
+	diffReq.Job = local
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Job.Diff", diffReq, &diffResp))
+	require.Len(diffResp.Diff.Fields, 1)
+	require.Equal("70", diffResp.Diff.Fields[0].Old)
+	require.Equal("80", diffResp.Diff.Fields[0].New)
+
+	// The unregistered job must have the same ID
+	diffReq.Job = mock.Job()
+	err := msgpackrpc.CallWithCodec(codec, "Job.Diff", diffReq, &diffResp)
+	require.Error(err)
+	require.Contains(err.Error(), "does not match")
+
+	// Missing versions have no diff
+	diffReq.Job = nil
+	diffReq.FromVersion = helper.Uint64ToPtr(10)
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Job.Diff", diffReq, &diffResp))
+	require.Nil(diffResp.Diff)
+}
+
+func TestJobEndpoint_Diff_ACL(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	s1, root := TestACLServer(t, nil)
+	defer s1.Shutdown()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+	state := s1.fsm.State()
+
+	job := mock.Job()
+	require.NoError(state.UpsertJob(1000, job))
+
+	diffReq := &structs.JobDiffRequest{
+		JobID: job.ID,
+		QueryOptions: structs.QueryOptions{
+			Region:    "global",
+			Namespace: job.Namespace,
+		},
+	}
+
+	// Expect failure without a token
+	var diffResp structs.JobDiffResponse
+	err := msgpackrpc.CallWithCodec(codec, "Job.Diff", diffReq, &diffResp)
+	require.Error(err)
+	require.Contains(err.Error(), "Permission denied")
+
+	// Expect failure for request with an invalid token
+	invalidToken := mock.CreatePolicyAndToken(t, state, 1003, "test-invalid",
+		mock.NamespacePolicy(structs.DefaultNamespace, "", []string{acl.NamespaceCapabilityListJobs}))
+	diffReq.AuthToken = invalidToken.SecretID
+	err = msgpackrpc.CallWithCodec(codec, "Job.Diff", diffReq, &diffResp)
+	require.Error(err)
+	require.Contains(err.Error(), "Permission denied")
+
+	// Expect success with a valid token
+	validToken := mock.CreatePolicyAndToken(t, state, 1005, "test-valid",
+		mock.NamespacePolicy(structs.DefaultNamespace, "", []string{acl.NamespaceCapabilityReadJob}))
+	diffReq.AuthToken = validToken.SecretID
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Job.Diff", diffReq, &diffResp))
+	require.Equal(structs.DiffTypeNone, diffResp.Diff.Type)
+
+	// Expect success with a management token
+	diffReq.AuthToken = root.SecretID
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Job.Diff", diffReq, &diffResp))
+	require.NotNil(diffResp.Diff)
+}
+
+func TestJobEndpoint_GetJobSubmission(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	s1 := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer s1.Shutdown()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	register := func(job *structs.Job, source string) *structs.JobRegisterResponse {
+		req := &structs.JobRegisterRequest{
+			Job: job,
+			Submission: &structs.JobSubmission{
+				Source:        source,
+				Format:        structs.JobSubmissionFormatHCL2,
+				VariableFlags: map[string]string{"priority": fmt.Sprintf("%d", job.Priority)},
+			},
+			WriteRequest: structs.WriteRequest{
+				Region:    "global",
+				Namespace: job.Namespace,
+			},
+		}
+		var resp structs.JobRegisterResponse
+		require.NoError(msgpackrpc.CallWithCodec(codec, "Job.Register", req, &resp))
+		return &resp
+	}
+	get := func(job *structs.Job, version uint64) *structs.JobSubmission {
+		req := &structs.JobSubmissionRequest{
+			JobID:   job.ID,
+			Version: version,
+			QueryOptions: structs.QueryOptions{
+				Region:    "global",
+				Namespace: job.Namespace,
+			},
+		}
+		var resp structs.JobSubmissionResponse
+		require.NoError(msgpackrpc.CallWithCodec(codec, "Job.GetJobSubmission", req, &resp))
+		return resp.Submission
+	}
+
+	// Register two versions of the job with their sources
+	job := mock.Job()
+	job.Priority = 100
+	register(job, "source v0")
+	job2 := job.Copy()
+	job2.Priority = 1
+	register(job2, "source v1")
+
+	sub := get(job, 0)
+	require.NotNil(sub)
+	require.Equal("source v0", sub.Source)
+	require.Equal(structs.JobSubmissionFormatHCL2, sub.Format)
+	require.Equal(map[string]string{"priority": "100"}, sub.VariableFlags)
+	require.Equal("source v1", get(job, 1).Source)
+	require.Nil(get(job, 2))
+
+	// Reverting carries over the source of the reverted version
+	revertReq := &structs.JobRevertRequest{
+		JobID:      job.ID,
+		JobVersion: 0,
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: job.Namespace,
+		},
+	}
+	var resp structs.JobRegisterResponse
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Job.Revert", revertReq, &resp))
+	require.Equal("source v0", get(job, 2).Source)
+
+	// Sources over the size limit are not stored
+	job3 := job.Copy()
+	job3.Priority = 50
+	regResp := register(job3, strings.Repeat("a", structs.JobSubmissionMaxSize+1))
+	require.Contains(regResp.Warnings, "was not stored")
+	require.Nil(get(job, 3))
+}
+
+func TestJobEndpoint_GetJobSubmission_ACL(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	s1, root := TestACLServer(t, nil)
+	defer s1.Shutdown()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+	state := s1.fsm.State()
+
+	job := mock.Job()
+	require.NoError(state.UpsertJob(1000, job))
+	require.NoError(state.UpsertJobSubmission(1001, &structs.JobSubmission{
+		Source:    "source",
+		Format:    structs.JobSubmissionFormatHCL1,
+		Namespace: job.Namespace,
+		JobID:     job.ID,
+	}))
+
+	req := &structs.JobSubmissionRequest{
+		JobID: job.ID,
+		QueryOptions: structs.QueryOptions{
+			Region:    "global",
+			Namespace: job.Namespace,
+		},
+	}
+
+	// Attempt to fetch without a token
+	var resp structs.JobSubmissionResponse
+	err := msgpackrpc.CallWithCodec(codec, "Job.GetJobSubmission", req, &resp)
+	require.EqualError(err, structs.ErrPermissionDenied.Error())
+
+	// Attempt to fetch with a token without read-job
+	invalid := mock.CreatePolicyAndToken(t, state, 1003, "invalid",
+		mock.NamespacePolicy(structs.DefaultNamespace, "", []string{acl.NamespaceCapabilityListJobs}))
+	req.AuthToken = invalid.SecretID
+	err = msgpackrpc.CallWithCodec(codec, "Job.GetJobSubmission", req, &resp)
+	require.EqualError(err, structs.ErrPermissionDenied.Error())
+
+	// Fetch with a read-job token
+	valid := mock.CreatePolicyAndToken(t, state, 1005, "valid",
+		mock.NamespacePolicy(structs.DefaultNamespace, "", []string{acl.NamespaceCapabilityReadJob}))
+	req.AuthToken = valid.SecretID
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Job.GetJobSubmission", req, &resp))
+	require.Equal("source", resp.Submission.Source)
+
+	// Fetch with the root token
+	req.AuthToken = root.SecretID
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Job.GetJobSubmission", req, &resp))
+	require.NotNil(resp.Submission)
+}
+
 func TestJobEndpoint_Revert(t *testing.T) {
 	t.Parallel()
 	s1 := TestServer(t, func(c *Config) {
@@ -1561,61 +1961,602 @@ func TestJobEndpoint_Stable(t *testing.T) {
 	codec := rpcClient(t, s1)
 	testutil.WaitForLeader(t, s1.RPC)
 
-	// Create the initial register request
+	// Create the initial register request
+	job := mock.Job()
+	req := &structs.JobRegisterRequest{
+		Job: job,
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: job.Namespace,
+		},
+	}
+
+	// Fetch the response
+	var resp structs.JobRegisterResponse
+	if err := msgpackrpc.CallWithCodec(codec, "Job.Register", req, &resp); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if resp.Index == 0 {
+		t.Fatalf("bad index: %d", resp.Index)
+	}
+
+	// Create stability request
+	stableReq := &structs.JobStabilityRequest{
+		JobID:      job.ID,
+		JobVersion: 0,
+		Stable:     true,
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: job.Namespace,
+		},
+	}
+
+	// Fetch the response
+	var stableResp structs.JobStabilityResponse
+	if err := msgpackrpc.CallWithCodec(codec, "Job.Stable", stableReq, &stableResp); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if stableResp.Index == 0 {
+		t.Fatalf("bad index: %d", resp.Index)
+	}
+
+	// Check that the job is marked stable
+	state := s1.fsm.State()
+	ws := memdb.NewWatchSet()
+	out, err := state.JobByID(ws, job.Namespace, job.ID)
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if out == nil {
+		t.Fatalf("expected job")
+	}
+	if !out.Stable {
+		t.Fatalf("Job is not marked stable")
+	}
+}
+
+func TestJobEndpoint_Stable_ACL(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	s1, root := TestACLServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer s1.Shutdown()
+	codec := rpcClient(t, s1)
+	state := s1.fsm.State()
+	testutil.WaitForLeader(t, s1.RPC)
+
+	// Register the job
+	job := mock.Job()
+	err := state.UpsertJob(1000, job)
+	require.Nil(err)
+
+	// Create stability request
+	stableReq := &structs.JobStabilityRequest{
+		JobID:      job.ID,
+		JobVersion: 0,
+		Stable:     true,
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: job.Namespace,
+		},
+	}
+
+	// Attempt to fetch the token without a token
+	var stableResp structs.JobStabilityResponse
+	err = msgpackrpc.CallWithCodec(codec, "Job.Stable", stableReq, &stableResp)
+	require.NotNil(err)
+	require.Contains("Permission denied", err.Error())
+
+	// Expect failure for request with an invalid token
+	invalidToken := mock.CreatePolicyAndToken(t, state, 1003, "test-invalid",
+		mock.NamespacePolicy(structs.DefaultNamespace, "", []string{acl.NamespaceCapabilityListJobs}))
+
+	stableReq.AuthToken = invalidToken.SecretID
+	var invalidStableResp structs.JobStabilityResponse
+	err = msgpackrpc.CallWithCodec(codec, "Job.Stable", stableReq, &invalidStableResp)
+	require.NotNil(err)
+	require.Contains("Permission denied", err.Error())
+
+	// Attempt to fetch with a management token
+	stableReq.AuthToken = root.SecretID
+	var validStableResp structs.JobStabilityResponse
+	err = msgpackrpc.CallWithCodec(codec, "Job.Stable", stableReq, &validStableResp)
+	require.Nil(err)
+
+	// Attempt to fetch with a valid token
+	validToken := mock.CreatePolicyAndToken(t, state, 1005, "test-invalid",
+		mock.NamespacePolicy(structs.DefaultNamespace, "", []string{acl.NamespaceCapabilitySubmitJob}))
+
+	stableReq.AuthToken = validToken.SecretID
+	var validStableResp2 structs.JobStabilityResponse
+	err = msgpackrpc.CallWithCodec(codec, "Job.Stable", stableReq, &validStableResp2)
+	require.Nil(err)
+
+	// Check that the job is marked stable
+	ws := memdb.NewWatchSet()
+	out, err := state.JobByID(ws, job.Namespace, job.ID)
+	require.Nil(err)
+	require.NotNil(job)
+	require.Equal(true, out.Stable)
+}
+
+func TestJobEndpoint_TagVersion(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	s1 := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer s1.Shutdown()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	// Register the job twice to get two versions
+	job := mock.Job()
+	regReq := &structs.JobRegisterRequest{
+		Job: job,
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: job.Namespace,
+		},
+	}
+	var regResp structs.JobRegisterResponse
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Job.Register", regReq, &regResp))
+
+	job2 := job.Copy()
+	job2.Priority = 100
+	regReq.Job = job2
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Job.Register", regReq, &regResp))
+
+	// Tag the current version
+	tagReq := &structs.JobTagRequest{
+		JobID:       job.ID,
+		Name:        "release-2026.10",
+		Description: "DEPLOY-123",
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: job.Namespace,
+		},
+	}
+	var tagResp structs.JobTagResponse
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Job.TagVersion", tagReq, &tagResp))
+	require.NotZero(tagResp.Index)
+
+	state := s1.fsm.State()
+	ws := memdb.NewWatchSet()
+	out, err := state.JobByID(ws, job.Namespace, job.ID)
+	require.NoError(err)
+	require.EqualValues(1, out.Version)
+	require.NotNil(out.VersionTag)
+	require.Equal("release-2026.10", out.VersionTag.Name)
+	require.Equal("DEPLOY-123", out.VersionTag.Description)
+	require.NotZero(out.VersionTag.TaggedTime)
+
+	// Registering a new version doesn't carry the tag over
+	job3 := job.Copy()
+	job3.Priority = 90
+	job3.VersionTag = out.VersionTag
+	regReq.Job = job3
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Job.Register", regReq, &regResp))
+	out, err = state.JobByID(ws, job.Namespace, job.ID)
+	require.NoError(err)
+	require.EqualValues(2, out.Version)
+	require.Nil(out.VersionTag)
+
+	// The tag name can't be used on another version
+	tagReq.Version = helper.Uint64ToPtr(0)
+	err = msgpackrpc.CallWithCodec(codec, "Job.TagVersion", tagReq, &tagResp)
+	require.Error(err)
+	require.Contains(err.Error(), "already exists on version 1")
+
+	// Tag names can't be version numbers
+	tagReq.Name = "3"
+	err = msgpackrpc.CallWithCodec(codec, "Job.TagVersion", tagReq, &tagResp)
+	require.Error(err)
+	require.Contains(err.Error(), "can't be a version number")
+
+	// Tagging a missing version fails
+	tagReq.Name = "other"
+	tagReq.Version = helper.Uint64ToPtr(10)
+	err = msgpackrpc.CallWithCodec(codec, "Job.TagVersion", tagReq, &tagResp)
+	require.Error(err)
+	require.Contains(err.Error(), "at version 10 not found")
+
+	// Revert to the tagged version by name
+	revertReq := &structs.JobRevertRequest{
+		JobID:   job.ID,
+		TagName: "release-2026.10",
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: job.Namespace,
+		},
+	}
+	var revertResp structs.JobRegisterResponse
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Job.Revert", revertReq, &revertResp))
+	out, err = state.JobByID(ws, job.Namespace, job.ID)
+	require.NoError(err)
+	require.EqualValues(3, out.Version)
+	require.Equal(100, out.Priority)
+	require.Nil(out.VersionTag)
+
+	// Remove the tag
+	unsetReq := &structs.JobTagRequest{
+		JobID: job.ID,
+		Name:  "release-2026.10",
+		Unset: true,
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: job.Namespace,
+		},
+	}
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Job.TagVersion", unsetReq, &tagResp))
+	out, err = state.JobByIDAndVersion(ws, job.Namespace, job.ID, 1)
+	require.NoError(err)
+	require.Nil(out.VersionTag)
+
+	// Reverting to a missing tag fails
+	err = msgpackrpc.CallWithCodec(codec, "Job.Revert", revertReq, &revertResp)
+	require.Error(err)
+	require.Contains(err.Error(), `has no version tagged "release-2026.10"`)
+}
+
+func TestJobEndpoint_TagVersion_ACL(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	s1, root := TestACLServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer s1.Shutdown()
+	codec := rpcClient(t, s1)
+	state := s1.fsm.State()
+	testutil.WaitForLeader(t, s1.RPC)
+
+	job := mock.Job()
+	require.NoError(state.UpsertJob(1000, job))
+
+	tagReq := &structs.JobTagRequest{
+		JobID: job.ID,
+		Name:  "release",
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: job.Namespace,
+		},
+	}
+
+	// Expect failure without a token
+	var tagResp structs.JobTagResponse
+	err := msgpackrpc.CallWithCodec(codec, "Job.TagVersion", tagReq, &tagResp)
+	require.Error(err)
+	require.Contains(err.Error(), "Permission denied")
+
+	// Expect failure for request with an invalid token
+	invalidToken := mock.CreatePolicyAndToken(t, state, 1003, "test-invalid",
+		mock.NamespacePolicy(structs.DefaultNamespace, "", []string{acl.NamespaceCapabilityReadJob}))
+	tagReq.AuthToken = invalidToken.SecretID
+	err = msgpackrpc.CallWithCodec(codec, "Job.TagVersion", tagReq, &tagResp)
+	require.Error(err)
+	require.Contains(err.Error(), "Permission denied")
+
+	// Expect success with a valid token
+	validToken := mock.CreatePolicyAndToken(t, state, 1005, "test-valid",
+		mock.NamespacePolicy(structs.DefaultNamespace, "", []string{acl.NamespaceCapabilitySubmitJob}))
+	tagReq.AuthToken = validToken.SecretID
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Job.TagVersion", tagReq, &tagResp))
+
+	// Expect success with a management token
+	tagReq.AuthToken = root.SecretID
+	tagReq.Name = "other"
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Job.TagVersion", tagReq, &tagResp))
+
+	out, err := state.JobByID(nil, job.Namespace, job.ID)
+	require.NoError(err)
+	require.Equal("other", out.VersionTag.Name)
+}
+
+func TestJobEndpoint_Scale(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	s1 := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer s1.Shutdown()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+	state := s1.fsm.State()
+
+	job := mock.Job()
+	regReq := &structs.JobRegisterRequest{
+		Job: job,
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: job.Namespace,
+		},
+	}
+	var regResp structs.JobRegisterResponse
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Job.Register", regReq, &regResp))
+	group := job.TaskGroups[0].Name
+
+	// Scale the task group
+	scaleReq := &structs.JobScaleRequest{
+		JobID: job.ID,
+		Target: map[string]string{
+			structs.ScalingTargetGroup: group,
+		},
+		Count:   helper.Int64ToPtr(3),
+		Message: "scale in",
+		Meta:    map[string]interface{}{"source": "test"},
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: job.Namespace,
+		},
+	}
+	var resp structs.JobRegisterResponse
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Job.Scale", scaleReq, &resp))
+	require.NotEmpty(resp.EvalID)
+	require.NotZero(resp.Index)
+
+	out, err := state.JobByID(nil, job.Namespace, job.ID)
+	require.NoError(err)
+	require.Equal(3, out.TaskGroups[0].Count)
+	require.Equal(resp.JobModifyIndex, out.JobModifyIndex)
+
+	// Scaling updates the current version of the job instead of creating a
+	// new one, and the stored version keeps its submitted count
+	require.Zero(out.Version)
+	versions, err := state.JobVersionsByID(nil, job.Namespace, job.ID)
+	require.NoError(err)
+	require.Len(versions, 1)
+	require.Equal(10, versions[0].TaskGroups[0].Count)
+
+	eval, err := state.EvalByID(nil, resp.EvalID)
+	require.NoError(err)
+	require.Equal(structs.EvalTriggerScaling, eval.TriggeredBy)
+	require.Equal(resp.JobModifyIndex, eval.JobModifyIndex)
+
+	events, _, err := state.ScalingEventsByJob(nil, job.Namespace, job.ID)
+	require.NoError(err)
+	require.Len(events[group], 1)
+	event := events[group][0]
+	require.EqualValues(3, *event.Count)
+	require.EqualValues(10, event.PreviousCount)
+	require.Equal("scale in", event.Message)
+	require.Equal("test", event.Meta["source"])
+	require.Equal(resp.EvalID, *event.EvalID)
+
+	// Scaling with a stale job modify index fails
+	scaleReq.Count = helper.Int64ToPtr(5)
+	scaleReq.EnforceIndex = true
+	scaleReq.JobModifyIndex = regResp.JobModifyIndex
+	err = msgpackrpc.CallWithCodec(codec, "Job.Scale", scaleReq, &resp)
+	require.Error(err)
+	require.Contains(err.Error(), RegisterEnforceIndexErrPrefix)
+
+	scaleReq.JobModifyIndex = out.JobModifyIndex
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Job.Scale", scaleReq, &resp))
+	out, err = state.JobByID(nil, job.Namespace, job.ID)
+	require.NoError(err)
+	require.Equal(5, out.TaskGroups[0].Count)
+
+	// Recording an error event leaves the count unchanged
+	errReq := &structs.JobScaleRequest{
+		JobID: job.ID,
+		Target: map[string]string{
+			structs.ScalingTargetGroup: group,
+		},
+		Message: "metrics unavailable",
+		Error:   true,
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: job.Namespace,
+		},
+	}
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Job.Scale", errReq, &resp))
+	require.Empty(resp.EvalID)
+	require.Equal(out.JobModifyIndex, resp.JobModifyIndex)
+
+	events, _, err = state.ScalingEventsByJob(nil, job.Namespace, job.ID)
+	require.NoError(err)
+	require.Len(events[group], 3)
+	require.True(events[group][0].Error)
+	require.Nil(events[group][0].Count)
+	require.EqualValues(5, events[group][0].PreviousCount)
+}
+
+func TestJobEndpoint_Scale_Invalid(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	s1 := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer s1.Shutdown()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	job := mock.Job()
+	require.NoError(s1.fsm.State().UpsertJob(1000, job))
+
+	cases := []struct {
+		Name   string
+		JobID  string
+		Group  string
+		Count  *int64
+		Error  bool
+		ErrMsg string
+	}{
+		{
+			Name:   "unknown job",
+			JobID:  "foo",
+			Group:  "web",
+			Count:  helper.Int64ToPtr(1),
+			ErrMsg: structs.ErrUnknownJobPrefix,
+		},
+		{
+			Name:   "missing group",
+			JobID:  job.ID,
+			Count:  helper.Int64ToPtr(1),
+			ErrMsg: "missing task group",
+		},
+		{
+			Name:   "unknown group",
+			JobID:  job.ID,
+			Group:  "foo",
+			Count:  helper.Int64ToPtr(1),
+			ErrMsg: "does not exist",
+		},
+		{
+			Name:   "negative count",
+			JobID:  job.ID,
+			Group:  "web",
+			Count:  helper.Int64ToPtr(-1),
+			ErrMsg: "non-negative",
+		},
+		{
+			Name:   "error with count",
+			JobID:  job.ID,
+			Group:  "web",
+			Count:  helper.Int64ToPtr(1),
+			Error:  true,
+			ErrMsg: "must not set a count",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			req := &structs.JobScaleRequest{
+				JobID: tc.JobID,
+				Target: map[string]string{
+					structs.ScalingTargetGroup: tc.Group,
+				},
+				Count: tc.Count,
+				Error: tc.Error,
+				WriteRequest: structs.WriteRequest{
+					Region:    "global",
+					Namespace: job.Namespace,
+				},
+			}
+			var resp structs.JobRegisterResponse
+			err := msgpackrpc.CallWithCodec(codec, "Job.Scale", req, &resp)
+			require.Error(err)
+			require.Contains(err.Error(), tc.ErrMsg)
+		})
+	}
+}
+
+func TestJobEndpoint_Scale_OutOfBounds(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	s1 := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer s1.Shutdown()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	job := mock.Job()
+	job.TaskGroups[0].Scaling = &structs.ScalingPolicy{
+		Min:     5,
+		Max:     20,
+		Enabled: true,
+	}
+	regReq := &structs.JobRegisterRequest{
+		Job: job,
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: job.Namespace,
+		},
+	}
+	var regResp structs.JobRegisterResponse
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Job.Register", regReq, &regResp))
+
+	scaleReq := &structs.JobScaleRequest{
+		JobID: job.ID,
+		Target: map[string]string{
+			structs.ScalingTargetGroup: job.TaskGroups[0].Name,
+		},
+		Count: helper.Int64ToPtr(4),
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: job.Namespace,
+		},
+	}
+	var resp structs.JobRegisterResponse
+	err := msgpackrpc.CallWithCodec(codec, "Job.Scale", scaleReq, &resp)
+	require.Error(err)
+	require.Contains(err.Error(), "group count was less than scaling policy minimum: 4 < 5")
+
+	scaleReq.Count = helper.Int64ToPtr(21)
+	err = msgpackrpc.CallWithCodec(codec, "Job.Scale", scaleReq, &resp)
+	require.Error(err)
+	require.Contains(err.Error(), "group count was greater than scaling policy maximum: 21 > 20")
+
+	scaleReq.Count = helper.Int64ToPtr(20)
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Job.Scale", scaleReq, &resp))
+}
+
+func TestJobEndpoint_Register_ScalingPolicyID(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	s1 := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer s1.Shutdown()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+	state := s1.fsm.State()
+
 	job := mock.Job()
+	job.TaskGroups[0].Scaling = &structs.ScalingPolicy{
+		Min:     1,
+		Max:     20,
+		Enabled: true,
+	}
 	req := &structs.JobRegisterRequest{
-		Job: job,
+		Job: job.Copy(),
 		WriteRequest: structs.WriteRequest{
 			Region:    "global",
 			Namespace: job.Namespace,
 		},
 	}
-
-	// Fetch the response
 	var resp structs.JobRegisterResponse
-	if err := msgpackrpc.CallWithCodec(codec, "Job.Register", req, &resp); err != nil {
-		t.Fatalf("err: %v", err)
-	}
-	if resp.Index == 0 {
-		t.Fatalf("bad index: %d", resp.Index)
-	}
-
-	// Create stability request
-	stableReq := &structs.JobStabilityRequest{
-		JobID:      job.ID,
-		JobVersion: 0,
-		Stable:     true,
-		WriteRequest: structs.WriteRequest{
-			Region:    "global",
-			Namespace: job.Namespace,
-		},
-	}
-
-	// Fetch the response
-	var stableResp structs.JobStabilityResponse
-	if err := msgpackrpc.CallWithCodec(codec, "Job.Stable", stableReq, &stableResp); err != nil {
-		t.Fatalf("err: %v", err)
-	}
-	if stableResp.Index == 0 {
-		t.Fatalf("bad index: %d", resp.Index)
-	}
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Job.Register", req, &resp))
 
-	// Check that the job is marked stable
-	state := s1.fsm.State()
-	ws := memdb.NewWatchSet()
-	out, err := state.JobByID(ws, job.Namespace, job.ID)
-	if err != nil {
-		t.Fatalf("err: %v", err)
-	}
-	if out == nil {
-		t.Fatalf("expected job")
-	}
-	if !out.Stable {
-		t.Fatalf("Job is not marked stable")
-	}
+	// The policy was given an ID
+	out, err := state.JobByID(nil, job.Namespace, job.ID)
+	require.NoError(err)
+	policyID := out.TaskGroups[0].Scaling.ID
+	require.NotEmpty(policyID)
+	policy, err := state.ScalingPolicyByID(nil, policyID)
+	require.NoError(err)
+	require.NotNil(policy)
+
+	// Resubmitting the job unchanged doesn't create a new version
+	req.Job = job.Copy()
+	var resp2 structs.JobRegisterResponse
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Job.Register", req, &resp2))
+	require.Equal(resp.JobModifyIndex, resp2.JobModifyIndex)
+
+	// Updating the job keeps the ID of the policy
+	req.Job = job.Copy()
+	req.Job.TaskGroups[0].Scaling.Max = 30
+	var resp3 structs.JobRegisterResponse
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Job.Register", req, &resp3))
+	out, err = state.JobByID(nil, job.Namespace, job.ID)
+	require.NoError(err)
+	require.EqualValues(1, out.Version)
+	require.Equal(policyID, out.TaskGroups[0].Scaling.ID)
+	policy, err = state.ScalingPolicyByID(nil, policyID)
+	require.NoError(err)
+	require.EqualValues(30, policy.Max)
 }
 
-func TestJobEndpoint_Stable_ACL(t *testing.T) {
+func TestJobEndpoint_Scale_ACL(t *testing.T) {
 	t.Parallel()
 	require := require.New(t)
 
@@ -1627,59 +2568,114 @@ func TestJobEndpoint_Stable_ACL(t *testing.T) {
 	state := s1.fsm.State()
 	testutil.WaitForLeader(t, s1.RPC)
 
-	// Register the job
 	job := mock.Job()
-	err := state.UpsertJob(1000, job)
-	require.Nil(err)
+	require.NoError(state.UpsertJob(1000, job))
 
-	// Create stability request
-	stableReq := &structs.JobStabilityRequest{
-		JobID:      job.ID,
-		JobVersion: 0,
-		Stable:     true,
+	scaleReq := &structs.JobScaleRequest{
+		JobID: job.ID,
+		Target: map[string]string{
+			structs.ScalingTargetGroup: job.TaskGroups[0].Name,
+		},
+		Count: helper.Int64ToPtr(2),
 		WriteRequest: structs.WriteRequest{
 			Region:    "global",
 			Namespace: job.Namespace,
 		},
 	}
 
-	// Attempt to fetch the token without a token
-	var stableResp structs.JobStabilityResponse
-	err = msgpackrpc.CallWithCodec(codec, "Job.Stable", stableReq, &stableResp)
-	require.NotNil(err)
-	require.Contains("Permission denied", err.Error())
+	// Expect failure without a token
+	var resp structs.JobRegisterResponse
+	err := msgpackrpc.CallWithCodec(codec, "Job.Scale", scaleReq, &resp)
+	require.Error(err)
+	require.Contains(err.Error(), "Permission denied")
 
 	// Expect failure for request with an invalid token
 	invalidToken := mock.CreatePolicyAndToken(t, state, 1003, "test-invalid",
-		mock.NamespacePolicy(structs.DefaultNamespace, "", []string{acl.NamespaceCapabilityListJobs}))
-
-	stableReq.AuthToken = invalidToken.SecretID
-	var invalidStableResp structs.JobStabilityResponse
-	err = msgpackrpc.CallWithCodec(codec, "Job.Stable", stableReq, &invalidStableResp)
-	require.NotNil(err)
-	require.Contains("Permission denied", err.Error())
+		mock.NamespacePolicy(structs.DefaultNamespace, "", []string{acl.NamespaceCapabilityReadJob}))
+	scaleReq.AuthToken = invalidToken.SecretID
+	err = msgpackrpc.CallWithCodec(codec, "Job.Scale", scaleReq, &resp)
+	require.Error(err)
+	require.Contains(err.Error(), "Permission denied")
 
-	// Attempt to fetch with a management token
-	stableReq.AuthToken = root.SecretID
-	var validStableResp structs.JobStabilityResponse
-	err = msgpackrpc.CallWithCodec(codec, "Job.Stable", stableReq, &validStableResp)
-	require.Nil(err)
+	// Expect success with a scale-job token
+	scaleToken := mock.CreatePolicyAndToken(t, state, 1005, "test-scale",
+		mock.NamespacePolicy(structs.DefaultNamespace, "", []string{acl.NamespaceCapabilityScaleJob}))
+	scaleReq.AuthToken = scaleToken.SecretID
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Job.Scale", scaleReq, &resp))
 
-	// Attempt to fetch with a valid token
-	validToken := mock.CreatePolicyAndToken(t, state, 1005, "test-invalid",
+	// Expect success with a submit-job token
+	submitToken := mock.CreatePolicyAndToken(t, state, 1007, "test-submit",
 		mock.NamespacePolicy(structs.DefaultNamespace, "", []string{acl.NamespaceCapabilitySubmitJob}))
+	scaleReq.AuthToken = submitToken.SecretID
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Job.Scale", scaleReq, &resp))
 
-	stableReq.AuthToken = validToken.SecretID
-	var validStableResp2 structs.JobStabilityResponse
-	err = msgpackrpc.CallWithCodec(codec, "Job.Stable", stableReq, &validStableResp2)
-	require.Nil(err)
+	// Expect success with a management token
+	scaleReq.AuthToken = root.SecretID
+	scaleReq.Count = helper.Int64ToPtr(4)
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Job.Scale", scaleReq, &resp))
 
-	// Check that the job is marked stable
-	ws := memdb.NewWatchSet()
-	out, err := state.JobByID(ws, job.Namespace, job.ID)
-	require.Nil(err)
-	require.NotNil(job)
-	require.Equal(true, out.Stable)
+	out, err := state.JobByID(nil, job.Namespace, job.ID)
+	require.NoError(err)
+	require.Equal(4, out.TaskGroups[0].Count)
+}
+
+func TestJobEndpoint_ScaleStatus(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	s1 := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer s1.Shutdown()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+	state := s1.fsm.State()
+
+	job := mock.Job()
+	group := job.TaskGroups[0].Name
+
+	req := &structs.JobScaleStatusRequest{
+		JobID: job.ID,
+		QueryOptions: structs.QueryOptions{
+			Region:    "global",
+			Namespace: job.Namespace,
+		},
+	}
+
+	// Unknown jobs have no status
+	var resp structs.JobScaleStatusResponse
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Job.ScaleStatus", req, &resp))
+	require.Nil(resp.JobScaleStatus)
+
+	require.NoError(state.UpsertJob(1000, job))
+
+	// Record a scaling event after a blocking query starts
+	time.AfterFunc(100*time.Millisecond, func() {
+		state.UpsertScalingEvent(1001, &structs.ScalingEventRequest{
+			Namespace: job.Namespace,
+			JobID:     job.ID,
+			TaskGroup: group,
+			ScalingEvent: &structs.ScalingEvent{
+				Message: "no-op",
+			},
+		})
+	})
+
+	req.MinQueryIndex = 1000
+	start := time.Now()
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Job.ScaleStatus", req, &resp))
+	if elapsed := time.Since(start); elapsed < 100*time.Millisecond {
+		t.Fatalf("should block (returned in %s) %#v", elapsed, resp)
+	}
+	require.EqualValues(1001, resp.Index)
+
+	status := resp.JobScaleStatus
+	require.NotNil(status)
+	require.Equal(job.ID, status.JobID)
+	require.False(status.JobStopped)
+	require.Equal(10, status.TaskGroups[group].Desired)
+	require.Len(status.TaskGroups[group].Events, 1)
+	require.Equal("no-op", status.TaskGroups[group].Events[0].Message)
 }
 
 func TestJobEndpoint_Evaluate(t *testing.T) {
@@ -3353,6 +4349,82 @@ func TestJobEndpoint_ListJobs(t *testing.T) {
 	}
 }
 
+func TestJobEndpoint_ListJobs_FilterPaginate(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	s1 := TestServer(t, nil)
+	defer s1.Shutdown()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	// Create jobs with known IDs, half of them owned by the payments team
+	state := s1.fsm.State()
+	for i, id := range []string{"job-a", "job-b", "job-c", "job-d", "job-e"} {
+		job := mock.Job()
+		job.ID = id
+		if i%2 == 0 {
+			job.Meta = map[string]string{"team": "payments"}
+		}
+		require.NoError(state.UpsertJob(uint64(1000+i), job))
+	}
+
+	list := func(filter string, perPage int32, nextToken string) ([]string, string) {
+		get := &structs.JobListRequest{
+			QueryOptions: structs.QueryOptions{
+				Region:    "global",
+				Namespace: structs.DefaultNamespace,
+				Filter:    filter,
+				PerPage:   perPage,
+				NextToken: nextToken,
+			},
+		}
+		var resp structs.JobListResponse
+		require.NoError(msgpackrpc.CallWithCodec(codec, "Job.List", get, &resp))
+		var ids []string
+		for _, job := range resp.Jobs {
+			ids = append(ids, job.ID)
+		}
+		return ids, resp.NextToken
+	}
+
+	// Filter only
+	ids, next := list(`Meta.team == "payments"`, 0, "")
+	require.Equal([]string{"job-a", "job-c", "job-e"}, ids)
+	require.Empty(next)
+
+	// Paginate only
+	ids, next = list("", 2, "")
+	require.Equal([]string{"job-a", "job-b"}, ids)
+	require.Equal("job-c", next)
+	ids, next = list("", 2, next)
+	require.Equal([]string{"job-c", "job-d"}, ids)
+	require.Equal("job-e", next)
+	ids, next = list("", 2, next)
+	require.Equal([]string{"job-e"}, ids)
+	require.Empty(next)
+
+	// Filter and paginate
+	ids, next = list(`Meta.team == "payments" and Status == "pending"`, 2, "")
+	require.Equal([]string{"job-a", "job-c"}, ids)
+	require.Equal("job-e", next)
+	ids, next = list(`Meta.team == "payments" and Status == "pending"`, 2, next)
+	require.Equal([]string{"job-e"}, ids)
+	require.Empty(next)
+
+	// Invalid filters are rejected
+	get := &structs.JobListRequest{
+		QueryOptions: structs.QueryOptions{
+			Region:    "global",
+			Namespace: structs.DefaultNamespace,
+			Filter:    `Unknown == "x"`,
+		},
+	}
+	var resp structs.JobListResponse
+	err := msgpackrpc.CallWithCodec(codec, "Job.List", get, &resp)
+	require.Error(err)
+	require.Contains(err.Error(), "failed to evaluate filter")
+}
+
 func TestJobEndpoint_ListJobs_WithACL(t *testing.T) {
 	require := require.New(t)
 	t.Parallel()
@@ -4820,3 +5892,181 @@ func TestJobEndpoint_Dispatch(t *testing.T) {
 		})
 	}
 }
+
+func TestJobEndpoint_Dispatch_MaxConcurrent(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	s1 := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer s1.Shutdown()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+	state := s1.fsm.State()
+
+	// Register a parameterized job that may only run one dispatch at once
+	job := mock.BatchJob()
+	job.ParameterizedJob = &structs.ParameterizedJobConfig{
+		MaxConcurrent: 1,
+	}
+	regReq := &structs.JobRegisterRequest{
+		Job: job,
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: job.Namespace,
+		},
+	}
+	var regResp structs.JobRegisterResponse
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Job.Register", regReq, &regResp))
+
+	dispatchReq := &structs.JobDispatchRequest{
+		JobID: job.ID,
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: job.Namespace,
+		},
+	}
+
+	// The first dispatch is registered
+	var resp1 structs.JobDispatchResponse
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Job.Dispatch", dispatchReq, &resp1))
+	require.False(resp1.Queued)
+	require.NotEmpty(resp1.EvalID)
+
+	// The second dispatch is queued
+	var resp2 structs.JobDispatchResponse
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Job.Dispatch", dispatchReq, &resp2))
+	require.True(resp2.Queued)
+	require.Empty(resp2.EvalID)
+	require.NotEmpty(resp2.DispatchedJobID)
+
+	out, err := state.JobByID(nil, job.Namespace, resp2.DispatchedJobID)
+	require.NoError(err)
+	require.Nil(out)
+
+	summary, err := state.JobSummaryByID(nil, job.Namespace, job.ID)
+	require.NoError(err)
+	require.EqualValues(1, summary.Children.Pending)
+	require.EqualValues(1, summary.Children.Queued)
+
+	// Purge the first dispatched job to free up the limit
+	dereg := &structs.JobDeregisterRequest{
+		JobID: resp1.DispatchedJobID,
+		Purge: true,
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: job.Namespace,
+		},
+	}
+	var deregResp structs.JobDeregisterResponse
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Job.Deregister", dereg, &deregResp))
+
+	// The leader should release the queued dispatch
+	testutil.WaitForResult(func() (bool, error) {
+		out, err := state.JobByID(nil, job.Namespace, resp2.DispatchedJobID)
+		if err != nil {
+			return false, err
+		}
+		if out == nil {
+			return false, fmt.Errorf("queued dispatched job not registered")
+		}
+		return true, nil
+	}, func(err error) {
+		t.Fatalf("err: %v", err)
+	})
+
+	summary, err = state.JobSummaryByID(nil, job.Namespace, job.ID)
+	require.NoError(err)
+	require.EqualValues(1, summary.Children.Pending)
+	require.EqualValues(0, summary.Children.Queued)
+	require.EqualValues(1, summary.Children.Dead)
+}
+
+func TestJobEndpoint_Dispatch_IdempotencyToken(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	s1 := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer s1.Shutdown()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+	state := s1.fsm.State()
+
+	// Register a parameterized job
+	job := mock.BatchJob()
+	job.ParameterizedJob = &structs.ParameterizedJobConfig{}
+	regReq := &structs.JobRegisterRequest{
+		Job: job,
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: job.Namespace,
+		},
+	}
+	var regResp structs.JobRegisterResponse
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Job.Register", regReq, &regResp))
+
+	dispatch := func(token string) *structs.JobDispatchResponse {
+		req := &structs.JobDispatchRequest{
+			JobID:            job.ID,
+			IdempotencyToken: token,
+			WriteRequest: structs.WriteRequest{
+				Region:    "global",
+				Namespace: job.Namespace,
+			},
+		}
+		var resp structs.JobDispatchResponse
+		require.NoError(msgpackrpc.CallWithCodec(codec, "Job.Dispatch", req, &resp))
+		return &resp
+	}
+
+	// The first dispatch with the token creates a job
+	resp1 := dispatch("foo")
+	require.False(resp1.Existing)
+	require.NotEmpty(resp1.EvalID)
+
+	out, err := state.JobByID(nil, job.Namespace, resp1.DispatchedJobID)
+	require.NoError(err)
+	require.NotNil(out)
+	require.Equal("foo", out.DispatchIdempotencyToken)
+
+	// Retrying with the token returns the same job and evaluation
+	resp2 := dispatch("foo")
+	require.True(resp2.Existing)
+	require.Equal(resp1.DispatchedJobID, resp2.DispatchedJobID)
+	require.Equal(resp1.JobCreateIndex, resp2.JobCreateIndex)
+	require.Equal(resp1.EvalID, resp2.EvalID)
+
+	// Another token dispatches a new job
+	resp3 := dispatch("bar")
+	require.False(resp3.Existing)
+	require.NotEqual(resp1.DispatchedJobID, resp3.DispatchedJobID)
+
+	// Once the token is past its retention period it dispatches a new job
+	s1.config.DispatchTokenRetention = 0
+	resp4 := dispatch("foo")
+	require.False(resp4.Existing)
+	require.NotEqual(resp1.DispatchedJobID, resp4.DispatchedJobID)
+
+	token, err := state.DispatchTokenByID(nil, job.Namespace, job.ID, "foo")
+	require.NoError(err)
+	require.Equal(resp4.DispatchedJobID, token.JobID)
+
+	// Purging the job deletes its token
+	dereg := &structs.JobDeregisterRequest{
+		JobID: resp4.DispatchedJobID,
+		Purge: true,
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: job.Namespace,
+		},
+	}
+	var deregResp structs.JobDeregisterResponse
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Job.Deregister", dereg, &deregResp))
+
+	token, err = state.DispatchTokenByID(nil, job.Namespace, job.ID, "foo")
+	require.NoError(err)
+	require.Nil(token)
+}
@@ -251,6 +251,9 @@ func (s *Server) establishLeadership(stopCh chan struct{}) error {
 	// Periodically unblock failed allocations
 	go s.periodicUnblockFailedEvals(stopCh)
 
+	// Release queued dispatched jobs as capacity frees up
+	go s.releaseQueuedDispatches(stopCh)
+
 	// Periodically publish job summary metrics
 	go s.publishJobSummaryMetrics(stopCh)
 
@@ -370,9 +373,10 @@ func (s *Server) restoreRevokingAccessors() error {
 
 // restorePeriodicDispatcher is used to restore all periodic jobs into the
 // periodic dispatcher. It also determines if a periodic job should have been
-// created during the leadership transition and force runs them. The periodic
-// dispatcher is maintained only by the leader, so it must be restored anytime a
-// leadership transition takes place.
+// created during the leadership transition and runs the missed launches
+// according to the job's catchup policy. The periodic dispatcher is maintained
+// only by the leader, so it must be restored anytime a leadership transition
+// takes place.
 func (s *Server) restorePeriodicDispatcher() error {
 	logger := s.logger.Named("periodic")
 	ws := memdb.NewWatchSet()
@@ -413,25 +417,24 @@ func (s *Server) restorePeriodicDispatcher() error {
 				job.ID, job.Namespace)
 		}
 
-		// nextLaunch is the next launch that should occur.
-		nextLaunch, err := job.Periodic.Next(launch.Launch.In(job.Periodic.GetLocation()))
+		// Determine the launches that were missed while there was no
+		// dispatcher running, according to the job's catchup policy.
+		location := job.Periodic.GetLocation()
+		missed, err := job.Periodic.MissedLaunches(launch.Launch.In(location), now.In(location))
 		if err != nil {
-			logger.Error("failed to determine next periodic launch for job", "job", job.NamespacedID(), "error", err)
+			logger.Error("failed to determine missed periodic launches for job", "job", job.NamespacedID(), "error", err)
 			continue
 		}
 
-		// We skip force launching the job if  there should be no next launch
-		// (the zero case) or if the next launch time is in the future. If it is
-		// in the future, it will be handled by the periodic dispatcher.
-		if nextLaunch.IsZero() || !nextLaunch.Before(now) {
-			continue
+		for _, missedLaunch := range missed {
+			if _, err := s.periodicDispatcher.CatchupRun(job.Namespace, job.ID, missedLaunch); err != nil {
+				logger.Error("catchup run of periodic job failed", "job", job.NamespacedID(), "launch", missedLaunch, "error", err)
+				return fmt.Errorf("catchup run of periodic job %q failed: %v", job.NamespacedID(), err)
+			}
 		}
-
-		if _, err := s.periodicDispatcher.ForceRun(job.Namespace, job.ID); err != nil {
-			logger.Error("force run of periodic job failed", "job", job.NamespacedID(), "error", err)
-			return fmt.Errorf("force run of periodic job %q failed: %v", job.NamespacedID(), err)
+		if len(missed) != 0 {
+			logger.Debug("periodic job caught up during leadership establishment", "job", job.NamespacedID(), "launches", len(missed))
 		}
-		logger.Debug("periodic job force runned during leadership establishment", "job", job.NamespacedID())
 	}
 
 	return nil
@@ -931,12 +934,24 @@ func (s *Server) addRaftPeer(m serf.Member, parts *serverParts) error {
 			return err
 		}
 	case minRaftProtocol == 2 && parts.RaftVersion >= 3:
-		addFuture := s.raft.AddVoter(raft.ServerID(parts.ID), raft.ServerAddress(addr), 0, 0)
+		var addFuture raft.IndexFuture
+		if parts.NonVoter {
+			addFuture = s.raft.AddNonvoter(raft.ServerID(parts.ID), raft.ServerAddress(addr), 0, 0)
+		} else {
+			addFuture = s.raft.AddVoter(raft.ServerID(parts.ID), raft.ServerAddress(addr), 0, 0)
+		}
 		if err := addFuture.Error(); err != nil {
 			s.logger.Error("failed to add raft peer", "error", err)
 			return err
 		}
 	default:
+		// Adding a read replica as a voter would change the quorum of the
+		// cluster so it is never added until non-voters are supported
+		if parts.NonVoter {
+			s.logger.Error("skipping adding read replica as a Raft peer since the cluster raft protocol does not support non-voters",
+				"peer", m.Name, "raft_protocol", minRaftProtocol, "peer_raft_protocol", parts.RaftVersion)
+			return nil
+		}
 		addFuture := s.raft.AddPeer(raft.ServerAddress(addr))
 		if err := addFuture.Error(); err != nil {
 			s.logger.Error("failed to add raft peer", "error", err)
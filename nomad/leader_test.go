@@ -3,6 +3,7 @@ package nomad
 import (
 	"errors"
 	"fmt"
+	"net"
 	"strconv"
 	"testing"
 	"time"
@@ -473,6 +474,63 @@ func TestLeader_PeriodicDispatcher_Restore_Evals(t *testing.T) {
 	}
 }
 
+func TestLeader_PeriodicDispatcher_Restore_Catchup(t *testing.T) {
+	s1 := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0
+	})
+	defer s1.Shutdown()
+	testutil.WaitForLeader(t, s1.RPC)
+	require := require.New(t)
+
+	// Inject periodic jobs that will each be triggered twice soon, one
+	// catching up all missed launches and one catching up none.
+	now := time.Now()
+	launches := []time.Time{now.Add(1 * time.Second), now.Add(2 * time.Second)}
+
+	all := testPeriodicJob(launches...)
+	all.Periodic.Catchup = structs.PeriodicCatchupAll
+	all.Periodic.CatchupWindow = time.Hour
+
+	none := testPeriodicJob(launches...)
+	none.Periodic.Catchup = structs.PeriodicCatchupNone
+
+	for _, job := range []*structs.Job{all, none} {
+		req := structs.JobRegisterRequest{
+			Job: job,
+			WriteRequest: structs.WriteRequest{
+				Namespace: job.Namespace,
+			},
+		}
+		_, _, err := s1.raftApply(structs.JobRegisterRequestType, req)
+		require.NoError(err)
+	}
+
+	// Flush the periodic dispatcher, ensuring that no evals will be created.
+	s1.periodicDispatcher.SetEnabled(false)
+
+	// Sleep till after the jobs should have been launched.
+	time.Sleep(3 * time.Second)
+
+	// Restore the periodic dispatcher.
+	s1.periodicDispatcher.SetEnabled(true)
+	require.NoError(s1.restorePeriodicDispatcher())
+
+	// Check that both missed launches were run for the job catching up all
+	// launches, and none for the other.
+	ws := memdb.NewWatchSet()
+	for _, launch := range launches {
+		id := s1.periodicDispatcher.derivedJobID(all, launch.Round(time.Second))
+		child, err := s1.fsm.State().JobByID(ws, all.Namespace, id)
+		require.NoError(err)
+		require.NotNil(child, "missing child %q", id)
+
+		id = s1.periodicDispatcher.derivedJobID(none, launch.Round(time.Second))
+		child, err = s1.fsm.State().JobByID(ws, none.Namespace, id)
+		require.NoError(err)
+		require.Nil(child)
+	}
+}
+
 func TestLeader_PeriodicDispatch(t *testing.T) {
 	s1 := TestServer(t, func(c *Config) {
 		c.NumSchedulers = 0
@@ -861,6 +919,41 @@ func TestLeader_UpgradeRaftVersion(t *testing.T) {
 	}
 }
 
+func TestLeader_ReadReplica_RaftProtocol(t *testing.T) {
+	t.Parallel()
+	s1 := TestServer(t, func(c *Config) {
+		c.RaftConfig.ProtocolVersion = 2
+	})
+	defer s1.Shutdown()
+	testutil.WaitForLeader(t, s1.RPC)
+
+	// A read replica speaking Raft protocol v2 can't be added as a non-voter
+	m := serf.Member{
+		Name:   "replica",
+		Addr:   net.IP([]byte{127, 0, 0, 1}),
+		Status: serf.StatusAlive,
+		Tags: map[string]string{
+			"role":     "nomad",
+			"region":   s1.Region(),
+			"dc":       "dc1",
+			"port":     "10000",
+			"vsn":      "1",
+			"raft_vsn": "2",
+			"id":       "5e2a8c8f-0b9e-4d2b-a1b0-8d1e6b0f6c3a",
+			"build":    "0.9.0",
+			"nonvoter": "1",
+		},
+	}
+	valid, parts := isNomadServer(m)
+	require.True(t, valid)
+	require.NoError(t, s1.addRaftPeer(m, parts))
+
+	// Make sure it was not added as a voter
+	future := s1.raft.GetConfiguration()
+	require.NoError(t, future.Error())
+	require.Len(t, future.Configuration().Servers, 1)
+}
+
 func TestLeader_Reelection(t *testing.T) {
 	raftProtocols := []int{1, 2, 3}
 	for _, p := range raftProtocols {
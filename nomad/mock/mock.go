@@ -849,6 +849,35 @@ func PlanResult() *structs.PlanResult {
 	return &structs.PlanResult{}
 }
 
+func Namespace() *structs.Namespace {
+	ns := &structs.Namespace{
+		Name:        fmt.Sprintf("team-%s", uuid.Generate()),
+		Description: "test namespace",
+		CreateIndex: 100,
+		ModifyIndex: 200,
+	}
+	ns.SetHash()
+	return ns
+}
+
+func AdmissionPolicy() *structs.AdmissionPolicy {
+	ap := &structs.AdmissionPolicy{
+		Name:             fmt.Sprintf("policy-%s", uuid.Generate()),
+		Description:      "Jobs must be owned by a team",
+		EnforcementLevel: structs.AdmissionPolicyEnforcementHardMandatory,
+		Policy: `
+		rule "team" {
+			condition = lookup(job.Meta, "team", "") != ""
+			message   = "jobs must set the team meta key"
+		}
+		`,
+		CreateIndex: 10,
+		ModifyIndex: 20,
+	}
+	ap.SetHash()
+	return ap
+}
+
 func ACLPolicy() *structs.ACLPolicy {
 	ap := &structs.ACLPolicy{
 		Name:        fmt.Sprintf("policy-%s", uuid.Generate()),
@@ -0,0 +1,329 @@
+package nomad
+
+import (
+	"testing"
+
+	msgpackrpc "github.com/hashicorp/net-rpc-msgpackrpc"
+	"github.com/hashicorp/nomad/acl"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNamespaceEndpoint_GetNamespace(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	s1 := TestServer(t, nil)
+	defer s1.Shutdown()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	ns := mock.Namespace()
+	s1.fsm.State().UpsertNamespaces(1000, []*structs.Namespace{ns})
+
+	// Lookup the namespace
+	get := &structs.NamespaceSpecificRequest{
+		Name:         ns.Name,
+		QueryOptions: structs.QueryOptions{Region: "global"},
+	}
+	var resp structs.SingleNamespaceResponse
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Namespace.GetNamespace", get, &resp))
+	require.EqualValues(1000, resp.Index)
+	require.Equal(ns, resp.Namespace)
+
+	// Lookup a missing namespace
+	get.Name = "missing"
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Namespace.GetNamespace", get, &resp))
+	require.EqualValues(1000, resp.Index)
+	require.Nil(resp.Namespace)
+}
+
+func TestNamespaceEndpoint_GetNamespace_ACL(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	s1, root := TestACLServer(t, nil)
+	defer s1.Shutdown()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	ns1 := mock.Namespace()
+	ns2 := mock.Namespace()
+	state := s1.fsm.State()
+	state.UpsertNamespaces(1000, []*structs.Namespace{ns1, ns2})
+
+	// Create a token that can read jobs in the first namespace only
+	token := mock.CreatePolicyAndToken(t, state, 1001, "test-valid",
+		mock.NamespacePolicy(ns1.Name, "", []string{acl.NamespaceCapabilityReadJob}))
+
+	get := &structs.NamespaceSpecificRequest{
+		Name:         ns1.Name,
+		QueryOptions: structs.QueryOptions{Region: "global"},
+	}
+
+	// Lookup the namespace without a token
+	var resp structs.SingleNamespaceResponse
+	err := msgpackrpc.CallWithCodec(codec, "Namespace.GetNamespace", get, &resp)
+	require.Error(err)
+	require.Contains(err.Error(), structs.ErrPermissionDenied.Error())
+
+	// Lookup the namespace with the token
+	get.AuthToken = token.SecretID
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Namespace.GetNamespace", get, &resp))
+	require.Equal(ns1, resp.Namespace)
+
+	// Lookup the other namespace with the token
+	get.Name = ns2.Name
+	err = msgpackrpc.CallWithCodec(codec, "Namespace.GetNamespace", get, &resp)
+	require.Error(err)
+	require.Contains(err.Error(), structs.ErrPermissionDenied.Error())
+
+	// Lookup the other namespace with the management token
+	get.AuthToken = root.SecretID
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Namespace.GetNamespace", get, &resp))
+	require.Equal(ns2, resp.Namespace)
+}
+
+func TestNamespaceEndpoint_ListNamespaces(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	s1 := TestServer(t, nil)
+	defer s1.Shutdown()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	ns1 := mock.Namespace()
+	ns2 := mock.Namespace()
+	ns1.Name = "aaaaaaaa-3350-4b4b-d185-0e1992ed43e9"
+	ns2.Name = "bbbbbbbb-3350-4b4b-d185-0e1992ed43e9"
+	s1.fsm.State().UpsertNamespaces(1000, []*structs.Namespace{ns1, ns2})
+
+	// List the namespaces, including the default namespace
+	get := &structs.NamespaceListRequest{
+		QueryOptions: structs.QueryOptions{Region: "global"},
+	}
+	var resp structs.NamespaceListResponse
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Namespace.ListNamespaces", get, &resp))
+	require.EqualValues(1000, resp.Index)
+	require.Len(resp.Namespaces, 3)
+
+	// List the namespaces with a prefix
+	get.Prefix = "aaaa"
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Namespace.ListNamespaces", get, &resp))
+	require.EqualValues(1000, resp.Index)
+	require.Len(resp.Namespaces, 1)
+	require.Equal(ns1.Name, resp.Namespaces[0].Name)
+}
+
+func TestNamespaceEndpoint_ListNamespaces_ACL(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	s1, root := TestACLServer(t, nil)
+	defer s1.Shutdown()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	ns1 := mock.Namespace()
+	ns2 := mock.Namespace()
+	state := s1.fsm.State()
+	state.UpsertNamespaces(1000, []*structs.Namespace{ns1, ns2})
+
+	// Create a token that can read jobs in the first namespace only
+	token := mock.CreatePolicyAndToken(t, state, 1001, "test-valid",
+		mock.NamespacePolicy(ns1.Name, "", []string{acl.NamespaceCapabilityReadJob}))
+
+	get := &structs.NamespaceListRequest{
+		QueryOptions: structs.QueryOptions{Region: "global"},
+	}
+
+	// List without a token
+	var resp structs.NamespaceListResponse
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Namespace.ListNamespaces", get, &resp))
+	require.Empty(resp.Namespaces)
+
+	// List with the token
+	get.AuthToken = token.SecretID
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Namespace.ListNamespaces", get, &resp))
+	require.Len(resp.Namespaces, 1)
+	require.Equal(ns1.Name, resp.Namespaces[0].Name)
+
+	// List with the management token
+	get.AuthToken = root.SecretID
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Namespace.ListNamespaces", get, &resp))
+	require.Len(resp.Namespaces, 3)
+}
+
+func TestNamespaceEndpoint_UpsertNamespaces(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	s1 := TestServer(t, nil)
+	defer s1.Shutdown()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	ns1 := mock.Namespace()
+	ns2 := mock.Namespace()
+
+	// Create the register request
+	req := &structs.NamespaceUpsertRequest{
+		Namespaces:   []*structs.Namespace{ns1, ns2},
+		WriteRequest: structs.WriteRequest{Region: "global"},
+	}
+	var resp structs.GenericResponse
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Namespace.UpsertNamespaces", req, &resp))
+	require.NotEqual(uint64(0), resp.Index)
+
+	// Check we created the namespaces
+	out, err := s1.fsm.State().NamespaceByName(nil, ns1.Name)
+	require.NoError(err)
+	require.NotNil(out)
+
+	out, err = s1.fsm.State().NamespaceByName(nil, ns2.Name)
+	require.NoError(err)
+	require.NotNil(out)
+
+	// Invalid namespaces are rejected
+	invalid := mock.Namespace()
+	invalid.Name = "in valid"
+	req.Namespaces = []*structs.Namespace{invalid}
+	err = msgpackrpc.CallWithCodec(codec, "Namespace.UpsertNamespaces", req, &resp)
+	require.Error(err)
+	require.Contains(err.Error(), "invalid name")
+
+	// Quotas aren't supported
+	quota := mock.Namespace()
+	quota.Quota = "foo"
+	req.Namespaces = []*structs.Namespace{quota}
+	err = msgpackrpc.CallWithCodec(codec, "Namespace.UpsertNamespaces", req, &resp)
+	require.Error(err)
+	require.Contains(err.Error(), "quotas are not supported")
+}
+
+func TestNamespaceEndpoint_UpsertNamespaces_ACL(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	s1, root := TestACLServer(t, nil)
+	defer s1.Shutdown()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	ns := mock.Namespace()
+	state := s1.fsm.State()
+
+	token := mock.CreatePolicyAndToken(t, state, 1001, "test-invalid",
+		mock.NamespacePolicy(ns.Name, "write", nil))
+
+	req := &structs.NamespaceUpsertRequest{
+		Namespaces:   []*structs.Namespace{ns},
+		WriteRequest: structs.WriteRequest{Region: "global"},
+	}
+
+	// Upsert the namespace without a token
+	var resp structs.GenericResponse
+	err := msgpackrpc.CallWithCodec(codec, "Namespace.UpsertNamespaces", req, &resp)
+	require.Error(err)
+	require.Contains(err.Error(), structs.ErrPermissionDenied.Error())
+
+	// Upsert the namespace with a non-management token
+	req.AuthToken = token.SecretID
+	err = msgpackrpc.CallWithCodec(codec, "Namespace.UpsertNamespaces", req, &resp)
+	require.Error(err)
+	require.Contains(err.Error(), structs.ErrPermissionDenied.Error())
+
+	// Upsert the namespace with the management token
+	req.AuthToken = root.SecretID
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Namespace.UpsertNamespaces", req, &resp))
+
+	out, err := state.NamespaceByName(nil, ns.Name)
+	require.NoError(err)
+	require.NotNil(out)
+}
+
+func TestNamespaceEndpoint_DeleteNamespaces(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	s1 := TestServer(t, nil)
+	defer s1.Shutdown()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	ns1 := mock.Namespace()
+	ns2 := mock.Namespace()
+	state := s1.fsm.State()
+	require.NoError(state.UpsertNamespaces(1000, []*structs.Namespace{ns1, ns2}))
+
+	// Register a job in the second namespace
+	job := mock.Job()
+	job.Namespace = ns2.Name
+	require.NoError(state.UpsertJob(1001, job))
+
+	// Delete the first namespace
+	req := &structs.NamespaceDeleteRequest{
+		Namespaces:   []string{ns1.Name},
+		WriteRequest: structs.WriteRequest{Region: "global"},
+	}
+	var resp structs.GenericResponse
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Namespace.DeleteNamespaces", req, &resp))
+	require.NotEqual(uint64(0), resp.Index)
+
+	out, err := state.NamespaceByName(nil, ns1.Name)
+	require.NoError(err)
+	require.Nil(out)
+
+	// Deleting the namespace with a job fails
+	req.Namespaces = []string{ns2.Name}
+	err = msgpackrpc.CallWithCodec(codec, "Namespace.DeleteNamespaces", req, &resp)
+	require.Error(err)
+	require.Contains(err.Error(), "contains at least one job")
+
+	out, err = state.NamespaceByName(nil, ns2.Name)
+	require.NoError(err)
+	require.NotNil(out)
+
+	// Deleting the default namespace fails
+	req.Namespaces = []string{structs.DefaultNamespace}
+	err = msgpackrpc.CallWithCodec(codec, "Namespace.DeleteNamespaces", req, &resp)
+	require.Error(err)
+	require.Contains(err.Error(), "can not delete default namespace")
+}
+
+func TestNamespaceEndpoint_DeleteNamespaces_ACL(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	s1, root := TestACLServer(t, nil)
+	defer s1.Shutdown()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	ns := mock.Namespace()
+	state := s1.fsm.State()
+	require.NoError(state.UpsertNamespaces(1000, []*structs.Namespace{ns}))
+
+	token := mock.CreatePolicyAndToken(t, state, 1001, "test-invalid",
+		mock.NamespacePolicy(ns.Name, "write", nil))
+
+	req := &structs.NamespaceDeleteRequest{
+		Namespaces:   []string{ns.Name},
+		WriteRequest: structs.WriteRequest{Region: "global"},
+	}
+
+	// Delete the namespace without a token
+	var resp structs.GenericResponse
+	err := msgpackrpc.CallWithCodec(codec, "Namespace.DeleteNamespaces", req, &resp)
+	require.Error(err)
+	require.Contains(err.Error(), structs.ErrPermissionDenied.Error())
+
+	// Delete the namespace with a non-management token
+	req.AuthToken = token.SecretID
+	err = msgpackrpc.CallWithCodec(codec, "Namespace.DeleteNamespaces", req, &resp)
+	require.Error(err)
+	require.Contains(err.Error(), structs.ErrPermissionDenied.Error())
+
+	// Delete the namespace with the management token
+	req.AuthToken = root.SecretID
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Namespace.DeleteNamespaces", req, &resp))
+
+	out, err := state.NamespaceByName(nil, ns.Name)
+	require.NoError(err)
+	require.Nil(out)
+}
@@ -414,8 +414,9 @@ func (n *Node) UpdateStatus(args *structs.NodeUpdateStatusRequest, reply *struct
 	var index uint64
 	if node.Status != args.Status {
 		// Attach an event if we are updating the node status to ready when it
-		// is down via a heartbeat
-		if node.Status == structs.NodeStatusDown && args.NodeEvent == nil {
+		// is down or disconnected via a heartbeat
+		if (node.Status == structs.NodeStatusDown || node.Status == structs.NodeStatusDisconnected) &&
+			args.NodeEvent == nil {
 			args.NodeEvent = structs.NewNodeEvent().
 				SetSubsystem(structs.NodeEventSubsystemCluster).
 				SetMessage(NodeHeartbeatEventReregistered)
@@ -458,6 +459,9 @@ func (n *Node) UpdateStatus(args *structs.NodeUpdateStatusRequest, reply *struct
 				return err
 			}
 		}
+	case structs.NodeStatusDisconnected:
+		// The heartbeater tracks how long the node may remain disconnected
+		// before it is marked down.
 	default:
 		ttl, err := n.srv.resetHeartbeatTimer(args.NodeID)
 		if err != nil {
@@ -484,7 +488,8 @@ func (n *Node) UpdateStatus(args *structs.NodeUpdateStatusRequest, reply *struct
 func transitionedToReady(newStatus, oldStatus string) bool {
 	initToReady := oldStatus == structs.NodeStatusInit && newStatus == structs.NodeStatusReady
 	terminalToReady := oldStatus == structs.NodeStatusDown && newStatus == structs.NodeStatusReady
-	return initToReady || terminalToReady
+	disconnectedToReady := oldStatus == structs.NodeStatusDisconnected && newStatus == structs.NodeStatusReady
+	return initToReady || terminalToReady || disconnectedToReady
 }
 
 // UpdateDrain is used to update the drain mode of a client node
@@ -1084,6 +1089,40 @@ func (n *Node) UpdateAlloc(args *structs.AllocUpdateRequest, reply *structs.Gene
 				}
 			}
 		}
+
+		// Add an evaluation if the client reports an allocation that was lost
+		// while its node was disconnected as running, so it may be resumed
+		if alloc.ClientStatus == structs.AllocClientStatusRunning {
+			existingAlloc, _ := n.srv.State().AllocByID(nil, alloc.ID)
+			if existingAlloc == nil || existingAlloc.ClientStatus != structs.AllocClientStatusLost ||
+				existingAlloc.LostTime == 0 {
+				continue
+			}
+			job, err := n.srv.State().JobByID(nil, existingAlloc.Namespace, existingAlloc.JobID)
+			if err != nil {
+				n.logger.Error("UpdateAlloc unable to find job", "job", existingAlloc.JobID, "error", err)
+				continue
+			}
+			if job == nil {
+				n.logger.Debug("UpdateAlloc unable to find job", "job", existingAlloc.JobID)
+				continue
+			}
+			taskGroup := job.LookupTaskGroup(existingAlloc.TaskGroup)
+			if taskGroup != nil && taskGroup.MaxClientDisconnect != nil {
+				eval := &structs.Evaluation{
+					ID:          uuid.Generate(),
+					Namespace:   existingAlloc.Namespace,
+					TriggeredBy: structs.EvalTriggerReconnect,
+					JobID:       existingAlloc.JobID,
+					Type:        job.Type,
+					Priority:    job.Priority,
+					Status:      structs.EvalStatusPending,
+					CreateTime:  now.UTC().UnixNano(),
+					ModifyTime:  now.UTC().UnixNano(),
+				}
+				evals = append(evals, eval)
+			}
+		}
 	}
 
 	// Add this to the batch
@@ -1226,16 +1265,28 @@ func (n *Node) List(args *structs.NodeListRequest,
 				return err
 			}
 
+			paginator, err := newPaginator(iter, args.QueryOptions,
+				func(raw interface{}) string {
+					return raw.(*structs.Node).ID
+				},
+				func(raw interface{}) (interface{}, error) {
+					return raw.(*structs.Node).Stub(), nil
+				})
+			if err != nil {
+				return err
+			}
+
+			stubs, nextToken, err := paginator.page()
+			if err != nil {
+				return err
+			}
+
 			var nodes []*structs.NodeListStub
-			for {
-				raw := iter.Next()
-				if raw == nil {
-					break
-				}
-				node := raw.(*structs.Node)
-				nodes = append(nodes, node.Stub())
+			for _, stub := range stubs {
+				nodes = append(nodes, stub.(*structs.NodeListStub))
 			}
 			reply.Nodes = nodes
+			reply.NextToken = nextToken
 
 			// Use the last index that affected the jobs table
 			index, err := state.Index("nodes")
@@ -11,6 +11,7 @@ import (
 	memdb "github.com/hashicorp/go-memdb"
 	msgpackrpc "github.com/hashicorp/net-rpc-msgpackrpc"
 	"github.com/hashicorp/nomad/acl"
+	"github.com/hashicorp/nomad/helper"
 	"github.com/hashicorp/nomad/helper/uuid"
 	"github.com/hashicorp/nomad/nomad/mock"
 	"github.com/hashicorp/nomad/nomad/state"
@@ -2125,6 +2126,68 @@ func TestClientEndpoint_UpdateAlloc(t *testing.T) {
 
 }
 
+func TestClientEndpoint_UpdateAlloc_Reconnect(t *testing.T) {
+	t.Parallel()
+	s1 := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0
+	})
+	defer s1.Shutdown()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+	require := require.New(t)
+
+	node := mock.Node()
+	state := s1.fsm.State()
+	require.NoError(state.UpsertNode(98, node))
+
+	// Inject a job whose allocations may reconnect
+	job := mock.Job()
+	job.TaskGroups[0].MaxClientDisconnect = helper.TimeToPtr(5 * time.Minute)
+	require.NoError(state.UpsertJob(99, job))
+
+	// Inject an allocation that was lost and one stopped for another reason
+	lost := mock.Alloc()
+	lost.Job = job
+	lost.JobID = job.ID
+	lost.NodeID = node.ID
+	lost.DesiredStatus = structs.AllocDesiredStatusStop
+	lost.ClientStatus = structs.AllocClientStatusLost
+	lost.LostTime = time.Now().UTC().UnixNano()
+
+	stopped := mock.Alloc()
+	stopped.Job = job
+	stopped.JobID = job.ID
+	stopped.NodeID = node.ID
+	stopped.DesiredStatus = structs.AllocDesiredStatusStop
+	stopped.ClientStatus = structs.AllocClientStatusComplete
+	require.NoError(state.UpsertAllocs(100, []*structs.Allocation{lost, stopped}))
+
+	// The client reports both allocations as running
+	var clientAllocs []*structs.Allocation
+	for _, alloc := range []*structs.Allocation{lost, stopped} {
+		clientAlloc := alloc.Copy()
+		clientAlloc.ClientStatus = structs.AllocClientStatusRunning
+		clientAllocs = append(clientAllocs, clientAlloc)
+	}
+	update := &structs.AllocUpdateRequest{
+		Alloc:        clientAllocs,
+		WriteRequest: structs.WriteRequest{Region: "global"},
+	}
+	var resp structs.NodeAllocsResponse
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Node.UpdateAlloc", update, &resp))
+
+	// Assert that exactly one eval was created for the lost allocation
+	evals, err := state.EvalsByJob(nil, job.Namespace, job.ID)
+	require.NoError(err)
+	require.Len(evals, 1)
+	require.Equal(structs.EvalTriggerReconnect, evals[0].TriggeredBy)
+
+	out, err := state.AllocByID(nil, lost.ID)
+	require.NoError(err)
+	require.Equal(structs.AllocClientStatusRunning, out.ClientStatus)
+	require.Equal(lost.LostTime, out.LostTime)
+}
+
 func TestClientEndpoint_BatchUpdate(t *testing.T) {
 	t.Parallel()
 	s1 := TestServer(t, nil)
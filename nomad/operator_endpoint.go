@@ -3,10 +3,13 @@ package nomad
 import (
 	"fmt"
 	"net"
+	"sort"
 
 	log "github.com/hashicorp/go-hclog"
+	multierror "github.com/hashicorp/go-multierror"
 
 	"github.com/hashicorp/consul/agent/consul/autopilot"
+	"github.com/hashicorp/nomad/helper"
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/hashicorp/raft"
 	"github.com/hashicorp/serf/serf"
@@ -56,11 +59,13 @@ func (op *Operator) RaftGetConfiguration(args *structs.GenericRequest, reply *st
 	for _, server := range future.Configuration().Servers {
 		node := "(unknown)"
 		raftProtocolVersion := "unknown"
+		readReplica := false
 		if member, ok := serverMap[server.Address]; ok {
 			node = member.Name
 			if raftVsn, ok := member.Tags["raft_vsn"]; ok {
 				raftProtocolVersion = raftVsn
 			}
+			_, readReplica = member.Tags["nonvoter"]
 		}
 
 		entry := &structs.RaftServer{
@@ -69,6 +74,7 @@ func (op *Operator) RaftGetConfiguration(args *structs.GenericRequest, reply *st
 			Address:      server.Address,
 			Leader:       server.Address == leader,
 			Voter:        server.Suffrage == raft.Voter,
+			ReadReplica:  readReplica,
 			RaftProtocol: raftProtocolVersion,
 		}
 		reply.Servers = append(reply.Servers, entry)
@@ -355,3 +361,165 @@ func (op *Operator) SchedulerGetConfiguration(args *structs.GenericRequest, repl
 
 	return nil
 }
+
+// EvalBrokerInspect is used to list the evaluations held by the eval broker and
+// blocked evals tracker on the leader.
+func (op *Operator) EvalBrokerInspect(args *structs.GenericRequest, reply *structs.EvalBrokerInspectResponse) error {
+	if done, err := op.srv.forward("Operator.EvalBrokerInspect", args, args, reply); done {
+		return err
+	}
+
+	// This action requires operator read access.
+	rule, err := op.srv.ResolveToken(args.AuthToken)
+	if err != nil {
+		return err
+	} else if rule != nil && !rule.AllowOperatorRead() {
+		return structs.ErrPermissionDenied
+	}
+
+	if !op.srv.evalBroker.Enabled() {
+		return fmt.Errorf("eval broker disabled")
+	}
+
+	broker := op.srv.evalBroker.Inspect()
+	captured, escaped := op.srv.blockedEvals.Inspect()
+
+	reply.Ready = sortBrokerEvals(broker.Ready)
+	reply.Unacked = sortBrokerEvals(broker.Unacked)
+	reply.Waiting = sortBrokerEvals(broker.Waiting)
+	reply.Pending = sortBrokerEvals(broker.Pending)
+	reply.Captured = sortBrokerEvals(captured)
+	reply.Escaped = sortBrokerEvals(escaped)
+
+	op.srv.setQueryMeta(&reply.QueryMeta)
+	return nil
+}
+
+// EvalBrokerUpdate is used to drop or re-enqueue evaluations held by the eval
+// broker and blocked evals tracker on the leader. Dropped evaluations are
+// marked as cancelled.
+func (op *Operator) EvalBrokerUpdate(args *structs.EvalBrokerUpdateRequest, reply *structs.EvalBrokerUpdateResponse) error {
+	if done, err := op.srv.forward("Operator.EvalBrokerUpdate", args, args, reply); done {
+		return err
+	}
+
+	// This action requires operator write access.
+	rule, err := op.srv.ResolveToken(args.AuthToken)
+	if err != nil {
+		return err
+	} else if rule != nil && !rule.AllowOperatorWrite() {
+		return structs.ErrPermissionDenied
+	}
+
+	if len(args.EvalIDs) == 0 {
+		return fmt.Errorf("missing evaluation IDs")
+	}
+	if !op.srv.evalBroker.Enabled() {
+		return fmt.Errorf("eval broker disabled")
+	}
+
+	var mErr multierror.Error
+	switch args.Action {
+	case structs.EvalBrokerActionDrop:
+		snap, err := op.srv.fsm.State().Snapshot()
+		if err != nil {
+			return err
+		}
+
+		// Determine the evaluations that can be dropped. Only evaluations
+		// that are pending or blocked are tracked by the brokers.
+		var dropped []*structs.Evaluation
+		for _, evalID := range args.EvalIDs {
+			var eval *structs.Evaluation
+			if helper.IsUUID(evalID) {
+				eval, err = snap.EvalByID(nil, evalID)
+				if err != nil {
+					return err
+				}
+			}
+			if eval == nil || !(eval.ShouldEnqueue() || eval.ShouldBlock()) {
+				multierror.Append(&mErr, fmt.Errorf("evaluation %q not found in eval broker", evalID))
+				continue
+			}
+			if _, ok := op.srv.evalBroker.Outstanding(evalID); ok {
+				multierror.Append(&mErr, fmt.Errorf("evaluation %q is being processed by a scheduler", evalID))
+				continue
+			}
+
+			cancelled := eval.Copy()
+			cancelled.Status = structs.EvalStatusCancelled
+			cancelled.StatusDescription = "evaluation dropped by operator"
+			cancelled.UpdateModifyTime()
+			dropped = append(dropped, cancelled)
+		}
+		if len(dropped) == 0 {
+			break
+		}
+
+		// Cancel the evaluations before removing them from the brokers so
+		// that a failed apply does not leave pending evaluations that no
+		// broker is tracking.
+		req := structs.EvalUpdateRequest{
+			Evals:        dropped,
+			WriteRequest: args.WriteRequest,
+		}
+		_, index, err := op.srv.raftApply(structs.EvalUpdateRequestType, &req)
+		if err != nil {
+			op.logger.Error("failed to cancel dropped evaluations", "error", err)
+			return err
+		}
+		reply.Index = index
+
+		for _, eval := range dropped {
+			reply.Updated = append(reply.Updated, eval.ID)
+
+			// The evaluation may have been dequeued since it was looked up,
+			// in which case the scheduler finishes processing it.
+			removed, err := op.srv.evalBroker.Drop(eval.ID)
+			if err != nil {
+				op.logger.Warn("cancelled evaluation is being processed by a scheduler", "eval_id", eval.ID)
+				continue
+			}
+			if removed == nil {
+				op.srv.blockedEvals.Drop(eval.ID)
+			}
+		}
+
+	case structs.EvalBrokerActionReenqueue:
+		for _, evalID := range args.EvalIDs {
+			ok, err := op.srv.evalBroker.Reenqueue(evalID)
+			if err != nil {
+				multierror.Append(&mErr, err)
+				continue
+			}
+			if !ok {
+				ok = op.srv.blockedEvals.Reenqueue(evalID)
+			}
+			if !ok {
+				multierror.Append(&mErr, fmt.Errorf("evaluation %q is not waiting, failed or blocked", evalID))
+				continue
+			}
+			reply.Updated = append(reply.Updated, evalID)
+		}
+
+	default:
+		return fmt.Errorf("unknown eval broker action %q", args.Action)
+	}
+
+	return mErr.ErrorOrNil()
+}
+
+// sortBrokerEvals sorts evaluations by descending priority and then by
+// creation time.
+func sortBrokerEvals(evals []*structs.BrokerEval) []*structs.BrokerEval {
+	sort.Slice(evals, func(i, j int) bool {
+		if evals[i].Priority != evals[j].Priority {
+			return evals[i].Priority > evals[j].Priority
+		}
+		if evals[i].CreateTime != evals[j].CreateTime {
+			return evals[i].CreateTime < evals[j].CreateTime
+		}
+		return evals[i].ID < evals[j].ID
+	})
+	return evals
+}
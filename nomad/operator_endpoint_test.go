@@ -5,10 +5,12 @@ import (
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/consul/lib/freeport"
 	msgpackrpc "github.com/hashicorp/net-rpc-msgpackrpc"
 	"github.com/hashicorp/nomad/acl"
+	"github.com/hashicorp/nomad/helper/uuid"
 	"github.com/hashicorp/nomad/nomad/mock"
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/hashicorp/nomad/testutil"
@@ -60,6 +62,49 @@ func TestOperator_RaftGetConfiguration(t *testing.T) {
 	}
 }
 
+func TestOperator_RaftGetConfiguration_ReadReplica(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	s1 := TestServer(t, nil)
+	defer s1.Shutdown()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	s2 := TestServer(t, func(c *Config) {
+		c.DevDisableBootstrap = true
+		c.NonVoter = true
+	})
+	defer s2.Shutdown()
+	TestJoin(t, s1, s2)
+
+	arg := structs.GenericRequest{
+		QueryOptions: structs.QueryOptions{
+			Region: s1.config.Region,
+		},
+	}
+	testutil.WaitForResult(func() (bool, error) {
+		var reply structs.RaftConfigurationResponse
+		if err := msgpackrpc.CallWithCodec(codec, "Operator.RaftGetConfiguration", &arg, &reply); err != nil {
+			return false, err
+		}
+		if len(reply.Servers) != 2 {
+			return false, fmt.Errorf("expected 2 servers, got %d", len(reply.Servers))
+		}
+		for _, server := range reply.Servers {
+			isReplica := server.Node == fmt.Sprintf("%v.%v", s2.config.NodeName, s2.config.Region)
+			if server.ReadReplica != isReplica {
+				return false, fmt.Errorf("unexpected read replica state: %+v", server)
+			}
+			if server.Voter == isReplica {
+				return false, fmt.Errorf("unexpected voter state: %+v", server)
+			}
+		}
+		return true, nil
+	}, func(err error) {
+		require.NoError(err)
+	})
+}
+
 func TestOperator_RaftGetConfiguration_ACL(t *testing.T) {
 	t.Parallel()
 	s1, root := TestACLServer(t, nil)
@@ -499,3 +544,137 @@ func TestOperator_SchedulerSetConfiguration_ACL(t *testing.T) {
 	}
 
 }
+
+func TestOperator_EvalBroker(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	s1 := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer s1.Shutdown()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	// Create two waiting evaluations
+	eval1 := mock.Eval()
+	eval1.Wait = time.Hour
+	eval2 := mock.Eval()
+	eval2.Wait = time.Hour
+	evals := []*structs.Evaluation{eval1, eval2}
+	require.NoError(s1.fsm.State().UpsertEvals(1000, evals))
+	for _, eval := range evals {
+		s1.evalBroker.Enqueue(eval)
+	}
+
+	// Inspect the broker
+	get := &structs.GenericRequest{
+		QueryOptions: structs.QueryOptions{
+			Region: "global",
+		},
+	}
+	var inspect structs.EvalBrokerInspectResponse
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Operator.EvalBrokerInspect", get, &inspect))
+	require.Len(inspect.Waiting, 2)
+	require.Empty(inspect.Ready)
+
+	// Re-enqueue the first eval
+	update := &structs.EvalBrokerUpdateRequest{
+		Action:  structs.EvalBrokerActionReenqueue,
+		EvalIDs: []string{eval1.ID},
+		WriteRequest: structs.WriteRequest{
+			Region: "global",
+		},
+	}
+	var resp structs.EvalBrokerUpdateResponse
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Operator.EvalBrokerUpdate", update, &resp))
+	require.Equal([]string{eval1.ID}, resp.Updated)
+
+	inspect = structs.EvalBrokerInspectResponse{}
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Operator.EvalBrokerInspect", get, &inspect))
+	require.Len(inspect.Waiting, 1)
+	require.Equal(eval2.ID, inspect.Waiting[0].ID)
+	require.Len(inspect.Ready, 1)
+	require.Equal(eval1.ID, inspect.Ready[0].ID)
+
+	// Drop the second eval and an unknown eval
+	update.Action = structs.EvalBrokerActionDrop
+	update.EvalIDs = []string{eval2.ID, "foo"}
+	resp = structs.EvalBrokerUpdateResponse{}
+	err := msgpackrpc.CallWithCodec(codec, "Operator.EvalBrokerUpdate", update, &resp)
+	require.Error(err)
+	require.Contains(err.Error(), `"foo" not found`)
+
+	out, err := s1.fsm.State().EvalByID(nil, eval2.ID)
+	require.NoError(err)
+	require.Equal(structs.EvalStatusCancelled, out.Status)
+	require.Zero(s1.evalBroker.Stats().TotalWaiting)
+
+	// Evals being processed are neither cancelled nor dropped
+	out, _, err = s1.evalBroker.Dequeue(defaultSched, time.Second)
+	require.NoError(err)
+	require.Equal(eval1.ID, out.ID)
+
+	update.EvalIDs = []string{eval1.ID}
+	resp = structs.EvalBrokerUpdateResponse{}
+	err = msgpackrpc.CallWithCodec(codec, "Operator.EvalBrokerUpdate", update, &resp)
+	require.Error(err)
+	require.Contains(err.Error(), "being processed")
+	require.Empty(resp.Updated)
+
+	out, err = s1.fsm.State().EvalByID(nil, eval1.ID)
+	require.NoError(err)
+	require.Equal(structs.EvalStatusPending, out.Status)
+
+	// Unknown actions are rejected
+	update.Action = "foo"
+	update.EvalIDs = []string{eval1.ID}
+	err = msgpackrpc.CallWithCodec(codec, "Operator.EvalBrokerUpdate", update, &resp)
+	require.Error(err)
+	require.Contains(err.Error(), "unknown eval broker action")
+}
+
+func TestOperator_EvalBroker_ACL(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	s1, root := TestACLServer(t, nil)
+	defer s1.Shutdown()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+	state := s1.fsm.State()
+
+	readToken := mock.CreatePolicyAndToken(t, state, 1001, "operator-read", mock.OperatorPolicy(acl.PolicyRead))
+
+	get := &structs.GenericRequest{
+		QueryOptions: structs.QueryOptions{
+			Region: "global",
+		},
+	}
+	var inspect structs.EvalBrokerInspectResponse
+
+	// Try with no token and expect permission denied
+	err := msgpackrpc.CallWithCodec(codec, "Operator.EvalBrokerInspect", get, &inspect)
+	require.EqualError(err, structs.ErrPermissionDenied.Error())
+
+	// Operator read is sufficient to inspect
+	get.AuthToken = readToken.SecretID
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Operator.EvalBrokerInspect", get, &inspect))
+
+	// Operator read is not sufficient to update
+	update := &structs.EvalBrokerUpdateRequest{
+		Action:  structs.EvalBrokerActionDrop,
+		EvalIDs: []string{uuid.Generate()},
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			AuthToken: readToken.SecretID,
+		},
+	}
+	var resp structs.EvalBrokerUpdateResponse
+	err = msgpackrpc.CallWithCodec(codec, "Operator.EvalBrokerUpdate", update, &resp)
+	require.EqualError(err, structs.ErrPermissionDenied.Error())
+
+	// The management token passes the ACL check
+	update.AuthToken = root.SecretID
+	err = msgpackrpc.CallWithCodec(codec, "Operator.EvalBrokerUpdate", update, &resp)
+	require.Error(err)
+	require.Contains(err.Error(), "not found")
+}
@@ -0,0 +1,96 @@
+package nomad
+
+import (
+	"fmt"
+
+	memdb "github.com/hashicorp/go-memdb"
+	"github.com/hashicorp/nomad/helper/filter"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// paginator wraps a state store iterator and applies the filter and
+// pagination query options of a list request to the objects it returns. The
+// iterator must return objects sorted by the token returned by tokenFn.
+type paginator struct {
+	iter      memdb.ResultIterator
+	filter    *filter.Filter
+	perPage   int32
+	nextToken string
+
+	// tokenFn returns the pagination token of a raw object, usually its ID.
+	tokenFn func(raw interface{}) string
+
+	// stubFn converts a raw object into the object the filter is evaluated
+	// against and that is returned to the caller.
+	stubFn func(raw interface{}) (interface{}, error)
+}
+
+// newPaginator returns a paginator for the given iterator and query options.
+// An error is returned if the filter expression is invalid.
+func newPaginator(iter memdb.ResultIterator, opts structs.QueryOptions,
+	tokenFn func(raw interface{}) string,
+	stubFn func(raw interface{}) (interface{}, error)) (*paginator, error) {
+
+	if opts.PerPage < 0 {
+		return nil, fmt.Errorf("per page must be non-negative")
+	}
+
+	p := &paginator{
+		iter:      iter,
+		perPage:   opts.PerPage,
+		nextToken: opts.NextToken,
+		tokenFn:   tokenFn,
+		stubFn:    stubFn,
+	}
+
+	if opts.Filter != "" {
+		f, err := filter.Parse(opts.Filter)
+		if err != nil {
+			return nil, fmt.Errorf("invalid filter: %v", err)
+		}
+		p.filter = f
+	}
+
+	return p, nil
+}
+
+// page returns the objects of the requested page that match the filter, and
+// the token of the first matching object of the next page. The token is empty
+// if there are no more matching objects.
+func (p *paginator) page() ([]interface{}, string, error) {
+	var stubs []interface{}
+	for {
+		raw := p.iter.Next()
+		if raw == nil {
+			return stubs, "", nil
+		}
+
+		// Skip the objects of the previous pages
+		token := p.tokenFn(raw)
+		if p.nextToken != "" && token < p.nextToken {
+			continue
+		}
+
+		stub, err := p.stubFn(raw)
+		if err != nil {
+			return nil, "", err
+		}
+
+		if p.filter != nil {
+			match, err := p.filter.Match(stub)
+			if err != nil {
+				return nil, "", fmt.Errorf("failed to evaluate filter: %v", err)
+			}
+			if !match {
+				continue
+			}
+		}
+
+		// The page is full, so this object starts the next one
+		if p.perPage > 0 && int32(len(stubs)) == p.perPage {
+			return stubs, token, nil
+		}
+
+		stubs = append(stubs, stub)
+	}
+}
@@ -292,6 +292,19 @@ func (p *PeriodicDispatch) removeLocked(jobID structs.NamespacedID) error {
 // ForceRun causes the periodic job to be evaluated immediately and returns the
 // subsequent eval.
 func (p *PeriodicDispatch) ForceRun(namespace, jobID string) (*structs.Evaluation, error) {
+	return p.forceRunAt(namespace, jobID, time.Time{})
+}
+
+// CatchupRun launches the periodic job for a launch time that was missed and
+// returns the subsequent eval. The derived job is named after the missed
+// launch time rather than the current time.
+func (p *PeriodicDispatch) CatchupRun(namespace, jobID string, launch time.Time) (*structs.Evaluation, error) {
+	return p.forceRunAt(namespace, jobID, launch)
+}
+
+// forceRunAt creates an evaluation for the tracked periodic job at the given
+// launch time. A zero launch time launches the job at the current time.
+func (p *PeriodicDispatch) forceRunAt(namespace, jobID string, launch time.Time) (*structs.Evaluation, error) {
 	p.l.Lock()
 
 	// Do nothing if not enabled
@@ -311,7 +324,10 @@ func (p *PeriodicDispatch) ForceRun(namespace, jobID string) (*structs.Evaluatio
 	}
 
 	p.l.Unlock()
-	return p.createEval(job, time.Now().In(job.Periodic.GetLocation()))
+	if launch.IsZero() {
+		launch = time.Now()
+	}
+	return p.createEval(job, launch.In(job.Periodic.GetLocation()))
 }
 
 // shouldRun returns whether the long lived run function should run.
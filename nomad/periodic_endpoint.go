@@ -2,6 +2,7 @@ package nomad
 
 import (
 	"fmt"
+	"sort"
 	"time"
 
 	metrics "github.com/armon/go-metrics"
@@ -9,6 +10,8 @@ import (
 	memdb "github.com/hashicorp/go-memdb"
 
 	"github.com/hashicorp/nomad/acl"
+	"github.com/hashicorp/nomad/helper"
+	"github.com/hashicorp/nomad/nomad/state"
 	"github.com/hashicorp/nomad/nomad/structs"
 )
 
@@ -67,3 +70,116 @@ func (p *Periodic) Force(args *structs.PeriodicForceRequest, reply *structs.Peri
 	reply.Index = eval.CreateIndex
 	return nil
 }
+
+// Status is used to retrieve the upcoming launch times and the launch history
+// of a periodic job
+func (p *Periodic) Status(args *structs.PeriodicStatusRequest, reply *structs.PeriodicStatusResponse) error {
+	if done, err := p.srv.forward("Periodic.Status", args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "periodic", "status"}, time.Now())
+
+	// Check for read-job permissions
+	if aclObj, err := p.srv.ResolveToken(args.AuthToken); err != nil {
+		return err
+	} else if aclObj != nil && !aclObj.AllowNsOp(args.RequestNamespace(), acl.NamespaceCapabilityReadJob) {
+		return structs.ErrPermissionDenied
+	}
+
+	// Validate the arguments
+	if args.JobID == "" {
+		return fmt.Errorf("missing job ID for periodic status")
+	}
+	if args.Next < 0 {
+		return fmt.Errorf("number of upcoming launches must be non-negative")
+	}
+	if args.Next > structs.PeriodicStatusMaxNext {
+		args.Next = structs.PeriodicStatusMaxNext
+	}
+
+	// Setup the blocking query
+	opts := blockingOptions{
+		queryOpts: &args.QueryOptions,
+		queryMeta: &reply.QueryMeta,
+		run: func(ws memdb.WatchSet, state *state.StateStore) error {
+			job, err := state.JobByID(ws, args.RequestNamespace(), args.JobID)
+			if err != nil {
+				return err
+			}
+			if job == nil {
+				return fmt.Errorf("job not found")
+			}
+			if !job.IsPeriodic() {
+				return fmt.Errorf("job %q is not periodic", job.ID)
+			}
+
+			launch, err := state.PeriodicLaunchByID(ws, job.Namespace, job.ID)
+			if err != nil {
+				return err
+			}
+			reply.LastLaunch = launch
+
+			// Compute the upcoming launches
+			reply.Next = nil
+			if job.IsPeriodicActive() {
+				from := time.Now().In(job.Periodic.GetLocation())
+				for i := 0; i < args.Next; i++ {
+					next, err := job.Periodic.Next(from)
+					if err != nil {
+						return err
+					}
+					if next.IsZero() {
+						break
+					}
+					reply.Next = append(reply.Next, next)
+					from = next
+				}
+			}
+
+			// Collect the launched children
+			prefix := fmt.Sprintf("%s%s", job.ID, structs.PeriodicLaunchSuffix)
+			iter, err := state.JobsByIDPrefix(ws, job.Namespace, prefix)
+			if err != nil {
+				return err
+			}
+
+			reply.Launches = nil
+			for raw := iter.Next(); raw != nil; raw = iter.Next() {
+				child := raw.(*structs.Job)
+				if child.ParentID != job.ID {
+					continue
+				}
+
+				t, err := p.srv.periodicDispatcher.LaunchTime(child.ID)
+				if err != nil {
+					continue
+				}
+
+				reply.Launches = append(reply.Launches, &structs.PeriodicChildLaunch{
+					JobID:      child.ID,
+					Launch:     t,
+					Status:     child.Status,
+					SubmitTime: child.SubmitTime,
+				})
+			}
+			sort.Slice(reply.Launches, func(i, j int) bool {
+				return reply.Launches[i].Launch.After(reply.Launches[j].Launch)
+			})
+
+			// Use the last index that affected the jobs or launch tables
+			jindex, err := state.Index("jobs")
+			if err != nil {
+				return err
+			}
+			lindex, err := state.Index("periodic_launch")
+			if err != nil {
+				return err
+			}
+			reply.Index = helper.Uint64Max(jindex, lindex)
+
+			// Set the query response
+			p.srv.setQueryMeta(&reply.QueryMeta)
+			return nil
+		}}
+	return p.srv.blockingRPC(&opts)
+}
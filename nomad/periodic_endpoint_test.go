@@ -2,6 +2,7 @@ package nomad
 
 import (
 	"testing"
+	"time"
 
 	memdb "github.com/hashicorp/go-memdb"
 	msgpackrpc "github.com/hashicorp/net-rpc-msgpackrpc"
@@ -10,6 +11,7 @@ import (
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/hashicorp/nomad/testutil"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestPeriodicEndpoint_Force(t *testing.T) {
@@ -173,3 +175,119 @@ func TestPeriodicEndpoint_Force_NonPeriodic(t *testing.T) {
 		t.Fatalf("Force on non-periodic job should err")
 	}
 }
+
+func TestPeriodicEndpoint_Status(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	s1 := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer s1.Shutdown()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	// Register a periodic job so its insertion is recorded as a launch.
+	job := mock.PeriodicJob()
+	regReq := &structs.JobRegisterRequest{
+		Job: job,
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: job.Namespace,
+		},
+	}
+	var regResp structs.JobRegisterResponse
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Job.Register", regReq, &regResp))
+
+	// Force two launches of it.
+	forceReq := &structs.PeriodicForceRequest{
+		JobID: job.ID,
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: job.Namespace,
+		},
+	}
+	var forceResp structs.PeriodicForceResponse
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Periodic.Force", forceReq, &forceResp))
+	launch1 := time.Now().Add(-2 * time.Second)
+	_, err := s1.periodicDispatcher.CatchupRun(job.Namespace, job.ID, launch1)
+	require.NoError(err)
+
+	// Lookup the status
+	req := &structs.PeriodicStatusRequest{
+		JobID: job.ID,
+		Next:  3,
+		QueryOptions: structs.QueryOptions{
+			Region:    "global",
+			Namespace: job.Namespace,
+		},
+	}
+	var resp structs.PeriodicStatusResponse
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Periodic.Status", req, &resp))
+	require.NotZero(resp.Index)
+
+	// mock.PeriodicJob launches every 30 minutes
+	require.Len(resp.Next, 3)
+	require.True(resp.Next[0].After(time.Now()))
+	require.Equal(30*time.Minute, resp.Next[1].Sub(resp.Next[0]))
+	require.Equal(30*time.Minute, resp.Next[2].Sub(resp.Next[1]))
+
+	require.NotNil(resp.LastLaunch)
+	require.Len(resp.Launches, 2)
+	require.True(resp.Launches[0].Launch.After(resp.Launches[1].Launch))
+	require.Equal(launch1.Unix(), resp.Launches[1].Launch.Unix())
+	require.Equal(s1.periodicDispatcher.derivedJobID(job, launch1), resp.Launches[1].JobID)
+
+	// The number of upcoming launches is capped
+	req.Next = structs.PeriodicStatusMaxNext + 1000
+	resp = structs.PeriodicStatusResponse{}
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Periodic.Status", req, &resp))
+	require.Len(resp.Next, structs.PeriodicStatusMaxNext)
+
+	// A non-periodic job is rejected
+	nonPeriodic := mock.Job()
+	require.NoError(s1.fsm.State().UpsertJob(1000, nonPeriodic))
+	req.JobID = nonPeriodic.ID
+	err = msgpackrpc.CallWithCodec(codec, "Periodic.Status", req, &resp)
+	require.Error(err)
+	require.Contains(err.Error(), "not periodic")
+}
+
+func TestPeriodicEndpoint_Status_ACL(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	s1, root := TestACLServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer s1.Shutdown()
+	state := s1.fsm.State()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	job := mock.PeriodicJob()
+	require.NoError(state.UpsertJob(100, job))
+
+	req := &structs.PeriodicStatusRequest{
+		JobID: job.ID,
+		Next:  1,
+		QueryOptions: structs.QueryOptions{
+			Region:    "global",
+			Namespace: job.Namespace,
+		},
+	}
+
+	// Try without a token, expect failure
+	var resp structs.PeriodicStatusResponse
+	err := msgpackrpc.CallWithCodec(codec, "Periodic.Status", req, &resp)
+	require.EqualError(err, structs.ErrPermissionDenied.Error())
+
+	// Try with a token that can read jobs
+	policy := mock.NamespacePolicy(structs.DefaultNamespace, "", []string{acl.NamespaceCapabilityReadJob})
+	token := mock.CreatePolicyAndToken(t, state, 1003, "valid", policy)
+	req.AuthToken = token.SecretID
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Periodic.Status", req, &resp))
+	require.Len(resp.Next, 1)
+
+	// Try with a management token
+	req.AuthToken = root.SecretID
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Periodic.Status", req, &resp))
+}
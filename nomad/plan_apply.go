@@ -643,6 +643,14 @@ func evaluateNodePlan(snap *state.StateSnapshot, plan *structs.Plan, nodeID stri
 	// the Raft commit happens.
 	if node == nil {
 		return false, "node does not exist", nil
+	} else if node.Status == structs.NodeStatusDisconnected {
+		// Allocations on a disconnected node may only be marked as unknown
+		for _, alloc := range plan.NodeAllocation[nodeID] {
+			if alloc.ClientStatus != structs.AllocClientStatusUnknown {
+				return false, "node is disconnected and cannot receive placements", nil
+			}
+		}
+		return true, "", nil
 	} else if node.Status != structs.NodeStatusReady {
 		return false, "node is not ready for placements", nil
 	} else if node.SchedulingEligibility == structs.NodeSchedulingIneligible {
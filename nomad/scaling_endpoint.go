@@ -0,0 +1,128 @@
+package nomad
+
+import (
+	"time"
+
+	metrics "github.com/armon/go-metrics"
+	log "github.com/hashicorp/go-hclog"
+	memdb "github.com/hashicorp/go-memdb"
+	"github.com/hashicorp/nomad/acl"
+	"github.com/hashicorp/nomad/nomad/state"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// Scaling endpoint is used for reading the scaling policies of the task
+// groups. The policies are written by registering jobs.
+type Scaling struct {
+	srv    *Server
+	logger log.Logger
+}
+
+// ListPolicies is used to list the scaling policies of a namespace
+func (p *Scaling) ListPolicies(args *structs.ScalingPolicyListRequest, reply *structs.ScalingPolicyListResponse) error {
+	if done, err := p.srv.forward("Scaling.ListPolicies", args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "scaling", "list_policies"}, time.Now())
+
+	// Check for read-job or scale-job permissions
+	if aclObj, err := p.srv.ResolveToken(args.AuthToken); err != nil {
+		return err
+	} else if aclObj != nil && !allowScalingPolicyRead(aclObj, args.RequestNamespace()) {
+		return structs.ErrPermissionDenied
+	}
+
+	// Setup the blocking query
+	opts := blockingOptions{
+		queryOpts: &args.QueryOptions,
+		queryMeta: &reply.QueryMeta,
+		run: func(ws memdb.WatchSet, s *state.StateStore) error {
+			// Iterate over the policies of the namespace
+			iter, err := s.ScalingPoliciesByNamespace(ws, args.RequestNamespace())
+			if err != nil {
+				return err
+			}
+
+			// Convert all the policies to a list stub
+			reply.Policies = nil
+			for raw := iter.Next(); raw != nil; raw = iter.Next() {
+				policy := raw.(*structs.ScalingPolicy)
+				reply.Policies = append(reply.Policies, policy.Stub())
+			}
+
+			// Use the last index that affected the scaling policy table
+			index, err := s.Index("scaling_policy")
+			if err != nil {
+				return err
+			}
+
+			// Ensure we never set the index to zero, otherwise a blocking query cannot be used.
+			// We floor the index at one, since realistically the first write must have a higher index.
+			if index == 0 {
+				index = 1
+			}
+			reply.Index = index
+			return nil
+		}}
+	return p.srv.blockingRPC(&opts)
+}
+
+// GetPolicy is used to get a specific scaling policy
+func (p *Scaling) GetPolicy(args *structs.ScalingPolicySpecificRequest, reply *structs.SingleScalingPolicyResponse) error {
+	if done, err := p.srv.forward("Scaling.GetPolicy", args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "scaling", "get_policy"}, time.Now())
+
+	// Check for read-job or scale-job permissions
+	if aclObj, err := p.srv.ResolveToken(args.AuthToken); err != nil {
+		return err
+	} else if aclObj != nil && !allowScalingPolicyRead(aclObj, args.RequestNamespace()) {
+		return structs.ErrPermissionDenied
+	}
+
+	// Setup the blocking query
+	opts := blockingOptions{
+		queryOpts: &args.QueryOptions,
+		queryMeta: &reply.QueryMeta,
+		run: func(ws memdb.WatchSet, s *state.StateStore) error {
+			// Look for the policy
+			out, err := s.ScalingPolicyByID(ws, args.ID)
+			if err != nil {
+				return err
+			}
+
+			// Policies of other namespaces are not visible
+			if out != nil && out.Namespace != args.RequestNamespace() {
+				out = nil
+			}
+
+			// Setup the output
+			reply.Policy = out
+			if out != nil {
+				reply.Index = out.ModifyIndex
+			} else {
+				// Use the last index that affected the scaling policy table
+				index, err := s.Index("scaling_policy")
+				if err != nil {
+					return err
+				}
+
+				// Ensure we never set the index to zero, otherwise a blocking query cannot be used.
+				// We floor the index at one, since realistically the first write must have a higher index.
+				if index == 0 {
+					index = 1
+				}
+				reply.Index = index
+			}
+			return nil
+		}}
+	return p.srv.blockingRPC(&opts)
+}
+
+// allowScalingPolicyRead returns whether the ACL allows reading the scaling
+// policies of the namespace.
+func allowScalingPolicyRead(aclObj *acl.ACL, namespace string) bool {
+	return aclObj.AllowNsOp(namespace, acl.NamespaceCapabilityReadJob) ||
+		aclObj.AllowNsOp(namespace, acl.NamespaceCapabilityScaleJob)
+}
@@ -0,0 +1,190 @@
+package nomad
+
+import (
+	"testing"
+	"time"
+
+	msgpackrpc "github.com/hashicorp/net-rpc-msgpackrpc"
+	"github.com/hashicorp/nomad/acl"
+	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScalingEndpoint_GetPolicy(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	s1 := TestServer(t, nil)
+	defer s1.Shutdown()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	job := mock.Job()
+	job.TaskGroups[0].Scaling = &structs.ScalingPolicy{
+		ID:      uuid.Generate(),
+		Min:     1,
+		Max:     20,
+		Enabled: true,
+	}
+	require.NoError(s1.fsm.State().UpsertJob(1000, job))
+
+	// Lookup the policy
+	get := &structs.ScalingPolicySpecificRequest{
+		ID: job.TaskGroups[0].Scaling.ID,
+		QueryOptions: structs.QueryOptions{
+			Region:    "global",
+			Namespace: job.Namespace,
+		},
+	}
+	var resp structs.SingleScalingPolicyResponse
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Scaling.GetPolicy", get, &resp))
+	require.EqualValues(1000, resp.Index)
+	require.NotNil(resp.Policy)
+	require.Equal(job.ID, resp.Policy.Target[structs.ScalingTargetJob])
+	require.EqualValues(20, resp.Policy.Max)
+
+	// Lookup a missing policy
+	get.ID = uuid.Generate()
+	var resp2 structs.SingleScalingPolicyResponse
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Scaling.GetPolicy", get, &resp2))
+	require.EqualValues(1000, resp2.Index)
+	require.Nil(resp2.Policy)
+}
+
+func TestScalingEndpoint_GetPolicy_Blocking(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	s1 := TestServer(t, nil)
+	defer s1.Shutdown()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+	state := s1.fsm.State()
+
+	job := mock.Job()
+	job.TaskGroups[0].Scaling = &structs.ScalingPolicy{
+		ID:  uuid.Generate(),
+		Max: 10,
+	}
+	require.NoError(state.UpsertJob(100, job))
+
+	// Update the policy after the query started
+	job2 := job.Copy()
+	job2.TaskGroups[0].Scaling.Max = 20
+	time.AfterFunc(100*time.Millisecond, func() {
+		require.NoError(state.UpsertJob(200, job2))
+	})
+
+	get := &structs.ScalingPolicySpecificRequest{
+		ID: job.TaskGroups[0].Scaling.ID,
+		QueryOptions: structs.QueryOptions{
+			Region:        "global",
+			Namespace:     job.Namespace,
+			MinQueryIndex: 150,
+		},
+	}
+	start := time.Now()
+	var resp structs.SingleScalingPolicyResponse
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Scaling.GetPolicy", get, &resp))
+	if elapsed := time.Since(start); elapsed < 100*time.Millisecond {
+		t.Fatalf("should block (returned in %s) %#v", elapsed, resp)
+	}
+	require.EqualValues(200, resp.Index)
+	require.EqualValues(20, resp.Policy.Max)
+}
+
+func TestScalingEndpoint_ListPolicies(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	s1 := TestServer(t, nil)
+	defer s1.Shutdown()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+	state := s1.fsm.State()
+
+	// List without any policies
+	get := &structs.ScalingPolicyListRequest{
+		QueryOptions: structs.QueryOptions{
+			Region:    "global",
+			Namespace: structs.DefaultNamespace,
+		},
+	}
+	var resp structs.ScalingPolicyListResponse
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Scaling.ListPolicies", get, &resp))
+	require.EqualValues(1, resp.Index)
+	require.Empty(resp.Policies)
+
+	j1 := mock.Job()
+	j1.TaskGroups[0].Scaling = &structs.ScalingPolicy{ID: uuid.Generate(), Max: 10}
+	j2 := mock.Job()
+	j2.TaskGroups[0].Scaling = &structs.ScalingPolicy{ID: uuid.Generate(), Max: 10}
+	j3 := mock.Job()
+	require.NoError(state.UpsertJob(1000, j1))
+	require.NoError(state.UpsertJob(1001, j2))
+	require.NoError(state.UpsertJob(1002, j3))
+
+	var resp2 structs.ScalingPolicyListResponse
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Scaling.ListPolicies", get, &resp2))
+	require.EqualValues(1001, resp2.Index)
+	require.Len(resp2.Policies, 2)
+
+	// Other namespaces don't have the policies
+	ns := mock.Namespace()
+	require.NoError(state.UpsertNamespaces(1003, []*structs.Namespace{ns}))
+	get.Namespace = ns.Name
+	var resp3 structs.ScalingPolicyListResponse
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Scaling.ListPolicies", get, &resp3))
+	require.Empty(resp3.Policies)
+}
+
+func TestScalingEndpoint_ListPolicies_ACL(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	s1, root := TestACLServer(t, nil)
+	defer s1.Shutdown()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+	state := s1.fsm.State()
+
+	job := mock.Job()
+	job.TaskGroups[0].Scaling = &structs.ScalingPolicy{ID: uuid.Generate(), Max: 10}
+	require.NoError(state.UpsertJob(1000, job))
+
+	get := &structs.ScalingPolicyListRequest{
+		QueryOptions: structs.QueryOptions{
+			Region:    "global",
+			Namespace: structs.DefaultNamespace,
+		},
+	}
+
+	// List without a token
+	var resp structs.ScalingPolicyListResponse
+	err := msgpackrpc.CallWithCodec(codec, "Scaling.ListPolicies", get, &resp)
+	require.Error(err)
+	require.Contains(err.Error(), structs.ErrPermissionDenied.Error())
+
+	// List with a token that can't read jobs
+	invalid := mock.CreatePolicyAndToken(t, state, 1001, "test-invalid",
+		mock.NamespacePolicy(structs.DefaultNamespace, "", []string{acl.NamespaceCapabilityListJobs}))
+	get.AuthToken = invalid.SecretID
+	err = msgpackrpc.CallWithCodec(codec, "Scaling.ListPolicies", get, &resp)
+	require.Error(err)
+	require.Contains(err.Error(), structs.ErrPermissionDenied.Error())
+
+	// List with read-job and scale-job tokens
+	for i, capability := range []string{acl.NamespaceCapabilityReadJob, acl.NamespaceCapabilityScaleJob} {
+		token := mock.CreatePolicyAndToken(t, state, uint64(1002+i), "test-"+capability,
+			mock.NamespacePolicy(structs.DefaultNamespace, "", []string{capability}))
+		get.AuthToken = token.SecretID
+		var resp2 structs.ScalingPolicyListResponse
+		require.NoError(msgpackrpc.CallWithCodec(codec, "Scaling.ListPolicies", get, &resp2))
+		require.Len(resp2.Policies, 1)
+	}
+
+	// List with the management token
+	get.AuthToken = root.SecretID
+	var resp3 structs.ScalingPolicyListResponse
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Scaling.ListPolicies", get, &resp3))
+	require.Len(resp3.Policies, 1)
+}
@@ -2,8 +2,10 @@ package nomad
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	metrics "github.com/armon/go-metrics"
 	log "github.com/hashicorp/go-hclog"
@@ -18,6 +20,10 @@ const (
 	// truncateLimit is the maximum number of matches that will be returned for a
 	// prefix for a specific context
 	truncateLimit = 20
+
+	// fuzzyMinTextLength is the minimum length of the text of a fuzzy search.
+	// Shorter text matches too many fields to be useful.
+	fuzzyMinTextLength = 2
 )
 
 var (
@@ -29,6 +35,19 @@ var (
 		structs.Nodes,
 		structs.Evals,
 		structs.Deployments,
+		structs.Namespaces,
+	}
+
+	// fuzzyContexts are the contexts which are searched to find fields
+	// matching the text of a fuzzy search
+	fuzzyContexts = []structs.Context{
+		structs.Jobs,
+		structs.Groups,
+		structs.Tasks,
+		structs.Services,
+		structs.Images,
+		structs.Nodes,
+		structs.NodeMeta,
 	}
 )
 
@@ -61,6 +80,8 @@ func (s *Search) getMatches(iter memdb.ResultIterator, prefix string) ([]string,
 			id = raw.(*structs.Node).ID
 		case *structs.Deployment:
 			id = raw.(*structs.Deployment).ID
+		case *structs.Namespace:
+			id = raw.(*structs.Namespace).Name
 		default:
 			matchID, ok := getEnterpriseMatch(raw)
 			if !ok {
@@ -95,15 +116,32 @@ func getResourceIter(context structs.Context, aclObj *acl.ACL, namespace, prefix
 		return state.NodesByIDPrefix(ws, prefix)
 	case structs.Deployments:
 		return state.DeploymentsByIDPrefix(ws, namespace, prefix)
+	case structs.Namespaces:
+		iter, err := state.NamespacesByNamePrefix(ws, prefix)
+		if err != nil {
+			return nil, err
+		}
+		if aclObj == nil {
+			return iter, nil
+		}
+		return memdb.NewFilterIterator(iter, namespaceFilter(aclObj)), nil
 	default:
 		return getEnterpriseResourceIter(context, aclObj, namespace, prefix, ws, state)
 	}
 }
 
+// namespaceFilter returns a filter function that filters all namespaces not
+// reachable by the provided ACL.
+func namespaceFilter(aclObj *acl.ACL) memdb.FilterFunc {
+	return func(v interface{}) bool {
+		return !aclObj.AllowNamespace(v.(*structs.Namespace).Name)
+	}
+}
+
 // If the length of a prefix is odd, return a subset to the last even character
-// This only applies to UUIDs, jobs are excluded
+// This only applies to UUIDs, jobs and namespaces are excluded
 func roundUUIDDownIfOdd(prefix string, context structs.Context) string {
-	if context == structs.Jobs {
+	if context == structs.Jobs || context == structs.Namespaces {
 		return prefix
 	}
 
@@ -194,3 +232,248 @@ func (s *Search) PrefixSearch(args *structs.SearchRequest, reply *structs.Search
 		}}
 	return s.srv.blockingRPC(&opts)
 }
+
+// fuzzyResult is a field matching a fuzzy search along with how well it
+// matched.
+type fuzzyResult struct {
+	match structs.FuzzyMatch
+
+	// fuzzy is set if the text is not a substring of the field but its
+	// characters appear in the field in order.
+	fuzzy bool
+
+	// pos is the position in the field at which the match ends for fuzzy
+	// matches or starts for substring matches.
+	pos int
+}
+
+// fuzzyMatcher collects the fields matching the text of a fuzzy search for
+// each of the searched contexts.
+type fuzzyMatcher struct {
+	text    string
+	results map[structs.Context][]fuzzyResult
+}
+
+func newFuzzyMatcher(text string, contexts []structs.Context) *fuzzyMatcher {
+	m := &fuzzyMatcher{
+		text:    strings.ToLower(text),
+		results: make(map[structs.Context][]fuzzyResult, len(contexts)),
+	}
+	for _, ctx := range contexts {
+		m.results[ctx] = nil
+	}
+	return m
+}
+
+// searches returns whether the context is being searched.
+func (m *fuzzyMatcher) searches(ctx structs.Context) bool {
+	_, ok := m.results[ctx]
+	return ok
+}
+
+// match records the field if the context is being searched and the field
+// matches the text.
+func (m *fuzzyMatcher) match(ctx structs.Context, field string, scope ...string) {
+	if !m.searches(ctx) || field == "" {
+		return
+	}
+
+	value := strings.ToLower(field)
+	result := fuzzyResult{
+		match: structs.FuzzyMatch{
+			ID:    field,
+			Scope: scope,
+		},
+	}
+
+	if i := strings.Index(value, m.text); i >= 0 {
+		result.pos = i
+	} else {
+		// Check if the characters of the text appear in order
+		pos := 0
+		for _, r := range m.text {
+			i := strings.IndexRune(value[pos:], r)
+			if i < 0 {
+				return
+			}
+			pos += i + utf8.RuneLen(r)
+		}
+		result.fuzzy = true
+		result.pos = pos
+	}
+
+	m.results[ctx] = append(m.results[ctx], result)
+}
+
+// matchJob records the fields of the job matching the text.
+func (m *fuzzyMatcher) matchJob(job *structs.Job) {
+	m.match(structs.Jobs, job.Name, job.Namespace, job.ID)
+
+	for _, tg := range job.TaskGroups {
+		m.match(structs.Groups, tg.Name, job.Namespace, job.ID)
+		for _, service := range tg.Services {
+			m.match(structs.Services, service.Name, job.Namespace, job.ID, tg.Name)
+		}
+
+		for _, task := range tg.Tasks {
+			m.match(structs.Tasks, task.Name, job.Namespace, job.ID, tg.Name)
+			for _, service := range task.Services {
+				m.match(structs.Services, service.Name, job.Namespace, job.ID, tg.Name, task.Name)
+			}
+			if image, ok := task.Config["image"].(string); ok {
+				m.match(structs.Images, image, job.Namespace, job.ID, tg.Name, task.Name)
+			}
+		}
+	}
+}
+
+// matchNode records the fields of the node matching the text.
+func (m *fuzzyMatcher) matchNode(node *structs.Node) {
+	m.match(structs.Nodes, node.Name, node.ID)
+	for k, v := range node.Meta {
+		m.match(structs.NodeMeta, fmt.Sprintf("%s=%s", k, v), node.ID)
+	}
+}
+
+// populate sets the best matches for each searched context on the reply.
+// Substring matches rank before fuzzy matches, and matches closer to the
+// start of the field rank first.
+func (m *fuzzyMatcher) populate(reply *structs.FuzzySearchResponse) {
+	for ctx, results := range m.results {
+		sort.SliceStable(results, func(i, j int) bool {
+			a, b := results[i], results[j]
+			switch {
+			case a.fuzzy != b.fuzzy:
+				return !a.fuzzy
+			case a.pos != b.pos:
+				return a.pos < b.pos
+			case a.match.ID != b.match.ID:
+				return a.match.ID < b.match.ID
+			default:
+				return strings.Join(a.match.Scope, "/") < strings.Join(b.match.Scope, "/")
+			}
+		})
+
+		reply.Truncations[ctx] = len(results) > truncateLimit
+		if len(results) > truncateLimit {
+			results = results[:truncateLimit]
+		}
+
+		matches := make([]structs.FuzzyMatch, 0, len(results))
+		for _, result := range results {
+			matches = append(matches, result.match)
+		}
+		reply.Matches[ctx] = matches
+	}
+}
+
+// fuzzySearchContexts returns the contexts a fuzzy search of the given context
+// should search that the aclObj is valid for.
+func fuzzySearchContexts(aclObj *acl.ACL, namespace string, context structs.Context) ([]structs.Context, error) {
+	switch context {
+	case "", structs.All:
+		var available []structs.Context
+		for _, ctx := range fuzzyContexts {
+			available = append(available, searchContexts(aclObj, namespace, ctx)...)
+		}
+		return available, nil
+	}
+
+	for _, ctx := range fuzzyContexts {
+		if ctx == context {
+			return searchContexts(aclObj, namespace, context), nil
+		}
+	}
+	return nil, fmt.Errorf("context must be one of %v or 'all' for all contexts; got %q", fuzzyContexts, context)
+}
+
+// FuzzySearch is used to list the fields of jobs and nodes containing the
+// given text, such as job, group, task, service, image and node names.
+func (s *Search) FuzzySearch(args *structs.FuzzySearchRequest, reply *structs.FuzzySearchResponse) error {
+	if done, err := s.srv.forward("Search.FuzzySearch", args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "search", "fuzzy_search"}, time.Now())
+
+	aclObj, err := s.srv.ResolveToken(args.AuthToken)
+	if err != nil {
+		return err
+	}
+
+	namespace := args.RequestNamespace()
+
+	// Require either node:read or namespace:read-job
+	if !anySearchPerms(aclObj, namespace, args.Context) {
+		return structs.ErrPermissionDenied
+	}
+
+	if l := len(args.Text); l < fuzzyMinTextLength {
+		return fmt.Errorf("fuzzy search text must be at least %d characters; got %d", fuzzyMinTextLength, l)
+	}
+
+	contexts, err := fuzzySearchContexts(aclObj, namespace, args.Context)
+	if err != nil {
+		return err
+	}
+
+	// Setup the blocking query
+	opts := blockingOptions{
+		queryMeta: &reply.QueryMeta,
+		queryOpts: &args.QueryOptions,
+		run: func(ws memdb.WatchSet, state *state.StateStore) error {
+			matcher := newFuzzyMatcher(args.Text, contexts)
+			searchJobs := false
+			searchNodes := false
+			for _, ctx := range contexts {
+				switch ctx {
+				case structs.Nodes, structs.NodeMeta:
+					searchNodes = true
+				default:
+					searchJobs = true
+				}
+			}
+
+			reply.Index = 0
+			if searchJobs {
+				iter, err := state.JobsByNamespace(ws, namespace)
+				if err != nil {
+					return err
+				}
+				for raw := iter.Next(); raw != nil; raw = iter.Next() {
+					matcher.matchJob(raw.(*structs.Job))
+				}
+
+				index, err := state.Index("jobs")
+				if err != nil {
+					return err
+				}
+				reply.Index = index
+			}
+
+			if searchNodes {
+				iter, err := state.Nodes(ws)
+				if err != nil {
+					return err
+				}
+				for raw := iter.Next(); raw != nil; raw = iter.Next() {
+					matcher.matchNode(raw.(*structs.Node))
+				}
+
+				index, err := state.Index("nodes")
+				if err != nil {
+					return err
+				}
+				if index > reply.Index {
+					reply.Index = index
+				}
+			}
+
+			reply.Matches = make(map[structs.Context][]structs.FuzzyMatch, len(contexts))
+			reply.Truncations = make(map[structs.Context]bool, len(contexts))
+			matcher.populate(reply)
+
+			s.srv.setQueryMeta(&reply.QueryMeta)
+			return nil
+		}}
+	return s.srv.blockingRPC(&opts)
+}
@@ -45,18 +45,24 @@ func anySearchPerms(aclObj *acl.ACL, namespace string, context structs.Context)
 	nodeRead := aclObj.AllowNodeRead()
 	jobRead := aclObj.AllowNsOp(namespace, acl.NamespaceCapabilityReadJob)
 	if !nodeRead && !jobRead {
-		return false
+		// Namespaces are filtered by the ACL rather than the capabilities
+		// in the searched namespace
+		return context == structs.Namespaces
 	}
 
 	// Reject requests that explicitly specify a disallowed context. This
 	// should give the user better feedback then simply filtering out all
 	// results and returning an empty list.
-	if !nodeRead && context == structs.Nodes {
-		return false
+	if !nodeRead {
+		switch context {
+		case structs.Nodes, structs.NodeMeta:
+			return false
+		}
 	}
 	if !jobRead {
 		switch context {
-		case structs.Allocs, structs.Deployments, structs.Evals, structs.Jobs:
+		case structs.Allocs, structs.Deployments, structs.Evals, structs.Jobs,
+			structs.Groups, structs.Tasks, structs.Services, structs.Images:
 			return false
 		}
 	}
@@ -88,14 +94,17 @@ func searchContexts(aclObj *acl.ACL, namespace string, context structs.Context)
 	available := make([]structs.Context, 0, len(all))
 	for _, c := range all {
 		switch c {
-		case structs.Allocs, structs.Jobs, structs.Evals, structs.Deployments:
+		case structs.Allocs, structs.Jobs, structs.Evals, structs.Deployments,
+			structs.Groups, structs.Tasks, structs.Services, structs.Images:
 			if jobRead {
 				available = append(available, c)
 			}
-		case structs.Nodes:
+		case structs.Nodes, structs.NodeMeta:
 			if aclObj.AllowNodeRead() {
 				available = append(available, c)
 			}
+		case structs.Namespaces:
+			available = append(available, c)
 		}
 	}
 	return available
@@ -1,6 +1,7 @@
 package nomad
 
 import (
+	"fmt"
 	"strconv"
 	"strings"
 	"testing"
@@ -11,6 +12,7 @@ import (
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/hashicorp/nomad/testutil"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 const jobIndex = 1000
@@ -556,6 +558,48 @@ func TestSearch_PrefixSearch_Deployment(t *testing.T) {
 	assert.Equal(uint64(2000), resp.Index)
 }
 
+func TestSearch_PrefixSearch_Namespace(t *testing.T) {
+	require := require.New(t)
+	t.Parallel()
+	s, root := TestACLServer(t, func(c *Config) {
+		c.NumSchedulers = 0
+	})
+
+	defer s.Shutdown()
+	codec := rpcClient(t, s)
+	testutil.WaitForLeader(t, s.RPC)
+
+	ns1 := mock.Namespace()
+	ns2 := mock.Namespace()
+	ns1.Name = "team-api"
+	ns2.Name = "team-web"
+	state := s.fsm.State()
+	require.NoError(state.UpsertNamespaces(2000, []*structs.Namespace{ns1, ns2}))
+
+	req := &structs.SearchRequest{
+		Prefix:  "team",
+		Context: structs.Namespaces,
+		QueryOptions: structs.QueryOptions{
+			Region:    "global",
+			AuthToken: root.SecretID,
+		},
+	}
+
+	var resp structs.SearchResponse
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Search.PrefixSearch", req, &resp))
+	require.Equal([]string{ns1.Name, ns2.Name}, resp.Matches[structs.Namespaces])
+	require.False(resp.Truncations[structs.Namespaces])
+	require.Equal(uint64(2000), resp.Index)
+
+	// Namespaces the token has no capabilities in are filtered out
+	token := mock.CreatePolicyAndToken(t, state, 2001, "test-valid",
+		mock.NamespacePolicy(ns2.Name, "", []string{acl.NamespaceCapabilityReadJob}))
+	req.AuthToken = token.SecretID
+	var resp2 structs.SearchResponse
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Search.PrefixSearch", req, &resp2))
+	require.Equal([]string{ns2.Name}, resp2.Matches[structs.Namespaces])
+}
+
 func TestSearch_PrefixSearch_AllContext(t *testing.T) {
 	assert := assert.New(t)
 	t.Parallel()
@@ -756,3 +800,246 @@ func TestSearch_PrefixSearch_MultiRegion(t *testing.T) {
 	assert.Equal(job.ID, resp.Matches[structs.Jobs][0])
 	assert.Equal(uint64(jobIndex), resp.Index)
 }
+
+func TestSearch_FuzzySearch_Jobs(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	s := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0
+	})
+	defer s.Shutdown()
+	codec := rpcClient(t, s)
+	testutil.WaitForLeader(t, s.RPC)
+
+	job := mock.Job()
+	job.Name = "payments-api"
+	job.TaskGroups[0].Name = "payments"
+	task := job.TaskGroups[0].Tasks[0]
+	task.Name = "payments-server"
+	task.Config["image"] = "registry.example.com/payments:1.2"
+	task.Services[0].Name = "payments-http"
+	require.NoError(s.fsm.State().UpsertJob(jobIndex, job))
+
+	req := &structs.FuzzySearchRequest{
+		Text:    "PAYMENTS",
+		Context: structs.All,
+		QueryOptions: structs.QueryOptions{
+			Region:    "global",
+			Namespace: job.Namespace,
+		},
+	}
+
+	var resp structs.FuzzySearchResponse
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Search.FuzzySearch", req, &resp))
+
+	ns := job.Namespace
+	require.Equal([]structs.FuzzyMatch{{ID: "payments-api", Scope: []string{ns, job.ID}}},
+		resp.Matches[structs.Jobs])
+	require.Equal([]structs.FuzzyMatch{{ID: "payments", Scope: []string{ns, job.ID}}},
+		resp.Matches[structs.Groups])
+	require.Equal([]structs.FuzzyMatch{{ID: "payments-server", Scope: []string{ns, job.ID, "payments"}}},
+		resp.Matches[structs.Tasks])
+	require.Equal([]structs.FuzzyMatch{{ID: "payments-http", Scope: []string{ns, job.ID, "payments", "payments-server"}}},
+		resp.Matches[structs.Services])
+	require.Equal([]structs.FuzzyMatch{{ID: "registry.example.com/payments:1.2", Scope: []string{ns, job.ID, "payments", "payments-server"}}},
+		resp.Matches[structs.Images])
+	require.Empty(resp.Matches[structs.Nodes])
+	require.Contains(resp.Matches, structs.NodeMeta)
+	require.Equal(uint64(jobIndex), resp.Index)
+
+	// Substring matches rank before fuzzy matches
+	req.Text = "pmts"
+	req.Context = structs.Tasks
+	resp = structs.FuzzySearchResponse{}
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Search.FuzzySearch", req, &resp))
+	require.Len(resp.Matches, 1)
+	require.Equal([]structs.FuzzyMatch{{ID: "payments-server", Scope: []string{ns, job.ID, "payments"}}},
+		resp.Matches[structs.Tasks])
+}
+
+func TestSearch_FuzzySearch_Nodes(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	s := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0
+	})
+	defer s.Shutdown()
+	codec := rpcClient(t, s)
+	testutil.WaitForLeader(t, s.RPC)
+
+	node := mock.Node()
+	node.Name = "worker-east-1"
+	node.Meta = map[string]string{"rack": "east-r12"}
+	require.NoError(s.fsm.State().UpsertNode(1001, node))
+
+	req := &structs.FuzzySearchRequest{
+		Text:    "east",
+		Context: structs.All,
+		QueryOptions: structs.QueryOptions{
+			Region:    "global",
+			Namespace: structs.DefaultNamespace,
+		},
+	}
+
+	var resp structs.FuzzySearchResponse
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Search.FuzzySearch", req, &resp))
+	require.Equal([]structs.FuzzyMatch{{ID: "worker-east-1", Scope: []string{node.ID}}},
+		resp.Matches[structs.Nodes])
+	require.Equal([]structs.FuzzyMatch{{ID: "rack=east-r12", Scope: []string{node.ID}}},
+		resp.Matches[structs.NodeMeta])
+	require.Equal(uint64(1001), resp.Index)
+}
+
+func TestSearch_FuzzySearch_Ranking(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	s := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0
+	})
+	defer s.Shutdown()
+	codec := rpcClient(t, s)
+	testutil.WaitForLeader(t, s.RPC)
+	state := s.fsm.State()
+
+	for i, name := range []string{"my-cache", "cache", "c-a-c-h-e", "redis-cache"} {
+		job := mock.Job()
+		job.Name = name
+		require.NoError(state.UpsertJob(uint64(1000+i), job))
+	}
+	for i := 0; i < truncateLimit; i++ {
+		job := mock.Job()
+		job.Name = fmt.Sprintf("unrelated-cache-%02d", i)
+		require.NoError(state.UpsertJob(uint64(2000+i), job))
+	}
+
+	req := &structs.FuzzySearchRequest{
+		Text:    "cache",
+		Context: structs.Jobs,
+		QueryOptions: structs.QueryOptions{
+			Region:    "global",
+			Namespace: structs.DefaultNamespace,
+		},
+	}
+
+	var resp structs.FuzzySearchResponse
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Search.FuzzySearch", req, &resp))
+
+	matches := resp.Matches[structs.Jobs]
+	require.Len(matches, truncateLimit)
+	require.True(resp.Truncations[structs.Jobs])
+	require.Equal("cache", matches[0].ID)
+	require.Equal("my-cache", matches[1].ID)
+	require.Equal("redis-cache", matches[2].ID)
+	require.Equal("unrelated-cache-00", matches[3].ID)
+}
+
+func TestSearch_FuzzySearch_Invalid(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	s := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0
+	})
+	defer s.Shutdown()
+	codec := rpcClient(t, s)
+	testutil.WaitForLeader(t, s.RPC)
+
+	req := &structs.FuzzySearchRequest{
+		Text:    "a",
+		Context: structs.Jobs,
+		QueryOptions: structs.QueryOptions{
+			Region:    "global",
+			Namespace: structs.DefaultNamespace,
+		},
+	}
+
+	var resp structs.FuzzySearchResponse
+	err := msgpackrpc.CallWithCodec(codec, "Search.FuzzySearch", req, &resp)
+	require.Error(err)
+	require.Contains(err.Error(), "at least 2 characters")
+
+	req.Text = "example"
+	req.Context = structs.Evals
+	err = msgpackrpc.CallWithCodec(codec, "Search.FuzzySearch", req, &resp)
+	require.Error(err)
+	require.Contains(err.Error(), "context must be one of")
+}
+
+func TestSearch_FuzzySearch_ACL(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	s, root := TestACLServer(t, func(c *Config) {
+		c.NumSchedulers = 0
+	})
+	defer s.Shutdown()
+	codec := rpcClient(t, s)
+	testutil.WaitForLeader(t, s.RPC)
+	state := s.fsm.State()
+
+	job := mock.Job()
+	job.Name = "example-job"
+	require.NoError(state.UpsertJob(1000, job))
+	node := mock.Node()
+	node.Name = "example-node"
+	require.NoError(state.UpsertNode(1001, node))
+
+	req := &structs.FuzzySearchRequest{
+		Text:    "example",
+		Context: structs.Jobs,
+		QueryOptions: structs.QueryOptions{
+			Region:    "global",
+			Namespace: job.Namespace,
+		},
+	}
+
+	// Try without a token and expect failure
+	{
+		var resp structs.FuzzySearchResponse
+		err := msgpackrpc.CallWithCodec(codec, "Search.FuzzySearch", req, &resp)
+		require.EqualError(err, structs.ErrPermissionDenied.Error())
+	}
+
+	// Try with a node:read token and expect failure due to Jobs being the context
+	nodeToken := mock.CreatePolicyAndToken(t, state, 1003, "test-node", mock.NodePolicy(acl.PolicyRead))
+	{
+		req.AuthToken = nodeToken.SecretID
+		var resp structs.FuzzySearchResponse
+		err := msgpackrpc.CallWithCodec(codec, "Search.FuzzySearch", req, &resp)
+		require.EqualError(err, structs.ErrPermissionDenied.Error())
+	}
+
+	// Try with a node:read token and expect only node matches for All
+	{
+		req.Context = structs.All
+		var resp structs.FuzzySearchResponse
+		require.NoError(msgpackrpc.CallWithCodec(codec, "Search.FuzzySearch", req, &resp))
+		require.Len(resp.Matches[structs.Nodes], 1)
+		require.NotContains(resp.Matches, structs.Jobs)
+		require.NotContains(resp.Matches, structs.Tasks)
+	}
+
+	// Try with a read-job token and expect only job matches for All
+	{
+		jobToken := mock.CreatePolicyAndToken(t, state, 1005, "test-job",
+			mock.NamespacePolicy(structs.DefaultNamespace, "", []string{acl.NamespaceCapabilityReadJob}))
+		req.AuthToken = jobToken.SecretID
+		var resp structs.FuzzySearchResponse
+		require.NoError(msgpackrpc.CallWithCodec(codec, "Search.FuzzySearch", req, &resp))
+		require.Len(resp.Matches[structs.Jobs], 1)
+		require.NotContains(resp.Matches, structs.Nodes)
+		require.NotContains(resp.Matches, structs.NodeMeta)
+	}
+
+	// Try with a management token and expect all matches
+	{
+		req.AuthToken = root.SecretID
+		var resp structs.FuzzySearchResponse
+		require.NoError(msgpackrpc.CallWithCodec(codec, "Search.FuzzySearch", req, &resp))
+		require.Len(resp.Matches[structs.Jobs], 1)
+		require.Len(resp.Matches[structs.Nodes], 1)
+	}
+}
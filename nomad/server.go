@@ -191,6 +191,12 @@ type Server struct {
 	// periodicDispatcher is used to track and create evaluations for periodic jobs.
 	periodicDispatcher *PeriodicDispatch
 
+	// dispatchLock serializes dispatches that are checked against the
+	// state: the decision to queue or register dispatched jobs of
+	// parameterized jobs with a concurrency limit, and the lookup of
+	// idempotency tokens.
+	dispatchLock sync.Mutex
+
 	// planner is used to mange the submitted allocation plans that are waiting
 	// to be accessed by the leader
 	*planner
@@ -205,6 +211,10 @@ type Server struct {
 	// vault is the client for communicating with Vault.
 	vault VaultClient
 
+	// admissionWebhooks are the external admission controllers called when
+	// jobs are registered or planned.
+	admissionWebhooks []*jobAdmissionWebhook
+
 	// Worker used for processing
 	workers []*Worker
 
@@ -234,24 +244,28 @@ type Server struct {
 
 // Holds the RPC endpoints
 type endpoints struct {
-	Status     *Status
-	Node       *Node
-	Job        *Job
-	Eval       *Eval
-	Plan       *Plan
-	Alloc      *Alloc
-	Deployment *Deployment
-	Region     *Region
-	Search     *Search
-	Periodic   *Periodic
-	System     *System
-	Operator   *Operator
-	ACL        *ACL
-	Enterprise *EnterpriseEndpoints
+	Status          *Status
+	Node            *Node
+	Job             *Job
+	Eval            *Eval
+	Plan            *Plan
+	Alloc           *Alloc
+	Deployment      *Deployment
+	Region          *Region
+	Search          *Search
+	Periodic        *Periodic
+	System          *System
+	Operator        *Operator
+	ACL             *ACL
+	Namespace       *Namespace
+	AdmissionPolicy *AdmissionPolicy
+	Scaling         *Scaling
+	Enterprise      *EnterpriseEndpoints
 
 	// Client endpoints
 	ClientStats       *ClientStats
 	FileSystem        *FileSystem
+	Agent             *Agent
 	ClientAllocations *ClientAllocations
 }
 
@@ -341,6 +355,13 @@ func NewServer(config *Config, consulCatalog consul.CatalogAPI) (*Server, error)
 		return nil, fmt.Errorf("Failed to setup Vault client: %v", err)
 	}
 
+	// Setup the admission webhooks
+	if err := s.setupAdmissionWebhooks(); err != nil {
+		s.Shutdown()
+		s.logger.Error("failed to setup admission webhooks", "error", err)
+		return nil, fmt.Errorf("Failed to setup admission webhooks: %v", err)
+	}
+
 	// Initialize the RPC layer
 	if err := s.setupRPC(tlsWrap); err != nil {
 		s.Shutdown()
@@ -896,6 +917,20 @@ func (s *Server) setupBootstrapHandler() error {
 	return nil
 }
 
+// setupAdmissionWebhooks creates the admission controllers of the configured
+// admission webhooks.
+func (s *Server) setupAdmissionWebhooks() error {
+	logger := s.logger.Named("admission_webhook")
+	for _, c := range s.config.AdmissionWebhooks {
+		webhook, err := newJobAdmissionWebhook(c, logger)
+		if err != nil {
+			return fmt.Errorf("admission webhook %q: %v", c.Name, err)
+		}
+		s.admissionWebhooks = append(s.admissionWebhooks, webhook)
+	}
+	return nil
+}
+
 // setupConsulSyncer creates Server-mode consul.Syncer which periodically
 // executes callbacks on a fixed interval.
 func (s *Server) setupConsulSyncer() error {
@@ -919,9 +954,15 @@ func (s *Server) setupDeploymentWatcher() error {
 		apply: s.raftApply,
 	}
 
+	// Create the RPC shim used to coordinate the deployments of multiregion
+	// jobs across regions
+	rpcShim := &deploymentWatcherRPCShim{
+		srv: s,
+	}
+
 	// Create the deployment watcher
 	s.deploymentWatcher = deploymentwatcher.NewDeploymentsWatcher(
-		s.logger, raftShim,
+		s.logger, raftShim, rpcShim,
 		deploymentwatcher.LimitStateQueriesPerSecond,
 		deploymentwatcher.CrossDeploymentUpdateBatchDuration)
 
@@ -1034,6 +1075,9 @@ func (s *Server) setupRpcServer(server *rpc.Server, ctx *RPCContext) {
 		s.staticEndpoints.Status = &Status{srv: s, logger: s.logger.Named("status")}
 		s.staticEndpoints.System = &System{srv: s, logger: s.logger.Named("system")}
 		s.staticEndpoints.Search = &Search{srv: s, logger: s.logger.Named("search")}
+		s.staticEndpoints.Namespace = &Namespace{srv: s, logger: s.logger.Named("namespace")}
+		s.staticEndpoints.AdmissionPolicy = &AdmissionPolicy{srv: s, logger: s.logger.Named("admission_policy")}
+		s.staticEndpoints.Scaling = &Scaling{srv: s, logger: s.logger.Named("scaling")}
 		s.staticEndpoints.Enterprise = NewEnterpriseEndpoints(s)
 
 		// Client endpoints
@@ -1044,6 +1088,8 @@ func (s *Server) setupRpcServer(server *rpc.Server, ctx *RPCContext) {
 		// Streaming endpoints
 		s.staticEndpoints.FileSystem = &FileSystem{srv: s, logger: s.logger.Named("client_fs")}
 		s.staticEndpoints.FileSystem.register()
+		s.staticEndpoints.Agent = &Agent{srv: s, logger: s.logger.Named("agent")}
+		s.staticEndpoints.Agent.register()
 	}
 
 	// Register the static handlers
@@ -1059,10 +1105,14 @@ func (s *Server) setupRpcServer(server *rpc.Server, ctx *RPCContext) {
 	server.Register(s.staticEndpoints.Status)
 	server.Register(s.staticEndpoints.System)
 	server.Register(s.staticEndpoints.Search)
+	server.Register(s.staticEndpoints.Namespace)
+	server.Register(s.staticEndpoints.AdmissionPolicy)
+	server.Register(s.staticEndpoints.Scaling)
 	s.staticEndpoints.Enterprise.Register(server)
 	server.Register(s.staticEndpoints.ClientStats)
 	server.Register(s.staticEndpoints.ClientAllocations)
 	server.Register(s.staticEndpoints.FileSystem)
+	server.Register(s.staticEndpoints.Agent)
 
 	// Create new dynamic endpoints and add them to the RPC server.
 	node := &Node{srv: s, ctx: ctx, logger: s.logger.Named("client")}
@@ -1346,6 +1396,20 @@ func (s *Server) numPeers() (int, error) {
 	return len(configuration.Servers), nil
 }
 
+// readReplicaIDs returns the Raft IDs of the servers in the local region that
+// are configured as non-voting read replicas.
+func (s *Server) readReplicaIDs() map[raft.ServerID]struct{} {
+	ids := make(map[raft.ServerID]struct{})
+	for _, member := range s.serf.Members() {
+		valid, parts := isNomadServer(member)
+		if !valid || !parts.NonVoter || parts.Region != s.Region() {
+			continue
+		}
+		ids[raft.ServerID(parts.ID)] = struct{}{}
+	}
+	return ids
+}
+
 // IsLeader checks if this server is the cluster leader
 func (s *Server) IsLeader() bool {
 	return s.raft.State() == raft.Leader
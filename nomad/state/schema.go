@@ -36,8 +36,11 @@ func init() {
 		jobTableSchema,
 		jobSummarySchema,
 		jobVersionSchema,
+		jobSubmissionSchema,
 		deploymentSchema,
 		periodicLaunchTableSchema,
+		dispatchQueueTableSchema,
+		dispatchTokenTableSchema,
 		evalTableSchema,
 		allocTableSchema,
 		vaultAccessorTableSchema,
@@ -45,6 +48,10 @@ func init() {
 		aclTokenTableSchema,
 		autopilotConfigTableSchema,
 		schedulerConfigTableSchema,
+		namespaceTableSchema,
+		admissionPolicyTableSchema,
+		scalingEventTableSchema,
+		scalingPolicyTableSchema,
 	}...)
 }
 
@@ -232,6 +239,39 @@ func jobVersionSchema() *memdb.TableSchema {
 	}
 }
 
+// jobSubmissionSchema returns the memdb schema for the job submission table.
+// This table stores the source of the tracked job versions.
+func jobSubmissionSchema() *memdb.TableSchema {
+	return &memdb.TableSchema{
+		Name: "job_submission",
+		Indexes: map[string]*memdb.IndexSchema{
+			"id": {
+				Name:         "id",
+				AllowMissing: false,
+				Unique:       true,
+
+				// Use a compound index so the tuple of (Namespace, JobID, Version)
+				// is uniquely identifying
+				Indexer: &memdb.CompoundIndex{
+					Indexes: []memdb.Indexer{
+						&memdb.StringFieldIndex{
+							Field: "Namespace",
+						},
+
+						&memdb.StringFieldIndex{
+							Field: "JobID",
+						},
+
+						&memdb.UintFieldIndex{
+							Field: "Version",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
 // jobIsGCable satisfies the ConditionalIndexFunc interface and creates an index
 // on whether a job is eligible for garbage collection.
 func jobIsGCable(obj interface{}) (bool, error) {
@@ -361,6 +401,106 @@ func periodicLaunchTableSchema() *memdb.TableSchema {
 	}
 }
 
+// dispatchQueueTableSchema returns the MemDB schema for dispatched jobs that
+// are queued until their parameterized job is below its concurrency limit.
+func dispatchQueueTableSchema() *memdb.TableSchema {
+	return &memdb.TableSchema{
+		Name: "dispatch_queue",
+		Indexes: map[string]*memdb.IndexSchema{
+			// Primary index is the ID of the dispatched job
+			"id": {
+				Name:         "id",
+				AllowMissing: false,
+				Unique:       true,
+
+				// Use a compound index so the tuple of (Namespace, ID) is
+				// uniquely identifying
+				Indexer: &memdb.CompoundIndex{
+					Indexes: []memdb.Indexer{
+						&memdb.StringFieldIndex{
+							Field: "Namespace",
+						},
+
+						&memdb.StringFieldIndex{
+							Field: "ID",
+						},
+					},
+				},
+			},
+
+			// Parent index is used to lookup the queue of a parameterized job
+			"parent": {
+				Name:         "parent",
+				AllowMissing: false,
+				Unique:       false,
+				Indexer: &memdb.CompoundIndex{
+					Indexes: []memdb.Indexer{
+						&memdb.StringFieldIndex{
+							Field: "Namespace",
+						},
+
+						&memdb.StringFieldIndex{
+							Field: "ParentID",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// dispatchTokenTableSchema returns the MemDB schema for the idempotency tokens
+// used to dispatch parameterized jobs.
+func dispatchTokenTableSchema() *memdb.TableSchema {
+	return &memdb.TableSchema{
+		Name: "dispatch_tokens",
+		Indexes: map[string]*memdb.IndexSchema{
+			// Primary index is the token scoped to its parameterized job
+			"id": {
+				Name:         "id",
+				AllowMissing: false,
+				Unique:       true,
+
+				// Use a compound index so the tuple of (Namespace, ParentID,
+				// Token) is uniquely identifying
+				Indexer: &memdb.CompoundIndex{
+					Indexes: []memdb.Indexer{
+						&memdb.StringFieldIndex{
+							Field: "Namespace",
+						},
+
+						&memdb.StringFieldIndex{
+							Field: "ParentID",
+						},
+
+						&memdb.StringFieldIndex{
+							Field: "Token",
+						},
+					},
+				},
+			},
+
+			// Job index is used to remove the tokens of a dispatched job
+			"job": {
+				Name:         "job",
+				AllowMissing: false,
+				Unique:       false,
+				Indexer: &memdb.CompoundIndex{
+					Indexes: []memdb.Indexer{
+						&memdb.StringFieldIndex{
+							Field: "Namespace",
+						},
+
+						&memdb.StringFieldIndex{
+							Field: "JobID",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
 // evalTableSchema returns the MemDB schema for the eval table.
 // This table is used to store all the evaluations that are pending
 // or recently completed.
@@ -619,3 +759,154 @@ func schedulerConfigTableSchema() *memdb.TableSchema {
 		},
 	}
 }
+
+// namespaceTableSchema returns the MemDB schema for the namespace table.
+// This table is used to store the namespaces that jobs are registered in
+func namespaceTableSchema() *memdb.TableSchema {
+	return &memdb.TableSchema{
+		Name: "namespaces",
+		Indexes: map[string]*memdb.IndexSchema{
+			"id": {
+				Name:         "id",
+				AllowMissing: false,
+				Unique:       true,
+				Indexer: &memdb.StringFieldIndex{
+					Field: "Name",
+				},
+			},
+		},
+	}
+}
+
+// admissionPolicyTableSchema returns the MemDB schema for the admission
+// policy table. This table is used to store the policies enforced when jobs
+// are registered or planned.
+func admissionPolicyTableSchema() *memdb.TableSchema {
+	return &memdb.TableSchema{
+		Name: "admission_policy",
+		Indexes: map[string]*memdb.IndexSchema{
+			"id": {
+				Name:         "id",
+				AllowMissing: false,
+				Unique:       true,
+				Indexer: &memdb.StringFieldIndex{
+					Field: "Name",
+				},
+			},
+		},
+	}
+}
+
+// scalingEventTableSchema returns the memdb schema for the scaling event
+// table. This table stores the recent scaling events of the task groups of
+// each job.
+func scalingEventTableSchema() *memdb.TableSchema {
+	return &memdb.TableSchema{
+		Name: "scaling_event",
+		Indexes: map[string]*memdb.IndexSchema{
+			"id": {
+				Name:         "id",
+				AllowMissing: false,
+				Unique:       true,
+
+				// Use a compound index so the tuple of (Namespace, JobID) is
+				// uniquely identifying
+				Indexer: &memdb.CompoundIndex{
+					Indexes: []memdb.Indexer{
+						&memdb.StringFieldIndex{
+							Field: "Namespace",
+						},
+
+						&memdb.StringFieldIndex{
+							Field: "JobID",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// scalingPolicyTableSchema returns the memdb schema for the scaling policy
+// table. This table stores the scaling policies of the task groups of the
+// jobs.
+func scalingPolicyTableSchema() *memdb.TableSchema {
+	return &memdb.TableSchema{
+		Name: "scaling_policy",
+		Indexes: map[string]*memdb.IndexSchema{
+			"id": {
+				Name:         "id",
+				AllowMissing: false,
+				Unique:       true,
+				Indexer: &memdb.StringFieldIndex{
+					Field: "ID",
+				},
+			},
+			"target": {
+				Name:         "target",
+				AllowMissing: false,
+				Unique:       true,
+				Indexer:      &ScalingPolicyTargetFieldIndex{},
+			},
+			"namespace": {
+				Name:         "namespace",
+				AllowMissing: false,
+				Unique:       false,
+				Indexer: &memdb.StringFieldIndex{
+					Field: "Namespace",
+				},
+			},
+		},
+	}
+}
+
+// ScalingPolicyTargetFieldIndex is used to index the target of a scaling
+// policy. The target is indexed as the tuple of (Namespace, Job, Group), so
+// that the policies of a job can be looked up with a prefix of the tuple.
+type ScalingPolicyTargetFieldIndex struct{}
+
+// FromObject is used to extract the target index value from a scaling policy
+func (s *ScalingPolicyTargetFieldIndex) FromObject(obj interface{}) (bool, []byte, error) {
+	policy, ok := obj.(*structs.ScalingPolicy)
+	if !ok {
+		return false, nil, fmt.Errorf("object %#v is not a ScalingPolicy", obj)
+	}
+	if policy.Target == nil {
+		return false, nil, nil
+	}
+
+	val := policy.Target[structs.ScalingTargetNamespace] + "\x00" +
+		policy.Target[structs.ScalingTargetJob] + "\x00" +
+		policy.Target[structs.ScalingTargetGroup] + "\x00"
+	return true, []byte(val), nil
+}
+
+// FromArgs is used to build an exact index lookup from the namespace, job
+// and group of the target
+func (s *ScalingPolicyTargetFieldIndex) FromArgs(args ...interface{}) ([]byte, error) {
+	if len(args) != 3 {
+		return nil, fmt.Errorf("must provide the namespace, job and group of the target")
+	}
+	return s.fromArgs(args...)
+}
+
+// PrefixFromArgs is used to build a prefix lookup from the leading elements
+// of the target
+func (s *ScalingPolicyTargetFieldIndex) PrefixFromArgs(args ...interface{}) ([]byte, error) {
+	if len(args) == 0 || len(args) > 3 {
+		return nil, fmt.Errorf("must provide between one and three elements of the target")
+	}
+	return s.fromArgs(args...)
+}
+
+func (s *ScalingPolicyTargetFieldIndex) fromArgs(args ...interface{}) ([]byte, error) {
+	var val string
+	for i, arg := range args {
+		s, ok := arg.(string)
+		if !ok {
+			return nil, fmt.Errorf("argument %d must be a string: %#v", i, arg)
+		}
+		val += s + "\x00"
+	}
+	return []byte(val), nil
+}
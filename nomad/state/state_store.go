@@ -12,6 +12,7 @@ import (
 	memdb "github.com/hashicorp/go-memdb"
 	multierror "github.com/hashicorp/go-multierror"
 	"github.com/hashicorp/nomad/helper"
+	"github.com/hashicorp/nomad/helper/uuid"
 	"github.com/hashicorp/nomad/nomad/structs"
 )
 
@@ -79,6 +80,12 @@ func NewStateStore(config *StateStoreConfig) (*StateStore, error) {
 		config:    config,
 		abandonCh: make(chan struct{}),
 	}
+
+	// Initialize the state store with the default namespace
+	if err := s.namespaceInit(); err != nil {
+		return nil, fmt.Errorf("namespace state store initialization failed: %v", err)
+	}
+
 	return s, nil
 }
 
@@ -643,8 +650,10 @@ func (s *StateStore) UpsertNode(index uint64, node *structs.Node) error {
 		// Retain node events that have already been set on the node
 		node.Events = exist.Events
 
-		// If we are transitioning from down, record the re-registration
-		if exist.Status == structs.NodeStatusDown && node.Status != structs.NodeStatusDown {
+		// If we are transitioning from down or disconnected, record the
+		// re-registration
+		if (exist.Status == structs.NodeStatusDown || exist.Status == structs.NodeStatusDisconnected) &&
+			node.Status != exist.Status {
 			appendNodeEvents(index, node, []*structs.NodeEvent{
 				structs.NewNodeEvent().SetSubsystem(structs.NodeEventSubsystemCluster).
 					SetMessage(NodeRegisterEventReregistered).
@@ -1016,6 +1025,16 @@ func (s *StateStore) upsertJobImpl(index uint64, job *structs.Job, keepVersion b
 		return fmt.Errorf("job lookup failed: %v", err)
 	}
 
+	// A queued dispatched job is released from the queue by registering it
+	if job.Dispatched && existing == nil {
+		if err := s.deleteQueuedDispatchTxn(index, job.Namespace, job.ID, txn); err != nil {
+			return err
+		}
+		if err := s.upsertDispatchTokenTxn(index, job, txn); err != nil {
+			return err
+		}
+	}
+
 	// Setup the indexes correctly
 	if existing != nil {
 		job.CreateIndex = existing.(*structs.Job).CreateIndex
@@ -1055,6 +1074,10 @@ func (s *StateStore) upsertJobImpl(index uint64, job *structs.Job, keepVersion b
 		}
 	}
 
+	if err := s.updateJobScalingPolicies(index, job, txn); err != nil {
+		return fmt.Errorf("unable to update job scaling policies: %v", err)
+	}
+
 	if err := s.updateSummaryWithJob(index, job, txn); err != nil {
 		return fmt.Errorf("unable to create job summary: %v", err)
 	}
@@ -1157,6 +1180,38 @@ func (s *StateStore) DeleteJobTxn(index uint64, namespace, jobID string, txn Txn
 		return err
 	}
 
+	// Delete any dispatched jobs that are still queued along with their
+	// idempotency tokens
+	if job.IsParameterized() {
+		iter, err := txn.Get("dispatch_queue", "parent", namespace, jobID)
+		if err != nil {
+			return fmt.Errorf("queued dispatch lookup failed: %v", err)
+		}
+		var queued []string
+		for raw := iter.Next(); raw != nil; raw = iter.Next() {
+			queued = append(queued, raw.(*structs.QueuedDispatch).ID)
+		}
+		for _, id := range queued {
+			if err := s.deleteDispatchTokensTxn(index, namespace, id, txn); err != nil {
+				return err
+			}
+		}
+
+		if _, err := txn.DeleteAll("dispatch_queue", "parent", namespace, jobID); err != nil {
+			return fmt.Errorf("deleting queued dispatches failed: %v", err)
+		}
+		if err := txn.Insert("index", &IndexEntry{"dispatch_queue", index}); err != nil {
+			return fmt.Errorf("index update failed: %v", err)
+		}
+	}
+
+	// Delete the idempotency token the job was dispatched with
+	if job.Dispatched {
+		if err := s.deleteDispatchTokensTxn(index, namespace, jobID, txn); err != nil {
+			return err
+		}
+	}
+
 	// Delete the job summary
 	if _, err = txn.DeleteAll("job_summary", "id", namespace, jobID); err != nil {
 		return fmt.Errorf("deleing job summary failed: %v", err)
@@ -1165,6 +1220,16 @@ func (s *StateStore) DeleteJobTxn(index uint64, namespace, jobID string, txn Txn
 		return fmt.Errorf("index update failed: %v", err)
 	}
 
+	// Delete the scaling events
+	if err := s.deleteScalingEventsTxn(index, namespace, jobID, txn); err != nil {
+		return err
+	}
+
+	// Delete the scaling policies
+	if err := s.deleteJobScalingPolicies(index, namespace, jobID, txn); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -1204,7 +1269,7 @@ func (s *StateStore) deleteJobVersions(index uint64, job *structs.Job, txn *memd
 		return fmt.Errorf("index update failed: %v", err)
 	}
 
-	return nil
+	return s.deleteJobSubmissionsTxn(index, job.Namespace, job.ID, nil, txn)
 }
 
 // upsertJobVersion inserts a job into its historic version table and limits the
@@ -1225,32 +1290,44 @@ func (s *StateStore) upsertJobVersion(index uint64, job *structs.Job, txn *memdb
 		return fmt.Errorf("failed to look up job versions for %q: %v", job.ID, err)
 	}
 
-	// If we are below the limit there is no GCing to be done
-	if len(all) <= structs.JobTrackedVersions {
-		return nil
-	}
-
-	// We have to delete a historic job to make room.
-	// Find index of the highest versioned stable job
+	// Tagged versions are never GCed, so only the untagged versions count
+	// towards the limit. Find the index of the highest versioned stable job
+	// amongst them, unless it is tagged.
+	untagged := make([]*structs.Job, 0, len(all))
 	stableIdx := -1
-	for i, j := range all {
-		if j.Stable {
-			stableIdx = i
-			break
+	foundStable := false
+	for _, j := range all {
+		if j.Stable && !foundStable {
+			foundStable = true
+			if j.VersionTag == nil {
+				stableIdx = len(untagged)
+			}
 		}
+		if j.VersionTag == nil {
+			untagged = append(untagged, j)
+		}
+	}
+
+	// If we are below the limit there is no GCing to be done
+	if len(untagged) <= structs.JobTrackedVersions {
+		return nil
 	}
 
-	// If the stable job is the oldest version, do a swap to bring it into the
-	// keep set.
+	// If the stable job is outside of the keep set, do a swap to bring it
+	// into the keep set.
 	max := structs.JobTrackedVersions
-	if stableIdx == max {
-		all[max-1], all[max] = all[max], all[max-1]
+	if stableIdx >= max {
+		untagged[max-1], untagged[stableIdx] = untagged[stableIdx], untagged[max-1]
 	}
 
-	// Delete the job outside of the set that are being kept.
-	d := all[max]
-	if err := txn.Delete("job_version", d); err != nil {
-		return fmt.Errorf("failed to delete job %v (%d) from job_version", d.ID, d.Version)
+	// Delete the jobs outside of the set that are being kept.
+	for _, d := range untagged[max:] {
+		if err := txn.Delete("job_version", d); err != nil {
+			return fmt.Errorf("failed to delete job %v (%d) from job_version", d.ID, d.Version)
+		}
+		if err := s.deleteJobSubmissionsTxn(index, d.Namespace, d.ID, &d.Version, txn); err != nil {
+			return fmt.Errorf("failed to delete job %v (%d) submission: %v", d.ID, d.Version, err)
+		}
 	}
 
 	return nil
@@ -1366,6 +1443,31 @@ func (s *StateStore) jobByIDAndVersionImpl(ws memdb.WatchSet, namespace, id stri
 	return nil, nil
 }
 
+// JobVersionByTagName returns the version of the job tagged with the given
+// name, or nil if no version has the tag.
+func (s *StateStore) JobVersionByTagName(ws memdb.WatchSet, namespace, id, name string) (*structs.Job, error) {
+	txn := s.db.Txn(false)
+	return s.jobVersionByTagNameImpl(&ws, namespace, id, name, txn)
+}
+
+// jobVersionByTagNameImpl returns the version of the job tagged with the
+// given name. The passed watchset may be nil.
+func (s *StateStore) jobVersionByTagNameImpl(ws *memdb.WatchSet, namespace, id, name string,
+	txn *memdb.Txn) (*structs.Job, error) {
+
+	versions, err := s.jobVersionByID(txn, ws, namespace, id)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, job := range versions {
+		if job.VersionTag != nil && job.VersionTag.Name == name {
+			return job, nil
+		}
+	}
+	return nil, nil
+}
+
 func (s *StateStore) JobVersions(ws memdb.WatchSet) (memdb.ResultIterator, error) {
 	txn := s.db.Txn(false)
 
@@ -1379,6 +1481,435 @@ func (s *StateStore) JobVersions(ws memdb.WatchSet) (memdb.ResultIterator, error
 	return iter, nil
 }
 
+// UpsertJobSubmission is used to store the source of a job version. The job
+// version must exist.
+func (s *StateStore) UpsertJobSubmission(index uint64, submission *structs.JobSubmission) error {
+	txn := s.db.Txn(true)
+	defer txn.Abort()
+
+	job, err := s.jobByIDAndVersionImpl(nil, submission.Namespace, submission.JobID, submission.Version, txn)
+	if err != nil {
+		return fmt.Errorf("job version lookup failed: %v", err)
+	}
+	if job == nil {
+		return fmt.Errorf("job %q in namespace %q at version %d not found",
+			submission.JobID, submission.Namespace, submission.Version)
+	}
+	submission.JobModifyIndex = job.JobModifyIndex
+
+	if err := txn.Insert("job_submission", submission); err != nil {
+		return fmt.Errorf("job submission insert failed: %v", err)
+	}
+	if err := txn.Insert("index", &IndexEntry{"job_submission", index}); err != nil {
+		return fmt.Errorf("index update failed: %v", err)
+	}
+
+	txn.Commit()
+	return nil
+}
+
+// JobSubmission returns the source of a job version, or nil if it was not
+// submitted with the job.
+func (s *StateStore) JobSubmission(ws memdb.WatchSet, namespace, jobID string, version uint64) (*structs.JobSubmission, error) {
+	txn := s.db.Txn(false)
+
+	watchCh, existing, err := txn.FirstWatch("job_submission", "id", namespace, jobID, version)
+	if err != nil {
+		return nil, fmt.Errorf("job submission lookup failed: %v", err)
+	}
+	ws.Add(watchCh)
+
+	if existing != nil {
+		return existing.(*structs.JobSubmission), nil
+	}
+	return nil, nil
+}
+
+// JobSubmissions returns an iterator over the sources of all the job versions
+func (s *StateStore) JobSubmissions(ws memdb.WatchSet) (memdb.ResultIterator, error) {
+	txn := s.db.Txn(false)
+
+	iter, err := txn.Get("job_submission", "id")
+	if err != nil {
+		return nil, err
+	}
+
+	ws.Add(iter.WatchCh())
+	return iter, nil
+}
+
+// deleteJobSubmissionsTxn deletes the sources of the versions of a job. If
+// version is nil the sources of all the versions are deleted.
+func (s *StateStore) deleteJobSubmissionsTxn(index uint64, namespace, jobID string, version *uint64, txn *memdb.Txn) error {
+	iter, err := txn.Get("job_submission", "id_prefix", namespace, jobID)
+	if err != nil {
+		return err
+	}
+
+	var submissions []*structs.JobSubmission
+	for {
+		raw := iter.Next()
+		if raw == nil {
+			break
+		}
+
+		// Ensure the ID is an exact match
+		sub := raw.(*structs.JobSubmission)
+		if sub.JobID != jobID || (version != nil && sub.Version != *version) {
+			continue
+		}
+		submissions = append(submissions, sub)
+	}
+	if len(submissions) == 0 {
+		return nil
+	}
+
+	for _, sub := range submissions {
+		if err := txn.Delete("job_submission", sub); err != nil {
+			return fmt.Errorf("deleting job submission failed: %v", err)
+		}
+	}
+	if err := txn.Insert("index", &IndexEntry{"job_submission", index}); err != nil {
+		return fmt.Errorf("index update failed: %v", err)
+	}
+	return nil
+}
+
+// UpsertScalingEvent is used to record a scaling event of a task group. Only
+// the most recent events of each task group are kept. If the event has a
+// count, the count of the task group is updated too.
+func (s *StateStore) UpsertScalingEvent(index uint64, req *structs.ScalingEventRequest) error {
+	txn := s.db.Txn(true)
+	defer txn.Abort()
+
+	if req.ScalingEvent.Count != nil {
+		if err := s.updateJobCountImpl(index, req, txn); err != nil {
+			return err
+		}
+	}
+
+	existing, err := txn.First("scaling_event", "id", req.Namespace, req.JobID)
+	if err != nil {
+		return fmt.Errorf("scaling event lookup failed: %v", err)
+	}
+
+	var jobEvents *structs.JobScalingEvents
+	if existing != nil {
+		jobEvents = existing.(*structs.JobScalingEvents).Copy()
+	} else {
+		jobEvents = &structs.JobScalingEvents{
+			Namespace:     req.Namespace,
+			JobID:         req.JobID,
+			ScalingEvents: make(map[string][]*structs.ScalingEvent),
+		}
+	}
+
+	event := req.ScalingEvent.Copy()
+	event.CreateIndex = index
+
+	// Prepend the event so that the most recent events come first
+	events := append([]*structs.ScalingEvent{event}, jobEvents.ScalingEvents[req.TaskGroup]...)
+	if len(events) > structs.JobTrackedScalingEvents {
+		events = events[:structs.JobTrackedScalingEvents]
+	}
+	jobEvents.ScalingEvents[req.TaskGroup] = events
+	jobEvents.ModifyIndex = index
+
+	if err := txn.Insert("scaling_event", jobEvents); err != nil {
+		return fmt.Errorf("scaling event insert failed: %v", err)
+	}
+	if err := txn.Insert("index", &IndexEntry{"scaling_event", index}); err != nil {
+		return fmt.Errorf("index update failed: %v", err)
+	}
+
+	txn.Commit()
+	return nil
+}
+
+// updateJobCountImpl updates the count of the task group of a scaling event.
+// Scaling doesn't create a new version of the job, so the job is updated in
+// place while the stored versions of the job are left as they were submitted.
+// The active deployment of the job version is updated to the new count.
+func (s *StateStore) updateJobCountImpl(index uint64, req *structs.ScalingEventRequest, txn *memdb.Txn) error {
+	existing, err := txn.First("jobs", "id", req.Namespace, req.JobID)
+	if err != nil {
+		return fmt.Errorf("job lookup failed: %v", err)
+	}
+	if existing == nil {
+		return fmt.Errorf("job %q in namespace %q not found", req.JobID, req.Namespace)
+	}
+
+	job := existing.(*structs.Job)
+	if job.JobModifyIndex != req.JobModifyIndex {
+		return fmt.Errorf("job %q was modified while being scaled: job modify index %d, expected %d",
+			req.JobID, job.JobModifyIndex, req.JobModifyIndex)
+	}
+	if job.LookupTaskGroup(req.TaskGroup) == nil {
+		return fmt.Errorf("task group %q does not exist in job %q", req.TaskGroup, req.JobID)
+	}
+
+	count := int(*req.ScalingEvent.Count)
+	job = job.Copy()
+	job.LookupTaskGroup(req.TaskGroup).Count = count
+	job.ModifyIndex = index
+	job.JobModifyIndex = index
+
+	if err := txn.Insert("jobs", job); err != nil {
+		return fmt.Errorf("job insert failed: %v", err)
+	}
+	if err := txn.Insert("index", &IndexEntry{"jobs", index}); err != nil {
+		return fmt.Errorf("index update failed: %v", err)
+	}
+
+	// The reconciler only sets the desired total of a group when it creates
+	// the deployment, so update it for the active deployment of the version
+	iter, err := txn.Get("deployment", "job", job.Namespace, job.ID)
+	if err != nil {
+		return fmt.Errorf("deployment lookup failed: %v", err)
+	}
+	var deployments []*structs.Deployment
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		d := raw.(*structs.Deployment)
+		if !d.Active() || d.JobCreateIndex != job.CreateIndex || d.JobVersion != job.Version {
+			continue
+		}
+		if dstate, ok := d.TaskGroups[req.TaskGroup]; ok && dstate.DesiredTotal != count {
+			deployments = append(deployments, d)
+		}
+	}
+	for _, d := range deployments {
+		d = d.Copy()
+		d.TaskGroups[req.TaskGroup].DesiredTotal = count
+		if err := s.upsertDeploymentImpl(index, d, txn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ScalingEvents returns an iterator over the scaling events of all the jobs
+func (s *StateStore) ScalingEvents(ws memdb.WatchSet) (memdb.ResultIterator, error) {
+	txn := s.db.Txn(false)
+
+	iter, err := txn.Get("scaling_event", "id")
+	if err != nil {
+		return nil, err
+	}
+
+	ws.Add(iter.WatchCh())
+	return iter, nil
+}
+
+// ScalingEventsByJob returns the scaling events of the task groups of a job,
+// or nil if none were recorded.
+func (s *StateStore) ScalingEventsByJob(ws memdb.WatchSet, namespace, jobID string) (map[string][]*structs.ScalingEvent, uint64, error) {
+	txn := s.db.Txn(false)
+
+	watchCh, existing, err := txn.FirstWatch("scaling_event", "id", namespace, jobID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("scaling event lookup failed: %v", err)
+	}
+	ws.Add(watchCh)
+
+	if existing != nil {
+		events := existing.(*structs.JobScalingEvents)
+		return events.ScalingEvents, events.ModifyIndex, nil
+	}
+	return nil, 0, nil
+}
+
+// deleteScalingEventsTxn deletes the scaling events of a job
+func (s *StateStore) deleteScalingEventsTxn(index uint64, namespace, jobID string, txn *memdb.Txn) error {
+	num, err := txn.DeleteAll("scaling_event", "id", namespace, jobID)
+	if err != nil {
+		return fmt.Errorf("deleting scaling events failed: %v", err)
+	}
+	if num == 0 {
+		return nil
+	}
+	if err := txn.Insert("index", &IndexEntry{"scaling_event", index}); err != nil {
+		return fmt.Errorf("index update failed: %v", err)
+	}
+	return nil
+}
+
+// updateJobScalingPolicies upserts the scaling policies of the task groups
+// of the job and deletes the policies of the task groups that were removed.
+// Jobs launched from a parent job do not have policies of their own.
+func (s *StateStore) updateJobScalingPolicies(index uint64, job *structs.Job, txn *memdb.Txn) error {
+	if job.ParentID != "" {
+		return nil
+	}
+
+	iter, err := txn.Get("scaling_policy", "target_prefix", job.Namespace, job.ID)
+	if err != nil {
+		return fmt.Errorf("scaling policy lookup failed: %v", err)
+	}
+	existing := make(map[string]*structs.ScalingPolicy)
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		policy := raw.(*structs.ScalingPolicy)
+		existing[policy.Target[structs.ScalingTargetGroup]] = policy
+	}
+
+	updated := false
+	for _, tg := range job.TaskGroups {
+		if tg.Scaling == nil {
+			continue
+		}
+
+		policy := tg.Scaling.Copy()
+		policy.TargetTaskGroup(job, tg)
+
+		prev, ok := existing[tg.Name]
+		delete(existing, tg.Name)
+		if ok {
+			// Policies registered through the job endpoint always carry an
+			// ID; fall back to the existing one otherwise.
+			if policy.ID == "" {
+				policy.ID = prev.ID
+			}
+			if policy.ID == prev.ID && scalingPolicyEqual(policy, prev) {
+				continue
+			}
+			if policy.ID == prev.ID {
+				policy.CreateIndex = prev.CreateIndex
+			} else {
+				policy.CreateIndex = index
+			}
+		} else {
+			if policy.ID == "" {
+				policy.ID = uuid.Generate()
+			}
+			policy.CreateIndex = index
+		}
+		policy.ModifyIndex = index
+		tg.Scaling.ID = policy.ID
+
+		if ok && policy.ID != prev.ID {
+			if err := txn.Delete("scaling_policy", prev); err != nil {
+				return fmt.Errorf("scaling policy delete failed: %v", err)
+			}
+		}
+		if err := txn.Insert("scaling_policy", policy); err != nil {
+			return fmt.Errorf("scaling policy insert failed: %v", err)
+		}
+		updated = true
+	}
+
+	// Delete the policies of the task groups that no longer have one
+	for _, policy := range existing {
+		if err := txn.Delete("scaling_policy", policy); err != nil {
+			return fmt.Errorf("scaling policy delete failed: %v", err)
+		}
+		updated = true
+	}
+
+	if !updated {
+		return nil
+	}
+	if err := txn.Insert("index", &IndexEntry{"scaling_policy", index}); err != nil {
+		return fmt.Errorf("index update failed: %v", err)
+	}
+	return nil
+}
+
+// scalingPolicyEqual returns whether the stored policy is unchanged by the
+// policy of the job, ignoring the raft indexes.
+func scalingPolicyEqual(policy, stored *structs.ScalingPolicy) bool {
+	a, b := *policy, *stored
+	a.CreateIndex, a.ModifyIndex = 0, 0
+	b.CreateIndex, b.ModifyIndex = 0, 0
+	return reflect.DeepEqual(&a, &b)
+}
+
+// deleteJobScalingPolicies deletes the scaling policies of a job
+func (s *StateStore) deleteJobScalingPolicies(index uint64, namespace, jobID string, txn *memdb.Txn) error {
+	num, err := txn.DeleteAll("scaling_policy", "target_prefix", namespace, jobID)
+	if err != nil {
+		return fmt.Errorf("deleting scaling policies failed: %v", err)
+	}
+	if num == 0 {
+		return nil
+	}
+	if err := txn.Insert("index", &IndexEntry{"scaling_policy", index}); err != nil {
+		return fmt.Errorf("index update failed: %v", err)
+	}
+	return nil
+}
+
+// ScalingPolicies returns an iterator over all the scaling policies
+func (s *StateStore) ScalingPolicies(ws memdb.WatchSet) (memdb.ResultIterator, error) {
+	txn := s.db.Txn(false)
+
+	iter, err := txn.Get("scaling_policy", "id")
+	if err != nil {
+		return nil, err
+	}
+
+	ws.Add(iter.WatchCh())
+	return iter, nil
+}
+
+// ScalingPoliciesByNamespace returns an iterator over the scaling policies of
+// a namespace
+func (s *StateStore) ScalingPoliciesByNamespace(ws memdb.WatchSet, namespace string) (memdb.ResultIterator, error) {
+	txn := s.db.Txn(false)
+
+	iter, err := txn.Get("scaling_policy", "namespace", namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	ws.Add(iter.WatchCh())
+	return iter, nil
+}
+
+// ScalingPoliciesByJob returns an iterator over the scaling policies of the
+// task groups of a job
+func (s *StateStore) ScalingPoliciesByJob(ws memdb.WatchSet, namespace, jobID string) (memdb.ResultIterator, error) {
+	txn := s.db.Txn(false)
+
+	iter, err := txn.Get("scaling_policy", "target_prefix", namespace, jobID)
+	if err != nil {
+		return nil, err
+	}
+
+	ws.Add(iter.WatchCh())
+	return iter, nil
+}
+
+// ScalingPolicyByID returns the scaling policy with the given ID
+func (s *StateStore) ScalingPolicyByID(ws memdb.WatchSet, id string) (*structs.ScalingPolicy, error) {
+	txn := s.db.Txn(false)
+
+	watchCh, existing, err := txn.FirstWatch("scaling_policy", "id", id)
+	if err != nil {
+		return nil, fmt.Errorf("scaling policy lookup failed: %v", err)
+	}
+	ws.Add(watchCh)
+
+	if existing != nil {
+		return existing.(*structs.ScalingPolicy), nil
+	}
+	return nil, nil
+}
+
+// ScalingPolicyByTarget returns the scaling policy of a task group
+func (s *StateStore) ScalingPolicyByTarget(ws memdb.WatchSet, namespace, jobID, group string) (*structs.ScalingPolicy, error) {
+	txn := s.db.Txn(false)
+
+	watchCh, existing, err := txn.FirstWatch("scaling_policy", "target", namespace, jobID, group)
+	if err != nil {
+		return nil, fmt.Errorf("scaling policy lookup failed: %v", err)
+	}
+	ws.Add(watchCh)
+
+	if existing != nil {
+		return existing.(*structs.ScalingPolicy), nil
+	}
+	return nil, nil
+}
+
 // Jobs returns an iterator over all the jobs
 func (s *StateStore) Jobs(ws memdb.WatchSet) (memdb.ResultIterator, error) {
 	txn := s.db.Txn(false)
@@ -1606,6 +2137,237 @@ func (s *StateStore) PeriodicLaunches(ws memdb.WatchSet) (memdb.ResultIterator,
 	return iter, nil
 }
 
+// UpsertQueuedDispatch is used to queue a dispatched job until its
+// parameterized job is below its concurrency limit.
+func (s *StateStore) UpsertQueuedDispatch(index uint64, dispatch *structs.QueuedDispatch) error {
+	txn := s.db.Txn(true)
+	defer txn.Abort()
+
+	// Check if the dispatch is already queued
+	existing, err := txn.First("dispatch_queue", "id", dispatch.Namespace, dispatch.ID)
+	if err != nil {
+		return fmt.Errorf("queued dispatch lookup failed: %v", err)
+	}
+
+	// Setup the indexes correctly
+	if existing != nil {
+		dispatch.CreateIndex = existing.(*structs.QueuedDispatch).CreateIndex
+		dispatch.ModifyIndex = index
+	} else {
+		dispatch.CreateIndex = index
+		dispatch.ModifyIndex = index
+
+		if err := s.updateQueuedChildren(index, dispatch.Namespace, dispatch.ParentID, 1, txn); err != nil {
+			return err
+		}
+		if err := s.upsertDispatchTokenTxn(index, dispatch.Job, txn); err != nil {
+			return err
+		}
+	}
+
+	// Insert the dispatch
+	if err := txn.Insert("dispatch_queue", dispatch); err != nil {
+		return fmt.Errorf("queued dispatch insert failed: %v", err)
+	}
+	if err := txn.Insert("index", &IndexEntry{"dispatch_queue", index}); err != nil {
+		return fmt.Errorf("index update failed: %v", err)
+	}
+
+	txn.Commit()
+	return nil
+}
+
+// deleteQueuedDispatchTxn removes a dispatched job from the queue of its
+// parameterized job. It is a no-op if the job is not queued.
+func (s *StateStore) deleteQueuedDispatchTxn(index uint64, namespace, jobID string, txn Txn) error {
+	existing, err := txn.First("dispatch_queue", "id", namespace, jobID)
+	if err != nil {
+		return fmt.Errorf("queued dispatch lookup failed: %v", err)
+	}
+	if existing == nil {
+		return nil
+	}
+
+	dispatch := existing.(*structs.QueuedDispatch)
+	if err := txn.Delete("dispatch_queue", dispatch); err != nil {
+		return fmt.Errorf("queued dispatch delete failed: %v", err)
+	}
+	if err := txn.Insert("index", &IndexEntry{"dispatch_queue", index}); err != nil {
+		return fmt.Errorf("index update failed: %v", err)
+	}
+
+	return s.updateQueuedChildren(index, namespace, dispatch.ParentID, -1, txn)
+}
+
+// updateQueuedChildren adjusts the number of queued children in the summary of
+// the parameterized job by delta.
+func (s *StateStore) updateQueuedChildren(index uint64, namespace, parentID string, delta int64, txn Txn) error {
+	summaryRaw, err := txn.First("job_summary", "id", namespace, parentID)
+	if err != nil {
+		return fmt.Errorf("unable to retrieve summary for parent job: %v", err)
+	}
+
+	// Only continue if the summary exists. It could not exist if the parent
+	// job was removed
+	if summaryRaw == nil {
+		return nil
+	}
+
+	pSummary := summaryRaw.(*structs.JobSummary).Copy()
+	if pSummary.Children == nil {
+		pSummary.Children = new(structs.JobChildrenSummary)
+	}
+	pSummary.Children.Queued += delta
+	pSummary.ModifyIndex = index
+
+	if err := txn.Insert("job_summary", pSummary); err != nil {
+		return fmt.Errorf("job summary insert failed: %v", err)
+	}
+	if err := txn.Insert("index", &IndexEntry{"job_summary", index}); err != nil {
+		return fmt.Errorf("index update failed: %v", err)
+	}
+	return nil
+}
+
+// QueuedDispatchesByParent returns the dispatched jobs queued for the given
+// parameterized job in the order they were queued.
+func (s *StateStore) QueuedDispatchesByParent(ws memdb.WatchSet, namespace, parentID string) ([]*structs.QueuedDispatch, error) {
+	txn := s.db.Txn(false)
+
+	iter, err := txn.Get("dispatch_queue", "parent", namespace, parentID)
+	if err != nil {
+		return nil, fmt.Errorf("queued dispatch lookup failed: %v", err)
+	}
+
+	ws.Add(iter.WatchCh())
+
+	var out []*structs.QueuedDispatch
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		out = append(out, raw.(*structs.QueuedDispatch))
+	}
+
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].CreateIndex < out[j].CreateIndex
+	})
+	return out, nil
+}
+
+// QueuedDispatchByID returns the queued dispatched job with the given ID
+func (s *StateStore) QueuedDispatchByID(ws memdb.WatchSet, namespace, id string) (*structs.QueuedDispatch, error) {
+	txn := s.db.Txn(false)
+
+	watchCh, existing, err := txn.FirstWatch("dispatch_queue", "id", namespace, id)
+	if err != nil {
+		return nil, fmt.Errorf("queued dispatch lookup failed: %v", err)
+	}
+
+	ws.Add(watchCh)
+
+	if existing != nil {
+		return existing.(*structs.QueuedDispatch), nil
+	}
+	return nil, nil
+}
+
+// QueuedDispatches returns an iterator over all the queued dispatches
+func (s *StateStore) QueuedDispatches(ws memdb.WatchSet) (memdb.ResultIterator, error) {
+	txn := s.db.Txn(false)
+
+	// Walk the entire table
+	iter, err := txn.Get("dispatch_queue", "id")
+	if err != nil {
+		return nil, err
+	}
+
+	ws.Add(iter.WatchCh())
+
+	return iter, nil
+}
+
+// upsertDispatchTokenTxn records the idempotency token the dispatched job was
+// created with. It is a no-op if the job was dispatched without a token.
+func (s *StateStore) upsertDispatchTokenTxn(index uint64, job *structs.Job, txn Txn) error {
+	if job == nil || job.DispatchIdempotencyToken == "" {
+		return nil
+	}
+
+	token := &structs.DispatchToken{
+		Token:       job.DispatchIdempotencyToken,
+		Namespace:   job.Namespace,
+		ParentID:    job.ParentID,
+		JobID:       job.ID,
+		CreateTime:  job.SubmitTime,
+		CreateIndex: index,
+		ModifyIndex: index,
+	}
+
+	// A queued dispatched job keeps its token when it is released. An expired
+	// token used by a new dispatch is replaced.
+	existing, err := txn.First("dispatch_tokens", "id", token.Namespace, token.ParentID, token.Token)
+	if err != nil {
+		return fmt.Errorf("dispatch token lookup failed: %v", err)
+	}
+	if existing != nil && existing.(*structs.DispatchToken).JobID == token.JobID {
+		token.CreateIndex = existing.(*structs.DispatchToken).CreateIndex
+	}
+
+	if err := txn.Insert("dispatch_tokens", token); err != nil {
+		return fmt.Errorf("dispatch token insert failed: %v", err)
+	}
+	if err := txn.Insert("index", &IndexEntry{"dispatch_tokens", index}); err != nil {
+		return fmt.Errorf("index update failed: %v", err)
+	}
+	return nil
+}
+
+// deleteDispatchTokensTxn removes the idempotency tokens of the dispatched job.
+func (s *StateStore) deleteDispatchTokensTxn(index uint64, namespace, jobID string, txn Txn) error {
+	num, err := txn.DeleteAll("dispatch_tokens", "job", namespace, jobID)
+	if err != nil {
+		return fmt.Errorf("deleting dispatch tokens failed: %v", err)
+	}
+	if num == 0 {
+		return nil
+	}
+	if err := txn.Insert("index", &IndexEntry{"dispatch_tokens", index}); err != nil {
+		return fmt.Errorf("index update failed: %v", err)
+	}
+	return nil
+}
+
+// DispatchTokenByID returns the idempotency token for the parameterized job
+// if it has been used.
+func (s *StateStore) DispatchTokenByID(ws memdb.WatchSet, namespace, parentID, token string) (*structs.DispatchToken, error) {
+	txn := s.db.Txn(false)
+
+	watchCh, existing, err := txn.FirstWatch("dispatch_tokens", "id", namespace, parentID, token)
+	if err != nil {
+		return nil, fmt.Errorf("dispatch token lookup failed: %v", err)
+	}
+
+	ws.Add(watchCh)
+
+	if existing != nil {
+		return existing.(*structs.DispatchToken), nil
+	}
+	return nil, nil
+}
+
+// DispatchTokens returns an iterator over all the dispatch idempotency tokens
+func (s *StateStore) DispatchTokens(ws memdb.WatchSet) (memdb.ResultIterator, error) {
+	txn := s.db.Txn(false)
+
+	// Walk the entire table
+	iter, err := txn.Get("dispatch_tokens", "id")
+	if err != nil {
+		return nil, err
+	}
+
+	ws.Add(iter.WatchCh())
+
+	return iter, nil
+}
+
 // UpsertEvals is used to upsert a set of evaluations
 func (s *StateStore) UpsertEvals(index uint64, evals []*structs.Evaluation) error {
 	txn := s.db.Txn(true)
@@ -2087,9 +2849,10 @@ func (s *StateStore) upsertAllocsImpl(index uint64, allocs []*structs.Allocation
 			// Keep the clients task states
 			alloc.TaskStates = exist.TaskStates
 
-			// If the scheduler is marking this allocation as lost we do not
-			// want to reuse the status of the existing allocation.
-			if alloc.ClientStatus != structs.AllocClientStatusLost {
+			// If the scheduler is marking this allocation as lost or unknown we
+			// do not want to reuse the status of the existing allocation.
+			if alloc.ClientStatus != structs.AllocClientStatusLost &&
+				alloc.ClientStatus != structs.AllocClientStatusUnknown {
 				alloc.ClientStatus = exist.ClientStatus
 				alloc.ClientDescription = exist.ClientDescription
 			}
@@ -2638,35 +3401,130 @@ func (s *StateStore) UpdateJobStability(index uint64, namespace, jobID string, j
 	txn := s.db.Txn(true)
 	defer txn.Abort()
 
-	if err := s.updateJobStabilityImpl(index, namespace, jobID, jobVersion, stable, txn); err != nil {
-		return err
+	if err := s.updateJobStabilityImpl(index, namespace, jobID, jobVersion, stable, txn); err != nil {
+		return err
+	}
+
+	txn.Commit()
+	return nil
+}
+
+// updateJobStabilityImpl updates the stability of the given job and version
+func (s *StateStore) updateJobStabilityImpl(index uint64, namespace, jobID string, jobVersion uint64, stable bool, txn *memdb.Txn) error {
+	// Get the job that is referenced
+	job, err := s.jobByIDAndVersionImpl(nil, namespace, jobID, jobVersion, txn)
+	if err != nil {
+		return err
+	}
+
+	// Has already been cleared, nothing to do
+	if job == nil {
+		return nil
+	}
+
+	// If the job already has the desired stability, nothing to do
+	if job.Stable == stable {
+		return nil
+	}
+
+	copy := job.Copy()
+	copy.Stable = stable
+	return s.upsertJobImpl(index, copy, true, txn)
+}
+
+// UpdateJobVersionTag tags the given job version, or removes the tag with the
+// given name if the request unsets it.
+func (s *StateStore) UpdateJobVersionTag(index uint64, namespace string, req *structs.JobTagRequest) error {
+	txn := s.db.Txn(true)
+	defer txn.Abort()
+
+	if err := s.updateJobVersionTagImpl(index, namespace, req, txn); err != nil {
+		return err
+	}
+
+	txn.Commit()
+	return nil
+}
+
+// updateJobVersionTagImpl applies or removes a job version tag
+func (s *StateStore) updateJobVersionTagImpl(index uint64, namespace string, req *structs.JobTagRequest, txn *memdb.Txn) error {
+	tagged, err := s.jobVersionByTagNameImpl(nil, namespace, req.JobID, req.Name, txn)
+	if err != nil {
+		return err
+	}
+
+	if req.Unset {
+		if tagged == nil {
+			return fmt.Errorf("job %q in namespace %q has no version tagged %q", req.JobID, namespace, req.Name)
+		}
+		return s.setJobVersionTagTxn(index, tagged, nil, txn)
+	}
+
+	if req.Version == nil {
+		return fmt.Errorf("missing job version to tag")
+	}
+	if tagged != nil && tagged.Version != *req.Version {
+		return fmt.Errorf("tag %q already exists on version %d of job %q", req.Name, tagged.Version, req.JobID)
+	}
+
+	job, err := s.jobByIDAndVersionImpl(nil, namespace, req.JobID, *req.Version, txn)
+	if err != nil {
+		return err
+	}
+	if job == nil {
+		return fmt.Errorf("job %q in namespace %q at version %d not found", req.JobID, namespace, *req.Version)
+	}
+
+	// Retagging a version doesn't add a tagged version
+	if job.VersionTag == nil {
+		versions, err := s.jobVersionByID(txn, nil, namespace, req.JobID)
+		if err != nil {
+			return err
+		}
+		if structs.TaggedJobVersions(versions) >= structs.JobMaxTaggedVersions {
+			return fmt.Errorf("job %q already has the maximum of %d tagged versions", req.JobID, structs.JobMaxTaggedVersions)
+		}
 	}
 
-	txn.Commit()
-	return nil
+	tag := &structs.JobVersionTag{
+		Name:        req.Name,
+		Description: req.Description,
+		TaggedTime:  req.TaggedTime,
+	}
+	return s.setJobVersionTagTxn(index, job, tag, txn)
 }
 
-// updateJobStabilityImpl updates the stability of the given job and version
-func (s *StateStore) updateJobStabilityImpl(index uint64, namespace, jobID string, jobVersion uint64, stable bool, txn *memdb.Txn) error {
-	// Get the job that is referenced
-	job, err := s.jobByIDAndVersionImpl(nil, namespace, jobID, jobVersion, txn)
-	if err != nil {
-		return err
+// setJobVersionTagTxn sets the tag of a job version, updating the current job
+// as well if the version is the current one.
+func (s *StateStore) setJobVersionTagTxn(index uint64, job *structs.Job, tag *structs.JobVersionTag, txn *memdb.Txn) error {
+	version := job.Copy()
+	version.VersionTag = tag
+	version.ModifyIndex = index
+	if err := txn.Insert("job_version", version); err != nil {
+		return fmt.Errorf("failed to insert job into job_version table: %v", err)
 	}
-
-	// Has already been cleared, nothing to do
-	if job == nil {
-		return nil
+	if err := txn.Insert("index", &IndexEntry{"job_version", index}); err != nil {
+		return fmt.Errorf("index update failed: %v", err)
 	}
 
-	// If the job already has the desired stability, nothing to do
-	if job.Stable == stable {
+	existing, err := txn.First("jobs", "id", job.Namespace, job.ID)
+	if err != nil {
+		return fmt.Errorf("job lookup failed: %v", err)
+	}
+	if existing == nil || existing.(*structs.Job).Version != job.Version {
 		return nil
 	}
 
-	copy := job.Copy()
-	copy.Stable = stable
-	return s.upsertJobImpl(index, copy, true, txn)
+	cur := existing.(*structs.Job).Copy()
+	cur.VersionTag = tag
+	cur.ModifyIndex = index
+	if err := txn.Insert("jobs", cur); err != nil {
+		return fmt.Errorf("job insert failed: %v", err)
+	}
+	if err := txn.Insert("index", &IndexEntry{"jobs", index}); err != nil {
+		return fmt.Errorf("index update failed: %v", err)
+	}
+	return nil
 }
 
 // UpdateDeploymentPromotion is used to promote canaries in a deployment and
@@ -3050,6 +3908,15 @@ func (s *StateStore) ReconcileJobSummaries(index uint64) error {
 				}
 			}
 
+			// Count the dispatched children waiting in the queue
+			queued, err := txn.Get("dispatch_queue", "parent", job.Namespace, job.ID)
+			if err != nil {
+				return err
+			}
+			for raw := queued.Next(); raw != nil; raw = queued.Next() {
+				summary.Children.Queued++
+			}
+
 			// Insert the job summary if its different
 			if !reflect.DeepEqual(summary, oldSummary) {
 				// Set the create index of the summary same as the job's create index
@@ -3108,6 +3975,8 @@ func (s *StateStore) ReconcileJobSummaries(index uint64) error {
 				tg.Running += 1
 			case structs.AllocClientStatusPending:
 				tg.Starting += 1
+			case structs.AllocClientStatusUnknown:
+				tg.Unknown += 1
 			default:
 				s.logger.Error("invalid client status set on allocation", "client_status", alloc.ClientStatus, "alloc_id", alloc.ID)
 			}
@@ -3540,6 +4409,8 @@ func (s *StateStore) updateSummaryWithAlloc(index uint64, alloc *structs.Allocat
 			tgSummary.Complete += 1
 		case structs.AllocClientStatusLost:
 			tgSummary.Lost += 1
+		case structs.AllocClientStatusUnknown:
+			tgSummary.Unknown += 1
 		}
 
 		// Decrementing the count of the bin of the last state
@@ -3556,6 +4427,10 @@ func (s *StateStore) updateSummaryWithAlloc(index uint64, alloc *structs.Allocat
 			if tgSummary.Lost > 0 {
 				tgSummary.Lost -= 1
 			}
+		case structs.AllocClientStatusUnknown:
+			if tgSummary.Unknown > 0 {
+				tgSummary.Unknown -= 1
+			}
 		case structs.AllocClientStatusFailed, structs.AllocClientStatusComplete:
 		default:
 			s.logger.Error("invalid old client status for allocatio",
@@ -3600,6 +4475,290 @@ func (s *StateStore) addEphemeralDiskToTaskGroups(job *structs.Job) {
 	}
 }
 
+// namespaceInit ensures the default namespace exists.
+func (s *StateStore) namespaceInit() error {
+	txn := s.db.Txn(true)
+	defer txn.Abort()
+
+	// Create the default namespace. This is safe to do every time the state
+	// store is created: every server of a new cluster creates the same default
+	// namespace, and a modified default namespace is overwritten when a
+	// snapshot is restored.
+	defaultNs := &structs.Namespace{
+		Name:        structs.DefaultNamespace,
+		Description: structs.DefaultNamespaceDescription,
+	}
+	if err := s.upsertNamespaceImpl(1, txn, defaultNs); err != nil {
+		return err
+	}
+
+	txn.Commit()
+	return nil
+}
+
+// namespaceExists returns whether a namespace exists
+func (s *StateStore) namespaceExists(txn *memdb.Txn, namespace string) (bool, error) {
+	if namespace == structs.DefaultNamespace {
+		return true, nil
+	}
+
+	existing, err := txn.First("namespaces", "id", namespace)
+	if err != nil {
+		return false, fmt.Errorf("namespace lookup failed: %v", err)
+	}
+	return existing != nil, nil
+}
+
+// UpsertNamespaces is used to create or update a set of namespaces
+func (s *StateStore) UpsertNamespaces(index uint64, namespaces []*structs.Namespace) error {
+	txn := s.db.Txn(true)
+	defer txn.Abort()
+
+	for _, ns := range namespaces {
+		if err := s.upsertNamespaceImpl(index, txn, ns); err != nil {
+			return err
+		}
+	}
+
+	if err := txn.Insert("index", &IndexEntry{"namespaces", index}); err != nil {
+		return fmt.Errorf("index update failed: %v", err)
+	}
+
+	txn.Commit()
+	return nil
+}
+
+// upsertNamespaceImpl is used to upsert a namespace
+func (s *StateStore) upsertNamespaceImpl(index uint64, txn *memdb.Txn, namespace *structs.Namespace) error {
+	// Ensure the namespace hash is non-nil. This should be done outside the
+	// state store for performance reasons, but we check here for defense in
+	// depth.
+	if len(namespace.Hash) == 0 {
+		namespace.SetHash()
+	}
+
+	// Check if the namespace already exists
+	existing, err := txn.First("namespaces", "id", namespace.Name)
+	if err != nil {
+		return fmt.Errorf("namespace lookup failed: %v", err)
+	}
+
+	// Setup the indexes correctly
+	if existing != nil {
+		exist := existing.(*structs.Namespace)
+		namespace.CreateIndex = exist.CreateIndex
+		namespace.ModifyIndex = index
+	} else {
+		namespace.CreateIndex = index
+		namespace.ModifyIndex = index
+	}
+
+	// Insert the namespace
+	if err := txn.Insert("namespaces", namespace); err != nil {
+		return fmt.Errorf("namespace insert failed: %v", err)
+	}
+
+	if err := txn.Insert("index", &IndexEntry{"namespaces", index}); err != nil {
+		return fmt.Errorf("index update failed: %v", err)
+	}
+
+	return nil
+}
+
+// DeleteNamespaces is used to remove a set of namespaces. Deleting the default
+// namespace or a namespace that still contains jobs is refused.
+func (s *StateStore) DeleteNamespaces(index uint64, names []string) error {
+	txn := s.db.Txn(true)
+	defer txn.Abort()
+
+	for _, name := range names {
+		// Look for existing namespace
+		existing, err := txn.First("namespaces", "id", name)
+		if err != nil {
+			return fmt.Errorf("namespace lookup failed: %v", err)
+		}
+		if existing == nil {
+			return fmt.Errorf("namespace %q not found", name)
+		}
+
+		if name == structs.DefaultNamespace {
+			return fmt.Errorf("default namespace can not be deleted")
+		}
+
+		// Ensure that the namespace doesn't have any jobs
+		iter, err := s.jobsByNamespaceImpl(nil, name, txn)
+		if err != nil {
+			return err
+		}
+		if raw := iter.Next(); raw != nil {
+			return fmt.Errorf("namespace %q contains at least one job %q. "+
+				"All jobs must be purged from the namespace before it can be deleted",
+				name, raw.(*structs.Job).ID)
+		}
+
+		// Delete the namespace
+		if err := txn.Delete("namespaces", existing); err != nil {
+			return fmt.Errorf("namespace deletion failed: %v", err)
+		}
+	}
+
+	if err := txn.Insert("index", &IndexEntry{"namespaces", index}); err != nil {
+		return fmt.Errorf("index update failed: %v", err)
+	}
+
+	txn.Commit()
+	return nil
+}
+
+// Namespaces returns an iterator over all the namespaces
+func (s *StateStore) Namespaces(ws memdb.WatchSet) (memdb.ResultIterator, error) {
+	txn := s.db.Txn(false)
+
+	// Walk the entire namespace table
+	iter, err := txn.Get("namespaces", "id")
+	if err != nil {
+		return nil, err
+	}
+	ws.Add(iter.WatchCh())
+	return iter, nil
+}
+
+// NamespaceByName is used to lookup a namespace by name
+func (s *StateStore) NamespaceByName(ws memdb.WatchSet, name string) (*structs.Namespace, error) {
+	txn := s.db.Txn(false)
+	return s.namespaceByNameImpl(ws, txn, name)
+}
+
+// namespaceByNameImpl is used to lookup a namespace by name
+func (s *StateStore) namespaceByNameImpl(ws memdb.WatchSet, txn *memdb.Txn, name string) (*structs.Namespace, error) {
+	watchCh, existing, err := txn.FirstWatch("namespaces", "id", name)
+	if err != nil {
+		return nil, fmt.Errorf("namespace lookup failed: %v", err)
+	}
+	ws.Add(watchCh)
+
+	if existing != nil {
+		return existing.(*structs.Namespace), nil
+	}
+	return nil, nil
+}
+
+// NamespacesByNamePrefix is used to lookup namespaces by prefix
+func (s *StateStore) NamespacesByNamePrefix(ws memdb.WatchSet, namePrefix string) (memdb.ResultIterator, error) {
+	txn := s.db.Txn(false)
+
+	iter, err := txn.Get("namespaces", "id_prefix", namePrefix)
+	if err != nil {
+		return nil, fmt.Errorf("namespaces lookup failed: %v", err)
+	}
+	ws.Add(iter.WatchCh())
+
+	return iter, nil
+}
+
+// UpsertAdmissionPolicies is used to create or update a set of admission
+// policies
+func (s *StateStore) UpsertAdmissionPolicies(index uint64, policies []*structs.AdmissionPolicy) error {
+	txn := s.db.Txn(true)
+	defer txn.Abort()
+
+	for _, policy := range policies {
+		// Ensure the policy hash is non-nil. This should be done outside the state store
+		// for performance reasons, but we check here for defense in depth.
+		if len(policy.Hash) == 0 {
+			policy.SetHash()
+		}
+
+		// Check if the policy already exists
+		existing, err := txn.First("admission_policy", "id", policy.Name)
+		if err != nil {
+			return fmt.Errorf("policy lookup failed: %v", err)
+		}
+
+		// Update all the indexes
+		if existing != nil {
+			policy.CreateIndex = existing.(*structs.AdmissionPolicy).CreateIndex
+			policy.ModifyIndex = index
+		} else {
+			policy.CreateIndex = index
+			policy.ModifyIndex = index
+		}
+
+		// Update the policy
+		if err := txn.Insert("admission_policy", policy); err != nil {
+			return fmt.Errorf("upserting policy failed: %v", err)
+		}
+	}
+
+	// Update the indexes table
+	if err := txn.Insert("index", &IndexEntry{"admission_policy", index}); err != nil {
+		return fmt.Errorf("index update failed: %v", err)
+	}
+
+	txn.Commit()
+	return nil
+}
+
+// DeleteAdmissionPolicies deletes the admission policies with the given names
+func (s *StateStore) DeleteAdmissionPolicies(index uint64, names []string) error {
+	txn := s.db.Txn(true)
+	defer txn.Abort()
+
+	// Delete the policy
+	for _, name := range names {
+		if _, err := txn.DeleteAll("admission_policy", "id", name); err != nil {
+			return fmt.Errorf("deleting admission policy failed: %v", err)
+		}
+	}
+	if err := txn.Insert("index", &IndexEntry{"admission_policy", index}); err != nil {
+		return fmt.Errorf("index update failed: %v", err)
+	}
+	txn.Commit()
+	return nil
+}
+
+// AdmissionPolicyByName is used to lookup an admission policy by name
+func (s *StateStore) AdmissionPolicyByName(ws memdb.WatchSet, name string) (*structs.AdmissionPolicy, error) {
+	txn := s.db.Txn(false)
+
+	watchCh, existing, err := txn.FirstWatch("admission_policy", "id", name)
+	if err != nil {
+		return nil, fmt.Errorf("admission policy lookup failed: %v", err)
+	}
+	ws.Add(watchCh)
+
+	if existing != nil {
+		return existing.(*structs.AdmissionPolicy), nil
+	}
+	return nil, nil
+}
+
+// AdmissionPolicyByNamePrefix is used to lookup admission policies by prefix
+func (s *StateStore) AdmissionPolicyByNamePrefix(ws memdb.WatchSet, prefix string) (memdb.ResultIterator, error) {
+	txn := s.db.Txn(false)
+
+	iter, err := txn.Get("admission_policy", "id_prefix", prefix)
+	if err != nil {
+		return nil, fmt.Errorf("admission policy lookup failed: %v", err)
+	}
+	ws.Add(iter.WatchCh())
+
+	return iter, nil
+}
+
+// AdmissionPolicies returns an iterator over all the admission policies
+func (s *StateStore) AdmissionPolicies(ws memdb.WatchSet) (memdb.ResultIterator, error) {
+	txn := s.db.Txn(false)
+
+	// Walk the entire table
+	iter, err := txn.Get("admission_policy", "id")
+	if err != nil {
+		return nil, err
+	}
+	ws.Add(iter.WatchCh())
+	return iter, nil
+}
+
 // UpsertACLPolicies is used to create or update a set of ACL policies
 func (s *StateStore) UpsertACLPolicies(index uint64, policies []*structs.ACLPolicy) error {
 	txn := s.db.Txn(true)
@@ -4126,6 +5285,30 @@ func (r *StateRestore) IndexRestore(idx *IndexEntry) error {
 	return nil
 }
 
+// DispatchTokenRestore is used to restore a dispatch idempotency token
+func (r *StateRestore) DispatchTokenRestore(token *structs.DispatchToken) error {
+	if err := r.txn.Insert("dispatch_tokens", token); err != nil {
+		return fmt.Errorf("dispatch token insert failed: %v", err)
+	}
+	return nil
+}
+
+// JobSubmissionRestore is used to restore the source of a job version
+func (r *StateRestore) JobSubmissionRestore(submission *structs.JobSubmission) error {
+	if err := r.txn.Insert("job_submission", submission); err != nil {
+		return fmt.Errorf("job submission insert failed: %v", err)
+	}
+	return nil
+}
+
+// QueuedDispatchRestore is used to restore a queued dispatch
+func (r *StateRestore) QueuedDispatchRestore(dispatch *structs.QueuedDispatch) error {
+	if err := r.txn.Insert("dispatch_queue", dispatch); err != nil {
+		return fmt.Errorf("queued dispatch insert failed: %v", err)
+	}
+	return nil
+}
+
 // PeriodicLaunchRestore is used to restore a periodic launch.
 func (r *StateRestore) PeriodicLaunchRestore(launch *structs.PeriodicLaunch) error {
 	if err := r.txn.Insert("periodic_launch", launch); err != nil {
@@ -4166,6 +5349,38 @@ func (r *StateRestore) VaultAccessorRestore(accessor *structs.VaultAccessor) err
 	return nil
 }
 
+// NamespaceRestore is used to restore a namespace
+func (r *StateRestore) NamespaceRestore(ns *structs.Namespace) error {
+	if err := r.txn.Insert("namespaces", ns); err != nil {
+		return fmt.Errorf("namespace insert failed: %v", err)
+	}
+	return nil
+}
+
+// AdmissionPolicyRestore is used to restore an admission policy
+func (r *StateRestore) AdmissionPolicyRestore(policy *structs.AdmissionPolicy) error {
+	if err := r.txn.Insert("admission_policy", policy); err != nil {
+		return fmt.Errorf("inserting admission policy failed: %v", err)
+	}
+	return nil
+}
+
+// ScalingEventsRestore is used to restore the scaling events of a job
+func (r *StateRestore) ScalingEventsRestore(events *structs.JobScalingEvents) error {
+	if err := r.txn.Insert("scaling_event", events); err != nil {
+		return fmt.Errorf("scaling event insert failed: %v", err)
+	}
+	return nil
+}
+
+// ScalingPolicyRestore is used to restore a scaling policy
+func (r *StateRestore) ScalingPolicyRestore(policy *structs.ScalingPolicy) error {
+	if err := r.txn.Insert("scaling_policy", policy); err != nil {
+		return fmt.Errorf("scaling policy insert failed: %v", err)
+	}
+	return nil
+}
+
 // ACLPolicyRestore is used to restore an ACL policy
 func (r *StateRestore) ACLPolicyRestore(policy *structs.ACLPolicy) error {
 	if err := r.txn.Insert("acl_policy", policy); err != nil {
@@ -2636,6 +2636,503 @@ func TestStateStore_RestorePeriodicLaunch(t *testing.T) {
 	}
 }
 
+func TestStateStore_QueuedDispatches(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	state := testStateStore(t)
+
+	// Make a parameterized job
+	parent := mock.BatchJob()
+	parent.ParameterizedJob = &structs.ParameterizedJobConfig{
+		MaxConcurrent: 1,
+	}
+	require.NoError(state.UpsertJob(1000, parent))
+
+	// Queue two dispatched jobs
+	var queued []*structs.QueuedDispatch
+	for i := 0; i < 2; i++ {
+		child := parent.Copy()
+		child.ID = structs.DispatchedID(parent.ID, time.Now())
+		child.ParentID = parent.ID
+		child.Dispatched = true
+		dispatch := &structs.QueuedDispatch{
+			ID:        child.ID,
+			Namespace: child.Namespace,
+			ParentID:  parent.ID,
+			Job:       child,
+		}
+		require.NoError(state.UpsertQueuedDispatch(uint64(1001+i), dispatch))
+		queued = append(queued, dispatch)
+	}
+
+	ws := memdb.NewWatchSet()
+	out, err := state.QueuedDispatchesByParent(ws, parent.Namespace, parent.ID)
+	require.NoError(err)
+	require.Len(out, 2)
+	require.Equal(queued[0].ID, out[0].ID)
+	require.Equal(uint64(1001), out[0].CreateIndex)
+	require.Equal(queued[1].ID, out[1].ID)
+
+	summary, err := state.JobSummaryByID(ws, parent.Namespace, parent.ID)
+	require.NoError(err)
+	require.EqualValues(2, summary.Children.Queued)
+
+	index, err := state.Index("dispatch_queue")
+	require.NoError(err)
+	require.EqualValues(1002, index)
+
+	// Registering the first dispatched job releases it from the queue
+	require.NoError(state.UpsertJob(1003, queued[0].Job))
+	require.True(watchFired(ws))
+
+	out, err = state.QueuedDispatchesByParent(nil, parent.Namespace, parent.ID)
+	require.NoError(err)
+	require.Len(out, 1)
+	require.Equal(queued[1].ID, out[0].ID)
+
+	summary, err = state.JobSummaryByID(nil, parent.Namespace, parent.ID)
+	require.NoError(err)
+	require.EqualValues(1, summary.Children.Queued)
+	require.EqualValues(1, summary.Children.Pending)
+
+	// Deleting the parameterized job drops the remaining queued jobs
+	require.NoError(state.DeleteJob(1004, parent.Namespace, parent.ID))
+
+	out, err = state.QueuedDispatchesByParent(nil, parent.Namespace, parent.ID)
+	require.NoError(err)
+	require.Empty(out)
+
+	index, err = state.Index("dispatch_queue")
+	require.NoError(err)
+	require.EqualValues(1004, index)
+}
+
+func TestStateStore_ReconcileParentJobSummary_Queued(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	state := testStateStore(t)
+
+	parent := mock.BatchJob()
+	parent.ParameterizedJob = &structs.ParameterizedJobConfig{
+		MaxConcurrent: 1,
+	}
+	require.NoError(state.UpsertJob(100, parent))
+
+	child := parent.Copy()
+	child.ID = structs.DispatchedID(parent.ID, time.Now())
+	child.ParentID = parent.ID
+	child.Dispatched = true
+	require.NoError(state.UpsertQueuedDispatch(110, &structs.QueuedDispatch{
+		ID:        child.ID,
+		Namespace: child.Namespace,
+		ParentID:  parent.ID,
+		Job:       child,
+	}))
+
+	// Make the summary incorrect in the state store
+	summary, err := state.JobSummaryByID(nil, parent.Namespace, parent.ID)
+	require.NoError(err)
+	summary = summary.Copy()
+	summary.Children = nil
+	require.NoError(state.UpsertJobSummary(115, summary))
+
+	require.NoError(state.ReconcileJobSummaries(120))
+
+	summary, err = state.JobSummaryByID(nil, parent.Namespace, parent.ID)
+	require.NoError(err)
+	require.Equal(&structs.JobChildrenSummary{Queued: 1}, summary.Children)
+}
+
+func TestStateStore_RestoreQueuedDispatch(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	state := testStateStore(t)
+
+	parent := mock.BatchJob()
+	child := parent.Copy()
+	child.ID = structs.DispatchedID(parent.ID, time.Now())
+	child.ParentID = parent.ID
+	dispatch := &structs.QueuedDispatch{
+		ID:          child.ID,
+		Namespace:   child.Namespace,
+		ParentID:    parent.ID,
+		Job:         child,
+		CreateIndex: 1000,
+		ModifyIndex: 1000,
+	}
+
+	restore, err := state.Restore()
+	require.NoError(err)
+	require.NoError(restore.QueuedDispatchRestore(dispatch))
+	restore.Commit()
+
+	out, err := state.QueuedDispatchesByParent(nil, parent.Namespace, parent.ID)
+	require.NoError(err)
+	require.Equal([]*structs.QueuedDispatch{dispatch}, out)
+}
+
+func TestStateStore_JobSubmissions(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	state := testStateStore(t)
+
+	job := mock.Job()
+	require.NoError(state.UpsertJob(1000, job))
+
+	// Submissions of unknown job versions are rejected
+	require.Error(state.UpsertJobSubmission(1001, &structs.JobSubmission{
+		Namespace: job.Namespace,
+		JobID:     job.ID,
+		Version:   1,
+	}))
+
+	sub := &structs.JobSubmission{
+		Source:    `job "example" {}`,
+		Format:    structs.JobSubmissionFormatHCL1,
+		Namespace: job.Namespace,
+		JobID:     job.ID,
+		Version:   0,
+	}
+	ws := memdb.NewWatchSet()
+	_, err := state.JobSubmission(ws, job.Namespace, job.ID, 0)
+	require.NoError(err)
+	require.NoError(state.UpsertJobSubmission(1001, sub))
+	require.True(watchFired(ws))
+
+	out, err := state.JobSubmission(nil, job.Namespace, job.ID, 0)
+	require.NoError(err)
+	require.Equal(sub, out)
+	require.Equal(uint64(1000), out.JobModifyIndex)
+
+	index, err := state.Index("job_submission")
+	require.NoError(err)
+	require.Equal(uint64(1001), index)
+
+	// Submissions are removed with their GC'd job versions
+	for i := 1; i <= structs.JobTrackedVersions; i++ {
+		update := job.Copy()
+		update.Meta = map[string]string{"version": fmt.Sprintf("%d", i)}
+		require.NoError(state.UpsertJob(uint64(1001+2*i), update))
+		require.NoError(state.UpsertJobSubmission(uint64(1002+2*i), &structs.JobSubmission{
+			Format:    structs.JobSubmissionFormatHCL1,
+			Namespace: job.Namespace,
+			JobID:     job.ID,
+			Version:   uint64(i),
+		}))
+	}
+	out, err = state.JobSubmission(nil, job.Namespace, job.ID, 0)
+	require.NoError(err)
+	require.Nil(out)
+	out, err = state.JobSubmission(nil, job.Namespace, job.ID, 1)
+	require.NoError(err)
+	require.NotNil(out)
+
+	// Submissions are removed with their job
+	require.NoError(state.DeleteJob(2000, job.Namespace, job.ID))
+	iter, err := state.JobSubmissions(nil)
+	require.NoError(err)
+	require.Nil(iter.Next())
+}
+
+func TestStateStore_ScalingEvents(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	state := testStateStore(t)
+
+	job := mock.Job()
+	require.NoError(state.UpsertJob(1000, job))
+	group := job.TaskGroups[0].Name
+
+	ws := memdb.NewWatchSet()
+	events, index, err := state.ScalingEventsByJob(ws, job.Namespace, job.ID)
+	require.NoError(err)
+	require.Nil(events)
+	require.Zero(index)
+
+	req := &structs.ScalingEventRequest{
+		Namespace: job.Namespace,
+		JobID:     job.ID,
+		TaskGroup: group,
+		ScalingEvent: &structs.ScalingEvent{
+			Count:         helper.Int64ToPtr(5),
+			PreviousCount: 10,
+			Message:       "scale in",
+		},
+		JobModifyIndex: 1000,
+	}
+	require.NoError(state.UpsertScalingEvent(1001, req))
+	require.True(watchFired(ws))
+
+	events, index, err = state.ScalingEventsByJob(nil, job.Namespace, job.ID)
+	require.NoError(err)
+	require.Equal(uint64(1001), index)
+	require.Len(events[group], 1)
+	require.Equal("scale in", events[group][0].Message)
+	require.Equal(uint64(1001), events[group][0].CreateIndex)
+
+	// The count of the current version of the job is updated
+	out, err := state.JobByID(nil, job.Namespace, job.ID)
+	require.NoError(err)
+	require.Equal(5, out.TaskGroups[0].Count)
+	require.Zero(out.Version)
+	require.Equal(uint64(1001), out.JobModifyIndex)
+	version, err := state.JobByIDAndVersion(nil, job.Namespace, job.ID, 0)
+	require.NoError(err)
+	require.Equal(10, version.TaskGroups[0].Count)
+
+	// The count isn't updated if the job was modified in the meantime
+	err = state.UpsertScalingEvent(1002, req)
+	require.Error(err)
+	require.Contains(err.Error(), "was modified while being scaled")
+
+	// Only the most recent events are kept, most recent first
+	req.ScalingEvent.Count = nil
+	for i := 0; i < structs.JobTrackedScalingEvents; i++ {
+		req.ScalingEvent.Message = fmt.Sprintf("event %d", i)
+		require.NoError(state.UpsertScalingEvent(uint64(1002+i), req))
+	}
+	events, _, err = state.ScalingEventsByJob(nil, job.Namespace, job.ID)
+	require.NoError(err)
+	require.Len(events[group], structs.JobTrackedScalingEvents)
+	require.Equal(fmt.Sprintf("event %d", structs.JobTrackedScalingEvents-1), events[group][0].Message)
+	require.Equal("event 0", events[group][structs.JobTrackedScalingEvents-1].Message)
+
+	// Events are removed with their job
+	require.NoError(state.DeleteJob(2000, job.Namespace, job.ID))
+	events, _, err = state.ScalingEventsByJob(nil, job.Namespace, job.ID)
+	require.NoError(err)
+	require.Nil(events)
+
+	index, err = state.Index("scaling_event")
+	require.NoError(err)
+	require.Equal(uint64(2000), index)
+}
+
+func TestStateStore_ScalingEvents_Deployment(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	state := testStateStore(t)
+
+	job := mock.Job()
+	require.NoError(state.UpsertJob(1000, job))
+	group := job.TaskGroups[0].Name
+
+	// An active deployment of the current version and a terminal one
+	active := mock.Deployment()
+	active.JobID = job.ID
+	active.JobVersion = job.Version
+	active.JobCreateIndex = job.CreateIndex
+	active.TaskGroups = map[string]*structs.DeploymentState{group: {DesiredTotal: 10}}
+	require.NoError(state.UpsertDeployment(1001, active))
+
+	done := active.Copy()
+	done.ID = uuid.Generate()
+	done.Status = structs.DeploymentStatusSuccessful
+	require.NoError(state.UpsertDeployment(1002, done))
+
+	req := &structs.ScalingEventRequest{
+		Namespace: job.Namespace,
+		JobID:     job.ID,
+		TaskGroup: group,
+		ScalingEvent: &structs.ScalingEvent{
+			Count:         helper.Int64ToPtr(5),
+			PreviousCount: 10,
+			Message:       "scale in",
+		},
+		JobModifyIndex: 1000,
+	}
+	require.NoError(state.UpsertScalingEvent(1003, req))
+
+	// The active deployment tracks the new count
+	out, err := state.DeploymentByID(nil, active.ID)
+	require.NoError(err)
+	require.Equal(5, out.TaskGroups[group].DesiredTotal)
+	require.Equal(uint64(1003), out.ModifyIndex)
+
+	// The terminal deployment is left alone
+	out, err = state.DeploymentByID(nil, done.ID)
+	require.NoError(err)
+	require.Equal(10, out.TaskGroups[group].DesiredTotal)
+	require.Equal(uint64(1002), out.ModifyIndex)
+}
+
+func TestStateStore_ScalingPolicies(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	state := testStateStore(t)
+
+	job := mock.Job()
+	job.TaskGroups[0].Scaling = &structs.ScalingPolicy{
+		ID:      uuid.Generate(),
+		Min:     1,
+		Max:     20,
+		Enabled: true,
+	}
+	policyID := job.TaskGroups[0].Scaling.ID
+	group := job.TaskGroups[0].Name
+
+	ws := memdb.NewWatchSet()
+	_, err := state.ScalingPolicyByID(ws, policyID)
+	require.NoError(err)
+
+	require.NoError(state.UpsertJob(1000, job))
+	require.True(watchFired(ws))
+
+	// The policy is targeted at the task group of the job
+	policy, err := state.ScalingPolicyByTarget(nil, job.Namespace, job.ID, group)
+	require.NoError(err)
+	require.NotNil(policy)
+	require.Equal(policyID, policy.ID)
+	require.Equal(job.ID, policy.Target[structs.ScalingTargetJob])
+	require.Equal(uint64(1000), policy.CreateIndex)
+	require.Equal(uint64(1000), policy.ModifyIndex)
+
+	iter, err := state.ScalingPoliciesByNamespace(nil, job.Namespace)
+	require.NoError(err)
+	require.Len(scalingPolicyIDs(iter), 1)
+
+	// Prefixes of the job ID don't match the policies of the job
+	iter, err = state.ScalingPoliciesByJob(nil, job.Namespace, job.ID[:4])
+	require.NoError(err)
+	require.Empty(scalingPolicyIDs(iter))
+
+	// Resubmitting the job without changing the policy leaves it untouched
+	job2 := job.Copy()
+	require.NoError(state.UpsertJob(1001, job2))
+	policy, err = state.ScalingPolicyByID(nil, policyID)
+	require.NoError(err)
+	require.Equal(uint64(1000), policy.ModifyIndex)
+
+	// Changing the policy updates it in place
+	job3 := job.Copy()
+	job3.TaskGroups[0].Scaling.Max = 30
+	require.NoError(state.UpsertJob(1002, job3))
+	policy, err = state.ScalingPolicyByID(nil, policyID)
+	require.NoError(err)
+	require.Equal(int64(30), policy.Max)
+	require.Equal(uint64(1000), policy.CreateIndex)
+	require.Equal(uint64(1002), policy.ModifyIndex)
+
+	// Removing the policy from the group deletes it
+	job4 := job.Copy()
+	job4.TaskGroups[0].Scaling = nil
+	require.NoError(state.UpsertJob(1003, job4))
+	policy, err = state.ScalingPolicyByID(nil, policyID)
+	require.NoError(err)
+	require.Nil(policy)
+
+	// Policies are removed with their job
+	require.NoError(state.UpsertJob(1004, job3.Copy()))
+	require.NoError(state.DeleteJob(1005, job.Namespace, job.ID))
+	iter, err = state.ScalingPolicies(nil)
+	require.NoError(err)
+	require.Empty(scalingPolicyIDs(iter))
+
+	index, err := state.Index("scaling_policy")
+	require.NoError(err)
+	require.Equal(uint64(1005), index)
+}
+
+func TestStateStore_ScalingPolicies_ChildJob(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	state := testStateStore(t)
+
+	parent := mock.Job()
+	parent.TaskGroups[0].Scaling = &structs.ScalingPolicy{
+		ID:  uuid.Generate(),
+		Max: 10,
+	}
+	require.NoError(state.UpsertJob(1000, parent))
+
+	// Jobs launched from a parent don't have policies of their own
+	child := parent.Copy()
+	child.ID = parent.ID + "/child"
+	child.ParentID = parent.ID
+	require.NoError(state.UpsertJob(1001, child))
+
+	iter, err := state.ScalingPolicies(nil)
+	require.NoError(err)
+	require.Equal([]string{parent.TaskGroups[0].Scaling.ID}, scalingPolicyIDs(iter))
+}
+
+// scalingPolicyIDs returns the IDs of the scaling policies of the iterator
+func scalingPolicyIDs(iter memdb.ResultIterator) []string {
+	var ids []string
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		ids = append(ids, raw.(*structs.ScalingPolicy).ID)
+	}
+	return ids
+}
+
+func TestStateStore_DispatchTokens(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	state := testStateStore(t)
+
+	parent := mock.BatchJob()
+	parent.ParameterizedJob = &structs.ParameterizedJobConfig{}
+	require.NoError(state.UpsertJob(1000, parent))
+
+	// Register a job dispatched with a token
+	child := parent.Copy()
+	child.ID = structs.DispatchedID(parent.ID, time.Now())
+	child.ParentID = parent.ID
+	child.Dispatched = true
+	child.DispatchIdempotencyToken = "foo"
+	child.SetSubmitTime()
+	require.NoError(state.UpsertJob(1001, child))
+
+	ws := memdb.NewWatchSet()
+	token, err := state.DispatchTokenByID(ws, parent.Namespace, parent.ID, "foo")
+	require.NoError(err)
+	require.NotNil(token)
+	require.Equal(child.ID, token.JobID)
+	require.Equal(child.SubmitTime, token.CreateTime)
+	require.EqualValues(1001, token.CreateIndex)
+
+	// Updating the dispatched job doesn't touch the token
+	require.NoError(state.UpsertJob(1002, child.Copy()))
+	token, err = state.DispatchTokenByID(nil, parent.Namespace, parent.ID, "foo")
+	require.NoError(err)
+	require.EqualValues(1001, token.ModifyIndex)
+
+	// Queue a job dispatched with another token
+	queued := child.Copy()
+	queued.ID = structs.DispatchedID(parent.ID, time.Now())
+	queued.DispatchIdempotencyToken = "bar"
+	require.NoError(state.UpsertQueuedDispatch(1003, &structs.QueuedDispatch{
+		ID:        queued.ID,
+		Namespace: queued.Namespace,
+		ParentID:  parent.ID,
+		Job:       queued,
+	}))
+
+	token, err = state.DispatchTokenByID(nil, parent.Namespace, parent.ID, "bar")
+	require.NoError(err)
+	require.NotNil(token)
+	require.Equal(queued.ID, token.JobID)
+
+	// Deleting the dispatched job deletes its token
+	require.NoError(state.DeleteJob(1004, child.Namespace, child.ID))
+	require.True(watchFired(ws))
+
+	token, err = state.DispatchTokenByID(nil, parent.Namespace, parent.ID, "foo")
+	require.NoError(err)
+	require.Nil(token)
+
+	// Deleting the parameterized job deletes the tokens of queued jobs
+	require.NoError(state.DeleteJob(1005, parent.Namespace, parent.ID))
+
+	token, err = state.DispatchTokenByID(nil, parent.Namespace, parent.ID, "bar")
+	require.NoError(err)
+	require.Nil(token)
+
+	index, err := state.Index("dispatch_tokens")
+	require.NoError(err)
+	require.EqualValues(1005, index)
+}
+
 func TestStateStore_RestoreJobVersion(t *testing.T) {
 	state := testStateStore(t)
 	job := mock.Job()
@@ -5777,6 +6274,170 @@ func TestStateStore_UpdateJobStability(t *testing.T) {
 	}
 }
 
+func TestStateStore_UpdateJobVersionTag(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	state := testStateStore(t)
+
+	// Insert a job twice to get two versions
+	job := mock.Job()
+	require.NoError(state.UpsertJob(1, job.Copy()))
+	require.NoError(state.UpsertJob(2, job.Copy()))
+
+	// Tag the first version
+	req := &structs.JobTagRequest{
+		JobID:       job.ID,
+		Name:        "release",
+		Description: "first release",
+		Version:     helper.Uint64ToPtr(0),
+		TaggedTime:  42,
+	}
+	require.NoError(state.UpdateJobVersionTag(3, job.Namespace, req))
+
+	ws := memdb.NewWatchSet()
+	out, err := state.JobByIDAndVersion(ws, job.Namespace, job.ID, 0)
+	require.NoError(err)
+	require.Equal(&structs.JobVersionTag{Name: "release", Description: "first release", TaggedTime: 42}, out.VersionTag)
+
+	tagged, err := state.JobVersionByTagName(ws, job.Namespace, job.ID, "release")
+	require.NoError(err)
+	require.EqualValues(0, tagged.Version)
+
+	// The current version is untouched
+	cur, err := state.JobByID(ws, job.Namespace, job.ID)
+	require.NoError(err)
+	require.EqualValues(1, cur.Version)
+	require.Nil(cur.VersionTag)
+
+	// The tag name can't be reused on another version
+	req.Version = helper.Uint64ToPtr(1)
+	err = state.UpdateJobVersionTag(4, job.Namespace, req)
+	require.Error(err)
+	require.Contains(err.Error(), "already exists on version 0")
+
+	// Tagging the current version updates the current job
+	req.Name = "latest"
+	require.NoError(state.UpdateJobVersionTag(5, job.Namespace, req))
+	cur, err = state.JobByID(ws, job.Namespace, job.ID)
+	require.NoError(err)
+	require.NotNil(cur.VersionTag)
+	require.Equal("latest", cur.VersionTag.Name)
+	require.EqualValues(5, cur.ModifyIndex)
+	require.EqualValues(1, cur.Version)
+
+	index, err := state.Index("jobs")
+	require.NoError(err)
+	require.EqualValues(5, index)
+
+	// Unset the tag
+	unset := &structs.JobTagRequest{
+		JobID: job.ID,
+		Name:  "release",
+		Unset: true,
+	}
+	require.NoError(state.UpdateJobVersionTag(6, job.Namespace, unset))
+	out, err = state.JobByIDAndVersion(ws, job.Namespace, job.ID, 0)
+	require.NoError(err)
+	require.Nil(out.VersionTag)
+
+	// Unsetting a missing tag fails
+	err = state.UpdateJobVersionTag(7, job.Namespace, unset)
+	require.Error(err)
+	require.Contains(err.Error(), `has no version tagged "release"`)
+}
+
+// TestStateStore_UpsertJob_TaggedVersions asserts that tagged job versions are
+// not garbage collected and don't count towards the tracked versions limit.
+func TestStateStore_UpsertJob_TaggedVersions(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	state := testStateStore(t)
+
+	job := mock.Job()
+	require.NoError(state.UpsertJob(1000, job.Copy()))
+
+	// Tag the first version
+	req := &structs.JobTagRequest{
+		JobID:   job.ID,
+		Name:    "first",
+		Version: helper.Uint64ToPtr(0),
+	}
+	require.NoError(state.UpdateJobVersionTag(1001, job.Namespace, req))
+
+	// Register many more versions
+	for i := 1; i < 20; i++ {
+		update := job.Copy()
+		update.Meta = map[string]string{"version": fmt.Sprintf("%d", i)}
+		require.NoError(state.UpsertJob(uint64(1001+i), update))
+	}
+
+	ws := memdb.NewWatchSet()
+	versions, err := state.JobVersionsByID(ws, job.Namespace, job.ID)
+	require.NoError(err)
+	require.Len(versions, structs.JobTrackedVersions+1)
+	require.EqualValues(19, versions[0].Version)
+	require.EqualValues(0, versions[len(versions)-1].Version)
+	require.Equal("first", versions[len(versions)-1].VersionTag.Name)
+
+	// Once untagged, the version is GCed by the next registration
+	unset := &structs.JobTagRequest{
+		JobID: job.ID,
+		Name:  "first",
+		Unset: true,
+	}
+	require.NoError(state.UpdateJobVersionTag(2000, job.Namespace, unset))
+	require.NoError(state.UpsertJob(2001, job.Copy()))
+
+	versions, err = state.JobVersionsByID(ws, job.Namespace, job.ID)
+	require.NoError(err)
+	require.Len(versions, structs.JobTrackedVersions)
+	require.EqualValues(20, versions[0].Version)
+	require.EqualValues(15, versions[len(versions)-1].Version)
+}
+
+// TestStateStore_UpdateJobVersionTag_Max asserts that the number of tagged
+// versions of a job is capped.
+func TestStateStore_UpdateJobVersionTag_Max(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	state := testStateStore(t)
+
+	// Register and tag as many versions as allowed, plus an untagged one
+	job := mock.Job()
+	index := uint64(1000)
+	for i := 0; i <= structs.JobMaxTaggedVersions; i++ {
+		index++
+		update := job.Copy()
+		update.Meta = map[string]string{"version": fmt.Sprintf("%d", i)}
+		require.NoError(state.UpsertJob(index, update))
+
+		if i == structs.JobMaxTaggedVersions {
+			break
+		}
+		index++
+		req := &structs.JobTagRequest{
+			JobID:   job.ID,
+			Name:    fmt.Sprintf("tag-%d", i),
+			Version: helper.Uint64ToPtr(uint64(i)),
+		}
+		require.NoError(state.UpdateJobVersionTag(index, job.Namespace, req))
+	}
+
+	// Tagging another version fails
+	req := &structs.JobTagRequest{
+		JobID:   job.ID,
+		Name:    "one-too-many",
+		Version: helper.Uint64ToPtr(uint64(structs.JobMaxTaggedVersions)),
+	}
+	err := state.UpdateJobVersionTag(index+1, job.Namespace, req)
+	require.Error(err)
+	require.Contains(err.Error(), "maximum of 10 tagged versions")
+
+	// Retagging a tagged version still works
+	req.Version = helper.Uint64ToPtr(0)
+	require.NoError(state.UpdateJobVersionTag(index+2, job.Namespace, req))
+}
+
 // Test that nonexistent deployment can't be promoted
 func TestStateStore_UpsertDeploymentPromotion_Nonexistent(t *testing.T) {
 	state := testStateStore(t)
@@ -7297,3 +7958,244 @@ func (n AllocIDSort) Less(i, j int) bool {
 func (n AllocIDSort) Swap(i, j int) {
 	n[i], n[j] = n[j], n[i]
 }
+
+func TestStateStore_DefaultNamespace(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	state := testStateStore(t)
+
+	ns, err := state.NamespaceByName(nil, structs.DefaultNamespace)
+	require.NoError(err)
+	require.NotNil(ns)
+	require.Equal(structs.DefaultNamespaceDescription, ns.Description)
+
+	// The default namespace can't be deleted
+	err = state.DeleteNamespaces(1000, []string{structs.DefaultNamespace})
+	require.Error(err)
+	require.Contains(err.Error(), "can not be deleted")
+}
+
+func TestStateStore_UpsertNamespaces(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	state := testStateStore(t)
+	ns1 := mock.Namespace()
+	ns2 := mock.Namespace()
+
+	ws := memdb.NewWatchSet()
+	_, err := state.NamespaceByName(ws, ns1.Name)
+	require.NoError(err)
+
+	require.NoError(state.UpsertNamespaces(1000, []*structs.Namespace{ns1, ns2}))
+	require.True(watchFired(ws))
+
+	ws = memdb.NewWatchSet()
+	out, err := state.NamespaceByName(ws, ns1.Name)
+	require.NoError(err)
+	require.Equal(ns1, out)
+	require.EqualValues(1000, out.CreateIndex)
+
+	iter, err := state.Namespaces(ws)
+	require.NoError(err)
+	count := 0
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		count++
+	}
+	require.Equal(3, count)
+
+	index, err := state.Index("namespaces")
+	require.NoError(err)
+	require.EqualValues(1000, index)
+	require.False(watchFired(ws))
+
+	// Updating a namespace keeps its create index
+	ns1 = ns1.Copy()
+	ns1.Description = "updated"
+	require.NoError(state.UpsertNamespaces(1001, []*structs.Namespace{ns1}))
+	out, err = state.NamespaceByName(nil, ns1.Name)
+	require.NoError(err)
+	require.Equal("updated", out.Description)
+	require.EqualValues(1000, out.CreateIndex)
+	require.EqualValues(1001, out.ModifyIndex)
+}
+
+func TestStateStore_DeleteNamespaces(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	state := testStateStore(t)
+	ns1 := mock.Namespace()
+	ns2 := mock.Namespace()
+	require.NoError(state.UpsertNamespaces(1000, []*structs.Namespace{ns1, ns2}))
+
+	ws := memdb.NewWatchSet()
+	_, err := state.NamespaceByName(ws, ns1.Name)
+	require.NoError(err)
+
+	require.NoError(state.DeleteNamespaces(1001, []string{ns1.Name, ns2.Name}))
+	require.True(watchFired(ws))
+
+	out, err := state.NamespaceByName(nil, ns1.Name)
+	require.NoError(err)
+	require.Nil(out)
+
+	index, err := state.Index("namespaces")
+	require.NoError(err)
+	require.EqualValues(1001, index)
+
+	// Deleting a missing namespace fails
+	err = state.DeleteNamespaces(1002, []string{ns1.Name})
+	require.Error(err)
+	require.Contains(err.Error(), "not found")
+}
+
+func TestStateStore_DeleteNamespaces_Jobs(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	state := testStateStore(t)
+	ns := mock.Namespace()
+	require.NoError(state.UpsertNamespaces(1000, []*structs.Namespace{ns}))
+
+	job := mock.Job()
+	job.Namespace = ns.Name
+	require.NoError(state.UpsertJob(1001, job))
+
+	// The namespace can't be deleted while it contains a job
+	err := state.DeleteNamespaces(1002, []string{ns.Name})
+	require.Error(err)
+	require.Contains(err.Error(), "contains at least one job")
+
+	out, err := state.NamespaceByName(nil, ns.Name)
+	require.NoError(err)
+	require.NotNil(out)
+
+	// Once the job is purged the namespace can be deleted
+	require.NoError(state.DeleteJob(1003, job.Namespace, job.ID))
+	require.NoError(state.DeleteNamespaces(1004, []string{ns.Name}))
+}
+
+func TestStateStore_RestoreNamespace(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	state := testStateStore(t)
+	ns := mock.Namespace()
+
+	restore, err := state.Restore()
+	require.NoError(err)
+	require.NoError(restore.NamespaceRestore(ns))
+	restore.Commit()
+
+	out, err := state.NamespaceByName(nil, ns.Name)
+	require.NoError(err)
+	require.Equal(ns, out)
+}
+
+func TestStateStore_UpsertAdmissionPolicies(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	state := testStateStore(t)
+	policy := mock.AdmissionPolicy()
+	policy2 := mock.AdmissionPolicy()
+
+	ws := memdb.NewWatchSet()
+	_, err := state.AdmissionPolicyByName(ws, policy.Name)
+	require.NoError(err)
+
+	require.NoError(state.UpsertAdmissionPolicies(1000, []*structs.AdmissionPolicy{policy, policy2}))
+	require.True(watchFired(ws))
+
+	ws = memdb.NewWatchSet()
+	out, err := state.AdmissionPolicyByName(ws, policy.Name)
+	require.NoError(err)
+	require.Equal(policy, out)
+	require.EqualValues(1000, out.CreateIndex)
+
+	iter, err := state.AdmissionPolicies(ws)
+	require.NoError(err)
+	count := 0
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		count++
+	}
+	require.Equal(2, count)
+
+	index, err := state.Index("admission_policy")
+	require.NoError(err)
+	require.EqualValues(1000, index)
+	require.False(watchFired(ws))
+
+	// Updating a policy keeps its create index
+	policy = policy.Copy()
+	policy.EnforcementLevel = structs.AdmissionPolicyEnforcementAdvisory
+	require.NoError(state.UpsertAdmissionPolicies(1001, []*structs.AdmissionPolicy{policy}))
+	out, err = state.AdmissionPolicyByName(nil, policy.Name)
+	require.NoError(err)
+	require.Equal(structs.AdmissionPolicyEnforcementAdvisory, out.EnforcementLevel)
+	require.EqualValues(1000, out.CreateIndex)
+	require.EqualValues(1001, out.ModifyIndex)
+}
+
+func TestStateStore_DeleteAdmissionPolicies(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	state := testStateStore(t)
+	policy := mock.AdmissionPolicy()
+	policy2 := mock.AdmissionPolicy()
+	require.NoError(state.UpsertAdmissionPolicies(1000, []*structs.AdmissionPolicy{policy, policy2}))
+
+	ws := memdb.NewWatchSet()
+	_, err := state.AdmissionPolicyByName(ws, policy.Name)
+	require.NoError(err)
+
+	require.NoError(state.DeleteAdmissionPolicies(1001, []string{policy.Name, policy2.Name}))
+	require.True(watchFired(ws))
+
+	out, err := state.AdmissionPolicyByName(nil, policy.Name)
+	require.NoError(err)
+	require.Nil(out)
+
+	iter, err := state.AdmissionPolicies(nil)
+	require.NoError(err)
+	require.Nil(iter.Next())
+
+	index, err := state.Index("admission_policy")
+	require.NoError(err)
+	require.EqualValues(1001, index)
+}
+
+func TestStateStore_AdmissionPolicyByNamePrefix(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	state := testStateStore(t)
+
+	names := []string{"foo", "bar", "foobar", "foozip", "zip"}
+	var policies []*structs.AdmissionPolicy
+	for _, name := range names {
+		p := mock.AdmissionPolicy()
+		p.Name = name
+		policies = append(policies, p)
+	}
+	require.NoError(state.UpsertAdmissionPolicies(1000, policies))
+
+	iter, err := state.AdmissionPolicyByNamePrefix(nil, "foo")
+	require.NoError(err)
+	var out []string
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		out = append(out, raw.(*structs.AdmissionPolicy).Name)
+	}
+	require.Equal([]string{"foo", "foobar", "foozip"}, out)
+}
+
+func TestStateStore_RestoreAdmissionPolicy(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	state := testStateStore(t)
+	policy := mock.AdmissionPolicy()
+
+	restore, err := state.Restore()
+	require.NoError(err)
+	require.NoError(restore.AdmissionPolicyRestore(policy))
+	restore.Commit()
+
+	out, err := state.AdmissionPolicyByName(nil, policy.Name)
+	require.NoError(err)
+	require.Equal(policy, out)
+}
@@ -0,0 +1,187 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+
+	multierror "github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/nomad/helper"
+)
+
+const (
+	// AdmissionWebhookOperationRegister is the operation of webhooks called
+	// when a job is registered.
+	AdmissionWebhookOperationRegister = "register"
+
+	// AdmissionWebhookOperationPlan is the operation of webhooks called when
+	// a job is planned.
+	AdmissionWebhookOperationPlan = "plan"
+
+	// AdmissionWebhookFailClosed rejects the job when a webhook can't be
+	// reached or returns an invalid response.
+	AdmissionWebhookFailClosed = "fail_closed"
+
+	// AdmissionWebhookFailOpen admits the job with a warning when a webhook
+	// can't be reached or returns an invalid response.
+	AdmissionWebhookFailOpen = "fail_open"
+
+	// DefaultAdmissionWebhookTimeout is the default time to wait for a
+	// webhook to respond.
+	DefaultAdmissionWebhookTimeout = 5 * time.Second
+)
+
+// AdmissionWebhookConfig configures an external HTTP endpoint called to
+// mutate or validate jobs before they are registered or planned.
+type AdmissionWebhookConfig struct {
+	// Name is the unique name of the webhook, used in errors and warnings.
+	Name string `hcl:",key"`
+
+	// URL is the address the job is POSTed to.
+	URL string `hcl:"url"`
+
+	// Operations is the set of operations the webhook is called for. It
+	// defaults to both register and plan.
+	Operations []string `hcl:"operations"`
+
+	// Timeout is the maximum time to wait for the webhook to respond.
+	Timeout    time.Duration `hcl:"-"`
+	TimeoutHCL string        `hcl:"timeout" json:"-"`
+
+	// FailurePolicy is either fail_closed, the default, or fail_open and
+	// controls whether jobs are admitted when the webhook fails.
+	FailurePolicy string `hcl:"failure_policy"`
+
+	// CAFile is the path to a PEM encoded CA certificate used to verify the
+	// webhook's certificate.
+	CAFile string `hcl:"ca_file"`
+
+	// TLSSkipVerify disables the verification of the webhook's certificate.
+	TLSSkipVerify bool `hcl:"tls_skip_verify"`
+
+	// ExtraKeysHCL is used by hcl to surface unexpected keys
+	ExtraKeysHCL []string `hcl:",unusedKeys" json:"-"`
+}
+
+// Canonicalize sets the defaults of unset fields.
+func (a *AdmissionWebhookConfig) Canonicalize() {
+	if len(a.Operations) == 0 {
+		a.Operations = []string{AdmissionWebhookOperationRegister, AdmissionWebhookOperationPlan}
+	}
+	if a.Timeout == 0 {
+		a.Timeout = DefaultAdmissionWebhookTimeout
+	}
+	if a.FailurePolicy == "" {
+		a.FailurePolicy = AdmissionWebhookFailClosed
+	}
+}
+
+// Validate returns an error if the webhook is misconfigured.
+func (a *AdmissionWebhookConfig) Validate() error {
+	var mErr multierror.Error
+	if a.Name == "" {
+		multierror.Append(&mErr, fmt.Errorf("missing name"))
+	}
+
+	if a.URL == "" {
+		multierror.Append(&mErr, fmt.Errorf("missing url"))
+	} else if u, err := url.Parse(a.URL); err != nil {
+		multierror.Append(&mErr, fmt.Errorf("invalid url: %v", err))
+	} else if u.Scheme != "http" && u.Scheme != "https" {
+		multierror.Append(&mErr, fmt.Errorf("url scheme must be http or https, got %q", u.Scheme))
+	}
+
+	for _, op := range a.Operations {
+		switch op {
+		case AdmissionWebhookOperationRegister, AdmissionWebhookOperationPlan:
+		default:
+			multierror.Append(&mErr, fmt.Errorf("unknown operation %q", op))
+		}
+	}
+
+	if a.Timeout < 0 {
+		multierror.Append(&mErr, fmt.Errorf("timeout must be positive"))
+	}
+
+	switch a.FailurePolicy {
+	case "", AdmissionWebhookFailClosed, AdmissionWebhookFailOpen:
+	default:
+		multierror.Append(&mErr, fmt.Errorf("failure_policy must be %q or %q, got %q",
+			AdmissionWebhookFailClosed, AdmissionWebhookFailOpen, a.FailurePolicy))
+	}
+
+	return mErr.ErrorOrNil()
+}
+
+// HandlesOperation returns whether the webhook is called for the operation.
+func (a *AdmissionWebhookConfig) HandlesOperation(op string) bool {
+	for _, o := range a.Operations {
+		if o == op {
+			return true
+		}
+	}
+	return false
+}
+
+// Merge merges two webhook configs with the same name. The settings of the
+// input take precedence.
+func (a *AdmissionWebhookConfig) Merge(b *AdmissionWebhookConfig) *AdmissionWebhookConfig {
+	result := a.Copy()
+
+	if b.URL != "" {
+		result.URL = b.URL
+	}
+	if len(b.Operations) != 0 {
+		result.Operations = helper.CopySliceString(b.Operations)
+	}
+	if b.Timeout != 0 {
+		result.Timeout = b.Timeout
+	}
+	if b.TimeoutHCL != "" {
+		result.TimeoutHCL = b.TimeoutHCL
+	}
+	if b.FailurePolicy != "" {
+		result.FailurePolicy = b.FailurePolicy
+	}
+	if b.CAFile != "" {
+		result.CAFile = b.CAFile
+	}
+	if b.TLSSkipVerify {
+		result.TLSSkipVerify = true
+	}
+
+	return result
+}
+
+// Copy returns a copy of the webhook config.
+func (a *AdmissionWebhookConfig) Copy() *AdmissionWebhookConfig {
+	if a == nil {
+		return nil
+	}
+
+	c := *a
+	c.Operations = helper.CopySliceString(a.Operations)
+	return &c
+}
+
+// AdmissionWebhookSetMerge merges two sets of webhook configs. Webhooks with
+// the same name are merged, and the order in which webhooks are first
+// defined is preserved since it is the order in which they are called.
+func AdmissionWebhookSetMerge(first, second []*AdmissionWebhookConfig) []*AdmissionWebhookConfig {
+	out := make([]*AdmissionWebhookConfig, 0, len(first)+len(second))
+	index := make(map[string]int, len(first)+len(second))
+
+	for _, set := range [][]*AdmissionWebhookConfig{first, second} {
+		for _, w := range set {
+			if i, ok := index[w.Name]; ok {
+				out[i] = out[i].Merge(w)
+				continue
+			}
+
+			index[w.Name] = len(out)
+			out = append(out, w.Copy())
+		}
+	}
+
+	return out
+}
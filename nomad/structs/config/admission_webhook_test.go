@@ -0,0 +1,110 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdmissionWebhookConfig_Canonicalize(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	w := &AdmissionWebhookConfig{Name: "labels", URL: "https://example.com"}
+	w.Canonicalize()
+	require.Equal([]string{"register", "plan"}, w.Operations)
+	require.Equal(DefaultAdmissionWebhookTimeout, w.Timeout)
+	require.Equal(AdmissionWebhookFailClosed, w.FailurePolicy)
+	require.True(w.HandlesOperation(AdmissionWebhookOperationPlan))
+
+	w = &AdmissionWebhookConfig{
+		Operations:    []string{"register"},
+		Timeout:       time.Second,
+		FailurePolicy: AdmissionWebhookFailOpen,
+	}
+	w.Canonicalize()
+	require.Equal([]string{"register"}, w.Operations)
+	require.Equal(time.Second, w.Timeout)
+	require.Equal(AdmissionWebhookFailOpen, w.FailurePolicy)
+	require.False(w.HandlesOperation(AdmissionWebhookOperationPlan))
+}
+
+func TestAdmissionWebhookConfig_Validate(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	w := &AdmissionWebhookConfig{
+		Name:          "labels",
+		URL:           "https://example.com/admit",
+		Operations:    []string{"register"},
+		FailurePolicy: AdmissionWebhookFailOpen,
+	}
+	require.NoError(w.Validate())
+
+	w = &AdmissionWebhookConfig{
+		URL:           "ftp://example.com",
+		Operations:    []string{"delete"},
+		Timeout:       -time.Second,
+		FailurePolicy: "ignore",
+	}
+	err := w.Validate()
+	require.Error(err)
+	require.Contains(err.Error(), "missing name")
+	require.Contains(err.Error(), "url scheme must be http or https")
+	require.Contains(err.Error(), `unknown operation "delete"`)
+	require.Contains(err.Error(), "timeout must be positive")
+	require.Contains(err.Error(), "failure_policy must be")
+}
+
+func TestAdmissionWebhookSetMerge(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	first := []*AdmissionWebhookConfig{
+		{
+			Name:       "labels",
+			URL:        "http://labels.example.com",
+			Operations: []string{"register"},
+		},
+		{
+			Name: "registries",
+			URL:  "http://registries.example.com",
+		},
+	}
+	second := []*AdmissionWebhookConfig{
+		{
+			Name:          "limits",
+			URL:           "http://limits.example.com",
+			FailurePolicy: AdmissionWebhookFailOpen,
+		},
+		{
+			Name:       "labels",
+			Timeout:    time.Second,
+			TimeoutHCL: "1s",
+		},
+	}
+
+	expected := []*AdmissionWebhookConfig{
+		{
+			Name:       "labels",
+			URL:        "http://labels.example.com",
+			Operations: []string{"register"},
+			Timeout:    time.Second,
+			TimeoutHCL: "1s",
+		},
+		{
+			Name: "registries",
+			URL:  "http://registries.example.com",
+		},
+		{
+			Name:          "limits",
+			URL:           "http://limits.example.com",
+			FailurePolicy: AdmissionWebhookFailOpen,
+		},
+	}
+	require.Equal(expected, AdmissionWebhookSetMerge(first, second))
+
+	// The inputs are not modified
+	require.Zero(first[0].Timeout)
+}
@@ -61,7 +61,7 @@ func (j *Job) Diff(other *Job, contextual bool) (*JobDiff, error) {
 	diff := &JobDiff{Type: DiffTypeNone}
 	var oldPrimitiveFlat, newPrimitiveFlat map[string]string
 	filter := []string{"ID", "Status", "StatusDescription", "Version", "Stable", "CreateIndex",
-		"ModifyIndex", "JobModifyIndex", "Update", "SubmitTime"}
+		"ModifyIndex", "JobModifyIndex", "Update", "SubmitTime", "DispatchIdempotencyToken", "VersionTag"}
 
 	if j == nil && other == nil {
 		return diff, nil
@@ -123,7 +123,7 @@ func (j *Job) Diff(other *Job, contextual bool) (*JobDiff, error) {
 	diff.TaskGroups = tgs
 
 	// Periodic diff
-	if pDiff := primitiveObjectDiff(j.Periodic, other.Periodic, nil, "Periodic", contextual); pDiff != nil {
+	if pDiff := periodicDiff(j.Periodic, other.Periodic, contextual); pDiff != nil {
 		diff.Objects = append(diff.Objects, pDiff)
 	}
 
@@ -132,6 +132,11 @@ func (j *Job) Diff(other *Job, contextual bool) (*JobDiff, error) {
 		diff.Objects = append(diff.Objects, cDiff)
 	}
 
+	// Multiregion diff
+	if mDiff := multiregionDiff(j.Multiregion, other.Multiregion, contextual); mDiff != nil {
+		diff.Objects = append(diff.Objects, mDiff)
+	}
+
 	// Check to see if there is a diff. We don't use reflect because we are
 	// filtering quite a few fields that will change on each diff.
 	if diff.Type == DiffTypeNone {
@@ -224,6 +229,14 @@ func (tg *TaskGroup) Diff(other *TaskGroup, contextual bool) (*TaskGroupDiff, er
 		newPrimitiveFlat = flatmap.Flatten(other, filter, true)
 	}
 
+	// MaxClientDisconnect is a pointer and is skipped when flattening
+	if tg.MaxClientDisconnect != nil {
+		oldPrimitiveFlat["MaxClientDisconnect"] = fmt.Sprintf("%d", *tg.MaxClientDisconnect)
+	}
+	if other.MaxClientDisconnect != nil {
+		newPrimitiveFlat["MaxClientDisconnect"] = fmt.Sprintf("%d", *other.MaxClientDisconnect)
+	}
+
 	// Diff the primitive fields.
 	diff.Fields = fieldDiffs(oldPrimitiveFlat, newPrimitiveFlat, false)
 
@@ -283,6 +296,11 @@ func (tg *TaskGroup) Diff(other *TaskGroup, contextual bool) (*TaskGroupDiff, er
 		diff.Objects = append(diff.Objects, sDiffs...)
 	}
 
+	// Scaling policy diff
+	if scDiff := scalingPolicyDiff(tg.Scaling, other.Scaling, contextual); scDiff != nil {
+		diff.Objects = append(diff.Objects, scDiff)
+	}
+
 	// Tasks diff
 	tasks, err := taskDiffs(tg.Tasks, other.Tasks, contextual)
 	if err != nil {
@@ -946,6 +964,33 @@ func vaultDiff(old, new *Vault, contextual bool) *ObjectDiff {
 // parameterizedJobDiff returns the diff of two parameterized job objects. If
 // contextual diff is enabled, all fields will be returned, even if no diff
 // occurred.
+// periodicDiff returns the diff of two periodic configs. If contextual diff is
+// enabled, all fields will be returned, even if no diff occurred.
+func periodicDiff(old, new *PeriodicConfig, contextual bool) *ObjectDiff {
+	diff := primitiveObjectDiff(old, new, nil, "Periodic", contextual)
+
+	var oldSpecs, newSpecs []string
+	if old != nil {
+		oldSpecs = old.Specs
+	}
+	if new != nil {
+		newSpecs = new.Specs
+	}
+
+	specsDiff := stringSetDiff(oldSpecs, newSpecs, "Specs", contextual)
+	if specsDiff == nil {
+		return diff
+	}
+	if diff == nil {
+		if specsDiff.Type == DiffTypeNone {
+			return nil
+		}
+		diff = &ObjectDiff{Type: DiffTypeEdited, Name: "Periodic"}
+	}
+	diff.Objects = append(diff.Objects, specsDiff)
+	return diff
+}
+
 func parameterizedJobDiff(old, new *ParameterizedJobConfig, contextual bool) *ObjectDiff {
 	diff := &ObjectDiff{Type: DiffTypeNone, Name: "ParameterizedJob"}
 	var oldPrimitiveFlat, newPrimitiveFlat map[string]string
@@ -981,6 +1026,132 @@ func parameterizedJobDiff(old, new *ParameterizedJobConfig, contextual bool) *Ob
 	return diff
 }
 
+// scalingPolicyDiff returns the diff of two scaling policies. The identity
+// and target of the policies are ignored as they are derived from the job.
+func scalingPolicyDiff(old, new *ScalingPolicy, contextual bool) *ObjectDiff {
+	diff := &ObjectDiff{Type: DiffTypeNone, Name: "Scaling"}
+	var oldPrimitiveFlat, newPrimitiveFlat map[string]string
+	filter := []string{"ID", "Namespace", "CreateIndex", "ModifyIndex"}
+
+	if old == nil && new == nil {
+		return nil
+	} else if old == nil {
+		old = &ScalingPolicy{}
+		diff.Type = DiffTypeAdded
+		newPrimitiveFlat = flatmap.Flatten(new, filter, true)
+	} else if new == nil {
+		new = &ScalingPolicy{}
+		diff.Type = DiffTypeDeleted
+		oldPrimitiveFlat = flatmap.Flatten(old, filter, true)
+	} else {
+		oldPrimitiveFlat = flatmap.Flatten(old, filter, true)
+		newPrimitiveFlat = flatmap.Flatten(new, filter, true)
+	}
+
+	// Diff the primitive fields.
+	diff.Fields = fieldDiffs(oldPrimitiveFlat, newPrimitiveFlat, contextual)
+
+	// Policy diff
+	if pDiff := configDiff(old.Policy, new.Policy, contextual); pDiff != nil {
+		pDiff.Name = "Policy"
+		diff.Objects = append(diff.Objects, pDiff)
+	}
+
+	if diff.Type == DiffTypeNone {
+		for _, f := range diff.Fields {
+			if f.Type != DiffTypeNone {
+				diff.Type = DiffTypeEdited
+				break
+			}
+		}
+		if len(diff.Objects) != 0 {
+			diff.Type = DiffTypeEdited
+		}
+	}
+	if diff.Type == DiffTypeNone && !contextual {
+		return nil
+	}
+	return diff
+}
+
+func multiregionDiff(old, new *Multiregion, contextual bool) *ObjectDiff {
+	diff := &ObjectDiff{Type: DiffTypeNone, Name: "Multiregion"}
+
+	if reflect.DeepEqual(old, new) {
+		return nil
+	} else if old == nil {
+		old = &Multiregion{}
+		diff.Type = DiffTypeAdded
+	} else if new == nil {
+		new = &Multiregion{}
+		diff.Type = DiffTypeDeleted
+	} else {
+		diff.Type = DiffTypeEdited
+	}
+
+	// Strategy diff
+	if sDiff := primitiveObjectDiff(old.Strategy, new.Strategy, nil, "Strategy", contextual); sDiff != nil {
+		diff.Objects = append(diff.Objects, sDiff)
+	}
+
+	// Regions diff, matching the regions by name
+	oldRegions := make(map[string]*MultiregionRegion, len(old.Regions))
+	for _, r := range old.Regions {
+		oldRegions[r.Name] = r
+	}
+	newRegions := make(map[string]*MultiregionRegion, len(new.Regions))
+	for _, r := range new.Regions {
+		newRegions[r.Name] = r
+	}
+
+	for _, r := range old.Regions {
+		if rDiff := multiregionRegionDiff(r, newRegions[r.Name], contextual); rDiff != nil {
+			diff.Objects = append(diff.Objects, rDiff)
+		}
+	}
+	for _, r := range new.Regions {
+		if _, ok := oldRegions[r.Name]; ok {
+			continue
+		}
+		if rDiff := multiregionRegionDiff(nil, r, contextual); rDiff != nil {
+			diff.Objects = append(diff.Objects, rDiff)
+		}
+	}
+
+	return diff
+}
+
+func multiregionRegionDiff(old, new *MultiregionRegion, contextual bool) *ObjectDiff {
+	diff := &ObjectDiff{Type: DiffTypeNone, Name: "Region"}
+	var oldPrimitiveFlat, newPrimitiveFlat map[string]string
+
+	if reflect.DeepEqual(old, new) {
+		return nil
+	} else if old == nil {
+		old = &MultiregionRegion{}
+		diff.Type = DiffTypeAdded
+		newPrimitiveFlat = flatmap.Flatten(new, nil, true)
+	} else if new == nil {
+		new = &MultiregionRegion{}
+		diff.Type = DiffTypeDeleted
+		oldPrimitiveFlat = flatmap.Flatten(old, nil, true)
+	} else {
+		diff.Type = DiffTypeEdited
+		oldPrimitiveFlat = flatmap.Flatten(old, nil, true)
+		newPrimitiveFlat = flatmap.Flatten(new, nil, true)
+	}
+
+	// Diff the primitive fields.
+	diff.Fields = fieldDiffs(oldPrimitiveFlat, newPrimitiveFlat, contextual)
+
+	// Datacenters diff
+	if setDiff := stringSetDiff(old.Datacenters, new.Datacenters, "Datacenters", contextual); setDiff != nil {
+		diff.Objects = append(diff.Objects, setDiff)
+	}
+
+	return diff
+}
+
 // Diff returns a diff of two resource objects. If contextual diff is enabled,
 // non-changed fields will still be returned.
 func (r *Resources) Diff(other *Resources, contextual bool) *ObjectDiff {
@@ -442,6 +442,8 @@ func TestJobDiff(t *testing.T) {
 					SpecType:        "foo",
 					ProhibitOverlap: false,
 					TimeZone:        "Europe/Minsk",
+					Catchup:         "all",
+					CatchupWindow:   time.Hour,
 				},
 			},
 			Expected: &JobDiff{
@@ -451,6 +453,18 @@ func TestJobDiff(t *testing.T) {
 						Type: DiffTypeAdded,
 						Name: "Periodic",
 						Fields: []*FieldDiff{
+							{
+								Type: DiffTypeAdded,
+								Name: "Catchup",
+								Old:  "",
+								New:  "all",
+							},
+							{
+								Type: DiffTypeAdded,
+								Name: "CatchupWindow",
+								Old:  "",
+								New:  "3600000000000",
+							},
 							{
 								Type: DiffTypeAdded,
 								Name: "Enabled",
@@ -495,6 +509,8 @@ func TestJobDiff(t *testing.T) {
 					SpecType:        "foo",
 					ProhibitOverlap: false,
 					TimeZone:        "Europe/Minsk",
+					Catchup:         "all",
+					CatchupWindow:   time.Hour,
 				},
 			},
 			New: &Job{},
@@ -505,6 +521,18 @@ func TestJobDiff(t *testing.T) {
 						Type: DiffTypeDeleted,
 						Name: "Periodic",
 						Fields: []*FieldDiff{
+							{
+								Type: DiffTypeDeleted,
+								Name: "Catchup",
+								Old:  "all",
+								New:  "",
+							},
+							{
+								Type: DiffTypeDeleted,
+								Name: "CatchupWindow",
+								Old:  "3600000000000",
+								New:  "",
+							},
 							{
 								Type: DiffTypeDeleted,
 								Name: "Enabled",
@@ -549,6 +577,8 @@ func TestJobDiff(t *testing.T) {
 					SpecType:        "foo",
 					ProhibitOverlap: false,
 					TimeZone:        "Europe/Minsk",
+					Specs:           []string{"0 12 * * 6,0"},
+					Catchup:         "latest",
 				},
 			},
 			New: &Job{
@@ -558,6 +588,9 @@ func TestJobDiff(t *testing.T) {
 					SpecType:        "cron",
 					ProhibitOverlap: true,
 					TimeZone:        "America/Los_Angeles",
+					Specs:           []string{"0 9 * * 1-5"},
+					Catchup:         "all",
+					CatchupWindow:   time.Hour,
 				},
 			},
 			Expected: &JobDiff{
@@ -567,6 +600,18 @@ func TestJobDiff(t *testing.T) {
 						Type: DiffTypeEdited,
 						Name: "Periodic",
 						Fields: []*FieldDiff{
+							{
+								Type: DiffTypeEdited,
+								Name: "Catchup",
+								Old:  "latest",
+								New:  "all",
+							},
+							{
+								Type: DiffTypeEdited,
+								Name: "CatchupWindow",
+								Old:  "0",
+								New:  "3600000000000",
+							},
 							{
 								Type: DiffTypeEdited,
 								Name: "Enabled",
@@ -598,6 +643,26 @@ func TestJobDiff(t *testing.T) {
 								New:  "America/Los_Angeles",
 							},
 						},
+						Objects: []*ObjectDiff{
+							{
+								Type: DiffTypeEdited,
+								Name: "Specs",
+								Fields: []*FieldDiff{
+									{
+										Type: DiffTypeAdded,
+										Name: "Specs",
+										Old:  "",
+										New:  "0 9 * * 1-5",
+									},
+									{
+										Type: DiffTypeDeleted,
+										Name: "Specs",
+										Old:  "0 12 * * 6,0",
+										New:  "",
+									},
+								},
+							},
+						},
 					},
 				},
 			},
@@ -630,6 +695,18 @@ func TestJobDiff(t *testing.T) {
 						Type: DiffTypeEdited,
 						Name: "Periodic",
 						Fields: []*FieldDiff{
+							{
+								Type: DiffTypeNone,
+								Name: "Catchup",
+								Old:  "",
+								New:  "",
+							},
+							{
+								Type: DiffTypeNone,
+								Name: "CatchupWindow",
+								Old:  "0",
+								New:  "0",
+							},
 							{
 								Type: DiffTypeEdited,
 								Name: "Enabled",
@@ -954,6 +1031,12 @@ func TestJobDiff(t *testing.T) {
 						Type: DiffTypeAdded,
 						Name: "ParameterizedJob",
 						Fields: []*FieldDiff{
+							{
+								Type: DiffTypeAdded,
+								Name: "MaxConcurrent",
+								Old:  "",
+								New:  "0",
+							},
 							{
 								Type: DiffTypeAdded,
 								Name: "Payload",
@@ -991,6 +1074,105 @@ func TestJobDiff(t *testing.T) {
 				},
 			},
 		},
+		{
+			// Multiregion edited
+			Old: &Job{
+				Multiregion: &Multiregion{
+					Strategy: &MultiregionStrategy{MaxParallel: 1},
+					Regions: []*MultiregionRegion{
+						{
+							Name:        "west",
+							Count:       1,
+							Datacenters: []string{"west-1"},
+						},
+					},
+				},
+			},
+			New: &Job{
+				Multiregion: &Multiregion{
+					Strategy: &MultiregionStrategy{MaxParallel: 2},
+					Regions: []*MultiregionRegion{
+						{
+							Name:        "west",
+							Count:       3,
+							Datacenters: []string{"west-1"},
+						},
+						{
+							Name:        "east",
+							Count:       2,
+							Datacenters: []string{"east-1"},
+						},
+					},
+				},
+			},
+			Expected: &JobDiff{
+				Type: DiffTypeEdited,
+				Objects: []*ObjectDiff{
+					{
+						Type: DiffTypeEdited,
+						Name: "Multiregion",
+						Objects: []*ObjectDiff{
+							{
+								Type: DiffTypeEdited,
+								Name: "Strategy",
+								Fields: []*FieldDiff{
+									{
+										Type: DiffTypeEdited,
+										Name: "MaxParallel",
+										Old:  "1",
+										New:  "2",
+									},
+								},
+							},
+							{
+								Type: DiffTypeEdited,
+								Name: "Region",
+								Fields: []*FieldDiff{
+									{
+										Type: DiffTypeEdited,
+										Name: "Count",
+										Old:  "1",
+										New:  "3",
+									},
+								},
+							},
+							{
+								Type: DiffTypeAdded,
+								Name: "Region",
+								Fields: []*FieldDiff{
+									{
+										Type: DiffTypeAdded,
+										Name: "Count",
+										Old:  "",
+										New:  "2",
+									},
+									{
+										Type: DiffTypeAdded,
+										Name: "Name",
+										Old:  "",
+										New:  "east",
+									},
+								},
+								Objects: []*ObjectDiff{
+									{
+										Type: DiffTypeAdded,
+										Name: "Datacenters",
+										Fields: []*FieldDiff{
+											{
+												Type: DiffTypeAdded,
+												Name: "Datacenters",
+												Old:  "",
+												New:  "east-1",
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			// Parameterized Job deleted
 			Old: &Job{
@@ -1008,6 +1190,12 @@ func TestJobDiff(t *testing.T) {
 						Type: DiffTypeDeleted,
 						Name: "ParameterizedJob",
 						Fields: []*FieldDiff{
+							{
+								Type: DiffTypeDeleted,
+								Name: "MaxConcurrent",
+								Old:  "0",
+								New:  "",
+							},
 							{
 								Type: DiffTypeDeleted,
 								Name: "Payload",
@@ -1141,6 +1329,12 @@ func TestJobDiff(t *testing.T) {
 						Type: DiffTypeEdited,
 						Name: "ParameterizedJob",
 						Fields: []*FieldDiff{
+							{
+								Type: DiffTypeNone,
+								Name: "MaxConcurrent",
+								Old:  "0",
+								New:  "0",
+							},
 							{
 								Type: DiffTypeEdited,
 								Name: "Payload",
@@ -2994,6 +3188,103 @@ func TestTaskGroupDiff(t *testing.T) {
 				},
 			},
 		},
+		{
+			// Scaling policy added
+			Old: &TaskGroup{},
+			New: &TaskGroup{
+				Scaling: &ScalingPolicy{
+					ID:      "id",
+					Min:     1,
+					Max:     3,
+					Enabled: true,
+				},
+			},
+			Expected: &TaskGroupDiff{
+				Type: DiffTypeEdited,
+				Objects: []*ObjectDiff{
+					{
+						Type: DiffTypeAdded,
+						Name: "Scaling",
+						Fields: []*FieldDiff{
+							{
+								Type: DiffTypeAdded,
+								Name: "Enabled",
+								Old:  "",
+								New:  "true",
+							},
+							{
+								Type: DiffTypeAdded,
+								Name: "Max",
+								Old:  "",
+								New:  "3",
+							},
+							{
+								Type: DiffTypeAdded,
+								Name: "Min",
+								Old:  "",
+								New:  "1",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			// Scaling policy edited
+			Old: &TaskGroup{
+				Scaling: &ScalingPolicy{
+					ID:      "id",
+					Min:     1,
+					Max:     3,
+					Enabled: true,
+					Policy: map[string]interface{}{
+						"foo": "bar",
+					},
+				},
+			},
+			New: &TaskGroup{
+				Scaling: &ScalingPolicy{
+					ID:      "id",
+					Min:     1,
+					Max:     5,
+					Enabled: true,
+					Policy: map[string]interface{}{
+						"foo": "baz",
+					},
+				},
+			},
+			Expected: &TaskGroupDiff{
+				Type: DiffTypeEdited,
+				Objects: []*ObjectDiff{
+					{
+						Type: DiffTypeEdited,
+						Name: "Scaling",
+						Fields: []*FieldDiff{
+							{
+								Type: DiffTypeEdited,
+								Name: "Max",
+								Old:  "3",
+								New:  "5",
+							},
+						},
+						Objects: []*ObjectDiff{
+							{
+								Type: DiffTypeEdited,
+								Name: "Policy",
+								Fields: []*FieldDiff{
+									{
+										Type: DiffTypeEdited,
+										Name: "foo",
+										Old:  "bar",
+										New:  "baz",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
 	}
 
 	for i, c := range cases {
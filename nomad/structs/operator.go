@@ -25,10 +25,14 @@ type RaftServer struct {
 
 	// Voter is true if this server has a vote in the cluster. This might
 	// be false if the server is staging and still coming online, or if
-	// it's a non-voting server, which will be added in a future release of
-	// Nomad.
+	// it's a non-voting read replica.
 	Voter bool
 
+	// ReadReplica is true if this server is configured as a non-voting
+	// server. Read replicas receive the Raft log and serve stale reads but
+	// are never promoted to voters.
+	ReadReplica bool
+
 	// RaftProtocol is the version of the Raft protocol spoken by this server.
 	RaftProtocol string
 }
@@ -173,3 +177,103 @@ type SchedulerSetConfigRequest struct {
 	// WriteRequest holds the ACL token to go along with this request.
 	WriteRequest
 }
+
+const (
+	// EvalBrokerActionDrop removes evaluations from the eval broker and
+	// blocked evals tracker and marks them as cancelled.
+	EvalBrokerActionDrop = "drop"
+
+	// EvalBrokerActionReenqueue moves waiting, failed or blocked evaluations
+	// back into the ready queue of their scheduler.
+	EvalBrokerActionReenqueue = "reenqueue"
+)
+
+// BrokerEval summarizes an evaluation tracked by the eval broker or the
+// blocked evals tracker.
+type BrokerEval struct {
+	ID          string
+	Namespace   string
+	JobID       string
+	Type        string
+	TriggeredBy string
+	Priority    int
+
+	// Queue is the broker queue holding the evaluation. This is usually the
+	// scheduler type but is "_failed" for evaluations that exceeded the
+	// delivery limit.
+	Queue string
+
+	// Dequeues is the number of times the evaluation has been dequeued by a
+	// scheduler.
+	Dequeues int
+
+	// WaitUntil is set for waiting evaluations and is the time at which the
+	// evaluation will be made ready.
+	WaitUntil time.Time
+
+	// CreateTime is the time the evaluation was created, in nanoseconds since
+	// the Unix epoch.
+	CreateTime int64
+}
+
+// NewBrokerEval returns a summary of the passed evaluation.
+func NewBrokerEval(eval *Evaluation, queue string, dequeues int) *BrokerEval {
+	return &BrokerEval{
+		ID:          eval.ID,
+		Namespace:   eval.Namespace,
+		JobID:       eval.JobID,
+		Type:        eval.Type,
+		TriggeredBy: eval.TriggeredBy,
+		Priority:    eval.Priority,
+		Queue:       queue,
+		Dequeues:    dequeues,
+		WaitUntil:   eval.WaitUntil,
+		CreateTime:  eval.CreateTime,
+	}
+}
+
+// EvalBrokerInspectResponse lists the evaluations held by the eval broker and
+// the blocked evals tracker on the leader.
+type EvalBrokerInspectResponse struct {
+	// Ready evaluations are waiting to be dequeued by a scheduler.
+	Ready []*BrokerEval
+
+	// Unacked evaluations have been dequeued but not yet acknowledged.
+	Unacked []*BrokerEval
+
+	// Waiting evaluations are delayed until their wait time elapses.
+	Waiting []*BrokerEval
+
+	// Pending evaluations are serialized behind another evaluation for the
+	// same job.
+	Pending []*BrokerEval
+
+	// Captured blocked evaluations are waiting on capacity for a computed
+	// node class.
+	Captured []*BrokerEval
+
+	// Escaped blocked evaluations are waiting on capacity of any node.
+	Escaped []*BrokerEval
+
+	QueryMeta
+}
+
+// EvalBrokerUpdateRequest is used to drop or re-enqueue evaluations held by
+// the eval broker and the blocked evals tracker.
+type EvalBrokerUpdateRequest struct {
+	// Action is one of EvalBrokerActionDrop or EvalBrokerActionReenqueue.
+	Action string
+
+	// EvalIDs are the full IDs of the evaluations to act on.
+	EvalIDs []string
+
+	WriteRequest
+}
+
+// EvalBrokerUpdateResponse is the response to an EvalBrokerUpdateRequest.
+type EvalBrokerUpdateResponse struct {
+	// Updated is the set of evaluation IDs that were acted upon.
+	Updated []string
+
+	WriteMeta
+}
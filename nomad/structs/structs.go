@@ -30,6 +30,7 @@ import (
 	"github.com/hashicorp/nomad/acl"
 	"github.com/hashicorp/nomad/helper"
 	"github.com/hashicorp/nomad/helper/args"
+	"github.com/hashicorp/nomad/helper/policy"
 	"github.com/hashicorp/nomad/helper/uuid"
 	"github.com/hashicorp/nomad/lib/kheap"
 	psstructs "github.com/hashicorp/nomad/plugins/shared/structs"
@@ -42,6 +43,9 @@ var (
 	// validPolicyName is used to validate a policy name
 	validPolicyName = regexp.MustCompile("^[a-zA-Z0-9-]{1,128}$")
 
+	// validNamespaceName is used to validate a namespace name
+	validNamespaceName = regexp.MustCompile("^[a-zA-Z0-9-]{1,128}$")
+
 	// b32 is a lowercase base32 encoding for use in URL friendly service hashes
 	b32 = base32.NewEncoding(strings.ToLower("abcdefghijklmnopqrstuvwxyz234567"))
 )
@@ -81,6 +85,13 @@ const (
 	BatchNodeUpdateDrainRequestType
 	SchedulerConfigRequestType
 	NodeBatchDeregisterRequestType
+	JobDispatchQueueRequestType
+	JobVersionTagRequestType
+	NamespaceUpsertRequestType
+	NamespaceDeleteRequestType
+	AdmissionPolicyUpsertRequestType
+	AdmissionPolicyDeleteRequestType
+	ScalingEventRegisterRequestType
 )
 
 const (
@@ -113,6 +124,9 @@ const (
 	// maxPolicyDescriptionLength limits a policy description length
 	maxPolicyDescriptionLength = 256
 
+	// maxNamespaceDescriptionLength limits a namespace description length
+	maxNamespaceDescriptionLength = 256
+
 	// maxTokenNameLength limits a ACL token name length
 	maxTokenNameLength = 256
 
@@ -155,6 +169,13 @@ const (
 	Namespaces  Context = "namespaces"
 	Quotas      Context = "quotas"
 	All         Context = "all"
+
+	// Contexts only used by fuzzy search
+	Groups   Context = "groups"
+	Tasks    Context = "tasks"
+	Services Context = "services"
+	Images   Context = "images"
+	NodeMeta Context = "node_meta"
 )
 
 // NamespacedID is a tuple of an ID and a namespace
@@ -223,6 +244,18 @@ type QueryOptions struct {
 	// If set, used as prefix for resource list searches
 	Prefix string
 
+	// Filter is an expression the entries returned by list requests must
+	// match.
+	Filter string
+
+	// PerPage is the maximum number of entries returned by list requests.
+	// If zero, all entries are returned.
+	PerPage int32
+
+	// NextToken is the token of the first entry returned by a list request,
+	// as returned in the QueryMeta of the previous page.
+	NextToken string
+
 	// AuthToken is secret portion of the ACL token used for the request
 	AuthToken string
 
@@ -296,6 +329,10 @@ type QueryMeta struct {
 
 	// Used to indicate if there is a known leader node
 	KnownLeader bool
+
+	// NextToken is the token of the first entry of the next page of a list
+	// request. It is empty if there are no more entries.
+	NextToken string
 }
 
 // WriteMeta allows a write response to include potentially
@@ -459,6 +496,46 @@ type SearchRequest struct {
 	QueryOptions
 }
 
+// FuzzySearchRequest is used to search for objects whose names or attributes
+// contain the given text.
+type FuzzySearchRequest struct {
+	// Text is matched case-insensitively as a substring of the searched
+	// fields. Fields containing the characters of Text in order are returned
+	// as fuzzy matches, ranked after the substring matches.
+	Text string
+
+	// Context is the type that can be matched against. An empty context or
+	// "all" searches every context the request is allowed to read.
+	Context Context
+
+	QueryOptions
+}
+
+// FuzzyMatch is a field that matched a fuzzy search.
+type FuzzyMatch struct {
+	// ID is the value of the matched field, such as a task or image name.
+	ID string
+
+	// Scope identifies the object the field belongs to, from the outermost
+	// object inwards. For example a task is scoped by its namespace, job ID
+	// and group name.
+	Scope []string
+}
+
+// FuzzySearchResponse is used to return the fields matching a fuzzy search,
+// keyed by the context the field belongs to.
+type FuzzySearchResponse struct {
+	// Matches are the matching fields for each context, ordered by how well
+	// they match the text
+	Matches map[Context][]FuzzyMatch
+
+	// Truncations indicates whether the matches for a particular context have
+	// been truncated
+	Truncations map[Context]bool
+
+	QueryMeta
+}
+
 // JobRegisterRequest is used for Job.Register endpoint
 // to register a job as being a schedulable entity.
 type JobRegisterRequest struct {
@@ -473,6 +550,14 @@ type JobRegisterRequest struct {
 	// PolicyOverride is set when the user is attempting to override any policies
 	PolicyOverride bool
 
+	// MultiregionPeer is set when a multiregion job is registered on behalf of
+	// the region it was submitted to, and should not be forwarded any further.
+	MultiregionPeer bool
+
+	// Submission is the source of the job as it was submitted. It is stored
+	// alongside the registered job version.
+	Submission *JobSubmission
+
 	WriteRequest
 }
 
@@ -554,6 +639,11 @@ type JobDispatchRequest struct {
 	JobID   string
 	Payload []byte
 	Meta    map[string]string
+
+	// IdempotencyToken, if set, ensures the parameterized job is only
+	// dispatched once for the token. Dispatching again with the same token
+	// within the retention period returns the existing dispatched job.
+	IdempotencyToken string
 	WriteRequest
 }
 
@@ -575,6 +665,10 @@ type JobRevertRequest struct {
 	// version before reverting.
 	EnforcePriorVersion *uint64
 
+	// TagName is the name of the tagged version to revert to. If set, it is
+	// used instead of JobVersion.
+	TagName string
+
 	// VaultToken is the Vault token that proves the submitter of the job revert
 	// has access to any Vault policies specified in the targeted job version. This
 	// field is only used to transfer the token and is not stored after the Job
@@ -600,6 +694,152 @@ type JobStabilityResponse struct {
 	WriteMeta
 }
 
+// JobTagRequest is used to tag a job version or to remove a version tag.
+type JobTagRequest struct {
+	// JobID is the ID of the tagged job
+	JobID string
+
+	// Name is the name of the tag
+	Name string
+
+	// Description is a human readable description of the tagged version
+	Description string
+
+	// Version is the version to tag. If unset, the current version is tagged.
+	Version *uint64
+
+	// Unset removes the tag with the given name instead of applying it
+	Unset bool
+
+	// TaggedTime is the time at which the version was tagged. It is set by
+	// the server handling the request.
+	TaggedTime int64
+
+	WriteRequest
+}
+
+// JobTagResponse is the response when tagging a job version.
+type JobTagResponse struct {
+	WriteMeta
+}
+
+// JobScaleRequest is used to change the count of a task group of a job, or to
+// only record a scaling event for it.
+type JobScaleRequest struct {
+	// JobID is the ID of the scaled job
+	JobID string
+
+	// Target identifies the scaled task group. It is keyed by the
+	// ScalingTarget constants.
+	Target map[string]string
+
+	// Count is the new count of the task group. If unset, only the scaling
+	// event is recorded.
+	Count *int64
+
+	// Message, Error and Meta describe the scaling event
+	Message string
+	Error   bool
+	Meta    map[string]interface{}
+
+	// EnforceIndex, if set, ensures the job is at the given modify index
+	// before scaling it.
+	EnforceIndex   bool
+	JobModifyIndex uint64
+
+	// PolicyOverride is set when the user is attempting to override any
+	// policies
+	PolicyOverride bool
+
+	WriteRequest
+}
+
+// Validate validates the arguments of the scaling request.
+func (r *JobScaleRequest) Validate() error {
+	if r.JobID == "" {
+		return fmt.Errorf("missing job ID for scaling")
+	}
+
+	namespace := r.Target[ScalingTargetNamespace]
+	if namespace != "" && namespace != r.RequestNamespace() {
+		return fmt.Errorf("namespace in scaling target does not match request namespace")
+	}
+
+	job := r.Target[ScalingTargetJob]
+	if job != "" && job != r.JobID {
+		return fmt.Errorf("job ID in scaling target does not match request job ID")
+	}
+
+	if r.Target[ScalingTargetGroup] == "" {
+		return fmt.Errorf("missing task group name for scaling")
+	}
+
+	if r.Count != nil {
+		if *r.Count < 0 {
+			return fmt.Errorf("scaling count must be non-negative")
+		}
+		if r.Error {
+			return fmt.Errorf("scaling error events must not set a count")
+		}
+	}
+	return nil
+}
+
+// JobScaleStatusRequest is used to get the scaling status of a job
+type JobScaleStatusRequest struct {
+	JobID string
+	QueryOptions
+}
+
+// JobScaleStatusResponse is used for a job scale status request. The status
+// is nil if the job doesn't exist.
+type JobScaleStatusResponse struct {
+	JobScaleStatus *JobScaleStatus
+	QueryMeta
+}
+
+// ScalingEventRequest is used to record a scaling event of a task group. If
+// the event has a count, the count of the task group is updated along with it.
+type ScalingEventRequest struct {
+	Namespace    string
+	JobID        string
+	TaskGroup    string
+	ScalingEvent *ScalingEvent
+
+	// JobModifyIndex is the modify index the job must be at for its count to
+	// be updated
+	JobModifyIndex uint64
+
+	// Eval is the evaluation created once the count is updated, if any
+	Eval *Evaluation
+
+	WriteRequest
+}
+
+// ScalingPolicyListRequest is used to list the scaling policies of a
+// namespace
+type ScalingPolicyListRequest struct {
+	QueryOptions
+}
+
+// ScalingPolicyListResponse is used for a list request
+type ScalingPolicyListResponse struct {
+	Policies []*ScalingPolicyListStub
+	QueryMeta
+}
+
+// ScalingPolicySpecificRequest is used to query a specific scaling policy
+type ScalingPolicySpecificRequest struct {
+	ID string
+	QueryOptions
+}
+
+// SingleScalingPolicyResponse is used to return a single scaling policy
+type SingleScalingPolicyResponse struct {
+	Policy *ScalingPolicy
+	QueryMeta
+}
+
 // NodeListRequest is used to parameterize a list request
 type NodeListRequest struct {
 	QueryOptions
@@ -619,6 +859,34 @@ type EvalDeleteRequest struct {
 	WriteRequest
 }
 
+// EvalBatchDeleteRequest is used to delete the evaluations matching a set of
+// filters. At least one of JobID, Status or OlderThan must be set.
+type EvalBatchDeleteRequest struct {
+	// JobID limits deletion to evaluations for the given job.
+	JobID string
+
+	// Status limits deletion to evaluations with the given status.
+	Status string
+
+	// OlderThan limits deletion to evaluations created at least this long
+	// ago.
+	OlderThan time.Duration
+
+	WriteRequest
+}
+
+// EvalBatchDeleteResponse is used to respond to an EvalBatchDeleteRequest.
+type EvalBatchDeleteResponse struct {
+	// Deleted is the number of evaluations that were deleted.
+	Deleted int
+
+	// Skipped is the number of matching evaluations that were not deleted
+	// because they are being processed by a scheduler.
+	Skipped int
+
+	WriteMeta
+}
+
 // EvalSpecificRequest is used when we just need to specify a target evaluation
 type EvalSpecificRequest struct {
 	EvalID string
@@ -784,6 +1052,21 @@ type PeriodicForceRequest struct {
 	WriteRequest
 }
 
+// PeriodicStatusRequest is used to retrieve the upcoming and past launches of
+// a periodic job.
+type PeriodicStatusRequest struct {
+	JobID string
+
+	// Next is the number of upcoming launch times to return. It is capped at
+	// PeriodicStatusMaxNext.
+	Next int
+	QueryOptions
+}
+
+// PeriodicStatusMaxNext is the maximum number of upcoming launch times
+// returned by a periodic status request.
+const PeriodicStatusMaxNext = 100
+
 // ServerMembersResponse has the list of servers in a cluster
 type ServerMembersResponse struct {
 	ServerName   string
@@ -1108,9 +1391,25 @@ type JobDispatchResponse struct {
 	EvalID          string
 	EvalCreateIndex uint64
 	JobCreateIndex  uint64
+
+	// Queued is set if the parameterized job is at its concurrency limit and
+	// the dispatched job was queued. The dispatched job is registered once
+	// it is released from the queue.
+	Queued bool
+
+	// Existing is set if the idempotency token was already used and the
+	// previously dispatched job was returned instead of dispatching again.
+	Existing bool
 	WriteMeta
 }
 
+// JobDispatchQueueRequest is used to queue a dispatched job until its
+// parameterized job is below its concurrency limit.
+type JobDispatchQueueRequest struct {
+	Dispatch *QueuedDispatch
+	WriteRequest
+}
+
 // JobListResponse is used for a list request
 type JobListResponse struct {
 	Jobs []*JobListStub
@@ -1124,6 +1423,35 @@ type JobVersionsRequest struct {
 	QueryOptions
 }
 
+// JobDiffRequest is used to diff two versions of a job, or a version of a job
+// against a job that isn't registered.
+type JobDiffRequest struct {
+	JobID string
+
+	// FromVersion is the version to diff from. If unset, the current version
+	// is used.
+	FromVersion *uint64
+
+	// ToVersion is the version to diff to. If unset, the current version is
+	// used. It is ignored if Job is set.
+	ToVersion *uint64
+
+	// Job is an unregistered job to diff to instead of a version of the job
+	Job *Job
+
+	// Contextual toggles including unchanged fields in the diff
+	Contextual bool
+
+	QueryOptions
+}
+
+// JobSubmissionRequest is used to get the source of a job version
+type JobSubmissionRequest struct {
+	JobID   string
+	Version uint64
+	QueryOptions
+}
+
 // JobVersionsResponse is used for a job get versions request
 type JobVersionsResponse struct {
 	Versions []*Job
@@ -1131,6 +1459,19 @@ type JobVersionsResponse struct {
 	QueryMeta
 }
 
+// JobDiffResponse is used for a job diff request. Diff is nil if the job or
+// one of its versions doesn't exist.
+type JobDiffResponse struct {
+	Diff *JobDiff
+	QueryMeta
+}
+
+// JobSubmissionResponse is used for a job get submission request
+type JobSubmissionResponse struct {
+	Submission *JobSubmission
+	QueryMeta
+}
+
 // JobPlanResponse is used to respond to a job plan request
 type JobPlanResponse struct {
 	// Annotations stores annotations explaining decisions the scheduler made.
@@ -1257,6 +1598,29 @@ type PeriodicForceResponse struct {
 	WriteMeta
 }
 
+// PeriodicStatusResponse is used to respond to a periodic status request
+type PeriodicStatusResponse struct {
+	// Next is the set of upcoming launch times of the job.
+	Next []time.Time
+
+	// LastLaunch is the last recorded launch of the job. If the job has never
+	// launched, it holds the time the job was registered.
+	LastLaunch *PeriodicLaunch
+
+	// Launches is the set of child jobs launched by the periodic job that
+	// have not been garbage collected, newest first.
+	Launches []*PeriodicChildLaunch
+	QueryMeta
+}
+
+// PeriodicChildLaunch describes a single child job launched by a periodic job.
+type PeriodicChildLaunch struct {
+	JobID      string
+	Launch     time.Time
+	Status     string
+	SubmitTime int64
+}
+
 // DeploymentUpdateResponse is used to respond to a deployment change. The
 // response will include the modify index of the deployment as well as details
 // of any triggered evaluation.
@@ -1366,9 +1730,10 @@ func (ne *NodeEvent) AddDetail(k, v string) *NodeEvent {
 }
 
 const (
-	NodeStatusInit  = "initializing"
-	NodeStatusReady = "ready"
-	NodeStatusDown  = "down"
+	NodeStatusInit         = "initializing"
+	NodeStatusReady        = "ready"
+	NodeStatusDown         = "down"
+	NodeStatusDisconnected = "disconnected"
 )
 
 // ShouldDrainNode checks if a given node status should trigger an
@@ -1377,7 +1742,7 @@ func ShouldDrainNode(status string) bool {
 	switch status {
 	case NodeStatusInit, NodeStatusReady:
 		return false
-	case NodeStatusDown:
+	case NodeStatusDown, NodeStatusDisconnected:
 		return true
 	default:
 		panic(fmt.Sprintf("unhandled node status %s", status))
@@ -1387,7 +1752,7 @@ func ShouldDrainNode(status string) bool {
 // ValidNodeStatus is used to check if a node status is valid
 func ValidNodeStatus(status string) bool {
 	switch status {
-	case NodeStatusInit, NodeStatusReady, NodeStatusDown:
+	case NodeStatusInit, NodeStatusReady, NodeStatusDown, NodeStatusDisconnected:
 		return true
 	default:
 		return false
@@ -3248,6 +3613,22 @@ const (
 	// JobTrackedVersions is the number of historic job versions that are
 	// kept.
 	JobTrackedVersions = 6
+
+	// JobSubmissionMaxSize is the maximum size of the source and variables of
+	// a job submission. Larger submissions are not stored.
+	JobSubmissionMaxSize = 1024 * 1024
+
+	// JobTrackedScalingEvents is the number of scaling events that are kept
+	// for each task group of a job.
+	JobTrackedScalingEvents = 20
+)
+
+const (
+	// JobSubmissionFormatHCL1 and the other formats are the formats of the
+	// source of a job submission.
+	JobSubmissionFormatHCL1 = "hcl1"
+	JobSubmissionFormatHCL2 = "hcl2"
+	JobSubmissionFormatJSON = "json"
 )
 
 // Job is the scope of a scheduling request to Nomad. It is the largest
@@ -3327,6 +3708,14 @@ type Job struct {
 	// parameterized job.
 	Dispatched bool
 
+	// DispatchIdempotencyToken is the idempotency token the job was
+	// dispatched with.
+	DispatchIdempotencyToken string
+
+	// Multiregion is used to deploy the job to a set of regions, each with
+	// their own count and datacenters.
+	Multiregion *Multiregion
+
 	// Payload is the payload supplied when the job was dispatched.
 	Payload []byte
 
@@ -3357,6 +3746,10 @@ type Job struct {
 	// on each job register.
 	Version uint64
 
+	// VersionTag is the tag attached to this version of the job. Tagged
+	// versions are never garbage collected from the job history.
+	VersionTag *JobVersionTag
+
 	// SubmitTime is the time at which the job was submitted as a UnixNano in
 	// UTC
 	SubmitTime int64
@@ -3407,6 +3800,10 @@ func (j *Job) Canonicalize() (warnings error) {
 		j.Periodic.Canonicalize()
 	}
 
+	if j.Multiregion != nil {
+		j.Multiregion.Canonicalize()
+	}
+
 	return mErr.ErrorOrNil()
 }
 
@@ -3433,6 +3830,8 @@ func (j *Job) Copy() *Job {
 	nj.Periodic = nj.Periodic.Copy()
 	nj.Meta = helper.CopyMapStringString(nj.Meta)
 	nj.ParameterizedJob = nj.ParameterizedJob.Copy()
+	nj.Multiregion = nj.Multiregion.Copy()
+	nj.VersionTag = nj.VersionTag.Copy()
 	return nj
 }
 
@@ -3465,7 +3864,10 @@ func (j *Job) Validate() error {
 		mErr.Errors = append(mErr.Errors, fmt.Errorf("Job priority must be between [%d, %d]", JobMinPriority, JobMaxPriority))
 	}
 	if len(j.Datacenters) == 0 {
-		mErr.Errors = append(mErr.Errors, errors.New("Missing job datacenters"))
+		// Multiregion jobs may instead set the datacenters of every region
+		if !j.IsMultiregion() || !j.Multiregion.HasDatacenters() {
+			mErr.Errors = append(mErr.Errors, errors.New("Missing job datacenters"))
+		}
 	} else {
 		for _, v := range j.Datacenters {
 			if v == "" {
@@ -3555,6 +3957,17 @@ func (j *Job) Validate() error {
 		if err := j.ParameterizedJob.Validate(); err != nil {
 			mErr.Errors = append(mErr.Errors, err)
 		}
+
+		if j.IsPeriodic() && j.ParameterizedJob.MaxConcurrent != 0 {
+			mErr.Errors = append(mErr.Errors,
+				fmt.Errorf("Parameterized job concurrency limit can not be used with periodic jobs"))
+		}
+	}
+
+	if j.IsMultiregion() {
+		if err := j.Multiregion.Validate(); err != nil {
+			mErr.Errors = append(mErr.Errors, err)
+		}
 	}
 
 	return mErr.ErrorOrNil()
@@ -3667,6 +4080,7 @@ func (j *Job) Stub(summary *JobSummary) *JobListStub {
 		JobModifyIndex:    j.JobModifyIndex,
 		SubmitTime:        j.SubmitTime,
 		JobSummary:        summary,
+		Meta:              j.Meta,
 	}
 }
 
@@ -3686,6 +4100,41 @@ func (j *Job) IsParameterized() bool {
 	return j.ParameterizedJob != nil && !j.Dispatched
 }
 
+// IsMultiregion returns whether a job is deployed to multiple regions.
+func (j *Job) IsMultiregion() bool {
+	return j.Multiregion != nil && len(j.Multiregion.Regions) != 0
+}
+
+// RegionalCopy returns a copy of the multiregion job as it should be
+// registered in the given region. The region's datacenters replace those of
+// the job, its count replaces the count of every task group and its meta is
+// merged into the job's meta.
+func (j *Job) RegionalCopy(region *MultiregionRegion) *Job {
+	nj := j.Copy()
+	nj.Region = region.Name
+
+	if len(region.Datacenters) != 0 {
+		nj.Datacenters = helper.CopySliceString(region.Datacenters)
+	}
+
+	if region.Count > 0 {
+		for _, tg := range nj.TaskGroups {
+			tg.Count = region.Count
+		}
+	}
+
+	if len(region.Meta) != 0 {
+		if nj.Meta == nil {
+			nj.Meta = make(map[string]string, len(region.Meta))
+		}
+		for k, v := range region.Meta {
+			nj.Meta[k] = v
+		}
+	}
+
+	return nj
+}
+
 // VaultPolicies returns the set of Vault policies per task group, per task
 func (j *Job) VaultPolicies() map[string]map[string]*Vault {
 	policies := make(map[string]map[string]*Vault, len(j.TaskGroups))
@@ -3778,6 +4227,7 @@ func (j *Job) SpecChanged(new *Job) bool {
 	c.StatusDescription = j.StatusDescription
 	c.Stable = j.Stable
 	c.Version = j.Version
+	c.VersionTag = j.VersionTag
 	c.CreateIndex = j.CreateIndex
 	c.ModifyIndex = j.ModifyIndex
 	c.JobModifyIndex = j.JobModifyIndex
@@ -3791,6 +4241,74 @@ func (j *Job) SetSubmitTime() {
 	j.SubmitTime = time.Now().UTC().UnixNano()
 }
 
+const (
+	// JobVersionTagMaxNameLength is the maximum length of a version tag name
+	JobVersionTagMaxNameLength = 128
+
+	// JobVersionTagMaxDescriptionLength is the maximum length of a version
+	// tag description
+	JobVersionTagMaxDescriptionLength = 1024
+
+	// JobMaxTaggedVersions is the maximum number of tagged versions of a job.
+	// Tagged versions are never GCed, so their number is capped to bound the
+	// size of the job history.
+	JobMaxTaggedVersions = 10
+)
+
+// JobVersionTag is a named tag attached to a job version, allowing the version
+// to be referred to by name.
+type JobVersionTag struct {
+	// Name is the name of the tag, unique among the versions of the job
+	Name string
+
+	// Description is a human readable description of the tagged version
+	Description string
+
+	// TaggedTime is the time at which the version was tagged as a UnixNano
+	// in UTC
+	TaggedTime int64
+}
+
+// TaggedJobVersions returns the number of tagged versions amongst the job
+// versions.
+func TaggedJobVersions(versions []*Job) int {
+	n := 0
+	for _, v := range versions {
+		if v.VersionTag != nil {
+			n++
+		}
+	}
+	return n
+}
+
+func (t *JobVersionTag) Copy() *JobVersionTag {
+	if t == nil {
+		return nil
+	}
+	nt := new(JobVersionTag)
+	*nt = *t
+	return nt
+}
+
+// Validate is used to sanity check a version tag. Tag names can't be version
+// numbers so that versions can be referred to by either.
+func (t *JobVersionTag) Validate() error {
+	var mErr multierror.Error
+	if t.Name == "" {
+		mErr.Errors = append(mErr.Errors, errors.New("Missing tag name"))
+	} else if _, err := strconv.ParseUint(t.Name, 10, 64); err == nil {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("Tag name %q can't be a version number", t.Name))
+	} else if strings.ContainsAny(t.Name, "/ ") {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("Tag name %q can't contain slashes or spaces", t.Name))
+	} else if len(t.Name) > JobVersionTagMaxNameLength {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("Tag name longer than %d characters", JobVersionTagMaxNameLength))
+	}
+	if len(t.Description) > JobVersionTagMaxDescriptionLength {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("Tag description longer than %d characters", JobVersionTagMaxDescriptionLength))
+	}
+	return mErr.ErrorOrNil()
+}
+
 // JobListStub is used to return a subset of job information
 // for the job list
 type JobListStub struct {
@@ -3806,6 +4324,7 @@ type JobListStub struct {
 	Status            string
 	StatusDescription string
 	JobSummary        *JobSummary
+	Meta              map[string]string
 	CreateIndex       uint64
 	ModifyIndex       uint64
 	JobModifyIndex    uint64
@@ -3849,6 +4368,10 @@ type JobChildrenSummary struct {
 	Pending int64
 	Running int64
 	Dead    int64
+
+	// Queued is the number of dispatched jobs waiting for the parameterized
+	// job to fall below its concurrency limit.
+	Queued int64
 }
 
 // Copy returns a new copy of a JobChildrenSummary
@@ -3871,6 +4394,7 @@ type TaskGroupSummary struct {
 	Running  int
 	Starting int
 	Lost     int
+	Unknown  int
 }
 
 const (
@@ -4023,6 +4547,24 @@ const (
 	PeriodicSpecTest = "_internal_test"
 )
 
+const (
+	// PeriodicCatchupNone skips any launches that were missed while the
+	// periodic dispatcher was not running.
+	PeriodicCatchupNone = "none"
+
+	// PeriodicCatchupLatest runs only the most recent missed launch. This is
+	// the default.
+	PeriodicCatchupLatest = "latest"
+
+	// PeriodicCatchupAll runs every missed launch that falls within the
+	// catchup window.
+	PeriodicCatchupAll = "all"
+
+	// PeriodicCatchupMaxLaunches is the maximum number of missed launches
+	// that are run, keeping the latest ones.
+	PeriodicCatchupMaxLaunches = 100
+)
+
 // Periodic defines the interval a job should be run at.
 type PeriodicConfig struct {
 	// Enabled determines if the job should be run periodically.
@@ -4032,12 +4574,27 @@ type PeriodicConfig struct {
 	// on the SpecType.
 	Spec string
 
+	// Specs is an optional list of additional specs. The job is launched at
+	// the earliest time matched by Spec or any of Specs. Only cron specs may
+	// be combined.
+	Specs []string
+
 	// SpecType defines the format of the spec.
 	SpecType string
 
 	// ProhibitOverlap enforces that spawned jobs do not run in parallel.
 	ProhibitOverlap bool
 
+	// Catchup determines which of the launches missed while the periodic
+	// dispatcher was not running, such as during a leader election, are run
+	// once it is restored.
+	Catchup string
+
+	// CatchupWindow bounds how old a missed launch may be and still be
+	// caught up. A zero value places no bound on latest catchup and is
+	// invalid for all catchup.
+	CatchupWindow time.Duration
+
 	// TimeZone is the user specified string that determines the time zone to
 	// launch against. The time zones must be specified from IANA Time Zone
 	// database, such as "America/New_York".
@@ -4055,6 +4612,7 @@ func (p *PeriodicConfig) Copy() *PeriodicConfig {
 	}
 	np := new(PeriodicConfig)
 	*np = *p
+	np.Specs = helper.CopySliceString(p.Specs)
 	return np
 }
 
@@ -4064,10 +4622,23 @@ func (p *PeriodicConfig) Validate() error {
 	}
 
 	var mErr multierror.Error
-	if p.Spec == "" {
+	if p.Spec == "" && len(p.Specs) == 0 {
 		multierror.Append(&mErr, fmt.Errorf("Must specify a spec"))
 	}
 
+	switch p.Catchup {
+	case "", PeriodicCatchupNone, PeriodicCatchupLatest:
+	case PeriodicCatchupAll:
+		if p.CatchupWindow <= 0 {
+			multierror.Append(&mErr, fmt.Errorf("Catchup %q requires a positive catchup window", p.Catchup))
+		}
+	default:
+		multierror.Append(&mErr, fmt.Errorf("Unknown catchup policy %q", p.Catchup))
+	}
+	if p.CatchupWindow < 0 {
+		multierror.Append(&mErr, fmt.Errorf("Catchup window must be non-negative"))
+	}
+
 	// Check if we got a valid time zone
 	if p.TimeZone != "" {
 		if _, err := time.LoadLocation(p.TimeZone); err != nil {
@@ -4077,12 +4648,16 @@ func (p *PeriodicConfig) Validate() error {
 
 	switch p.SpecType {
 	case PeriodicSpecCron:
-		// Validate the cron spec
-		if _, err := cronexpr.Parse(p.Spec); err != nil {
-			multierror.Append(&mErr, fmt.Errorf("Invalid cron spec %q: %v", p.Spec, err))
+		// Validate the cron specs
+		for _, spec := range p.allSpecs() {
+			if _, err := cronexpr.Parse(spec); err != nil {
+				multierror.Append(&mErr, fmt.Errorf("Invalid cron spec %q: %v", spec, err))
+			}
 		}
 	case PeriodicSpecTest:
-		// No-op
+		if len(p.Specs) != 0 {
+			multierror.Append(&mErr, fmt.Errorf("Multiple specs are only supported for cron specs"))
+		}
 	default:
 		multierror.Append(&mErr, fmt.Errorf("Unknown periodic specification type %q", p.SpecType))
 	}
@@ -4120,9 +4695,23 @@ func CronParseNext(e *cronexpr.Expression, fromTime time.Time, spec string) (t t
 func (p *PeriodicConfig) Next(fromTime time.Time) (time.Time, error) {
 	switch p.SpecType {
 	case PeriodicSpecCron:
-		if e, err := cronexpr.Parse(p.Spec); err == nil {
-			return CronParseNext(e, fromTime, p.Spec)
+		// Launch at the earliest time matched by any of the specs
+		var next time.Time
+		for _, spec := range p.allSpecs() {
+			e, err := cronexpr.Parse(spec)
+			if err != nil {
+				continue
+			}
+
+			t, err := CronParseNext(e, fromTime, spec)
+			if err != nil {
+				return time.Time{}, err
+			}
+			if !t.IsZero() && (next.IsZero() || t.Before(next)) {
+				next = t
+			}
 		}
+		return next, nil
 	case PeriodicSpecTest:
 		split := strings.Split(p.Spec, ",")
 		if len(split) == 1 && split[0] == "" {
@@ -4148,98 +4737,647 @@ func (p *PeriodicConfig) Next(fromTime time.Time) (time.Time, error) {
 		}
 	}
 
-	return time.Time{}, nil
+	return time.Time{}, nil
+}
+
+// allSpecs returns Spec along with any additional Specs.
+func (p *PeriodicConfig) allSpecs() []string {
+	specs := make([]string, 0, len(p.Specs)+1)
+	if p.Spec != "" {
+		specs = append(specs, p.Spec)
+	}
+	return append(specs, p.Specs...)
+}
+
+// MissedLaunches returns the launches that should have occurred after the
+// last launch and before now, filtered by the catchup policy and window. The
+// launches are returned in ascending order. Jobs that prohibit overlap only
+// ever catch up their latest missed launch, and at most
+// PeriodicCatchupMaxLaunches of the latest missed launches are caught up.
+func (p *PeriodicConfig) MissedLaunches(last, now time.Time) ([]time.Time, error) {
+	if p.Catchup == PeriodicCatchupNone {
+		return nil, nil
+	}
+
+	// Missed launches must also be within the window
+	from := last
+	if p.CatchupWindow > 0 {
+		if earliest := now.Add(-p.CatchupWindow).Add(-time.Nanosecond); from.Before(earliest) {
+			from = earliest
+		}
+	}
+
+	limit := 1
+	if p.Catchup == PeriodicCatchupAll && !p.ProhibitOverlap {
+		limit = PeriodicCatchupMaxLaunches
+	}
+
+	// Search back from now rather than walking every launch since the last
+	// one, which may be far in the past.
+	var missed []time.Time
+	for to := now; len(missed) < limit; {
+		launch, err := p.previous(from, to)
+		if err != nil {
+			return nil, err
+		}
+		if launch.IsZero() {
+			break
+		}
+		missed = append(missed, launch)
+		to = launch
+	}
+
+	for i, j := 0, len(missed)-1; i < j; i, j = i+1, j-1 {
+		missed[i], missed[j] = missed[j], missed[i]
+	}
+	return missed, nil
+}
+
+// previous returns the latest launch after the from time and before the to
+// time, or the zero time if there is none. It searches a growing lookback from
+// the to time and then bisects it, so its cost doesn't depend on the number of
+// launches in between. Launches have a granularity of a second.
+func (p *PeriodicConfig) previous(from, to time.Time) (time.Time, error) {
+	// The latest launch is always after lo and never after hi
+	lo, hi := from, to
+	next, err := p.Next(lo)
+	if err != nil || next.IsZero() || !next.Before(to) {
+		return time.Time{}, err
+	}
+
+	for lookback := time.Minute; lookback > 0 && lookback < to.Sub(lo); lookback *= 2 {
+		start := to.Add(-lookback)
+		next, err := p.Next(start)
+		if err != nil {
+			return time.Time{}, err
+		}
+		if !next.IsZero() && next.Before(to) {
+			lo = start
+			break
+		}
+		hi = start
+	}
+
+	for hi.Sub(lo) > time.Second {
+		mid := lo.Add(hi.Sub(lo) / 2)
+		next, err := p.Next(mid)
+		if err != nil {
+			return time.Time{}, err
+		}
+		if !next.IsZero() && next.Before(to) {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	return p.Next(lo)
+}
+
+// GetLocation returns the location to use for determining the time zone to run
+// the periodic job against.
+func (p *PeriodicConfig) GetLocation() *time.Location {
+	// Jobs pre 0.5.5 will not have this
+	if p.location != nil {
+		return p.location
+	}
+
+	return time.UTC
+}
+
+const (
+	// PeriodicLaunchSuffix is the string appended to the periodic jobs ID
+	// when launching derived instances of it.
+	PeriodicLaunchSuffix = "/periodic-"
+)
+
+// PeriodicLaunch tracks the last launch time of a periodic job.
+type PeriodicLaunch struct {
+	ID        string    // ID of the periodic job.
+	Namespace string    // Namespace of the periodic job
+	Launch    time.Time // The last launch time.
+
+	// Raft Indexes
+	CreateIndex uint64
+	ModifyIndex uint64
+}
+
+const (
+	DispatchPayloadForbidden = "forbidden"
+	DispatchPayloadOptional  = "optional"
+	DispatchPayloadRequired  = "required"
+
+	// DispatchLaunchSuffix is the string appended to the parameterized job's ID
+	// when dispatching instances of it.
+	DispatchLaunchSuffix = "/dispatch-"
+)
+
+// ParameterizedJobConfig is used to configure the parameterized job
+type ParameterizedJobConfig struct {
+	// Payload configure the payload requirements
+	Payload string
+
+	// MetaRequired is metadata keys that must be specified by the dispatcher
+	MetaRequired []string
+
+	// MetaOptional is metadata keys that may be specified by the dispatcher
+	MetaOptional []string
+
+	// MaxConcurrent is the maximum number of dispatched jobs that may be
+	// pending or running at once. Dispatches beyond the limit are queued and
+	// released in order as running jobs complete. Zero means no limit.
+	MaxConcurrent int
+}
+
+func (d *ParameterizedJobConfig) Validate() error {
+	var mErr multierror.Error
+	switch d.Payload {
+	case DispatchPayloadOptional, DispatchPayloadRequired, DispatchPayloadForbidden:
+	default:
+		multierror.Append(&mErr, fmt.Errorf("Unknown payload requirement: %q", d.Payload))
+	}
+
+	if d.MaxConcurrent < 0 {
+		multierror.Append(&mErr, fmt.Errorf("Max concurrent must be non-negative"))
+	}
+
+	// Check that the meta configurations are disjoint sets
+	disjoint, offending := helper.SliceSetDisjoint(d.MetaRequired, d.MetaOptional)
+	if !disjoint {
+		multierror.Append(&mErr, fmt.Errorf("Required and optional meta keys should be disjoint. Following keys exist in both: %v", offending))
+	}
+
+	return mErr.ErrorOrNil()
+}
+
+func (d *ParameterizedJobConfig) Canonicalize() {
+	if d.Payload == "" {
+		d.Payload = DispatchPayloadOptional
+	}
+}
+
+func (d *ParameterizedJobConfig) Copy() *ParameterizedJobConfig {
+	if d == nil {
+		return nil
+	}
+	nd := new(ParameterizedJobConfig)
+	*nd = *d
+	nd.MetaOptional = helper.CopySliceString(nd.MetaOptional)
+	nd.MetaRequired = helper.CopySliceString(nd.MetaRequired)
+	return nd
+}
+
+// Multiregion is used to deploy a job to a set of regions. The job is
+// registered in every region and the deployments are rolled out across the
+// regions in the listed order.
+type Multiregion struct {
+	Strategy *MultiregionStrategy
+	Regions  []*MultiregionRegion
+}
+
+// MultiregionStrategy controls how the deployments of a multiregion job are
+// rolled out across its regions.
+type MultiregionStrategy struct {
+	// MaxParallel is the number of regions that deploy at the same time. A
+	// region starts deploying once the region MaxParallel places ahead of it
+	// completes its deployment.
+	MaxParallel int
+}
+
+// MultiregionRegion specifies the per-region overrides of a multiregion job.
+type MultiregionRegion struct {
+	Name        string
+	Count       int
+	Datacenters []string
+	Meta        map[string]string
+}
+
+func (m *Multiregion) Copy() *Multiregion {
+	if m == nil {
+		return nil
+	}
+	nm := new(Multiregion)
+	if m.Strategy != nil {
+		nm.Strategy = new(MultiregionStrategy)
+		*nm.Strategy = *m.Strategy
+	}
+	if m.Regions != nil {
+		nm.Regions = make([]*MultiregionRegion, len(m.Regions))
+		for i, r := range m.Regions {
+			nr := new(MultiregionRegion)
+			*nr = *r
+			nr.Datacenters = helper.CopySliceString(r.Datacenters)
+			nr.Meta = helper.CopyMapStringString(r.Meta)
+			nm.Regions[i] = nr
+		}
+	}
+	return nm
+}
+
+func (m *Multiregion) Canonicalize() {
+	if m.Strategy == nil {
+		m.Strategy = &MultiregionStrategy{}
+	}
+	if m.Strategy.MaxParallel == 0 {
+		m.Strategy.MaxParallel = 1
+	}
+	for _, r := range m.Regions {
+		if len(r.Meta) == 0 {
+			r.Meta = nil
+		}
+	}
+}
+
+func (m *Multiregion) Validate() error {
+	var mErr multierror.Error
+	if m.Strategy != nil && m.Strategy.MaxParallel < 0 {
+		multierror.Append(&mErr, fmt.Errorf("Multiregion max_parallel must be non-negative"))
+	}
+
+	seen := make(map[string]int, len(m.Regions))
+	for idx, r := range m.Regions {
+		if r.Name == "" {
+			multierror.Append(&mErr, fmt.Errorf("Multiregion region %d missing name", idx+1))
+		} else if existing, ok := seen[r.Name]; ok {
+			multierror.Append(&mErr, fmt.Errorf("Multiregion region %d redefines %q from region %d", idx+1, r.Name, existing+1))
+		} else {
+			seen[r.Name] = idx
+		}
+
+		if r.Count < 0 {
+			multierror.Append(&mErr, fmt.Errorf("Multiregion region %q count must be non-negative", r.Name))
+		}
+		for _, dc := range r.Datacenters {
+			if dc == "" {
+				multierror.Append(&mErr, fmt.Errorf("Multiregion region %q datacenter must be non-empty string", r.Name))
+			}
+		}
+	}
+
+	return mErr.ErrorOrNil()
+}
+
+// HasDatacenters returns whether every region sets its datacenters.
+func (m *Multiregion) HasDatacenters() bool {
+	for _, r := range m.Regions {
+		if len(r.Datacenters) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Predecessor returns the region whose deployment must complete before the
+// given region starts deploying, or an empty string if the region deploys as
+// soon as the job is registered.
+func (m *Multiregion) Predecessor(region string) string {
+	idx := m.regionIndex(region) - m.maxParallel()
+	if idx < 0 {
+		return ""
+	}
+	return m.Regions[idx].Name
+}
+
+// Successor returns the region that starts deploying once the given region's
+// deployment completes, or an empty string if there is none.
+func (m *Multiregion) Successor(region string) string {
+	idx := m.regionIndex(region)
+	if idx < 0 {
+		return ""
+	}
+	idx += m.maxParallel()
+	if idx >= len(m.Regions) {
+		return ""
+	}
+	return m.Regions[idx].Name
+}
+
+func (m *Multiregion) regionIndex(region string) int {
+	for i, r := range m.Regions {
+		if r.Name == region {
+			return i
+		}
+	}
+	return -1
+}
+
+func (m *Multiregion) maxParallel() int {
+	if m.Strategy == nil || m.Strategy.MaxParallel < 1 {
+		return 1
+	}
+	return m.Strategy.MaxParallel
+}
+
+// DispatchedID returns an ID appropriate for a job dispatched against a
+// particular parameterized job
+func DispatchedID(templateID string, t time.Time) string {
+	u := uuid.Generate()[:8]
+	return fmt.Sprintf("%s%s%d-%s", templateID, DispatchLaunchSuffix, t.Unix(), u)
+}
+
+// QueuedDispatch is a dispatched job held back because its parameterized job
+// is at its concurrency limit. Queued dispatches of a parameterized job are
+// released in the order they were queued.
+type QueuedDispatch struct {
+	// ID is the ID of the dispatched job.
+	ID string
+
+	// Namespace is the namespace of the parameterized and dispatched job.
+	Namespace string
+
+	// ParentID is the ID of the parameterized job.
+	ParentID string
+
+	// Job is the dispatched job that is registered once released.
+	Job *Job
+
+	// Raft Indexes
+	CreateIndex uint64
+	ModifyIndex uint64
+}
+
+// DispatchToken records the dispatched job created for an idempotency token
+// so that retried dispatches of the parameterized job return it rather than
+// dispatching again. Tokens are removed along with their dispatched job.
+type DispatchToken struct {
+	// Token is the idempotency token supplied when dispatching.
+	Token string
+
+	// Namespace is the namespace of the parameterized and dispatched job.
+	Namespace string
+
+	// ParentID is the ID of the parameterized job.
+	ParentID string
+
+	// JobID is the ID of the dispatched job.
+	JobID string
+
+	// CreateTime is the submit time of the dispatched job, used to determine
+	// whether the token is within its retention period.
+	CreateTime int64
+
+	// Raft Indexes
+	CreateIndex uint64
+	ModifyIndex uint64
+}
+
+// Expired returns whether the token is older than the retention period as of
+// the given time.
+func (d *DispatchToken) Expired(retention time.Duration, now time.Time) bool {
+	return now.Sub(time.Unix(0, d.CreateTime)) > retention
+}
+
+// JobSubmission is the source of a job version as it was submitted, kept to
+// audit the changes to a job. Submissions are removed along with their job
+// version.
+type JobSubmission struct {
+	// Source is the text of the submitted job file.
+	Source string
+
+	// Format is the format of the source, one of hcl1, hcl2 or json.
+	Format string
+
+	// VariableFlags are the values of the variables set with -var flags.
+	VariableFlags map[string]string
+
+	// Variables is the content of the variable files.
+	Variables string
+
+	// Namespace, JobID and Version identify the job version of the
+	// submission.
+	Namespace string
+	JobID     string
+	Version   uint64
+
+	// JobModifyIndex is the modify index of the job version.
+	JobModifyIndex uint64
+}
+
+// Copy returns a copy of the submission.
+func (s *JobSubmission) Copy() *JobSubmission {
+	if s == nil {
+		return nil
+	}
+	ns := new(JobSubmission)
+	*ns = *s
+	ns.VariableFlags = helper.CopyMapStringString(s.VariableFlags)
+	return ns
+}
+
+// Size returns the size of the source and variables of the submission.
+func (s *JobSubmission) Size() int {
+	size := len(s.Source) + len(s.Variables)
+	for k, v := range s.VariableFlags {
+		size += len(k) + len(v)
+	}
+	return size
+}
+
+// Validate validates the format of the submission.
+func (s *JobSubmission) Validate() error {
+	switch s.Format {
+	case JobSubmissionFormatHCL1, JobSubmissionFormatHCL2, JobSubmissionFormatJSON:
+		return nil
+	default:
+		return fmt.Errorf("invalid job submission format %q", s.Format)
+	}
+}
+
+const (
+	// ScalingTargetNamespace, ScalingTargetJob and ScalingTargetGroup are the
+	// keys of the target of a scaling request.
+	ScalingTargetNamespace = "Namespace"
+	ScalingTargetJob       = "Job"
+	ScalingTargetGroup     = "Group"
+)
+
+// ScalingEvent describes a scaling action or a failed attempt to scale a task
+// group.
+type ScalingEvent struct {
+	// Time is the time of the event in nanoseconds since the epoch
+	Time int64
+
+	// Count is the new count of the task group. It is nil if the event didn't
+	// change the count.
+	Count *int64
+
+	// PreviousCount is the count of the task group before the event
+	PreviousCount int64
+
+	// Message is a human readable description of the event
+	Message string
+
+	// Error is set when the event is a failure to scale
+	Error bool
+
+	// Meta is arbitrary metadata set by the scaler
+	Meta map[string]interface{}
+
+	// EvalID is the ID of the evaluation created for the new count
+	EvalID *string
+
+	// CreateIndex is the Raft index at which the event was recorded
+	CreateIndex uint64
 }
 
-// GetLocation returns the location to use for determining the time zone to run
-// the periodic job against.
-func (p *PeriodicConfig) GetLocation() *time.Location {
-	// Jobs pre 0.5.5 will not have this
-	if p.location != nil {
-		return p.location
+// Copy returns a copy of the scaling event.
+func (e *ScalingEvent) Copy() *ScalingEvent {
+	if e == nil {
+		return nil
 	}
-
-	return time.UTC
+	ne := new(ScalingEvent)
+	*ne = *e
+	if e.Count != nil {
+		ne.Count = helper.Int64ToPtr(*e.Count)
+	}
+	if e.EvalID != nil {
+		ne.EvalID = helper.StringToPtr(*e.EvalID)
+	}
+	ne.Meta = helper.CopyMapStringInterface(e.Meta)
+	return ne
 }
 
-const (
-	// PeriodicLaunchSuffix is the string appended to the periodic jobs ID
-	// when launching derived instances of it.
-	PeriodicLaunchSuffix = "/periodic-"
-)
+// JobScalingEvents holds the most recent scaling events of the task groups
+// of a job. Events are removed along with the job.
+type JobScalingEvents struct {
+	Namespace string
+	JobID     string
 
-// PeriodicLaunch tracks the last launch time of a periodic job.
-type PeriodicLaunch struct {
-	ID        string    // ID of the periodic job.
-	Namespace string    // Namespace of the periodic job
-	Launch    time.Time // The last launch time.
+	// ScalingEvents are the events of each task group, most recent first
+	ScalingEvents map[string][]*ScalingEvent
 
 	// Raft Indexes
-	CreateIndex uint64
 	ModifyIndex uint64
 }
 
-const (
-	DispatchPayloadForbidden = "forbidden"
-	DispatchPayloadOptional  = "optional"
-	DispatchPayloadRequired  = "required"
+// Copy returns a copy of the scaling events of the job.
+func (j *JobScalingEvents) Copy() *JobScalingEvents {
+	if j == nil {
+		return nil
+	}
+	nj := new(JobScalingEvents)
+	*nj = *j
+	nj.ScalingEvents = make(map[string][]*ScalingEvent, len(j.ScalingEvents))
+	for group, events := range j.ScalingEvents {
+		nevents := make([]*ScalingEvent, len(events))
+		for i, e := range events {
+			nevents[i] = e.Copy()
+		}
+		nj.ScalingEvents[group] = nevents
+	}
+	return nj
+}
 
-	// DispatchLaunchSuffix is the string appended to the parameterized job's ID
-	// when dispatching instances of it.
-	DispatchLaunchSuffix = "/dispatch-"
-)
+// JobScaleStatus is the scaling status of the task groups of a job
+type JobScaleStatus struct {
+	JobID          string
+	Namespace      string
+	JobCreateIndex uint64
+	JobModifyIndex uint64
+	JobStopped     bool
+	TaskGroups     map[string]*TaskGroupScaleStatus
+}
+
+// TaskGroupScaleStatus is the scaling status of a task group. Placed,
+// Healthy and Unhealthy are those of the latest deployment of the job.
+type TaskGroupScaleStatus struct {
+	Desired   int
+	Placed    int
+	Running   int
+	Healthy   int
+	Unhealthy int
+	Events    []*ScalingEvent
+}
+
+// ScalingPolicy is the policy of a task group read by external autoscalers.
+// Nomad only enforces that the count of the task group stays within Min and
+// Max; Policy is opaque to Nomad.
+type ScalingPolicy struct {
+	// ID is the ID of the policy, kept across the versions of the job
+	ID string
 
-// ParameterizedJobConfig is used to configure the parameterized job
-type ParameterizedJobConfig struct {
-	// Payload configure the payload requirements
-	Payload string
+	// Namespace is the namespace of the job of the policy
+	Namespace string
 
-	// MetaRequired is metadata keys that must be specified by the dispatcher
-	MetaRequired []string
+	// Target identifies the scaled task group. It is keyed by the
+	// ScalingTarget constants.
+	Target map[string]string
 
-	// MetaOptional is metadata keys that may be specified by the dispatcher
-	MetaOptional []string
+	// Min and Max are the bounds of the count of the task group
+	Min int64
+	Max int64
+
+	// Policy is the configuration of the autoscaler
+	Policy map[string]interface{}
+
+	// Enabled marks whether the autoscaler should act on the policy
+	Enabled bool
+
+	// Raft Indexes
+	CreateIndex uint64
+	ModifyIndex uint64
 }
 
-func (d *ParameterizedJobConfig) Validate() error {
-	var mErr multierror.Error
-	switch d.Payload {
-	case DispatchPayloadOptional, DispatchPayloadRequired, DispatchPayloadForbidden:
-	default:
-		multierror.Append(&mErr, fmt.Errorf("Unknown payload requirement: %q", d.Payload))
+// Copy returns a copy of the scaling policy.
+func (p *ScalingPolicy) Copy() *ScalingPolicy {
+	if p == nil {
+		return nil
 	}
+	np := new(ScalingPolicy)
+	*np = *p
+	np.Target = helper.CopyMapStringString(p.Target)
 
-	// Check that the meta configurations are disjoint sets
-	disjoint, offending := helper.SliceSetDisjoint(d.MetaRequired, d.MetaOptional)
-	if !disjoint {
-		multierror.Append(&mErr, fmt.Errorf("Required and optional meta keys should be disjoint. Following keys exist in both: %v", offending))
+	if i, err := copystructure.Copy(p.Policy); err != nil {
+		panic(err.Error())
+	} else {
+		np.Policy = i.(map[string]interface{})
 	}
+	return np
+}
 
-	return mErr.ErrorOrNil()
+// TargetTaskGroup sets the target of the policy to the task group of the job.
+func (p *ScalingPolicy) TargetTaskGroup(job *Job, tg *TaskGroup) *ScalingPolicy {
+	p.Namespace = job.Namespace
+	p.Target = map[string]string{
+		ScalingTargetNamespace: job.Namespace,
+		ScalingTargetJob:       job.ID,
+		ScalingTargetGroup:     tg.Name,
+	}
+	return p
 }
 
-func (d *ParameterizedJobConfig) Canonicalize() {
-	if d.Payload == "" {
-		d.Payload = DispatchPayloadOptional
+// Validate validates the bounds of the scaling policy.
+func (p *ScalingPolicy) Validate() error {
+	var mErr multierror.Error
+	if p.Max < 0 {
+		mErr.Errors = append(mErr.Errors, errors.New("maximum count must be specified and non-negative"))
+	}
+	if p.Min < 0 {
+		mErr.Errors = append(mErr.Errors, errors.New("minimum count must be non-negative"))
 	}
+	if p.Max >= 0 && p.Min > p.Max {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("maximum count %d is less than minimum count %d", p.Max, p.Min))
+	}
+	return mErr.ErrorOrNil()
 }
 
-func (d *ParameterizedJobConfig) Copy() *ParameterizedJobConfig {
-	if d == nil {
-		return nil
+// Stub returns a summary of the scaling policy.
+func (p *ScalingPolicy) Stub() *ScalingPolicyListStub {
+	return &ScalingPolicyListStub{
+		ID:          p.ID,
+		Enabled:     p.Enabled,
+		Target:      helper.CopyMapStringString(p.Target),
+		CreateIndex: p.CreateIndex,
+		ModifyIndex: p.ModifyIndex,
 	}
-	nd := new(ParameterizedJobConfig)
-	*nd = *d
-	nd.MetaOptional = helper.CopySliceString(nd.MetaOptional)
-	nd.MetaRequired = helper.CopySliceString(nd.MetaRequired)
-	return nd
 }
 
-// DispatchedID returns an ID appropriate for a job dispatched against a
-// particular parameterized job
-func DispatchedID(templateID string, t time.Time) string {
-	u := uuid.Generate()[:8]
-	return fmt.Sprintf("%s%s%d-%s", templateID, DispatchLaunchSuffix, t.Unix(), u)
+// ScalingPolicyListStub is used to return a subset of a scaling policy
+type ScalingPolicyListStub struct {
+	ID          string
+	Enabled     bool
+	Target      map[string]string
+	CreateIndex uint64
+	ModifyIndex uint64
 }
 
 // DispatchPayloadConfig configures how a task gets its input from a job dispatch
@@ -4707,6 +5845,15 @@ type TaskGroup struct {
 
 	// Volumes is a map of volumes that have been requested by the task group.
 	Volumes map[string]*VolumeRequest
+
+	// MaxClientDisconnect, if set, configures how long allocations of the
+	// group may remain in the unknown state after their node stops
+	// heartbeating before they are considered lost and replaced.
+	MaxClientDisconnect *time.Duration
+
+	// Scaling is the policy used by external autoscalers to scale the task
+	// group
+	Scaling *ScalingPolicy
 }
 
 func (tg *TaskGroup) Copy() *TaskGroup {
@@ -4722,6 +5869,11 @@ func (tg *TaskGroup) Copy() *TaskGroup {
 	ntg.Affinities = CopySliceAffinities(ntg.Affinities)
 	ntg.Spreads = CopySliceSpreads(ntg.Spreads)
 	ntg.Volumes = CopyMapVolumeRequest(ntg.Volumes)
+	ntg.Scaling = ntg.Scaling.Copy()
+
+	if tg.MaxClientDisconnect != nil {
+		ntg.MaxClientDisconnect = helper.TimeToPtr(*tg.MaxClientDisconnect)
+	}
 
 	// Copy the network objects
 	if tg.Networks != nil {
@@ -4791,6 +5943,10 @@ func (tg *TaskGroup) Canonicalize(job *Job) {
 		network.Canonicalize()
 	}
 
+	if tg.Scaling != nil {
+		tg.Scaling.TargetTaskGroup(job, tg)
+	}
+
 	for _, task := range tg.Tasks {
 		task.Canonicalize(job, tg)
 	}
@@ -4882,6 +6038,27 @@ func (tg *TaskGroup) Validate(j *Job) error {
 		}
 	}
 
+	// Validate the scaling policy
+	if tg.Scaling != nil {
+		if j.Type == JobTypeSystem {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("System jobs may not have a scaling stanza"))
+		} else if err := tg.Scaling.Validate(); err != nil {
+			mErr.Errors = append(mErr.Errors, multierror.Prefix(err, "Scaling policy:"))
+		} else if int64(tg.Count) < tg.Scaling.Min || int64(tg.Count) > tg.Scaling.Max {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("Task group count %d must be between the scaling minimum %d and maximum %d",
+				tg.Count, tg.Scaling.Min, tg.Scaling.Max))
+		}
+	}
+
+	// Validate the disconnect window
+	if tg.MaxClientDisconnect != nil {
+		if j.Type == JobTypeSystem {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("System jobs may not set max_client_disconnect"))
+		} else if *tg.MaxClientDisconnect < 0 {
+			mErr.Errors = append(mErr.Errors, errors.New("max_client_disconnect cannot be negative"))
+		}
+	}
+
 	// Validate the migration strategy
 	switch j.Type {
 	case JobTypeService:
@@ -7049,6 +8226,7 @@ const (
 	DeploymentStatusDescriptionRunningNeedsPromotion = "Deployment is running but requires manual promotion"
 	DeploymentStatusDescriptionRunningAutoPromotion  = "Deployment is running pending automatic promotion"
 	DeploymentStatusDescriptionPaused                = "Deployment is paused"
+	DeploymentStatusDescriptionPendingRegion         = "Deployment is pending the completion of an earlier region"
 	DeploymentStatusDescriptionSuccessful            = "Deployment completed successfully"
 	DeploymentStatusDescriptionStoppedJob            = "Cancelled because job is stopped"
 	DeploymentStatusDescriptionNewerJob              = "Cancelled due to newer version of job"
@@ -7411,6 +8589,7 @@ const (
 	AllocClientStatusComplete = "complete"
 	AllocClientStatusFailed   = "failed"
 	AllocClientStatusLost     = "lost"
+	AllocClientStatusUnknown  = "unknown"
 )
 
 // Allocation is used to allocate the placement of a task group to a node.
@@ -7530,6 +8709,10 @@ type Allocation struct {
 
 	// ModifyTime is the time the allocation was last updated.
 	ModifyTime int64
+
+	// LostTime is the time the allocation was last marked as lost by the
+	// scheduler. It is not updated when the client updates the allocation.
+	LostTime int64
 }
 
 // Index returns the index of the allocation. If the allocation is from a task
@@ -8266,6 +9449,9 @@ const (
 	EvalTriggerRetryFailedAlloc  = "alloc-failure"
 	EvalTriggerQueuedAllocs      = "queued-allocs"
 	EvalTriggerPreemption        = "preemption"
+	EvalTriggerMaxDisconnect     = "max-disconnect-timeout"
+	EvalTriggerReconnect         = "reconnect"
+	EvalTriggerScaling           = "job-scaling"
 )
 
 const (
@@ -8677,12 +9863,33 @@ func (p *Plan) AppendStoppedAlloc(alloc *Allocation, desiredDesc, clientStatus s
 	if clientStatus != "" {
 		newAlloc.ClientStatus = clientStatus
 	}
+	if clientStatus == AllocClientStatusLost {
+		newAlloc.LostTime = time.Now().UTC().UnixNano()
+	}
 
 	node := alloc.NodeID
 	existing := p.NodeUpdate[node]
 	p.NodeUpdate[node] = append(existing, newAlloc)
 }
 
+// AppendUnknownAlloc marks an allocation on a disconnected node as having an
+// unknown client status. The allocation keeps its desired status so that it
+// may resume if the node reconnects.
+func (p *Plan) AppendUnknownAlloc(alloc *Allocation, clientDesc string) {
+	newAlloc := new(Allocation)
+	*newAlloc = *alloc
+
+	// Normalize the job
+	newAlloc.Job = nil
+
+	newAlloc.ClientStatus = AllocClientStatusUnknown
+	newAlloc.ClientDescription = clientDesc
+
+	node := alloc.NodeID
+	existing := p.NodeAllocation[node]
+	p.NodeAllocation[node] = append(existing, newAlloc)
+}
+
 // AppendPreemptedAlloc is used to append an allocation that's being preempted to the plan.
 // To minimize the size of the plan, this only sets a minimal set of fields in the allocation
 func (p *Plan) AppendPreemptedAlloc(alloc *Allocation, preemptingAllocID string) {
@@ -9316,3 +10523,241 @@ type ACLTokenUpsertResponse struct {
 	Tokens []*ACLToken
 	WriteMeta
 }
+
+// Namespace allows logically grouping jobs and their associated objects.
+type Namespace struct {
+	// Name is the name of the namespace
+	Name string
+
+	// Description is a human readable description of the namespace
+	Description string
+
+	// Quota is the quota specification that the namespace should account
+	// against.
+	Quota string
+
+	// Hash is the hash of the user set fields of the namespace
+	Hash []byte
+
+	// Raft Indexes
+	CreateIndex uint64
+	ModifyIndex uint64
+}
+
+func (n *Namespace) Validate() error {
+	var mErr multierror.Error
+
+	// Validate the name and description
+	if !validNamespaceName.MatchString(n.Name) {
+		err := fmt.Errorf("invalid name %q. Must match regex %s", n.Name, validNamespaceName)
+		mErr.Errors = append(mErr.Errors, err)
+	}
+	if len(n.Description) > maxNamespaceDescriptionLength {
+		err := fmt.Errorf("description longer than %d", maxNamespaceDescriptionLength)
+		mErr.Errors = append(mErr.Errors, err)
+	}
+
+	return mErr.ErrorOrNil()
+}
+
+// SetHash is used to compute and set the hash of the namespace
+func (n *Namespace) SetHash() []byte {
+	// Initialize a 256bit Blake2 hash (32 bytes)
+	hash, err := blake2b.New256(nil)
+	if err != nil {
+		panic(err)
+	}
+
+	// Write all the user set fields
+	hash.Write([]byte(n.Name))
+	hash.Write([]byte(n.Description))
+	hash.Write([]byte(n.Quota))
+
+	// Finalize the hash
+	hashVal := hash.Sum(nil)
+
+	// Set and return the hash
+	n.Hash = hashVal
+	return hashVal
+}
+
+func (n *Namespace) Copy() *Namespace {
+	nc := new(Namespace)
+	*nc = *n
+	nc.Hash = make([]byte, len(n.Hash))
+	copy(nc.Hash, n.Hash)
+	return nc
+}
+
+// NamespaceListRequest is used to request a list of namespaces
+type NamespaceListRequest struct {
+	QueryOptions
+}
+
+// NamespaceListResponse is used for a list request
+type NamespaceListResponse struct {
+	Namespaces []*Namespace
+	QueryMeta
+}
+
+// NamespaceSpecificRequest is used to query a specific namespace
+type NamespaceSpecificRequest struct {
+	Name string
+	QueryOptions
+}
+
+// SingleNamespaceResponse is used to return a single namespace
+type SingleNamespaceResponse struct {
+	Namespace *Namespace
+	QueryMeta
+}
+
+// NamespaceDeleteRequest is used to delete a set of namespaces
+type NamespaceDeleteRequest struct {
+	Namespaces []string
+	WriteRequest
+}
+
+// NamespaceUpsertRequest is used to upsert a set of namespaces
+type NamespaceUpsertRequest struct {
+	Namespaces []*Namespace
+	WriteRequest
+}
+
+const (
+	// AdmissionPolicyEnforcementAdvisory policies only warn when they fail.
+	AdmissionPolicyEnforcementAdvisory = "advisory"
+
+	// AdmissionPolicyEnforcementSoftMandatory policies reject the job when
+	// they fail, unless the policy override is set.
+	AdmissionPolicyEnforcementSoftMandatory = "soft-mandatory"
+
+	// AdmissionPolicyEnforcementHardMandatory policies always reject the
+	// job when they fail.
+	AdmissionPolicyEnforcementHardMandatory = "hard-mandatory"
+)
+
+// AdmissionPolicy is a policy enforced when jobs are registered or planned.
+type AdmissionPolicy struct {
+	Name             string // Unique name
+	Description      string // Human readable
+	EnforcementLevel string // Advisory, soft-mandatory or hard-mandatory
+	Policy           string // Rules in HCL2 format
+	Hash             []byte
+	CreateIndex      uint64
+	ModifyIndex      uint64
+}
+
+// SetHash is used to compute and set the hash of the admission policy
+func (a *AdmissionPolicy) SetHash() []byte {
+	// Initialize a 256bit Blake2 hash (32 bytes)
+	hash, err := blake2b.New256(nil)
+	if err != nil {
+		panic(err)
+	}
+
+	// Write all the user set fields
+	hash.Write([]byte(a.Name))
+	hash.Write([]byte(a.Description))
+	hash.Write([]byte(a.EnforcementLevel))
+	hash.Write([]byte(a.Policy))
+
+	// Finalize the hash
+	hashVal := hash.Sum(nil)
+
+	// Set and return the hash
+	a.Hash = hashVal
+	return hashVal
+}
+
+func (a *AdmissionPolicy) Copy() *AdmissionPolicy {
+	ac := new(AdmissionPolicy)
+	*ac = *a
+	ac.Hash = make([]byte, len(a.Hash))
+	copy(ac.Hash, a.Hash)
+	return ac
+}
+
+func (a *AdmissionPolicy) Stub() *AdmissionPolicyListStub {
+	return &AdmissionPolicyListStub{
+		Name:             a.Name,
+		Description:      a.Description,
+		EnforcementLevel: a.EnforcementLevel,
+		Hash:             a.Hash,
+		CreateIndex:      a.CreateIndex,
+		ModifyIndex:      a.ModifyIndex,
+	}
+}
+
+func (a *AdmissionPolicy) Validate() error {
+	var mErr multierror.Error
+	if !validPolicyName.MatchString(a.Name) {
+		err := fmt.Errorf("invalid name '%s'", a.Name)
+		mErr.Errors = append(mErr.Errors, err)
+	}
+	switch a.EnforcementLevel {
+	case AdmissionPolicyEnforcementAdvisory,
+		AdmissionPolicyEnforcementSoftMandatory,
+		AdmissionPolicyEnforcementHardMandatory:
+	default:
+		err := fmt.Errorf("invalid enforcement level %q. Must be one of %s, %s or %s", a.EnforcementLevel,
+			AdmissionPolicyEnforcementAdvisory,
+			AdmissionPolicyEnforcementSoftMandatory,
+			AdmissionPolicyEnforcementHardMandatory)
+		mErr.Errors = append(mErr.Errors, err)
+	}
+	if _, err := policy.Parse(a.Name, a.Policy); err != nil {
+		err = fmt.Errorf("failed to parse policy: %v", err)
+		mErr.Errors = append(mErr.Errors, err)
+	}
+	if len(a.Description) > maxPolicyDescriptionLength {
+		err := fmt.Errorf("description longer than %d", maxPolicyDescriptionLength)
+		mErr.Errors = append(mErr.Errors, err)
+	}
+	return mErr.ErrorOrNil()
+}
+
+// AdmissionPolicyListStub is used to for listing admission policies
+type AdmissionPolicyListStub struct {
+	Name             string
+	Description      string
+	EnforcementLevel string
+	Hash             []byte
+	CreateIndex      uint64
+	ModifyIndex      uint64
+}
+
+// AdmissionPolicyListRequest is used to request a list of policies
+type AdmissionPolicyListRequest struct {
+	QueryOptions
+}
+
+// AdmissionPolicyListResponse is used for a list request
+type AdmissionPolicyListResponse struct {
+	Policies []*AdmissionPolicyListStub
+	QueryMeta
+}
+
+// AdmissionPolicySpecificRequest is used to query a specific policy
+type AdmissionPolicySpecificRequest struct {
+	Name string
+	QueryOptions
+}
+
+// SingleAdmissionPolicyResponse is used to return a single policy
+type SingleAdmissionPolicyResponse struct {
+	Policy *AdmissionPolicy
+	QueryMeta
+}
+
+// AdmissionPolicyDeleteRequest is used to delete a set of policies
+type AdmissionPolicyDeleteRequest struct {
+	Names []string
+	WriteRequest
+}
+
+// AdmissionPolicyUpsertRequest is used to upsert a set of policies
+type AdmissionPolicyUpsertRequest struct {
+	Policies []*AdmissionPolicy
+	WriteRequest
+}
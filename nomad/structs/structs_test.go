@@ -10,6 +10,7 @@ import (
 
 	"github.com/hashicorp/consul/api"
 	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/nomad/helper"
 	"github.com/hashicorp/nomad/helper/uuid"
 	"github.com/kr/pretty"
 	"github.com/stretchr/testify/assert"
@@ -194,6 +195,7 @@ func TestJob_SpecChanged(t *testing.T) {
 	mutatedBase := base.Copy()
 	mutatedBase.Status = "foo"
 	mutatedBase.ModifyIndex = base.ModifyIndex + 100
+	mutatedBase.VersionTag = &JobVersionTag{Name: "release"}
 
 	// changed contains a spec change that should be detected
 	change := base.Copy()
@@ -228,6 +230,91 @@ func TestJob_SpecChanged(t *testing.T) {
 	}
 }
 
+func TestJobVersionTag_Validate(t *testing.T) {
+	cases := []struct {
+		Name string
+		Tag  *JobVersionTag
+		Err  string
+	}{
+		{
+			Name: "valid",
+			Tag:  &JobVersionTag{Name: "release-2026.10", Description: "DEPLOY-123"},
+		},
+		{
+			Name: "missing name",
+			Tag:  &JobVersionTag{},
+			Err:  "Missing tag name",
+		},
+		{
+			Name: "version number",
+			Tag:  &JobVersionTag{Name: "12"},
+			Err:  "can't be a version number",
+		},
+		{
+			Name: "slash",
+			Tag:  &JobVersionTag{Name: "release/1"},
+			Err:  "can't contain slashes or spaces",
+		},
+		{
+			Name: "long description",
+			Tag:  &JobVersionTag{Name: "release", Description: strings.Repeat("a", JobVersionTagMaxDescriptionLength+1)},
+			Err:  "Tag description longer than",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			err := c.Tag.Validate()
+			if c.Err == "" {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), c.Err)
+			}
+		})
+	}
+}
+
+func TestNamespace_Validate(t *testing.T) {
+	cases := []struct {
+		Name      string
+		Namespace *Namespace
+		Err       string
+	}{
+		{
+			Name:      "valid",
+			Namespace: &Namespace{Name: "team-api", Description: "API team"},
+		},
+		{
+			Name:      "missing name",
+			Namespace: &Namespace{},
+			Err:       "invalid name",
+		},
+		{
+			Name:      "invalid name",
+			Namespace: &Namespace{Name: "team api"},
+			Err:       "invalid name",
+		},
+		{
+			Name:      "long description",
+			Namespace: &Namespace{Name: "team-api", Description: strings.Repeat("a", maxNamespaceDescriptionLength+1)},
+			Err:       "description longer than",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			err := c.Namespace.Validate()
+			if c.Err == "" {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), c.Err)
+			}
+		})
+	}
+}
+
 func testJob() *Job {
 	return &Job{
 		Region:      "global",
@@ -963,6 +1050,79 @@ func TestTaskGroup_Validate(t *testing.T) {
 	expected = `Check check-a invalid: only script and gRPC checks should have tasks`
 	require.Contains(t, err.Error(), expected)
 
+	tg = &TaskGroup{
+		Name:                "group-a",
+		MaxClientDisconnect: helper.TimeToPtr(-1 * time.Second),
+		Tasks:               []*Task{taskA},
+	}
+	err = tg.Validate(&Job{Type: JobTypeService})
+	expected = `max_client_disconnect cannot be negative`
+	require.Contains(t, err.Error(), expected)
+
+	tg.MaxClientDisconnect = helper.TimeToPtr(time.Minute)
+	err = tg.Validate(&Job{Type: JobTypeSystem})
+	expected = `System jobs may not set max_client_disconnect`
+	require.Contains(t, err.Error(), expected)
+}
+
+func TestScalingPolicy_Copy(t *testing.T) {
+	p := &ScalingPolicy{
+		ID:     "policy",
+		Target: map[string]string{ScalingTargetGroup: "web"},
+		Policy: map[string]interface{}{
+			"cooldown": "1m",
+			"check": map[string]interface{}{
+				"query":  "avg_cpu",
+				"labels": []interface{}{"a", "b"},
+			},
+		},
+		Min: 1,
+		Max: 5,
+	}
+
+	c := p.Copy()
+	require.Equal(t, p, c)
+
+	// Nested values of the policy aren't shared with the copy
+	c.Policy["check"].(map[string]interface{})["query"] = "avg_memory"
+	c.Policy["check"].(map[string]interface{})["labels"].([]interface{})[0] = "c"
+	c.Target[ScalingTargetGroup] = "api"
+	require.Equal(t, "avg_cpu", p.Policy["check"].(map[string]interface{})["query"])
+	require.Equal(t, "a", p.Policy["check"].(map[string]interface{})["labels"].([]interface{})[0])
+	require.Equal(t, "web", p.Target[ScalingTargetGroup])
+}
+
+func TestTaskGroup_Validate_Scaling(t *testing.T) {
+	taskA := &Task{Name: "task-a"}
+	tg := &TaskGroup{
+		Name:  "group-a",
+		Count: 1,
+		Scaling: &ScalingPolicy{
+			Min: 2,
+			Max: 1,
+		},
+		Tasks: []*Task{taskA},
+	}
+	err := tg.Validate(&Job{Type: JobTypeService})
+	require.Contains(t, err.Error(), "maximum count 1 is less than minimum count 2")
+
+	tg.Scaling = &ScalingPolicy{Min: 0, Max: -1}
+	err = tg.Validate(&Job{Type: JobTypeService})
+	require.Contains(t, err.Error(), "maximum count must be specified and non-negative")
+
+	tg.Scaling = &ScalingPolicy{Min: 1, Max: 5}
+	tg.Count = 10
+	err = tg.Validate(&Job{Type: JobTypeService})
+	require.Contains(t, err.Error(), "Task group count 10 must be between the scaling minimum 1 and maximum 5")
+
+	tg.Count = 3
+	err = tg.Validate(&Job{Type: JobTypeService})
+	if err != nil {
+		require.NotContains(t, err.Error(), "scaling")
+	}
+
+	err = tg.Validate(&Job{Type: JobTypeSystem})
+	require.Contains(t, err.Error(), "System jobs may not have a scaling stanza")
 }
 
 func TestTask_Validate(t *testing.T) {
@@ -2696,6 +2856,151 @@ func TestPeriodicConfig_DST(t *testing.T) {
 	require.Equal(e2, n2.UTC())
 }
 
+func TestPeriodicConfig_NextMultipleSpecs(t *testing.T) {
+	require := require.New(t)
+
+	// Weekdays at 09:00 and weekends at 12:00
+	p := &PeriodicConfig{
+		Enabled:  true,
+		SpecType: PeriodicSpecCron,
+		Specs:    []string{"0 9 * * 1-5", "0 12 * * 6,0"},
+	}
+	p.Canonicalize()
+	require.NoError(p.Validate())
+
+	// Friday, November 13th 2009
+	from := time.Date(2009, time.November, 13, 10, 0, 0, 0, time.UTC)
+	n, err := p.Next(from)
+	require.NoError(err)
+	require.Equal(time.Date(2009, time.November, 14, 12, 0, 0, 0, time.UTC), n)
+
+	n, err = p.Next(n)
+	require.NoError(err)
+	require.Equal(time.Date(2009, time.November, 15, 12, 0, 0, 0, time.UTC), n)
+
+	n, err = p.Next(n)
+	require.NoError(err)
+	require.Equal(time.Date(2009, time.November, 16, 9, 0, 0, 0, time.UTC), n)
+
+	// An invalid spec in the list is rejected
+	p.Specs = append(p.Specs, "foo")
+	require.Error(p.Validate())
+}
+
+func TestPeriodicConfig_ValidateCatchup(t *testing.T) {
+	require := require.New(t)
+
+	p := &PeriodicConfig{Enabled: true, SpecType: PeriodicSpecCron, Spec: "@hourly"}
+	for _, c := range []string{"", PeriodicCatchupNone, PeriodicCatchupLatest} {
+		p.Catchup = c
+		require.NoError(p.Validate())
+	}
+
+	p.Catchup = PeriodicCatchupAll
+	err := p.Validate()
+	require.Error(err)
+	require.Contains(err.Error(), "positive catchup window")
+
+	p.CatchupWindow = time.Hour
+	require.NoError(p.Validate())
+
+	p.Catchup = "foo"
+	err = p.Validate()
+	require.Error(err)
+	require.Contains(err.Error(), "Unknown catchup policy")
+}
+
+func TestPeriodicConfig_MissedLaunches(t *testing.T) {
+	last := time.Date(2009, time.November, 10, 8, 30, 0, 0, time.UTC)
+	now := time.Date(2009, time.November, 10, 12, 30, 0, 0, time.UTC)
+	at := func(hour int) time.Time {
+		return time.Date(2009, time.November, 10, hour, 0, 0, 0, time.UTC)
+	}
+
+	cases := []struct {
+		Name     string
+		Config   *PeriodicConfig
+		Expected []time.Time
+	}{
+		{
+			Name:     "default",
+			Config:   &PeriodicConfig{},
+			Expected: []time.Time{at(12)},
+		},
+		{
+			Name:     "none",
+			Config:   &PeriodicConfig{Catchup: PeriodicCatchupNone},
+			Expected: nil,
+		},
+		{
+			Name:     "latest",
+			Config:   &PeriodicConfig{Catchup: PeriodicCatchupLatest},
+			Expected: []time.Time{at(12)},
+		},
+		{
+			Name:     "latest outside window",
+			Config:   &PeriodicConfig{Catchup: PeriodicCatchupLatest, CatchupWindow: 10 * time.Minute},
+			Expected: nil,
+		},
+		{
+			Name:     "all",
+			Config:   &PeriodicConfig{Catchup: PeriodicCatchupAll, CatchupWindow: 24 * time.Hour},
+			Expected: []time.Time{at(9), at(10), at(11), at(12)},
+		},
+		{
+			Name:     "all within window",
+			Config:   &PeriodicConfig{Catchup: PeriodicCatchupAll, CatchupWindow: 2 * time.Hour},
+			Expected: []time.Time{at(11), at(12)},
+		},
+		{
+			Name:     "all prohibiting overlap",
+			Config:   &PeriodicConfig{Catchup: PeriodicCatchupAll, CatchupWindow: 24 * time.Hour, ProhibitOverlap: true},
+			Expected: []time.Time{at(12)},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			c.Config.Enabled = true
+			c.Config.SpecType = PeriodicSpecCron
+			c.Config.Spec = "@hourly"
+			c.Config.Canonicalize()
+
+			missed, err := c.Config.MissedLaunches(last, now)
+			require.NoError(t, err)
+			require.Equal(t, c.Expected, missed)
+		})
+	}
+}
+
+func TestPeriodicConfig_MissedLaunches_Bounded(t *testing.T) {
+	require := require.New(t)
+	last := time.Date(1990, time.January, 1, 0, 0, 0, 0, time.UTC)
+	now := time.Date(2009, time.November, 10, 12, 30, 0, 0, time.UTC)
+
+	// A launch every second since long before now
+	p := &PeriodicConfig{
+		Enabled:  true,
+		SpecType: PeriodicSpecCron,
+		Spec:     "* * * * * * *",
+	}
+	p.Canonicalize()
+
+	missed, err := p.MissedLaunches(last, now)
+	require.NoError(err)
+	require.Equal([]time.Time{now.Add(-time.Second)}, missed)
+
+	// All catchup only keeps the latest launches
+	p.Catchup = PeriodicCatchupAll
+	p.CatchupWindow = 30 * 365 * 24 * time.Hour
+	missed, err = p.MissedLaunches(last, now)
+	require.NoError(err)
+	require.Len(missed, PeriodicCatchupMaxLaunches)
+	for i, launch := range missed {
+		require.Equal(now.Add(time.Duration(i-PeriodicCatchupMaxLaunches)*time.Second), launch)
+	}
+}
+
 func TestRestartPolicy_Validate(t *testing.T) {
 	// Policy with acceptable restart options passes
 	p := &RestartPolicy{
@@ -3271,6 +3576,8 @@ func TestPlan_AppendStoppedAllocAppendsAllocWithUpdatedAttrs(t *testing.T) {
 	expectedAlloc.DesiredStatus = AllocDesiredStatusStop
 	expectedAlloc.ClientStatus = AllocClientStatusLost
 	expectedAlloc.Job = nil
+	assert.NotZero(t, appendedAlloc.LostTime)
+	expectedAlloc.LostTime = appendedAlloc.LostTime
 	assert.Equal(t, expectedAlloc, appendedAlloc)
 	assert.Equal(t, alloc.Job, plan.Job)
 }
@@ -4111,6 +4418,31 @@ func TestParameterizedJobConfig_Validate(t *testing.T) {
 	if err := d.Validate(); err == nil || !strings.Contains(err.Error(), "disjoint") {
 		t.Fatalf("Expected meta not being disjoint error: %v", err)
 	}
+
+	d.MetaRequired = nil
+	d.MaxConcurrent = -1
+
+	if err := d.Validate(); err == nil || !strings.Contains(err.Error(), "Max concurrent") {
+		t.Fatalf("Expected negative concurrency limit error: %v", err)
+	}
+}
+
+func TestParameterizedJobConfig_Validate_MaxConcurrentPeriodic(t *testing.T) {
+	job := testJob()
+	job.Type = JobTypeBatch
+	job.ParameterizedJob = &ParameterizedJobConfig{
+		Payload:       DispatchPayloadOptional,
+		MaxConcurrent: 1,
+	}
+	job.Periodic = &PeriodicConfig{
+		Enabled:  true,
+		Spec:     "*/15 * * * *",
+		SpecType: PeriodicSpecCron,
+	}
+
+	if err := job.Validate(); err == nil || !strings.Contains(err.Error(), "concurrency limit") {
+		t.Fatalf("Expected concurrency limit with periodic error: %v", err)
+	}
 }
 
 func TestParameterizedJobConfig_Validate_NonBatch(t *testing.T) {
@@ -4133,6 +4465,105 @@ func TestParameterizedJobConfig_Canonicalize(t *testing.T) {
 	}
 }
 
+func TestMultiregion_Validate(t *testing.T) {
+	require := require.New(t)
+	m := &Multiregion{
+		Strategy: &MultiregionStrategy{MaxParallel: -1},
+		Regions: []*MultiregionRegion{
+			{Name: "west", Count: -1},
+			{Name: "west", Datacenters: []string{""}},
+			{},
+		},
+	}
+
+	err := m.Validate()
+	require.Error(err)
+	mErr := err.(*multierror.Error)
+	require.Len(mErr.Errors, 5)
+	require.Contains(mErr.Errors[0].Error(), "max_parallel must be non-negative")
+	require.Contains(mErr.Errors[1].Error(), `"west" count must be non-negative`)
+	require.Contains(mErr.Errors[2].Error(), `redefines "west" from region 1`)
+	require.Contains(mErr.Errors[3].Error(), "datacenter must be non-empty string")
+	require.Contains(mErr.Errors[4].Error(), "region 3 missing name")
+
+	// Every region must set its datacenters if the job doesn't
+	job := testJob()
+	job.Datacenters = nil
+	job.Multiregion = &Multiregion{
+		Regions: []*MultiregionRegion{
+			{Name: "west", Datacenters: []string{"west-1"}},
+			{Name: "east"},
+		},
+	}
+	err = job.Validate()
+	require.Error(err)
+	require.Contains(err.Error(), "Missing job datacenters")
+
+	job.Multiregion.Regions[1].Datacenters = []string{"east-1"}
+	require.NoError(job.Validate())
+}
+
+func TestMultiregion_Rollout(t *testing.T) {
+	require := require.New(t)
+	m := &Multiregion{
+		Strategy: &MultiregionStrategy{MaxParallel: 2},
+		Regions: []*MultiregionRegion{
+			{Name: "a"}, {Name: "b"}, {Name: "c"}, {Name: "d"}, {Name: "e"},
+		},
+	}
+
+	require.Equal("", m.Predecessor("a"))
+	require.Equal("", m.Predecessor("b"))
+	require.Equal("a", m.Predecessor("c"))
+	require.Equal("c", m.Predecessor("e"))
+	require.Equal("", m.Predecessor("unknown"))
+
+	require.Equal("c", m.Successor("a"))
+	require.Equal("e", m.Successor("c"))
+	require.Equal("", m.Successor("d"))
+	require.Equal("", m.Successor("unknown"))
+
+	// Deploy one region at a time by default
+	m.Strategy = nil
+	require.Equal("a", m.Predecessor("b"))
+	require.Equal("c", m.Successor("b"))
+}
+
+func TestJob_RegionalCopy(t *testing.T) {
+	require := require.New(t)
+	job := testJob()
+	job.Multiregion = &Multiregion{
+		Regions: []*MultiregionRegion{
+			{
+				Name:        "west",
+				Count:       3,
+				Datacenters: []string{"west-1"},
+				Meta:        map[string]string{"region_code": "W"},
+			},
+			{
+				Name: "east",
+			},
+		},
+	}
+
+	west := job.RegionalCopy(job.Multiregion.Regions[0])
+	require.Equal("west", west.Region)
+	require.Equal([]string{"west-1"}, west.Datacenters)
+	require.Equal(3, west.TaskGroups[0].Count)
+	require.Equal("W", west.Meta["region_code"])
+	require.Equal("armon", west.Meta["owner"])
+
+	// The original job is untouched
+	require.Equal("global", job.Region)
+	require.Equal(10, job.TaskGroups[0].Count)
+	require.NotContains(job.Meta, "region_code")
+
+	east := job.RegionalCopy(job.Multiregion.Regions[1])
+	require.Equal("east", east.Region)
+	require.Equal(job.Datacenters, east.Datacenters)
+	require.Equal(10, east.TaskGroups[0].Count)
+}
+
 func TestDispatchPayloadConfig_Validate(t *testing.T) {
 	d := &DispatchPayloadConfig{
 		File: "foo",
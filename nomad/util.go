@@ -9,6 +9,7 @@ import (
 	"strconv"
 
 	version "github.com/hashicorp/go-version"
+	"github.com/hashicorp/nomad/acl"
 	"github.com/hashicorp/nomad/nomad/state"
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/hashicorp/serf/serf"
@@ -255,6 +256,32 @@ func getNodeForRpc(snap *state.StateSnapshot, nodeID string) (*structs.Node, err
 	return node, nil
 }
 
+// checkAllocNsOp returns a permission denied error unless the ACL object allows
+// one of the operations in the namespace of the allocation. Operations on an
+// allocation are authorized against its namespace rather than the namespace of
+// the request, so a token can't reach the allocations of other namespaces by
+// ID. Unknown allocations are authorized against the namespace of the request.
+func checkAllocNsOp(state *state.StateStore, aclObj *acl.ACL, allocID, namespace string, ops ...string) error {
+	if aclObj == nil {
+		return nil
+	}
+
+	alloc, err := state.AllocByID(nil, allocID)
+	if err != nil {
+		return err
+	}
+	if alloc != nil {
+		namespace = alloc.Namespace
+	}
+
+	for _, op := range ops {
+		if aclObj.AllowNsOp(namespace, op) {
+			return nil
+		}
+	}
+	return structs.ErrPermissionDenied
+}
+
 var minNodeVersionSupportingRPC = version.Must(version.NewVersion("0.8.0-rc1"))
 
 // nodeSupportsRpc returns a non-nil error if a Node does not support RPC.
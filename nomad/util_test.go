@@ -6,7 +6,11 @@ import (
 	"testing"
 
 	version "github.com/hashicorp/go-version"
+	"github.com/hashicorp/nomad/acl"
 	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/state"
+	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/hashicorp/serf/serf"
 	"github.com/stretchr/testify/require"
 )
@@ -258,3 +262,60 @@ func TestMaxUint64(t *testing.T) {
 		t.Fatalf("bad")
 	}
 }
+
+// testAllocInNamespace creates a namespace and an allocation in it, for the
+// tests of the authorization of operations on allocations by ID.
+func testAllocInNamespace(t *testing.T, state *state.StateStore, index uint64) *structs.Allocation {
+	ns := mock.Namespace()
+	require.NoError(t, state.UpsertNamespaces(index, []*structs.Namespace{ns}))
+
+	alloc := mock.Alloc()
+	alloc.Namespace = ns.Name
+	alloc.Job.Namespace = ns.Name
+	summary := mock.JobSummary(alloc.JobID)
+	summary.Namespace = ns.Name
+	require.NoError(t, state.UpsertJobSummary(index+1, summary))
+	require.NoError(t, state.UpsertAllocs(index+2, []*structs.Allocation{alloc}))
+	return alloc
+}
+
+func TestCheckAllocNsOp(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	state := state.TestStateStore(t)
+	alloc := testAllocInNamespace(t, state, 1000)
+
+	policy := func(ns string) string {
+		return mock.NamespacePolicy(ns, "", []string{acl.NamespaceCapabilityReadFS})
+	}
+	defaultACL, err := acl.NewACL(false, []*acl.Policy{mustParsePolicy(t, policy(structs.DefaultNamespace))})
+	require.NoError(err)
+	allocACL, err := acl.NewACL(false, []*acl.Policy{mustParsePolicy(t, policy(alloc.Namespace))})
+	require.NoError(err)
+
+	// The namespace of the request doesn't grant access to the allocation
+	err = checkAllocNsOp(state, defaultACL, alloc.ID, structs.DefaultNamespace, acl.NamespaceCapabilityReadFS)
+	require.Equal(structs.ErrPermissionDenied, err)
+	require.NoError(checkAllocNsOp(state, allocACL, alloc.ID, structs.DefaultNamespace, acl.NamespaceCapabilityReadFS))
+
+	// Any of the operations is enough
+	require.NoError(checkAllocNsOp(state, allocACL, alloc.ID, structs.DefaultNamespace,
+		acl.NamespaceCapabilityReadLogs, acl.NamespaceCapabilityReadFS))
+	err = checkAllocNsOp(state, allocACL, alloc.ID, structs.DefaultNamespace, acl.NamespaceCapabilityReadLogs)
+	require.Equal(structs.ErrPermissionDenied, err)
+
+	// Unknown allocations are checked against the namespace of the request
+	require.NoError(checkAllocNsOp(state, defaultACL, uuid.Generate(), structs.DefaultNamespace, acl.NamespaceCapabilityReadFS))
+	err = checkAllocNsOp(state, allocACL, uuid.Generate(), structs.DefaultNamespace, acl.NamespaceCapabilityReadFS)
+	require.Equal(structs.ErrPermissionDenied, err)
+
+	// ACLs disabled
+	require.NoError(checkAllocNsOp(state, nil, alloc.ID, structs.DefaultNamespace, acl.NamespaceCapabilityReadFS))
+}
+
+func mustParsePolicy(t *testing.T, rules string) *acl.Policy {
+	policy, err := acl.Parse(rules)
+	require.NoError(t, err)
+	return policy
+}
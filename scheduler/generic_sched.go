@@ -42,6 +42,18 @@ const (
 	// allocRescheduled is the status used when an allocation failed and was rescheduled
 	allocRescheduled = "alloc was rescheduled because it failed"
 
+	// allocUnknown is the status used when an allocation is on a node that
+	// has disconnected and may still be running
+	allocUnknown = "alloc status is unknown since its node is disconnected"
+
+	// allocReconnected is the status used when stopping the replacement of an
+	// allocation whose node has reconnected
+	allocReconnected = "alloc not needed as the original allocation reconnected"
+
+	// allocResumed is the status used when resuming an allocation that was
+	// stopped as lost once its node has reconnected
+	allocResumed = "alloc resumed as its node reconnected"
+
 	// blockedEvalMaxPlanDesc is the description used for blocked evals that are
 	// a result of hitting the max number of plan attempts
 	blockedEvalMaxPlanDesc = "created due to placement conflicts"
@@ -54,6 +66,10 @@ const (
 	// up evals for delayed rescheduling
 	reschedulingFollowupEvalDesc = "created for delayed rescheduling"
 
+	// disconnectTimeoutFollowupEvalDesc is the description used when creating
+	// follow up evals for allocations whose disconnect window expires
+	disconnectTimeoutFollowupEvalDesc = "created for delayed disconnect timeout"
+
 	// maxPastRescheduleEvents is the maximum number of past reschedule event
 	// that we track when unlimited rescheduling is enabled
 	maxPastRescheduleEvents = 5
@@ -340,9 +356,17 @@ func (s *GenericScheduler) computeJobAllocs() error {
 	// nodes to lost
 	updateNonTerminalAllocsToLost(s.plan, tainted, allocs)
 
+	// Determine when the nodes of lost allocations reconnected
+	reconnected, err := reconnectedNodes(s.state, allocs, tainted)
+	if err != nil {
+		return fmt.Errorf("failed to get reconnected nodes for job '%s': %v",
+			s.eval.JobID, err)
+	}
+
 	reconciler := NewAllocReconciler(s.logger,
 		genericAllocUpdateFn(s.ctx, s.stack, s.eval.ID),
 		s.batch, s.eval.JobID, s.job, s.deployment, allocs, tainted, s.eval.ID)
+	reconciler.reconnectedNodes = reconnected
 	results := reconciler.Compute()
 	s.logger.Debug("reconciled current state with desired state", "results", log.Fmt("%#v", results))
 
@@ -387,6 +411,11 @@ func (s *GenericScheduler) computeJobAllocs() error {
 		s.ctx.Plan().AppendAlloc(update)
 	}
 
+	// Mark the allocations on disconnected nodes as unknown
+	for _, update := range results.disconnectUpdates {
+		s.plan.AppendUnknownAlloc(update, allocUnknown)
+	}
+
 	// Nothing remaining to do if placement is not required
 	if len(results.place)+len(results.destructiveUpdate) == 0 {
 		// If the job has been purged we don't have access to the job. Otherwise
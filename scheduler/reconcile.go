@@ -70,6 +70,10 @@ type allocReconciler struct {
 	// taintedNodes contains a map of nodes that are tainted
 	taintedNodes map[string]*structs.Node
 
+	// reconnectedNodes contains a map of the untainted nodes of allocations
+	// that were stopped as lost
+	reconnectedNodes map[string]*structs.Node
+
 	// existingAllocs is non-terminal existing allocations
 	existingAllocs []*structs.Allocation
 
@@ -112,6 +116,10 @@ type reconcileResults struct {
 	// jobspec change.
 	attributeUpdates map[string]*structs.Allocation
 
+	// disconnectUpdates is the set of allocations on disconnected nodes that
+	// should be marked as having an unknown client status.
+	disconnectUpdates map[string]*structs.Allocation
+
 	// desiredTGUpdates captures the desired set of changes to make for each
 	// task group.
 	desiredTGUpdates map[string]*structs.DesiredUpdates
@@ -175,6 +183,7 @@ func NewAllocReconciler(logger log.Logger, allocUpdateFn allocUpdateType, batch
 		result: &reconcileResults{
 			desiredTGUpdates:     make(map[string]*structs.DesiredUpdates),
 			desiredFollowupEvals: make(map[string][]*structs.Evaluation),
+			disconnectUpdates:    make(map[string]*structs.Allocation),
 		},
 	}
 }
@@ -350,6 +359,16 @@ func (a *allocReconciler) computeGroup(group string, all allocSet) bool {
 	// Determine what set of allocations are on tainted nodes
 	untainted, migrate, lost := all.filterByTainted(a.taintedNodes)
 
+	// Allocations on disconnected nodes are kept in the unknown state until
+	// the group's disconnect window passes rather than being replaced
+	lost, disconnecting, disconnectLater := lost.filterByDisconnecting(tg, a.taintedNodes, a.now)
+
+	// Stop the replacements of allocations whose node has reconnected and
+	// resume the original allocations if they were stopped as lost
+	replaced, resumed := a.computeReconnected(untainted, untainted.filterByReconnecting(tg, a.taintedNodes, a.reconnectedNodes))
+	desiredChanges.Stop += uint64(len(replaced))
+	untainted = untainted.difference(replaced, resumed).union(resumed)
+
 	// Determine what set of terminal allocations need to be rescheduled
 	untainted, rescheduleNow, rescheduleLater := untainted.filterByRescheduleable(a.batch, a.now, a.evalID, a.deployment)
 
@@ -357,6 +376,9 @@ func (a *allocReconciler) computeGroup(group string, all allocSet) bool {
 	// reschedulable later and mark the allocations for in place updating
 	a.handleDelayedReschedules(rescheduleLater, all, tg.Name)
 
+	// Disconnecting allocations count against the desired total
+	untainted = untainted.union(disconnecting)
+
 	// Create a structure for choosing names. Seed with the taken names which is
 	// the union of untainted and migrating nodes (includes canaries)
 	nameIndex := newAllocNameIndex(a.jobID, group, tg.Count, untainted.union(migrate, rescheduleNow))
@@ -369,9 +391,12 @@ func (a *allocReconciler) computeGroup(group string, all allocSet) bool {
 	untainted = untainted.difference(stop)
 
 	// Do inplace upgrades where possible and capture the set of upgrades that
-	// need to be done destructively.
-	ignore, inplace, destructive := a.computeUpdates(tg, untainted)
-	desiredChanges.Ignore += uint64(len(ignore))
+	// need to be done destructively. Allocations on disconnected nodes can not
+	// be updated until they reconnect.
+	disconnecting = disconnecting.difference(stop)
+	a.handleDisconnecting(disconnecting, disconnectLater, tg.Name)
+	ignore, inplace, destructive := a.computeUpdates(tg, untainted.difference(disconnecting, resumed))
+	desiredChanges.Ignore += uint64(len(ignore) + len(disconnecting) + len(resumed))
 	desiredChanges.InPlaceUpdate += uint64(len(inplace))
 	if !existingDeployment {
 		dstate.DesiredTotal += len(destructive) + len(inplace)
@@ -383,13 +408,31 @@ func (a *allocReconciler) computeGroup(group string, all allocSet) bool {
 		untainted = untainted.difference(canaries)
 	}
 
+	// Create new deployment if:
+	// 1. Updating a job specification
+	// 2. No running allocations (first time running a job)
+	updatingSpec := len(destructive) != 0 || len(a.result.inplaceUpdate) != 0
+	hadRunning := false
+	for _, alloc := range all {
+		if alloc.Job.Version == a.job.Version && alloc.Job.CreateIndex == a.job.CreateIndex {
+			hadRunning = true
+			break
+		}
+	}
+
+	// Regions of a multiregion job that deploy after an earlier region create
+	// their deployment paused and place nothing until it is resumed
+	strategy := tg.Update
+	pendingRegion := a.deployment == nil && !strategy.IsEmpty() && (!hadRunning || updatingSpec) &&
+		a.job.IsMultiregion() && a.job.Multiregion.Predecessor(a.job.Region) != ""
+	deploymentPaused := a.deploymentPaused || pendingRegion
+
 	// The fact that we have destructive updates and have less canaries than is
 	// desired means we need to create canaries
 	numDestructive := len(destructive)
-	strategy := tg.Update
 	canariesPromoted := dstate != nil && dstate.Promoted
 	requireCanary := numDestructive != 0 && strategy != nil && len(canaries) < strategy.Canary && !canariesPromoted
-	if requireCanary && !a.deploymentPaused && !a.deploymentFailed {
+	if requireCanary && !deploymentPaused && !a.deploymentFailed {
 		number := strategy.Canary - len(canaries)
 		desiredChanges.Canary += uint64(number)
 		if !existingDeployment {
@@ -407,7 +450,7 @@ func (a *allocReconciler) computeGroup(group string, all allocSet) bool {
 
 	// Determine how many we can place
 	canaryState = dstate != nil && dstate.DesiredCanaries != 0 && !dstate.Promoted
-	limit := a.computeLimit(tg, untainted, destructive, migrate, canaryState)
+	limit := a.computeLimit(tg, untainted, destructive, migrate, canaryState, deploymentPaused)
 
 	// Place if:
 	// * The deployment is not paused or failed
@@ -420,7 +463,7 @@ func (a *allocReconciler) computeGroup(group string, all allocSet) bool {
 
 	// deploymentPlaceReady tracks whether the deployment is in a state where
 	// placements can be made without any other consideration.
-	deploymentPlaceReady := !a.deploymentPaused && !a.deploymentFailed && !canaryState
+	deploymentPlaceReady := !deploymentPaused && !a.deploymentFailed && !canaryState
 
 	if deploymentPlaceReady {
 		desiredChanges.Place += uint64(len(place))
@@ -496,23 +539,16 @@ func (a *allocReconciler) computeGroup(group string, all allocSet) bool {
 		})
 	}
 
-	// Create new deployment if:
-	// 1. Updating a job specification
-	// 2. No running allocations (first time running a job)
-	updatingSpec := len(destructive) != 0 || len(a.result.inplaceUpdate) != 0
-	hadRunning := false
-	for _, alloc := range all {
-		if alloc.Job.Version == a.job.Version && alloc.Job.CreateIndex == a.job.CreateIndex {
-			hadRunning = true
-			break
-		}
-	}
-
 	// Create a new deployment if necessary
 	if !existingDeployment && !strategy.IsEmpty() && dstate.DesiredTotal != 0 && (!hadRunning || updatingSpec) {
 		// A previous group may have made the deployment already
 		if a.deployment == nil {
 			a.deployment = structs.NewDeployment(a.job)
+			if pendingRegion {
+				a.deployment.Status = structs.DeploymentStatusPaused
+				a.deployment.StatusDescription = structs.DeploymentStatusDescriptionPendingRegion
+				a.deploymentPaused = true
+			}
 			a.result.deployment = a.deployment
 		}
 
@@ -614,13 +650,13 @@ func (a *allocReconciler) handleGroupCanaries(all allocSet, desiredChanges *stru
 
 // computeLimit returns the placement limit for a particular group. The inputs
 // are the group definition, the untainted, destructive, and migrate allocation
-// set and whether we are in a canary state.
-func (a *allocReconciler) computeLimit(group *structs.TaskGroup, untainted, destructive, migrate allocSet, canaryState bool) int {
+// set and whether we are in a canary state or the deployment is paused.
+func (a *allocReconciler) computeLimit(group *structs.TaskGroup, untainted, destructive, migrate allocSet, canaryState, deploymentPaused bool) int {
 	// If there is no update strategy or deployment for the group we can deploy
 	// as many as the group has
 	if group.Update.IsEmpty() || len(destructive)+len(migrate) == 0 {
 		return group.Count
-	} else if a.deploymentPaused || a.deploymentFailed {
+	} else if deploymentPaused || a.deploymentFailed {
 		// If the deployment is paused or failed, do not create anything else
 		return 0
 	}
@@ -835,14 +871,111 @@ func (a *allocReconciler) handleDelayedReschedules(rescheduleLater []*delayedRes
 		return
 	}
 
+	allocIDToFollowupEvalID := a.createFollowupEvals(rescheduleLater, tgName,
+		structs.EvalTriggerRetryFailedAlloc, reschedulingFollowupEvalDesc)
+
+	// Initialize the annotations
+	if len(allocIDToFollowupEvalID) != 0 && a.result.attributeUpdates == nil {
+		a.result.attributeUpdates = make(map[string]*structs.Allocation)
+	}
+
+	// Create in-place updates for every alloc ID that needs to be updated with its follow up eval ID
+	for allocID, evalID := range allocIDToFollowupEvalID {
+		existingAlloc := all[allocID]
+		updatedAlloc := existingAlloc.Copy()
+		updatedAlloc.FollowupEvalID = evalID
+		a.result.attributeUpdates[updatedAlloc.ID] = updatedAlloc
+	}
+}
+
+// handleDisconnecting marks the allocations on disconnected nodes that are not
+// yet in the unknown state to be updated and creates batched followup
+// evaluations for when their disconnect window expires.
+func (a *allocReconciler) handleDisconnecting(disconnecting allocSet, disconnectLater []*delayedRescheduleInfo, tgName string) {
+	var timeouts []*delayedRescheduleInfo
+	for _, info := range disconnectLater {
+		// Allocations already marked as unknown have a pending followup eval
+		if _, ok := disconnecting[info.allocID]; !ok || info.alloc.ClientStatus == structs.AllocClientStatusUnknown {
+			continue
+		}
+		timeouts = append(timeouts, info)
+	}
+	if len(timeouts) == 0 {
+		return
+	}
+
+	allocIDToFollowupEvalID := a.createFollowupEvals(timeouts, tgName,
+		structs.EvalTriggerMaxDisconnect, disconnectTimeoutFollowupEvalDesc)
+
+	for allocID, evalID := range allocIDToFollowupEvalID {
+		updatedAlloc := disconnecting[allocID].Copy()
+		updatedAlloc.FollowupEvalID = evalID
+		a.result.disconnectUpdates[updatedAlloc.ID] = updatedAlloc
+	}
+}
+
+// computeReconnected returns the set of allocations that replaced an
+// allocation whose node has reconnected, along with the reconnected
+// allocations that must be resumed because they were stopped as lost. The
+// replacements are marked for stop so that the original allocation keeps
+// running. Replacements of lost allocations are not chained to the original
+// allocation so they are also matched by name.
+func (a *allocReconciler) computeReconnected(untainted, reconnecting allocSet) (stop, resumed allocSet) {
+	stop = make(map[string]*structs.Allocation)
+	resumed = make(map[string]*structs.Allocation)
+	if len(reconnecting) == 0 {
+		return
+	}
+
+	names := reconnecting.nameSet()
+	for id, alloc := range untainted {
+		if alloc.TerminalStatus() {
+			continue
+		}
+		if _, ok := reconnecting[id]; ok {
+			continue
+		}
+		_, replaced := reconnecting[alloc.PreviousAllocation]
+		if _, ok := names[alloc.Name]; !replaced && !ok {
+			continue
+		}
+		stop[id] = alloc
+	}
+	a.markStop(stop, "", allocReconnected)
+
+	for id, alloc := range reconnecting {
+		if !alloc.ServerTerminalStatus() {
+			continue
+		}
+
+		updated := alloc.Copy()
+		updated.DesiredStatus = structs.AllocDesiredStatusRun
+		updated.DesiredDescription = allocResumed
+		resumed[id] = updated
+	}
+
+	// Initialize the annotations
+	if len(resumed) != 0 && a.result.attributeUpdates == nil {
+		a.result.attributeUpdates = make(map[string]*structs.Allocation)
+	}
+	for id, alloc := range resumed {
+		a.result.attributeUpdates[id] = alloc
+	}
+	return
+}
+
+// createFollowupEvals creates batched followup evaluations with the WaitUntil
+// field set for the passed allocations and returns the mapping of allocation
+// ID to the ID of its followup evaluation.
+func (a *allocReconciler) createFollowupEvals(later []*delayedRescheduleInfo, tgName, triggeredBy, statusDesc string) map[string]string {
 	// Sort by time
-	sort.Slice(rescheduleLater, func(i, j int) bool {
-		return rescheduleLater[i].rescheduleTime.Before(rescheduleLater[j].rescheduleTime)
+	sort.Slice(later, func(i, j int) bool {
+		return later[i].rescheduleTime.Before(later[j].rescheduleTime)
 	})
 
 	var evals []*structs.Evaluation
-	nextReschedTime := rescheduleLater[0].rescheduleTime
-	allocIDToFollowupEvalID := make(map[string]string, len(rescheduleLater))
+	nextReschedTime := later[0].rescheduleTime
+	allocIDToFollowupEvalID := make(map[string]string, len(later))
 
 	// Create a new eval for the first batch
 	eval := &structs.Evaluation{
@@ -850,16 +983,16 @@ func (a *allocReconciler) handleDelayedReschedules(rescheduleLater []*delayedRes
 		Namespace:         a.job.Namespace,
 		Priority:          a.job.Priority,
 		Type:              a.job.Type,
-		TriggeredBy:       structs.EvalTriggerRetryFailedAlloc,
+		TriggeredBy:       triggeredBy,
 		JobID:             a.job.ID,
 		JobModifyIndex:    a.job.ModifyIndex,
 		Status:            structs.EvalStatusPending,
-		StatusDescription: reschedulingFollowupEvalDesc,
+		StatusDescription: statusDesc,
 		WaitUntil:         nextReschedTime,
 	}
 	evals = append(evals, eval)
 
-	for _, allocReschedInfo := range rescheduleLater {
+	for _, allocReschedInfo := range later {
 		if allocReschedInfo.rescheduleTime.Sub(nextReschedTime) < batchedFailedAllocWindowSize {
 			allocIDToFollowupEvalID[allocReschedInfo.allocID] = eval.ID
 		} else {
@@ -871,7 +1004,7 @@ func (a *allocReconciler) handleDelayedReschedules(rescheduleLater []*delayedRes
 				Namespace:      a.job.Namespace,
 				Priority:       a.job.Priority,
 				Type:           a.job.Type,
-				TriggeredBy:    structs.EvalTriggerRetryFailedAlloc,
+				TriggeredBy:    triggeredBy,
 				JobID:          a.job.ID,
 				JobModifyIndex: a.job.ModifyIndex,
 				Status:         structs.EvalStatusPending,
@@ -883,18 +1016,6 @@ func (a *allocReconciler) handleDelayedReschedules(rescheduleLater []*delayedRes
 		}
 	}
 
-	a.result.desiredFollowupEvals[tgName] = evals
-
-	// Initialize the annotations
-	if len(allocIDToFollowupEvalID) != 0 && a.result.attributeUpdates == nil {
-		a.result.attributeUpdates = make(map[string]*structs.Allocation)
-	}
-
-	// Create in-place updates for every alloc ID that needs to be updated with its follow up eval ID
-	for allocID, evalID := range allocIDToFollowupEvalID {
-		existingAlloc := all[allocID]
-		updatedAlloc := existingAlloc.Copy()
-		updatedAlloc.FollowupEvalID = evalID
-		a.result.attributeUpdates[updatedAlloc.ID] = updatedAlloc
-	}
+	a.result.desiredFollowupEvals[tgName] = append(a.result.desiredFollowupEvals[tgName], evals...)
+	return allocIDToFollowupEvalID
 }
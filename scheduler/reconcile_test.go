@@ -769,6 +769,321 @@ func TestReconciler_LostNode(t *testing.T) {
 	assertNamesHaveIndexes(t, intRange(0, 1), placeResultsToNames(r.place))
 }
 
+// Tests the reconciler keeps allocations on disconnected nodes in the unknown
+// state while within the group's max_client_disconnect window
+func TestReconciler_DisconnectedNode(t *testing.T) {
+	require := require.New(t)
+	job := mock.Job()
+	job.TaskGroups[0].MaxClientDisconnect = helper.TimeToPtr(5 * time.Minute)
+
+	// Create 10 existing allocations
+	var allocs []*structs.Allocation
+	for i := 0; i < 10; i++ {
+		alloc := mock.Alloc()
+		alloc.Job = job
+		alloc.JobID = job.ID
+		alloc.NodeID = uuid.Generate()
+		alloc.Name = structs.AllocName(job.ID, job.TaskGroups[0].Name, uint(i))
+		alloc.ClientStatus = structs.AllocClientStatusRunning
+		allocs = append(allocs, alloc)
+	}
+
+	// Build a map of disconnected nodes
+	tainted := make(map[string]*structs.Node, 2)
+	for i := 0; i < 2; i++ {
+		n := mock.Node()
+		n.ID = allocs[i].NodeID
+		n.Status = structs.NodeStatusDisconnected
+		n.StatusUpdatedAt = time.Now().Unix()
+		tainted[n.ID] = n
+	}
+
+	reconciler := NewAllocReconciler(testlog.HCLogger(t), allocUpdateFnIgnore, false, job.ID, job, nil, allocs, tainted, "")
+	r := reconciler.Compute()
+
+	// Assert the correct results
+	assertResults(t, r, &resultExpectation{
+		createDeployment:  nil,
+		deploymentUpdates: nil,
+		place:             0,
+		inplace:           0,
+		stop:              0,
+		desiredTGUpdates: map[string]*structs.DesiredUpdates{
+			job.TaskGroups[0].Name: {
+				Ignore: 10,
+			},
+		},
+	})
+
+	// Both allocations are marked unknown and share a followup eval
+	require.Len(r.disconnectUpdates, 2)
+	evals := r.desiredFollowupEvals[job.TaskGroups[0].Name]
+	require.Len(evals, 1)
+	require.Equal(structs.EvalTriggerMaxDisconnect, evals[0].TriggeredBy)
+	for i := 0; i < 2; i++ {
+		update := r.disconnectUpdates[allocs[i].ID]
+		require.NotNil(update)
+		require.Equal(evals[0].ID, update.FollowupEvalID)
+	}
+}
+
+// Tests the reconciler replaces allocations on disconnected nodes once the
+// group's max_client_disconnect window has passed
+func TestReconciler_DisconnectedNode_Expired(t *testing.T) {
+	job := mock.Job()
+	job.TaskGroups[0].MaxClientDisconnect = helper.TimeToPtr(5 * time.Minute)
+
+	// Create 10 existing allocations
+	var allocs []*structs.Allocation
+	for i := 0; i < 10; i++ {
+		alloc := mock.Alloc()
+		alloc.Job = job
+		alloc.JobID = job.ID
+		alloc.NodeID = uuid.Generate()
+		alloc.Name = structs.AllocName(job.ID, job.TaskGroups[0].Name, uint(i))
+		allocs = append(allocs, alloc)
+	}
+
+	// Build a map of nodes disconnected longer than the window
+	tainted := make(map[string]*structs.Node, 2)
+	for i := 0; i < 2; i++ {
+		allocs[i].ClientStatus = structs.AllocClientStatusUnknown
+		n := mock.Node()
+		n.ID = allocs[i].NodeID
+		n.Status = structs.NodeStatusDisconnected
+		n.StatusUpdatedAt = time.Now().Add(-10 * time.Minute).Unix()
+		tainted[n.ID] = n
+	}
+
+	reconciler := NewAllocReconciler(testlog.HCLogger(t), allocUpdateFnIgnore, false, job.ID, job, nil, allocs, tainted, "")
+	r := reconciler.Compute()
+
+	// Assert the correct results
+	assertResults(t, r, &resultExpectation{
+		createDeployment:  nil,
+		deploymentUpdates: nil,
+		place:             2,
+		inplace:           0,
+		stop:              2,
+		desiredTGUpdates: map[string]*structs.DesiredUpdates{
+			job.TaskGroups[0].Name: {
+				Place:  2,
+				Stop:   2,
+				Ignore: 8,
+			},
+		},
+	})
+
+	assertNamesHaveIndexes(t, intRange(0, 1), stopResultsToNames(r.stop))
+	assertNamesHaveIndexes(t, intRange(0, 1), placeResultsToNames(r.place))
+	require.Empty(t, r.disconnectUpdates)
+}
+
+// Tests the reconciler stops the replacement of an allocation whose node has
+// reconnected after the allocation was replaced as lost and resumes the
+// original allocation
+func TestReconciler_ReconnectedNode_StopReplacement(t *testing.T) {
+	require := require.New(t)
+	job := mock.Job()
+	job.TaskGroups[0].Count = 2
+	job.TaskGroups[0].MaxClientDisconnect = helper.TimeToPtr(5 * time.Minute)
+
+	// Create 2 existing allocations
+	var allocs []*structs.Allocation
+	for i := 0; i < 2; i++ {
+		alloc := mock.Alloc()
+		alloc.Job = job
+		alloc.JobID = job.ID
+		alloc.NodeID = uuid.Generate()
+		alloc.Name = structs.AllocName(job.ID, job.TaskGroups[0].Name, uint(i))
+		alloc.ClientStatus = structs.AllocClientStatusRunning
+		allocs = append(allocs, alloc)
+	}
+	original := allocs[0]
+
+	// The node of the first allocation disconnects
+	node := mock.Node()
+	node.ID = original.NodeID
+	node.Status = structs.NodeStatusDisconnected
+	node.StatusUpdatedAt = time.Now().Unix()
+	tainted := map[string]*structs.Node{node.ID: node}
+
+	reconciler := NewAllocReconciler(testlog.HCLogger(t), allocUpdateFnIgnore, false, job.ID, job, nil, allocs, tainted, "")
+	r := reconciler.Compute()
+	require.Empty(r.place)
+	require.Empty(r.stop)
+	require.Len(r.disconnectUpdates, 1)
+
+	update := r.disconnectUpdates[original.ID]
+	require.NotNil(update)
+	update.ClientStatus = structs.AllocClientStatusUnknown
+	allocs[0] = update
+
+	// The disconnect window passes so the allocation is replaced as lost
+	node.StatusUpdatedAt = time.Now().Add(-10 * time.Minute).Unix()
+
+	reconciler = NewAllocReconciler(testlog.HCLogger(t), allocUpdateFnIgnore, false, job.ID, job, nil, allocs, tainted, "")
+	r = reconciler.Compute()
+	require.Len(r.stop, 1)
+	require.Len(r.place, 1)
+	require.Equal(original.ID, r.stop[0].alloc.ID)
+	require.Equal(structs.AllocClientStatusLost, r.stop[0].clientStatus)
+
+	lost := allocs[0].Copy()
+	lost.DesiredStatus = structs.AllocDesiredStatusStop
+	lost.DesiredDescription = r.stop[0].statusDescription
+	lost.ClientStatus = r.stop[0].clientStatus
+	lost.LostTime = time.Now().UTC().UnixNano()
+	allocs[0] = lost
+
+	replacement := mock.Alloc()
+	replacement.Job = job
+	replacement.JobID = job.ID
+	replacement.NodeID = uuid.Generate()
+	replacement.Name = r.place[0].name
+	replacement.ClientStatus = structs.AllocClientStatusRunning
+	allocs = append(allocs, replacement)
+
+	// The node reconnects before the client reports the allocation
+	node = node.Copy()
+	node.Status = structs.NodeStatusReady
+	node.StatusUpdatedAt = time.Now().Unix()
+	reconnected := map[string]*structs.Node{node.ID: node}
+
+	reconciler = NewAllocReconciler(testlog.HCLogger(t), allocUpdateFnIgnore, false, job.ID, job, nil, allocs, nil, "")
+	reconciler.reconnectedNodes = reconnected
+	r = reconciler.Compute()
+	require.Empty(r.stop)
+	require.Empty(r.attributeUpdates)
+
+	// The client reports the allocation as running
+	running := lost.Copy()
+	running.ClientStatus = structs.AllocClientStatusRunning
+	allocs[0] = running
+
+	reconciler = NewAllocReconciler(testlog.HCLogger(t), allocUpdateFnIgnore, false, job.ID, job, nil, allocs, nil, "")
+	reconciler.reconnectedNodes = reconnected
+	r = reconciler.Compute()
+
+	// Assert the correct results
+	assertResults(t, r, &resultExpectation{
+		createDeployment:  nil,
+		deploymentUpdates: nil,
+		place:             0,
+		inplace:           0,
+		attributeUpdates:  1,
+		stop:              1,
+		desiredTGUpdates: map[string]*structs.DesiredUpdates{
+			job.TaskGroups[0].Name: {
+				Stop:   1,
+				Ignore: 2,
+			},
+		},
+	})
+
+	require.Equal(replacement.ID, r.stop[0].alloc.ID)
+	require.Equal(allocReconnected, r.stop[0].statusDescription)
+
+	resumed := r.attributeUpdates[original.ID]
+	require.NotNil(resumed)
+	require.Equal(structs.AllocDesiredStatusRun, resumed.DesiredStatus)
+	require.Equal(structs.AllocClientStatusRunning, resumed.ClientStatus)
+}
+
+// Tests the reconciler does not resume an allocation that was lost longer than
+// the group's max_client_disconnect window before its node reconnected
+func TestReconciler_ReconnectedNode_LostExpired(t *testing.T) {
+	require := require.New(t)
+	job := mock.Job()
+	job.TaskGroups[0].Count = 1
+	job.TaskGroups[0].MaxClientDisconnect = helper.TimeToPtr(5 * time.Minute)
+
+	// The client reports the lost allocation as running
+	alloc := mock.Alloc()
+	alloc.Job = job
+	alloc.JobID = job.ID
+	alloc.NodeID = uuid.Generate()
+	alloc.Name = structs.AllocName(job.ID, job.TaskGroups[0].Name, 0)
+	alloc.DesiredStatus = structs.AllocDesiredStatusStop
+	alloc.DesiredDescription = allocLost
+	alloc.ClientStatus = structs.AllocClientStatusRunning
+	alloc.LostTime = time.Now().Add(-10 * time.Minute).UTC().UnixNano()
+
+	replacement := mock.Alloc()
+	replacement.Job = job
+	replacement.JobID = job.ID
+	replacement.NodeID = uuid.Generate()
+	replacement.Name = alloc.Name
+	replacement.ClientStatus = structs.AllocClientStatusRunning
+
+	node := mock.Node()
+	node.ID = alloc.NodeID
+	node.StatusUpdatedAt = time.Now().Unix()
+
+	allocs := []*structs.Allocation{alloc, replacement}
+	reconciler := NewAllocReconciler(testlog.HCLogger(t), allocUpdateFnIgnore, false, job.ID, job, nil, allocs, nil, "")
+	reconciler.reconnectedNodes = map[string]*structs.Node{node.ID: node}
+	r := reconciler.Compute()
+
+	// Assert the correct results
+	assertResults(t, r, &resultExpectation{
+		createDeployment:  nil,
+		deploymentUpdates: nil,
+		place:             0,
+		inplace:           0,
+		stop:              0,
+		desiredTGUpdates: map[string]*structs.DesiredUpdates{
+			job.TaskGroups[0].Name: {
+				Ignore: 1,
+			},
+		},
+	})
+	require.Empty(r.attributeUpdates)
+}
+
+// Tests the reconciler does not resume an allocation on a reconnected node
+// that was stopped for a reason other than being lost
+func TestReconciler_ReconnectedNode_StoppedAlloc(t *testing.T) {
+	job := mock.Job()
+	job.TaskGroups[0].Count = 1
+	job.TaskGroups[0].MaxClientDisconnect = helper.TimeToPtr(5 * time.Minute)
+
+	// The allocation was stopped while its node was disconnected
+	alloc := mock.Alloc()
+	alloc.Job = job
+	alloc.JobID = job.ID
+	alloc.NodeID = uuid.Generate()
+	alloc.Name = structs.AllocName(job.ID, job.TaskGroups[0].Name, 0)
+	alloc.DesiredStatus = structs.AllocDesiredStatusStop
+	alloc.DesiredDescription = allocNotNeeded
+	alloc.ClientStatus = structs.AllocClientStatusUnknown
+
+	replacement := mock.Alloc()
+	replacement.Job = job
+	replacement.JobID = job.ID
+	replacement.NodeID = uuid.Generate()
+	replacement.Name = alloc.Name
+	replacement.ClientStatus = structs.AllocClientStatusRunning
+
+	allocs := []*structs.Allocation{alloc, replacement}
+	reconciler := NewAllocReconciler(testlog.HCLogger(t), allocUpdateFnIgnore, false, job.ID, job, nil, allocs, nil, "")
+	r := reconciler.Compute()
+
+	// Assert the correct results
+	assertResults(t, r, &resultExpectation{
+		createDeployment:  nil,
+		deploymentUpdates: nil,
+		place:             0,
+		inplace:           0,
+		stop:              0,
+		desiredTGUpdates: map[string]*structs.DesiredUpdates{
+			job.TaskGroups[0].Name: {
+				Ignore: 1,
+			},
+		},
+	})
+}
+
 // Tests the reconciler properly handles lost nodes with allocations while
 // scaling up
 func TestReconciler_LostNode_ScaleUp(t *testing.T) {
@@ -2733,6 +3048,62 @@ func TestReconciler_DontCreateDeployment_NoChanges(t *testing.T) {
 
 // Tests the reconciler doesn't place any more canaries when the deployment is
 // paused or failed
+// Tests the reconciler creates a paused deployment and places nothing for a
+// region of a multiregion job that deploys after an earlier region
+func TestReconciler_CreateDeployment_MultiregionPending(t *testing.T) {
+	job := mock.Job()
+	job.TaskGroups[0].Update = noCanaryUpdate
+	job.Region = "east"
+	job.Multiregion = &structs.Multiregion{
+		Strategy: &structs.MultiregionStrategy{MaxParallel: 1},
+		Regions: []*structs.MultiregionRegion{
+			{Name: "west"},
+			{Name: "east"},
+		},
+	}
+
+	reconciler := NewAllocReconciler(testlog.HCLogger(t), allocUpdateFnIgnore, false, job.ID, job, nil, nil, nil, "")
+	r := reconciler.Compute()
+
+	d := structs.NewDeployment(job)
+	d.Status = structs.DeploymentStatusPaused
+	d.StatusDescription = structs.DeploymentStatusDescriptionPendingRegion
+	d.TaskGroups[job.TaskGroups[0].Name] = &structs.DeploymentState{
+		DesiredTotal: 10,
+	}
+
+	// Assert the correct results
+	assertResults(t, r, &resultExpectation{
+		createDeployment:  d,
+		deploymentUpdates: nil,
+		place:             0,
+		desiredTGUpdates: map[string]*structs.DesiredUpdates{
+			job.TaskGroups[0].Name: {},
+		},
+	})
+
+	// The first region deploys immediately
+	job.Region = "west"
+	reconciler = NewAllocReconciler(testlog.HCLogger(t), allocUpdateFnIgnore, false, job.ID, job, nil, nil, nil, "")
+	r = reconciler.Compute()
+
+	d = structs.NewDeployment(job)
+	d.TaskGroups[job.TaskGroups[0].Name] = &structs.DeploymentState{
+		DesiredTotal: 10,
+	}
+
+	assertResults(t, r, &resultExpectation{
+		createDeployment:  d,
+		deploymentUpdates: nil,
+		place:             10,
+		desiredTGUpdates: map[string]*structs.DesiredUpdates{
+			job.TaskGroups[0].Name: {
+				Place: 10,
+			},
+		},
+	})
+}
+
 func TestReconciler_PausedOrFailedDeployment_NoMoreCanaries(t *testing.T) {
 	job := mock.Job()
 	job.TaskGroups[0].Update = canaryUpdate
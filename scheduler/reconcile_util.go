@@ -193,7 +193,7 @@ func (a allocSet) fromKeys(keys ...[]string) allocSet {
 // into three groups:
 // 1. Those that exist on untainted nodes
 // 2. Those exist on nodes that are draining
-// 3. Those that exist on lost nodes
+// 3. Those that exist on lost or disconnected nodes
 func (a allocSet) filterByTainted(nodes map[string]*structs.Node) (untainted, migrate, lost allocSet) {
 	untainted = make(map[string]*structs.Allocation)
 	migrate = make(map[string]*structs.Allocation)
@@ -218,8 +218,8 @@ func (a allocSet) filterByTainted(nodes map[string]*structs.Node) (untainted, mi
 			continue
 		}
 
-		// Allocs on GC'd (nil) or lost nodes are Lost
-		if n == nil || n.TerminalStatus() {
+		// Allocs on GC'd (nil), lost or disconnected nodes are Lost
+		if n == nil || n.TerminalStatus() || n.Status == structs.NodeStatusDisconnected {
 			lost[alloc.ID] = alloc
 			continue
 		}
@@ -230,6 +230,76 @@ func (a allocSet) filterByTainted(nodes map[string]*structs.Node) (untainted, mi
 	return
 }
 
+// filterByDisconnecting takes a set of lost allocations and filters out those
+// that are on disconnected nodes and whose task group allows them to remain in
+// the unknown state. Allocations whose max_client_disconnect window has passed
+// remain lost. The time at which each disconnecting allocation should be
+// considered lost is returned so that follow up evaluations can be created.
+func (a allocSet) filterByDisconnecting(group *structs.TaskGroup, nodes map[string]*structs.Node, now time.Time) (lost, disconnecting allocSet, timeouts []*delayedRescheduleInfo) {
+	lost = make(map[string]*structs.Allocation)
+	disconnecting = make(map[string]*structs.Allocation)
+	for _, alloc := range a {
+		n := nodes[alloc.NodeID]
+		if group.MaxClientDisconnect == nil || n == nil || n.Status != structs.NodeStatusDisconnected {
+			lost[alloc.ID] = alloc
+			continue
+		}
+
+		// The window starts when the node was marked as disconnected
+		timeout := time.Unix(n.StatusUpdatedAt, 0).Add(*group.MaxClientDisconnect)
+		if timeout.Sub(now) <= rescheduleWindowSize {
+			lost[alloc.ID] = alloc
+			continue
+		}
+
+		disconnecting[alloc.ID] = alloc
+		timeouts = append(timeouts, &delayedRescheduleInfo{alloc.ID, alloc, timeout})
+	}
+	return
+}
+
+// filterByReconnecting returns the allocations of the set whose node has
+// reconnected after the allocations were marked unknown or lost because the
+// node disconnected. Allocations that were stopped because their
+// max_client_disconnect window passed are only included if the client reports
+// them as running and their node reconnected within the window of them being
+// lost, not those stopped for any other reason. Allocations on the tainted
+// nodes have not reconnected, and the reconnected nodes are the untainted
+// nodes of allocations that were lost.
+func (a allocSet) filterByReconnecting(group *structs.TaskGroup, tainted, reconnected map[string]*structs.Node) (reconnecting allocSet) {
+	reconnecting = make(map[string]*structs.Allocation)
+	if group.MaxClientDisconnect == nil {
+		return
+	}
+
+	for _, alloc := range a {
+		if _, ok := tainted[alloc.NodeID]; ok {
+			continue
+		}
+
+		switch {
+		case alloc.ClientStatus == structs.AllocClientStatusUnknown && !alloc.ServerTerminalStatus():
+		case alloc.ClientStatus == structs.AllocClientStatusRunning &&
+			alloc.DesiredStatus == structs.AllocDesiredStatusStop &&
+			alloc.DesiredDescription == allocLost:
+			// The scheduler marked the allocation as lost so a running
+			// status was reported by the client since
+			n := reconnected[alloc.NodeID]
+			if n == nil || alloc.LostTime == 0 {
+				continue
+			}
+			timeout := time.Unix(0, alloc.LostTime).Add(*group.MaxClientDisconnect)
+			if time.Unix(n.StatusUpdatedAt, 0).After(timeout) {
+				continue
+			}
+		default:
+			continue
+		}
+		reconnecting[alloc.ID] = alloc
+	}
+	return
+}
+
 // filterByRescheduleable filters the allocation set to return the set of allocations that are either
 // untainted or a set of allocations that must be rescheduled now. Allocations that can be rescheduled
 // at a future time are also returned so that we can create follow up evaluations for them. Allocs are
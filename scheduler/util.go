@@ -113,7 +113,8 @@ func diffAllocs(job *structs.Job, taintedNodes map[string]*structs.Node,
 				goto IGNORE
 			}
 
-			if !exist.TerminalStatus() && (node == nil || node.TerminalStatus()) {
+			if !exist.TerminalStatus() &&
+				(node == nil || node.TerminalStatus() || node.Status == structs.NodeStatusDisconnected) {
 				result.lost = append(result.lost, allocTuple{
 					Name:      name,
 					TaskGroup: tg,
@@ -325,6 +326,33 @@ func taintedNodes(state State, allocs []*structs.Allocation) (map[string]*struct
 	return out, nil
 }
 
+// reconnectedNodes returns the untainted nodes of the allocations that were
+// stopped as lost, so the scheduler can determine when they reconnected.
+func reconnectedNodes(state State, allocs []*structs.Allocation, tainted map[string]*structs.Node) (map[string]*structs.Node, error) {
+	out := make(map[string]*structs.Node)
+	for _, alloc := range allocs {
+		if alloc.DesiredDescription != allocLost {
+			continue
+		}
+		if _, ok := tainted[alloc.NodeID]; ok {
+			continue
+		}
+		if _, ok := out[alloc.NodeID]; ok {
+			continue
+		}
+
+		ws := memdb.NewWatchSet()
+		node, err := state.NodeByID(ws, alloc.NodeID)
+		if err != nil {
+			return nil, err
+		}
+		if node != nil {
+			out[alloc.NodeID] = node
+		}
+	}
+	return out, nil
+}
+
 // shuffleNodes randomizes the slice order with the Fisher-Yates algorithm
 func shuffleNodes(nodes []*structs.Node) {
 	n := len(nodes)
@@ -735,8 +763,9 @@ func updateNonTerminalAllocsToLost(plan *structs.Plan, tainted map[string]*struc
 			continue
 		}
 
-		// Only handle down nodes or nodes that are gone (node == nil)
-		if node != nil && node.Status != structs.NodeStatusDown {
+		// Only handle down or disconnected nodes or nodes that are gone (node == nil)
+		if node != nil && node.Status != structs.NodeStatusDown &&
+			node.Status != structs.NodeStatusDisconnected {
 			continue
 		}
 